@@ -150,13 +150,60 @@ EXAMPLES:
 		RunE: runAgentUpdate,
 	}
 
+	agentSimulateToolsCmd = &cobra.Command{
+		Use:   "simulate-tools <name>",
+		Short: "Run an agent with simulated tools and report attempted tool usage",
+		Long: `Run an agent against faked tool backends and report which tools it
+attempted to call and with what argument shapes.
+
+Every assigned tool keeps its real name, description, and input schema, but
+calls are answered with simulated responses instead of reaching real systems.
+Use this to decide whether an agent's toolset is right before pointing it at
+production MCP servers.
+
+EXAMPLES:
+  # Simulate a task and review the tool usage report
+  stn agent simulate-tools cost-analyzer --task "Summarize last month's AWS spend"
+
+  # Machine-readable report for CI checks
+  stn agent simulate-tools cost-analyzer --task "Summarize spend" --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAgentSimulateTools,
+	}
+
+	agentDocsCmd = &cobra.Command{
+		Use:   "docs",
+		Short: "Generate Markdown documentation for agents",
+		Long: `Generate a Markdown documentation page per agent in an environment:
+description, system prompt, tool inventory with parameter schemas, schedule,
+recent performance stats, and example runs. An index README.md links the pages.
+
+Run it from CI after deployments to keep an always-current agent catalog.
+
+EXAMPLES:
+  # Document the production agents into docs/
+  stn agent docs --env prod -o docs/
+
+  # Document the default environment into the default docs directory
+  stn agent docs`,
+		RunE: runAgentDocs,
+	}
+
 	agentDeleteCmd = &cobra.Command{
 		Use:   "delete <name>",
 		Short: "Delete an agent",
-		Long:  "Delete an agent and all associated data by name",
+		Long:  "Soft-delete an agent by name; run history is kept and the agent can be restored with 'stn agent restore' until the retention window expires",
 		Args:  cobra.ExactArgs(1),
 		RunE:  runAgentDelete,
 	}
+
+	agentRestoreCmd = &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Restore a soft-deleted agent",
+		Long:  "Restore a soft-deleted agent by name, bringing back its run history, tool assignments, and .prompt file",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runAgentRestore,
+	}
 )
 
 // runAgentList lists all agents
@@ -177,12 +224,30 @@ func runAgentRun(cmd *cobra.Command, args []string) error {
 	return agentHandler.RunAgentRun(cmd, args)
 }
 
+// runAgentSimulateTools runs an agent with simulated tool backends
+func runAgentSimulateTools(cmd *cobra.Command, args []string) error {
+	agentHandler := agent.NewAgentHandler(nil, telemetryService)
+	return agentHandler.RunAgentSimulateTools(cmd, args)
+}
+
+// runAgentDocs generates Markdown documentation for agents
+func runAgentDocs(cmd *cobra.Command, args []string) error {
+	agentHandler := agent.NewAgentHandler(nil, telemetryService)
+	return agentHandler.RunAgentDocs(cmd, args)
+}
+
 // runAgentDelete deletes an agent
 func runAgentDelete(cmd *cobra.Command, args []string) error {
 	agentHandler := agent.NewAgentHandler(nil, telemetryService)
 	return agentHandler.RunAgentDelete(cmd, args)
 }
 
+// runAgentRestore restores a soft-deleted agent
+func runAgentRestore(cmd *cobra.Command, args []string) error {
+	agentHandler := agent.NewAgentHandler(nil, telemetryService)
+	return agentHandler.RunAgentRestore(cmd, args)
+}
+
 // runAgentCreate creates a new agent
 func runAgentCreate(cmd *cobra.Command, args []string) error {
 	agentHandler := agent.NewAgentHandler(nil, telemetryService)
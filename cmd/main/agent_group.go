@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+	"station/pkg/models"
+)
+
+var (
+	agentGroupCmd = &cobra.Command{
+		Use:   "group",
+		Short: "Manage agent groups",
+		Long:  "Group agents into named teams (e.g. 'finops-team'). Reports can target a group instead of a whole environment, and SLOs/budgets can be set per group via the slo.group.<id> setting",
+	}
+
+	agentGroupCreateCmd = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an agent group",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runAgentGroupCreate,
+	}
+
+	agentGroupListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List agent groups",
+		RunE:  runAgentGroupList,
+	}
+
+	agentGroupShowCmd = &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show a group's members and SLO status",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runAgentGroupShow,
+	}
+
+	agentGroupDeleteCmd = &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete an agent group (memberships only; agents are untouched)",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runAgentGroupDelete,
+	}
+
+	agentGroupAddCmd = &cobra.Command{
+		Use:   "add <name> <agent-id>",
+		Short: "Add an agent to a group",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runAgentGroupAdd,
+	}
+
+	agentGroupRemoveCmd = &cobra.Command{
+		Use:   "remove <name> <agent-id>",
+		Short: "Remove an agent from a group",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runAgentGroupRemove,
+	}
+)
+
+// openAgentGroupRepos opens the station database for group commands
+func openAgentGroupRepos() (*repositories.Repositories, func(), error) {
+	databasePath := viper.GetString("database_url")
+	if databasePath == "" {
+		configDir := getWorkspacePath()
+		databasePath = filepath.Join(configDir, "station.db")
+	}
+
+	database, err := db.New(databasePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return repositories.New(database), func() { _ = database.Close() }, nil
+}
+
+// resolveAgentGroup looks up a group by name, falling back to numeric ID
+func resolveAgentGroup(ctx context.Context, repos *repositories.Repositories, nameOrID string) (*models.AgentGroup, error) {
+	group, err := repos.AgentGroups.GetByName(ctx, nameOrID)
+	if err == nil {
+		return group, nil
+	}
+	if id, parseErr := strconv.ParseInt(nameOrID, 10, 64); parseErr == nil {
+		if group, err := repos.AgentGroups.GetByID(ctx, id); err == nil {
+			return group, nil
+		}
+	}
+	return nil, fmt.Errorf("agent group '%s' not found", nameOrID)
+}
+
+func runAgentGroupCreate(cmd *cobra.Command, args []string) error {
+	repos, cleanup, err := openAgentGroupRepos()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	description, _ := cmd.Flags().GetString("description")
+	group, err := repos.AgentGroups.Create(context.Background(), args[0], description)
+	if err != nil {
+		return fmt.Errorf("failed to create agent group: %w", err)
+	}
+
+	fmt.Printf("✅ Created agent group '%s' (ID: %d)\n", group.Name, group.ID)
+	fmt.Printf("   Add agents with: stn agent group add %s <agent-id>\n", group.Name)
+	fmt.Printf("   Set a group SLO/budget with: stn settings set slo.group.%d '{\"daily_cost_usd\": 10}'\n", group.ID)
+	return nil
+}
+
+func runAgentGroupList(cmd *cobra.Command, args []string) error {
+	repos, cleanup, err := openAgentGroupRepos()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	groups, err := repos.AgentGroups.List(ctx)
+	if err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		fmt.Println("No agent groups defined. Create one with: stn agent group create <name>")
+		return nil
+	}
+
+	fmt.Printf("Agent Groups (%d)\n\n", len(groups))
+	for _, group := range groups {
+		members, _ := repos.AgentGroups.ListMembers(ctx, group.ID)
+		fmt.Printf("  %s (ID: %d) — %d agent(s)\n", group.Name, group.ID, len(members))
+		if group.Description != "" {
+			fmt.Printf("    %s\n", group.Description)
+		}
+	}
+	return nil
+}
+
+func runAgentGroupShow(cmd *cobra.Command, args []string) error {
+	repos, cleanup, err := openAgentGroupRepos()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	group, err := resolveAgentGroup(ctx, repos, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Agent Group: %s (ID: %d)\n", group.Name, group.ID)
+	if group.Description != "" {
+		fmt.Printf("Description: %s\n", group.Description)
+	}
+
+	members, err := repos.AgentGroups.ListMembers(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		fmt.Println("\nNo members. Add agents with: stn agent group add", group.Name, "<agent-id>")
+	} else {
+		fmt.Printf("\nMembers (%d):\n", len(members))
+		for _, agent := range members {
+			fmt.Printf("  [%d] %s\n", agent.ID, agent.Name)
+		}
+	}
+
+	sloMonitor := services.NewSLOMonitorService(repos, nil)
+	slo, err := sloMonitor.GetGroupSLO(group.ID)
+	if err != nil {
+		return err
+	}
+	if slo == nil {
+		fmt.Printf("\nNo group SLO defined. Set one with: stn settings set slo.group.%d '{\"p95_latency_seconds\": 60, \"error_rate_percent\": 5, \"daily_cost_usd\": 10}'\n", group.ID)
+		return nil
+	}
+
+	status, err := sloMonitor.EvaluateGroup(ctx, group.ID, slo)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nGroup SLO (pooled over %d recent run(s)):\n", status.RunsAnalyzed)
+	if slo.P95LatencySeconds > 0 {
+		fmt.Printf("  p95 latency: %.1fs (target %.0fs)\n", status.P95LatencySeconds, slo.P95LatencySeconds)
+	}
+	if slo.ErrorRatePercent > 0 {
+		fmt.Printf("  error rate:  %.1f%% (target %.1f%%)\n", status.ErrorRatePercent, slo.ErrorRatePercent)
+	}
+	if slo.DailyCostUSD > 0 {
+		fmt.Printf("  daily cost:  $%.2f (budget $%.2f)\n", status.DailyCostUSD, slo.DailyCostUSD)
+	}
+	if status.Compliant() {
+		fmt.Println("  ✅ All objectives met")
+	} else {
+		for _, breach := range status.Breaches {
+			fmt.Printf("  ⚠️  %s\n", breach)
+		}
+	}
+	return nil
+}
+
+func runAgentGroupDelete(cmd *cobra.Command, args []string) error {
+	repos, cleanup, err := openAgentGroupRepos()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	group, err := resolveAgentGroup(ctx, repos, args[0])
+	if err != nil {
+		return err
+	}
+	if err := repos.AgentGroups.Delete(ctx, group.ID); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Deleted agent group '%s'\n", group.Name)
+	return nil
+}
+
+func runAgentGroupAdd(cmd *cobra.Command, args []string) error {
+	repos, cleanup, err := openAgentGroupRepos()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	group, err := resolveAgentGroup(ctx, repos, args[0])
+	if err != nil {
+		return err
+	}
+	agentID, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid agent ID: %s", args[1])
+	}
+	agent, err := repos.Agents.GetByID(agentID)
+	if err != nil {
+		return fmt.Errorf("agent %d not found: %w", agentID, err)
+	}
+
+	if err := repos.AgentGroups.AddMember(ctx, group.ID, agentID); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Added agent '%s' (ID: %d) to group '%s'\n", agent.Name, agent.ID, group.Name)
+	return nil
+}
+
+func runAgentGroupRemove(cmd *cobra.Command, args []string) error {
+	repos, cleanup, err := openAgentGroupRepos()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	group, err := resolveAgentGroup(ctx, repos, args[0])
+	if err != nil {
+		return err
+	}
+	agentID, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid agent ID: %s", args[1])
+	}
+
+	if err := repos.AgentGroups.RemoveMember(ctx, group.ID, agentID); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Removed agent %d from group '%s'\n", agentID, group.Name)
+	return nil
+}
@@ -30,11 +30,41 @@ Results are stored in the database and include:
 • Production readiness assessment
 • Detailed metric breakdowns with evidence`,
 		Example: `  stn benchmark evaluate 42           # Evaluate run ID 42
-  stn benchmark evaluate 42 --verbose  # Show detailed metric analysis`,
+  stn benchmark evaluate 42 --verbose  # Show detailed metric analysis
+  stn benchmark evaluate 42 --suite ./suites/payments.yaml  # Sync suite tasks first`,
 		Args: cobra.ExactArgs(1),
 		RunE: runBenchmarkEvaluate,
 	}
 
+	benchmarkImportCmd = &cobra.Command{
+		Use:   "import <suite-file>",
+		Short: "Import benchmark tasks from a suite file",
+		Long: `Import benchmark tasks from a versionable suite file (YAML or JSON).
+
+Tasks are matched by name: existing tasks are updated in place, new tasks
+are created. Suites can be kept in git and shared across teams.`,
+		Example: `  stn benchmark import ./suites/payments.yaml
+  stn benchmark import ./suites/security.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runBenchmarkImport,
+	}
+
+	benchmarkExportCmd = &cobra.Command{
+		Use:   "export <output-file>",
+		Short: "Export benchmark tasks to a suite file",
+		Long: `Export benchmark tasks to a suite file for versioning and sharing.
+
+The format follows the file extension:
+• .yaml/.yml - YAML suite format (recommended for git)
+• .json      - JSON suite format
+• .jsonl     - OpenAI evals-style records (one per task)`,
+		Example: `  stn benchmark export ./suites/all-tasks.yaml
+  stn benchmark export ./suites/finops.yaml --category finops
+  stn benchmark export ./evals/tasks.jsonl`,
+		Args: cobra.ExactArgs(1),
+		RunE: runBenchmarkExport,
+	}
+
 	benchmarkListCmd = &cobra.Command{
 		Use:   "list [run-id]",
 		Short: "List benchmark results",
@@ -72,3 +102,15 @@ func runBenchmarkTasks(cmd *cobra.Command, args []string) error {
 	benchmarkHandler := benchmark.NewBenchmarkHandler(nil, telemetryService)
 	return benchmarkHandler.RunBenchmarkTasks(cmd, args)
 }
+
+// runBenchmarkImport imports benchmark tasks from a suite file
+func runBenchmarkImport(cmd *cobra.Command, args []string) error {
+	benchmarkHandler := benchmark.NewBenchmarkHandler(nil, telemetryService)
+	return benchmarkHandler.RunBenchmarkImport(cmd, args)
+}
+
+// runBenchmarkExport exports benchmark tasks to a suite file
+func runBenchmarkExport(cmd *cobra.Command, args []string) error {
+	benchmarkHandler := benchmark.NewBenchmarkHandler(nil, telemetryService)
+	return benchmarkHandler.RunBenchmarkExport(cmd, args)
+}
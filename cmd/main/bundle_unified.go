@@ -29,10 +29,42 @@ var bundleCmd = &cobra.Command{
 
 Subcommands:
   create   Create a bundle from an environment
-  install  Install a bundle from URL or file path
+  search   Search configured registries for bundles
+  info     Show bundle details from a registry
+  install  Install a bundle from a registry, URL, or file path
   share    Upload a bundle to CloudShip`,
 }
 
+// Bundle search subcommand
+var bundleSearchCmd = &cobra.Command{
+	Use:   "search [term]",
+	Short: "Search registries for bundles",
+	Long: `Search the official registry and any configured private registries for bundles.
+With no term, lists every available bundle.
+
+Private registries are configured via settings:
+  stn settings set bundle.registries '[{"name": "internal", "url": "https://bundles.corp.example.com"}]'
+
+Examples:
+  stn bundle search                # List all bundles
+  stn bundle search security       # Search by name, description, or tag`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBundleSearch,
+}
+
+// Bundle info subcommand
+var bundleInfoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Show bundle details from a registry",
+	Long: `Show a bundle's version, author, ratings/downloads, included agents,
+and the template variables it requires, resolved from configured registries.
+
+Examples:
+  stn bundle info devops-security-bundle`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundleInfo,
+}
+
 // Bundle create subcommand
 var bundleCreateCmd = &cobra.Command{
 	Use:   "create <environment>",
@@ -51,11 +83,12 @@ Examples:
 
 // Bundle install subcommand
 var bundleInstallCmd = &cobra.Command{
-	Use:   "install <bundle-source> <environment-name>",
-	Short: "Install a bundle from CloudShip, URL, or file path",
-	Long: `Install a bundle from CloudShip (by ID), a remote URL, or local file path.
+	Use:   "install <bundle-source> [environment-name]",
+	Short: "Install a bundle from a registry, CloudShip, URL, or file path",
+	Long: `Install a bundle from a registry (by name), CloudShip (by ID), a remote URL, or local file path.
 
 The bundle source can be:
+  - Registry bundle name: Resolved via 'stn bundle search' registries, use with --env
   - CloudShip Bundle ID (UUID format): Downloads from your CloudShip account
   - URL (http/https): Downloads from the URL
   - File path: Uses the local file
@@ -63,6 +96,9 @@ The bundle source can be:
 For CloudShip bundles, you must be authenticated. Run 'stn auth login' first.
 
 Examples:
+  # Install a registry bundle into an environment and sync it
+  stn bundle install devops-security-bundle --env prod
+
   # Install from CloudShip bundle ID
   stn bundle install e26b414a-f076-4135-927f-810bc1dc892a finops
 
@@ -71,7 +107,7 @@ Examples:
 
   # Install from local file
   stn bundle install ./my-bundle.tar.gz production`,
-	Args: cobra.ExactArgs(2),
+	Args: cobra.RangeArgs(1, 2),
 	RunE: runBundleInstall,
 }
 
@@ -103,6 +139,8 @@ func init() {
 
 	// Add flags to install subcommand
 	bundleInstallCmd.Flags().Bool("force", false, "Overwrite existing environment (merges bundle contents)")
+	bundleInstallCmd.Flags().String("env", "", "Target environment (alternative to positional argument; required for registry installs)")
+	bundleInstallCmd.Flags().Bool("no-sync", false, "Skip running sync after a registry install")
 
 	// Add flags to share subcommand
 	bundleShareCmd.Flags().String("api-url", "https://api.cloudshipai.com", "CloudShip API URL")
@@ -110,10 +148,123 @@ func init() {
 
 	// Add subcommands to main bundle command
 	bundleCmd.AddCommand(bundleCreateCmd)
+	bundleCmd.AddCommand(bundleSearchCmd)
+	bundleCmd.AddCommand(bundleInfoCmd)
 	bundleCmd.AddCommand(bundleInstallCmd)
 	bundleCmd.AddCommand(bundleShareCmd)
 }
 
+func newBundleRegistryService() (*services.BundleRegistryService, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	repos := repositories.New(database)
+	return services.NewBundleRegistryService(repos), func() { _ = database.Close() }, nil
+}
+
+func runBundleSearch(cmd *cobra.Command, args []string) error {
+	term := ""
+	if len(args) > 0 {
+		term = args[0]
+	}
+
+	registryService, cleanup, err := newBundleRegistryService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	bundles, err := registryService.Search(cmd.Context(), term)
+	if err != nil {
+		return err
+	}
+
+	if len(bundles) == 0 {
+		if term == "" {
+			fmt.Println("No bundles available in configured registries.")
+		} else {
+			fmt.Printf("No bundles matching %q found.\n", term)
+		}
+		return nil
+	}
+
+	fmt.Printf("Found %d bundle(s):\n\n", len(bundles))
+	for _, bundle := range bundles {
+		fmt.Printf("• %s (v%s) [%s]\n", bundle.Name, bundle.Version, bundle.Registry)
+		fmt.Printf("  %s\n", bundle.Description)
+		if bundle.Downloads > 0 || bundle.Rating > 0 {
+			fmt.Printf("  ⬇️  %d downloads", bundle.Downloads)
+			if bundle.Rating > 0 {
+				fmt.Printf("  ⭐ %.1f", bundle.Rating)
+			}
+			fmt.Println()
+		}
+		if len(bundle.Tags) > 0 {
+			fmt.Printf("  Tags: %s\n", strings.Join(bundle.Tags, ", "))
+		}
+	}
+
+	fmt.Println("\nInstall with 'stn bundle install <name> --env <environment>'")
+	return nil
+}
+
+func runBundleInfo(cmd *cobra.Command, args []string) error {
+	registryService, cleanup, err := newBundleRegistryService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	details, err := registryService.Info(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📦 %s (v%s)\n", details.Name, details.Version)
+	fmt.Printf("Registry: %s\n", details.Registry)
+	if details.Author != "" {
+		fmt.Printf("Author:   %s\n", details.Author)
+	}
+	fmt.Printf("%s\n", details.Description)
+	if details.Downloads > 0 {
+		fmt.Printf("Downloads: %d\n", details.Downloads)
+	}
+	if details.Rating > 0 {
+		fmt.Printf("Rating:    %.1f\n", details.Rating)
+	}
+	if len(details.Tags) > 0 {
+		fmt.Printf("Tags:      %s\n", strings.Join(details.Tags, ", "))
+	}
+
+	if len(details.Agents) > 0 {
+		fmt.Printf("\n🤖 Agents (%d):\n", len(details.Agents))
+		for _, agent := range details.Agents {
+			fmt.Printf("• %s — %s\n", agent.Name, agent.Description)
+		}
+	}
+
+	if len(details.Variables) > 0 {
+		fmt.Printf("\n🔧 Required variables:\n")
+		for name, variable := range details.Variables {
+			required := "optional"
+			if variable.Required {
+				required = "required"
+			}
+			fmt.Printf("• %s (%s, %s) — %s\n", name, variable.Type, required, variable.Description)
+		}
+	}
+
+	fmt.Printf("\nInstall with 'stn bundle install %s --env <environment>'\n", details.Name)
+	return nil
+}
+
 func runBundleCreate(cmd *cobra.Command, args []string) error {
 	environmentName := args[0]
 	outputPath, _ := cmd.Flags().GetString("output")
@@ -212,20 +363,50 @@ func runBundleCreate(cmd *cobra.Command, args []string) error {
 
 func runBundleInstall(cmd *cobra.Command, args []string) error {
 	bundleSource := args[0]
-	environmentName := args[1]
+	environmentName, _ := cmd.Flags().GetString("env")
+	if len(args) > 1 {
+		environmentName = args[1]
+	}
+	if environmentName == "" {
+		return fmt.Errorf("environment is required: pass it as the second argument or via --env")
+	}
 	force, _ := cmd.Flags().GetBool("force")
+	noSync, _ := cmd.Flags().GetBool("no-sync")
+
+	// Resolve registry bundle names (not a UUID, URL, or local file) to
+	// their download URL via the configured registries
+	registryInstall := false
+	if !isUUID(bundleSource) && !strings.HasPrefix(bundleSource, "http") {
+		if _, err := os.Stat(bundleSource); os.IsNotExist(err) {
+			registryService, cleanup, err := newBundleRegistryService()
+			if err != nil {
+				return err
+			}
+			bundle, err := registryService.Resolve(cmd.Context(), bundleSource)
+			cleanup()
+			if err != nil {
+				return err
+			}
+			if bundle.DownloadURL == "" {
+				return fmt.Errorf("bundle %q has no download URL in registry %s", bundle.Name, bundle.Registry)
+			}
+			fmt.Printf("📦 Resolved %s (v%s) from registry %s\n", bundle.Name, bundle.Version, bundle.Registry)
+			bundleSource = bundle.DownloadURL
+			registryInstall = true
+		}
+	}
 
 	// Check if bundleSource is a CloudShip bundle ID (UUID format)
 	if isUUID(bundleSource) {
 		fmt.Printf("📦 Downloading bundle from CloudShip: %s\n", bundleSource)
-		
+
 		// Download from CloudShip
 		downloadedPath, err := downloadBundleFromCloudShip(bundleSource)
 		if err != nil {
 			return fmt.Errorf("failed to download bundle from CloudShip: %w", err)
 		}
 		defer os.Remove(downloadedPath) // Clean up temp file
-		
+
 		bundleSource = downloadedPath
 		fmt.Printf("✅ Bundle downloaded successfully\n")
 	} else {
@@ -260,7 +441,19 @@ func runBundleInstall(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("✅ Bundle installed successfully!\n")
-	fmt.Printf("📊 Installed: %d agents, %d MCP configs\n", result.InstalledAgents, result.InstalledMCPs)
+	fmt.Printf("📊 Installed: %d agents, %d MCP configs, %d workflows, %d benchmark suites\n",
+		result.InstalledAgents, result.InstalledMCPs, result.InstalledWorkflows, result.InstalledSuites)
+
+	// Registry installs sync automatically so the environment is immediately usable
+	if registryInstall && !noSync {
+		fmt.Printf("\n🔄 Syncing environment '%s'...\n", result.EnvironmentName)
+		if err := runSyncForEnvironment(result.EnvironmentName, true, false, false, false, common.OutputTable); err != nil {
+			fmt.Printf("⚠️  Sync failed: %v\n", err)
+			fmt.Printf("   Run 'stn sync %s' after fixing the issue\n", result.EnvironmentName)
+		}
+		return nil
+	}
+
 	fmt.Printf("\n🔧 Next steps:\n")
 	fmt.Printf("   stn sync %s                  # Sync MCP tools\n", result.EnvironmentName)
 	fmt.Printf("   stn agent list --env %s     # List available agents\n", result.EnvironmentName)
@@ -284,21 +477,21 @@ func downloadBundleFromCloudShip(bundleID string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("authentication required: %w\nRun 'stn auth login' to authenticate", err)
 	}
-	
+
 	// Get API URL
 	apiURL := GetCloudShipAPIURL()
-	
+
 	// Build download URL (trailing slash required by CloudShip API)
 	downloadURL := fmt.Sprintf("%s/api/public/bundles/%s/download/", apiURL, bundleID)
-	
+
 	// Create request
 	req, err := http.NewRequest("GET", downloadURL, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
-	
+
 	req.Header.Set(headerName, headerValue)
-	
+
 	// Make request
 	client := &http.Client{Timeout: 60 * time.Second}
 	resp, err := client.Do(req)
@@ -306,25 +499,25 @@ func downloadBundleFromCloudShip(bundleID string) (string, error) {
 		return "", fmt.Errorf("failed to connect to CloudShip: %w", err)
 	}
 	defer resp.Body.Close()
-	
+
 	if resp.StatusCode == 401 || resp.StatusCode == 403 {
 		return "", fmt.Errorf("unauthorized - check your API key")
 	}
-	
+
 	if resp.StatusCode == 404 {
 		return "", fmt.Errorf("bundle not found: %s", bundleID)
 	}
-	
+
 	if resp.StatusCode != 200 {
 		return "", fmt.Errorf("CloudShip API error (status %d)", resp.StatusCode)
 	}
-	
+
 	// Create temp file
 	tmpFile, err := os.CreateTemp("", "station-bundle-*.tar.gz")
 	if err != nil {
 		return "", fmt.Errorf("failed to create temp file: %w", err)
 	}
-	
+
 	// Copy response body to temp file
 	_, err = io.Copy(tmpFile, resp.Body)
 	if err != nil {
@@ -332,7 +525,7 @@ func downloadBundleFromCloudShip(bundleID string) (string, error) {
 		os.Remove(tmpFile.Name())
 		return "", fmt.Errorf("failed to download bundle: %w", err)
 	}
-	
+
 	tmpFile.Close()
 	return tmpFile.Name(), nil
 }
@@ -365,7 +558,7 @@ func runBundleShare(cmd *cobra.Command, args []string) error {
 	// Use bundle registry URL from config (can be overridden by flag)
 	apiURL, _ := cmd.Flags().GetString("api-url")
 	flagIsDefault := apiURL == "https://api.cloudshipai.com"
-	
+
 	if flagIsDefault {
 		// Flag wasn't overridden, determine URL from config
 		// Priority: APIURL (explicit config) > BundleRegistryURL (may be default)
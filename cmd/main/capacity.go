@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Show execution capacity and autoscaling hints",
+	Long:  "Report current/peak concurrency, queue depth, average run duration, and a recommended worker count, derived from run history. Use --json for the same payload the /api/v1/capacity endpoint serves to external autoscalers (K8s HPA, Nomad)",
+	RunE:  runCapacity,
+}
+
+// runCapacity prints the capacity report
+func runCapacity(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	databasePath := viper.GetString("database_url")
+	if databasePath == "" {
+		configDir := getWorkspacePath()
+		databasePath = filepath.Join(configDir, "station.db")
+	}
+
+	database, err := db.New(databasePath)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	report, err := services.NewCapacityService(repos).Report(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Printf("Execution Capacity (last %dh)\n\n", report.WindowHours)
+	fmt.Printf("  Current concurrency:  %d\n", report.CurrentConcurrency)
+	fmt.Printf("  Peak concurrency:     %d\n", report.PeakConcurrency)
+	fmt.Printf("  Queue depth:          %d\n", report.QueueDepth)
+	if report.AvgRunDurationSeconds > 0 {
+		fmt.Printf("  Avg run duration:     %.1fs\n", report.AvgRunDurationSeconds)
+	} else {
+		fmt.Printf("  Avg run duration:     no completed runs in window\n")
+	}
+	fmt.Printf("  Runs started (1h):    %d\n", report.RunsStartedLastHour)
+	fmt.Printf("  Projected concurrency: %.2f\n", report.ProjectedConcurrency)
+	fmt.Printf("\n  Recommended workers:  %d\n", report.RecommendedWorkers)
+	fmt.Printf("\nAutoscalers can poll GET /api/v1/capacity for this report as flat JSON.\n")
+	return nil
+}
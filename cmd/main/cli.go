@@ -24,6 +24,7 @@ import (
 
 	"github.com/firebase/genkit/go/ai"
 	"github.com/firebase/genkit/go/genkit"
+	"station/cmd/main/handlers/common"
 	"station/internal/config"
 	"station/internal/db"
 	"station/internal/db/repositories"
@@ -187,11 +188,29 @@ func runMCPList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get MCP configs: %w", err)
 	}
 
+	// Group servers by file config so versions can be shown under each config
+	servers, err := repos.MCPServers.GetByEnvironmentID(env.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get MCP servers: %w", err)
+	}
+
 	fmt.Printf("MCP Servers in environment '%s':\n\n", environmentName)
 	for _, config := range configs {
 		fmt.Printf("📦 %s\n", config.ConfigName)
 		fmt.Printf("   Config ID: %d\n", config.ID)
-		fmt.Printf("   Updated: %s\n\n", config.UpdatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("   Updated: %s\n", config.UpdatedAt.Format("2006-01-02 15:04:05"))
+		for _, server := range servers {
+			if server.FileConfigID == nil || *server.FileConfigID != config.ID {
+				continue
+			}
+			infoName, infoVersion, infoErr := repos.MCPServers.GetServerInfo(cmd.Context(), server.ID)
+			if infoErr == nil && infoVersion != "" {
+				fmt.Printf("   🖥️  %s — %s %s\n", server.Name, infoName, infoVersion)
+			} else {
+				fmt.Printf("   🖥️  %s — version unknown (run 'stn sync' to discover)\n", server.Name)
+			}
+		}
+		fmt.Println()
 	}
 
 	if len(configs) == 0 {
@@ -202,6 +221,66 @@ func runMCPList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runMCPOutdated implements the "station mcp outdated" command
+func runMCPOutdated(cmd *cobra.Command, args []string) error {
+	// Load Station config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	// Initialize database
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+
+	// Get environment (default to "default" if not specified)
+	environmentName := "default"
+	if len(args) > 0 {
+		environmentName = args[0]
+	}
+
+	env, err := repos.Environments.GetByName(environmentName)
+	if err != nil {
+		return fmt.Errorf("environment '%s' not found: %w", environmentName, err)
+	}
+
+	checker := services.NewMCPVersionChecker(repos)
+	statuses, err := checker.CheckEnvironment(cmd.Context(), env.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(statuses) == 0 {
+		fmt.Printf("No MCP servers configured in environment '%s'\n", environmentName)
+		return nil
+	}
+
+	fmt.Printf("MCP server versions in environment '%s':\n\n", environmentName)
+	outdatedCount := 0
+	for _, status := range statuses {
+		switch {
+		case status.Skipped != "":
+			fmt.Printf("⏭️  %s — skipped: %s\n", status.ServerName, status.Skipped)
+		case status.Outdated:
+			outdatedCount++
+			fmt.Printf("📦 %s — %s %s → %s available (%s)\n",
+				status.ServerName, status.Package, status.CurrentVersion, status.LatestVersion, status.Registry)
+		default:
+			fmt.Printf("✅ %s — %s %s is up to date\n", status.ServerName, status.Package, status.CurrentVersion)
+		}
+	}
+
+	if outdatedCount > 0 {
+		fmt.Printf("\n%d server(s) have newer versions. Update the version pin in your template and run 'stn sync'.\n", outdatedCount)
+	}
+	return nil
+}
+
 // runMCPTools implements the "station mcp tools" command
 func runMCPTools(cmd *cobra.Command, args []string) error {
 	// Load Station config
@@ -237,6 +316,21 @@ func runMCPTools(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get tools: %w", err)
 	}
 
+	// Machine formats emit only the rows, no banners or styling
+	if format := common.OutputFormat(cmd); common.MachineFormat(format) {
+		columns := []string{"id", "name", "description", "environment"}
+		rows := make([][]string, 0, len(tools))
+		for _, tool := range tools {
+			rows = append(rows, []string{
+				fmt.Sprintf("%d", tool.ID),
+				tool.Name,
+				tool.Description,
+				environmentName,
+			})
+		}
+		return common.RenderList(format, columns, rows)
+	}
+
 	fmt.Printf("MCP Tools in environment '%s':\n\n", environmentName)
 	for _, tool := range tools {
 		fmt.Printf("🔧 %s\n", tool.Name)
@@ -254,6 +348,51 @@ func runMCPTools(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runMCPLogs implements the "station mcp logs" command
+func runMCPLogs(cmd *cobra.Command, args []string) error {
+	serverName := args[0]
+	environmentName, _ := cmd.Flags().GetString("env")
+	maxBytes, _ := cmd.Flags().GetInt("bytes")
+
+	// Load Station config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	// Initialize database
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+
+	env, err := repos.Environments.GetByName(environmentName)
+	if err != nil {
+		return fmt.Errorf("environment '%s' not found: %w", environmentName, err)
+	}
+
+	if _, err := repos.MCPServers.GetByNameAndEnvironment(serverName, env.ID); err != nil {
+		return fmt.Errorf("MCP server '%s' not found in environment '%s': %w", serverName, environmentName, err)
+	}
+
+	output := services.ReadMCPServerStderr(serverName, maxBytes)
+	if output == "" {
+		fmt.Printf("No stderr output captured for MCP server '%s'\n", serverName)
+		fmt.Printf("Stderr is captured when the server runs as a stdio subprocess during agent execution\n")
+		return nil
+	}
+
+	fmt.Printf("Recent stderr from MCP server '%s':\n\n", serverName)
+	fmt.Print(output)
+	if !strings.HasSuffix(output, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
 // runMCPDelete implements the "station mcp delete" command
 func runMCPDelete(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
@@ -640,6 +779,26 @@ func runMCPStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("No tools discovered. You may need to run 'stn sync' to discover tools.\n")
 	}
 
+	// Show tripped tool circuits so operators can see which tools are
+	// currently failing fast
+	circuits, err := services.NewToolCircuitService(repos).List()
+	if err == nil && len(circuits) > 0 {
+		fmt.Printf("\n⚡ Tool Circuits:\n")
+		for _, circuit := range circuits {
+			switch circuit.State {
+			case services.ToolCircuitOpen:
+				fmt.Printf("  • %s: OPEN until %s (%d consecutive failures, last error: %s)\n",
+					circuit.ToolName, circuit.OpenUntil, circuit.ConsecutiveFailures, circuit.LastError)
+			case services.ToolCircuitHalfOpen:
+				fmt.Printf("  • %s: HALF-OPEN (probing after %d failures)\n",
+					circuit.ToolName, circuit.ConsecutiveFailures)
+			default:
+				fmt.Printf("  • %s: closed (%d consecutive failure(s) so far)\n",
+					circuit.ToolName, circuit.ConsecutiveFailures)
+			}
+		}
+	}
+
 	return nil
 }
 
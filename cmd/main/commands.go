@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/viper"
 
 	"station/cmd/main/handlers"
+	"station/cmd/main/handlers/common"
 	"station/internal/auth"
 	"station/internal/config"
 	"station/internal/db"
@@ -31,6 +32,13 @@ var (
 		RunE:  runServe,
 	}
 
+	serveDrainCmd = &cobra.Command{
+		Use:   "drain",
+		Short: "Drain the running server for a restart",
+		Long:  "Signal the running server to stop accepting new runs and workflow starts, wait for in-flight executions up to the drain timeout (STATION_DRAIN_TIMEOUT_SECONDS, default 120s), and exit cleanly. Queued work persists and resumes after restart.",
+		RunE:  runServeDrain,
+	}
+
 	initCmd = &cobra.Command{
 		Use:   "init",
 		Short: "Initialize Station configuration",
@@ -51,6 +59,8 @@ Use --provider and --model flags to skip interactive setup.
 Use --yes flag to use sensible defaults without any prompts.
 Use --replicate flag to also configure Litestream for database replication to cloud storage.
 Use --ship flag to bootstrap with ship CLI MCP integration for filesystem access.
+Use --import-from claude|cursor|vscode to bootstrap the default environment from an
+existing MCP client config (secrets become template variables and the first sync runs).
 
 Lattice Configuration:
   --lattice-url         Join an existing lattice mesh (e.g., nats://orchestrator:4222)
@@ -161,6 +171,38 @@ EXAMPLES:
 		RunE: runMCPAddOpenapi,
 	}
 
+	mcpAddOpenapiDirCmd = &cobra.Command{
+		Use:   "add-openapi-dir <directory>",
+		Short: "Bundle a directory of OpenAPI specs into one environment config",
+		Long: `Convert every OpenAPI spec in a directory and bundle them into a single
+environment MCP config, instead of adding specs one by one.
+
+OVERVIEW:
+  Each spec in the directory (.json, .yaml or .yml) is normalized into the
+  environment as <api>.openapi.json, and a single merged template wires all
+  of them up as MCP servers. Tool names are prefixed consistently per API
+  (derived from the spec filename), and the per-API tool count is reported
+  up front.
+
+TEMPLATE VARIABLES:
+  Specs may reference Go template variables for secrets, e.g.
+  "Authorization": "Bearer {{.API_KEY}}". Variables shared across specs are
+  reported once and prompted once per environment during 'stn sync', so a
+  common auth token only needs to be entered a single time.
+
+EXAMPLES:
+  # Bundle all specs in ./specs into the default environment
+  stn mcp add-openapi-dir ./specs
+
+  # Bundle into production with a custom bundle name
+  stn mcp add-openapi-dir ./specs -e production --name partner-apis
+
+  # After bundling, sync to discover tools
+  stn sync default`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMCPAddOpenapiDir,
+	}
+
 	blastoffCmd = &cobra.Command{
 		Use:    "blastoff",
 		Short:  "🚀 Epic retro station blastoff animation",
@@ -212,6 +254,14 @@ Perfect for getting started quickly with a fully configured Station instance.`,
 		RunE:  runMCPTools,
 	}
 
+	mcpCallCmd = &cobra.Command{
+		Use:   "call <tool-name>",
+		Short: "Invoke an MCP tool directly",
+		Long:  "Invoke any discovered MCP tool through Station's MCP client, showing the raw request, response, and latency",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runMCPCall,
+	}
+
 	mcpDeleteCmd = &cobra.Command{
 		Use:   "delete <config-id>",
 		Short: "Delete an MCP configuration",
@@ -220,6 +270,13 @@ Perfect for getting started quickly with a fully configured Station instance.`,
 		RunE:  runMCPDelete,
 	}
 
+	mcpOutdatedCmd = &cobra.Command{
+		Use:   "outdated [environment]",
+		Short: "Check MCP servers for newer published versions",
+		Long:  "Compare the version each MCP server reported at discovery time against its package registry (npm for npx servers, PyPI for uvx servers) and list servers with newer versions available",
+		RunE:  runMCPOutdated,
+	}
+
 	mcpStatusCmd = &cobra.Command{
 		Use:   "status [environment]",
 		Short: "Show MCP configuration status",
@@ -227,6 +284,22 @@ Perfect for getting started quickly with a fully configured Station instance.`,
 		RunE:  runMCPStatus,
 	}
 
+	mcpAnnotateCmd = &cobra.Command{
+		Use:   "annotate [tool-name]",
+		Short: "Annotate tools with expected cost and latency",
+		Long:  "Attach expected cost/latency metadata to MCP tools, either manually via --cost/--latency or learned from run history via --learn; annotations are shown to the model during runs and aggregated into per-tool spend in reports",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runMCPAnnotate,
+	}
+
+	mcpLogsCmd = &cobra.Command{
+		Use:   "logs <server>",
+		Short: "Show recent stderr output from a stdio MCP server",
+		Long:  "Display the most recent stderr output captured from a stdio MCP server subprocess, useful for diagnosing server crashes and tool failures",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runMCPLogs,
+	}
+
 	// Template bundle commands
 	templateCmd = &cobra.Command{
 		Use:   "template",
@@ -322,7 +395,7 @@ Perfect for getting started quickly with a fully configured Station instance.`,
 	settingsGetCmd = &cobra.Command{
 		Use:   "get <key>",
 		Short: "Get a setting value",
-		Long:  "Get the value of a specific setting",
+		Long:  "Get the value of a specific setting, optionally scoped to an environment or agent, or resolved through the agent > environment > global hierarchy with --agent",
 		Args:  cobra.ExactArgs(1),
 		RunE:  runSettingsGet,
 	}
@@ -330,11 +403,57 @@ Perfect for getting started quickly with a fully configured Station instance.`,
 	settingsSetCmd = &cobra.Command{
 		Use:   "set <key> <value>",
 		Short: "Set a setting value",
-		Long:  "Set the value of a specific setting",
+		Long:  "Set the value of a specific setting, optionally scoped to an environment (--scope env:prod) or agent (--scope agent:3); scoped values override global ones during resolution",
 		Args:  cobra.ExactArgs(2),
 		RunE:  runSettingsSet,
 	}
 
+	// Remote runner commands
+	runnerCmd = &cobra.Command{
+		Use:   "runner",
+		Short: "Remote runner management commands",
+		Long:  "Register SSH runners that stdio MCP servers can execute on, for tools that must run inside a VPC or next to production data",
+	}
+
+	runnerAddCmd = &cobra.Command{
+		Use:   "add <name> <ssh-url>",
+		Short: "Register a remote runner",
+		Long: `Register a named SSH runner for remote MCP server execution.
+
+MCP servers reference runners via a "runner" field in their config; their
+stdio process then executes on the remote host with the JSON-RPC stream
+proxied transparently over SSH.
+
+Example:
+  stn runner add prod-bastion ssh://deploy@bastion.internal:22
+
+  # In the environment's MCP config:
+  "mcpServers": {
+    "filesystem": {
+      "command": "npx",
+      "args": ["-y", "@modelcontextprotocol/server-filesystem@latest", "/data"],
+      "runner": "prod-bastion"
+    }
+  }`,
+		Args: cobra.ExactArgs(2),
+		RunE: runRunnerAdd,
+	}
+
+	runnerListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List registered runners",
+		Long:  "List all registered remote runners and their SSH targets",
+		RunE:  runRunnerList,
+	}
+
+	runnerRemoveCmd = &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a registered runner",
+		Long:  "Remove a registered remote runner by name",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRunnerRemove,
+	}
+
 	syncCmd = &cobra.Command{
 		Use:   "sync [environment]",
 		Short: "Sync all file-based configurations",
@@ -433,6 +552,7 @@ Supported targets:
   fly        - Fly.io (uses fly secrets set, persistent storage)
   kubernetes - Kubernetes (generates manifests, use --secrets-backend for runtime secrets)
   ansible    - Ansible (generates playbook for Docker + SSH deployment)
+  ecs        - AWS ECS/Fargate (generates CloudFormation stack, secrets via SSM)
   cloudflare - [EXPERIMENTAL] Cloudflare Containers
 
 Runtime secrets (--secrets-backend):
@@ -470,6 +590,11 @@ The deployed instance exposes agents via MCP for public access.`,
   stn deploy my-env --target ansible --dry-run      # Generate playbook
   stn deploy my-env --target ansible                # Run playbook
 
+  # AWS ECS/Fargate (CloudFormation)
+  stn deploy my-env --target ecs --dry-run          # Generate CloudFormation template
+  stn deploy my-env --target ecs --region us-east-1 # Deploy stack (needs STN_ECS_VPC_ID/STN_ECS_SUBNET_IDS)
+  stn deploy my-env --target ecs --litestream       # Litestream-to-S3 instead of EFS
+
   # Runtime secrets (container fetches from backend at startup)
   stn deploy --bundle-id xxx --target k8s --secrets-backend vault --secrets-path secret/data/station/prod
   stn deploy --bundle-id xxx --target k8s --secrets-backend aws-secretsmanager --secrets-path station/prod
@@ -486,6 +611,9 @@ The deployed instance exposes agents via MCP for public access.`,
 )
 
 func runServe(cmd *cobra.Command, args []string) error {
+	// Tag server logs so JSON log consumers can separate them from CLI output
+	logging.SetComponent("serve")
+
 	// Set GenKit environment based on --dev flag
 	devMode, _ := cmd.Flags().GetBool("dev")
 	if !devMode && os.Getenv("GENKIT_ENV") == "" {
@@ -751,6 +879,16 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// Check if ship setup is requested
 	shipSetup, _ := cmd.Flags().GetBool("ship")
 
+	// Check if an MCP client config import is requested; validate the client
+	// name up front so a typo fails before any setup happens
+	importFrom, _ := cmd.Flags().GetString("import-from")
+	if importFrom != "" {
+		importFrom = strings.ToLower(importFrom)
+		if _, err := mcpClientConfigPaths(importFrom); err != nil {
+			return err
+		}
+	}
+
 	// Check CloudShip AI configuration
 	cloudshipaiKey, _ := cmd.Flags().GetString("cloudshipai")
 	cloudshipaiEndpoint, _ := cmd.Flags().GetString("cloudshipai_endpoint")
@@ -852,6 +990,8 @@ func runInit(cmd *cobra.Command, args []string) error {
 				model = recommended["cost_effective"]
 			} else if provider == "cloudshipai" {
 				model = "cloudship/llama-3.1-70b"
+			} else if preset, ok := openAICompatPresets[provider]; ok {
+				model = preset.Models[0]
 			} else {
 				model = "gemini-2.5-flash"
 			}
@@ -865,6 +1005,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		}
 
 		providerConfig = &ProviderConfig{Provider: provider, Model: model, BaseURL: baseURL}
+		applyProviderPreset(providerConfig)
 
 		if !configExists {
 			fmt.Printf("🤖 Using AI provider: %s\n", providerConfig.Provider)
@@ -894,6 +1035,11 @@ func runInit(cmd *cobra.Command, args []string) error {
 		if providerConfig.BaseURL != "" {
 			viper.Set("ai_base_url", providerConfig.BaseURL)
 		}
+		// Preset selections read their key from the provider's native env var
+		// (e.g. GROQ_API_KEY); config.Load expands the ${VAR} form at runtime
+		if providerConfig.APIKeyEnv != "" {
+			viper.Set("ai_api_key", fmt.Sprintf("${%s}", providerConfig.APIKeyEnv))
+		}
 	}
 
 	// Set workspace and database paths
@@ -1143,6 +1289,24 @@ ssh_host_key.pub
 		}
 	}
 
+	// Import MCP servers from an existing client config if requested, then
+	// run the first sync so tools are discovered and any secret variables
+	// are prompted for
+	if importFrom != "" {
+		fmt.Printf("📥 Importing MCP servers from %s...\n", importFrom)
+		importedServers, err := importMCPClientConfig(importFrom, workspaceDir)
+		if err != nil {
+			return fmt.Errorf("import from %s failed: %w", importFrom, err)
+		}
+		if importedServers > 0 {
+			fmt.Printf("🔄 Running first sync for imported servers...\n")
+			if err := runSyncForEnvironment("default", false, false, false, false, common.OutputTable); err != nil {
+				fmt.Printf("⚠️  First sync failed: %v\n", err)
+				fmt.Printf("💡 Fix the issue and run 'stn sync default' to retry\n")
+			}
+		}
+	}
+
 	// Set up Litestream replication configuration if requested
 	if replicationSetup {
 		if err := setupReplicationConfiguration(configDir); err != nil {
@@ -1396,12 +1560,73 @@ func runSync(cmd *cobra.Command, args []string) error {
 
 	environment := args[0]
 	browserMode, _ := cmd.Flags().GetBool("browser")
+	checkDrift, _ := cmd.Flags().GetBool("check-drift")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
+	fullSync, _ := cmd.Flags().GetBool("full")
+	enrichTools, _ := cmd.Flags().GetBool("enrich")
+	forceUnlock, _ := cmd.Flags().GetBool("force-unlock")
+
+	if checkDrift {
+		return runSyncCheckDrift(environment)
+	}
 
 	if browserMode {
 		return runSyncWithBrowser(environment)
 	}
 
-	return runSyncForEnvironment(environment)
+	return runSyncForEnvironment(environment, autoConfirm, fullSync, enrichTools, forceUnlock, common.OutputFormat(cmd))
+}
+
+// runSyncCheckDrift reports variable drift for an environment without
+// syncing. Exit codes for CI enforcement: 0 = clean, 1 = unused variables or
+// cross-environment value differences, 2 = missing variables.
+func runSyncCheckDrift(environment string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := cfg.LoadSecretsFromBackend(); err != nil {
+		return fmt.Errorf("failed to load secrets from backend: %w", err)
+	}
+
+	environmentsRoot := filepath.Join(config.GetConfigRoot(), "environments")
+	report, err := services.CheckVariableDrift(environmentsRoot, environment)
+	if err != nil {
+		return fmt.Errorf("drift check failed for environment %s: %w", environment, err)
+	}
+
+	fmt.Printf("Variable drift check for environment: %s\n", environment)
+
+	if report.Clean() {
+		fmt.Printf("  ✅ No drift detected\n")
+		return nil
+	}
+
+	if len(report.Missing) > 0 {
+		fmt.Printf("  ❌ Missing variables (referenced by templates, defined nowhere):\n")
+		for _, name := range report.Missing {
+			fmt.Printf("     - %s\n", name)
+		}
+	}
+	if len(report.Unused) > 0 {
+		fmt.Printf("  ⚠️  Unused variables (in variables.yml, referenced by no template):\n")
+		for _, name := range report.Unused {
+			fmt.Printf("     - %s\n", name)
+		}
+	}
+	if len(report.CrossEnvDiffs) > 0 {
+		fmt.Printf("  ⚠️  Values differing from environments sharing a bundle:\n")
+		for _, diff := range report.CrossEnvDiffs {
+			fmt.Printf("     - %s differs from environment '%s' (via %s)\n", diff.Variable, diff.OtherEnvironment, diff.SharedTemplate)
+		}
+	}
+
+	if len(report.Missing) > 0 {
+		os.Exit(2)
+	}
+	os.Exit(1)
+	return nil
 }
 
 func runSyncWithBrowser(environment string) error {
@@ -1450,7 +1675,7 @@ func installShipCLI() error {
 }
 
 // runSyncForEnvironment runs sync for a specific environment using DeclarativeSync service
-func runSyncForEnvironment(environment string) error {
+func runSyncForEnvironment(environment string, autoConfirm bool, fullSync bool, enrichTools bool, forceUnlock bool, outputFormat string) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -1468,19 +1693,42 @@ func runSyncForEnvironment(environment string) error {
 
 	repos := repositories.New(database)
 
+	// Clear a stale advisory sync lock before syncing when asked
+	if forceUnlock {
+		holder, unlockErr := services.NewSyncLockService(repos).ForceUnlock(environment)
+		if unlockErr != nil {
+			return fmt.Errorf("failed to force-unlock environment %s: %w", environment, unlockErr)
+		}
+		if holder != nil {
+			fmt.Printf("🔓 Removed sync lock held by %s (pid %d on %s since %s)\n",
+				holder.Source, holder.PID, holder.Hostname, holder.AcquiredAt.Format(time.RFC3339))
+		} else {
+			fmt.Printf("🔓 No sync lock was held for environment: %s\n", environment)
+		}
+	}
+
 	// Create sync service
 	syncer := services.NewDeclarativeSync(repos, cfg)
 
 	fmt.Printf("Starting sync for environment: %s\n", environment)
 
+	// Per-server discovery progress (bar on a TTY, plain lines otherwise,
+	// machine-readable events with --output json)
+	progress := common.NewProgressTracker("Discovering MCP tools", outputFormat)
+
 	// Sync the specific environment
 	result, err := syncer.SyncEnvironment(context.Background(), environment, services.SyncOptions{
 		DryRun:      false,
 		Validate:    false,
 		Interactive: true,
 		Verbose:     false,
-		Confirm:     false,
+		Confirm:     autoConfirm,
+		Full:        fullSync,
+		EnrichTools: enrichTools,
+		Source:      "cli",
+		Progress:    progress.Update,
 	})
+	progress.Done()
 
 	if err != nil {
 		return fmt.Errorf("sync failed for environment %s: %w", environment, err)
@@ -1720,6 +1968,25 @@ func runSettingsList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list settings: %w", err)
 	}
 
+	// Machine formats emit only the rows, no banners or styling
+	if format := common.OutputFormat(cmd); common.MachineFormat(format) {
+		columns := []string{"key", "value", "description", "updated_at"}
+		rows := make([][]string, 0, len(settings))
+		for _, setting := range settings {
+			description := ""
+			if setting.Description != nil {
+				description = *setting.Description
+			}
+			rows = append(rows, []string{
+				setting.Key,
+				setting.Value,
+				description,
+				setting.UpdatedAt.Format(time.RFC3339),
+			})
+		}
+		return common.RenderList(format, columns, rows)
+	}
+
 	if len(settings) == 0 {
 		fmt.Println("No settings found.")
 		return nil
@@ -1745,6 +2012,11 @@ func runSettingsGet(cmd *cobra.Command, args []string) error {
 	}
 
 	key := args[0]
+	scope, _ := cmd.Flags().GetString("scope")
+	agentID, _ := cmd.Flags().GetInt64("agent")
+	if scope != "" && agentID != 0 {
+		return fmt.Errorf("--scope and --agent are mutually exclusive")
+	}
 
 	// Get database path from config
 	databasePath := viper.GetString("database_url")
@@ -1763,9 +2035,36 @@ func runSettingsGet(cmd *cobra.Command, args []string) error {
 	// Initialize repositories
 	repos := repositories.New(database)
 
+	// Resolve through the agent > environment > global hierarchy
+	if agentID != 0 {
+		setting, winningScope, err := services.ResolveAgentSetting(repos, agentID, key)
+		if err != nil {
+			return err
+		}
+		if setting == nil {
+			return fmt.Errorf("setting '%s' not set at any scope for agent %d", key, agentID)
+		}
+		fmt.Printf("Key: %s\n", key)
+		fmt.Printf("Value: %s\n", setting.Value)
+		fmt.Printf("Resolved from scope: %s\n", winningScope)
+		if setting.Description != nil {
+			fmt.Printf("Description: %s\n", *setting.Description)
+		}
+		fmt.Printf("Updated: %s\n", setting.UpdatedAt.Format(time.RFC3339))
+		return nil
+	}
+
+	storedKey, err := services.ScopedSettingKey(scope, key)
+	if err != nil {
+		return err
+	}
+
 	// Get the setting
-	setting, err := repos.Settings.GetByKey(key)
+	setting, err := repos.Settings.GetByKey(storedKey)
 	if err != nil {
+		if scope != "" {
+			return fmt.Errorf("setting '%s' not found in scope '%s'", key, scope)
+		}
 		return fmt.Errorf("setting '%s' not found", key)
 	}
 
@@ -1788,6 +2087,12 @@ func runSettingsSet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	value := args[1]
 	description, _ := cmd.Flags().GetString("description")
+	scope, _ := cmd.Flags().GetString("scope")
+
+	storedKey, err := services.ScopedSettingKey(scope, key)
+	if err != nil {
+		return err
+	}
 
 	// Get database path from config
 	databasePath := viper.GetString("database_url")
@@ -1807,12 +2112,16 @@ func runSettingsSet(cmd *cobra.Command, args []string) error {
 	repos := repositories.New(database)
 
 	// Set the setting
-	err = repos.Settings.Set(key, value, description)
+	err = repos.Settings.Set(storedKey, value, description)
 	if err != nil {
 		return fmt.Errorf("failed to set setting: %w", err)
 	}
 
-	fmt.Printf("Setting '%s' has been set to '%s'\n", key, value)
+	if scope != "" && scope != "global" {
+		fmt.Printf("Setting '%s' has been set to '%s' in scope '%s'\n", key, value, scope)
+	} else {
+		fmt.Printf("Setting '%s' has been set to '%s'\n", key, value)
+	}
 	if description != "" {
 		fmt.Printf("Description: %s\n", description)
 	}
@@ -1820,6 +2129,84 @@ func runSettingsSet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Remote runner command functions
+
+func openSettingsDatabase() (*db.DB, *repositories.Repositories, error) {
+	databasePath := viper.GetString("database_url")
+	if databasePath == "" {
+		configDir := getWorkspacePath()
+		databasePath = filepath.Join(configDir, "station.db")
+	}
+
+	database, err := db.New(databasePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return database, repositories.New(database), nil
+}
+
+func runRunnerAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	sshURL := args[1]
+
+	database, repos, err := openSettingsDatabase()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	if err := services.AddRemoteRunner(repos, name, sshURL); err != nil {
+		return err
+	}
+
+	fmt.Printf("Runner '%s' registered for %s\n", name, sshURL)
+	fmt.Printf("Reference it from an MCP server config with \"runner\": \"%s\"\n", name)
+	return nil
+}
+
+func runRunnerList(cmd *cobra.Command, args []string) error {
+	database, repos, err := openSettingsDatabase()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	runners, err := services.ListRemoteRunners(repos)
+	if err != nil {
+		return fmt.Errorf("failed to list runners: %w", err)
+	}
+
+	if len(runners) == 0 {
+		fmt.Println("No runners registered.")
+		fmt.Println("Use 'stn runner add <name> ssh://user@host:port' to register one")
+		return nil
+	}
+
+	fmt.Printf("Registered runners (%d total):\n\n", len(runners))
+	for _, runner := range runners {
+		fmt.Printf("🖥️  %s\n", runner.Name)
+		fmt.Printf("   SSH: %s\n\n", runner.SSHURL)
+	}
+	return nil
+}
+
+func runRunnerRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	database, repos, err := openSettingsDatabase()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	if err := services.RemoveRemoteRunner(repos, name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Runner '%s' removed\n", name)
+	return nil
+}
+
 func runDeploy(cmd *cobra.Command, args []string) error {
 	var envName string
 	if len(args) > 0 {
@@ -1847,6 +2234,9 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	envFile, _ := cmd.Flags().GetString("env-file")
 	secretsBackend, _ := cmd.Flags().GetString("secrets-backend")
 	secretsPath, _ := cmd.Flags().GetString("secrets-path")
+	ingressHost, _ := cmd.Flags().GetString("ingress-host")
+	litestream, _ := cmd.Flags().GetBool("litestream")
+	k8sOptions := &handlers.K8sDeployOptions{IngressHost: ingressHost, Litestream: litestream}
 
 	if envName == "" && bundleID == "" && bundlePath == "" {
 		return fmt.Errorf("either environment name, --bundle-id, or --bundle is required\n\nUsage:\n  stn deploy <environment>          Deploy local environment\n  stn deploy --bundle-id <uuid>     Deploy CloudShip bundle\n  stn deploy --bundle ./file.tar.gz Deploy local bundle file")
@@ -1867,7 +2257,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	}
 
 	if bundlePath != "" {
-		return deployLocalBundle(cmd, bundlePath, target, region, sleepAfter, instanceType, destroy, autoStop, withOpenCode, withSandbox, namespace, k8sContext, outputDir, dryRun, appName, hosts, sshKey, sshUser, envFile, secretsBackend, secretsPath)
+		return deployLocalBundle(cmd, bundlePath, target, region, sleepAfter, instanceType, destroy, autoStop, withOpenCode, withSandbox, namespace, k8sContext, outputDir, dryRun, appName, hosts, sshKey, sshUser, envFile, secretsBackend, secretsPath, k8sOptions)
 	}
 
 	if !autoStop {
@@ -1875,7 +2265,7 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := context.Background()
-	return handlers.HandleDeploy(ctx, envName, target, region, sleepAfter, instanceType, destroy, autoStop, withOpenCode, withSandbox, namespace, k8sContext, outputDir, dryRun, bundleID, appName, hosts, sshKey, sshUser, "", envFile, secretsBackend, secretsPath)
+	return handlers.HandleDeploy(ctx, envName, target, region, sleepAfter, instanceType, destroy, autoStop, withOpenCode, withSandbox, namespace, k8sContext, outputDir, dryRun, bundleID, appName, hosts, sshKey, sshUser, "", envFile, secretsBackend, secretsPath, k8sOptions)
 }
 
 func resolveDeployEnvName(customName string, cfg *config.Config, bundlePath string) string {
@@ -1898,7 +2288,7 @@ func resolveDeployEnvName(customName string, cfg *config.Config, bundlePath stri
 	return bundleBaseName
 }
 
-func deployLocalBundle(cmd *cobra.Command, bundlePath, target, region, sleepAfter, instanceType string, destroy, autoStop, withOpenCode, withSandbox bool, namespace, k8sContext, outputDir string, dryRun bool, appName string, hosts []string, sshKey, sshUser, envFile, secretsBackend, secretsPath string) error {
+func deployLocalBundle(cmd *cobra.Command, bundlePath, target, region, sleepAfter, instanceType string, destroy, autoStop, withOpenCode, withSandbox bool, namespace, k8sContext, outputDir string, dryRun bool, appName string, hosts []string, sshKey, sshUser, envFile, secretsBackend, secretsPath string, k8sOptions *handlers.K8sDeployOptions) error {
 	if _, err := os.Stat(bundlePath); os.IsNotExist(err) {
 		return fmt.Errorf("bundle file not found: %s", bundlePath)
 	}
@@ -1950,7 +2340,7 @@ func deployLocalBundle(cmd *cobra.Command, bundlePath, target, region, sleepAfte
 	}
 
 	ctx := context.Background()
-	return handlers.HandleDeploy(ctx, envName, target, region, sleepAfter, instanceType, destroy, autoStop, withOpenCode, withSandbox, namespace, k8sContext, outputDir, dryRun, "", appName, hosts, sshKey, sshUser, bundlePath, envFile, secretsBackend, secretsPath)
+	return handlers.HandleDeploy(ctx, envName, target, region, sleepAfter, instanceType, destroy, autoStop, withOpenCode, withSandbox, namespace, k8sContext, outputDir, dryRun, "", appName, hosts, sshKey, sshUser, bundlePath, envFile, secretsBackend, secretsPath, k8sOptions)
 }
 
 // bootstrapGitHubWorkflows creates GitHub Actions workflow files in .github/workflows/
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+var (
+	composeCmd = &cobra.Command{
+		Use:   "compose",
+		Short: "Docker Compose helpers for local stacks",
+		Long:  "Generate Docker Compose configurations that wire Station serve mode, Jaeger telemetry, optional Ollama, and mock MCP servers from an environment's configuration",
+	}
+
+	composeGenerateCmd = &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a docker-compose.yml for a full local stack",
+		Long:  "Produce a docker-compose.yml wiring Station serve mode, Jaeger, optional Ollama, and the mock MCP servers declared in the environment's configuration, so a full local stack comes up with one 'docker compose up'",
+		RunE:  runComposeGenerate,
+	}
+)
+
+// runComposeGenerate writes the generated compose file
+func runComposeGenerate(cmd *cobra.Command, args []string) error {
+	envName, _ := cmd.Flags().GetString("env")
+	output, _ := cmd.Flags().GetString("output")
+	includeOllama, _ := cmd.Flags().GetBool("ollama")
+	force, _ := cmd.Flags().GetBool("force")
+
+	if _, err := os.Stat(output); err == nil && !force {
+		return fmt.Errorf("%s already exists; use --force to overwrite", output)
+	}
+
+	databasePath := viper.GetString("database_url")
+	if databasePath == "" {
+		configDir := getWorkspacePath()
+		databasePath = filepath.Join(configDir, "station.db")
+	}
+
+	database, err := db.New(databasePath)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	servers, err := services.NewMCPServerManagementService(repos).GetMCPServersForEnvironment(envName)
+	if err != nil {
+		return fmt.Errorf("failed to read MCP servers for environment %s: %w", envName, err)
+	}
+
+	content := buildComposeFile(envName, servers, includeOllama)
+	if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	mockCount := len(mockServerNames(servers))
+	fmt.Printf("✅ Wrote %s (environment: %s)\n", output, envName)
+	fmt.Printf("   Services: station, jaeger")
+	if includeOllama {
+		fmt.Printf(", ollama")
+	}
+	if mockCount > 0 {
+		fmt.Printf(", %d mock MCP server(s)", mockCount)
+	}
+	fmt.Printf("\n\nStart the stack with: docker compose -f %s up\n", output)
+	return nil
+}
+
+// mockServerNames returns the environment's MCP servers that can run as
+// standalone demo containers (stn mock <tool>), sorted for stable output
+func mockServerNames(servers map[string]services.MCPServerConfig) []string {
+	var names []string
+	for name, server := range servers {
+		if server.Command == "stn" && len(server.Args) >= 2 && server.Args[0] == "mock" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// buildComposeFile renders the compose YAML for the local stack. Stdio MCP
+// servers that are not Station mocks run inside the station container during
+// `stn sync`, so they are listed as comments rather than services.
+func buildComposeFile(envName string, servers map[string]services.MCPServerConfig, includeOllama bool) string {
+	var b strings.Builder
+
+	b.WriteString("# Station local stack (generated by `stn compose generate`)\n")
+	fmt.Fprintf(&b, "# Environment: %s\n", envName)
+	b.WriteString("#\n")
+	b.WriteString("# Usage:\n")
+	b.WriteString("#   docker compose up          - start the full stack\n")
+	b.WriteString("#   docker compose down        - stop it\n")
+	b.WriteString("#\n")
+	b.WriteString("# Station UI:  http://localhost:8585\n")
+	b.WriteString("# Jaeger UI:   http://localhost:16686\n")
+	if includeOllama {
+		b.WriteString("# Ollama API:  http://localhost:11434\n")
+	}
+	b.WriteString("\nservices:\n")
+
+	b.WriteString("  station:\n")
+	b.WriteString("    image: ghcr.io/cloudshipai/station:latest\n")
+	b.WriteString("    # To run your local checkout instead:\n")
+	b.WriteString("    # build: .\n")
+	b.WriteString("    command: [\"stn\", \"serve\"]\n")
+	b.WriteString("    ports:\n")
+	b.WriteString("      - \"8585:8585\"   # API + UI\n")
+	b.WriteString("      - \"3000:3000\"   # MCP server\n")
+	b.WriteString("      - \"2222:2222\"   # SSH admin\n")
+	b.WriteString("    volumes:\n")
+	b.WriteString("      - ~/.config/station:/home/station/.config/station\n")
+	b.WriteString("    environment:\n")
+	b.WriteString("      - STATION_RUNTIME=docker\n")
+	fmt.Fprintf(&b, "      - STATION_ENV=%s\n", envName)
+	b.WriteString("      - OTEL_EXPORTER_OTLP_ENDPOINT=http://jaeger:4318\n")
+	b.WriteString("      - OTEL_EXPORTER_OTLP_PROTOCOL=http/protobuf\n")
+	b.WriteString("      - OTEL_SERVICE_NAME=station\n")
+	if includeOllama {
+		b.WriteString("      - OPENAI_BASE_URL=http://ollama:11434/v1\n")
+	}
+	b.WriteString("    depends_on:\n")
+	b.WriteString("      - jaeger\n")
+	if includeOllama {
+		b.WriteString("      - ollama\n")
+	}
+	b.WriteString("    restart: unless-stopped\n")
+	b.WriteString("    networks:\n")
+	b.WriteString("      - station-network\n")
+
+	b.WriteString("\n  jaeger:\n")
+	b.WriteString("    image: jaegertracing/all-in-one:latest\n")
+	b.WriteString("    ports:\n")
+	b.WriteString("      - \"16686:16686\"   # Jaeger UI\n")
+	b.WriteString("      - \"4317:4317\"     # OTLP gRPC\n")
+	b.WriteString("      - \"4318:4318\"     # OTLP HTTP\n")
+	b.WriteString("    environment:\n")
+	b.WriteString("      - COLLECTOR_OTLP_ENABLED=true\n")
+	b.WriteString("      - SPAN_STORAGE_TYPE=memory\n")
+	b.WriteString("    networks:\n")
+	b.WriteString("      - station-network\n")
+
+	if includeOllama {
+		b.WriteString("\n  ollama:\n")
+		b.WriteString("    image: ollama/ollama:latest\n")
+		b.WriteString("    ports:\n")
+		b.WriteString("      - \"11434:11434\"\n")
+		b.WriteString("    volumes:\n")
+		b.WriteString("      - ollama_data:/root/.ollama\n")
+		b.WriteString("    networks:\n")
+		b.WriteString("      - station-network\n")
+	}
+
+	for _, name := range mockServerNames(servers) {
+		server := servers[name]
+		fmt.Fprintf(&b, "\n  mock-%s:\n", name)
+		b.WriteString("    image: ghcr.io/cloudshipai/station:latest\n")
+		fmt.Fprintf(&b, "    command: [\"stn\", \"mock\", \"%s\"]\n", server.Args[1])
+		b.WriteString("    stdin_open: true\n")
+		b.WriteString("    restart: unless-stopped\n")
+		b.WriteString("    networks:\n")
+		b.WriteString("      - station-network\n")
+	}
+
+	var stdioServers []string
+	mockNames := mockServerNames(servers)
+	for name, server := range servers {
+		isMock := false
+		for _, mockName := range mockNames {
+			if name == mockName {
+				isMock = true
+				break
+			}
+		}
+		if isMock || server.Command == "" {
+			continue
+		}
+		stdioServers = append(stdioServers, fmt.Sprintf("#   %s: %s %s", name, server.Command, strings.Join(server.Args, " ")))
+	}
+	if len(stdioServers) > 0 {
+		sort.Strings(stdioServers)
+		b.WriteString("\n# Stdio MCP servers from this environment run inside the station\n")
+		b.WriteString("# container when it syncs; no separate service is needed:\n")
+		b.WriteString(strings.Join(stdioServers, "\n"))
+		b.WriteString("\n")
+	}
+
+	if includeOllama {
+		b.WriteString("\nvolumes:\n")
+		b.WriteString("  ollama_data:\n")
+	}
+
+	b.WriteString("\nnetworks:\n")
+	b.WriteString("  station-network:\n")
+	b.WriteString("    driver: bridge\n")
+
+	return b.String()
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+var costsCmd = &cobra.Command{
+	Use:   "costs",
+	Short: "Historical cost breakdown from run records",
+	Long: `Report actual token usage and cost from recorded runs, priced with the
+model pricing table, grouped by any combination of env, model, and agent.
+Each row includes the delta versus the preceding window of the same length.
+
+EXAMPLES:
+  # Last 30 days grouped by environment and model
+  stn costs --since 30d --group-by env,model
+
+  # Per-agent spend over the last week, as JSON
+  stn costs --since 7d --group-by agent --json`,
+	RunE: runCosts,
+}
+
+// runCosts prints the historical cost report
+func runCosts(cmd *cobra.Command, args []string) error {
+	sinceStr, _ := cmd.Flags().GetString("since")
+	groupByStr, _ := cmd.Flags().GetString("group-by")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	since, err := parseDuration(sinceStr)
+	if err != nil {
+		return fmt.Errorf("invalid --since value %q: %w", sinceStr, err)
+	}
+	var groupBy []string
+	for _, dim := range strings.Split(groupByStr, ",") {
+		if dim = strings.TrimSpace(dim); dim != "" {
+			groupBy = append(groupBy, dim)
+		}
+	}
+	if len(groupBy) == 0 {
+		return fmt.Errorf("--group-by requires at least one of: env, model, agent")
+	}
+
+	databasePath := viper.GetString("database_url")
+	if databasePath == "" {
+		configDir := getWorkspacePath()
+		databasePath = filepath.Join(configDir, "station.db")
+	}
+
+	database, err := db.New(databasePath)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	report, err := services.NewCostReportService(repos).Report(context.Background(), since, groupBy)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Printf("Cost Report (last %s, grouped by %s)\n\n", sinceStr, strings.Join(groupBy, ", "))
+	if len(report.Groups) == 0 {
+		fmt.Println("No runs with recorded usage in the window.")
+		return nil
+	}
+
+	header := make([]string, 0, len(groupBy)+4)
+	for _, dim := range groupBy {
+		header = append(header, costColumnTitle(dim))
+	}
+	header = append(header, "Runs", "Tokens", "Cost", "Δ prev window")
+	fmt.Println(strings.Join(header, "\t"))
+
+	for _, group := range report.Groups {
+		row := make([]string, 0, len(header))
+		for _, dim := range groupBy {
+			switch dim {
+			case "env":
+				row = append(row, group.Environment)
+			case "model":
+				row = append(row, group.Model)
+			case "agent":
+				row = append(row, group.Agent)
+			}
+		}
+		delta := "n/a"
+		if group.DeltaPercent != nil {
+			delta = fmt.Sprintf("%+.1f%%", *group.DeltaPercent)
+		}
+		row = append(row,
+			fmt.Sprintf("%d", group.Runs),
+			fmt.Sprintf("%d", group.TotalTokens),
+			fmt.Sprintf("$%.4f", group.CostUSD),
+			delta)
+		fmt.Println(strings.Join(row, "\t"))
+	}
+
+	fmt.Printf("\nTotal: %d run(s), %d tokens, $%.4f\n", report.TotalRuns, report.TotalTokens, report.TotalCostUSD)
+	if len(report.UnpricedModels) > 0 {
+		fmt.Printf("⚠️  Fallback rates used for model(s) not in the pricing table: %s\n", strings.Join(report.UnpricedModels, ", "))
+	}
+	return nil
+}
+
+// costColumnTitle maps a grouping dimension to its table header
+func costColumnTitle(dim string) string {
+	switch dim {
+	case "env":
+		return "Environment"
+	case "model":
+		return "Model"
+	case "agent":
+		return "Agent"
+	}
+	return dim
+}
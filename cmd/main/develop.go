@@ -21,6 +21,7 @@ import (
 	"station/internal/config"
 	"station/internal/db"
 	"station/internal/db/repositories"
+	"station/internal/genkit/stub"
 	"station/internal/logging"
 	"station/internal/services"
 )
@@ -244,6 +245,20 @@ func initializeGenKitWithPromptDir(ctx context.Context, promptDir string) (*genk
 		)
 		err = nil // GenKit v1.0.1 Init doesn't return error
 
+	case "stub":
+		logging.Debug("Setting up stub plugin for offline development")
+
+		stubPlugin := &stub.Stub{
+			Models:     []string{cfg.AIModel},
+			ScriptPath: os.Getenv("STN_STUB_RESPONSES"),
+		}
+
+		genkitApp = genkit.Init(ctx,
+			genkit.WithPlugins(stubPlugin),
+			genkit.WithPromptDir(promptDir),
+		)
+		err = nil
+
 	default:
 		return nil, fmt.Errorf("unsupported AI provider for development: %s", cfg.AIProvider)
 	}
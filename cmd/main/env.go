@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+var (
+	envCloneVarsFrom string
+	envCloneVarsFile string
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Manage environments",
+	Long:  "Clone and manage Station environments",
+}
+
+var envCloneCmd = &cobra.Command{
+	Use:   "clone <source> <target>",
+	Short: "Clone an environment with its agents, templates, workflows, and tool assignments",
+	Long: `Deep-copy an environment into a new one.
+
+The clone copies MCP template configs, agent .prompt files, and workflow
+definitions, then prompts for environment-specific variable values (a manual
+directory copy would silently inherit the source's endpoints and credentials).
+After the files are in place the new environment is synced, and DB-side
+agent-tool assignments are copied from the source — the part a plain
+directory copy misses.
+
+Variable sources (--vars-from):
+  prompt  Interactively prompt for each variable, defaulting to the source value
+  file    Take variables.yml from the path given with --vars-file
+
+Examples:
+  # Clone prod into a disaster-recovery environment, prompting for variables
+  stn env clone prod prod-dr
+
+  # Non-interactive clone using a prepared variables file
+  stn env clone prod prod-dr --vars-from file --vars-file ./prod-dr-vars.yml`,
+	Args: cobra.ExactArgs(2),
+	RunE: runEnvClone,
+}
+
+var envRestoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "Restore a soft-deleted environment",
+	Long: `Restore a soft-deleted environment by name.
+
+Deleting an environment parks its directory and soft-deletes the database
+entries; restore brings back the environment, the agents deleted with it,
+and the parked files. Environments stay restorable until the purger removes
+them after the retention window (soft_delete_retention_days, default 30).`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEnvRestore,
+}
+
+func init() {
+	envCloneCmd.Flags().StringVar(&envCloneVarsFrom, "vars-from", "prompt", "Where variable values come from: prompt or file")
+	envCloneCmd.Flags().StringVar(&envCloneVarsFile, "vars-file", "", "Variables file to install when --vars-from=file")
+
+	envCmd.AddCommand(envCloneCmd)
+	envCmd.AddCommand(envRestoreCmd)
+}
+
+func runEnvRestore(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	envService := services.NewEnvironmentManagementService(repos)
+
+	result := envService.RestoreEnvironment(name)
+	if !result.Success {
+		deleted, listErr := repos.Environments.ListDeleted(cmd.Context())
+		if listErr == nil && len(deleted) > 0 {
+			fmt.Println("Deleted environments available for restore:")
+			for _, env := range deleted {
+				fmt.Printf("  %s (deleted: %s)\n", env.Name, env.DeletedAt.Format("2006-01-02 15:04"))
+			}
+		}
+		return fmt.Errorf("%s", result.Message)
+	}
+
+	fmt.Printf("✅ %s\n", result.Message)
+	if result.FileCleanupError != "" {
+		fmt.Printf("⚠️  %s\n", result.FileCleanupError)
+	}
+	return nil
+}
+
+func runEnvClone(cmd *cobra.Command, args []string) error {
+	sourceName := args[0]
+	targetName := args[1]
+
+	switch envCloneVarsFrom {
+	case "prompt":
+	case "file":
+		if envCloneVarsFile == "" {
+			return fmt.Errorf("--vars-from=file requires --vars-file")
+		}
+	default:
+		return fmt.Errorf("invalid --vars-from value '%s' (expected prompt or file)", envCloneVarsFrom)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.LoadSecretsFromBackend(); err != nil {
+		return fmt.Errorf("failed to load secrets from backend: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	cloneService := services.NewEnvironmentCloneService(repos)
+
+	fmt.Printf("🧬 Cloning environment '%s' into '%s'\n\n", sourceName, targetName)
+
+	_, files, err := cloneService.PrepareClone(sourceName, targetName, 1)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("📁 Copied %d template(s), %d agent(s), %d workflow file(s)\n",
+		files.TemplatesCopied, files.AgentsCopied, files.WorkflowsCopied)
+
+	if envCloneVarsFrom == "file" {
+		if err := cloneService.CopyVariablesFile(targetName, envCloneVarsFile); err != nil {
+			return err
+		}
+		fmt.Printf("📋 Installed variables from %s\n", envCloneVarsFile)
+	} else {
+		if err := promptCloneVariables(cloneService, sourceName, targetName); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\n🔄 Syncing environment '%s'...\n", targetName)
+	syncer := services.NewDeclarativeSync(repos, cfg)
+	syncResult, err := syncer.SyncEnvironment(cmd.Context(), targetName, services.SyncOptions{
+		Interactive: true,
+		Confirm:     true,
+	})
+	if err != nil {
+		return fmt.Errorf("sync failed for environment %s: %w", targetName, err)
+	}
+	fmt.Printf("   Agents: %d processed, %d synced\n", syncResult.AgentsProcessed, syncResult.AgentsSynced)
+	fmt.Printf("   MCP Servers: %d processed, %d connected\n", syncResult.MCPServersProcessed, syncResult.MCPServersConnected)
+
+	assigned, err := cloneService.AssignToolsFromSource(sourceName, targetName)
+	if err != nil {
+		return fmt.Errorf("failed to copy tool assignments: %w", err)
+	}
+	fmt.Printf("🔧 Copied %d tool assignment(s) from '%s'\n", assigned, sourceName)
+
+	fmt.Printf("\n✅ Environment '%s' cloned successfully\n", targetName)
+	return nil
+}
+
+// promptCloneVariables asks for each of the source environment's variables,
+// keeping the source value when the user just presses enter
+func promptCloneVariables(cloneService *services.EnvironmentCloneService, sourceName, targetName string) error {
+	sourceVars, err := cloneService.SourceVariables(sourceName)
+	if err != nil {
+		return fmt.Errorf("failed to read source variables: %w", err)
+	}
+	if len(sourceVars) == 0 {
+		fmt.Printf("📋 Source environment has no variables to re-enter\n")
+		return nil
+	}
+
+	keys := make([]string, 0, len(sourceVars))
+	for key := range sourceVars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("\n📋 Enter variable values for '%s' (press enter to keep the source value):\n", targetName)
+	reader := bufio.NewReader(os.Stdin)
+	targetVars := make(map[string]string, len(sourceVars))
+	for _, key := range keys {
+		fmt.Printf("   %s [%s]: ", key, sourceVars[key])
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		input = strings.TrimSpace(input)
+		if input == "" {
+			input = sourceVars[key]
+		}
+		targetVars[key] = input
+	}
+
+	return cloneService.WriteVariables(targetName, targetVars)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"station/internal/config"
 	"station/internal/db"
@@ -26,6 +27,8 @@ var (
 	fakerAITemplate    string
 	fakerStandalone    bool
 	fakerID            string
+	fakerCacheTTL      time.Duration
+	fakerChaosFile     string
 )
 
 var fakerCmd = &cobra.Command{
@@ -76,6 +79,12 @@ Examples:
     --ai-enabled \
     --ai-template "monitoring-high-alert"
 
+  # Chaos injection for resilience testing (latency, errors, malformed responses)
+  stn faker \
+    --standalone --faker-id aws-cloudwatch-faker \
+    --ai-instruction "Generate realistic CloudWatch monitoring data" \
+    --chaos-file chaos-scenario.json
+
   # View faker session history
   stn faker sessions list
   stn faker sessions view <session-id>
@@ -100,23 +109,59 @@ Usage in template.json:
 	RunE: runFaker,
 }
 
-func init() {
-	fakerCmd.Flags().StringVar(&fakerCommand, "command", "", "Command to execute target MCP server")
-	fakerCmd.Flags().StringVar(&fakerArgs, "args", "", "Comma-separated args for target (e.g., '-y,@aws/mcp')")
-	fakerCmd.Flags().StringSliceVar(&fakerEnvVars, "env", []string{}, "Environment variables (repeatable, format: KEY=VALUE)")
-	fakerCmd.Flags().StringVar(&fakerCacheDir, "cache-dir", "", "Directory for schema cache (default: ~/.cache/station/faker)")
-	fakerCmd.Flags().BoolVar(&fakerDebug, "debug", false, "Enable debug logging")
-	fakerCmd.Flags().BoolVar(&fakerPassthrough, "passthrough", false, "Disable enrichment (pure proxy mode)")
+// fakerServeCmd is an explicit entry point for the faker proxy role. It is
+// identical to running `stn faker` with flags, but reads naturally in
+// template.json alongside `stn openapi-mcp serve` and stays unambiguous as
+// management subcommands accumulate under faker.
+var fakerServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the MCP faker proxy (same as 'stn faker' with flags)",
+	Long: `Run the MCP faker proxy server over stdio.
+
+This is the same proxy as 'stn faker' with flags; the explicit serve
+subcommand exists so template.json entries and deployment scripts can name
+the subprocess role directly:
+
+  {
+    "mcpServers": {
+      "aws-cost-faker": {
+        "command": "stn",
+        "args": ["faker", "serve", "--command", "npx", "--args", "-y,@aws-sdk/mcp-server-aws-cost-explorer"]
+      }
+    }
+  }`,
+	RunE: runFaker,
+}
+
+// addFakerProxyFlags registers the proxy flags shared by `stn faker` and
+// `stn faker serve`
+func addFakerProxyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&fakerCommand, "command", "", "Command to execute target MCP server")
+	cmd.Flags().StringVar(&fakerArgs, "args", "", "Comma-separated args for target (e.g., '-y,@aws/mcp')")
+	cmd.Flags().StringSliceVar(&fakerEnvVars, "env", []string{}, "Environment variables (repeatable, format: KEY=VALUE)")
+	cmd.Flags().StringVar(&fakerCacheDir, "cache-dir", "", "Directory for schema cache (default: ~/.cache/station/faker)")
+	cmd.Flags().BoolVar(&fakerDebug, "debug", false, "Enable debug logging")
+	cmd.Flags().BoolVar(&fakerPassthrough, "passthrough", false, "Disable enrichment (pure proxy mode)")
 
 	// AI enrichment flags
-	fakerCmd.Flags().BoolVar(&fakerAIEnabled, "ai-enabled", false, "Enable AI-powered enrichment using Station's configured AI provider")
-	fakerCmd.Flags().StringVar(&fakerAIModel, "ai-model", "", "AI model for enrichment (overrides Station's configured model)")
-	fakerCmd.Flags().StringVar(&fakerAIInstruction, "ai-instruction", "", "Custom instruction for AI data generation (e.g., 'Generate high-alert monitoring data')")
-	fakerCmd.Flags().StringVar(&fakerAITemplate, "ai-template", "", "Predefined instruction template (use 'list' to see available templates)")
+	cmd.Flags().BoolVar(&fakerAIEnabled, "ai-enabled", false, "Enable AI-powered enrichment using Station's configured AI provider")
+	cmd.Flags().StringVar(&fakerAIModel, "ai-model", "", "AI model for enrichment (overrides Station's configured model)")
+	cmd.Flags().StringVar(&fakerAIInstruction, "ai-instruction", "", "Custom instruction for AI data generation (e.g., 'Generate high-alert monitoring data')")
+	cmd.Flags().StringVar(&fakerAITemplate, "ai-template", "", "Predefined instruction template (use 'list' to see available templates)")
 
 	// Standalone mode flags
-	fakerCmd.Flags().BoolVar(&fakerStandalone, "standalone", false, "Run in standalone mode (no target MCP server, AI-generated tools only)")
-	fakerCmd.Flags().StringVar(&fakerID, "faker-id", "", "Unique identifier for this faker instance (required for standalone mode, e.g., 'aws-cloudwatch-faker')")
+	cmd.Flags().BoolVar(&fakerStandalone, "standalone", false, "Run in standalone mode (no target MCP server, AI-generated tools only)")
+	cmd.Flags().StringVar(&fakerID, "faker-id", "", "Unique identifier for this faker instance (required for standalone mode, e.g., 'aws-cloudwatch-faker')")
+	cmd.Flags().DurationVar(&fakerCacheTTL, "cache-ttl", 0, "Expire cached tools after this duration and regenerate them (e.g. 24h; default: never expire)")
+
+	// Chaos injection flags (env vars STN_FAKER_CHAOS_* also work)
+	cmd.Flags().StringVar(&fakerChaosFile, "chaos-file", "", "Chaos scenario file with latency/error/malformed-response injection rules (global and per-tool)")
+}
+
+func init() {
+	addFakerProxyFlags(fakerCmd)
+	addFakerProxyFlags(fakerServeCmd)
+	fakerCmd.AddCommand(fakerServeCmd)
 
 	// Note: --command is required for the proxy mode, but not for subcommands like sessions/metrics or standalone mode
 }
@@ -199,8 +244,8 @@ func runFaker(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to run database migrations: %w", err)
 		}
 
-		// Create tool cache
-		toolCache := toolcache.NewCache(database.Conn())
+		// Create tool cache (TTL of 0 keeps tools until the config changes)
+		toolCache := toolcache.NewCacheWithTTL(database.Conn(), fakerCacheTTL)
 
 		// Generate deterministic config hash from faker configuration
 		// This ensures same config always uses same cache key
@@ -231,6 +276,15 @@ func runFaker(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Chaos scenario file overrides any STN_FAKER_CHAOS_* env vars
+	if fakerChaosFile != "" {
+		chaosCfg, err := faker.LoadChaosConfig(fakerChaosFile)
+		if err != nil {
+			return fmt.Errorf("failed to load chaos scenario: %w", err)
+		}
+		f.SetChaosConfig(chaosCfg)
+	}
+
 	if err := f.Serve(); err != nil {
 		return fmt.Errorf("faker failed: %w", err)
 	}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"station/pkg/faker/toolcache"
+
+	"github.com/spf13/cobra"
+	_ "modernc.org/sqlite"
+)
+
+var fakerCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the standalone faker tool cache",
+	Long: `Manage AI-generated tools cached for standalone fakers.
+
+Cached tools are keyed by a hash of the faker's configuration (name,
+instruction, model), so changing the instruction regenerates tools on the
+next start. Use these commands to inspect what is cached and to force
+regeneration without changing the configuration.`,
+}
+
+var fakerCacheLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List cached tool sets",
+	Long: `List all cached tool sets with their faker ID, config hash, tool count,
+and last update time.
+
+Examples:
+  stn faker cache ls`,
+	RunE: runFakerCacheLs,
+}
+
+var fakerCacheClearCmd = &cobra.Command{
+	Use:   "clear [faker-id]",
+	Short: "Clear cached tools",
+	Long: `Clear cached tools for one faker (by faker ID or config hash), or the
+entire cache when no argument is given.
+
+Examples:
+  # Clear one faker's cached tools
+  stn faker cache clear aws-cloudwatch-faker
+
+  # Clear the whole cache
+  stn faker cache clear`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFakerCacheClear,
+}
+
+func init() {
+	fakerCmd.AddCommand(fakerCacheCmd)
+	fakerCacheCmd.AddCommand(fakerCacheLsCmd)
+	fakerCacheCmd.AddCommand(fakerCacheClearCmd)
+}
+
+func runFakerCacheLs(cmd *cobra.Command, args []string) error {
+	db, err := getDB()
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	defer db.Close()
+
+	cache := toolcache.NewCache(db)
+	entries, err := cache.ListEntries(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to list cache entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("Faker tool cache is empty.")
+		fmt.Println("\nTools are cached when a standalone faker (stn faker --standalone) generates them.")
+		return nil
+	}
+
+	fmt.Println("\nFAKER TOOL CACHE")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("%-45s %-7s %s\n", "FAKER ID (CONFIG HASH)", "TOOLS", "UPDATED")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	for _, entry := range entries {
+		id := entry.ConfigHash
+		if id == "" {
+			id = entry.FakerID
+		}
+		if len(id) > 43 {
+			id = id[:40] + "..."
+		}
+
+		updated := "-"
+		if !entry.UpdatedAt.IsZero() {
+			updated = entry.UpdatedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%-45s %-7d %s\n", id, entry.ToolCount, updated)
+	}
+
+	fmt.Printf("\nTotal: %d cached tool set(s)\n", len(entries))
+	fmt.Println("\nUse 'stn faker cache clear [faker-id]' to force tool regeneration.")
+	return nil
+}
+
+func runFakerCacheClear(cmd *cobra.Command, args []string) error {
+	db, err := getDB()
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	defer db.Close()
+
+	cache := toolcache.NewCache(db)
+
+	if len(args) == 1 {
+		if err := cache.ClearTools(context.Background(), args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Cleared cached tools for '%s'\n", args[0])
+		return nil
+	}
+
+	deleted, err := cache.ClearAll(context.Background())
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		fmt.Println("Faker tool cache is already empty.")
+		return nil
+	}
+	fmt.Printf("✓ Cleared %d cached tool(s)\n", deleted)
+	return nil
+}
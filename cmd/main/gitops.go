@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+var (
+	gitopsCmd = &cobra.Command{
+		Use:   "gitops",
+		Short: "GitOps environment sync commands",
+		Long:  "Sync environment configuration from a git repository so config changes go through PR review",
+	}
+
+	gitopsEnableCmd = &cobra.Command{
+		Use:   "enable",
+		Short: "Enable GitOps sync from a git repository",
+		Long: `Enable GitOps mode: serve mode periodically pulls the repository, validates
+and syncs each environment directory under --path, and records the commit SHA
+of every applied sync.
+
+EXAMPLE:
+  stn gitops enable --repo git@github.com:team/station-config --branch main --path envs/`,
+		RunE: runGitopsEnable,
+	}
+
+	gitopsDisableCmd = &cobra.Command{
+		Use:   "disable",
+		Short: "Disable GitOps sync",
+		Long:  "Disable GitOps mode; the sync history is kept",
+		RunE:  runGitopsDisable,
+	}
+
+	gitopsStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show GitOps configuration and sync history",
+		Long:  "Display the configured repository and the recorded sync history with commit SHAs",
+		RunE:  runGitopsStatus,
+	}
+
+	gitopsSyncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Pull and sync from the GitOps repository now",
+		Long:  "Run one pull + validate + sync cycle immediately instead of waiting for the serve-mode interval",
+		RunE:  runGitopsSync,
+	}
+
+	gitopsRollbackCmd = &cobra.Command{
+		Use:   "rollback <sha>",
+		Short: "Roll back environment config to a previous commit",
+		Long:  "Check out a previously synced commit SHA and re-apply its environment configuration",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runGitopsRollback,
+	}
+)
+
+// newGitOpsService builds the service with the standard local-mode boilerplate;
+// the returned cleanup closes the database
+func newGitOpsService() (*services.GitOpsService, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	repos := repositories.New(database)
+	cleanup := func() { _ = database.Close() }
+	return services.NewGitOpsService(repos, cfg), cleanup, nil
+}
+
+func runGitopsEnable(cmd *cobra.Command, args []string) error {
+	repo, _ := cmd.Flags().GetString("repo")
+	branch, _ := cmd.Flags().GetString("branch")
+	path, _ := cmd.Flags().GetString("path")
+	interval, _ := cmd.Flags().GetInt("interval")
+
+	svc, cleanup, err := newGitOpsService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := svc.Enable(services.GitOpsConfig{
+		Repo:            repo,
+		Branch:          branch,
+		Path:            path,
+		IntervalMinutes: interval,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ GitOps enabled: %s (branch %s, path %s)\n", repo, branch, path)
+	fmt.Printf("   Serve mode will pull every %d minutes; run 'stn gitops sync' to sync now\n", interval)
+	return nil
+}
+
+func runGitopsDisable(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := newGitOpsService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := svc.Disable(); err != nil {
+		return err
+	}
+	fmt.Println("✅ GitOps disabled")
+	return nil
+}
+
+func runGitopsStatus(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := newGitOpsService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	gitopsConfig, err := svc.GetConfig()
+	if err != nil {
+		return err
+	}
+	if gitopsConfig == nil {
+		fmt.Println("GitOps is not enabled (run 'stn gitops enable' to configure)")
+		return nil
+	}
+
+	fmt.Printf("Repository: %s\n", gitopsConfig.Repo)
+	fmt.Printf("Branch:     %s\n", gitopsConfig.Branch)
+	fmt.Printf("Path:       %s\n", gitopsConfig.Path)
+	fmt.Printf("Interval:   %d minutes\n", gitopsConfig.IntervalMinutes)
+
+	history, err := svc.History()
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		fmt.Println("\nNo syncs recorded yet")
+		return nil
+	}
+
+	fmt.Println("\nSync history (newest first):")
+	for _, record := range history {
+		marker := ""
+		if record.Rollback {
+			marker = " (rollback)"
+		}
+		fmt.Printf("  %s  %s  %d environments%s\n",
+			record.SHA[:min(8, len(record.SHA))],
+			record.SyncedAt.Format("2006-01-02 15:04:05"),
+			len(record.Environments), marker)
+	}
+	return nil
+}
+
+func runGitopsSync(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := newGitOpsService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	record, err := svc.SyncOnce(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Synced commit %s (%d environments)\n", record.SHA[:min(8, len(record.SHA))], len(record.Environments))
+	return nil
+}
+
+func runGitopsRollback(cmd *cobra.Command, args []string) error {
+	svc, cleanup, err := newGitOpsService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	record, err := svc.Rollback(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Rolled back to commit %s (%d environments)\n", record.SHA[:min(8, len(record.SHA))], len(record.Environments))
+	return nil
+}
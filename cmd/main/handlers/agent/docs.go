@@ -0,0 +1,86 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+// RunAgentDocs generates Markdown documentation for every agent in an
+// environment so teams can keep an agent catalog current via CI
+func (h *AgentHandler) RunAgentDocs(cmd *cobra.Command, args []string) error {
+	environment, _ := cmd.Flags().GetString("env")
+	outputDir, _ := cmd.Flags().GetString("output")
+	if outputDir == "" {
+		outputDir = "docs"
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load station config: %v", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	env, err := repos.Environments.GetByName(environment)
+	if err != nil {
+		return fmt.Errorf("environment '%s' not found: %v", environment, err)
+	}
+
+	agents, err := repos.Agents.ListByEnvironment(env.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list agents: %v", err)
+	}
+	if len(agents) == 0 {
+		fmt.Printf("No agents found in environment '%s'\n", environment)
+		return nil
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	ctx := context.Background()
+	docsService := services.NewAgentDocsService(repos)
+
+	var index strings.Builder
+	fmt.Fprintf(&index, "# Agent Catalog: %s\n\n", env.Name)
+	fmt.Fprintf(&index, "%d agent(s). Regenerate with `stn agent docs --env %s -o %s`.\n\n", len(agents), env.Name, outputDir)
+
+	for _, agent := range agents {
+		doc, err := docsService.GenerateMarkdown(ctx, agent, env.Name)
+		if err != nil {
+			return fmt.Errorf("failed to generate docs for agent '%s': %v", agent.Name, err)
+		}
+
+		filename := services.AgentDocFilename(agent.Name)
+		path := filepath.Join(outputDir, filename)
+		if err := os.WriteFile(path, []byte(doc), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+
+		fmt.Fprintf(&index, "- [%s](%s) — %s\n", agent.Name, filename, agent.Description)
+		fmt.Printf("📄 %s\n", path)
+	}
+
+	indexPath := filepath.Join(outputDir, "README.md")
+	if err := os.WriteFile(indexPath, []byte(index.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", indexPath, err)
+	}
+
+	fmt.Printf("✅ Documented %d agent(s) from '%s' in %s\n", len(agents), env.Name, outputDir)
+	return nil
+}
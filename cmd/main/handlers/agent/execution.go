@@ -1,14 +1,20 @@
 package agent
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"station/internal/config"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 	"station/internal/db"
 	"station/internal/db/repositories"
+	"station/internal/services"
 	"station/pkg/models"
 )
 
@@ -17,14 +23,77 @@ import (
 func (h *AgentHandler) RunAgentRun(cmd *cobra.Command, args []string) error {
 	tail, _ := cmd.Flags().GetBool("tail")
 	codingSession, _ := cmd.Flags().GetString("coding-session")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	profile, _ := cmd.Flags().GetString("profile")
+	plan, _ := cmd.Flags().GetBool("plan")
+	attachmentPaths, _ := cmd.Flags().GetStringArray("file")
+	citeTools, _ := cmd.Flags().GetBool("cite-tools")
+	flagValues, _ := cmd.Flags().GetStringArray("flag")
+	preferCheap, _ := cmd.Flags().GetBool("prefer-cheap")
 
-	// Validate arguments
-	if len(args) != 2 {
+	var seed *int64
+	if cmd.Flags().Changed("seed") {
+		seedValue, _ := cmd.Flags().GetInt64("seed")
+		seed = &seedValue
+	}
+
+	goldenPath, _ := cmd.Flags().GetString("assert-golden")
+	assertMode, _ := cmd.Flags().GetString("assert-mode")
+	if goldenPath != "" {
+		if err := services.ValidateGoldenAssertMode(assertMode); err != nil {
+			return err
+		}
+		if _, err := os.Stat(goldenPath); err != nil {
+			return fmt.Errorf("golden file %s not readable: %w", goldenPath, err)
+		}
+	}
+
+	flagOverrides, err := services.ParseFeatureFlagOverrides(flagValues)
+	if err != nil {
+		return err
+	}
+
+	envVarValues, _ := cmd.Flags().GetStringArray("env-var")
+	var envOverrides map[string]string
+	if len(envVarValues) > 0 {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load Station config: %w", err)
+		}
+		envOverrides, err = services.ParseRunEnvOverrides(envVarValues, cfg.RunEnvAllowlist)
+		if err != nil {
+			return err
+		}
+	}
+
+	structuredInput, err := h.parseStructuredInput(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Validate arguments - the task is optional when structured input is
+	// provided or when only previewing the run with --plan
+	if structuredInput == nil && len(args) != 2 && !(plan && len(args) == 1) {
 		return fmt.Errorf("usage: stn agent run <agent_name> <task>")
 	}
+	if structuredInput != nil && (len(args) < 1 || len(args) > 2) {
+		return fmt.Errorf("usage: stn agent run <agent_name> [task] --input-json '{...}'")
+	}
 
 	agentName := args[0]
-	task := args[1]
+	var task string
+	if len(args) == 2 {
+		task = args[1]
+	}
+	if task == "" && structuredInput != nil {
+		// Derive the task from the structured input
+		if userInput, ok := structuredInput["userInput"].(string); ok && userInput != "" {
+			task = userInput
+		} else {
+			inputJSON, _ := json.Marshal(structuredInput)
+			task = string(inputJSON)
+		}
+	}
 
 	// Local execution - delegate to the existing working function
 	agentID, err := strconv.ParseInt(agentName, 10, 64)
@@ -36,7 +105,303 @@ func (h *AgentHandler) RunAgentRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	return h.runAgentLocal(agentID, task, tail, codingSession)
+	if plan {
+		return h.runAgentPlan(agentID, task, profile)
+	}
+
+	// Deduplicate event-triggered executions: the same agent+key inside the
+	// dedup window returns the original run instead of starting a new one
+	idempotencyKey, _ := cmd.Flags().GetString("idempotency-key")
+	if idempotencyKey != "" {
+		duplicate, err := h.checkRunIdempotency(agentID, idempotencyKey)
+		if err != nil {
+			return err
+		}
+		if duplicate != nil {
+			fmt.Printf("⏭️  Duplicate request: run %d was already created for this idempotency key at %s\n", duplicate.RunID, duplicate.CreatedAt)
+			fmt.Printf("💡 Inspect it with 'stn runs inspect %d'\n", duplicate.RunID)
+			return nil
+		}
+	}
+
+	runErr := h.runAgentLocal(agentID, task, tail, timeout, structuredInput, profile, attachmentPaths, citeTools, flagOverrides, preferCheap, seed, goldenPath, assertMode, envOverrides, codingSession)
+	if runErr == nil && idempotencyKey != "" {
+		h.recordRunIdempotency(agentID, idempotencyKey, task)
+	}
+	return runErr
+}
+
+// checkRunIdempotency looks up a previous run created with the same
+// agent+key inside the dedup window
+func (h *AgentHandler) checkRunIdempotency(agentID int64, key string) (*services.RunIdempotencyRecord, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Station config: %w", err)
+	}
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	return services.NewRunIdempotencyService(repositories.New(database)).Check(agentID, key)
+}
+
+// recordRunIdempotency associates the key with the run the execution just
+// created (the newest run for the agent); best-effort so a recording failure
+// never fails the run itself
+func (h *AgentHandler) recordRunIdempotency(agentID int64, key, task string) {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	runs, err := repos.AgentRuns.GetRecentByAgent(context.Background(), agentID, 1)
+	if err != nil || len(runs) == 0 {
+		return
+	}
+	if err := services.NewRunIdempotencyService(repos).Record(agentID, key, runs[0].ID, task); err != nil {
+		fmt.Printf("⚠️  Failed to record idempotency key: %v\n", err)
+	}
+}
+
+// RunAgentResume continues an interrupted run from its last checkpoint: the
+// new run is seeded with the original task plus the checkpointed tool call
+// results so completed work (and its token cost) is not repeated
+func (h *AgentHandler) RunAgentResume(cmd *cobra.Command, args []string) error {
+	runID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid run ID: %s", args[0])
+	}
+
+	tail, _ := cmd.Flags().GetBool("tail")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	repos := repositories.New(database)
+
+	run, err := repos.AgentRuns.GetByID(context.Background(), runID)
+	if err != nil {
+		_ = database.Close()
+		return fmt.Errorf("run %d not found: %w", runID, err)
+	}
+	if run.Status == "completed" {
+		_ = database.Close()
+		return fmt.Errorf("run %d completed successfully; nothing to resume", runID)
+	}
+
+	checkpoint, err := services.NewRunCheckpointService(repos).GetCheckpoint(runID)
+	if err != nil {
+		_ = database.Close()
+		return err
+	}
+	_ = database.Close()
+
+	if checkpoint != nil && len(checkpoint.ToolCalls) > 0 {
+		fmt.Printf("🔁 Resuming run %d from checkpoint: %d completed tool call(s) carried over\n", runID, len(checkpoint.ToolCalls))
+	} else {
+		fmt.Printf("🔁 Run %d has no checkpoint; re-running the original task from the start\n", runID)
+	}
+
+	resumeTask := services.BuildResumeTask(run.Task, checkpoint)
+	return h.runAgentLocal(run.AgentID, resumeTask, tail, timeout, nil, "", nil, false, nil, false, nil, "", "", nil)
+}
+
+// runAgentPlan performs only the planning phase of a run: the composed
+// system prompt, the resolved tool list, model/profile, and an estimated
+// cost range from recent run history — without executing anything
+func (h *AgentHandler) runAgentPlan(agentID int64, task, profileName string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+
+	agent, err := repos.Agents.GetByID(agentID)
+	if err != nil {
+		return fmt.Errorf("agent with ID %d not found: %w", agentID, err)
+	}
+	env, err := repos.Environments.GetByID(agent.EnvironmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get environment for agent %s: %w", agent.Name, err)
+	}
+
+	fmt.Printf("🔍 Run Plan for agent '%s' (no execution)\n\n", agent.Name)
+	fmt.Printf("Task:        %s\n", task)
+	fmt.Printf("Environment: %s\n", env.Name)
+	fmt.Printf("Model:       %s/%s\n", cfg.AIProvider, cfg.AIModel)
+	fmt.Printf("Max Steps:   %d\n", agent.MaxSteps)
+
+	// Resolve the execution profile the run would use (--profile override,
+	// otherwise the agent's `profile:` frontmatter)
+	if profileName == "" {
+		profileName = planProfileFromPromptFile(env.Name, agent.Name)
+	}
+	if profileName != "" {
+		profile, err := services.ResolveExecutionProfile(cfg, profileName)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Profile:     %s", profileName)
+		if profile.Temperature != nil {
+			fmt.Printf(" temperature=%v", *profile.Temperature)
+		}
+		if profile.TopP != nil {
+			fmt.Printf(" top_p=%v", *profile.TopP)
+		}
+		if profile.MaxTurns > 0 {
+			fmt.Printf(" max_turns=%d", profile.MaxTurns)
+		}
+		if profile.ToolChoice != "" {
+			fmt.Printf(" tool_choice=%s", profile.ToolChoice)
+		}
+		if profile.Seed != nil {
+			fmt.Printf(" seed=%d", *profile.Seed)
+		}
+		fmt.Println()
+	}
+
+	tools, err := repos.AgentTools.ListAgentTools(agent.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get agent tools: %w", err)
+	}
+	annotations, err := services.NewToolAnnotationService(repos).GetEnvironmentAnnotations(agent.EnvironmentID)
+	if err != nil {
+		annotations = nil
+	}
+	fmt.Printf("\n🛠 Tools (%d):\n", len(tools))
+	for _, tool := range tools {
+		fmt.Printf("  • %s", tool.ToolName)
+		if tool.ToolDescription != "" {
+			fmt.Printf(" — %s", firstLine(tool.ToolDescription))
+		}
+		if annotation, ok := annotations[tool.ToolName]; ok {
+			if formatted := services.FormatToolAnnotation(annotation); formatted != "" {
+				fmt.Printf(" [%s]", formatted)
+			}
+		}
+		fmt.Println()
+	}
+
+	printPlanCostEstimate(repos, agent.ID)
+
+	fmt.Printf("\n📝 System Prompt:\n%s\n", agent.Prompt)
+	return nil
+}
+
+// planProfileFromPromptFile reads the `profile:` frontmatter field from the
+// agent's .prompt file; empty when unset or unreadable
+func planProfileFromPromptFile(envName, agentName string) string {
+	content, err := os.ReadFile(config.GetAgentPromptPath(envName, agentName))
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(string(content), "---")
+	if len(parts) < 3 {
+		return ""
+	}
+	var fm struct {
+		Profile string `yaml:"profile"`
+	}
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(parts[1])), &fm); err != nil {
+		return ""
+	}
+	return fm.Profile
+}
+
+// printPlanCostEstimate derives a cost range from the token usage of recent
+// completed runs using the platform's standard per-token heuristic
+func printPlanCostEstimate(repos *repositories.Repositories, agentID int64) {
+	runs, err := repos.AgentRuns.GetRecentByAgent(context.Background(), agentID, 20)
+	if err != nil {
+		return
+	}
+
+	var minTokens, maxTokens, totalTokens, samples int64
+	for _, run := range runs {
+		if !run.TotalTokens.Valid || run.TotalTokens.Int64 <= 0 {
+			continue
+		}
+		tokens := run.TotalTokens.Int64
+		if samples == 0 || tokens < minTokens {
+			minTokens = tokens
+		}
+		if tokens > maxTokens {
+			maxTokens = tokens
+		}
+		totalTokens += tokens
+		samples++
+	}
+
+	if samples == 0 {
+		fmt.Printf("\n💰 Estimated cost: no run history yet for this agent\n")
+		return
+	}
+
+	fmt.Printf("\n💰 Estimated cost (from last %d runs): $%.4f - $%.4f (avg $%.4f)\n",
+		samples,
+		services.EstimateRunCostUSD(minTokens),
+		services.EstimateRunCostUSD(maxTokens),
+		services.EstimateRunCostUSD(totalTokens/samples))
+}
+
+// firstLine truncates a tool description to its first line for the plan view
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
+
+// parseStructuredInput reads the --input-json/--input-file flags into a map.
+// Returns nil when neither flag is set.
+func (h *AgentHandler) parseStructuredInput(cmd *cobra.Command) (map[string]interface{}, error) {
+	inputJSON, _ := cmd.Flags().GetString("input-json")
+	inputFile, _ := cmd.Flags().GetString("input-file")
+
+	if inputJSON != "" && inputFile != "" {
+		return nil, fmt.Errorf("--input-json and --input-file are mutually exclusive")
+	}
+	if inputJSON == "" && inputFile == "" {
+		return nil, nil
+	}
+
+	raw := []byte(inputJSON)
+	if inputFile != "" {
+		data, err := os.ReadFile(inputFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input file %s: %w", inputFile, err)
+		}
+		raw = data
+	}
+
+	var input map[string]interface{}
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return nil, fmt.Errorf("input must be a JSON object: %w", err)
+	}
+	return input, nil
 }
 
 // displayExecutionResults shows the results of an agent execution
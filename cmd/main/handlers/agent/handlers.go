@@ -34,13 +34,29 @@ func NewAgentHandler(themeManager *theme.ThemeManager, telemetryService *telemet
 // RunAgentList lists all agents
 func (h *AgentHandler) RunAgentList(cmd *cobra.Command, args []string) error {
 	startTime := time.Now()
+	environment, _ := cmd.Flags().GetString("environment")
+
+	// Machine formats emit only the rows, no banners or styling
+	format := common.OutputFormat(cmd)
+	if common.MachineFormat(format) {
+		return h.listAgentsMachine(environment, format)
+	}
+
 	styles := common.GetCLIStyles(h.themeManager)
 	banner := styles.Banner.Render("🤖 Agents")
 	fmt.Println(banner)
 
-	environment, _ := cmd.Flags().GetString("environment")
 	err := h.listAgentsLocalWithFilter(environment)
 
+	// Aggregate remote stations when requested (stn remote add <name> <endpoint>)
+	if err == nil {
+		remoteName, _ := cmd.Flags().GetString("remote")
+		allRemotes, _ := cmd.Flags().GetBool("all-remotes")
+		if remoteName != "" || allRemotes {
+			err = h.listAgentsFromRemotes(remoteName, allRemotes)
+		}
+	}
+
 	// Track telemetry
 	if h.telemetryService != nil {
 		h.telemetryService.TrackCLICommand("agent", "list", err == nil, time.Since(startTime).Milliseconds())
@@ -49,6 +65,45 @@ func (h *AgentHandler) RunAgentList(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// listAgentsFromRemotes fetches agents from configured remote stations and
+// prints them after the local rows, labeled with the remote's name.
+func (h *AgentHandler) listAgentsFromRemotes(remoteName string, allRemotes bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	remotes, err := common.ResolveRemotes(cfg, remoteName, allRemotes)
+	if err != nil {
+		return err
+	}
+
+	styles := common.GetCLIStyles(h.themeManager)
+	for _, name := range common.SortedRemoteNames(remotes) {
+		fmt.Printf("\n🌐 Remote '%s' (%s):\n", name, remotes[name].Endpoint)
+
+		agents, err := common.FetchRemoteAgents(name, remotes[name])
+		if err != nil {
+			fmt.Printf("• ⚠️  %v\n", err)
+			continue
+		}
+		if len(agents) == 0 {
+			fmt.Println("• No agents found")
+			continue
+		}
+
+		for _, agent := range agents {
+			fmt.Printf("• %s (ID: %d)", styles.Success.Render(agent.Name), agent.ID)
+			if agent.Description != "" {
+				fmt.Printf(" - %s", agent.Description)
+			}
+			fmt.Printf(" [Origin: %s, Max Steps: %d]\n", agent.Remote, agent.MaxSteps)
+		}
+	}
+
+	return nil
+}
+
 // RunAgentShow shows details of a specific agent
 func (h *AgentHandler) RunAgentShow(cmd *cobra.Command, args []string) error {
 	environment, _ := cmd.Flags().GetString("environment")
@@ -136,10 +191,38 @@ func (h *AgentHandler) showAgentLocalByName(agentName, environment string) error
 		}
 	}
 
+	// Display SLO compliance when an SLO is defined for this agent
+	sloMonitor := services.NewSLOMonitorService(repos, cfg)
+	if slo, err := sloMonitor.GetAgentSLO(targetAgent.ID); err == nil && slo != nil {
+		if status, err := sloMonitor.EvaluateAgent(context.Background(), targetAgent.ID, slo); err == nil {
+			fmt.Printf("\n🎯 SLO Compliance (last %d runs):\n", status.RunsAnalyzed)
+			if slo.P95LatencySeconds > 0 {
+				fmt.Printf("  %s p95 latency: %.1fs (target < %.0fs)\n",
+					sloCheckmark(status.P95LatencySeconds <= slo.P95LatencySeconds), status.P95LatencySeconds, slo.P95LatencySeconds)
+			}
+			if slo.ErrorRatePercent > 0 {
+				fmt.Printf("  %s error rate: %.1f%% (target < %.1f%%)\n",
+					sloCheckmark(status.ErrorRatePercent <= slo.ErrorRatePercent), status.ErrorRatePercent, slo.ErrorRatePercent)
+			}
+			if slo.DailyCostUSD > 0 {
+				fmt.Printf("  %s daily cost: $%.2f (target < $%.2f)\n",
+					sloCheckmark(status.DailyCostUSD <= slo.DailyCostUSD), status.DailyCostUSD, slo.DailyCostUSD)
+			}
+		}
+	}
+
 	fmt.Printf("\n📝 Prompt:\n%s\n", targetAgent.Prompt)
 	return nil
 }
 
+// sloCheckmark renders a pass/fail marker for SLO compliance lines
+func sloCheckmark(ok bool) string {
+	if ok {
+		return "✅"
+	}
+	return "❌"
+}
+
 // RunAgentDelete deletes an agent
 func (h *AgentHandler) RunAgentDelete(cmd *cobra.Command, args []string) error {
 	environment, _ := cmd.Flags().GetString("environment")
@@ -228,7 +311,79 @@ func (h *AgentHandler) deleteAgentLocalByName(agentName, environment string) err
 		})
 	}
 
-	fmt.Printf("✅ Agent '%s' deleted successfully\n", targetAgent.Name)
+	fmt.Printf("✅ Agent '%s' deleted (recoverable with 'stn agent restore %s' until purged)\n", targetAgent.Name, targetAgent.Name)
+	return nil
+}
+
+// RunAgentRestore restores a soft-deleted agent by name
+func (h *AgentHandler) RunAgentRestore(cmd *cobra.Command, args []string) error {
+	environment, _ := cmd.Flags().GetString("environment")
+
+	if len(args) != 1 {
+		return fmt.Errorf("usage: stn agent restore <agent_name>")
+	}
+
+	return h.restoreAgentLocalByName(args[0], environment)
+}
+
+// restoreAgentLocalByName restores a soft-deleted agent by name and environment
+func (h *AgentHandler) restoreAgentLocalByName(agentName, environment string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load station config: %v", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	agentService := services.NewAgentService(repos)
+	ctx := context.Background()
+
+	deleted, err := repos.Agents.ListDeleted(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list deleted agents: %v", err)
+	}
+
+	var target *repositories.SoftDeletedAgent
+	for i := range deleted {
+		agent := deleted[i]
+		if agent.Name != agentName {
+			continue
+		}
+		if environment != "" {
+			env, err := repos.Environments.GetByID(agent.EnvironmentID)
+			if err != nil || env.Name != environment {
+				continue
+			}
+		}
+		target = &deleted[i]
+		break
+	}
+
+	if target == nil {
+		if len(deleted) == 0 {
+			return fmt.Errorf("no deleted agent named '%s' found", agentName)
+		}
+		fmt.Println("Deleted agents available for restore:")
+		for _, agent := range deleted {
+			envName := "unknown"
+			if env, err := repos.Environments.GetByID(agent.EnvironmentID); err == nil {
+				envName = env.Name
+			}
+			fmt.Printf("  %s (env: %s, deleted: %s)\n", agent.Name, envName, agent.DeletedAt.Format("2006-01-02 15:04"))
+		}
+		return fmt.Errorf("no deleted agent named '%s' found", agentName)
+	}
+
+	if err := agentService.RestoreAgent(ctx, target.ID); err != nil {
+		return fmt.Errorf("failed to restore agent: %v", err)
+	}
+
+	fmt.Printf("✅ Agent '%s' restored\n", agentName)
 	return nil
 }
 
@@ -301,6 +456,16 @@ func (h *AgentHandler) RunAgentCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("environment '%s' not found: %v", environment, err)
 	}
 
+	// Local Ollama models without function-calling support silently fail to
+	// use MCP tools — warn up front instead of at first execution
+	if services.IsOllamaConfigured(cfg) {
+		ollama := services.NewOllamaService(cfg)
+		if supports, err := ollama.ModelSupportsTools(ctx, cfg.AIModel); err == nil && !supports {
+			fmt.Printf("⚠️  Configured model '%s' does not advertise tool calling — this agent will not be able to use MCP tools\n", cfg.AIModel)
+			fmt.Printf("   See 'stn models list' for tool-calling compatible models\n")
+		}
+	}
+
 	var toolNames []string
 	if toolsStr != "" {
 		toolNames = strings.Split(toolsStr, ",")
@@ -9,12 +9,15 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sort"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/google/uuid"
+	"station/cmd/main/handlers/common"
 	"station/internal/config"
 	"station/internal/db"
 	"station/internal/db/repositories"
@@ -22,6 +25,7 @@ import (
 	"station/internal/services"
 	"station/internal/theme"
 	"station/pkg/models"
+	"station/pkg/schema"
 	"station/pkg/types"
 )
 
@@ -197,24 +201,28 @@ func (h *AgentHandler) listAgentsLocal() error {
 	return nil
 }
 
-func (h *AgentHandler) listAgentsLocalWithFilter(envFilter string) error {
+// loadAgentsWithFilter loads agents (optionally filtered by environment ID
+// or name) along with the environment-ID-to-name map for display and the
+// set of agents currently failing their heartbeat probe
+func (h *AgentHandler) loadAgentsWithFilter(envFilter string) ([]*models.Agent, map[int64]string, map[int64]string, error) {
 	cfg, err := config.Load()
 	if err != nil {
-		return fmt.Errorf("failed to load Station config: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to load Station config: %w", err)
 	}
 
 	database, err := db.New(cfg.DatabaseURL)
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 	defer func() { _ = database.Close() }()
 
 	repos := repositories.New(database)
+	unhealthy := services.UnhealthyAgents(repos)
 
 	// Get all agents
 	agents, err := repos.Agents.List()
 	if err != nil {
-		return fmt.Errorf("failed to list agents: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to list agents: %w", err)
 	}
 
 	// Get environment names for filtering and display
@@ -241,7 +249,7 @@ func (h *AgentHandler) listAgentsLocalWithFilter(envFilter string) error {
 			// Try as environment name
 			targetEnvID = envID
 		} else {
-			return fmt.Errorf("environment '%s' not found", envFilter)
+			return nil, nil, nil, fmt.Errorf("environment '%s' not found", envFilter)
 		}
 
 		// Filter agents by environment
@@ -254,6 +262,15 @@ func (h *AgentHandler) listAgentsLocalWithFilter(envFilter string) error {
 		filteredAgents = agents
 	}
 
+	return filteredAgents, environments, unhealthy, nil
+}
+
+func (h *AgentHandler) listAgentsLocalWithFilter(envFilter string) error {
+	filteredAgents, environments, unhealthy, err := h.loadAgentsWithFilter(envFilter)
+	if err != nil {
+		return err
+	}
+
 	if len(filteredAgents) == 0 {
 		if envFilter != "" {
 			fmt.Printf("• No agents found in environment '%s'\n", envFilter)
@@ -277,6 +294,9 @@ func (h *AgentHandler) listAgentsLocalWithFilter(envFilter string) error {
 		}
 
 		fmt.Printf("• %s (ID: %d)", styles.Success.Render(agent.Name), agent.ID)
+		if _, bad := unhealthy[agent.ID]; bad {
+			fmt.Printf(" %s", styles.Error.Render("⚠ UNHEALTHY"))
+		}
 		if agent.Description != "" {
 			fmt.Printf(" - %s", agent.Description)
 		}
@@ -286,6 +306,37 @@ func (h *AgentHandler) listAgentsLocalWithFilter(envFilter string) error {
 	return nil
 }
 
+// listAgentsMachine emits agents with a stable column set for --output
+// json/yaml/csv consumers
+func (h *AgentHandler) listAgentsMachine(envFilter, format string) error {
+	filteredAgents, environments, unhealthy, err := h.loadAgentsWithFilter(envFilter)
+	if err != nil {
+		return err
+	}
+
+	columns := []string{"id", "name", "description", "environment", "max_steps", "health"}
+	rows := make([][]string, 0, len(filteredAgents))
+	for _, agent := range filteredAgents {
+		envName := environments[agent.EnvironmentID]
+		if envName == "" {
+			envName = fmt.Sprintf("%d", agent.EnvironmentID)
+		}
+		health := "healthy"
+		if _, bad := unhealthy[agent.ID]; bad {
+			health = "unhealthy"
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", agent.ID),
+			agent.Name,
+			agent.Description,
+			envName,
+			fmt.Sprintf("%d", agent.MaxSteps),
+			health,
+		})
+	}
+	return common.RenderList(format, columns, rows)
+}
+
 func (h *AgentHandler) showAgentLocal(agentID int64) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -331,7 +382,7 @@ func (h *AgentHandler) showAgentLocal(agentID int64) error {
 	return nil
 }
 
-func (h *AgentHandler) runAgentLocal(agentID int64, task string, tail bool, codingSession ...string) error {
+func (h *AgentHandler) runAgentLocal(agentID int64, task string, tail bool, timeout time.Duration, structuredInput map[string]interface{}, profile string, attachmentPaths []string, citeTools bool, flagOverrides map[string]bool, preferCheap bool, seed *int64, goldenPath string, assertMode string, envOverrides map[string]string, codingSession ...string) error {
 	styles := getCLIStyles(h.themeManager)
 
 	// Load configuration and connect to database (including environment variables)
@@ -358,24 +409,56 @@ func (h *AgentHandler) runAgentLocal(agentID int64, task string, tail bool, codi
 		return fmt.Errorf("agent with ID %d not found: %w", agentID, err)
 	}
 
+	// Validate structured input against the agent's declared input schema
+	if structuredInput != nil {
+		if agent.InputSchema != nil && *agent.InputSchema != "" {
+			if _, err := schema.NewExportHelper().GetMergedInputData(agent, task, structuredInput); err != nil {
+				database.Close()
+				return fmt.Errorf("invalid input for agent %s: %w", agent.Name, err)
+			}
+		}
+	}
+
+	// Prepare file attachments before execution: text files become task
+	// context, binaries become input artifacts stored once the run ID exists
+	var attachments []*services.RunAttachment
+	if len(attachmentPaths) > 0 {
+		attachments, err = services.NewRunAttachmentService(repos).PrepareAttachments(attachmentPaths)
+		if err != nil {
+			database.Close()
+			return err
+		}
+		fmt.Printf("📎 Attached %d file(s)\n", len(attachments))
+	}
+
+	if seed != nil {
+		fmt.Printf("🎲 Generation seed pinned to %d\n", *seed)
+	}
+
 	fmt.Printf("📋 Task: %s\n", styles.Info.Render(task))
 
 	// Close database connection before trying server execution to avoid locks
 	database.Close()
 
-	// Try server first, fall back to stdio MCP self-bootstrapping execution
-	if h.tryServerExecution(agentID, task, tail, cfg) == nil {
-		return nil
-	}
+	// Try server first, fall back to stdio MCP self-bootstrapping execution.
+	// Structured input, profile overrides, attachments, citation enforcement,
+	// feature flag overrides, the prefer-cheap planner hint, seed pinning,
+	// golden assertions, and per-run env vars are only supported by local
+	// execution since the queue API accepts a plain task string.
+	if structuredInput == nil && profile == "" && len(attachments) == 0 && !citeTools && len(flagOverrides) == 0 && !preferCheap && seed == nil && goldenPath == "" && len(envOverrides) == 0 {
+		if h.tryServerExecution(agentID, task, tail, cfg) == nil {
+			return nil
+		}
 
-	// Server not available, use self-bootstrapping stdio MCP execution
-	fmt.Printf("💡 Server not available, using self-bootstrapping stdio MCP execution\n\n")
+		// Server not available, use self-bootstrapping stdio MCP execution
+		fmt.Printf("💡 Server not available, using self-bootstrapping stdio MCP execution\n\n")
+	}
 
 	var sessionID string
 	if len(codingSession) > 0 {
 		sessionID = codingSession[0]
 	}
-	return h.runAgentWithStdioMCP(agentID, task, tail, cfg, agent, sessionID)
+	return h.runAgentWithStdioMCP(agentID, task, tail, timeout, structuredInput, profile, attachments, citeTools, flagOverrides, preferCheap, seed, goldenPath, assertMode, envOverrides, cfg, agent, sessionID)
 }
 
 func (h *AgentHandler) deleteAgentLocal(agentID int64) error {
@@ -446,7 +529,7 @@ func (h *AgentHandler) tryServerExecution(agentID int64, task string, tail bool,
 	}
 }
 
-func (h *AgentHandler) runAgentWithStdioMCP(agentID int64, task string, tail bool, cfg *config.Config, agent *models.Agent, codingSessionID string) error {
+func (h *AgentHandler) runAgentWithStdioMCP(agentID int64, task string, tail bool, timeout time.Duration, structuredInput map[string]interface{}, profile string, attachments []*services.RunAttachment, citeTools bool, flagOverrides map[string]bool, preferCheap bool, seed *int64, goldenPath string, assertMode string, envOverrides map[string]string, cfg *config.Config, agent *models.Agent, codingSessionID string) error {
 	// Create execution context
 	ctx := context.Background()
 
@@ -533,6 +616,20 @@ func (h *AgentHandler) runAgentWithStdioMCP(agentID int64, task string, tail boo
 		return fmt.Errorf("failed to create agent run record: %w", err)
 	}
 
+	// Store binary attachments as input artifacts now that the run ID exists,
+	// inject the attachment context into the task, and record the metadata
+	// for 'stn runs inspect'
+	if len(attachments) > 0 {
+		attachmentService := services.NewRunAttachmentService(repos)
+		if err := attachmentService.StoreArtifacts(agentRun.ID, attachments); err != nil {
+			return err
+		}
+		task = services.AttachmentTaskContext(task, attachments)
+		if err := attachmentService.RecordRunAttachments(agentRun.ID, attachments); err != nil {
+			fmt.Printf("⚠️  Failed to record attachment metadata: %v\n", err)
+		}
+	}
+
 	// Handle interruption signals in goroutine
 	go func() {
 		sig := <-sigChan
@@ -577,12 +674,31 @@ func (h *AgentHandler) runAgentWithStdioMCP(agentID int64, task string, tail boo
 	fmt.Printf("🤖 Executing agent using self-bootstrapping architecture...\n")
 
 	variables := make(map[string]interface{})
+	for k, v := range structuredInput {
+		if k != "userInput" {
+			variables[k] = v
+		}
+	}
 	if codingSessionID != "" {
 		variables["coding_session_id"] = codingSessionID
 		fmt.Printf("🔗 Using existing coding session: %s\n", codingSessionID)
 	}
 
-	result, err := agentService.GetExecutionEngine().Execute(ctx, agent, task, agentRun.ID, variables)
+	featureFlags := services.NewFeatureFlagService(repos).Resolve(flagOverrides)
+	if enabledFlags := featureFlags.Names(); len(enabledFlags) > 0 {
+		fmt.Printf("🚩 Feature flags: %s\n", strings.Join(enabledFlags, ", "))
+	}
+
+	if len(envOverrides) > 0 {
+		names := make([]string, 0, len(envOverrides))
+		for name := range envOverrides {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("🔧 Per-run env vars: %s\n", strings.Join(names, ", "))
+	}
+
+	result, err := agentService.GetExecutionEngine().ExecuteWithOptions(ctx, agent, task, agentRun.ID, variables, services.ExecutionOptions{Timeout: timeout, Profile: profile, RequireCitations: citeTools, FeatureFlags: featureFlags, PreferCheapTools: preferCheap, Seed: seed, EnvOverrides: envOverrides})
 	if err != nil {
 		// Store original error before it gets overwritten
 		originalErr := err
@@ -598,7 +714,7 @@ func (h *AgentHandler) runAgentWithStdioMCP(agentID int64, task string, tail boo
 			0,   // steps_taken
 			nil, // tool_calls
 			nil, // execution_steps
-			"failed",
+			services.ClassifyRunFailure(originalErr),
 			&completedAt,
 			nil,       // inputTokens
 			nil,       // outputTokens
@@ -881,7 +997,23 @@ func (h *AgentHandler) runAgentWithStdioMCP(agentID int64, task string, tail boo
 	executionCompleted = true
 
 	fmt.Printf("✅ Agent execution completed via stdio MCP!\n")
-	return h.displayExecutionResults(updatedRun)
+	if err := h.displayExecutionResults(updatedRun); err != nil {
+		return err
+	}
+
+	// Compare the final response against the checked-in golden expectation;
+	// a mismatch exits non-zero so CI treats the run as a failed test step
+	if goldenPath != "" {
+		if !result.Success {
+			return fmt.Errorf("golden assertion failed: run %d did not complete successfully", agentRun.ID)
+		}
+		if err := services.AssertGolden(ctx, cfg, goldenPath, assertMode, result.Response); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return err
+		}
+		fmt.Printf("✅ Golden assertion passed (%s mode, %s)\n", assertMode, goldenPath)
+	}
+	return nil
 }
 
 // queueAgentExecution calls the API to queue an agent execution
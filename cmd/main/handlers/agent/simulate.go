@@ -0,0 +1,162 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+// RunAgentSimulateTools runs an agent with simulated tool backends and
+// reports which tools it attempted to call and with what argument shapes
+func (h *AgentHandler) RunAgentSimulateTools(cmd *cobra.Command, args []string) error {
+	task, _ := cmd.Flags().GetString("task")
+	if task == "" {
+		return fmt.Errorf("--task is required")
+	}
+	outputJSON, _ := cmd.Flags().GetBool("json")
+
+	agentName := args[0]
+	agentID, err := strconv.ParseInt(agentName, 10, 64)
+	if err != nil {
+		agentID, err = h.findAgentByName(agentName, cmd)
+		if err != nil {
+			return fmt.Errorf("failed to find agent '%s': %v", agentName, err)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+	if err := cfg.LoadSecretsFromBackend(); err != nil {
+		return fmt.Errorf("failed to load secrets from backend: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	agent, err := repos.Agents.GetByID(agentID)
+	if err != nil {
+		return fmt.Errorf("agent with ID %d not found: %w", agentID, err)
+	}
+
+	styles := getCLIStyles(h.themeManager)
+	if !outputJSON {
+		banner := styles.Banner.Render("🧪 Tool Usage Simulation")
+		fmt.Println(banner)
+		fmt.Printf("🤖 Agent: %s\n", styles.Success.Render(agent.Name))
+		fmt.Printf("📋 Task: %s\n", styles.Info.Render(task))
+		fmt.Printf("💡 All tools are simulated — no real systems will be contacted\n\n")
+	}
+
+	report, err := services.NewToolSimulationService(repos).SimulateTools(context.Background(), agent, task)
+	if err != nil {
+		return fmt.Errorf("simulation failed: %w", err)
+	}
+
+	if outputJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	return h.displaySimulationReport(report)
+}
+
+// displaySimulationReport renders the simulation report for terminal use
+func (h *AgentHandler) displaySimulationReport(report *services.ToolSimulationReport) error {
+	styles := getCLIStyles(h.themeManager)
+
+	if report.Error != "" {
+		fmt.Printf("⚠️  Simulation finished with an error: %s\n\n", styles.Error.Render(report.Error))
+	}
+
+	fmt.Printf("🛠 Assigned tools: %d\n", len(report.AssignedTools))
+	fmt.Printf("📞 Tool calls attempted: %d\n\n", len(report.Calls))
+
+	if len(report.Calls) > 0 {
+		// Group observed argument shapes per tool so repeated calls with the
+		// same structure collapse into one line
+		shapesByTool := make(map[string]map[string]bool)
+		for _, call := range report.Calls {
+			if shapesByTool[call.ToolName] == nil {
+				shapesByTool[call.ToolName] = make(map[string]bool)
+			}
+			shapesByTool[call.ToolName][formatArgumentShape(call.ArgumentShape)] = true
+		}
+
+		toolNames := make([]string, 0, len(report.CallCounts))
+		for name := range report.CallCounts {
+			toolNames = append(toolNames, name)
+		}
+		sort.Strings(toolNames)
+
+		fmt.Println("Tools called:")
+		for _, name := range toolNames {
+			fmt.Printf("  • %s (%d call(s))\n", styles.Success.Render(name), report.CallCounts[name])
+			shapes := make([]string, 0, len(shapesByTool[name]))
+			for shape := range shapesByTool[name] {
+				shapes = append(shapes, shape)
+			}
+			sort.Strings(shapes)
+			for _, shape := range shapes {
+				fmt.Printf("    args: %s\n", shape)
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(report.UnusedTools) > 0 {
+		fmt.Println("Assigned but never called:")
+		for _, name := range report.UnusedTools {
+			fmt.Printf("  • %s\n", name)
+		}
+		fmt.Println()
+		fmt.Printf("💡 Unused tools may be unnecessary for this workload — consider trimming the toolset\n")
+	} else if len(report.Calls) > 0 {
+		fmt.Printf("✅ Every assigned tool was used for this task\n")
+	}
+
+	if len(report.Calls) == 0 {
+		fmt.Printf("💡 The agent completed without calling any tools — the toolset may be unnecessary for this task\n")
+	}
+
+	fmt.Printf("\n⏱  Simulation duration: %.1fs\n", report.Duration.Seconds())
+	return nil
+}
+
+// formatArgumentShape renders an argument shape map as a stable single line
+func formatArgumentShape(shape map[string]string) string {
+	if len(shape) == 0 {
+		return "{}"
+	}
+	keys := make([]string, 0, len(shape))
+	for key := range shape {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	line := "{"
+	for i, key := range keys {
+		if i > 0 {
+			line += ", "
+		}
+		line += fmt.Sprintf("%s: %s", key, shape[key])
+	}
+	return line + "}"
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
+	"station/cmd/main/handlers/common"
 	"station/internal/config"
 	"station/internal/db"
 	"station/internal/db/repositories"
@@ -41,13 +43,23 @@ func (h *BenchmarkHandler) RunBenchmarkEvaluate(cmd *cobra.Command, args []strin
 	}
 
 	verbose, _ := cmd.Flags().GetBool("verbose")
+	suitePath, _ := cmd.Flags().GetString("suite")
+	heuristicMetrics, _ := cmd.Flags().GetString("heuristic")
 
 	styles := getCLIStyles(h.themeManager)
 	banner := styles.Banner.Render("🎯 Benchmark Evaluation")
 	fmt.Println(banner)
 
+	// Sync suite tasks into the database first so evaluation uses the
+	// suite's current criteria and weights
+	if suitePath != "" {
+		if err := h.RunBenchmarkImport(cmd, []string{suitePath}); err != nil {
+			return err
+		}
+	}
+
 	fmt.Println(styles.Info.Render("📊 Evaluating run using LLM-as-judge metrics..."))
-	return h.evaluateRunLocal(runID, verbose)
+	return h.evaluateRunLocal(runID, verbose, heuristicMetrics, common.OutputFormat(cmd))
 }
 
 // RunBenchmarkList lists benchmark results
@@ -80,7 +92,7 @@ func (h *BenchmarkHandler) RunBenchmarkTasks(cmd *cobra.Command, args []string)
 
 // Local operations
 
-func (h *BenchmarkHandler) evaluateRunLocal(runID int64, verbose bool) error {
+func (h *BenchmarkHandler) evaluateRunLocal(runID int64, verbose bool, heuristicMetrics, outputFormat string) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load Station config: %w", err)
@@ -114,11 +126,31 @@ func (h *BenchmarkHandler) evaluateRunLocal(runID int64, verbose bool) error {
 		analyzer = pkgbenchmark.NewAnalyzer(database.Conn(), judge)
 	}
 
-	// Evaluate the run
+	// Switch selected metrics to the GPU-free heuristic judge (local
+	// embedding similarity) — cheap approximations for CI runs
+	if heuristicMetrics != "" {
+		metrics := strings.Split(heuristicMetrics, ",")
+		if heuristicMetrics == "all" {
+			metrics = []string{pkgbenchmark.MetricRelevancy, pkgbenchmark.MetricFaithfulness}
+		}
+		for _, metric := range metrics {
+			metric = strings.TrimSpace(metric)
+			if err := analyzer.SetMetricMode(metric, pkgbenchmark.ModeHeuristic); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Using heuristic (embedding) evaluation for %s\n", metric)
+		}
+	}
+
+	// Evaluate the run with per-metric progress
 	styles := getCLIStyles(h.themeManager)
 	fmt.Printf("🔍 Loading run %d...\n", runID)
 
+	progress := common.NewProgressTracker("Evaluating metrics", outputFormat)
+	analyzer.SetProgress(progress.Update)
+
 	result, err := analyzer.EvaluateRun(context.Background(), runID)
+	progress.Done()
 	if err != nil {
 		return fmt.Errorf("evaluation failed: %w", err)
 	}
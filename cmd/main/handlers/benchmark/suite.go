@@ -0,0 +1,168 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/repositories"
+	pkgbenchmark "station/pkg/benchmark"
+)
+
+// RunBenchmarkImport imports benchmark tasks from a suite file (YAML/JSON)
+func (h *BenchmarkHandler) RunBenchmarkImport(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("suite file path is required")
+	}
+	suitePath := args[0]
+
+	styles := getCLIStyles(h.themeManager)
+	banner := styles.Banner.Render("📥 Import Benchmark Suite")
+	fmt.Println(banner)
+
+	suite, err := pkgbenchmark.LoadSuiteFile(suitePath)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+
+	created := 0
+	updated := 0
+	for _, task := range suite.Tasks {
+		weights := task.Weights
+		if weights == (pkgbenchmark.SuiteWeights{}) {
+			weights = pkgbenchmark.DefaultWeights
+		}
+
+		wasCreated, err := repos.BenchmarkTasks.Upsert(context.Background(), &repositories.BenchmarkTask{
+			Name:                  task.Name,
+			Category:              task.Category,
+			Description:           task.Description,
+			ExpectedOutputExample: task.ExpectedOutputExample,
+			EvaluationCriteria:    task.EvaluationCriteria,
+			TaskCompletionWeight:  weights.TaskCompletion,
+			RelevancyWeight:       weights.Relevancy,
+			HallucinationWeight:   weights.Hallucination,
+			FaithfulnessWeight:    weights.Faithfulness,
+			ToxicityWeight:        weights.Toxicity,
+		})
+		if err != nil {
+			return err
+		}
+		if wasCreated {
+			created++
+		} else {
+			updated++
+		}
+	}
+
+	fmt.Printf("✅ Suite '%s' imported: %d task(s) created, %d updated\n", suite.Name, created, updated)
+	fmt.Println("\n💡 Use 'stn benchmark tasks' to review the imported tasks")
+	return nil
+}
+
+// RunBenchmarkExport exports benchmark tasks to a suite file. The output
+// format follows the file extension: .yaml/.yml, .json, or .jsonl (OpenAI
+// evals-style records).
+func (h *BenchmarkHandler) RunBenchmarkExport(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("output file path is required")
+	}
+	outputPath := args[0]
+	category, _ := cmd.Flags().GetString("category")
+
+	styles := getCLIStyles(h.themeManager)
+	banner := styles.Banner.Render("📤 Export Benchmark Suite")
+	fmt.Println(banner)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+
+	var tasks []repositories.BenchmarkTask
+	if category != "" {
+		tasks, err = repos.BenchmarkTasks.GetByCategory(context.Background(), category)
+	} else {
+		tasks, err = repos.BenchmarkTasks.GetAll(context.Background())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list benchmark tasks: %w", err)
+	}
+	if len(tasks) == 0 {
+		return fmt.Errorf("no benchmark tasks to export")
+	}
+
+	suite := &pkgbenchmark.Suite{
+		Name:  suiteNameFromPath(outputPath),
+		Tasks: make([]pkgbenchmark.SuiteTask, 0, len(tasks)),
+	}
+	if category != "" {
+		suite.Description = fmt.Sprintf("Benchmark tasks in category %q", category)
+	}
+	for _, task := range tasks {
+		suite.Tasks = append(suite.Tasks, pkgbenchmark.SuiteTask{
+			Name:                  task.Name,
+			Category:              task.Category,
+			Description:           task.Description,
+			ExpectedOutputExample: task.ExpectedOutputExample,
+			EvaluationCriteria:    task.EvaluationCriteria,
+			Weights: pkgbenchmark.SuiteWeights{
+				TaskCompletion: task.TaskCompletionWeight,
+				Relevancy:      task.RelevancyWeight,
+				Hallucination:  task.HallucinationWeight,
+				Faithfulness:   task.FaithfulnessWeight,
+				Toxicity:       task.ToxicityWeight,
+			},
+		})
+	}
+
+	if strings.ToLower(filepath.Ext(outputPath)) == ".jsonl" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		if err := suite.WriteEvalsJSONL(file); err != nil {
+			return err
+		}
+	} else {
+		if err := suite.SaveFile(outputPath); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("✅ Exported %d task(s) to %s\n", len(suite.Tasks), outputPath)
+	return nil
+}
+
+// suiteNameFromPath derives a suite name from the output file name
+func suiteNameFromPath(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
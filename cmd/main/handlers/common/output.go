@@ -0,0 +1,83 @@
+package common
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Machine-readable output formats supported by the global --output flag.
+// "table" keeps each command's existing styled terminal output.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputCSV   = "csv"
+)
+
+// OutputFormat resolves the global --output flag for a command, defaulting
+// to table when the flag is absent (e.g. in tests constructing bare commands)
+func OutputFormat(cmd *cobra.Command) string {
+	if cmd == nil {
+		return OutputTable
+	}
+	if flag := cmd.Flags().Lookup("output"); flag != nil {
+		if format := flag.Value.String(); format != "" {
+			return format
+		}
+	}
+	return OutputTable
+}
+
+// MachineFormat reports whether the format bypasses styled terminal output
+func MachineFormat(format string) bool {
+	return format == OutputJSON || format == OutputYAML || format == OutputCSV
+}
+
+// RenderList writes rows in the requested machine format with a stable
+// column set, so shell pipelines and spreadsheets can consume Station data
+// without scraping the styled output. Columns are lower_snake_case field
+// names; each row must align with the column order.
+func RenderList(format string, columns []string, rows [][]string) error {
+	switch format {
+	case OutputCSV:
+		writer := csv.NewWriter(os.Stdout)
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case OutputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rowsToRecords(columns, rows))
+	case OutputYAML:
+		return yaml.NewEncoder(os.Stdout).Encode(rowsToRecords(columns, rows))
+	default:
+		return fmt.Errorf("unsupported output format: %s (expected table, json, yaml, or csv)", format)
+	}
+}
+
+// rowsToRecords converts column-aligned rows into ordered maps for JSON/YAML
+func rowsToRecords(columns []string, rows [][]string) []map[string]string {
+	records := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		record := make(map[string]string, len(columns))
+		for i, column := range columns {
+			if i < len(row) {
+				record[column] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	original := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stdout = w
+	fnErr := fn()
+	w.Close()
+	os.Stdout = original
+	if fnErr != nil {
+		t.Fatalf("render returned error: %v", fnErr)
+	}
+
+	var b strings.Builder
+	buf := make([]byte, 4096)
+	for {
+		n, readErr := r.Read(buf)
+		b.Write(buf[:n])
+		if readErr != nil {
+			break
+		}
+	}
+	return b.String()
+}
+
+func TestRenderListCSV(t *testing.T) {
+	out := captureStdout(t, func() error {
+		return RenderList(OutputCSV,
+			[]string{"id", "name"},
+			[][]string{{"1", "Security Scanner"}, {"2", "has,comma"}})
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || lines[0] != "id,name" {
+		t.Fatalf("expected CSV header + 2 rows, got %q", out)
+	}
+	if lines[2] != `2,"has,comma"` {
+		t.Errorf("expected quoted CSV value, got %q", lines[2])
+	}
+}
+
+func TestRenderListJSON(t *testing.T) {
+	out := captureStdout(t, func() error {
+		return RenderList(OutputJSON,
+			[]string{"id", "status"},
+			[][]string{{"7", "completed"}})
+	})
+
+	var records []map[string]string
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", out, err)
+	}
+	if len(records) != 1 || records[0]["id"] != "7" || records[0]["status"] != "completed" {
+		t.Errorf("unexpected records: %+v", records)
+	}
+}
+
+func TestRenderListYAML(t *testing.T) {
+	out := captureStdout(t, func() error {
+		return RenderList(OutputYAML,
+			[]string{"key", "value"},
+			[][]string{{"ai_provider", "openai"}})
+	})
+	if !strings.Contains(out, "key: ai_provider") || !strings.Contains(out, "value: openai") {
+		t.Errorf("unexpected YAML output: %q", out)
+	}
+}
+
+func TestRenderListRejectsUnknownFormat(t *testing.T) {
+	if err := RenderList("xml", []string{"id"}, nil); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestMachineFormat(t *testing.T) {
+	for _, format := range []string{OutputJSON, OutputYAML, OutputCSV} {
+		if !MachineFormat(format) {
+			t.Errorf("expected %s to be a machine format", format)
+		}
+	}
+	if MachineFormat(OutputTable) {
+		t.Error("table is not a machine format")
+	}
+	if MachineFormat("") {
+		t.Error("empty format is not a machine format")
+	}
+}
@@ -0,0 +1,161 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProgressTracker renders unified progress for long-running CLI operations
+// (sync tool discovery, report evaluations, benchmark runs). On a TTY it
+// draws an in-place bar with a per-task ETA; when output is redirected it
+// degrades to plain log lines; with --output json it emits one
+// machine-readable progress event per update so wrappers can follow along.
+type ProgressTracker struct {
+	label   string
+	json    bool
+	tty     bool
+	started time.Time
+
+	mu       sync.Mutex
+	lastLine int
+	updated  bool
+}
+
+// progressEvent is the machine-readable shape emitted in json mode, one
+// JSON object per line on stdout
+type progressEvent struct {
+	Event          string  `json:"event"`
+	Operation      string  `json:"operation"`
+	Completed      int     `json:"completed"`
+	Total          int     `json:"total"`
+	Task           string  `json:"task,omitempty"`
+	ETASeconds     float64 `json:"eta_seconds,omitempty"`
+	ElapsedSeconds float64 `json:"elapsed_seconds,omitempty"`
+}
+
+// NewProgressTracker creates a tracker for one labeled operation. format is
+// the resolved --output format (see OutputFormat); anything other than json
+// falls back to terminal rendering.
+func NewProgressTracker(label, format string) *ProgressTracker {
+	return &ProgressTracker{
+		label:   label,
+		json:    format == OutputJSON,
+		tty:     stdoutIsTerminal(),
+		started: time.Now(),
+	}
+}
+
+// Update reports that completed of total tasks have finished and task is
+// the item currently in progress. Safe for concurrent use.
+func (p *ProgressTracker) Update(completed, total int, task string) {
+	if p == nil || total <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.updated = true
+
+	eta := p.etaSeconds(completed, total)
+	if p.json {
+		p.emit(progressEvent{
+			Event: "progress", Operation: p.label,
+			Completed: completed, Total: total, Task: task, ETASeconds: eta,
+		})
+		return
+	}
+
+	detail := fmt.Sprintf("%d/%d", completed, total)
+	if task != "" {
+		detail += " " + task
+	}
+	if eta > 0 {
+		detail += fmt.Sprintf(" (ETA %s)", formatETA(eta))
+	}
+
+	if p.tty {
+		line := fmt.Sprintf("%s %s %s", p.label, renderBar(completed, total), detail)
+		padding := ""
+		if pad := p.lastLine - len(line); pad > 0 {
+			padding = strings.Repeat(" ", pad)
+		}
+		fmt.Printf("\r%s%s", line, padding)
+		p.lastLine = len(line)
+		return
+	}
+
+	fmt.Printf("%s: %s\n", p.label, detail)
+}
+
+// Done finishes the progress display: terminates the in-place bar line on a
+// TTY and emits a completion event in json mode. No-op if Update was never
+// called (e.g. the operation had nothing to do).
+func (p *ProgressTracker) Done() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.updated {
+		return
+	}
+	p.updated = false
+	if p.json {
+		p.emit(progressEvent{
+			Event: "complete", Operation: p.label,
+			ElapsedSeconds: time.Since(p.started).Seconds(),
+		})
+		return
+	}
+	if p.tty {
+		fmt.Println()
+	}
+}
+
+// etaSeconds projects time remaining from the average pace so far
+func (p *ProgressTracker) etaSeconds(completed, total int) float64 {
+	if completed <= 0 || completed >= total {
+		return 0
+	}
+	elapsed := time.Since(p.started).Seconds()
+	return elapsed / float64(completed) * float64(total-completed)
+}
+
+func (p *ProgressTracker) emit(event progressEvent) {
+	encoder := json.NewEncoder(os.Stdout)
+	if err := encoder.Encode(event); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to emit progress event: %v\n", err)
+	}
+}
+
+// renderBar draws a fixed-width bar like [██████░░░░░░░░░░░░░░]
+func renderBar(completed, total int) string {
+	const width = 20
+	filled := completed * width / total
+	if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// formatETA keeps estimates readable: seconds under a minute, m+s above
+func formatETA(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second)).Round(time.Second)
+	if d < time.Minute {
+		return d.String()
+	}
+	return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// stdoutIsTerminal reports whether stdout is attached to a terminal, so
+// progress degrades to plain logs in CI and when piped
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"station/internal/config"
+)
+
+// RemoteAgent is an agent row fetched from a remote Station's API,
+// tagged with the remote's configured name so federated views can
+// label the origin of each row.
+type RemoteAgent struct {
+	Remote        string `json:"-"`
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	EnvironmentID int64  `json:"environment_id"`
+	MaxSteps      int64  `json:"max_steps"`
+}
+
+// RemoteRun is a run row fetched from a remote Station's API.
+type RemoteRun struct {
+	Remote      string     `json:"-"`
+	ID          int64      `json:"id"`
+	AgentID     int64      `json:"agent_id"`
+	AgentName   string     `json:"agent_name"`
+	Task        string     `json:"task"`
+	Status      string     `json:"status"`
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// ResolveRemotes returns the remotes to query for a federated view.
+// remoteName selects a single configured remote; allRemotes selects every
+// configured remote in name order. Exactly one of the two should be set.
+func ResolveRemotes(cfg *config.Config, remoteName string, allRemotes bool) (map[string]config.RemoteConfig, error) {
+	if remoteName != "" && allRemotes {
+		return nil, fmt.Errorf("--remote and --all-remotes cannot be used together")
+	}
+
+	if remoteName != "" {
+		remote, exists := cfg.Remotes[remoteName]
+		if !exists {
+			return nil, fmt.Errorf("remote '%s' not configured (add it with: stn remote add %s <endpoint>)", remoteName, remoteName)
+		}
+		return map[string]config.RemoteConfig{remoteName: remote}, nil
+	}
+
+	if allRemotes {
+		if len(cfg.Remotes) == 0 {
+			return nil, fmt.Errorf("no remotes configured (add one with: stn remote add <name> <endpoint>)")
+		}
+		return cfg.Remotes, nil
+	}
+
+	return nil, nil
+}
+
+// SortedRemoteNames returns remote names in stable display order.
+func SortedRemoteNames(remotes map[string]config.RemoteConfig) []string {
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FetchRemoteAgents lists agents from a remote Station via its REST API.
+func FetchRemoteAgents(name string, remote config.RemoteConfig) ([]RemoteAgent, error) {
+	var response struct {
+		Agents []RemoteAgent `json:"agents"`
+	}
+	if err := fetchRemoteJSON(remote, "/api/v1/agents", &response); err != nil {
+		return nil, err
+	}
+	for i := range response.Agents {
+		response.Agents[i].Remote = name
+	}
+	return response.Agents, nil
+}
+
+// FetchRemoteRuns lists recent runs from a remote Station via its REST API.
+func FetchRemoteRuns(name string, remote config.RemoteConfig, limit int) ([]RemoteRun, error) {
+	var response struct {
+		Runs []RemoteRun `json:"runs"`
+	}
+	path := fmt.Sprintf("/api/v1/runs?limit=%d", limit)
+	if err := fetchRemoteJSON(remote, path, &response); err != nil {
+		return nil, err
+	}
+	for i := range response.Runs {
+		response.Runs[i].Remote = name
+	}
+	return response.Runs, nil
+}
+
+// fetchRemoteJSON performs an authenticated GET against a remote Station
+// and decodes the JSON response body into out.
+func fetchRemoteJSON(remote config.RemoteConfig, path string, out interface{}) error {
+	req, err := http.NewRequest("GET", remote.Endpoint+path, nil)
+	if err != nil {
+		return fmt.Errorf("invalid remote endpoint: %w", err)
+	}
+	if remote.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+remote.APIKey)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("remote returned HTTP %d: %s", resp.StatusCode, TruncateString(string(body), 200))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode remote response: %w", err)
+	}
+	return nil
+}
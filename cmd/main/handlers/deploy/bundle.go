@@ -32,7 +32,7 @@ func BuildDeploymentImage(ctx context.Context, envName string, envConfig *Enviro
 }
 
 // HandleBundleDeploy handles deployment from a CloudShip bundle ID (--bundle-id)
-func HandleBundleDeploy(ctx context.Context, bundleID, appName, target, region, sleepAfter, instanceType string, autoStop, withOpenCode, withSandbox bool, namespace, k8sContext, outputDir string, dryRun bool, envFile string, runtimeSecrets *RuntimeSecretsConfig) error {
+func HandleBundleDeploy(ctx context.Context, bundleID, appName, target, region, sleepAfter, instanceType string, autoStop, withOpenCode, withSandbox bool, namespace, k8sContext, outputDir string, dryRun bool, envFile string, runtimeSecrets *RuntimeSecretsConfig, k8sOptions *K8sDeployOptions) error {
 	aiConfig, err := DetectAIConfig()
 	if err != nil {
 		return fmt.Errorf("AI configuration error: %w\n\nPlease set the appropriate environment variable for your provider", err)
@@ -84,21 +84,24 @@ func HandleBundleDeploy(ctx context.Context, bundleID, appName, target, region,
 		return DeployBundleToFly(ctx, bundleID, resolvedAppName, aiConfig, cloudShipConfig, bundleEnvConfig, region, autoStop, withOpenCode, withSandbox)
 
 	case "kubernetes", "k8s":
-		return DeployBundleToKubernetes(ctx, bundleID, resolvedAppName, aiConfig, cloudShipConfig, bundleEnvConfig, externalSecrets, namespace, k8sContext, outputDir, dryRun, runtimeSecrets)
+		return DeployBundleToKubernetes(ctx, bundleID, resolvedAppName, aiConfig, cloudShipConfig, bundleEnvConfig, externalSecrets, namespace, k8sContext, outputDir, dryRun, runtimeSecrets, k8sOptions)
 
 	case "ansible":
 		return DeployBundleToAnsible(ctx, bundleID, resolvedAppName, aiConfig, cloudShipConfig, bundleEnvConfig, externalSecrets, outputDir, dryRun, nil, "", "", runtimeSecrets)
 
+	case "ecs", "fargate":
+		return DeployBundleToECS(ctx, bundleID, resolvedAppName, aiConfig, cloudShipConfig, bundleEnvConfig, externalSecrets, region, outputDir, dryRun, runtimeSecrets, k8sOptions)
+
 	case "cloudflare", "cf", "cloudflare-containers":
 		return fmt.Errorf("cloudflare target does not support --bundle-id (bundles are already built into the workflow)")
 
 	default:
-		return fmt.Errorf("unsupported deployment target: %s (supported: fly, kubernetes, ansible)", target)
+		return fmt.Errorf("unsupported deployment target: %s (supported: fly, kubernetes, ansible, ecs)", target)
 	}
 }
 
 // HandleBundlePathDeploy handles deployment from a local bundle file (--bundle ./file.tar.gz)
-func HandleBundlePathDeploy(ctx context.Context, bundlePath, appName, target, namespace, k8sContext, outputDir string, dryRun bool, hosts []string, sshKey, sshUser, envFile string, runtimeSecrets *RuntimeSecretsConfig) error {
+func HandleBundlePathDeploy(ctx context.Context, bundlePath, appName, target, namespace, k8sContext, outputDir string, dryRun bool, hosts []string, sshKey, sshUser, envFile string, runtimeSecrets *RuntimeSecretsConfig, k8sOptions *K8sDeployOptions) error {
 	aiConfig, err := DetectAIConfig()
 	if err != nil {
 		return fmt.Errorf("AI configuration error: %w\n\nPlease set the appropriate environment variable for your provider", err)
@@ -151,7 +154,7 @@ func HandleBundlePathDeploy(ctx context.Context, bundlePath, appName, target, na
 
 	switch strings.ToLower(target) {
 	case "kubernetes", "k8s":
-		return DeployToKubernetes(ctx, bundleName, aiConfig, cloudShipConfig, bundleEnvConfig, externalSecrets, namespace, k8sContext, outputDir, dryRun, bundlePath, runtimeSecrets)
+		return DeployToKubernetes(ctx, bundleName, aiConfig, cloudShipConfig, bundleEnvConfig, externalSecrets, namespace, k8sContext, outputDir, dryRun, bundlePath, runtimeSecrets, k8sOptions)
 
 	case "ansible":
 		return DeployToAnsible(ctx, bundleName, aiConfig, cloudShipConfig, bundleEnvConfig, externalSecrets, outputDir, dryRun, hosts, sshKey, sshUser, bundlePath, runtimeSecrets)
@@ -6,12 +6,13 @@ import (
 	"os/exec"
 	"strings"
 
+	"station/internal/deployment"
 	_ "station/internal/deployment/secrets"
 	_ "station/internal/deployment/targets"
 )
 
 // HandleDeploy is the main entry point for deployment operations
-func HandleDeploy(ctx context.Context, envName, target, region, sleepAfter, instanceType string, destroy, autoStop, withOpenCode, withSandbox bool, namespace, k8sContext, outputDir string, dryRun bool, bundleID, appName string, hosts []string, sshKey, sshUser, bundlePath, envFile, secretsBackend, secretsPath string) error {
+func HandleDeploy(ctx context.Context, envName, target, region, sleepAfter, instanceType string, destroy, autoStop, withOpenCode, withSandbox bool, namespace, k8sContext, outputDir string, dryRun bool, bundleID, appName string, hosts []string, sshKey, sshUser, bundlePath, envFile, secretsBackend, secretsPath string, k8sOptions *K8sDeployOptions) error {
 	if target == "" {
 		target = "fly"
 	}
@@ -49,12 +50,12 @@ func HandleDeploy(ctx context.Context, envName, target, region, sleepAfter, inst
 
 	if isBundleIDDeploy {
 		fmt.Printf("🚀 Deploying bundle '%s' to %s (region: %s)\n\n", bundleID, target, region)
-		return HandleBundleDeploy(ctx, bundleID, appName, target, region, sleepAfter, instanceType, autoStop, withOpenCode, withSandbox, namespace, k8sContext, outputDir, dryRun, envFile, runtimeSecrets)
+		return HandleBundleDeploy(ctx, bundleID, appName, target, region, sleepAfter, instanceType, autoStop, withOpenCode, withSandbox, namespace, k8sContext, outputDir, dryRun, envFile, runtimeSecrets, k8sOptions)
 	}
 
 	if isBundlePathDeploy {
 		fmt.Printf("🚀 Deploying from bundle file '%s' to %s\n\n", bundlePath, target)
-		return HandleBundlePathDeploy(ctx, bundlePath, appName, target, namespace, k8sContext, outputDir, dryRun, hosts, sshKey, sshUser, envFile, runtimeSecrets)
+		return HandleBundlePathDeploy(ctx, bundlePath, appName, target, namespace, k8sContext, outputDir, dryRun, hosts, sshKey, sshUser, envFile, runtimeSecrets, k8sOptions)
 	}
 
 	fmt.Printf("🚀 Deploying environment '%s' to %s (region: %s)\n\n", envName, target, region)
@@ -127,16 +128,19 @@ func HandleDeploy(ctx context.Context, envName, target, region, sleepAfter, inst
 		return DeployToFly(ctx, envName, aiConfig, cloudShipConfig, envConfig, imageName, region, autoStop, withOpenCode, withSandbox)
 
 	case "kubernetes", "k8s":
-		return DeployToKubernetes(ctx, envName, aiConfig, cloudShipConfig, envConfig, externalSecrets, namespace, k8sContext, outputDir, dryRun, bundlePath, runtimeSecrets)
+		return DeployToKubernetes(ctx, envName, aiConfig, cloudShipConfig, envConfig, externalSecrets, namespace, k8sContext, outputDir, dryRun, bundlePath, runtimeSecrets, k8sOptions)
 
 	case "ansible":
 		return DeployToAnsible(ctx, envName, aiConfig, cloudShipConfig, envConfig, externalSecrets, outputDir, dryRun, hosts, sshKey, sshUser, bundlePath, runtimeSecrets)
 
+	case "ecs", "fargate":
+		return DeployToECS(ctx, envName, aiConfig, cloudShipConfig, envConfig, externalSecrets, region, outputDir, dryRun, runtimeSecrets, k8sOptions)
+
 	case "cloudflare", "cf", "cloudflare-containers":
 		return fmt.Errorf("cloudflare target is currently disabled (experimental, not working)")
 
 	default:
-		return fmt.Errorf("unsupported deployment target: %s (supported: fly, kubernetes, ansible)", target)
+		return fmt.Errorf("unsupported deployment target: %s (supported: fly, kubernetes, ansible, ecs)", target)
 	}
 }
 
@@ -151,6 +155,13 @@ func handleDeployDestroy(ctx context.Context, envName, target string) error {
 		}
 		return DestroyFlyApp(ctx, appName)
 
+	case "ecs", "fargate":
+		ecsTarget, ok := deployment.GetDeploymentTarget("ecs")
+		if !ok {
+			return fmt.Errorf("ecs deployment target not registered")
+		}
+		return ecsTarget.Destroy(ctx, &deployment.DeploymentConfig{EnvironmentName: envName})
+
 	case "cloudflare", "cf", "cloudflare-containers":
 		return fmt.Errorf("cloudflare target is currently disabled (experimental, not working)")
 
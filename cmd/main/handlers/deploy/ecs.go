@@ -0,0 +1,88 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"station/internal/deployment"
+)
+
+// DeployToECS deploys an environment to AWS ECS/Fargate
+func DeployToECS(ctx context.Context, envName string, aiConfig *DeploymentAIConfig, cloudShipConfig *DeploymentCloudShipConfig, envConfig *EnvironmentConfig, externalSecrets map[string]string, region, outputDir string, dryRun bool, runtimeSecrets *RuntimeSecretsConfig, k8sOptions *K8sDeployOptions) error {
+	fmt.Printf("☁️  Deploying to AWS ECS/Fargate...\n\n")
+
+	target, ok := deployment.GetDeploymentTarget("ecs")
+	if !ok {
+		return fmt.Errorf("ecs deployment target not registered")
+	}
+
+	if !dryRun {
+		if err := target.Validate(ctx); err != nil {
+			return fmt.Errorf("ecs validation failed: %w", err)
+		}
+	}
+
+	imageName, err := BuildDeploymentImage(ctx, envName, envConfig, aiConfig)
+	if err != nil {
+		return err
+	}
+
+	deployConfig := &deployment.DeploymentConfig{
+		EnvironmentName:      envName,
+		DockerImage:          imageName,
+		AIProvider:           aiConfig.Provider,
+		AIModel:              aiConfig.Model,
+		EnvironmentVariables: envConfig.Variables,
+	}
+
+	secrets := BuildAllSecrets(aiConfig, cloudShipConfig, envConfig, externalSecrets, runtimeSecrets)
+
+	options := deployment.DeployOptions{
+		DryRun:    dryRun,
+		OutputDir: outputDir,
+		Region:    region,
+	}
+	if k8sOptions != nil {
+		options.Litestream = k8sOptions.Litestream
+	}
+
+	return target.Deploy(ctx, deployConfig, secrets, options)
+}
+
+// DeployBundleToECS deploys a bundle to AWS ECS/Fargate
+func DeployBundleToECS(ctx context.Context, bundleID, appName string, aiConfig *DeploymentAIConfig, cloudShipConfig *DeploymentCloudShipConfig, envConfig *EnvironmentConfig, externalSecrets map[string]string, region, outputDir string, dryRun bool, runtimeSecrets *RuntimeSecretsConfig, k8sOptions *K8sDeployOptions) error {
+	fmt.Printf("☁️  Deploying bundle to AWS ECS/Fargate...\n\n")
+
+	target, ok := deployment.GetDeploymentTarget("ecs")
+	if !ok {
+		return fmt.Errorf("ecs deployment target not registered")
+	}
+
+	if !dryRun {
+		if err := target.Validate(ctx); err != nil {
+			return fmt.Errorf("ecs validation failed: %w", err)
+		}
+	}
+
+	deployConfig := &deployment.DeploymentConfig{
+		EnvironmentName:      appName,
+		DockerImage:          BaseStationImage,
+		AIProvider:           aiConfig.Provider,
+		AIModel:              aiConfig.Model,
+		EnvironmentVariables: envConfig.Variables,
+	}
+
+	secrets := BuildAllSecrets(aiConfig, cloudShipConfig, envConfig, externalSecrets, runtimeSecrets)
+	secrets["STN_BUNDLE_ID"] = bundleID
+
+	options := deployment.DeployOptions{
+		DryRun:    dryRun,
+		OutputDir: outputDir,
+		Region:    region,
+	}
+	if k8sOptions != nil {
+		options.Litestream = k8sOptions.Litestream
+	}
+
+	return target.Deploy(ctx, deployConfig, secrets, options)
+}
@@ -8,7 +8,7 @@ import (
 )
 
 // DeployToKubernetes deploys an environment to Kubernetes
-func DeployToKubernetes(ctx context.Context, envName string, aiConfig *DeploymentAIConfig, cloudShipConfig *DeploymentCloudShipConfig, envConfig *EnvironmentConfig, externalSecrets map[string]string, namespace, k8sContext, outputDir string, dryRun bool, bundlePath string, runtimeSecrets *RuntimeSecretsConfig) error {
+func DeployToKubernetes(ctx context.Context, envName string, aiConfig *DeploymentAIConfig, cloudShipConfig *DeploymentCloudShipConfig, envConfig *EnvironmentConfig, externalSecrets map[string]string, namespace, k8sContext, outputDir string, dryRun bool, bundlePath string, runtimeSecrets *RuntimeSecretsConfig, k8sOptions *K8sDeployOptions) error {
 	fmt.Printf("☸️  Deploying to Kubernetes...\n\n")
 
 	target, ok := deployment.GetDeploymentTarget("kubernetes")
@@ -54,12 +54,16 @@ func DeployToKubernetes(ctx context.Context, envName string, aiConfig *Deploymen
 		Context:    k8sContext,
 		BundlePath: bundlePath,
 	}
+	if k8sOptions != nil {
+		options.IngressHost = k8sOptions.IngressHost
+		options.Litestream = k8sOptions.Litestream
+	}
 
 	return target.Deploy(ctx, deployConfig, secrets, options)
 }
 
 // DeployBundleToKubernetes deploys a bundle to Kubernetes
-func DeployBundleToKubernetes(ctx context.Context, bundleID, appName string, aiConfig *DeploymentAIConfig, cloudShipConfig *DeploymentCloudShipConfig, envConfig *EnvironmentConfig, externalSecrets map[string]string, namespace, k8sContext, outputDir string, dryRun bool, runtimeSecrets *RuntimeSecretsConfig) error {
+func DeployBundleToKubernetes(ctx context.Context, bundleID, appName string, aiConfig *DeploymentAIConfig, cloudShipConfig *DeploymentCloudShipConfig, envConfig *EnvironmentConfig, externalSecrets map[string]string, namespace, k8sContext, outputDir string, dryRun bool, runtimeSecrets *RuntimeSecretsConfig, k8sOptions *K8sDeployOptions) error {
 	fmt.Printf("☸️  Deploying bundle to Kubernetes...\n\n")
 
 	target, ok := deployment.GetDeploymentTarget("kubernetes")
@@ -91,6 +95,10 @@ func DeployBundleToKubernetes(ctx context.Context, bundleID, appName string, aiC
 		Namespace: namespace,
 		Context:   k8sContext,
 	}
+	if k8sOptions != nil {
+		options.IngressHost = k8sOptions.IngressHost
+		options.Litestream = k8sOptions.Litestream
+	}
 
 	return target.Deploy(ctx, deployConfig, secrets, options)
 }
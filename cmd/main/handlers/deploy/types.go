@@ -36,6 +36,12 @@ type EnvironmentConfig struct {
 	Agents    []string
 }
 
+// K8sDeployOptions holds Kubernetes-specific deployment options
+type K8sDeployOptions struct {
+	IngressHost string // Generate an Ingress for this host (empty = no Ingress)
+	Litestream  bool   // Replicate the DB with a Litestream sidecar instead of a PVC
+}
+
 // RuntimeSecretsConfig holds configuration for runtime secrets backends
 type RuntimeSecretsConfig struct {
 	Backend string // aws-secretsmanager, aws-ssm, vault, gcp-secretmanager, sops
@@ -0,0 +1,308 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"station/cmd/main/handlers/common"
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/queries"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+// modelAgentResult is one agent's evaluation under a single model
+type modelAgentResult struct {
+	Score              float64
+	Passed             bool
+	SuccessRate        float64
+	AvgCost            float64
+	AvgDurationSeconds float64
+	RunsAnalyzed       int64
+}
+
+// modelComparisonColumn is the aggregated evaluation for one candidate model:
+// the completed (model-filtered) report it came from plus per-agent results
+type modelComparisonColumn struct {
+	Model         string
+	ReportID      int64
+	TeamScore     float64
+	RunsAnalyzed  int64
+	AvgCostPerRun float64
+	AvgDuration   float64
+	Agents        map[string]modelAgentResult
+	// Err is set when no evaluation could be produced for this model
+	// (e.g. no runs recorded with it); the model is excluded from the
+	// recommendation but still shown so the gap is visible
+	Err error
+}
+
+// RunReportCompare generates side-by-side model evaluations for an environment
+func (h *ReportHandler) RunReportCompare(cmd *cobra.Command, args []string) error {
+	startTime := time.Now()
+	styles := common.GetCLIStyles(h.themeManager)
+	banner := styles.Banner.Render("⚖️  Compare Models")
+	fmt.Println(banner)
+
+	envName, _ := cmd.Flags().GetString("environment")
+	modelsFlag, _ := cmd.Flags().GetString("models")
+	minScore, _ := cmd.Flags().GetFloat64("min-score")
+	regenerate, _ := cmd.Flags().GetBool("regenerate")
+
+	if envName == "" {
+		return fmt.Errorf("--environment flag is required")
+	}
+
+	modelNames := splitModelList(modelsFlag)
+	if len(modelNames) < 2 {
+		return fmt.Errorf("--models requires at least two comma-separated model names (e.g. gpt-4o,gemini-2.5-pro)")
+	}
+
+	err := h.compareModels(envName, modelNames, minScore, regenerate, common.OutputFormat(cmd))
+
+	// Track telemetry
+	if h.telemetryService != nil {
+		h.telemetryService.TrackCLICommand("report", "compare", err == nil, time.Since(startTime).Milliseconds())
+	}
+
+	return err
+}
+
+// compareModels evaluates each candidate model and renders the comparison.
+// Each model is scored by a model-filtered report: an existing completed one
+// is reused, otherwise a new report is created and generated on the spot
+func (h *ReportHandler) compareModels(envName string, modelNames []string, minScore float64, regenerate bool, outputFormat string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load station config: %v", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer database.Close()
+
+	repos := repositories.New(database)
+	ctx := context.Background()
+
+	env, err := repos.Environments.GetByName(envName)
+	if err != nil {
+		return fmt.Errorf("environment not found: %v", err)
+	}
+
+	styles := common.GetCLIStyles(h.themeManager)
+	columns := make([]modelComparisonColumn, 0, len(modelNames))
+
+	for _, model := range modelNames {
+		var report *queries.Report
+		if !regenerate {
+			report = latestCompletedReportForModel(ctx, repos, env.ID, model)
+		}
+
+		if report == nil {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("🔄 No completed evaluation for %s, generating one...", model)))
+			report, err = h.generateModelFilteredReport(ctx, repos, database.Conn(), env.ID, envName, model, outputFormat)
+			if err != nil {
+				fmt.Println(styles.Error.Render(fmt.Sprintf("⚠️  %s: %v", model, err)))
+				columns = append(columns, modelComparisonColumn{Model: model, Err: err})
+				continue
+			}
+		} else {
+			fmt.Println(styles.Muted.Render(fmt.Sprintf("Reusing completed report %d for %s (use --regenerate for a fresh evaluation)", report.ID, model)))
+		}
+
+		details, err := repos.Reports.GetAgentReportDetails(ctx, report.ID)
+		if err != nil {
+			return fmt.Errorf("failed to load agent details for report %d: %v", report.ID, err)
+		}
+		columns = append(columns, buildComparisonColumn(model, *report, details))
+	}
+
+	fmt.Println()
+	renderModelComparison(styles, columns, minScore)
+	return nil
+}
+
+// latestCompletedReportForModel returns the most recent completed report in
+// the environment that was generated with the given model filter, or nil
+func latestCompletedReportForModel(ctx context.Context, repos *repositories.Repositories, environmentID int64, model string) *queries.Report {
+	reports, err := repos.Reports.ListByEnvironment(ctx, environmentID)
+	if err != nil {
+		return nil
+	}
+
+	var latest *queries.Report
+	for i := range reports {
+		r := reports[i]
+		if r.Status != "completed" || !r.FilterModel.Valid || r.FilterModel.String != model {
+			continue
+		}
+		if latest == nil || (r.CreatedAt.Valid && latest.CreatedAt.Valid && r.CreatedAt.Time.After(latest.CreatedAt.Time)) {
+			latest = &reports[i]
+		}
+	}
+	return latest
+}
+
+// generateModelFilteredReport creates and synchronously generates a report
+// restricted to runs recorded with the given model
+func (h *ReportHandler) generateModelFilteredReport(ctx context.Context, repos *repositories.Repositories, dbConn *sql.DB, environmentID int64, envName, model, outputFormat string) (*queries.Report, error) {
+	teamCriteriaJSON, err := defaultTeamCriteriaJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := repos.Reports.CreateReport(ctx, queries.CreateReportParams{
+		Name:          fmt.Sprintf("Model comparison: %s", model),
+		Description:   sql.NullString{String: fmt.Sprintf("Generated by stn report compare for environment %s", envName), Valid: true},
+		EnvironmentID: environmentID,
+		TeamCriteria:  teamCriteriaJSON,
+		AgentCriteria: sql.NullString{Valid: false},
+		JudgeModel:    sql.NullString{String: "gpt-5-mini", Valid: true},
+		FilterModel:   sql.NullString{String: model, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report: %v", err)
+	}
+
+	progress := common.NewProgressTracker(fmt.Sprintf("Evaluating agents with %s", model), outputFormat)
+	reportGenerator := services.NewReportGenerator(repos, dbConn, &services.ReportGeneratorConfig{
+		Progress: progress.Update,
+	})
+	err = reportGenerator.GenerateReport(ctx, created.ID)
+	progress.Done()
+	if err != nil {
+		return nil, fmt.Errorf("evaluation failed: %v", err)
+	}
+
+	generated, err := repos.Reports.GetByID(ctx, created.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch generated report: %v", err)
+	}
+	return &generated, nil
+}
+
+// buildComparisonColumn aggregates a completed report's per-agent details
+// into one comparison column with run-weighted cost and latency averages
+func buildComparisonColumn(model string, report queries.Report, details []queries.AgentReportDetail) modelComparisonColumn {
+	column := modelComparisonColumn{
+		Model:        model,
+		ReportID:     report.ID,
+		TeamScore:    report.TeamScore.Float64,
+		RunsAnalyzed: report.TotalRunsAnalyzed.Int64,
+		Agents:       make(map[string]modelAgentResult, len(details)),
+	}
+
+	var totalCost, totalDuration float64
+	var weightedRuns int64
+	for _, detail := range details {
+		runs := detail.RunsAnalyzed.Int64
+		column.Agents[detail.AgentName] = modelAgentResult{
+			Score:              detail.Score,
+			Passed:             detail.Passed,
+			SuccessRate:        detail.SuccessRate.Float64,
+			AvgCost:            detail.AvgCost.Float64,
+			AvgDurationSeconds: detail.AvgDurationSeconds.Float64,
+			RunsAnalyzed:       runs,
+		}
+		totalCost += detail.AvgCost.Float64 * float64(runs)
+		totalDuration += detail.AvgDurationSeconds.Float64 * float64(runs)
+		weightedRuns += runs
+	}
+	if weightedRuns > 0 {
+		column.AvgCostPerRun = totalCost / float64(weightedRuns)
+		column.AvgDuration = totalDuration / float64(weightedRuns)
+	}
+	return column
+}
+
+// recommendModel picks the cheapest evaluated model whose team score meets
+// the threshold; nil when no model qualifies
+func recommendModel(columns []modelComparisonColumn, minScore float64) *modelComparisonColumn {
+	var best *modelComparisonColumn
+	for i := range columns {
+		column := &columns[i]
+		if column.Err != nil || column.TeamScore < minScore {
+			continue
+		}
+		if best == nil || column.AvgCostPerRun < best.AvgCostPerRun {
+			best = column
+		}
+	}
+	return best
+}
+
+// renderModelComparison prints the per-model summary, the per-agent
+// side-by-side breakdown, and the cheapest-qualifying recommendation
+func renderModelComparison(styles common.CLIStyles, columns []modelComparisonColumn, minScore float64) {
+	fmt.Println(styles.Info.Render("Model Summary"))
+	for _, column := range columns {
+		if column.Err != nil {
+			fmt.Printf("  %-24s %s\n", column.Model, styles.Error.Render(fmt.Sprintf("no evaluation (%v)", column.Err)))
+			continue
+		}
+		fmt.Printf("  %-24s %s\n", column.Model,
+			styles.Muted.Render(fmt.Sprintf("score %.1f/10 | avg cost/run $%.4f | avg latency %.1fs | runs %d (report %d)",
+				column.TeamScore, column.AvgCostPerRun, column.AvgDuration, column.RunsAnalyzed, column.ReportID)))
+	}
+
+	agentNames := map[string]bool{}
+	for _, column := range columns {
+		for name := range column.Agents {
+			agentNames[name] = true
+		}
+	}
+	sortedNames := make([]string, 0, len(agentNames))
+	for name := range agentNames {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	if len(sortedNames) > 0 {
+		fmt.Println()
+		fmt.Println(styles.Info.Render("Per-Agent Breakdown"))
+		for _, name := range sortedNames {
+			fmt.Printf("  %s\n", name)
+			for _, column := range columns {
+				result, ok := column.Agents[name]
+				if !ok {
+					fmt.Printf("    %-22s %s\n", column.Model, styles.Muted.Render("no runs"))
+					continue
+				}
+				passIcon := "✅"
+				if !result.Passed {
+					passIcon = "❌"
+				}
+				fmt.Printf("    %-22s %s\n", column.Model,
+					styles.Muted.Render(fmt.Sprintf("%s %.1f/10 | success %.0f%% | $%.4f/run | %.1fs (%d runs)",
+						passIcon, result.Score, result.SuccessRate, result.AvgCost, result.AvgDurationSeconds, result.RunsAnalyzed)))
+			}
+		}
+	}
+
+	fmt.Println()
+	if recommended := recommendModel(columns, minScore); recommended != nil {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("💡 Recommended: %s — cheapest model scoring at least %.1f/10 ($%.4f/run at %.1f/10)",
+			recommended.Model, minScore, recommended.AvgCostPerRun, recommended.TeamScore)))
+	} else {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("No compared model meets the %.1f/10 quality threshold (adjust with --min-score)", minScore)))
+	}
+}
+
+// splitModelList parses the --models flag, dropping empty entries
+func splitModelList(value string) []string {
+	var models []string
+	for _, part := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			models = append(models, trimmed)
+		}
+	}
+	return models
+}
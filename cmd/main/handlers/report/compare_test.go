@@ -0,0 +1,100 @@
+package report
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"station/internal/db/queries"
+)
+
+func completedReport(id int64, teamScore float64, runs int64) queries.Report {
+	return queries.Report{
+		ID:                id,
+		Status:            "completed",
+		TeamScore:         sql.NullFloat64{Float64: teamScore, Valid: true},
+		TotalRunsAnalyzed: sql.NullInt64{Int64: runs, Valid: true},
+	}
+}
+
+func agentDetail(name string, score, successRate, avgCost, avgDuration float64, runs int64) queries.AgentReportDetail {
+	return queries.AgentReportDetail{
+		AgentName:          name,
+		Score:              score,
+		Passed:             score >= 7.0,
+		SuccessRate:        sql.NullFloat64{Float64: successRate, Valid: true},
+		AvgCost:            sql.NullFloat64{Float64: avgCost, Valid: true},
+		AvgDurationSeconds: sql.NullFloat64{Float64: avgDuration, Valid: true},
+		RunsAnalyzed:       sql.NullInt64{Int64: runs, Valid: true},
+	}
+}
+
+func TestBuildComparisonColumnWeightedAverages(t *testing.T) {
+	column := buildComparisonColumn("gpt-4o", completedReport(1, 8.2, 30), []queries.AgentReportDetail{
+		agentDetail("scanner", 8.5, 95, 0.02, 10, 20),
+		agentDetail("reporter", 7.5, 90, 0.08, 40, 10),
+	})
+
+	if column.TeamScore != 8.2 || column.RunsAnalyzed != 30 {
+		t.Errorf("unexpected report summary: %+v", column)
+	}
+	// (0.02*20 + 0.08*10) / 30 = 0.04
+	if fmt.Sprintf("%.4f", column.AvgCostPerRun) != "0.0400" {
+		t.Errorf("expected run-weighted avg cost 0.04, got %f", column.AvgCostPerRun)
+	}
+	// (10*20 + 40*10) / 30 = 20
+	if fmt.Sprintf("%.1f", column.AvgDuration) != "20.0" {
+		t.Errorf("expected run-weighted avg duration 20s, got %f", column.AvgDuration)
+	}
+	if result, ok := column.Agents["scanner"]; !ok || result.Score != 8.5 || !result.Passed {
+		t.Errorf("unexpected scanner result: %+v", result)
+	}
+}
+
+func TestBuildComparisonColumnNoRuns(t *testing.T) {
+	column := buildComparisonColumn("gpt-4o", completedReport(1, 0, 0), nil)
+	if column.AvgCostPerRun != 0 || column.AvgDuration != 0 {
+		t.Errorf("expected zero averages with no details, got %+v", column)
+	}
+}
+
+func TestRecommendModelPicksCheapestQualifying(t *testing.T) {
+	columns := []modelComparisonColumn{
+		{Model: "gpt-4o", TeamScore: 9.0, AvgCostPerRun: 0.10},
+		{Model: "gemini-2.5-pro", TeamScore: 7.5, AvgCostPerRun: 0.03},
+		{Model: "gpt-5-mini", TeamScore: 6.0, AvgCostPerRun: 0.01},
+	}
+
+	recommended := recommendModel(columns, 7.0)
+	if recommended == nil || recommended.Model != "gemini-2.5-pro" {
+		t.Errorf("expected gemini-2.5-pro (cheapest above threshold), got %+v", recommended)
+	}
+}
+
+func TestRecommendModelSkipsFailedEvaluations(t *testing.T) {
+	columns := []modelComparisonColumn{
+		{Model: "gpt-4o", TeamScore: 9.0, AvgCostPerRun: 0.10},
+		{Model: "broken", Err: fmt.Errorf("no runs"), AvgCostPerRun: 0},
+	}
+
+	recommended := recommendModel(columns, 7.0)
+	if recommended == nil || recommended.Model != "gpt-4o" {
+		t.Errorf("expected gpt-4o, got %+v", recommended)
+	}
+}
+
+func TestRecommendModelNoneQualify(t *testing.T) {
+	columns := []modelComparisonColumn{
+		{Model: "gpt-5-mini", TeamScore: 5.0, AvgCostPerRun: 0.01},
+	}
+	if recommended := recommendModel(columns, 7.0); recommended != nil {
+		t.Errorf("expected nil recommendation, got %+v", recommended)
+	}
+}
+
+func TestSplitModelList(t *testing.T) {
+	models := splitModelList(" gpt-4o, gemini-2.5-pro ,,")
+	if len(models) != 2 || models[0] != "gpt-4o" || models[1] != "gemini-2.5-pro" {
+		t.Errorf("unexpected models: %v", models)
+	}
+}
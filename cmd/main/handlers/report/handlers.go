@@ -77,7 +77,7 @@ func (h *ReportHandler) RunReportGenerate(cmd *cobra.Command, args []string) err
 		return fmt.Errorf("invalid report ID: %v", err)
 	}
 
-	err = h.generateReport(reportID)
+	err = h.generateReport(reportID, common.OutputFormat(cmd))
 
 	// Track telemetry
 	if h.telemetryService != nil {
@@ -90,12 +90,17 @@ func (h *ReportHandler) RunReportGenerate(cmd *cobra.Command, args []string) err
 // RunReportList lists all reports
 func (h *ReportHandler) RunReportList(cmd *cobra.Command, args []string) error {
 	startTime := time.Now()
-	styles := common.GetCLIStyles(h.themeManager)
-	banner := styles.Banner.Render("📋 Reports")
-	fmt.Println(banner)
-
 	envName, _ := cmd.Flags().GetString("environment")
-	err := h.listReports(envName)
+
+	// Machine formats emit only the rows, no banners or styling
+	format := common.OutputFormat(cmd)
+	if !common.MachineFormat(format) {
+		styles := common.GetCLIStyles(h.themeManager)
+		banner := styles.Banner.Render("📋 Reports")
+		fmt.Println(banner)
+	}
+
+	err := h.listReports(envName, format)
 
 	// Track telemetry
 	if h.telemetryService != nil {
@@ -131,6 +136,64 @@ func (h *ReportHandler) RunReportShow(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// RunReportShare generates an expiring read-only share link for a report
+func (h *ReportHandler) RunReportShare(cmd *cobra.Command, args []string) error {
+	startTime := time.Now()
+	styles := common.GetCLIStyles(h.themeManager)
+	banner := styles.Banner.Render("🔗 Share Report")
+	fmt.Println(banner)
+
+	if len(args) != 1 {
+		return fmt.Errorf("usage: stn report share <report_id>")
+	}
+
+	reportID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid report ID: %v", err)
+	}
+
+	expires, _ := cmd.Flags().GetString("expires")
+	err = h.shareReport(reportID, expires)
+
+	// Track telemetry
+	if h.telemetryService != nil {
+		h.telemetryService.TrackCLICommand("report", "share", err == nil, time.Since(startTime).Milliseconds())
+	}
+
+	return err
+}
+
+// shareReport creates the share link and prints the public URL
+func (h *ReportHandler) shareReport(reportID int64, expires string) error {
+	ttl, err := services.ParseShareExpiry(expires)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load station config: %v", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	shareService := services.NewShareService(repos)
+
+	link, err := shareService.CreateShareLink(context.Background(), services.ShareResourceReport, reportID, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to create share link: %v", err)
+	}
+
+	fmt.Printf("Share URL: http://localhost:%d/share/%s\n", cfg.APIPort, link.Token)
+	fmt.Printf("Expires:   %s\n", link.ExpiresAt.Format("2006-01-02 15:04:05 MST"))
+	return nil
+}
+
 // createReport creates a new report
 func (h *ReportHandler) createReport(envName, reportName, description string) error {
 	cfg, err := config.Load()
@@ -152,30 +215,11 @@ func (h *ReportHandler) createReport(envName, reportName, description string) er
 		return fmt.Errorf("environment not found: %v", err)
 	}
 
-	// Create default team criteria
-	teamCriteria := services.TeamCriteria{
-		Goal: "Evaluate the overall performance and quality of all agents in the environment",
-		Criteria: map[string]services.EvaluationCriterion{
-			"effectiveness": {
-				Weight:      0.4,
-				Description: "How well agents accomplish their intended tasks",
-				Threshold:   7.0,
-			},
-			"reliability": {
-				Weight:      0.3,
-				Description: "Consistency and success rate of agent executions",
-				Threshold:   8.0,
-			},
-			"efficiency": {
-				Weight:      0.3,
-				Description: "Resource usage and execution speed",
-				Threshold:   7.0,
-			},
-		},
+	teamCriteriaJSON, err := defaultTeamCriteriaJSON()
+	if err != nil {
+		return err
 	}
 
-	teamCriteriaJSON, _ := json.Marshal(teamCriteria)
-
 	descSQL := sql.NullString{String: description, Valid: description != ""}
 
 	// Create report
@@ -183,7 +227,7 @@ func (h *ReportHandler) createReport(envName, reportName, description string) er
 		Name:          reportName,
 		Description:   descSQL,
 		EnvironmentID: env.ID,
-		TeamCriteria:  string(teamCriteriaJSON),
+		TeamCriteria:  teamCriteriaJSON,
 		AgentCriteria: sql.NullString{Valid: false},
 		JudgeModel:    sql.NullString{String: "gpt-5-mini", Valid: true},
 	})
@@ -201,8 +245,39 @@ func (h *ReportHandler) createReport(envName, reportName, description string) er
 	return nil
 }
 
+// defaultTeamCriteriaJSON returns the standard team evaluation criteria
+// used when a report is created without custom criteria
+func defaultTeamCriteriaJSON() (string, error) {
+	teamCriteria := services.TeamCriteria{
+		Goal: "Evaluate the overall performance and quality of all agents in the environment",
+		Criteria: map[string]services.EvaluationCriterion{
+			"effectiveness": {
+				Weight:      0.4,
+				Description: "How well agents accomplish their intended tasks",
+				Threshold:   7.0,
+			},
+			"reliability": {
+				Weight:      0.3,
+				Description: "Consistency and success rate of agent executions",
+				Threshold:   8.0,
+			},
+			"efficiency": {
+				Weight:      0.3,
+				Description: "Resource usage and execution speed",
+				Threshold:   7.0,
+			},
+		},
+	}
+
+	teamCriteriaJSON, err := json.Marshal(teamCriteria)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode team criteria: %v", err)
+	}
+	return string(teamCriteriaJSON), nil
+}
+
 // generateReport generates a report
-func (h *ReportHandler) generateReport(reportID int64) error {
+func (h *ReportHandler) generateReport(reportID int64, outputFormat string) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load station config: %v", err)
@@ -231,8 +306,11 @@ func (h *ReportHandler) generateReport(reportID int64) error {
 		return fmt.Errorf("report generation already in progress")
 	}
 
-	// Create report generator service
-	reportGenerator := services.NewReportGenerator(repos, database.Conn(), nil) // Uses default config
+	// Create report generator with per-agent evaluation progress
+	progress := common.NewProgressTracker("Evaluating agents", outputFormat)
+	reportGenerator := services.NewReportGenerator(repos, database.Conn(), &services.ReportGeneratorConfig{
+		Progress: progress.Update,
+	})
 
 	// Generate report
 	styles := common.GetCLIStyles(h.themeManager)
@@ -240,6 +318,7 @@ func (h *ReportHandler) generateReport(reportID int64) error {
 	fmt.Println()
 
 	err = reportGenerator.GenerateReport(context.Background(), reportID)
+	progress.Done()
 	if err != nil {
 		return fmt.Errorf("failed to generate report: %v", err)
 	}
@@ -263,7 +342,7 @@ func (h *ReportHandler) generateReport(reportID int64) error {
 }
 
 // listReports lists all reports (simplified version)
-func (h *ReportHandler) listReports(envName string) error {
+func (h *ReportHandler) listReports(envName, format string) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load station config: %v", err)
@@ -300,6 +379,32 @@ func (h *ReportHandler) listReports(envName string) error {
 		}
 	}
 
+	// Machine formats emit only the rows, no banners or styling
+	if common.MachineFormat(format) {
+		columns := []string{"id", "name", "environment", "status", "team_score", "agents_analyzed", "runs_analyzed"}
+		rows := make([][]string, 0, len(reports))
+		for _, report := range reports {
+			envNameDisplay := ""
+			if env, err := repos.Environments.GetByID(report.EnvironmentID); err == nil {
+				envNameDisplay = env.Name
+			}
+			teamScore := ""
+			if report.TeamScore.Valid {
+				teamScore = fmt.Sprintf("%.1f", report.TeamScore.Float64)
+			}
+			rows = append(rows, []string{
+				fmt.Sprintf("%d", report.ID),
+				report.Name,
+				envNameDisplay,
+				report.Status,
+				teamScore,
+				fmt.Sprintf("%d", report.TotalAgentsAnalyzed.Int64),
+				fmt.Sprintf("%d", report.TotalRunsAnalyzed.Int64),
+			})
+		}
+		return common.RenderList(format, columns, rows)
+	}
+
 	if len(reports) == 0 {
 		fmt.Println("No reports found.")
 		fmt.Println()
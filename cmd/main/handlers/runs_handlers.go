@@ -2,14 +2,24 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"station/cmd/main/handlers/common"
 	"station/internal/config"
 	"station/internal/db"
 	"station/internal/db/repositories"
+	"station/internal/services"
 	"station/internal/theme"
+	"station/pkg/models"
 )
 
 // RunsHandler handles runs-related CLI commands
@@ -23,14 +33,76 @@ func NewRunsHandler(themeManager *theme.ThemeManager) *RunsHandler {
 
 // RunRunsList lists agent runs
 func (h *RunsHandler) RunRunsList(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	// Machine formats emit only the rows, no banners or styling
+	format := common.OutputFormat(cmd)
+	if common.MachineFormat(format) {
+		return h.listRunsMachine(limit, format)
+	}
+
 	styles := getCLIStyles(h.themeManager)
 	banner := styles.Banner.Render("🏃 Agent Runs")
 	fmt.Println(banner)
 
-	limit, _ := cmd.Flags().GetInt("limit")
-
 	fmt.Println(styles.Info.Render("🏠 Listing local runs"))
-	return h.listRunsLocal(limit)
+	if err := h.listRunsLocal(limit); err != nil {
+		return err
+	}
+
+	// Aggregate remote stations when requested (stn remote add <name> <endpoint>)
+	remoteName, _ := cmd.Flags().GetString("remote")
+	allRemotes, _ := cmd.Flags().GetBool("all-remotes")
+	if remoteName != "" || allRemotes {
+		return h.listRunsFromRemotes(remoteName, allRemotes, limit)
+	}
+	return nil
+}
+
+// listRunsFromRemotes fetches recent runs from configured remote stations and
+// prints them after the local rows, labeled with the remote's name.
+func (h *RunsHandler) listRunsFromRemotes(remoteName string, allRemotes bool, limit int) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	remotes, err := common.ResolveRemotes(cfg, remoteName, allRemotes)
+	if err != nil {
+		return err
+	}
+
+	if limit <= 0 {
+		limit = 50
+	}
+
+	styles := getCLIStyles(h.themeManager)
+	for _, name := range common.SortedRemoteNames(remotes) {
+		fmt.Printf("\n🌐 Remote '%s' (%s):\n", name, remotes[name].Endpoint)
+
+		runs, err := common.FetchRemoteRuns(name, remotes[name], limit)
+		if err != nil {
+			fmt.Printf("• ⚠️  %v\n", err)
+			continue
+		}
+		if len(runs) == 0 {
+			fmt.Println("• No runs found")
+			continue
+		}
+
+		for _, run := range runs {
+			statusIcon := h.getStatusIcon(run.Status)
+			fmt.Printf("• Run %d: %s %s", run.ID, statusIcon, styles.Success.Render(run.AgentName))
+			fmt.Printf(" [Origin: %s, %s]", run.Remote, run.StartedAt.Format("Jan 2 15:04"))
+			if run.CompletedAt != nil {
+				duration := run.CompletedAt.Sub(run.StartedAt)
+				fmt.Printf(" (%.1fs)", duration.Seconds())
+			}
+			fmt.Printf("\n  Task: %s\n", h.truncateString(run.Task, 80))
+		}
+	}
+
+	return nil
 }
 
 // RunRunsInspect inspects a specific run
@@ -46,6 +118,12 @@ func (h *RunsHandler) RunRunsInspect(cmd *cobra.Command, args []string) error {
 
 	verbose, _ := cmd.Flags().GetBool("verbose")
 
+	timeline, _ := cmd.Flags().GetBool("timeline")
+	if timeline {
+		outputPath, _ := cmd.Flags().GetString("output")
+		return h.exportRunTimeline(runID, outputPath)
+	}
+
 	styles := getCLIStyles(h.themeManager)
 	banner := styles.Banner.Render("🔍 Run Details")
 	fmt.Println(banner)
@@ -54,6 +132,445 @@ func (h *RunsHandler) RunRunsInspect(cmd *cobra.Command, args []string) error {
 	return h.inspectRunLocal(runID, verbose)
 }
 
+// exportRunTimeline writes a self-contained HTML Gantt visualization of the
+// run's turns, tool calls, and token accumulation (--timeline flag)
+func (h *RunsHandler) exportRunTimeline(runID int64, outputPath string) error {
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("run-%d-timeline.html", runID)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	timelineService := services.NewRunTimelineService(repos)
+
+	html, err := timelineService.GenerateHTML(context.Background(), runID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, []byte(html), 0644); err != nil {
+		return fmt.Errorf("failed to write timeline: %w", err)
+	}
+
+	fmt.Printf("✅ Timeline for run %d written to %s\n", runID, outputPath)
+	return nil
+}
+
+// RunRunsShare generates an expiring read-only share link for a run
+func (h *RunsHandler) RunRunsShare(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("run ID is required")
+	}
+
+	runID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid run ID: %s", args[0])
+	}
+
+	expires, _ := cmd.Flags().GetString("expires")
+	ttl, err := services.ParseShareExpiry(expires)
+	if err != nil {
+		return err
+	}
+
+	styles := getCLIStyles(h.themeManager)
+	banner := styles.Banner.Render("🔗 Share Run")
+	fmt.Println(banner)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	shareService := services.NewShareService(repos)
+
+	link, err := shareService.CreateShareLink(context.Background(), services.ShareResourceRun, runID, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	fmt.Printf("Share URL: http://localhost:%d/share/%s\n", cfg.APIPort, link.Token)
+	fmt.Printf("Expires:   %s\n", link.ExpiresAt.Format("2006-01-02 15:04:05 MST"))
+	fmt.Println(styles.Info.Render("Anyone with this URL can view a redacted, read-only copy of the run while the server is running."))
+	return nil
+}
+
+// RunRunsExport exports runs as flattened, schema-stable files for data warehouses
+func (h *RunsHandler) RunRunsExport(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	sinceStr, _ := cmd.Flags().GetString("since")
+	outputDir, _ := cmd.Flags().GetString("output")
+	destination, _ := cmd.Flags().GetString("push")
+
+	var since time.Duration
+	if sinceStr != "" {
+		parsed, err := services.ParseShareExpiry(sinceStr)
+		if err != nil {
+			return fmt.Errorf("invalid --since %q (expected e.g. 30d, 12h)", sinceStr)
+		}
+		since = parsed
+	}
+
+	styles := getCLIStyles(h.themeManager)
+	banner := styles.Banner.Render("📦 Export Runs")
+	fmt.Println(banner)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	exportService := services.NewRunExportService(repos)
+
+	result, err := exportService.Export(context.Background(), services.RunExportOptions{
+		Format:      format,
+		Since:       since,
+		OutputDir:   outputDir,
+		Destination: destination,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Exported %d runs, %d tool calls, %d execution steps, %d benchmark metrics\n",
+		result.Runs, result.ToolCalls, result.ExecutionSteps, result.BenchmarkMetrics)
+	for _, file := range result.Files {
+		fmt.Printf("• %s\n", file)
+	}
+	if destination != "" {
+		fmt.Printf("☁️  Pushed to %s\n", destination)
+	}
+	return nil
+}
+
+// RunRunsTriage runs LLM root-cause analysis on a failed run, or aggregates
+// stored verdicts with --summary
+func (h *RunsHandler) RunRunsTriage(cmd *cobra.Command, args []string) error {
+	summary, _ := cmd.Flags().GetBool("summary")
+
+	styles := getCLIStyles(h.themeManager)
+	banner := styles.Banner.Render("🩺 Run Triage")
+	fmt.Println(banner)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	triageService := services.NewRunTriageService(repos)
+
+	if summary {
+		return h.printTriageSummary(triageService)
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("run ID is required (or use --summary for aggregated verdicts)")
+	}
+	runID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid run ID: %s", args[0])
+	}
+
+	fmt.Println(styles.Info.Render("🔍 Analyzing failure with configured model..."))
+	result, err := triageService.Triage(context.Background(), runID)
+	if err != nil {
+		return err
+	}
+
+	h.printTriageResult(result)
+	fmt.Println(styles.Info.Render("Verdict stored; it will appear in 'stn runs inspect -v' and 'stn runs triage --summary'."))
+	return nil
+}
+
+func (h *RunsHandler) printTriageResult(result *services.TriageResult) {
+	fmt.Printf("\n📋 Category:      %s\n", result.Category)
+	fmt.Printf("💥 Root cause:    %s\n", result.RootCause)
+	fmt.Printf("🔧 Suggested fix: %s\n", result.SuggestedFix)
+	fmt.Printf("📊 Confidence:    %.0f%%\n", result.Confidence*100)
+}
+
+func (h *RunsHandler) printTriageSummary(triageService *services.RunTriageService) error {
+	summary, err := triageService.Summary()
+	if err != nil {
+		return err
+	}
+	if summary.TriagedRuns == 0 {
+		fmt.Println("No triaged runs yet. Run 'stn runs triage <id>' on a failed run first.")
+		return nil
+	}
+
+	fmt.Printf("\n🗂  %d triaged run(s) by category:\n", summary.TriagedRuns)
+	for category, count := range summary.ByCategory {
+		fmt.Printf("• %-11s %d\n", category+":", count)
+	}
+
+	fmt.Println("\nRecent verdicts:")
+	for _, result := range summary.Recent {
+		fmt.Printf("• Run %d [%s] %s\n", result.RunID, result.Category, result.RootCause)
+	}
+	return nil
+}
+
+// RunRunsAnnotate records human feedback (rating, thumbs, comment) on a run
+// and optionally seeds a benchmark task from it (--benchmark)
+func (h *RunsHandler) RunRunsAnnotate(cmd *cobra.Command, args []string) error {
+	runID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid run ID: %s", args[0])
+	}
+
+	ratingFlag, _ := cmd.Flags().GetInt("rating")
+	thumbsFlag, _ := cmd.Flags().GetString("thumbs")
+	comment, _ := cmd.Flags().GetString("comment")
+	benchmark, _ := cmd.Flags().GetBool("benchmark")
+
+	var rating *int64
+	if ratingFlag != 0 {
+		value := int64(ratingFlag)
+		rating = &value
+	}
+	var thumbs *string
+	if thumbsFlag != "" {
+		thumbs = &thumbsFlag
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	createdBy := os.Getenv("USER")
+	if createdBy == "" {
+		createdBy = "operator"
+	}
+
+	repos := repositories.New(database)
+	feedbackService := services.NewRunFeedbackService(repos)
+
+	annotation, err := feedbackService.Annotate(context.Background(), runID, rating, thumbs, comment, createdBy)
+	if err != nil {
+		return err
+	}
+
+	styles := getCLIStyles(h.themeManager)
+	fmt.Printf("✅ %s\n", styles.Success.Render(fmt.Sprintf("Feedback recorded for run %d (%s)", runID, h.formatAnnotation(annotation))))
+
+	if benchmark {
+		taskName, err := feedbackService.SeedBenchmarkTask(context.Background(), runID, annotation)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🧪 Benchmark task '%s' created; evaluate it with 'stn benchmark evaluate'\n", taskName)
+	}
+	return nil
+}
+
+// formatAnnotation renders an annotation's rating/thumbs/comment on one line
+func (h *RunsHandler) formatAnnotation(annotation *models.RunAnnotation) string {
+	parts := []string{}
+	if annotation.Rating != nil {
+		parts = append(parts, fmt.Sprintf("%d/5", *annotation.Rating))
+	}
+	if annotation.Thumbs != nil {
+		if *annotation.Thumbs == repositories.ThumbsUp {
+			parts = append(parts, "👍")
+		} else {
+			parts = append(parts, "👎")
+		}
+	}
+	if annotation.Comment != "" {
+		parts = append(parts, fmt.Sprintf("%q", annotation.Comment))
+	}
+	return strings.Join(parts, " ")
+}
+
+// RunRunsCancel cancels a running agent execution
+func (h *RunsHandler) RunRunsCancel(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("run ID is required")
+	}
+
+	runID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid run ID: %s", args[0])
+	}
+
+	styles := getCLIStyles(h.themeManager)
+	banner := styles.Banner.Render("🛑 Cancel Run")
+	fmt.Println(banner)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	// Prefer the server API so an execution running inside `stn serve` is
+	// aborted cleanly; fall back to the local database if no server is up
+	if handled, err := h.cancelRunViaServer(runID, cfg.APIPort); handled {
+		return err
+	}
+
+	fmt.Println(styles.Info.Render("🏠 Server not available, cancelling local run record"))
+	return h.cancelRunLocal(runID)
+}
+
+// RunRunsApprove approves the pending gated tool call for a run
+func (h *RunsHandler) RunRunsApprove(cmd *cobra.Command, args []string) error {
+	return h.decideToolApproval(cmd, args, true)
+}
+
+// RunRunsDeny denies the pending gated tool call for a run
+func (h *RunsHandler) RunRunsDeny(cmd *cobra.Command, args []string) error {
+	return h.decideToolApproval(cmd, args, false)
+}
+
+// decideToolApproval resolves a run's pending tool approval (see the
+// approval_tools frontmatter gate); the blocked execution picks up the
+// decision within a poll interval
+func (h *RunsHandler) decideToolApproval(cmd *cobra.Command, args []string, approve bool) error {
+	runID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid run ID: %s", args[0])
+	}
+	comment, _ := cmd.Flags().GetString("comment")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	decidedBy := os.Getenv("USER")
+	if decidedBy == "" {
+		decidedBy = "operator"
+	}
+
+	repos := repositories.New(database)
+	request, err := services.NewToolApprovalService(repos).Decide(runID, approve, decidedBy, comment)
+	if err != nil {
+		return err
+	}
+
+	styles := getCLIStyles(h.themeManager)
+	if approve {
+		fmt.Printf("✅ %s\n", styles.Success.Render(fmt.Sprintf("Approved %s for run %d; execution will resume shortly", request.ToolName, runID)))
+	} else {
+		fmt.Printf("🚫 %s\n", styles.Error.Render(fmt.Sprintf("Denied %s for run %d; the agent will be told the call was rejected", request.ToolName, runID)))
+	}
+	return nil
+}
+
+// cancelRunViaServer requests cancellation through the running server's API.
+// Returns handled=false if the server is unreachable.
+func (h *RunsHandler) cancelRunViaServer(runID int64, apiPort int) (bool, error) {
+	if apiPort == 0 {
+		apiPort = 8080
+	}
+
+	url := fmt.Sprintf("http://localhost:%d/api/v1/runs/%d/cancel", apiPort, runID)
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Post(url, "application/json", nil)
+	if err != nil {
+		return false, err // Server not available
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return true, fmt.Errorf("cancel request failed (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	styles := getCLIStyles(h.themeManager)
+	fmt.Printf("✅ %s\n", styles.Success.Render(fmt.Sprintf("Cancellation requested for run %d", runID)))
+	return true, nil
+}
+
+// cancelRunLocal marks a run as cancelled in the local database. This covers
+// stale "running" records left behind by interrupted executions.
+func (h *RunsHandler) cancelRunLocal(runID int64) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+
+	run, err := repos.AgentRuns.GetByID(context.Background(), runID)
+	if err != nil {
+		return fmt.Errorf("run %d not found", runID)
+	}
+
+	if run.Status != "running" && run.Status != "pending" {
+		return fmt.Errorf("run %d is not active (status: %s)", runID, run.Status)
+	}
+
+	// Cancel in-process first in case this CLI process is executing the run
+	services.CancelActiveRun(runID)
+
+	completedAt := time.Now()
+	errorMsg := "Run cancelled via stn runs cancel"
+	err = repos.AgentRuns.UpdateCompletionWithMetadata(
+		context.Background(), runID, errorMsg, 0, nil, nil,
+		services.RunStatusCancelled, &completedAt,
+		nil, nil, nil, nil, nil, nil, &errorMsg,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update run status: %w", err)
+	}
+
+	styles := getCLIStyles(h.themeManager)
+	fmt.Printf("✅ %s\n", styles.Success.Render(fmt.Sprintf("Run %d marked as cancelled", runID)))
+	return nil
+}
+
 // Local operations
 
 func (h *RunsHandler) listRunsLocal(limit int) error {
@@ -101,6 +618,53 @@ func (h *RunsHandler) listRunsLocal(limit int) error {
 	return nil
 }
 
+// listRunsMachine emits runs with a stable column set for --output
+// json/yaml/csv consumers
+func (h *RunsHandler) listRunsMachine(limit int, format string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	if limit <= 0 {
+		limit = 50
+	}
+
+	runs, err := repos.AgentRuns.ListRecent(context.Background(), int64(limit))
+	if err != nil {
+		return fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	columns := []string{"id", "agent_id", "agent_name", "status", "started_at", "completed_at", "duration_seconds", "task"}
+	rows := make([][]string, 0, len(runs))
+	for _, run := range runs {
+		completedAt := ""
+		duration := ""
+		if run.CompletedAt != nil {
+			completedAt = run.CompletedAt.Format(time.RFC3339)
+			duration = fmt.Sprintf("%.1f", run.CompletedAt.Sub(run.StartedAt).Seconds())
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", run.ID),
+			fmt.Sprintf("%d", run.AgentID),
+			run.AgentName,
+			run.Status,
+			run.StartedAt.Format(time.RFC3339),
+			completedAt,
+			duration,
+			run.Task,
+		})
+	}
+	return common.RenderList(format, columns, rows)
+}
+
 func (h *RunsHandler) inspectRunLocal(runID int64, verbose bool) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -140,6 +704,18 @@ func (h *RunsHandler) inspectRunLocal(runID int64, verbose bool) error {
 
 	fmt.Printf("Steps Taken: %d\n", run.StepsTaken)
 
+	// Pending tool approval (approval_tools frontmatter gate)
+	if run.Status == "awaiting_approval" {
+		if request, err := services.NewToolApprovalService(repos).GetRequest(run.ID); err == nil && request != nil && request.Status == services.ToolApprovalStatusPending {
+			fmt.Printf("\n⏸️  Awaiting approval for tool call: %s\n", styles.Info.Render(request.ToolName))
+			if len(request.Arguments) > 0 {
+				args, _ := json.MarshalIndent(request.Arguments, "", "  ")
+				fmt.Printf("Arguments:\n%s\n", string(args))
+			}
+			fmt.Printf("Decide with: stn runs approve %d  or  stn runs deny %d --comment \"reason\"\n", run.ID, run.ID)
+		}
+	}
+
 	if verbose {
 		// Show comprehensive details in verbose mode
 		fmt.Print("\n" + styles.Banner.Render("📊 Detailed Run Information") + "\n")
@@ -161,6 +737,45 @@ func (h *RunsHandler) inspectRunLocal(runID int64, verbose bool) error {
 			fmt.Printf("• Completed At: %s\n", run.CompletedAt.Format("Jan 2, 2006 15:04:05 MST"))
 			fmt.Printf("• Total Duration: %.2fs\n", duration.Seconds())
 		}
+		if seed, err := repos.AgentRuns.GetSeed(context.Background(), run.ID); err == nil && seed != nil {
+			fmt.Printf("• Seed: %d (replay with stn agent run --seed %d)\n", *seed, *seed)
+		}
+
+		// Token attribution per tool (estimated from captured tool outputs)
+		h.printTokenBreakdown(run.ToolCalls, run.TotalTokens)
+
+		// Stored triage verdict for failed runs (stn runs triage <id>)
+		triageService := services.NewRunTriageService(repos)
+		if triage, err := triageService.GetTriage(run.ID); err == nil && triage != nil {
+			fmt.Printf("\n🩺 Triage Verdict:\n")
+			h.printTriageResult(triage)
+		}
+
+		// Files attached to the run (stn agent run --file)
+		attachmentService := services.NewRunAttachmentService(repos)
+		if attachments, err := attachmentService.GetRunAttachments(run.ID); err == nil && len(attachments) > 0 {
+			fmt.Printf("\n📎 Attachments (%d):\n", len(attachments))
+			for _, attachment := range attachments {
+				fmt.Printf("• %s (%s, %d bytes", attachment.Name, attachment.Kind, attachment.SizeBytes)
+				if attachment.Truncated {
+					fmt.Printf(", truncated in context")
+				}
+				fmt.Printf(")")
+				if attachment.StoredPath != "" {
+					fmt.Printf(" → %s", attachment.StoredPath)
+				}
+				fmt.Println()
+			}
+		}
+
+		// Human feedback (stn runs annotate / UI thumbs)
+		if annotations, err := repos.RunAnnotations.ListByRun(context.Background(), run.ID); err == nil && len(annotations) > 0 {
+			fmt.Printf("\n🗣  Human Feedback (%d):\n", len(annotations))
+			for _, annotation := range annotations {
+				fmt.Printf("• %s — %s, %s\n", h.formatAnnotation(annotation), annotation.CreatedBy,
+					annotation.CreatedAt.Format("Jan 2 15:04"))
+			}
+		}
 
 		// Task Information
 		fmt.Printf("\n📋 Task:\n")
@@ -183,6 +798,71 @@ func (h *RunsHandler) inspectRunLocal(runID int64, verbose bool) error {
 	return nil
 }
 
+// printTokenBreakdown aggregates estimated token usage per tool from the
+// captured tool calls and prints a breakdown sorted by heaviest tool first,
+// so runs with bloated context are easy to diagnose. Older runs captured
+// before per-call estimates were recorded are skipped silently.
+func (h *RunsHandler) printTokenBreakdown(toolCalls *models.JSONArray, totalTokens *int64) {
+	if toolCalls == nil || len(*toolCalls) == 0 {
+		return
+	}
+
+	type toolUsage struct {
+		name   string
+		calls  int
+		tokens int64
+	}
+	usageByTool := make(map[string]*toolUsage)
+	var estimatedTotal int64
+
+	for _, entry := range *toolCalls {
+		call, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := call["tool_name"].(string)
+		if name == "" {
+			continue
+		}
+		usage, exists := usageByTool[name]
+		if !exists {
+			usage = &toolUsage{name: name}
+			usageByTool[name] = usage
+		}
+		usage.calls++
+		// JSON round-trip through the database stores numbers as float64
+		if tokens, ok := call["estimated_tokens"].(float64); ok {
+			usage.tokens += int64(tokens)
+			estimatedTotal += int64(tokens)
+		}
+	}
+
+	if estimatedTotal == 0 {
+		return
+	}
+
+	breakdown := make([]*toolUsage, 0, len(usageByTool))
+	for _, usage := range usageByTool {
+		breakdown = append(breakdown, usage)
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		if breakdown[i].tokens != breakdown[j].tokens {
+			return breakdown[i].tokens > breakdown[j].tokens
+		}
+		return breakdown[i].name < breakdown[j].name
+	})
+
+	fmt.Printf("\n🧮 Token Usage by Tool (estimated from tool outputs):\n")
+	for _, usage := range breakdown {
+		fmt.Printf("• %s: ~%d tokens across %d call(s) (%.1f%% of tool output)\n",
+			usage.name, usage.tokens, usage.calls,
+			float64(usage.tokens)/float64(estimatedTotal)*100)
+	}
+	if totalTokens != nil && *totalTokens > 0 {
+		fmt.Printf("• Tool outputs account for ~%d of %d total tokens\n", estimatedTotal, *totalTokens)
+	}
+}
+
 // Helper functions
 
 func (h *RunsHandler) getStatusIcon(status string) string {
@@ -195,6 +875,8 @@ func (h *RunsHandler) getStatusIcon(status string) string {
 		return "🔄"
 	case "pending":
 		return "⏳"
+	case "awaiting_approval":
+		return "⏸️"
 	default:
 		return "❓"
 	}
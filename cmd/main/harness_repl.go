@@ -449,7 +449,8 @@ func handleReplCommand(ctx context.Context, session *ReplSession, skillsMW *skil
 			} else {
 				session.MessageCount = 0
 				session.TotalTokens = 0
-				fmt.Println("History cleared.\n")
+				fmt.Println("History cleared.")
+				fmt.Println()
 			}
 		} else {
 			fmt.Println("History store not initialized")
@@ -484,7 +485,8 @@ func handleReplCommand(ctx context.Context, session *ReplSession, skillsMW *skil
 
 	case "/files":
 		fmt.Println("\nWorkspace file listing not implemented yet")
-		fmt.Println("Use: /tools to see available file operations\n")
+		fmt.Println("Use: /tools to see available file operations")
+		fmt.Println()
 
 	default:
 		fmt.Printf("Unknown command: %s (try /help)\n\n", cmdName)
@@ -509,8 +511,8 @@ REPL Commands:
   /load FILE   Load session state from file
   /exit, /q    Exit REPL
 
-Any other input is sent to the agent as a task.
-`)
+Any other input is sent to the agent as a task.`)
+	fmt.Println()
 }
 
 func printReplSessionStatus(session *ReplSession) {
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// stn init --import-from claude|cursor|vscode bootstraps an environment from
+// the MCP servers a user already has configured in their editor/client.
+// Server definitions are converted into a Station template config, secret
+// env values are replaced with {{ .VAR }} references so the first sync
+// prompts for them, and the first sync runs automatically.
+
+// mcpClientServer is one server entry in a client's MCP config file
+type mcpClientServer struct {
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env"`
+	Type    string            `json:"type,omitempty"`
+	URL     string            `json:"url,omitempty"`
+}
+
+// mcpClientConfig covers the config shapes of the supported clients: Claude
+// Desktop and Cursor use "mcpServers", VS Code's mcp.json uses "servers"
+type mcpClientConfig struct {
+	MCPServers map[string]mcpClientServer `json:"mcpServers"`
+	Servers    map[string]mcpClientServer `json:"servers"`
+}
+
+// mcpClientConfigPaths returns the candidate config file locations for a
+// client, most likely first
+func mcpClientConfigPaths(client string) ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+
+	switch client {
+	case "claude":
+		if runtime.GOOS == "darwin" {
+			return []string{filepath.Join(home, "Library", "Application Support", "Claude", "claude_desktop_config.json")}, nil
+		}
+		return []string{filepath.Join(home, ".config", "Claude", "claude_desktop_config.json")}, nil
+	case "cursor":
+		return []string{filepath.Join(home, ".cursor", "mcp.json")}, nil
+	case "vscode":
+		if runtime.GOOS == "darwin" {
+			return []string{filepath.Join(home, "Library", "Application Support", "Code", "User", "mcp.json")}, nil
+		}
+		return []string{filepath.Join(home, ".config", "Code", "User", "mcp.json")}, nil
+	}
+	return nil, fmt.Errorf("unsupported client %q (supported: claude, cursor, vscode)", client)
+}
+
+// loadMCPClientServers reads and parses a client's MCP config file
+func loadMCPClientServers(client string) (map[string]mcpClientServer, string, error) {
+	paths, err := mcpClientConfigPaths(client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var configPath string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			configPath = path
+			break
+		}
+	}
+	if configPath == "" {
+		return nil, "", fmt.Errorf("no %s MCP config found (looked in %s)", client, strings.Join(paths, ", "))
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	var cfg mcpClientConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+
+	servers := cfg.MCPServers
+	if len(servers) == 0 {
+		servers = cfg.Servers
+	}
+	if len(servers) == 0 {
+		return nil, "", fmt.Errorf("%s contains no MCP servers", configPath)
+	}
+	return servers, configPath, nil
+}
+
+// looksLikeSecretEnv reports whether an env key carries a credential
+func looksLikeSecretEnv(key string) bool {
+	secretKeywords := []string{"TOKEN", "KEY", "SECRET", "PASSWORD", "CREDENTIAL", "AUTH"}
+	upper := strings.ToUpper(key)
+	for _, keyword := range secretKeywords {
+		if strings.Contains(upper, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertClientServers turns client server definitions into a Station
+// template config. Secret-looking env values become {{ .VAR }} references
+// (returned in secrets) so the first sync prompts for them instead of
+// copying credentials into the template. URL-only servers are skipped and
+// returned for reporting.
+func convertClientServers(servers map[string]mcpClientServer) (template map[string]interface{}, secrets []string, skipped []string) {
+	converted := make(map[string]interface{})
+	seenSecrets := make(map[string]bool)
+
+	for name, server := range servers {
+		if server.Command == "" {
+			skipped = append(skipped, name)
+			continue
+		}
+
+		entry := map[string]interface{}{
+			"command": server.Command,
+		}
+		if len(server.Args) > 0 {
+			entry["args"] = server.Args
+		}
+		if len(server.Env) > 0 {
+			env := make(map[string]string, len(server.Env))
+			for key, value := range server.Env {
+				if looksLikeSecretEnv(key) {
+					env[key] = fmt.Sprintf("{{ .%s }}", key)
+					if !seenSecrets[key] {
+						seenSecrets[key] = true
+						secrets = append(secrets, key)
+					}
+				} else {
+					env[key] = value
+				}
+			}
+			entry["env"] = env
+		}
+		converted[name] = entry
+	}
+
+	return map[string]interface{}{"mcpServers": converted}, secrets, skipped
+}
+
+// importMCPClientConfig converts a client's MCP servers into a template
+// config in the default environment and returns the number imported
+func importMCPClientConfig(client, workspaceDir string) (int, error) {
+	servers, configPath, err := loadMCPClientServers(client)
+	if err != nil {
+		return 0, err
+	}
+	fmt.Printf("   📥 Importing %d MCP server(s) from %s\n", len(servers), configPath)
+
+	template, secrets, skipped := convertClientServers(servers)
+	for _, name := range skipped {
+		fmt.Printf("   ⏭️  Skipped '%s': only stdio (command-based) servers can be imported\n", name)
+	}
+	mcpServers, _ := template["mcpServers"].(map[string]interface{})
+	if len(mcpServers) == 0 {
+		return 0, fmt.Errorf("no importable (command-based) MCP servers in %s", configPath)
+	}
+
+	data, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to serialize template: %w", err)
+	}
+
+	envDir := filepath.Join(workspaceDir, "environments", "default")
+	if err := os.MkdirAll(envDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create environment directory: %w", err)
+	}
+	templatePath := filepath.Join(envDir, fmt.Sprintf("imported-from-%s.json", client))
+	if err := os.WriteFile(templatePath, data, 0644); err != nil {
+		return 0, fmt.Errorf("failed to write template config: %w", err)
+	}
+	fmt.Printf("   ✅ Created %s\n", templatePath)
+
+	if len(secrets) > 0 {
+		fmt.Printf("   🔐 Secrets converted to template variables (you will be prompted during sync): %s\n",
+			strings.Join(secrets, ", "))
+	}
+	return len(mcpServers), nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestConvertClientServers(t *testing.T) {
+	servers := map[string]mcpClientServer{
+		"filesystem": {
+			Command: "npx",
+			Args:    []string{"-y", "@modelcontextprotocol/server-filesystem@latest", "/workspace"},
+		},
+		"github": {
+			Command: "npx",
+			Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+			Env: map[string]string{
+				"GITHUB_PERSONAL_ACCESS_TOKEN": "ghp_secret123",
+				"GITHUB_API_URL":               "https://api.github.com",
+			},
+		},
+		"remote": {
+			Type: "sse",
+			URL:  "https://example.com/mcp",
+		},
+	}
+
+	template, secrets, skipped := convertClientServers(servers)
+
+	if len(skipped) != 1 || skipped[0] != "remote" {
+		t.Errorf("expected URL-only server skipped, got %v", skipped)
+	}
+	if len(secrets) != 1 || secrets[0] != "GITHUB_PERSONAL_ACCESS_TOKEN" {
+		t.Errorf("expected the token env to be detected as a secret, got %v", secrets)
+	}
+
+	converted, ok := template["mcpServers"].(map[string]interface{})
+	if !ok || len(converted) != 2 {
+		t.Fatalf("expected 2 converted servers, got %v", template)
+	}
+
+	github, ok := converted["github"].(map[string]interface{})
+	if !ok {
+		t.Fatal("github server missing from converted template")
+	}
+	env, _ := github["env"].(map[string]string)
+	if env["GITHUB_PERSONAL_ACCESS_TOKEN"] != "{{ .GITHUB_PERSONAL_ACCESS_TOKEN }}" {
+		t.Errorf("secret env should become a template variable, got %q", env["GITHUB_PERSONAL_ACCESS_TOKEN"])
+	}
+	if env["GITHUB_API_URL"] != "https://api.github.com" {
+		t.Errorf("non-secret env should keep its value, got %q", env["GITHUB_API_URL"])
+	}
+
+	filesystem, _ := converted["filesystem"].(map[string]interface{})
+	if filesystem["command"] != "npx" {
+		t.Errorf("expected command preserved, got %v", filesystem["command"])
+	}
+}
+
+func TestConvertClientServersSharedSecret(t *testing.T) {
+	servers := map[string]mcpClientServer{
+		"a": {Command: "npx", Env: map[string]string{"API_KEY": "one"}},
+		"b": {Command: "uvx", Env: map[string]string{"API_KEY": "two"}},
+	}
+
+	_, secrets, _ := convertClientServers(servers)
+	sort.Strings(secrets)
+	if len(secrets) != 1 || secrets[0] != "API_KEY" {
+		t.Errorf("shared secret keys should be deduplicated, got %v", secrets)
+	}
+}
+
+func TestMCPClientConfigPaths(t *testing.T) {
+	for _, client := range []string{"claude", "cursor", "vscode"} {
+		paths, err := mcpClientConfigPaths(client)
+		if err != nil || len(paths) == 0 {
+			t.Errorf("expected config paths for %s, got %v (%v)", client, paths, err)
+		}
+	}
+
+	if _, err := mcpClientConfigPaths("zed"); err == nil {
+		t.Error("expected an error for an unsupported client")
+	}
+}
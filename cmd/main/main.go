@@ -23,6 +23,7 @@ var (
 	cfgFile              string
 	enableOTEL           bool   // Global flag to enable OTEL telemetry
 	otelEndpoint         string // OTEL endpoint override
+	logFormat            string // Log output format override (text or json)
 	themeManager         *theme.ThemeManager
 	telemetryService     *telemetry.TelemetryService // PostHog analytics
 	otelTelemetryService *services.TelemetryService  // OTEL distributed tracing
@@ -46,13 +47,18 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $XDG_CONFIG_HOME/station/config.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&enableOTEL, "enable-telemetry", false, "Enable OpenTelemetry distributed tracing (exports to Jaeger)")
 	rootCmd.PersistentFlags().StringVar(&otelEndpoint, "otel-endpoint", "", "OpenTelemetry OTLP endpoint (default: http://localhost:4318)")
+	rootCmd.PersistentFlags().String("output", "table", "Output format for list commands: table, json, yaml, or csv")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "Log output format: text or json (overrides log_format config)")
 
 	// Add subcommands
 	rootCmd.AddCommand(serveCmd)
+	serveCmd.AddCommand(serveDrainCmd)
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(bootstrapCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(envCmd)
+	rootCmd.AddCommand(gitopsCmd)
 	rootCmd.AddCommand(deployCmd)
 	initDeploySubcommands()
 	rootCmd.AddCommand(mcpCmd)
@@ -61,7 +67,14 @@ func init() {
 	rootCmd.AddCommand(runsCmd)
 	rootCmd.AddCommand(reportCmd)
 	rootCmd.AddCommand(benchmarkCmd)
+	rootCmd.AddCommand(modelsCmd)
+	rootCmd.AddCommand(registryCmd)
 	rootCmd.AddCommand(settingsCmd)
+	rootCmd.AddCommand(quotaCmd)
+	rootCmd.AddCommand(capacityCmd)
+	rootCmd.AddCommand(costsCmd)
+	rootCmd.AddCommand(composeCmd)
+	rootCmd.AddCommand(runnerCmd)
 	rootCmd.AddCommand(uiCmd)
 	rootCmd.AddCommand(developCmd)
 	rootCmd.AddCommand(blastoffCmd)
@@ -76,6 +89,8 @@ func init() {
 	rootCmd.AddCommand(latticeCmd)
 	rootCmd.AddCommand(githubCmd)
 	rootCmd.AddCommand(secretsCmd)
+	rootCmd.AddCommand(sshKeysCmd)
+	rootCmd.AddCommand(remoteCmd)
 	rootCmd.AddCommand(sessionCmd)
 	rootCmd.AddCommand(harnessCmd)
 
@@ -91,10 +106,19 @@ func init() {
 
 	mcpCmd.AddCommand(mcpListCmd)
 	mcpCmd.AddCommand(mcpToolsCmd)
+	mcpCmd.AddCommand(mcpCallCmd)
 	mcpCmd.AddCommand(mcpAddCmd)
 	mcpCmd.AddCommand(mcpAddOpenapiCmd)
+	mcpCmd.AddCommand(mcpAddOpenapiDirCmd)
 	mcpCmd.AddCommand(mcpDeleteCmd)
+	mcpCmd.AddCommand(mcpOutdatedCmd)
 	mcpCmd.AddCommand(mcpStatusCmd)
+	mcpCmd.AddCommand(mcpLogsCmd)
+	mcpCmd.AddCommand(mcpLintCmd)
+	mcpCmd.AddCommand(mcpAnnotateCmd)
+	mcpCmd.AddCommand(mcpPresetsCmd)
+	mcpPresetsCmd.AddCommand(mcpPresetsListCmd)
+	mcpPresetsCmd.AddCommand(mcpPresetsAddCmd)
 
 	// Unified bundle command replaces the old template system
 	// bundleCmd is standalone and doesn't need subcommands
@@ -105,18 +129,40 @@ func init() {
 	agentCmd.AddCommand(agentCreateCmd)
 	agentCmd.AddCommand(agentUpdateCmd)
 	agentCmd.AddCommand(agentDeleteCmd)
+	agentCmd.AddCommand(agentRestoreCmd)
+	agentCmd.AddCommand(agentSimulateToolsCmd)
+	agentCmd.AddCommand(agentDocsCmd)
+	agentCmd.AddCommand(agentGroupCmd)
+	agentGroupCmd.AddCommand(agentGroupCreateCmd)
+	agentGroupCmd.AddCommand(agentGroupListCmd)
+	agentGroupCmd.AddCommand(agentGroupShowCmd)
+	agentGroupCmd.AddCommand(agentGroupDeleteCmd)
+	agentGroupCmd.AddCommand(agentGroupAddCmd)
+	agentGroupCmd.AddCommand(agentGroupRemoveCmd)
 
 	reportCmd.AddCommand(reportCreateCmd)
 	reportCmd.AddCommand(reportGenerateCmd)
 	reportCmd.AddCommand(reportListCmd)
 	reportCmd.AddCommand(reportShowCmd)
+	reportCmd.AddCommand(reportShareCmd)
+	reportCmd.AddCommand(reportCompareCmd)
 
 	runsCmd.AddCommand(runsListCmd)
 	runsCmd.AddCommand(runsInspectCmd)
+	runsCmd.AddCommand(runsCancelCmd)
+	runsCmd.AddCommand(runsApproveCmd)
+	runsCmd.AddCommand(runsDenyCmd)
+	runsCmd.AddCommand(runsShareCmd)
+	runsCmd.AddCommand(runsTriageCmd)
+	runsCmd.AddCommand(runsResumeCmd)
+	runsCmd.AddCommand(runsExportCmd)
+	runsCmd.AddCommand(runsAnnotateCmd)
 
 	benchmarkCmd.AddCommand(benchmarkEvaluateCmd)
 	benchmarkCmd.AddCommand(benchmarkListCmd)
 	benchmarkCmd.AddCommand(benchmarkTasksCmd)
+	benchmarkCmd.AddCommand(benchmarkImportCmd)
+	benchmarkCmd.AddCommand(benchmarkExportCmd)
 
 	workflowCmd.AddCommand(workflowListCmd)
 	workflowCmd.AddCommand(workflowShowCmd)
@@ -126,6 +172,9 @@ func init() {
 	workflowCmd.AddCommand(workflowDebugExpressionCmd)
 	workflowCmd.AddCommand(workflowExportCmd)
 	workflowCmd.AddCommand(workflowDeleteCmd)
+	workflowCmd.AddCommand(workflowCanaryCmd)
+	workflowCmd.AddCommand(workflowPromoteCmd)
+	workflowCmd.AddCommand(workflowRollbackCmd)
 	workflowCmd.AddCommand(workflowValidateCmd)
 	workflowCmd.AddCommand(workflowApprovalsCmd)
 	workflowApprovalsCmd.AddCommand(workflowApprovalsListCmd)
@@ -136,11 +185,18 @@ func init() {
 	settingsCmd.AddCommand(settingsGetCmd)
 	settingsCmd.AddCommand(settingsSetCmd)
 
+	quotaCmd.AddCommand(quotaStatusCmd)
+
+	runnerCmd.AddCommand(runnerAddCmd)
+	runnerCmd.AddCommand(runnerListCmd)
+	runnerCmd.AddCommand(runnerRemoveCmd)
+
 	// Init command flags
 	initCmd.Flags().Bool("replicate", false, "Set up Litestream database replication for production deployments")
 	initCmd.Flags().StringP("config", "c", "", "Path to configuration file (sets workspace to config file's directory)")
 	initCmd.Flags().Bool("ship", false, "Bootstrap with ship CLI MCP integration for filesystem access")
-	initCmd.Flags().String("provider", "", "AI provider (cloudshipai, openai, anthropic, gemini, custom) - if not set, shows interactive selection")
+	initCmd.Flags().String("import-from", "", "Import MCP servers from an existing client config (claude, cursor, vscode)")
+	initCmd.Flags().String("provider", "", "AI provider (cloudshipai, openai, anthropic, gemini, groq, mistral, deepseek, together, custom) - if not set, shows interactive selection")
 	initCmd.Flags().String("model", "", "AI model name - if not set, shows interactive selection based on provider")
 	initCmd.Flags().String("api-key", "", "API key for AI provider (alternative to environment variables)")
 	initCmd.Flags().String("base-url", "", "Base URL for OpenAI-compatible endpoints (e.g., http://localhost:11434/v1 for Ollama)")
@@ -165,6 +221,8 @@ func init() {
 	serveCmd.Flags().Bool("dev", false, "Enable development mode with GenKit reflection server (default: disabled)")
 	serveCmd.Flags().Bool("orchestration", false, "Run as lattice orchestrator with embedded NATS hub (enables Station-to-Station mesh)")
 	serveCmd.Flags().String("lattice", "", "Connect to lattice orchestrator NATS URL (e.g., nats://orchestrator:4222)")
+	serveCmd.Flags().Bool("worker", false, "Run as a worker: consume queued run requests from NATS JetStream (share DB with the publishing Station)")
+	serveCmd.Flags().Bool("distributed-runs", false, "Publish API run requests to NATS JetStream for worker Stations to execute")
 
 	// MCP Add command flags
 	mcpAddCmd.Flags().StringP("environment", "e", "default", "Environment to add configuration to")
@@ -178,6 +236,8 @@ func init() {
 	mcpAddOpenapiCmd.Flags().StringP("environment", "e", "default", "Environment to add the OpenAPI spec to")
 	mcpAddOpenapiCmd.Flags().StringP("url", "u", "", "URL to download OpenAPI spec from")
 	mcpAddOpenapiCmd.Flags().StringP("file", "f", "", "Local file path to OpenAPI spec")
+	mcpAddOpenapiDirCmd.Flags().StringP("environment", "e", "default", "Environment to bundle the OpenAPI specs into")
+	mcpAddOpenapiDirCmd.Flags().String("name", "", "Bundle config name (defaults to the directory name)")
 
 	// MCP command flags
 	mcpListCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
@@ -187,6 +247,18 @@ func init() {
 	mcpToolsCmd.Flags().String("environment", "default", "Environment to list tools from")
 	mcpToolsCmd.Flags().String("filter", "", "Filter tools by name or description")
 
+	mcpLogsCmd.Flags().String("env", "default", "Environment the server belongs to")
+	mcpLogsCmd.Flags().Int("bytes", 16*1024, "Maximum bytes of recent stderr output to show")
+
+	mcpCallCmd.Flags().String("env", "default", "Environment containing the tool")
+	mcpCallCmd.Flags().String("args", "", "Tool arguments as a JSON object")
+	mcpCallCmd.Flags().BoolP("interactive", "i", false, "Prompt for each argument from the tool's input schema")
+
+	mcpAnnotateCmd.Flags().String("env", "default", "Environment containing the tool")
+	mcpAnnotateCmd.Flags().Float64("cost", 0, "Expected cost per call in USD")
+	mcpAnnotateCmd.Flags().Int64("latency", 0, "Expected latency per call in milliseconds")
+	mcpAnnotateCmd.Flags().Bool("learn", false, "Learn expected latencies from the tool call durations in recent run history")
+
 	mcpDeleteCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	mcpDeleteCmd.Flags().String("environment", "default", "Environment to delete from")
 	mcpDeleteCmd.Flags().Bool("confirm", false, "Confirm deletion without prompt")
@@ -196,7 +268,38 @@ func init() {
 	syncCmd.Flags().Bool("dry-run", false, "Show what would be synced without making changes")
 	syncCmd.Flags().Bool("validate", false, "Validate configurations only without syncing")
 	syncCmd.Flags().BoolP("interactive", "i", true, "Prompt for missing variables (default: true)")
+	syncCmd.Flags().BoolP("yes", "y", false, "Apply agent changes without showing a confirmation prompt")
 	syncCmd.Flags().Bool("browser", false, "Open browser for secure variable input (useful for LLM agents)")
+	syncCmd.Flags().Bool("check-drift", false, "Report variable drift without syncing (exit 2 = missing variables, 1 = unused/differing, 0 = clean)")
+	syncCmd.Flags().Bool("full", false, "Force tool re-discovery for all MCP servers, even unchanged ones")
+	syncCmd.Flags().Bool("enrich", false, "Expand terse tool descriptions with the configured model after discovery (originals preserved)")
+	syncCmd.Flags().Bool("force-unlock", false, "Remove a stale sync lock left by a crashed sync before syncing")
+
+	// GitOps command flags
+	gitopsCmd.AddCommand(gitopsEnableCmd)
+	gitopsCmd.AddCommand(gitopsDisableCmd)
+	gitopsCmd.AddCommand(gitopsStatusCmd)
+	gitopsCmd.AddCommand(gitopsSyncCmd)
+	gitopsCmd.AddCommand(gitopsRollbackCmd)
+
+	modelsCmd.AddCommand(modelsListCmd)
+	modelsCmd.AddCommand(modelsPullCmd)
+	modelsCmd.AddCommand(modelsRmCmd)
+
+	registryCmd.AddCommand(registryServeCmd)
+	registryCmd.AddCommand(registryPublishCmd)
+	registryServeCmd.Flags().Int("port", 8590, "Port to listen on")
+	registryServeCmd.Flags().String("storage", "", "Storage directory (default: <config-root>/registry)")
+	registryServeCmd.Flags().String("token", "", "Token required to publish (or set STATION_REGISTRY_TOKEN)")
+	registryServeCmd.Flags().String("s3-bucket", "", "Mirror storage to an S3 bucket after each publish (s3://...)")
+	registryPublishCmd.Flags().String("registry", "http://localhost:8590", "Registry URL to publish to")
+	registryPublishCmd.Flags().String("token", "", "Registry token (or set STATION_REGISTRY_TOKEN)")
+	registryPublishCmd.Flags().String("manifest", "", "Optional bundle manifest JSON with description/version/variables")
+	gitopsEnableCmd.Flags().String("repo", "", "Git repository URL holding environment configuration (required)")
+	gitopsEnableCmd.Flags().String("branch", "main", "Branch to sync from")
+	gitopsEnableCmd.Flags().String("path", "envs/", "Directory inside the repo containing one subdirectory per environment")
+	gitopsEnableCmd.Flags().Int("interval", 5, "Minutes between automatic pulls in serve mode")
+	_ = gitopsEnableCmd.MarkFlagRequired("repo")
 
 	// Bootstrap command flags
 	bootstrapCmd.Flags().Bool("openai", false, "Bootstrap with OpenAI provider (runs stn init --ship --provider openai --model gpt-5)")
@@ -211,7 +314,7 @@ func init() {
 	syncCmd.Flags().BoolP("verbose", "v", false, "Verbose output showing all operations")
 
 	// Deploy command flags
-	deployCmd.Flags().String("target", "fly", "Deployment target (fly, kubernetes, ansible, cloudflare)")
+	deployCmd.Flags().String("target", "fly", "Deployment target (fly, kubernetes, ansible, ecs, cloudflare)")
 	deployCmd.Flags().String("region", "ord", "Deployment region (e.g., ord, syd, fra)")
 	deployCmd.Flags().String("sleep-after", "10m", "Cloudflare: sleep container after inactivity (e.g., 10m, 1h, 24h)")
 	deployCmd.Flags().Bool("auto-stop", false, "Enable auto-stop/suspend when idle (default: always-on for persistent Lighthouse connection)")
@@ -223,6 +326,8 @@ func init() {
 	deployCmd.Flags().String("secrets-path", "", "Path for runtime secrets backend (e.g., station/prod for AWS SM, secret/data/station/prod for Vault)")
 	deployCmd.Flags().String("namespace", "", "Kubernetes namespace (default: default)")
 	deployCmd.Flags().String("context", "", "Kubernetes context to use")
+	deployCmd.Flags().String("ingress-host", "", "Kubernetes: generate an Ingress for this hostname (omit for no Ingress)")
+	deployCmd.Flags().Bool("litestream", false, "Kubernetes: replicate database with a Litestream sidecar instead of a PVC (requires LITESTREAM_S3_* secrets)")
 	deployCmd.Flags().String("output-dir", "", "Output directory for generated configs")
 	deployCmd.Flags().Bool("dry-run", false, "Generate configs only, don't deploy")
 	deployCmd.Flags().String("bundle-id", "", "CloudShip bundle ID to deploy (uses base image, no local environment needed)")
@@ -236,6 +341,12 @@ func init() {
 	mcpStatusCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	mcpStatusCmd.Flags().String("environment", "default", "Environment to check status for (default shows all)")
 
+	mcpPresetsAddCmd.Flags().StringP("environment", "e", "default", "Environment to add the preset to")
+
+	mcpLintCmd.Flags().String("format", "text", "Output format: text or json")
+	mcpLintCmd.Flags().Bool("fix", false, "Apply safe auto-corrections (formatting, missing name field)")
+	mcpLintCmd.Flags().Bool("check-urls", false, "Probe HTTP server URLs for reachability")
+
 	// Template command flags
 	templateCreateCmd.Flags().String("name", "", "Bundle name (defaults to directory name)")
 	templateCreateCmd.Flags().String("author", "", "Bundle author")
@@ -257,14 +368,37 @@ func init() {
 	// Agent command flags
 	agentListCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	agentListCmd.Flags().String("env", "", "Filter agents by environment name or ID")
+	agentListCmd.Flags().String("remote", "", "Also list agents from a configured remote station (see 'stn remote')")
+	agentListCmd.Flags().Bool("all-remotes", false, "Also list agents from all configured remote stations")
 	agentShowCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	agentShowCmd.Flags().String("env", "default", "Environment name for the agent")
 	agentRunCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	agentRunCmd.Flags().String("env", "default", "Environment name for the agent")
 	agentRunCmd.Flags().Bool("tail", false, "Follow the agent execution with real-time output")
 	agentRunCmd.Flags().String("coding-session", "", "Existing OpenCode session ID to continue (for coding_open tool)")
+	agentGroupCreateCmd.Flags().String("description", "", "Description of the group")
+	agentSimulateToolsCmd.Flags().String("env", "default", "Environment name for the agent")
+	agentSimulateToolsCmd.Flags().String("task", "", "Task to simulate (required)")
+	agentSimulateToolsCmd.Flags().Bool("json", false, "Emit the simulation report as JSON")
+	agentDocsCmd.Flags().String("env", "default", "Environment to document")
+	agentDocsCmd.Flags().StringP("output", "o", "docs", "Output directory for the generated Markdown files")
+	agentRunCmd.Flags().Duration("timeout", 0, "Maximum execution time for this run (e.g. 10m); overrides the agent's configured timeout")
+	agentRunCmd.Flags().String("input-json", "", "Structured task input as a JSON object, validated against the agent's input schema")
+	agentRunCmd.Flags().String("input-file", "", "Path to a JSON file with structured task input, validated against the agent's input schema")
+	agentRunCmd.Flags().String("profile", "", "Execution profile for this run (e.g. strict); overrides the agent's configured profile")
+	agentRunCmd.Flags().String("idempotency-key", "", "Deduplication key: repeated requests with the same key inside the dedup window return the original run")
+	agentRunCmd.Flags().StringArray("env-var", nil, "Inject an env var into stdio MCP servers for this run only (KEY=VALUE, repeatable; must be in run_env_allowlist)")
+	agentRunCmd.Flags().Bool("plan", false, "Preview the run without executing: system prompt, tools, model/profile, and estimated cost")
+	agentRunCmd.Flags().Bool("cite-tools", false, "Require the agent to cite supporting tool calls for each claim; citations are validated against the tools that actually ran")
+	agentRunCmd.Flags().StringArray("flag", []string{}, "Override a feature flag for this run, e.g. --flag streaming_middleware=on (repeatable)")
+	agentRunCmd.Flags().Bool("prefer-cheap", false, "Hint the model to prefer cheaper/faster tools first, based on tool cost/latency annotations")
+	agentRunCmd.Flags().Int64("seed", 0, "Pin the generation seed for this run on providers that support it (e.g. OpenAI); recorded on the run for reproduction")
+	agentRunCmd.Flags().String("assert-golden", "", "Compare the run output against a golden file and exit non-zero on mismatch (CI assertion)")
+	agentRunCmd.Flags().String("assert-mode", "exact", "Golden comparison mode: exact (structural for JSON), judge (LLM similarity), or jsonpath (golden file maps paths to expected values)")
+	agentRunCmd.Flags().StringArray("file", nil, "Attach a local file to the run (repeatable); text files are injected as context, binaries are stored as input artifacts")
 	agentDeleteCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	agentDeleteCmd.Flags().Bool("confirm", false, "Confirm deletion without prompt")
+	agentRestoreCmd.Flags().StringP("environment", "e", "", "Environment of the deleted agent (when names collide)")
 
 	agentCreateCmd.Flags().StringP("prompt", "p", "", "System prompt for the agent (required)")
 	agentCreateCmd.Flags().StringP("description", "d", "", "Agent description (required)")
@@ -302,11 +436,42 @@ func init() {
 	// Runs command flags
 	runsListCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	runsListCmd.Flags().Int("limit", 50, "Maximum number of runs to display")
+	runsListCmd.Flags().String("remote", "", "Also list runs from a configured remote station (see 'stn remote')")
+	runsListCmd.Flags().Bool("all-remotes", false, "Also list runs from all configured remote stations")
 	runsInspectCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	runsInspectCmd.Flags().BoolP("verbose", "v", false, "Show detailed run information including tool calls, execution steps, and metadata")
+	runsInspectCmd.Flags().Bool("timeline", false, "Export a self-contained HTML Gantt timeline of the run instead of printing details")
+	runsInspectCmd.Flags().StringP("output", "o", "", "Output file for --timeline (default: run-<id>-timeline.html)")
+	runsShareCmd.Flags().String("expires", "7d", "How long the share link stays valid (e.g. 7d, 12h, 30m)")
+	runsTriageCmd.Flags().Bool("summary", false, "Aggregate stored triage verdicts by category instead of triaging one run")
+	capacityCmd.Flags().Bool("json", false, "Emit the capacity report as JSON (same shape as GET /api/v1/capacity)")
+	costsCmd.Flags().String("since", "30d", "Reporting window (e.g. 7d, 30d, 12h)")
+	costsCmd.Flags().String("group-by", "env,model", "Comma-separated grouping dimensions: env, model, agent")
+	costsCmd.Flags().Bool("json", false, "Emit the cost report as JSON")
+	composeCmd.AddCommand(composeGenerateCmd)
+	composeGenerateCmd.Flags().String("env", "default", "Environment whose MCP server configuration seeds the stack")
+	composeGenerateCmd.Flags().String("output", "docker-compose.yml", "Path of the generated compose file")
+	composeGenerateCmd.Flags().Bool("ollama", false, "Include an Ollama service and point Station's OpenAI-compatible base URL at it")
+	composeGenerateCmd.Flags().Bool("force", false, "Overwrite the output file if it exists")
+	runsApproveCmd.Flags().String("comment", "", "Optional note recorded with the decision")
+	runsDenyCmd.Flags().String("comment", "", "Optional reason shown to the agent in the denial message")
+	runsResumeCmd.Flags().Bool("tail", false, "Follow the resumed execution with real-time output")
+	runsResumeCmd.Flags().Duration("timeout", 0, "Maximum execution time for the resumed run (e.g. 10m)")
+	runsExportCmd.Flags().String("format", "jsonl", "Export format: jsonl (parquet planned)")
+	runsExportCmd.Flags().String("since", "", "Only export runs started within this window (e.g. 30d, 12h; default: all)")
+	runsExportCmd.Flags().StringP("output", "o", "./station-export", "Directory to write export files to")
+	runsExportCmd.Flags().String("push", "", "Push export files to an S3/GCS bucket (s3://... or gs://...)")
+	runsAnnotateCmd.Flags().Int("rating", 0, "Rating from 1 (poor) to 5 (excellent)")
+	runsAnnotateCmd.Flags().String("thumbs", "", "Quick verdict: up or down")
+	runsAnnotateCmd.Flags().String("comment", "", "Free-form feedback on the run")
+	runsAnnotateCmd.Flags().Bool("benchmark", false, "Also create a benchmark task from this run for supervised evaluation")
+	reportShareCmd.Flags().String("expires", "7d", "How long the share link stays valid (e.g. 7d, 12h, 30m)")
 
 	// Benchmark command flags
 	benchmarkEvaluateCmd.Flags().BoolP("verbose", "v", false, "Show detailed metric analysis and evidence")
+	benchmarkEvaluateCmd.Flags().String("suite", "", "Suite file whose tasks are imported before evaluation")
+	benchmarkEvaluateCmd.Flags().String("heuristic", "", "Comma-separated metrics to evaluate with local embeddings instead of the LLM judge (relevancy, faithfulness, or 'all')")
+	benchmarkExportCmd.Flags().String("category", "", "Only export tasks in this category")
 
 	// Workflow command flags
 	workflowShowCmd.Flags().Int64("version", 0, "Specific workflow version to show (0 for latest)")
@@ -315,6 +480,7 @@ func init() {
 	workflowRunCmd.Flags().Int64("version", 0, "Specific workflow version to run (0 for latest)")
 	workflowRunCmd.Flags().Bool("wait", false, "Wait for workflow to complete")
 	workflowRunCmd.Flags().Duration("timeout", 5*time.Minute, "Timeout when waiting for completion")
+	workflowCanaryCmd.Flags().Int("percent", 10, "Percentage of new runs routed to the latest version (1-99)")
 	workflowRunsCmd.Flags().Int64("limit", 20, "Maximum number of runs to show")
 	workflowRunsCmd.Flags().Int64("offset", 0, "Number of runs to skip for pagination")
 	workflowRunsCmd.Flags().String("status", "", "Filter by status (running, completed, failed)")
@@ -342,12 +508,19 @@ func init() {
 	reportListCmd.Flags().StringP("environment", "e", "", "Filter reports by environment name")
 	reportListCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	reportShowCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
+	reportCompareCmd.Flags().StringP("environment", "e", "", "Environment name (required)")
+	reportCompareCmd.Flags().String("models", "", "Comma-separated models to compare (e.g. gpt-4o,gemini-2.5-pro)")
+	reportCompareCmd.Flags().Float64("min-score", 7.0, "Minimum team score a model must reach to be recommended")
+	reportCompareCmd.Flags().Bool("regenerate", false, "Generate fresh evaluations instead of reusing completed model-filtered reports")
 
 	// Settings command flags
 	settingsListCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	settingsGetCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
+	settingsGetCmd.Flags().String("scope", "", "Scope to read from: global, env:<name>, or agent:<id> (default: global)")
+	settingsGetCmd.Flags().Int64("agent", 0, "Resolve the setting for an agent through the agent > environment > global hierarchy")
 	settingsSetCmd.Flags().String("endpoint", "", "Station API endpoint (default: use local mode)")
 	settingsSetCmd.Flags().String("description", "", "Description for the setting")
+	settingsSetCmd.Flags().String("scope", "", "Scope to write to: global, env:<name>, or agent:<id> (default: global)")
 
 	filesUploadCmd.Flags().String("station", "", "Station API endpoint (use HTTP API instead of local NATS)")
 	filesUploadCmd.Flags().String("key", "", "Custom file key (default: auto-generated)")
@@ -371,6 +544,8 @@ func init() {
 	viper.BindPFlag("dev_mode", serveCmd.Flags().Lookup("dev"))
 	viper.BindPFlag("lattice_orchestration", serveCmd.Flags().Lookup("orchestration"))
 	viper.BindPFlag("lattice_url", serveCmd.Flags().Lookup("lattice"))
+	viper.BindPFlag("worker_mode", serveCmd.Flags().Lookup("worker"))
+	viper.BindPFlag("distributed_runs", serveCmd.Flags().Lookup("distributed-runs"))
 
 	// Set default values
 	viper.SetDefault("telemetry_enabled", false)
@@ -436,11 +611,28 @@ func initLogging() {
 	if err != nil {
 		// If config fails to load, default to info level (debug disabled)
 		logging.Initialize(false)
+		applyLogFormat("")
 		return
 	}
 
 	// Initialize logging based on config
 	logging.Initialize(cfg.Debug)
+	applyLogFormat(cfg.LogFormat)
+}
+
+// applyLogFormat switches logging to JSON when requested, with the
+// --log-format flag taking precedence over the log_format config setting
+func applyLogFormat(configFormat string) {
+	format := configFormat
+	if logFormat != "" {
+		format = logFormat
+	}
+	if format == "" {
+		return
+	}
+	if err := logging.SetFormat(format); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using text format\n", err)
+	}
 }
 
 func initTelemetry() {
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+// runMCPAnnotate implements the "station mcp annotate" command: attach
+// expected cost/latency metadata to tools in an environment, either manually
+// (--cost/--latency on a named tool) or learned from the durations recorded
+// in recent run history (--learn). Annotations are surfaced to the model
+// during runs and used to aggregate per-tool spend in reports.
+func runMCPAnnotate(cmd *cobra.Command, args []string) error {
+	environmentName, _ := cmd.Flags().GetString("env")
+	learn, _ := cmd.Flags().GetBool("learn")
+	costUSD, _ := cmd.Flags().GetFloat64("cost")
+	latencyMS, _ := cmd.Flags().GetInt64("latency")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+
+	env, err := repos.Environments.GetByName(environmentName)
+	if err != nil {
+		return fmt.Errorf("environment '%s' not found: %w", environmentName, err)
+	}
+
+	annotationService := services.NewToolAnnotationService(repos)
+
+	if learn {
+		updated, err := annotationService.LearnLatencies(context.Background(), env.ID)
+		if err != nil {
+			return fmt.Errorf("failed to learn tool latencies: %w", err)
+		}
+		fmt.Printf("📊 Learned latencies for %d tool(s) from run history in environment '%s'\n", updated, environmentName)
+		return printToolAnnotations(annotationService, env.ID)
+	}
+
+	if len(args) == 0 {
+		// No tool name: show the environment's current annotations
+		return printToolAnnotations(annotationService, env.ID)
+	}
+
+	toolName := args[0]
+	var cost *float64
+	var latency *int64
+	if cmd.Flags().Changed("cost") {
+		cost = &costUSD
+	}
+	if cmd.Flags().Changed("latency") {
+		latency = &latencyMS
+	}
+	if cost == nil && latency == nil {
+		return fmt.Errorf("nothing to annotate: set --cost and/or --latency, or use --learn")
+	}
+
+	annotation, err := annotationService.Annotate(env.ID, toolName, cost, latency)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Annotated %s: %s\n", toolName, services.FormatToolAnnotation(*annotation))
+	return nil
+}
+
+// printToolAnnotations lists an environment's tool annotations sorted by
+// tool name
+func printToolAnnotations(annotationService *services.ToolAnnotationService, environmentID int64) error {
+	annotations, err := annotationService.GetEnvironmentAnnotations(environmentID)
+	if err != nil {
+		return fmt.Errorf("failed to load tool annotations: %w", err)
+	}
+	if len(annotations) == 0 {
+		fmt.Println("• No tool annotations set (use --cost/--latency or --learn)")
+		return nil
+	}
+
+	names := make([]string, 0, len(annotations))
+	for name := range annotations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("Tool annotations (%d):\n", len(names))
+	for _, name := range names {
+		fmt.Printf("  • %s: %s\n", name, services.FormatToolAnnotation(annotations[name]))
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/spf13/cobra"
+
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+// runMCPCall implements the "station mcp call" command: invoke any
+// discovered tool directly through Station's MCP client, showing the raw
+// request, response, and latency. Useful for debugging server configs
+// without running an entire agent.
+func runMCPCall(cmd *cobra.Command, args []string) error {
+	toolName := args[0]
+	environmentName, _ := cmd.Flags().GetString("env")
+	argsJSON, _ := cmd.Flags().GetString("args")
+	interactive, _ := cmd.Flags().GetBool("interactive")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+
+	env, err := repos.Environments.GetByName(environmentName)
+	if err != nil {
+		return fmt.Errorf("environment '%s' not found: %w", environmentName, err)
+	}
+
+	ctx := context.Background()
+
+	// Connect to the environment's MCP servers the same way agent execution does
+	fmt.Printf("🔌 Connecting to MCP servers in environment '%s'...\n", environmentName)
+	connManager := services.NewMCPConnectionManager(repos, nil)
+	tools, clients, err := connManager.GetEnvironmentMCPTools(ctx, env.ID)
+	if err != nil {
+		return fmt.Errorf("failed to connect to MCP servers: %w", err)
+	}
+	defer func() {
+		for _, client := range clients {
+			client.Disconnect()
+		}
+	}()
+
+	tool := findMCPToolByName(tools, toolName)
+	if tool == nil {
+		return fmt.Errorf("tool '%s' not found in environment '%s' (%d tools available, see 'stn mcp tools %s')",
+			toolName, environmentName, len(tools), environmentName)
+	}
+
+	// Build the tool arguments from --args or interactive prompts
+	toolArgs := map[string]interface{}{}
+	if argsJSON != "" {
+		if err := json.Unmarshal([]byte(argsJSON), &toolArgs); err != nil {
+			return fmt.Errorf("invalid --args JSON: %w", err)
+		}
+	} else if interactive {
+		toolArgs, err = promptForToolArguments(tool.Definition())
+		if err != nil {
+			return err
+		}
+	}
+
+	requestJSON, _ := json.MarshalIndent(toolArgs, "", "  ")
+	fmt.Printf("\n📤 Request: %s %s\n", tool.Definition().Name, string(requestJSON))
+
+	start := time.Now()
+	output, callErr := tool.RunRaw(ctx, toolArgs)
+	latency := time.Since(start)
+
+	fmt.Printf("⏱  Latency: %s\n", latency.Round(time.Millisecond))
+
+	if callErr != nil {
+		fmt.Printf("❌ Tool returned error: %v\n", callErr)
+		return callErr
+	}
+
+	fmt.Printf("📥 Response:\n%s\n", formatMCPToolOutput(output))
+	return nil
+}
+
+// findMCPToolByName locates a tool by its definition name
+func findMCPToolByName(tools []ai.Tool, name string) ai.Tool {
+	for _, tool := range tools {
+		if tool.Definition().Name == name {
+			return tool
+		}
+	}
+	return nil
+}
+
+// promptForToolArguments walks the tool's input schema and prompts for each
+// field on stdin. Empty input skips optional fields.
+func promptForToolArguments(def *ai.ToolDefinition) (map[string]interface{}, error) {
+	properties, _ := def.InputSchema["properties"].(map[string]any)
+	if len(properties) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	required := map[string]bool{}
+	if reqList, ok := def.InputSchema["required"].([]any); ok {
+		for _, r := range reqList {
+			if s, ok := r.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	// Prompt in a stable order: required fields first, then alphabetical
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if required[names[i]] != required[names[j]] {
+			return required[names[i]]
+		}
+		return names[i] < names[j]
+	})
+
+	fmt.Printf("\n📝 Enter arguments for %s (blank skips optional fields):\n", def.Name)
+	reader := bufio.NewReader(os.Stdin)
+	toolArgs := map[string]interface{}{}
+
+	for _, name := range names {
+		propSchema, _ := properties[name].(map[string]any)
+		propType, _ := propSchema["type"].(string)
+		if propType == "" {
+			propType = "string"
+		}
+
+		label := fmt.Sprintf("  %s (%s", name, propType)
+		if required[name] {
+			label += ", required"
+		}
+		label += ")"
+		if desc, ok := propSchema["description"].(string); ok && desc != "" {
+			label += " - " + desc
+		}
+
+		for {
+			fmt.Printf("%s: ", label)
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return nil, fmt.Errorf("failed to read input: %w", err)
+			}
+			line = strings.TrimSpace(line)
+
+			if line == "" {
+				if required[name] {
+					fmt.Println("    this field is required")
+					continue
+				}
+				break
+			}
+
+			value, err := parseArgumentValue(line, propType)
+			if err != nil {
+				fmt.Printf("    %v\n", err)
+				continue
+			}
+			toolArgs[name] = value
+			break
+		}
+	}
+
+	return toolArgs, nil
+}
+
+// parseArgumentValue converts raw input to the schema-declared type
+func parseArgumentValue(raw, propType string) (interface{}, error) {
+	switch propType {
+	case "number":
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number")
+		}
+		return value, nil
+	case "integer":
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer")
+		}
+		return value, nil
+	case "boolean":
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false")
+		}
+		return value, nil
+	case "object", "array":
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("expected JSON for %s field", propType)
+		}
+		return value, nil
+	default:
+		return raw, nil
+	}
+}
+
+// formatMCPToolOutput pretty-prints the raw tool response. String responses
+// containing JSON are indented; everything else is JSON encoded.
+func formatMCPToolOutput(output interface{}) string {
+	if s, ok := output.(string); ok {
+		trimmed := strings.TrimSpace(s)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+				if pretty, err := json.MarshalIndent(decoded, "", "  "); err == nil {
+					return string(pretty)
+				}
+			}
+		}
+		return s
+	}
+
+	pretty, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", output)
+	}
+	return string(pretty)
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"station/internal/config"
+	"station/pkg/templates"
+)
+
+var mcpLintCmd = &cobra.Command{
+	Use:   "lint [environment]",
+	Short: "Lint MCP server configurations",
+	Long: `Statically analyze the MCP server templates in an environment and report problems
+before they break 'stn sync' or agent execution.
+
+CHECKS:
+  unresolved-variable   Template variables with no value in variables.yml or the process environment
+  invalid-json          Config does not parse as JSON after template rendering
+  command-not-found     Server command is not on PATH
+  inline-secret         Env var that looks like a secret holds a literal value instead of a {{.VAR}} template
+  duplicate-server      The same server name appears in more than one config file
+  unreachable-url       HTTP/SSE server URL does not respond (only with --check-urls)
+  missing-name          Config file has no top-level "name" field
+
+OUTPUT:
+  Human-readable by default. Use --format json for machine-readable output
+  suitable for CI pipelines; the command exits non-zero when errors are found.
+
+FIXES:
+  --fix applies safe auto-corrections only: normalizes JSON formatting and fills
+  a missing "name" field from the config file name. Nothing destructive.
+
+EXAMPLES:
+  stn mcp lint                       # Lint the default environment
+  stn mcp lint production            # Lint a specific environment
+  stn mcp lint --format json         # Machine-readable output for CI
+  stn mcp lint --fix                 # Apply safe fixes in place
+  stn mcp lint --check-urls          # Also probe HTTP server URLs`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMCPLint,
+}
+
+// mcpLintIssue is a single finding from the linter, serializable for --format json.
+type mcpLintIssue struct {
+	File     string `json:"file"`
+	Server   string `json:"server,omitempty"`
+	Severity string `json:"severity"` // "error" or "warning"
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Fixed    bool   `json:"fixed,omitempty"`
+}
+
+func runMCPLint(cmd *cobra.Command, args []string) error {
+	environmentName := "default"
+	if len(args) > 0 {
+		environmentName = args[0]
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	applyFixes, _ := cmd.Flags().GetBool("fix")
+	checkURLs, _ := cmd.Flags().GetBool("check-urls")
+
+	envDir := config.GetEnvironmentDir(environmentName)
+	if _, err := os.Stat(envDir); os.IsNotExist(err) {
+		return fmt.Errorf("environment '%s' does not exist at %s", environmentName, envDir)
+	}
+
+	variables := loadLintVariables(environmentName)
+
+	entries, err := os.ReadDir(envDir)
+	if err != nil {
+		return fmt.Errorf("failed to read environment directory: %w", err)
+	}
+
+	var issues []mcpLintIssue
+	serverOwners := make(map[string]string) // server name -> first file that declared it
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".openapi.json") {
+			continue
+		}
+		path := filepath.Join(envDir, name)
+		issues = append(issues, lintMCPConfigFile(path, name, variables, serverOwners, applyFixes, checkURLs)...)
+	}
+
+	if format == "json" {
+		output, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal lint results: %w", err)
+		}
+		fmt.Println(string(output))
+	} else {
+		printLintIssues(environmentName, issues)
+	}
+
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == "error" && !issue.Fixed {
+			errorCount++
+		}
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("%d lint error(s) found in environment '%s'", errorCount, environmentName)
+	}
+	return nil
+}
+
+// lintMCPConfigFile runs all checks against a single MCP config template.
+func lintMCPConfigFile(path, fileName string, variables map[string]string, serverOwners map[string]string, applyFixes, checkURLs bool) []mcpLintIssue {
+	var issues []mcpLintIssue
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return append(issues, mcpLintIssue{
+			File: fileName, Severity: "error", Code: "invalid-json",
+			Message: fmt.Sprintf("failed to read file: %v", err),
+		})
+	}
+
+	// Detect unresolved template variables by rendering with missingkey=error,
+	// the same mechanism sync uses (no fragile regex detection).
+	rendered := string(content)
+	for _, missing := range detectMissingTemplateVars(string(content), variables) {
+		issues = append(issues, mcpLintIssue{
+			File: fileName, Severity: "error", Code: "unresolved-variable",
+			Message: fmt.Sprintf("template variable %s has no value in variables.yml or the environment", missing),
+		})
+	}
+	if renderedContent, err := renderLintTemplate(string(content), variables); err == nil {
+		rendered = renderedContent
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(rendered), &parsed); err != nil {
+		issues = append(issues, mcpLintIssue{
+			File: fileName, Severity: "error", Code: "invalid-json",
+			Message: fmt.Sprintf("config is not valid JSON after template rendering: %v", err),
+		})
+		return issues // Remaining checks need parsed JSON
+	}
+
+	fileChanged := false
+	if _, ok := parsed["name"]; !ok {
+		issue := mcpLintIssue{
+			File: fileName, Severity: "warning", Code: "missing-name",
+			Message: "config has no top-level \"name\" field",
+		}
+		if applyFixes {
+			parsed["name"] = strings.TrimSuffix(fileName, ".json")
+			issue.Fixed = true
+			fileChanged = true
+		}
+		issues = append(issues, issue)
+	}
+
+	servers, _ := parsed["mcpServers"].(map[string]interface{})
+	for serverName, rawServer := range servers {
+		if firstFile, exists := serverOwners[serverName]; exists {
+			issues = append(issues, mcpLintIssue{
+				File: fileName, Server: serverName, Severity: "error", Code: "duplicate-server",
+				Message: fmt.Sprintf("server '%s' is already declared in %s", serverName, firstFile),
+			})
+		} else {
+			serverOwners[serverName] = fileName
+		}
+
+		server, ok := rawServer.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if command, _ := server["command"].(string); command != "" {
+			if _, err := exec.LookPath(command); err != nil {
+				issues = append(issues, mcpLintIssue{
+					File: fileName, Server: serverName, Severity: "warning", Code: "command-not-found",
+					Message: fmt.Sprintf("command '%s' not found on PATH", command),
+				})
+			}
+		}
+
+		if env, ok := server["env"].(map[string]interface{}); ok {
+			for key, rawValue := range env {
+				value, _ := rawValue.(string)
+				if looksLikeSecretVar(key) && value != "" && !strings.Contains(value, "{{") {
+					issues = append(issues, mcpLintIssue{
+						File: fileName, Server: serverName, Severity: "warning", Code: "inline-secret",
+						Message: fmt.Sprintf("env var '%s' holds a literal value - use a {{.%s}} template so the secret stays in variables.yml", key, key),
+					})
+				}
+			}
+		}
+
+		if serverURL, _ := server["url"].(string); serverURL != "" {
+			if _, err := url.ParseRequestURI(serverURL); err != nil {
+				issues = append(issues, mcpLintIssue{
+					File: fileName, Server: serverName, Severity: "error", Code: "unreachable-url",
+					Message: fmt.Sprintf("server URL '%s' is not a valid URL", serverURL),
+				})
+			} else if checkURLs && !strings.Contains(serverURL, "{{") {
+				if err := probeLintURL(serverURL); err != nil {
+					issues = append(issues, mcpLintIssue{
+						File: fileName, Server: serverName, Severity: "warning", Code: "unreachable-url",
+						Message: fmt.Sprintf("server URL '%s' is unreachable: %v", serverURL, err),
+					})
+				}
+			}
+		}
+	}
+
+	if applyFixes {
+		formatted, err := json.MarshalIndent(parsed, "", "  ")
+		if err == nil {
+			formatted = append(formatted, '\n')
+			// Only rewrite when --fix changed the structure or normalized formatting
+			if fileChanged || !bytes.Equal(formatted, content) {
+				// Never rewrite files that still contain template variables - rendering
+				// already substituted them and writing would bake values into the template
+				if !strings.Contains(string(content), "{{") {
+					if err := os.WriteFile(path, formatted, 0644); err == nil && !fileChanged {
+						issues = append(issues, mcpLintIssue{
+							File: fileName, Severity: "warning", Code: "formatting",
+							Message: "normalized JSON formatting", Fixed: true,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// detectMissingTemplateVars repeatedly renders the template with placeholder
+// values to enumerate every variable that has no value available.
+func detectMissingTemplateVars(templateContent string, variables map[string]string) []string {
+	vars := make(map[string]string, len(variables))
+	for k, v := range variables {
+		vars[k] = v
+	}
+
+	var missing []string
+	for attempt := 0; attempt < 20; attempt++ {
+		_, err := renderLintTemplate(templateContent, vars)
+		if err == nil {
+			break
+		}
+		name := extractMissingKeyFromTemplateError(err)
+		if name == "" {
+			break
+		}
+		missing = append(missing, name)
+		vars[name] = "PLACEHOLDER_FOR_DETECTION"
+	}
+	return missing
+}
+
+func renderLintTemplate(templateContent string, variables map[string]string) (string, error) {
+	data := make(map[string]interface{}, len(variables))
+	for k, v := range variables {
+		data[k] = v
+	}
+	tmpl, err := template.New("mcp-lint").Funcs(templates.FuncMap(data)).Option("missingkey=error").Parse(templateContent)
+	if err != nil {
+		return "", err
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+// extractMissingKeyFromTemplateError pulls the variable name out of Go template
+// "map has no entry for key" errors.
+func extractMissingKeyFromTemplateError(err error) string {
+	errorStr := err.Error()
+	marker := "map has no entry for key \""
+	start := strings.Index(errorStr, marker)
+	if start == -1 {
+		return ""
+	}
+	start += len(marker)
+	end := strings.Index(errorStr[start:], "\"")
+	if end == -1 {
+		return ""
+	}
+	return errorStr[start : start+end]
+}
+
+// loadLintVariables merges variables.yml with process environment variables,
+// mirroring the resolution order used by TemplateVariableService during sync.
+func loadLintVariables(environmentName string) map[string]string {
+	variables := make(map[string]string)
+
+	if data, err := os.ReadFile(config.GetVariablesPath(environmentName)); err == nil {
+		var parsed map[string]interface{}
+		if err := yaml.Unmarshal(data, &parsed); err == nil {
+			for key, value := range parsed {
+				variables[key] = fmt.Sprintf("%v", value)
+			}
+		}
+	}
+
+	for _, envPair := range os.Environ() {
+		parts := strings.SplitN(envPair, "=", 2)
+		if len(parts) == 2 {
+			variables[parts[0]] = parts[1]
+		}
+	}
+
+	return variables
+}
+
+func looksLikeSecretVar(name string) bool {
+	upperName := strings.ToUpper(name)
+	for _, keyword := range []string{"TOKEN", "KEY", "SECRET", "PASSWORD", "CREDENTIAL", "AUTH"} {
+		if strings.Contains(upperName, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func probeLintURL(serverURL string) error {
+	client := &http.Client{Timeout: 3 * time.Second}
+	resp, err := client.Head(serverURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func printLintIssues(environmentName string, issues []mcpLintIssue) {
+	styles := getCLIStyles(themeManager)
+
+	if len(issues) == 0 {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✅ No issues found in environment '%s'", environmentName)))
+		return
+	}
+
+	fmt.Printf("Lint results for environment '%s':\n\n", environmentName)
+	for _, issue := range issues {
+		icon := "⚠️ "
+		if issue.Severity == "error" {
+			icon = "❌"
+		}
+		if issue.Fixed {
+			icon = "🔧"
+		}
+		location := issue.File
+		if issue.Server != "" {
+			location = fmt.Sprintf("%s (server: %s)", issue.File, issue.Server)
+		}
+		fmt.Printf("%s [%s] %s: %s\n", icon, issue.Code, location, issue.Message)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"station/internal/config"
+	"station/pkg/openapi"
+	"station/pkg/openapi/converter"
+	"station/pkg/openapi/models"
+	"station/pkg/openapi/parser"
+)
+
+// openapiTemplateVarPattern matches Go template variable references like
+// {{ .API_KEY }} in an OpenAPI spec (spaces optional, matching the template engine)
+var openapiTemplateVarPattern = regexp.MustCompile(`\{\{\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// openapiDirAPI describes one spec bundled by `stn mcp add-openapi-dir`
+type openapiDirAPI struct {
+	Name      string
+	SpecFile  string   // spec filename written into the environment
+	ToolCount int      // -1 when the spec could not be converted yet (e.g. unresolved variables)
+	Variables []string // template variable names referenced by the spec
+}
+
+// openapiDirBundle is the result of bundling a directory of OpenAPI specs
+type openapiDirBundle struct {
+	ConfigFile string // merged MCP template filename in the environment
+	APIs       []openapiDirAPI
+	Skipped    []string // files in the directory that are not OpenAPI specs
+}
+
+func runMCPAddOpenapiDir(cmd *cobra.Command, args []string) error {
+	specsDir := args[0]
+	envName, _ := cmd.Flags().GetString("environment")
+	bundleName, _ := cmd.Flags().GetString("name")
+
+	if bundleName == "" {
+		bundleName = filepath.Base(filepath.Clean(specsDir))
+	}
+	openapiService := openapi.NewService()
+	bundleName = strings.TrimSuffix(openapiService.GenerateFileName(bundleName), ".json")
+
+	envDir := config.GetEnvironmentDir(envName)
+	if err := os.MkdirAll(envDir, 0755); err != nil {
+		return fmt.Errorf("failed to create environment directory: %w", err)
+	}
+
+	result, err := bundleOpenAPIDir(specsDir, envDir, envName, bundleName)
+	if err != nil {
+		return err
+	}
+
+	styles := getCLIStyles(nil)
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✅ Bundled %d OpenAPI API(s) into %s", len(result.APIs), filepath.Join(envDir, result.ConfigFile))))
+	for _, api := range result.APIs {
+		if api.ToolCount >= 0 {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("   %s: %d tools (prefix '%s')", api.Name, api.ToolCount, api.Name)))
+		} else {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("   %s: tool count available after variables are resolved at sync", api.Name)))
+		}
+	}
+	for _, skipped := range result.Skipped {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("   Skipped %s - not an OpenAPI spec", skipped)))
+	}
+
+	if varUsage := result.variableUsage(); len(varUsage) > 0 {
+		fmt.Println(styles.Info.Render("🔑 Template variables (prompted once per environment during sync):"))
+		for _, usage := range varUsage {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("   %s — used by %s", usage.name, strings.Join(usage.apis, ", "))))
+		}
+	}
+
+	fmt.Println(styles.Info.Render(fmt.Sprintf("   Run 'stn sync %s' to activate (use --browser for secure variable input)", envName)))
+	return nil
+}
+
+// bundleOpenAPIDir converts every OpenAPI spec in specsDir into the
+// environment: each spec is normalized to <api>.openapi.json and a single
+// merged MCP template (<bundleName>.json) wires all of them up with a
+// consistent per-API tool prefix. Sync skips bundled specs so they are not
+// converted a second time.
+func bundleOpenAPIDir(specsDir, envDir, envName, bundleName string) (*openapiDirBundle, error) {
+	entries, err := os.ReadDir(specsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec directory: %w", err)
+	}
+
+	openapiService := openapi.NewService()
+	result := &openapiDirBundle{ConfigFile: bundleName + ".json"}
+	mcpServers := make(map[string]interface{})
+	seenNames := make(map[string]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(specsDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		// Normalize to JSON and require an OpenAPI/Swagger version marker,
+		// mirroring the single-spec add-openapi validation
+		var spec map[string]interface{}
+		if ext == ".json" {
+			err = json.Unmarshal(data, &spec)
+		} else {
+			err = yaml.Unmarshal(data, &spec)
+		}
+		if err != nil {
+			result.Skipped = append(result.Skipped, entry.Name())
+			continue
+		}
+		if _, ok := spec["openapi"]; !ok {
+			if _, ok := spec["swagger"]; !ok {
+				result.Skipped = append(result.Skipped, entry.Name())
+				continue
+			}
+		}
+
+		baseName := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		baseName = strings.TrimSuffix(baseName, ".openapi")
+		apiName := strings.TrimSuffix(openapiService.GenerateFileName(baseName), ".json")
+		if previous, ok := seenNames[apiName]; ok {
+			return nil, fmt.Errorf("specs %s and %s both map to API name '%s'; rename one", previous, entry.Name(), apiName)
+		}
+		seenNames[apiName] = entry.Name()
+
+		formatted, err := json.MarshalIndent(spec, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to format spec %s: %w", entry.Name(), err)
+		}
+
+		specFileName := apiName + ".openapi.json"
+		if err := os.WriteFile(filepath.Join(envDir, specFileName), formatted, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write spec file: %w", err)
+		}
+
+		api := openapiDirAPI{
+			Name:      apiName,
+			SpecFile:  specFileName,
+			ToolCount: countOpenAPITools(data, apiName),
+			Variables: collectOpenAPITemplateVariables(string(data)),
+		}
+		result.APIs = append(result.APIs, api)
+
+		mcpServers[fmt.Sprintf("%s-openapi", apiName)] = map[string]interface{}{
+			"command":     "stn",
+			"args":        []string{"openapi-runtime", "--spec", filepath.Join("environments", envName, specFileName)},
+			"description": fmt.Sprintf("OpenAPI MCP Server: %s", apiName),
+		}
+	}
+
+	if len(result.APIs) == 0 {
+		return nil, fmt.Errorf("no OpenAPI specs found in %s", specsDir)
+	}
+
+	bundleConfig := map[string]interface{}{
+		"name":        bundleName,
+		"description": fmt.Sprintf("OpenAPI bundle: %d API(s) from %s", len(result.APIs), specsDir),
+		"mcpServers":  mcpServers,
+	}
+	configJSON, err := json.MarshalIndent(bundleConfig, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle config: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(envDir, result.ConfigFile), configJSON, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write bundle config: %w", err)
+	}
+
+	return result, nil
+}
+
+// countOpenAPITools converts the spec in-memory to count the tools it will
+// expose. Returns -1 when the spec cannot be converted yet, e.g. when
+// unresolved template variables keep it from parsing
+func countOpenAPITools(specData []byte, apiName string) int {
+	p := parser.NewParser()
+	if err := p.Parse(specData); err != nil {
+		return -1
+	}
+	mcpConfig, err := converter.NewConverter(p, models.ConvertOptions{
+		ServerName:     apiName,
+		ToolNamePrefix: apiName,
+	}).Convert()
+	if err != nil {
+		return -1
+	}
+	return len(mcpConfig.Tools)
+}
+
+// collectOpenAPITemplateVariables returns the sorted, de-duplicated template
+// variable names referenced by a spec (e.g. API_KEY from {{ .API_KEY }})
+func collectOpenAPITemplateVariables(spec string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, match := range openapiTemplateVarPattern.FindAllStringSubmatch(spec, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+type openapiVariableUsage struct {
+	name string
+	apis []string
+}
+
+// variableUsage groups template variables across the bundled APIs so shared
+// auth variables (the common case) are reported once with every consumer
+func (b *openapiDirBundle) variableUsage() []openapiVariableUsage {
+	byVar := make(map[string][]string)
+	for _, api := range b.APIs {
+		for _, name := range api.Variables {
+			byVar[name] = append(byVar[name], api.Name)
+		}
+	}
+
+	var usage []openapiVariableUsage
+	for name, apis := range byVar {
+		usage = append(usage, openapiVariableUsage{name: name, apis: apis})
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].name < usage[j].name })
+	return usage
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPetstoreSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Petstore", "version": "1.0.0"},
+  "servers": [{"url": "https://petstore.example.com"}],
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "responses": {"200": {"description": "ok"}}
+      },
+      "post": {
+        "operationId": "createPet",
+        "responses": {"201": {"description": "created"}}
+      }
+    }
+  }
+}`
+
+const testOrdersSpec = `openapi: 3.0.0
+info:
+  title: Orders
+  version: 1.0.0
+servers:
+  - url: https://orders.example.com
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      parameters:
+        - name: Authorization
+          in: header
+          schema:
+            type: string
+            default: "Bearer {{ .API_KEY }}"
+      responses:
+        "200":
+          description: ok
+`
+
+func TestBundleOpenAPIDir(t *testing.T) {
+	specsDir := t.TempDir()
+	envDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(specsDir, "Pet Store.json"), []byte(testPetstoreSpec), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(specsDir, "orders.yaml"), []byte(testOrdersSpec), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(specsDir, "notes.json"), []byte(`{"not": "a spec"}`), 0644))
+
+	result, err := bundleOpenAPIDir(specsDir, envDir, "default", "partner-apis")
+	require.NoError(t, err)
+
+	require.Len(t, result.APIs, 2)
+	assert.Equal(t, []string{"notes.json"}, result.Skipped)
+
+	byName := make(map[string]openapiDirAPI)
+	for _, api := range result.APIs {
+		byName[api.Name] = api
+	}
+
+	petstore, ok := byName["pet-store"]
+	require.True(t, ok, "expected spec filename to be sanitized to pet-store")
+	assert.Equal(t, 2, petstore.ToolCount)
+	assert.Empty(t, petstore.Variables)
+
+	orders, ok := byName["orders"]
+	require.True(t, ok)
+	assert.Equal(t, 1, orders.ToolCount)
+	assert.Equal(t, []string{"API_KEY"}, orders.Variables)
+
+	// Specs are normalized into the environment as JSON
+	for _, api := range result.APIs {
+		specData, err := os.ReadFile(filepath.Join(envDir, api.SpecFile))
+		require.NoError(t, err)
+		var spec map[string]interface{}
+		require.NoError(t, json.Unmarshal(specData, &spec))
+		assert.Contains(t, spec, "openapi")
+	}
+
+	// Merged bundle template wires up one server per API
+	bundleData, err := os.ReadFile(filepath.Join(envDir, "partner-apis.json"))
+	require.NoError(t, err)
+	var bundleConfig map[string]interface{}
+	require.NoError(t, json.Unmarshal(bundleData, &bundleConfig))
+	servers, ok := bundleConfig["mcpServers"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Len(t, servers, 2)
+	assert.Contains(t, servers, "pet-store-openapi")
+	assert.Contains(t, servers, "orders-openapi")
+
+	usage := result.variableUsage()
+	require.Len(t, usage, 1)
+	assert.Equal(t, "API_KEY", usage[0].name)
+	assert.Equal(t, []string{"orders"}, usage[0].apis)
+}
+
+func TestBundleOpenAPIDirEmpty(t *testing.T) {
+	specsDir := t.TempDir()
+	envDir := t.TempDir()
+
+	_, err := bundleOpenAPIDir(specsDir, envDir, "default", "empty")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no OpenAPI specs found")
+}
+
+func TestCollectOpenAPITemplateVariables(t *testing.T) {
+	spec := `{"auth": "Bearer {{.API_KEY}}", "url": "{{ .BASE_URL }}/v1", "again": "{{ .API_KEY }}"}`
+	assert.Equal(t, []string{"API_KEY", "BASE_URL"}, collectOpenAPITemplateVariables(spec))
+}
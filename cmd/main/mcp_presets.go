@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"station/internal/config"
+)
+
+var mcpPresetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "Curated MCP server presets",
+	Long: `Browse and install a curated catalog of common MCP servers.
+
+Presets ship the command, args, and env templates for well-known servers so
+adding one is a single command instead of hand-writing a JSON template.
+Secrets are declared as {{.VAR}} template variables and prompted at sync time.
+
+EXAMPLES:
+  stn mcp presets list                       # Show the catalog
+  stn mcp presets add github                 # Add GitHub server to default env
+  stn mcp presets add filesystem -e staging  # Add to a specific environment`,
+}
+
+var mcpPresetsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available MCP server presets",
+	Long:  "List the curated MCP server presets with their commands and required variables",
+	RunE:  runMCPPresetsList,
+}
+
+var mcpPresetsAddCmd = &cobra.Command{
+	Use:   "add <preset>",
+	Short: "Add a preset MCP server to an environment",
+	Long: `Write a preset's server config into an environment.
+
+Creates: <workspace>/environments/<env>/<preset>.json
+
+Any template variables the preset declares are listed after creation; provide
+them in variables.yml or at sync time with 'stn sync <env> --browser'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMCPPresetsAdd,
+}
+
+// mcpPresetVariable documents one template variable a preset expects
+type mcpPresetVariable struct {
+	Name        string
+	Description string
+	Secret      bool
+}
+
+// mcpPreset is one entry in the curated server catalog
+type mcpPreset struct {
+	Name        string
+	Description string
+	Command     string
+	Args        []string
+	Env         map[string]string
+	Variables   []mcpPresetVariable
+}
+
+// mcpPresetCatalog is the curated set of common MCP servers. Args and env
+// values may reference {{.VAR}} template variables, which sync resolves from
+// variables.yml or browser prompts like any other config template.
+var mcpPresetCatalog = map[string]mcpPreset{
+	"filesystem": {
+		Name:        "filesystem",
+		Description: "Local filesystem operations (read, write, list, search)",
+		Command:     "npx",
+		Args:        []string{"-y", "@modelcontextprotocol/server-filesystem@latest", "{{ .PROJECT_ROOT }}"},
+		Variables: []mcpPresetVariable{
+			{Name: "PROJECT_ROOT", Description: "Root directory the server may access"},
+		},
+	},
+	"github": {
+		Name:        "github",
+		Description: "GitHub repositories, issues, and pull requests",
+		Command:     "npx",
+		Args:        []string{"-y", "@modelcontextprotocol/server-github"},
+		Env:         map[string]string{"GITHUB_PERSONAL_ACCESS_TOKEN": "{{ .GITHUB_TOKEN }}"},
+		Variables: []mcpPresetVariable{
+			{Name: "GITHUB_TOKEN", Description: "GitHub personal access token", Secret: true},
+		},
+	},
+	"aws-cloudwatch": {
+		Name:        "aws-cloudwatch",
+		Description: "AWS CloudWatch logs and metrics queries",
+		Command:     "uvx",
+		Args:        []string{"awslabs.cloudwatch-mcp-server@latest"},
+		Env: map[string]string{
+			"AWS_REGION":  "{{ .AWS_REGION }}",
+			"AWS_PROFILE": "{{ .AWS_PROFILE }}",
+		},
+		Variables: []mcpPresetVariable{
+			{Name: "AWS_REGION", Description: "AWS region to query (e.g. us-east-1)"},
+			{Name: "AWS_PROFILE", Description: "AWS credentials profile name"},
+		},
+	},
+	"grafana": {
+		Name:        "grafana",
+		Description: "Grafana dashboards, datasources, and alerting",
+		Command:     "mcp-grafana",
+		Env: map[string]string{
+			"GRAFANA_URL":     "{{ .GRAFANA_URL }}",
+			"GRAFANA_API_KEY": "{{ .GRAFANA_API_KEY }}",
+		},
+		Variables: []mcpPresetVariable{
+			{Name: "GRAFANA_URL", Description: "Grafana instance URL"},
+			{Name: "GRAFANA_API_KEY", Description: "Grafana service account token", Secret: true},
+		},
+	},
+	"slack": {
+		Name:        "slack",
+		Description: "Slack channels, messages, and user lookups",
+		Command:     "npx",
+		Args:        []string{"-y", "@modelcontextprotocol/server-slack"},
+		Env: map[string]string{
+			"SLACK_BOT_TOKEN": "{{ .SLACK_BOT_TOKEN }}",
+			"SLACK_TEAM_ID":   "{{ .SLACK_TEAM_ID }}",
+		},
+		Variables: []mcpPresetVariable{
+			{Name: "SLACK_BOT_TOKEN", Description: "Slack bot token (xoxb-...)", Secret: true},
+			{Name: "SLACK_TEAM_ID", Description: "Slack workspace team ID"},
+		},
+	},
+}
+
+// sortedPresetNames returns catalog keys in stable order for display
+func sortedPresetNames() []string {
+	names := make([]string, 0, len(mcpPresetCatalog))
+	for name := range mcpPresetCatalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runMCPPresetsList(cmd *cobra.Command, args []string) error {
+	styles := getCLIStyles(themeManager)
+	fmt.Println(styles.Banner.Render("📦 MCP Server Presets"))
+	fmt.Println()
+
+	for _, name := range sortedPresetNames() {
+		preset := mcpPresetCatalog[name]
+		fmt.Println(styles.Success.Render(fmt.Sprintf("  %s", name)))
+		fmt.Println(styles.Info.Render(fmt.Sprintf("    %s", preset.Description)))
+		command := preset.Command
+		if len(preset.Args) > 0 {
+			command += " " + strings.Join(preset.Args, " ")
+		}
+		fmt.Printf("    Command: %s\n", command)
+		if len(preset.Variables) > 0 {
+			varNames := make([]string, 0, len(preset.Variables))
+			for _, variable := range preset.Variables {
+				label := variable.Name
+				if variable.Secret {
+					label += " (secret)"
+				}
+				varNames = append(varNames, label)
+			}
+			fmt.Printf("    Variables: %s\n", strings.Join(varNames, ", "))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(styles.Info.Render("Add one with: stn mcp presets add <preset> [--environment <env>]"))
+	return nil
+}
+
+func runMCPPresetsAdd(cmd *cobra.Command, args []string) error {
+	presetName := args[0]
+	envName, _ := cmd.Flags().GetString("environment")
+
+	preset, ok := mcpPresetCatalog[presetName]
+	if !ok {
+		return fmt.Errorf("unknown preset '%s' (available: %s)", presetName, strings.Join(sortedPresetNames(), ", "))
+	}
+
+	serverConfig := map[string]interface{}{
+		"command": preset.Command,
+	}
+	if len(preset.Args) > 0 {
+		serverConfig["args"] = preset.Args
+	}
+	if len(preset.Env) > 0 {
+		serverConfig["env"] = preset.Env
+	}
+
+	mcpConfig := map[string]interface{}{
+		"name":        preset.Name,
+		"description": preset.Description,
+		"mcpServers": map[string]interface{}{
+			preset.Name: serverConfig,
+		},
+	}
+
+	envDir := config.GetEnvironmentDir(envName)
+	if err := os.MkdirAll(envDir, 0755); err != nil {
+		return fmt.Errorf("failed to create environment directory: %w", err)
+	}
+
+	configPath := filepath.Join(envDir, preset.Name+".json")
+	if _, err := os.Stat(configPath); err == nil {
+		return fmt.Errorf("config already exists: %s (delete it first to re-add the preset)", configPath)
+	}
+
+	configJSON, err := json.MarshalIndent(mcpConfig, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(configPath, configJSON, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	styles := getCLIStyles(themeManager)
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✅ Added preset '%s': %s", preset.Name, configPath)))
+	if len(preset.Variables) > 0 {
+		fmt.Println(styles.Info.Render("   Required variables:"))
+		for _, variable := range preset.Variables {
+			label := variable.Name
+			if variable.Secret {
+				label += " (secret)"
+			}
+			fmt.Printf("     • %s - %s\n", label, variable.Description)
+		}
+	}
+	fmt.Println(styles.Info.Render(fmt.Sprintf("   Run 'stn sync %s' to activate (use --browser for secure variable input)", envName)))
+
+	return nil
+}
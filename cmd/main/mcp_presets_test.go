@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMCPPresetCatalogIntegrity(t *testing.T) {
+	for name, preset := range mcpPresetCatalog {
+		assert.Equal(t, name, preset.Name, "catalog key should match preset name")
+		assert.NotEmpty(t, preset.Description, "preset %s needs a description", name)
+		assert.NotEmpty(t, preset.Command, "preset %s needs a command", name)
+	}
+}
+
+func TestMCPPresetVariablesAreDeclared(t *testing.T) {
+	for name, preset := range mcpPresetCatalog {
+		declared := make(map[string]bool)
+		for _, variable := range preset.Variables {
+			declared[variable.Name] = true
+		}
+
+		var referenced []string
+		for _, arg := range preset.Args {
+			referenced = append(referenced, extractTemplateVars(arg)...)
+		}
+		for _, value := range preset.Env {
+			referenced = append(referenced, extractTemplateVars(value)...)
+		}
+
+		for _, varName := range referenced {
+			assert.True(t, declared[varName],
+				"preset %s references {{.%s}} but does not declare it", name, varName)
+		}
+		for _, variable := range preset.Variables {
+			assert.Contains(t, referenced, variable.Name,
+				"preset %s declares %s but never references it", name, variable.Name)
+		}
+	}
+}
+
+func TestSortedPresetNamesStable(t *testing.T) {
+	names := sortedPresetNames()
+	assert.Len(t, names, len(mcpPresetCatalog))
+	for i := 1; i < len(names); i++ {
+		assert.Less(t, names[i-1], names[i], "preset names should be sorted")
+	}
+}
+
+// extractTemplateVars pulls VAR names out of {{ .VAR }} references in a value
+func extractTemplateVars(value string) []string {
+	var vars []string
+	rest := value
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			return vars
+		}
+		end := strings.Index(rest[start:], "}}")
+		if end < 0 {
+			return vars
+		}
+		inner := strings.TrimSpace(rest[start+2 : start+end])
+		if name, ok := strings.CutPrefix(inner, "."); ok {
+			vars = append(vars, name)
+		}
+		rest = rest[start+end+2:]
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"station/internal/config"
+	"station/internal/services"
+)
+
+// Model management command definitions (Ollama-backed local models)
+var (
+	modelsCmd = &cobra.Command{
+		Use:   "models",
+		Short: "Manage local Ollama models",
+		Long:  "List, pull, and remove models on the configured Ollama server, with tool-calling compatibility shown for each model",
+	}
+
+	modelsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List installed models",
+		Long:  "List models installed on the Ollama server and whether each supports tool calling (required for Station agents)",
+		RunE:  runModelsList,
+	}
+
+	modelsPullCmd = &cobra.Command{
+		Use:   "pull <model>",
+		Short: "Pull a model from the Ollama registry",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runModelsPull,
+	}
+
+	modelsRmCmd = &cobra.Command{
+		Use:   "rm <model>",
+		Short: "Remove an installed model",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runModelsRm,
+	}
+)
+
+func runModelsList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ollama := services.NewOllamaService(cfg)
+	models, err := ollama.ListModels(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if len(models) == 0 {
+		fmt.Println("No models installed.")
+		fmt.Println("Pull one with 'stn models pull <model>' (e.g. stn models pull qwen2.5:7b)")
+		return nil
+	}
+
+	fmt.Printf("Installed models (%d):\n\n", len(models))
+	for _, model := range models {
+		toolSupport := "✅ tool calling"
+		if !model.SupportsTools {
+			toolSupport = "⚠️  no tool calling"
+		}
+		fmt.Printf("• %s (%s) — %s\n", model.Name, services.FormatModelSize(model.SizeBytes), toolSupport)
+	}
+
+	fmt.Println("\nStation agents require tool calling; models without it cannot use MCP tools.")
+	return nil
+}
+
+func runModelsPull(cmd *cobra.Command, args []string) error {
+	modelName := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ollama := services.NewOllamaService(cfg)
+	fmt.Printf("⬇️  Pulling %s...\n", modelName)
+	if err := ollama.PullModel(context.Background(), modelName, os.Stdout); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Pulled %s\n", modelName)
+	if supports, err := ollama.ModelSupportsTools(context.Background(), modelName); err == nil && !supports {
+		fmt.Printf("⚠️  %s does not advertise tool calling — agents using it cannot call MCP tools\n", modelName)
+	}
+	return nil
+}
+
+func runModelsRm(cmd *cobra.Command, args []string) error {
+	modelName := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ollama := services.NewOllamaService(cfg)
+	if err := ollama.DeleteModel(context.Background(), modelName); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Removed %s\n", modelName)
+	return nil
+}
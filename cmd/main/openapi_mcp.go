@@ -0,0 +1,56 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"station/pkg/openapi"
+
+	"github.com/spf13/cobra"
+)
+
+var openapiMcpCmd = &cobra.Command{
+	Use:   "openapi-mcp",
+	Short: "Serve OpenAPI specs from an environment as MCP tools",
+	Long: `Serve every *.openapi.json spec in an environment directory as one
+combined MCP server over stdio.
+
+This embeds the standalone openapi-mcp-server binary, so template.json
+entries can reference the stn binary that is already installed instead of
+requiring a second binary on PATH:
+
+  {
+    "mcpServers": {
+      "apis": {
+        "command": "stn",
+        "args": ["openapi-mcp", "serve", "environments/default"]
+      }
+    }
+  }`,
+}
+
+var openapiMcpServeCmd = &cobra.Command{
+	Use:   "serve <environment-dir>",
+	Short: "Run the combined OpenAPI MCP server over stdio",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runOpenAPIMcpServe,
+}
+
+func init() {
+	openapiMcpCmd.AddCommand(openapiMcpServeCmd)
+	rootCmd.AddCommand(openapiMcpCmd)
+}
+
+func runOpenAPIMcpServe(cmd *cobra.Command, args []string) error {
+	// Log to stderr to avoid interfering with the JSON-RPC stream
+	log.SetOutput(os.Stderr)
+
+	server, err := openapi.NewEnvironmentServer(args[0])
+	if err != nil {
+		return err
+	}
+
+	log.Printf("OpenAPI MCP Server started with %d tools from %d specs", server.ToolCount(), server.SpecCount())
+	server.ServeStdio(os.Stdin, os.Stdout)
+	return nil
+}
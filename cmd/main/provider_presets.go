@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// providerPreset is a curated OpenAI-compatible endpoint offered by the init
+// wizard. Presets run through the standard openai provider with the preset
+// base URL, so only the wizard needs to know about them; the model lists are
+// limited to models known to support tool calling.
+type providerPreset struct {
+	Description string
+	BaseURL     string
+	APIKeyEnv   string // environment variable holding the provider's API key
+	Models      []string
+}
+
+var openAICompatPresets = map[string]providerPreset{
+	"groq": {
+		Description: "Groq - Ultra-fast inference for Llama models (OpenAI-compatible)",
+		BaseURL:     "https://api.groq.com/openai/v1",
+		APIKeyEnv:   "GROQ_API_KEY",
+		Models: []string{
+			"llama-3.3-70b-versatile",
+			"llama-3.1-8b-instant",
+		},
+	},
+	"mistral": {
+		Description: "Mistral AI - European models with strong tool calling (OpenAI-compatible)",
+		BaseURL:     "https://api.mistral.ai/v1",
+		APIKeyEnv:   "MISTRAL_API_KEY",
+		Models: []string{
+			"mistral-large-latest",
+			"mistral-medium-latest",
+			"mistral-small-latest",
+		},
+	},
+	"deepseek": {
+		Description: "DeepSeek - Cost-effective reasoning and chat models (OpenAI-compatible)",
+		BaseURL:     "https://api.deepseek.com/v1",
+		APIKeyEnv:   "DEEPSEEK_API_KEY",
+		Models: []string{
+			"deepseek-chat",
+			"deepseek-reasoner",
+		},
+	},
+	"together": {
+		Description: "Together AI - Open models like Llama, Qwen, DeepSeek (OpenAI-compatible)",
+		BaseURL:     "https://api.together.xyz/v1",
+		APIKeyEnv:   "TOGETHER_API_KEY",
+		Models: []string{
+			"meta-llama/Llama-3.3-70B-Instruct-Turbo",
+			"Qwen/Qwen2.5-72B-Instruct-Turbo",
+			"deepseek-ai/DeepSeek-V3",
+		},
+	},
+}
+
+// openAICompatPresetOrder fixes the wizard's listing order for presets
+var openAICompatPresetOrder = []string{"groq", "mistral", "deepseek", "together"}
+
+// applyProviderPreset rewrites a preset selection into the openai provider
+// with the preset's base URL so the runtime needs no preset awareness. The
+// API key env var is kept so init can persist ai_api_key as ${VAR}.
+func applyProviderPreset(pc *ProviderConfig) {
+	preset, ok := openAICompatPresets[pc.Provider]
+	if !ok {
+		return
+	}
+
+	if pc.Model == "" {
+		pc.Model = preset.Models[0]
+	}
+	if pc.BaseURL == "" {
+		pc.BaseURL = preset.BaseURL
+	}
+	pc.APIKeyEnv = preset.APIKeyEnv
+	pc.Provider = "openai"
+}
+
+// validateOpenAICompatEndpoint pings the endpoint's /models route to catch
+// wrong base URLs and bad API keys before the config is written
+func validateOpenAICompatEndpoint(baseURL, apiKey string) error {
+	req, err := http.NewRequest("GET", strings.TrimSuffix(baseURL, "/")+"/models", nil)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("endpoint unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("authentication failed (HTTP %d) - check your API key", resp.StatusCode)
+	default:
+		return fmt.Errorf("unexpected response: HTTP %d", resp.StatusCode)
+	}
+}
+
+// validatePresetEndpoint pings a preset endpoint during the init wizard and
+// reports the outcome without blocking setup - a missing key is common when
+// init runs before credentials are provisioned
+func validatePresetEndpoint(presetName string) {
+	preset, ok := openAICompatPresets[presetName]
+	if !ok {
+		return
+	}
+
+	apiKey := os.Getenv(preset.APIKeyEnv)
+	if apiKey == "" {
+		log.Printf("⚠️  %s is not set - set it before running agents\n", preset.APIKeyEnv)
+		return
+	}
+
+	if err := validateOpenAICompatEndpoint(preset.BaseURL, apiKey); err != nil {
+		log.Printf("⚠️  %s validation failed: %v\n", presetName, err)
+		return
+	}
+	log.Printf("✓ %s endpoint validated (%s)\n", presetName, preset.BaseURL)
+}
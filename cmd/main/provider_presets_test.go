@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProviderPreset(t *testing.T) {
+	pc := &ProviderConfig{Provider: "groq"}
+	applyProviderPreset(pc)
+
+	assert.Equal(t, "openai", pc.Provider)
+	assert.Equal(t, "https://api.groq.com/openai/v1", pc.BaseURL)
+	assert.Equal(t, "GROQ_API_KEY", pc.APIKeyEnv)
+	assert.Equal(t, "llama-3.3-70b-versatile", pc.Model)
+}
+
+func TestApplyProviderPresetKeepsOverrides(t *testing.T) {
+	pc := &ProviderConfig{Provider: "mistral", Model: "mistral-small-latest", BaseURL: "http://localhost:9999/v1"}
+	applyProviderPreset(pc)
+
+	assert.Equal(t, "openai", pc.Provider)
+	assert.Equal(t, "mistral-small-latest", pc.Model)
+	assert.Equal(t, "http://localhost:9999/v1", pc.BaseURL)
+}
+
+func TestApplyProviderPresetIgnoresKnownProviders(t *testing.T) {
+	pc := &ProviderConfig{Provider: "openai", Model: "gpt-4o-mini"}
+	applyProviderPreset(pc)
+
+	assert.Equal(t, "openai", pc.Provider)
+	assert.Empty(t, pc.BaseURL)
+	assert.Empty(t, pc.APIKeyEnv)
+}
+
+func TestValidateOpenAICompatEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/models", r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer good-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	assert.NoError(t, validateOpenAICompatEndpoint(server.URL, "good-key"))
+
+	err := validateOpenAICompatEndpoint(server.URL, "bad-key")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "authentication failed")
+}
+
+func TestPresetModelsSelectable(t *testing.T) {
+	models := getProviderModelsWithPresets()
+	for _, name := range openAICompatPresetOrder {
+		require.Contains(t, models, name)
+		assert.NotEmpty(t, models[name])
+	}
+	// Base providers remain available
+	assert.Contains(t, models, "openai")
+	assert.Contains(t, models, "gemini")
+}
@@ -17,9 +17,10 @@ import (
 
 // Provider configuration
 type ProviderConfig struct {
-	Provider string
-	Model    string
-	BaseURL  string
+	Provider  string
+	Model     string
+	BaseURL   string
+	APIKeyEnv string // env var holding the API key for curated presets (e.g. GROQ_API_KEY)
 }
 
 func getProviderModels() map[string][]string {
@@ -47,6 +48,16 @@ func getProviderModels() map[string][]string {
 	}
 }
 
+// getProviderModelsWithPresets merges the curated OpenAI-compatible preset
+// model lists into the base provider map for wizard selection
+func getProviderModelsWithPresets() map[string][]string {
+	models := getProviderModels()
+	for name, preset := range openAICompatPresets {
+		models[name] = preset.Models
+	}
+	return models
+}
+
 // getDefaultProvider returns the default provider and model
 func getDefaultProvider() (string, string) {
 	// CloudShip AI is the default when registration key is available
@@ -64,6 +75,12 @@ var providerDescriptions = map[string]string{
 	"custom":      "Configure a custom provider (any OpenAI-compatible endpoint)",
 }
 
+func init() {
+	for name, preset := range openAICompatPresets {
+		providerDescriptions[name] = preset.Description
+	}
+}
+
 // setupProviderInteractively runs the interactive provider setup
 func setupProviderInteractively() (*ProviderConfig, error) {
 	// Check for environment variables first
@@ -119,7 +136,17 @@ func setupProviderInteractively() (*ProviderConfig, error) {
 		log.Printf("Selected model: '%s'\n", model)
 	}
 
-	return &ProviderConfig{Provider: provider, Model: model, BaseURL: ""}, nil
+	providerConfig := &ProviderConfig{Provider: provider, Model: model, BaseURL: ""}
+
+	// Curated OpenAI-compatible presets (Groq, Mistral, DeepSeek, Together)
+	// are rewritten to the openai provider with the preset base URL; ping the
+	// endpoint so misconfigurations surface during init rather than at run time
+	if _, isPreset := openAICompatPresets[provider]; isPreset {
+		applyProviderPreset(providerConfig)
+		validatePresetEndpoint(provider)
+	}
+
+	return providerConfig, nil
 }
 
 // ensureCloudShipAuth checks if CloudShip auth exists, and runs the auth flow if not
@@ -152,6 +179,11 @@ func detectProviderFromEnv() (string, string) {
 	if os.Getenv("GEMINI_API_KEY") != "" || os.Getenv("GOOGLE_API_KEY") != "" {
 		return "gemini", "gemini-2.5-flash"
 	}
+	for _, name := range openAICompatPresetOrder {
+		if preset := openAICompatPresets[name]; os.Getenv(preset.APIKeyEnv) != "" {
+			return name, preset.Models[0]
+		}
+	}
 	return "", ""
 }
 
@@ -381,8 +413,11 @@ func selectProvider() (string, error) {
 		item("openai"),
 		item("anthropic"),
 		item("gemini"),
-		item("custom"),
 	}
+	for _, name := range openAICompatPresetOrder {
+		items = append(items, item(name))
+	}
+	items = append(items, item("custom"))
 
 	const defaultWidth = 60
 	const listHeight = 14
@@ -415,7 +450,7 @@ func selectProvider() (string, error) {
 
 // selectModel shows interactive model selection for a provider
 func selectModel(provider string) (string, error) {
-	providerModels := getProviderModels()
+	providerModels := getProviderModelsWithPresets()
 	models, exists := providerModels[provider]
 	if !exists {
 		return "", fmt.Errorf("unknown provider: %s", provider)
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+var (
+	quotaCmd = &cobra.Command{
+		Use:   "quota",
+		Short: "Org quota commands",
+		Long:  "Inspect org-level quotas pushed from CloudShip and today's usage against them",
+	}
+
+	quotaStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show org quota limits and today's usage",
+		Long:  "Display the org-level quota pushed from CloudShip Lighthouse (runs/day, token ceiling, allowed models) and how much of it this Station has consumed since midnight UTC",
+		RunE:  runQuotaStatus,
+	}
+)
+
+// runQuotaStatus shows the org quota and current usage
+func runQuotaStatus(cmd *cobra.Command, args []string) error {
+	databasePath := viper.GetString("database_url")
+	if databasePath == "" {
+		configDir := getWorkspacePath()
+		databasePath = filepath.Join(configDir, "station.db")
+	}
+
+	database, err := db.New(databasePath)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	quotaService := services.NewQuotaService(repos)
+
+	quota, err := quotaService.GetQuota()
+	if err != nil {
+		return err
+	}
+	if quota == nil {
+		fmt.Println("No org quota configured.")
+		fmt.Println("Quotas are pushed from CloudShip Lighthouse when this Station is registered.")
+		return nil
+	}
+
+	usage, err := quotaService.UsageToday(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Org Quota (pushed from CloudShip)")
+	fmt.Printf("  Updated: %s\n\n", quota.UpdatedAt.Format("2006-01-02 15:04 MST"))
+
+	if quota.MaxRunsPerDay > 0 {
+		fmt.Printf("  Runs today:   %d / %d\n", usage.RunsToday, quota.MaxRunsPerDay)
+	} else {
+		fmt.Printf("  Runs today:   %d (unlimited)\n", usage.RunsToday)
+	}
+	if quota.MaxTokensPerDay > 0 {
+		fmt.Printf("  Tokens today: %d / %d\n", usage.TokensToday, quota.MaxTokensPerDay)
+	} else {
+		fmt.Printf("  Tokens today: %d (unlimited)\n", usage.TokensToday)
+	}
+	if len(quota.AllowedModels) > 0 {
+		fmt.Printf("  Allowed models:\n")
+		for _, model := range quota.AllowedModels {
+			fmt.Printf("    - %s\n", model)
+		}
+	} else {
+		fmt.Printf("  Allowed models: any\n")
+	}
+
+	fmt.Println("\nThe usage window resets at midnight UTC.")
+	return nil
+}
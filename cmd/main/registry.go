@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"station/internal/config"
+	"station/internal/services"
+)
+
+// Registry command definitions (self-hosted bundle registry)
+var (
+	registryCmd = &cobra.Command{
+		Use:   "registry",
+		Short: "Self-hosted bundle registry",
+		Long:  "Run and publish to a lightweight bundle registry compatible with 'stn bundle search/info/install'",
+	}
+
+	registryServeCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Run a bundle registry server",
+		Long: `Run a lightweight bundle registry serving /index.json, bundle downloads,
+and manifests, with token-authenticated publishing and optional S3-backed storage.
+
+Point clients at it via the bundle.registries setting:
+  stn settings set bundle.registries '[{"name": "internal", "url": "http://registry.internal:8590"}]'
+
+Examples:
+  stn registry serve --port 8590 --token $REGISTRY_TOKEN
+  stn registry serve --storage /var/lib/station-registry --s3-bucket s3://corp-bundles`,
+		RunE: runRegistryServe,
+	}
+
+	registryPublishCmd = &cobra.Command{
+		Use:   "publish <bundle.tar.gz>",
+		Short: "Publish a bundle to a registry",
+		Long: `Upload a bundle archive (and optional manifest) to a registry started
+with 'stn registry serve'.
+
+Examples:
+  stn registry publish my-bundle.tar.gz --registry http://registry.internal:8590 --token $REGISTRY_TOKEN
+  stn registry publish my-bundle.tar.gz --registry http://localhost:8590 --manifest my-bundle.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runRegistryPublish,
+	}
+)
+
+func runRegistryServe(cmd *cobra.Command, args []string) error {
+	port, _ := cmd.Flags().GetInt("port")
+	storageDir, _ := cmd.Flags().GetString("storage")
+	token, _ := cmd.Flags().GetString("token")
+	s3Bucket, _ := cmd.Flags().GetString("s3-bucket")
+
+	if token == "" {
+		token = os.Getenv("STATION_REGISTRY_TOKEN")
+	}
+	if storageDir == "" {
+		storageDir = filepath.Join(config.GetConfigRoot(), "registry")
+	}
+
+	server, err := services.NewRegistryServer(services.RegistryServerOptions{
+		Port:       port,
+		StorageDir: storageDir,
+		Token:      token,
+		S3Bucket:   s3Bucket,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📦 Bundle registry listening on :%d\n", port)
+	fmt.Printf("📂 Storage: %s\n", storageDir)
+	if token == "" {
+		fmt.Println("⚠️  No token configured — publishing is open (set --token or STATION_REGISTRY_TOKEN)")
+	}
+	if s3Bucket != "" {
+		fmt.Printf("☁️  Mirroring to %s after each publish\n", s3Bucket)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return server.Serve(ctx)
+}
+
+func runRegistryPublish(cmd *cobra.Command, args []string) error {
+	bundlePath := args[0]
+	registryURL, _ := cmd.Flags().GetString("registry")
+	token, _ := cmd.Flags().GetString("token")
+	manifestPath, _ := cmd.Flags().GetString("manifest")
+
+	if token == "" {
+		token = os.Getenv("STATION_REGISTRY_TOKEN")
+	}
+	if !strings.HasSuffix(bundlePath, ".tar.gz") {
+		return fmt.Errorf("bundle must be a .tar.gz archive (create one with 'stn bundle create')")
+	}
+	if _, err := os.Stat(bundlePath); os.IsNotExist(err) {
+		return fmt.Errorf("bundle file not found: %s", bundlePath)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	bundleFile, err := os.Open(bundlePath)
+	if err != nil {
+		return fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer func() { _ = bundleFile.Close() }()
+
+	part, err := writer.CreateFormFile("bundle", filepath.Base(bundlePath))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, bundleFile); err != nil {
+		return err
+	}
+
+	if manifestPath != "" {
+		manifestData, err := os.ReadFile(manifestPath)
+		if err != nil {
+			return fmt.Errorf("failed to read manifest: %w", err)
+		}
+		if !json.Valid(manifestData) {
+			return fmt.Errorf("manifest %s is not valid JSON", manifestPath)
+		}
+		manifestPart, err := writer.CreateFormFile("manifest", filepath.Base(manifestPath))
+		if err != nil {
+			return err
+		}
+		if _, err := manifestPart.Write(manifestData); err != nil {
+			return err
+		}
+	}
+	writer.Close()
+
+	publishURL := strings.TrimSuffix(registryURL, "/") + "/api/bundles"
+	req, err := http.NewRequest("POST", publishURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if token != "" {
+		req.Header.Set("X-Registry-Token", token)
+	}
+
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach registry: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("publish failed (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var result struct {
+		Name string `json:"name"`
+		Size int64  `json:"size"`
+		URL  string `json:"url"`
+	}
+	if err := json.Unmarshal(respBody, &result); err == nil {
+		fmt.Printf("✅ Published %s (%d bytes)\n", result.Name, result.Size)
+		fmt.Printf("🔗 %s%s\n", strings.TrimSuffix(registryURL, "/"), result.URL)
+	} else {
+		fmt.Println("✅ Published bundle")
+	}
+	return nil
+}
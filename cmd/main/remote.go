@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"station/internal/config"
+)
+
+var remoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "Manage remote Station instances for federated views",
+	Long: `Register other Station instances (dev laptop, CI, prod server) so their
+agents and runs can be aggregated into local CLI views.
+
+Once a remote is added, use --remote <name> or --all-remotes with
+'stn agent list' and 'stn runs list' to include its rows, labeled with
+their origin.`,
+}
+
+var remoteAddCmd = &cobra.Command{
+	Use:   "add <name> <endpoint>",
+	Short: "Add a remote Station instance",
+	Long: `Add a remote Station instance by name and API endpoint.
+
+Examples:
+  stn remote add ci http://ci-server:8585
+  stn remote add prod https://station.internal.example.com --api-key $STATION_PROD_KEY`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRemoteAdd,
+}
+
+var remoteListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured remote Station instances",
+	RunE:  runRemoteList,
+}
+
+var remoteRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a remote Station instance",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRemoteRemove,
+}
+
+func init() {
+	remoteAddCmd.Flags().String("api-key", "", "Bearer token for authenticating against the remote API")
+
+	remoteCmd.AddCommand(remoteAddCmd)
+	remoteCmd.AddCommand(remoteListCmd)
+	remoteCmd.AddCommand(remoteRemoveCmd)
+}
+
+func runRemoteAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	endpoint := strings.TrimRight(args[1], "/")
+	apiKey, _ := cmd.Flags().GetString("api-key")
+
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		return fmt.Errorf("endpoint must start with http:// or https://")
+	}
+
+	viper.Set(fmt.Sprintf("remotes.%s.endpoint", name), endpoint)
+	if apiKey != "" {
+		viper.Set(fmt.Sprintf("remotes.%s.api_key", name), apiKey)
+	}
+
+	if err := viper.WriteConfig(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✅ Remote '%s' added:\n", name)
+	fmt.Printf("   Endpoint: %s\n", endpoint)
+	if apiKey != "" {
+		fmt.Printf("   API Key: configured\n")
+	}
+	fmt.Println()
+	fmt.Printf("Use 'stn agent list --remote %s' or 'stn runs list --all-remotes' to see its rows.\n", name)
+
+	return nil
+}
+
+func runRemoteList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	if len(cfg.Remotes) == 0 {
+		fmt.Println("No remotes configured. Add one with: stn remote add <name> <endpoint>")
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.Remotes))
+	for name := range cfg.Remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("Configured remotes (%d):\n", len(names))
+	for _, name := range names {
+		remote := cfg.Remotes[name]
+		auth := ""
+		if remote.APIKey != "" {
+			auth = " (authenticated)"
+		}
+		fmt.Printf("• %s: %s%s\n", name, remote.Endpoint, auth)
+	}
+
+	return nil
+}
+
+func runRemoteRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	if _, exists := cfg.Remotes[name]; !exists {
+		return fmt.Errorf("remote '%s' not found", name)
+	}
+
+	// Viper has no delete, so rewrite the remotes map without this entry
+	remotes := make(map[string]interface{})
+	for remoteName, remote := range cfg.Remotes {
+		if remoteName != name {
+			remotes[remoteName] = map[string]string{
+				"endpoint": remote.Endpoint,
+				"api_key":  remote.APIKey,
+			}
+		}
+	}
+	viper.Set("remotes", remotes)
+
+	if err := viper.WriteConfig(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✅ Remote '%s' removed\n", name)
+	return nil
+}
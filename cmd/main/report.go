@@ -42,6 +42,21 @@ var (
 		Args:  cobra.ExactArgs(1),
 		RunE:  runReportShow,
 	}
+
+	reportCompareCmd = &cobra.Command{
+		Use:   "compare",
+		Short: "Compare models across an environment's agents",
+		Long:  "Generate side-by-side model evaluations (scores, cost, latency per agent) from model-filtered reports and recommend the cheapest model meeting the quality threshold",
+		RunE:  runReportCompare,
+	}
+
+	reportShareCmd = &cobra.Command{
+		Use:   "share <report_id>",
+		Short: "Create a read-only share link for a report",
+		Long:  "Generate an expiring, tokenized URL that renders the report as a standalone redacted HTML page for stakeholders without Station access",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runReportShare,
+	}
 )
 
 // runReportCreate creates a new report
@@ -67,3 +82,15 @@ func runReportShow(cmd *cobra.Command, args []string) error {
 	reportHandler := report.NewReportHandler(nil, telemetryService)
 	return reportHandler.RunReportShow(cmd, args)
 }
+
+// runReportCompare generates side-by-side model evaluations
+func runReportCompare(cmd *cobra.Command, args []string) error {
+	reportHandler := report.NewReportHandler(nil, telemetryService)
+	return reportHandler.RunReportCompare(cmd, args)
+}
+
+// runReportShare creates a read-only share link for a report
+func runReportShare(cmd *cobra.Command, args []string) error {
+	reportHandler := report.NewReportHandler(nil, telemetryService)
+	return reportHandler.RunReportShare(cmd, args)
+}
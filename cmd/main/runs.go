@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/spf13/cobra"
 	"station/cmd/main/handlers"
+	"station/cmd/main/handlers/agent"
 )
 
 // Runs command definitions
@@ -27,6 +28,69 @@ var (
 		Args:  cobra.ExactArgs(1),
 		RunE:  runRunsInspect,
 	}
+
+	runsCancelCmd = &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Cancel a running execution",
+		Long:  "Cancel a running agent execution on demand, marking the run as cancelled",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRunsCancel,
+	}
+
+	runsApproveCmd = &cobra.Command{
+		Use:   "approve <id>",
+		Short: "Approve a run's pending tool call",
+		Long:  "Approve the gated tool call a run is paused on (approval_tools frontmatter); the blocked execution resumes and runs the tool",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRunsApprove,
+	}
+
+	runsDenyCmd = &cobra.Command{
+		Use:   "deny <id>",
+		Short: "Deny a run's pending tool call",
+		Long:  "Deny the gated tool call a run is paused on; the tool does not execute and the agent is told the call was rejected so it can adjust",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRunsDeny,
+	}
+
+	runsShareCmd = &cobra.Command{
+		Use:   "share <id>",
+		Short: "Create a read-only share link for a run",
+		Long:  "Generate an expiring, tokenized URL that renders the run as a standalone redacted HTML page for stakeholders without Station access",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRunsShare,
+	}
+
+	runsTriageCmd = &cobra.Command{
+		Use:   "triage [id]",
+		Short: "Analyze why a run failed",
+		Long:  "Feed a failed run's steps, tool errors, and config context to the configured model for a structured root-cause verdict (category, probable cause, suggested fix), stored on the run. Use --summary to aggregate verdicts across runs.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runRunsTriage,
+	}
+
+	runsResumeCmd = &cobra.Command{
+		Use:   "resume <id>",
+		Short: "Resume an interrupted run from its checkpoint",
+		Long:  "Continue a crashed or failed run from its last checkpoint: completed tool calls are carried over into the new run so finished work and its token cost are not repeated",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRunsResume,
+	}
+
+	runsAnnotateCmd = &cobra.Command{
+		Use:   "annotate <id>",
+		Short: "Record human feedback on a run",
+		Long:  "Attach a 1-5 rating, thumbs up/down, or comment to a run. Feedback appears in 'stn runs inspect' and report evaluations; --benchmark additionally turns a completed run into a benchmark task for supervised evaluation.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRunsAnnotate,
+	}
+
+	runsExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export runs for data warehouses",
+		Long:  "Export runs, tool calls, execution steps, and benchmark metrics as flattened JSONL files suitable for loading into BigQuery/Snowflake, with optional push to an S3/GCS bucket",
+		RunE:  runRunsExport,
+	}
 )
 
 // runRunsList lists agent runs
@@ -40,3 +104,51 @@ func runRunsInspect(cmd *cobra.Command, args []string) error {
 	runsHandler := handlers.NewRunsHandler(themeManager)
 	return runsHandler.RunRunsInspect(cmd, args)
 }
+
+// runRunsCancel cancels a running execution
+func runRunsCancel(cmd *cobra.Command, args []string) error {
+	runsHandler := handlers.NewRunsHandler(themeManager)
+	return runsHandler.RunRunsCancel(cmd, args)
+}
+
+// runRunsApprove approves a run's pending gated tool call
+func runRunsApprove(cmd *cobra.Command, args []string) error {
+	runsHandler := handlers.NewRunsHandler(themeManager)
+	return runsHandler.RunRunsApprove(cmd, args)
+}
+
+// runRunsDeny denies a run's pending gated tool call
+func runRunsDeny(cmd *cobra.Command, args []string) error {
+	runsHandler := handlers.NewRunsHandler(themeManager)
+	return runsHandler.RunRunsDeny(cmd, args)
+}
+
+// runRunsShare creates a read-only share link for a run
+func runRunsShare(cmd *cobra.Command, args []string) error {
+	runsHandler := handlers.NewRunsHandler(themeManager)
+	return runsHandler.RunRunsShare(cmd, args)
+}
+
+// runRunsTriage analyzes a failed run's root cause
+func runRunsTriage(cmd *cobra.Command, args []string) error {
+	runsHandler := handlers.NewRunsHandler(themeManager)
+	return runsHandler.RunRunsTriage(cmd, args)
+}
+
+// runRunsResume continues an interrupted run from its last checkpoint
+func runRunsResume(cmd *cobra.Command, args []string) error {
+	agentHandler := agent.NewAgentHandler(nil, telemetryService)
+	return agentHandler.RunAgentResume(cmd, args)
+}
+
+// runRunsAnnotate records human feedback on a run
+func runRunsAnnotate(cmd *cobra.Command, args []string) error {
+	runsHandler := handlers.NewRunsHandler(themeManager)
+	return runsHandler.RunRunsAnnotate(cmd, args)
+}
+
+// runRunsExport exports runs as flattened warehouse-ready files
+func runRunsExport(cmd *cobra.Command, args []string) error {
+	runsHandler := handlers.NewRunsHandler(themeManager)
+	return runsHandler.RunRunsExport(cmd, args)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"station/internal/config"
+	"station/internal/services"
+)
+
+// Serve mode writes its PID here so `stn serve drain` can signal the running
+// process from another shell
+func servePidFilePath() string {
+	return filepath.Join(config.GetConfigRoot(), "station.pid")
+}
+
+// writeServePidFile records the server's PID for drain signalling
+func writeServePidFile() {
+	path := servePidFilePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Printf("Warning: failed to create config directory for pid file: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		log.Printf("Warning: failed to write pid file: %v", err)
+	}
+}
+
+// removeServePidFile cleans up the pid file on shutdown
+func removeServePidFile() {
+	_ = os.Remove(servePidFilePath())
+}
+
+// drainTimeout returns how long drain mode waits for in-flight executions
+// before forcing shutdown (STATION_DRAIN_TIMEOUT_SECONDS, default 120s)
+func drainTimeout() time.Duration {
+	if value := os.Getenv("STATION_DRAIN_TIMEOUT_SECONDS"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 120 * time.Second
+}
+
+// watchForDrainSignal starts the drain handler: on SIGUSR1 (sent directly or
+// via `stn serve drain`) the server stops accepting new runs and workflow
+// starts, waits for in-flight executions up to the drain timeout, then feeds
+// the normal shutdown path. Queued runs in the database and pending JetStream
+// messages persist across the restart, so only in-flight work is waited on.
+func watchForDrainSignal(shutdown chan<- os.Signal) {
+	drainCh := make(chan os.Signal, 1)
+	if !registerDrainSignal(drainCh) {
+		return
+	}
+
+	go func() {
+		<-drainCh
+		coordinator := services.SharedDrainCoordinator()
+		coordinator.BeginDrain()
+
+		timeout := drainTimeout()
+		inFlight := coordinator.InFlight()
+		log.Printf("🪫 Drain requested: rejecting new runs, waiting up to %s for %d in-flight execution(s)", timeout, inFlight)
+
+		if coordinator.Wait(timeout) {
+			log.Printf("🪫 Drain complete: all in-flight executions finished")
+		} else {
+			log.Printf("🪫 Drain timeout reached with %d execution(s) still running; shutting down anyway", coordinator.InFlight())
+		}
+		log.Printf("🪫 Queued runs and pending workflow messages persist and will resume after restart")
+
+		shutdown <- os.Interrupt
+	}()
+}
+
+// runServeDrain sends the drain signal to the running server using the pid
+// file written by serve mode
+func runServeDrain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(servePidFilePath())
+	if err != nil {
+		return fmt.Errorf("no running server found (missing pid file %s): %w", servePidFilePath(), err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("invalid pid file %s: %w", servePidFilePath(), err)
+	}
+
+	if err := sendDrainSignal(pid); err != nil {
+		return fmt.Errorf("failed to signal server process %d: %w", pid, err)
+	}
+
+	fmt.Printf("🪫 Drain signal sent to server process %d\n", pid)
+	fmt.Printf("   The server will finish in-flight executions (up to the drain timeout) and exit\n")
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// registerDrainSignal subscribes the channel to SIGUSR1 (Unix only)
+func registerDrainSignal(ch chan<- os.Signal) bool {
+	signal.Notify(ch, syscall.SIGUSR1)
+	return true
+}
+
+// sendDrainSignal delivers SIGUSR1 to the running server process
+func sendDrainSignal(pid int) error {
+	return syscall.Kill(pid, syscall.SIGUSR1)
+}
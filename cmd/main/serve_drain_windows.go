@@ -0,0 +1,18 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// registerDrainSignal is not supported on Windows (no SIGUSR1)
+func registerDrainSignal(ch chan<- os.Signal) bool {
+	return false
+}
+
+// sendDrainSignal is not supported on Windows
+func sendDrainSignal(pid int) error {
+	return fmt.Errorf("drain signalling is not supported on Windows; stop the server with Ctrl+C instead")
+}
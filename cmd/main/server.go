@@ -182,6 +182,7 @@ func runMainServer() error {
 		Interactive: false, // Non-interactive for Docker containers
 		Verbose:     true,  // Verbose for debugging
 		Confirm:     false,
+		Source:      "serve",
 	})
 	if err != nil {
 		// Log sync failure but don't crash the server - cleanup already ran
@@ -208,6 +209,11 @@ func runMainServer() error {
 		log.Printf("Warning: Failed to initialize Lighthouse client: %v", err)
 	}
 
+	// Apply org quotas pushed from CloudShip so the run queue enforces them locally
+	if lighthouseClient != nil {
+		lighthouseClient.SetOrgSettingsHandler(services.NewQuotaService(repos).ApplyPushedSettings)
+	}
+
 	// Initialize workflow engine FIRST for NATS-based workflow execution
 	// IMPORTANT: This must happen before AgentService creation because the
 	// CodingToolFactory needs the embedded NATS server to be running
@@ -239,12 +245,63 @@ func runMainServer() error {
 		log.Printf("Warning: Failed to initialize MCP for agent service: %v", err)
 	}
 
+	// Distributed run execution over NATS JetStream (stn serve --worker)
+	workerMode := viper.GetBool("worker_mode")
+	distributedRuns := viper.GetBool("distributed_runs")
+	var runQueueSvc *services.RunQueueService
+	if workerMode || distributedRuns {
+		if workflowEngine == nil {
+			log.Printf("⚠️  Run queue requires NATS (workflow engine) - distributed run execution disabled")
+		} else if runQueueSvc, err = services.NewRunQueueService(workflowEngine.JetStream(), repos, agentSvc); err != nil {
+			log.Printf("⚠️  Failed to initialize run queue: %v", err)
+			runQueueSvc = nil
+		} else if workerMode {
+			if err := runQueueSvc.StartWorker(ctx); err != nil {
+				log.Printf("⚠️  Failed to start run queue worker: %v", err)
+			} else {
+				log.Printf("✅ Worker mode: consuming run requests from JetStream")
+			}
+		}
+	}
+
 	schedulerSvc := services.NewSchedulerService(database, repos, agentSvc)
 	if err := schedulerSvc.Start(); err != nil {
 		return fmt.Errorf("failed to start scheduler service: %w", err)
 	}
 	defer schedulerSvc.Stop()
 
+	sloMonitorSvc := services.NewSLOMonitorService(repos, cfg)
+	sloMonitorSvc.Start()
+	defer sloMonitorSvc.Stop()
+
+	agentHealthSvc := services.NewAgentHealthService(repos, agentSvc, cfg)
+	agentHealthSvc.Start()
+	defer agentHealthSvc.Stop()
+
+	gitOpsSvc := services.NewGitOpsService(repos, cfg)
+	gitOpsSvc.Start()
+	defer gitOpsSvc.Stop()
+
+	runExportSvc := services.NewRunExportService(repos)
+	runExportSvc.Start()
+	defer runExportSvc.Stop()
+
+	softDeletePurgeSvc := services.NewSoftDeletePurgeService(repos, cfg)
+	softDeletePurgeSvc.Start()
+	defer softDeletePurgeSvc.Stop()
+
+	// Pre-warm MCP servers so the first run after boot skips stdio
+	// cold-start costs, then keep them healthy for reuse across runs
+	mcpPoolManager := services.NewMCPConnectionManager(repos, nil)
+	go func() {
+		if err := mcpPoolManager.PrewarmEnvironments(ctx); err != nil {
+			log.Printf("Warning: MCP pool pre-warming failed: %v", err)
+		}
+	}()
+	mcpPoolManager.StartPoolKeepAlive()
+	defer mcpPoolManager.StopPoolKeepAlive()
+	defer mcpPoolManager.ShutdownServerPool()
+
 	workflowSchedulerSvc := services.NewWorkflowSchedulerService(repos, workflowService)
 	if err := workflowSchedulerSvc.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start workflow scheduler service: %w", err)
@@ -487,6 +544,12 @@ func runMainServer() error {
 		apiServer.SetWorkflowComponents(workflowService, workflowEngine, workflowTelemetry)
 	}
 
+	// Route API queue requests through JetStream when workers handle execution
+	if runQueueSvc != nil && distributedRuns && !workerMode {
+		apiServer.SetRunQueue(runQueueSvc)
+		log.Printf("✅ Distributed runs: API queue requests will be published to JetStream")
+	}
+
 	apiServer.InitializeHandlers()
 	apiServer.SetWorkflowScheduler(workflowSchedulerSvc)
 
@@ -561,6 +624,12 @@ func runMainServer() error {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
+	// Drain mode for rolling restarts: SIGUSR1 (or `stn serve drain`) stops
+	// new executions, waits for in-flight runs, then triggers shutdown
+	writeServePidFile()
+	defer removeServePidFile()
+	watchForDrainSignal(c)
+
 	<-c
 	fmt.Println("\n🛑 Received shutdown signal, gracefully shutting down...")
 
@@ -609,6 +678,11 @@ func runMainServer() error {
 		log.Printf("🔗 Lattice embedded NATS server stopped")
 	}
 
+	if runQueueSvc != nil {
+		runQueueSvc.Stop()
+		log.Printf("🛑 Run queue stopped")
+	}
+
 	if workflowConsumer != nil {
 		log.Printf("🛑 Shutting down workflow consumer...")
 		workflowConsumer.Stop()
@@ -705,8 +779,11 @@ func startServerWorkflowConsumer(
 	registry.Register(runtime.NewCustomExecutor(nil))
 	registry.Register(runtime.NewCronExecutor())
 	registry.Register(runtime.NewTimerExecutor())
+	registry.Register(runtime.NewAwaitEventExecutor())
 	registry.Register(runtime.NewTryCatchExecutor(registry))
 	registry.Register(runtime.NewTransformExecutor())
+	registry.Register(runtime.NewScriptExecutor())
+	registry.Register(runtime.NewHTTPExecutor())
 
 	stepAdapter := &serverRegistryStepExecutorAdapter{registry: registry}
 	registry.Register(runtime.NewParallelExecutor(stepAdapter))
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/internal/services"
+)
+
+var sshKeysAddName string
+
+var sshKeysCmd = &cobra.Command{
+	Use:   "ssh-keys",
+	Short: "Manage per-user SSH keys for the admin interface",
+	Long: `Manage the SSH public keys authorized for each user on the Station
+SSH admin interface, replacing a single shared credential. Keys added here
+are checked before the system user's authorized_keys file.`,
+}
+
+var sshKeysAddCmd = &cobra.Command{
+	Use:   "add <username> <public-key-or-file>",
+	Short: "Authorize a public key for a user",
+	Long: `Authorize an SSH public key (authorized_keys format) for a user.
+The key argument is either the key itself or a path to a .pub file.
+
+Examples:
+  stn ssh-keys add alice ~/.ssh/id_ed25519.pub
+  stn ssh-keys add bob "ssh-ed25519 AAAA... bob@laptop" --name laptop`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSSHKeysAdd,
+}
+
+var sshKeysRemoveCmd = &cobra.Command{
+	Use:   "remove <username> <key-name>",
+	Short: "Remove a user's authorized key by name",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runSSHKeysRemove,
+}
+
+var sshKeysListCmd = &cobra.Command{
+	Use:   "list <username>",
+	Short: "List a user's authorized keys",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSSHKeysList,
+}
+
+func init() {
+	sshKeysAddCmd.Flags().StringVar(&sshKeysAddName, "name", "", "Name for the key (defaults to the key comment)")
+
+	sshKeysCmd.AddCommand(sshKeysAddCmd)
+	sshKeysCmd.AddCommand(sshKeysRemoveCmd)
+	sshKeysCmd.AddCommand(sshKeysListCmd)
+}
+
+func newSSHKeyService() (*services.SSHKeyService, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+	cleanup := func() { _ = database.Close() }
+	return services.NewSSHKeyService(repositories.New(database)), cleanup, nil
+}
+
+func runSSHKeysAdd(cmd *cobra.Command, args []string) error {
+	username := args[0]
+	keyArg := args[1]
+
+	// Accept either a key file path or the key itself
+	keyLine := keyArg
+	if content, err := os.ReadFile(keyArg); err == nil {
+		keyLine = strings.TrimSpace(string(content))
+	}
+
+	keyService, cleanup, err := newSSHKeyService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	key, err := keyService.AddKey(username, sshKeysAddName, keyLine)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Authorized key '%s' for user '%s'\n", key.Name, username)
+	return nil
+}
+
+func runSSHKeysRemove(cmd *cobra.Command, args []string) error {
+	username := args[0]
+	keyName := args[1]
+
+	keyService, cleanup, err := newSSHKeyService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := keyService.RemoveKey(username, keyName); err != nil {
+		return err
+	}
+
+	fmt.Printf("🗑️  Removed key '%s' for user '%s'\n", keyName, username)
+	return nil
+}
+
+func runSSHKeysList(cmd *cobra.Command, args []string) error {
+	username := args[0]
+
+	keyService, cleanup, err := newSSHKeyService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	keys, err := keyService.ListKeys(username)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		fmt.Printf("No keys authorized for user '%s'\n", username)
+		return nil
+	}
+
+	fmt.Printf("🔑 Keys for user '%s' (%d):\n\n", username, len(keys))
+	for _, key := range keys {
+		keyType := strings.SplitN(key.PublicKey, " ", 2)[0]
+		fmt.Printf("  • %s (%s, added %s)\n", key.Name, keyType, key.AddedAt.Format("2006-01-02"))
+	}
+	return nil
+}
@@ -48,6 +48,9 @@ func init() {
 }
 
 func runStdioServer(cmd *cobra.Command, args []string) error {
+	// Tag stdio MCP logs so JSON log consumers can separate them from CLI output
+	logging.SetComponent("mcp")
+
 	// Set GenKit environment based on --dev flag
 	devMode, _ := cmd.Flags().GetBool("dev")
 	coreMode, _ := cmd.Flags().GetBool("core")
@@ -308,8 +311,11 @@ func startStdioWorkflowConsumer(ctx context.Context, repos *repositories.Reposit
 	registry.Register(runtime.NewCustomExecutor(nil))
 	registry.Register(runtime.NewCronExecutor())
 	registry.Register(runtime.NewTimerExecutor())
+	registry.Register(runtime.NewAwaitEventExecutor())
 	registry.Register(runtime.NewTryCatchExecutor(registry))
 	registry.Register(runtime.NewTransformExecutor())
+	registry.Register(runtime.NewScriptExecutor())
+	registry.Register(runtime.NewHTTPExecutor())
 
 	stepAdapter := &stdioRegistryStepExecutorAdapter{registry: registry}
 	registry.Register(runtime.NewParallelExecutor(stepAdapter))
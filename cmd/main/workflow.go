@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"station/cmd/main/handlers/common"
 	"station/internal/config"
 	"station/internal/db"
 	"station/internal/db/repositories"
@@ -119,6 +120,30 @@ var (
 		RunE:  runWorkflowDelete,
 	}
 
+	workflowCanaryCmd = &cobra.Command{
+		Use:   "canary <workflow-id>",
+		Short: "Start a gradual rollout of the latest version",
+		Long:  "Route a percentage of new runs to the latest workflow version while the rest stay on the previous version. Compare results with 'stn workflow show', then promote or rollback.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWorkflowCanary,
+	}
+
+	workflowPromoteCmd = &cobra.Command{
+		Use:   "promote <workflow-id>",
+		Short: "Promote the canary version to all traffic",
+		Long:  "End an in-progress canary rollout with the canary version taking all new runs",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWorkflowPromote,
+	}
+
+	workflowRollbackCmd = &cobra.Command{
+		Use:   "rollback <workflow-id>",
+		Short: "Roll back an in-progress canary rollout",
+		Long:  "End an in-progress canary rollout by re-publishing the stable version's definition as the new latest version",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWorkflowRollback,
+	}
+
 	workflowValidateCmd = &cobra.Command{
 		Use:   "validate <workflow-file>",
 		Short: "Validate a workflow definition",
@@ -230,6 +255,22 @@ func runWorkflowList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list workflows: %w", err)
 	}
 
+	// Machine formats emit only the rows, no banners or styling
+	if format := common.OutputFormat(cmd); common.MachineFormat(format) {
+		columns := []string{"workflow_id", "name", "version", "status", "updated_at"}
+		rows := make([][]string, 0, len(workflows))
+		for _, wf := range workflows {
+			rows = append(rows, []string{
+				wf.WorkflowID,
+				wf.Name,
+				fmt.Sprintf("%d", wf.Version),
+				wf.Status,
+				wf.UpdatedAt.Format(time.RFC3339),
+			})
+		}
+		return common.RenderList(format, columns, rows)
+	}
+
 	if len(workflows) == 0 {
 		fmt.Println("No workflows found.")
 		fmt.Println("Create workflow files in ~/.config/station/environments/<env>/workflows/")
@@ -293,6 +334,20 @@ func runWorkflowShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("   Created: %s\n", wf.CreatedAt.Format(time.RFC3339))
 	fmt.Printf("   Updated: %s\n", wf.UpdatedAt.Format(time.RFC3339))
 
+	if canary, err := workflowService.GetCanary(workflowID); err == nil && canary != nil {
+		fmt.Printf("\n🐤 Canary rollout: %d%% of new runs on v%d, %d%% on v%d\n",
+			canary.Percent, canary.CanaryVersion, 100-canary.Percent, canary.StableVersion)
+		fmt.Printf("   Promote: stn workflow promote %s | Rollback: stn workflow rollback %s\n", workflowID, workflowID)
+	}
+
+	if metrics, err := workflowService.VersionMetrics(ctx, workflowID); err == nil && len(metrics) > 0 {
+		fmt.Printf("\n📈 Recent runs by version:\n")
+		for _, m := range metrics {
+			fmt.Printf("   v%d: %d runs, %d succeeded, %d failed (%.1f%% success)\n",
+				m.Version, m.Runs, m.Succeeded, m.Failed, m.SuccessRatePercent())
+		}
+	}
+
 	// Pretty print definition
 	verbose, _ := cmd.Flags().GetBool("verbose")
 	if verbose {
@@ -309,6 +364,88 @@ func runWorkflowShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runWorkflowCanary(cmd *cobra.Command, args []string) error {
+	workflowID := args[0]
+	percent, _ := cmd.Flags().GetInt("percent")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	workflowService := services.NewWorkflowService(repos)
+
+	canary, err := workflowService.StartCanary(context.Background(), workflowID, percent)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🐤 Canary rollout started for %s\n", workflowID)
+	fmt.Printf("   %d%% of new runs → v%d (canary)\n", canary.Percent, canary.CanaryVersion)
+	fmt.Printf("   %d%% of new runs → v%d (stable)\n", 100-canary.Percent, canary.StableVersion)
+	fmt.Printf("\nCompare versions with 'stn workflow show %s', then promote or rollback.\n", workflowID)
+	return nil
+}
+
+func runWorkflowPromote(cmd *cobra.Command, args []string) error {
+	workflowID := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	workflowService := services.NewWorkflowService(repos)
+
+	canary, err := workflowService.PromoteCanary(workflowID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Promoted %s v%d — all new runs now use the canary version\n", workflowID, canary.CanaryVersion)
+	return nil
+}
+
+func runWorkflowRollback(cmd *cobra.Command, args []string) error {
+	workflowID := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	database, err := db.New(cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	repos := repositories.New(database)
+	workflowService := services.NewWorkflowService(repos)
+
+	restored, err := workflowService.RollbackCanary(context.Background(), workflowID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("↩️  Rolled back %s — stable definition re-published as v%d\n", workflowID, restored.Version)
+	return nil
+}
+
 func runWorkflowRun(cmd *cobra.Command, args []string) error {
 	workflowID := args[0]
 	inputJSON, _ := cmd.Flags().GetString("input")
@@ -637,7 +774,7 @@ func getStatusIcon(status string) string {
 		return "❌"
 	case "running", "pending":
 		return "🔄"
-	case "waiting_approval":
+	case "waiting_approval", "waiting_timer", "waiting_event":
 		return "⏸️"
 	case "canceled":
 		return "⏹️"
@@ -715,8 +852,11 @@ func startCLIWorkflowConsumer(ctx context.Context, repos *repositories.Repositor
 	registry.Register(runtime.NewCustomExecutor(nil))
 	registry.Register(runtime.NewCronExecutor())
 	registry.Register(runtime.NewTimerExecutor())
+	registry.Register(runtime.NewAwaitEventExecutor())
 	registry.Register(runtime.NewTryCatchExecutor(registry))
 	registry.Register(runtime.NewTransformExecutor())
+	registry.Register(runtime.NewScriptExecutor())
+	registry.Register(runtime.NewHTTPExecutor())
 
 	stepAdapter := &cliRegistryStepExecutorAdapter{registry: registry}
 	registry.Register(runtime.NewParallelExecutor(stepAdapter))
@@ -69,6 +69,9 @@ type Server struct {
 	workflowService   *services.WorkflowService
 	workflowEngine    runtime.Engine
 	workflowTelemetry *runtime.WorkflowTelemetry
+
+	// Run queue - injected from server.go when distributed run execution is enabled
+	runQueue *services.RunQueueService
 }
 
 func New(cfg *internalconfig.Config, database db.Database, localMode bool, telemetryService *telemetry.TelemetryService) *Server {
@@ -114,6 +117,15 @@ func (s *Server) SetWorkflowComponents(workflowService *services.WorkflowService
 	s.workflowTelemetry = telemetry
 }
 
+// SetRunQueue routes queued run requests through NATS JetStream so worker
+// Stations (`stn serve --worker`) execute them instead of this process
+func (s *Server) SetRunQueue(runQueue *services.RunQueueService) {
+	s.runQueue = runQueue
+	if s.handlers != nil {
+		s.handlers.SetRunQueue(runQueue)
+	}
+}
+
 // InitializeHandlers initializes the API handlers and starts the workflow consumer.
 // This must be called after SetAgentService and SetWorkflowComponents, and before Start.
 func (s *Server) InitializeHandlers() {
@@ -154,6 +166,10 @@ func (s *Server) InitializeHandlers() {
 			s.cfg,
 		)
 	}
+
+	if s.runQueue != nil {
+		s.handlers.SetRunQueue(s.runQueue)
+	}
 }
 
 func (s *Server) Start(ctx context.Context) error {
@@ -194,6 +210,9 @@ func (s *Server) Start(ctx context.Context) error {
 		c.JSON(http.StatusOK, gin.H{"debug": "working"})
 	})
 
+	// Public read-only share pages (token is the capability, content is redacted)
+	router.GET("/share/:token", s.handleShareLink)
+
 	// Ensure handlers are initialized
 	s.InitializeHandlers()
 
@@ -377,6 +396,40 @@ func (s *Server) setupUIRoutes(router *gin.Engine) {
 		c.Data(http.StatusOK, c.GetHeader("Content-Type"), content)
 	})
 
+	// Serve the UI under /ui as a stable mount point (assets still resolve at
+	// root, so this reuses the same filesystem and falls back to index.html)
+	router.GET("/ui/*filepath", func(c *gin.Context) {
+		requestPath := strings.TrimPrefix(c.Param("filepath"), "/")
+
+		if requestPath != "" {
+			if file, err := uiFS.Open(requestPath); err == nil {
+				defer func() { _ = file.Close() }()
+
+				content, err := io.ReadAll(file)
+				if err == nil {
+					c.Data(http.StatusOK, contentTypeForUIPath(requestPath), content)
+					return
+				}
+			}
+		}
+
+		// SPA fallback - client-side routing handles /ui/agents, /ui/runs, etc.
+		file, err := uiFS.Open("index.html")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load UI"})
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read UI"})
+			return
+		}
+
+		c.Data(http.StatusOK, "text/html; charset=utf-8", content)
+	})
+
 	// Serve index.html for all other routes (SPA catch-all)
 	router.NoRoute(func(c *gin.Context) {
 		// Skip API routes
@@ -427,3 +480,27 @@ func (s *Server) setupUIRoutes(router *gin.Engine) {
 		c.Data(http.StatusOK, "text/html; charset=utf-8", content)
 	})
 }
+
+// contentTypeForUIPath maps embedded UI file extensions to MIME types
+func contentTypeForUIPath(path string) string {
+	switch {
+	case strings.HasSuffix(path, ".html"):
+		return "text/html; charset=utf-8"
+	case strings.HasSuffix(path, ".js"):
+		return "application/javascript"
+	case strings.HasSuffix(path, ".css"):
+		return "text/css"
+	case strings.HasSuffix(path, ".svg"):
+		return "image/svg+xml"
+	case strings.HasSuffix(path, ".png"):
+		return "image/png"
+	case strings.HasSuffix(path, ".jpg"), strings.HasSuffix(path, ".jpeg"):
+		return "image/jpeg"
+	case strings.HasSuffix(path, ".gif"):
+		return "image/gif"
+	case strings.HasSuffix(path, ".ico"):
+		return "image/x-icon"
+	default:
+		return "application/octet-stream"
+	}
+}
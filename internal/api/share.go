@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"station/internal/services"
+)
+
+// sharePageTemplate renders a shared run or report as a standalone read-only
+// HTML page. No UI assets or Station access required on the viewer's side.
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex, nofollow">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} - Station</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; background: #1a1b26; color: #c0caf5; margin: 0; padding: 2rem; }
+.container { max-width: 900px; margin: 0 auto; }
+h1 { color: #7aa2f7; font-size: 1.4rem; }
+.meta { color: #565f89; font-size: 0.85rem; margin-bottom: 1.5rem; }
+.section { background: #24283b; border-radius: 8px; padding: 1rem 1.25rem; margin-bottom: 1rem; }
+.section h2 { color: #bb9af7; font-size: 1rem; margin-top: 0; }
+.status { display: inline-block; padding: 0.15rem 0.6rem; border-radius: 4px; font-size: 0.8rem; }
+.status-completed { background: #1f3d2e; color: #9ece6a; }
+.status-failed { background: #3d1f28; color: #f7768e; }
+.status-other { background: #2d3148; color: #e0af68; }
+pre { white-space: pre-wrap; word-wrap: break-word; font-size: 0.85rem; line-height: 1.5; margin: 0; }
+.footer { color: #565f89; font-size: 0.75rem; margin-top: 2rem; }
+</style>
+</head>
+<body>
+<div class="container">
+<h1>{{.Title}}</h1>
+<div class="meta">
+Shared read-only view &middot; expires {{.ExpiresAt}} &middot;
+<span class="status status-{{.StatusClass}}">{{.Status}}</span>
+</div>
+{{range .Sections}}
+<div class="section">
+<h2>{{.Heading}}</h2>
+<pre>{{.Body}}</pre>
+</div>
+{{end}}
+<div class="footer">Generated by Station. Sensitive values are redacted.</div>
+</div>
+</body>
+</html>`))
+
+type sharePageSection struct {
+	Heading string
+	Body    string
+}
+
+type sharePageData struct {
+	Title       string
+	Status      string
+	StatusClass string
+	ExpiresAt   string
+	Sections    []sharePageSection
+}
+
+// handleShareLink serves the public read-only page for a share token. The
+// route is unauthenticated by design: the token is the capability, links
+// expire, and all content passes through redaction.
+func (s *Server) handleShareLink(c *gin.Context) {
+	shareService := services.NewShareService(s.repos)
+
+	link, err := shareService.Resolve(c.Param("token"))
+	if err != nil {
+		c.String(http.StatusNotFound, "This share link is invalid or has expired.")
+		return
+	}
+
+	var data *sharePageData
+	switch link.ResourceType {
+	case services.ShareResourceRun:
+		data, err = s.buildRunSharePage(c.Request.Context(), link.ResourceID)
+	case services.ShareResourceReport:
+		data, err = s.buildReportSharePage(c.Request.Context(), link.ResourceID)
+	default:
+		err = fmt.Errorf("unknown resource type %s", link.ResourceType)
+	}
+	if err != nil {
+		c.String(http.StatusNotFound, "The shared resource is no longer available.")
+		return
+	}
+
+	data.ExpiresAt = link.ExpiresAt.Format("2006-01-02 15:04 MST")
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := sharePageTemplate.Execute(c.Writer, data); err != nil {
+		c.String(http.StatusInternalServerError, "Failed to render share page.")
+	}
+}
+
+// buildRunSharePage assembles the redacted page content for a shared run
+func (s *Server) buildRunSharePage(ctx context.Context, runID int64) (*sharePageData, error) {
+	run, err := s.repos.AgentRuns.GetByID(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	agentName := fmt.Sprintf("Agent %d", run.AgentID)
+	if agent, err := s.repos.Agents.GetByID(run.AgentID); err == nil {
+		agentName = agent.Name
+	}
+
+	data := &sharePageData{
+		Title:       fmt.Sprintf("Run #%d - %s", run.ID, agentName),
+		Status:      run.Status,
+		StatusClass: shareStatusClass(run.Status),
+		Sections: []sharePageSection{
+			{Heading: "Task", Body: services.RedactSensitive(run.Task)},
+		},
+	}
+
+	if run.FinalResponse != "" {
+		data.Sections = append(data.Sections, sharePageSection{
+			Heading: "Result", Body: services.RedactSensitive(run.FinalResponse),
+		})
+	}
+
+	details := fmt.Sprintf("Started: %s\nSteps taken: %d", run.StartedAt.Format(time.RFC1123), run.StepsTaken)
+	if run.DurationSeconds != nil {
+		details += fmt.Sprintf("\nDuration: %.1fs", *run.DurationSeconds)
+	}
+	if run.TotalTokens != nil {
+		details += fmt.Sprintf("\nTokens: %d", *run.TotalTokens)
+	}
+	if run.ModelName != nil && *run.ModelName != "" {
+		details += fmt.Sprintf("\nModel: %s", *run.ModelName)
+	}
+	data.Sections = append(data.Sections, sharePageSection{Heading: "Execution Details", Body: details})
+
+	return data, nil
+}
+
+// buildReportSharePage assembles the redacted page content for a shared report
+func (s *Server) buildReportSharePage(ctx context.Context, reportID int64) (*sharePageData, error) {
+	report, err := s.repos.Reports.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &sharePageData{
+		Title:       fmt.Sprintf("Report: %s", report.Name),
+		Status:      report.Status,
+		StatusClass: shareStatusClass(report.Status),
+	}
+
+	if report.Description.Valid && report.Description.String != "" {
+		data.Sections = append(data.Sections, sharePageSection{
+			Heading: "Description", Body: services.RedactSensitive(report.Description.String),
+		})
+	}
+	if report.ExecutiveSummary.Valid && report.ExecutiveSummary.String != "" {
+		data.Sections = append(data.Sections, sharePageSection{
+			Heading: "Executive Summary", Body: services.RedactSensitive(report.ExecutiveSummary.String),
+		})
+	}
+	if report.TeamReasoning.Valid && report.TeamReasoning.String != "" {
+		data.Sections = append(data.Sections, sharePageSection{
+			Heading: "Team Assessment", Body: services.RedactSensitive(report.TeamReasoning.String),
+		})
+	}
+
+	summary := ""
+	if report.TeamScore.Valid {
+		summary += fmt.Sprintf("Team score: %.1f\n", report.TeamScore.Float64)
+	}
+	if report.TotalRunsAnalyzed.Valid {
+		summary += fmt.Sprintf("Runs analyzed: %d\n", report.TotalRunsAnalyzed.Int64)
+	}
+	if report.TotalAgentsAnalyzed.Valid {
+		summary += fmt.Sprintf("Agents analyzed: %d\n", report.TotalAgentsAnalyzed.Int64)
+	}
+	if summary != "" {
+		data.Sections = append(data.Sections, sharePageSection{Heading: "Metrics", Body: summary})
+	}
+
+	return data, nil
+}
+
+// shareStatusClass maps a run/report status to a page style class
+func shareStatusClass(status string) string {
+	switch status {
+	case "completed":
+		return "completed"
+	case "failed":
+		return "failed"
+	default:
+		return "other"
+	}
+}
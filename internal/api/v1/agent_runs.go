@@ -3,18 +3,24 @@ package v1
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"station/internal/services"
 )
 
 // registerAgentRunRoutes registers agent run routes
 func (h *APIHandlers) registerAgentRunRoutes(group *gin.RouterGroup) {
-	group.GET("", h.listRuns)                        // Users can list runs
-	group.GET("/:id", h.getRun)                      // Users can get run details
-	group.GET("/:id/logs", h.getRunLogs)             // Users can get run debug logs
-	group.GET("/agent/:agent_id", h.listRunsByAgent) // Users can list runs by agent
-	group.DELETE("/:id", h.deleteRun)                // Delete a single run
-	group.DELETE("", h.deleteRuns)                   // Delete runs (bulk by IDs, by status, or all)
+	group.GET("", h.listRuns)                             // Users can list runs
+	group.GET("/:id", h.getRun)                           // Users can get run details
+	group.GET("/:id/logs", h.getRunLogs)                  // Users can get run debug logs
+	group.GET("/agent/:agent_id", h.listRunsByAgent)      // Users can list runs by agent
+	group.DELETE("/:id", h.deleteRun)                     // Delete a single run
+	group.DELETE("", h.deleteRuns)                        // Delete runs (bulk by IDs, by status, or all)
+	group.POST("/:id/cancel", h.cancelRun)                // Cancel a running execution on demand
+	group.GET("/:id/annotations", h.listRunAnnotations)   // Human feedback on a run
+	group.POST("/:id/annotations", h.createRunAnnotation) // Record feedback (UI thumbs up/down, ratings)
 }
 
 // Agent runs handlers
@@ -37,9 +43,9 @@ func (h *APIHandlers) listRuns(c *gin.Context) {
 
 	if status != "" {
 		// Validate status
-		validStatuses := map[string]bool{"completed": true, "running": true, "failed": true, "pending": true}
+		validStatuses := map[string]bool{"completed": true, "running": true, "failed": true, "pending": true, "cancelled": true, "timed_out": true}
 		if !validStatuses[status] {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be one of: completed, running, failed, pending"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be one of: completed, running, failed, pending, cancelled, timed_out"})
 			return
 		}
 		runs, err = h.repos.AgentRuns.ListRecentByStatus(c.Request.Context(), status, limit)
@@ -79,6 +85,107 @@ func (h *APIHandlers) listRuns(c *gin.Context) {
 	})
 }
 
+// cancelRun cancels a running agent execution. If the run is executing in
+// this process the execution engine aborts it cleanly; otherwise a stale
+// "running" record is marked cancelled directly.
+func (h *APIHandlers) cancelRun(c *gin.Context) {
+	runID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run ID"})
+		return
+	}
+
+	run, err := h.repos.AgentRuns.GetByID(c.Request.Context(), runID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Run not found"})
+		return
+	}
+
+	if run.Status != "running" && run.Status != "pending" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Run is not active", "status": run.Status})
+		return
+	}
+
+	if services.CancelActiveRun(runID) {
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"run_id":  runID,
+			"message": "Cancellation requested; the run will be marked cancelled once it unwinds",
+		})
+		return
+	}
+
+	// No active execution in this process - mark the stale record cancelled
+	completedAt := time.Now()
+	errorMsg := "Run cancelled on demand (no active execution found)"
+	if err := h.repos.AgentRuns.UpdateCompletionWithMetadata(
+		c.Request.Context(), runID, errorMsg, 0, nil, nil, services.RunStatusCancelled, &completedAt,
+		nil, nil, nil, nil, nil, nil, &errorMsg,
+	); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel run"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"run_id":  runID,
+		"message": "Run marked cancelled",
+	})
+}
+
+// CreateRunAnnotationRequest is the request body for recording run feedback
+type CreateRunAnnotationRequest struct {
+	Rating  *int64  `json:"rating"`  // Optional 1-5 rating
+	Thumbs  *string `json:"thumbs"`  // Optional "up" or "down"
+	Comment string  `json:"comment"` // Optional free-form feedback
+}
+
+// createRunAnnotation records human feedback on a run (UI thumbs up/down,
+// ratings, comments)
+func (h *APIHandlers) createRunAnnotation(c *gin.Context) {
+	runID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run ID"})
+		return
+	}
+
+	var req CreateRunAnnotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	feedbackService := services.NewRunFeedbackService(h.repos)
+	annotation, err := feedbackService.Annotate(c.Request.Context(), runID, req.Rating, req.Thumbs, req.Comment, "ui")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"annotation": annotation})
+}
+
+// listRunAnnotations returns the feedback recorded on a run
+func (h *APIHandlers) listRunAnnotations(c *gin.Context) {
+	runID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run ID"})
+		return
+	}
+
+	annotations, err := h.repos.RunAnnotations.ListByRun(c.Request.Context(), runID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list annotations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"run_id":      runID,
+		"annotations": annotations,
+		"count":       len(annotations),
+	})
+}
+
 func (h *APIHandlers) getRun(c *gin.Context) {
 	runID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
@@ -231,9 +338,9 @@ func (h *APIHandlers) deleteRuns(c *gin.Context) {
 
 	if req.Status != "" {
 		// Validate status
-		validStatuses := map[string]bool{"completed": true, "running": true, "failed": true, "pending": true}
+		validStatuses := map[string]bool{"completed": true, "running": true, "failed": true, "pending": true, "cancelled": true, "timed_out": true}
 		if !validStatuses[req.Status] {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be one of: completed, running, failed, pending"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be one of: completed, running, failed, pending, cancelled, timed_out"})
 			return
 		}
 
@@ -197,11 +197,12 @@ func (h *APIHandlers) callAgent(c *gin.Context) {
 		response, err := h.agentService.ExecuteAgentWithRunID(ctx, agentID, req.Task, agentRun.ID, nil)
 		if err != nil {
 			log.Printf("❌ Agent execution failed: %v", err)
-			// Update run status to failed with error message
+			// Update run status with error message, distinguishing timeouts
+			// and cancellations from ordinary failures
 			completedAt := time.Now()
 			errorMsg := fmt.Sprintf("API execution failed: %v", err)
 			h.repos.AgentRuns.UpdateCompletionWithMetadata(
-				ctx, agentRun.ID, errorMsg, 0, nil, nil, "failed", &completedAt,
+				ctx, agentRun.ID, errorMsg, 0, nil, nil, services.ClassifyRunFailure(err), &completedAt,
 				nil, nil, nil, nil, nil, nil, &errorMsg,
 			)
 		} else {
@@ -324,8 +325,10 @@ func (h *APIHandlers) queueAgent(c *gin.Context) {
 	}
 
 	var req struct {
-		Task      string                 `json:"task" binding:"required"`
-		Variables map[string]interface{} `json:"variables"`
+		Task           string                      `json:"task" binding:"required"`
+		Variables      map[string]interface{}      `json:"variables"`
+		Attachments    []services.InlineAttachment `json:"attachments"`
+		IdempotencyKey string                      `json:"idempotency_key"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -333,6 +336,43 @@ func (h *APIHandlers) queueAgent(c *gin.Context) {
 		return
 	}
 
+	// Idempotency key from the body or the standard header; webhook and CI
+	// retries with the same key inside the dedup window return the original
+	// run instead of double-executing the agent
+	idempotencyKey := req.IdempotencyKey
+	if idempotencyKey == "" {
+		idempotencyKey = c.GetHeader("Idempotency-Key")
+	}
+	idempotencyService := services.NewRunIdempotencyService(h.repos)
+	if idempotencyKey != "" {
+		existing, err := idempotencyService.Check(agentID, idempotencyKey)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if existing != nil {
+			c.JSON(http.StatusOK, gin.H{
+				"agent_id": agentID,
+				"run_id":   existing.RunID,
+				"status":   "duplicate",
+				"message":  fmt.Sprintf("Duplicate request: run %d was already created for this idempotency key at %s", existing.RunID, existing.CreatedAt),
+			})
+			return
+		}
+	}
+
+	// Prepare inline attachments up front so malformed payloads fail fast
+	attachmentService := services.NewRunAttachmentService(h.repos)
+	var attachments []*services.RunAttachment
+	if len(req.Attachments) > 0 {
+		var err error
+		attachments, err = attachmentService.PrepareInlineAttachments(req.Attachments)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	// Get user ID for tracking (use console user for local mode)
 	var userID int64 = 1 // Default console user
 	if !h.localMode {
@@ -351,6 +391,37 @@ func (h *APIHandlers) queueAgent(c *gin.Context) {
 		return
 	}
 
+	// Distributed mode: publish the run request to NATS JetStream for a
+	// worker Station (stn serve --worker) instead of executing in-process
+	if h.runQueue != nil {
+		// Artifact storage is local to this Station — a worker would not see
+		// the files, so attachments are limited to in-process execution
+		if len(attachments) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "attachments are not supported in distributed mode"})
+			return
+		}
+		agentRun, err := h.runQueue.Enqueue(c.Request.Context(), agentID, userID, req.Task, req.Variables)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to enqueue agent run: %v", err)})
+			return
+		}
+
+		if idempotencyKey != "" {
+			if err := idempotencyService.Record(agentID, idempotencyKey, agentRun.ID, req.Task); err != nil {
+				log.Printf("Failed to record idempotency key for run %d: %v", agentRun.ID, err)
+			}
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"agent_id":   agentID,
+			"agent_name": agent.Name,
+			"run_id":     agentRun.ID,
+			"status":     "queued",
+			"message":    "Run request published to JetStream - a worker Station will execute it",
+		})
+		return
+	}
+
 	// Create agent run record (same as CLI approach)
 	agentRun, err := h.repos.AgentRuns.Create(
 		c.Request.Context(),
@@ -369,6 +440,26 @@ func (h *APIHandlers) queueAgent(c *gin.Context) {
 		return
 	}
 
+	if idempotencyKey != "" {
+		if err := idempotencyService.Record(agentID, idempotencyKey, agentRun.ID, req.Task); err != nil {
+			log.Printf("Failed to record idempotency key for run %d: %v", agentRun.ID, err)
+		}
+	}
+
+	// Store binary attachments as input artifacts, inject attachment context
+	// into the task, and record the metadata for run inspection (same as CLI)
+	executionTask := req.Task
+	if len(attachments) > 0 {
+		if err := attachmentService.StoreArtifacts(agentRun.ID, attachments); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to store attachments: %v", err)})
+			return
+		}
+		executionTask = services.AttachmentTaskContext(req.Task, attachments)
+		if err := attachmentService.RecordRunAttachments(agentRun.ID, attachments); err != nil {
+			log.Printf("Failed to record attachment metadata (Run ID: %d): %v", agentRun.ID, err)
+		}
+	}
+
 	// Execute agent directly (same as CLI) - async with goroutine for API responsiveness
 	go func() {
 		ctx := context.Background()
@@ -385,7 +476,7 @@ func (h *APIHandlers) queueAgent(c *gin.Context) {
 			}
 		}
 
-		_, err := h.agentService.ExecuteAgentWithRunID(ctx, agent.ID, req.Task, agentRun.ID, metadata)
+		_, err := h.agentService.ExecuteAgentWithRunID(ctx, agent.ID, executionTask, agentRun.ID, metadata)
 		if err != nil {
 			log.Printf("Agent execution failed (Run ID: %d): %v", agentRun.ID, err)
 		} else {
@@ -30,11 +30,19 @@ type APIHandlers struct {
 	workflowConsumer     *runtime.WorkflowConsumer
 	workflowEngine       runtime.Engine
 	workflowTelemetry    *runtime.WorkflowTelemetry
+	runQueue             *services.RunQueueService
 	filesHandler         *FilesHandler
 	localMode            bool
 	cfg                  *config.Config
 }
 
+// SetRunQueue enables distributed run execution: queued run requests are
+// published to NATS JetStream for worker Stations instead of executing
+// in-process
+func (h *APIHandlers) SetRunQueue(runQueue *services.RunQueueService) {
+	h.runQueue = runQueue
+}
+
 func NewAPIHandlers(
 	repos *repositories.Repositories,
 	db *sql.DB,
@@ -197,8 +205,11 @@ func (h *APIHandlers) startWorkflowConsumer(repos *repositories.Repositories, en
 	registry.Register(runtime.NewCustomExecutor(nil))
 	registry.Register(runtime.NewCronExecutor())
 	registry.Register(runtime.NewTimerExecutor())
+	registry.Register(runtime.NewAwaitEventExecutor())
 	registry.Register(runtime.NewTryCatchExecutor(registry))
 	registry.Register(runtime.NewTransformExecutor())
+	registry.Register(runtime.NewScriptExecutor())
+	registry.Register(runtime.NewHTTPExecutor())
 
 	stepAdapter := &registryStepExecutorAdapter{registry: registry}
 	registry.Register(runtime.NewParallelExecutor(stepAdapter))
@@ -678,6 +689,9 @@ func (h *APIHandlers) RegisterRoutes(router *gin.RouterGroup) {
 	}
 	h.registerOpenAPIRoutes(openapiGroup)
 
+	// Autoscaling hints (concurrency, queue depth, recommended workers)
+	router.GET("/capacity", h.CapacityHandler)
+
 	// CloudShip lighthouse status
 	router.GET("/lighthouse/status", h.LighthouseStatusHandler)
 
@@ -740,6 +754,15 @@ func (h *APIHandlers) syncConfigurations(c *gin.Context) {
 
 // registerMCPRoutes registers MCP tool bridge routes
 func (h *APIHandlers) registerMCPRoutes(mcpGroup *gin.RouterGroup) {
-	// For now, this can be empty as we're using existing REST endpoints
-	// Future: Could add direct MCP tool bridging if needed
+	mcpGroup.GET("/pool/stats", h.getMCPPoolStats)
+}
+
+// getMCPPoolStats reports lifecycle stats for every warm MCP server in the
+// shared connection pool (serve mode)
+func (h *APIHandlers) getMCPPoolStats(c *gin.Context) {
+	stats := services.SharedMCPServerPool().Stats()
+	c.JSON(http.StatusOK, gin.H{
+		"servers": stats,
+		"count":   len(stats),
+	})
 }
@@ -0,0 +1,22 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"station/internal/services"
+)
+
+// CapacityHandler reports current/peak concurrency, queue depth, average run
+// duration, and a recommended worker count. The response is flat numeric
+// JSON so external autoscalers (K8s HPA external metrics adapters, Nomad
+// autoscaler) can scale worker replicas directly on it.
+func (h *APIHandlers) CapacityHandler(c *gin.Context) {
+	report, err := services.NewCapacityService(h.repos).Report(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute capacity report"})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -65,6 +65,7 @@ func (h *APIHandlers) registerWorkflowRunRoutes(group *gin.RouterGroup) {
 	group.GET("/:runId/approvals", h.listWorkflowRunApprovals)
 	group.POST("/:runId/cancel", h.cancelWorkflowRun)
 	group.POST("/:runId/signal", h.signalWorkflowRun)
+	group.POST("/:runId/events/:name", h.deliverWorkflowRunEvent)
 	group.POST("/:runId/pause", h.pauseWorkflowRun)
 	group.POST("/:runId/resume", h.resumeWorkflowRun)
 	group.POST("/:runId/complete", h.completeWorkflowRun)
@@ -286,6 +287,32 @@ func (h *APIHandlers) signalWorkflowRun(c *gin.Context) {
 	})
 }
 
+func (h *APIHandlers) deliverWorkflowRunEvent(c *gin.Context) {
+	runID := c.Param("runId")
+	name := c.Param("name")
+
+	var payload json.RawMessage
+	if err := c.ShouldBindJSON(&payload); err != nil && err.Error() != "EOF" { // empty body allowed
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event payload"})
+		return
+	}
+
+	run, err := h.workflowService.DeliverEvent(c.Request.Context(), services.DeliverWorkflowEventRequest{
+		RunID:   runID,
+		Name:    name,
+		Payload: payload,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to deliver event"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"run":     run,
+		"message": "Event delivered",
+	})
+}
+
 func (h *APIHandlers) pauseWorkflowRun(c *gin.Context) {
 	runID := c.Param("runId")
 	var req pauseWorkflowRunRequest
@@ -365,8 +365,11 @@ func startTestWorkflowConsumer(t *testing.T, engine runtime.Engine, repos *repos
 	registry.Register(runtime.NewCustomExecutor(nil))
 	registry.Register(runtime.NewCronExecutor())
 	registry.Register(runtime.NewTimerExecutor())
+	registry.Register(runtime.NewAwaitEventExecutor())
 	registry.Register(runtime.NewTryCatchExecutor(registry))
 	registry.Register(runtime.NewTransformExecutor())
+	registry.Register(runtime.NewScriptExecutor())
+	registry.Register(runtime.NewHTTPExecutor())
 
 	stepAdapter := &testRegistryStepExecutorAdapter{registry: registry}
 	registry.Register(runtime.NewParallelExecutor(stepAdapter))
@@ -27,6 +27,7 @@ func (h *APIHandlers) registerWorkflowRoutes(group *gin.RouterGroup) {
 	group.GET("/:workflowId/validate", h.validateWorkflowByID)
 	group.GET("/:workflowId/versions", h.listWorkflowVersions)
 	group.GET("/:workflowId/versions/:version", h.getWorkflowVersion)
+	group.GET("/:workflowId/input-schema", h.getWorkflowInputSchema)
 	group.PUT("/:workflowId", h.updateWorkflow)
 	group.DELETE("/:workflowId", h.disableWorkflow)
 	group.POST("/:workflowId/runs", h.startWorkflowRunNested)
@@ -160,6 +161,29 @@ func (h *APIHandlers) getWorkflow(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"workflow": record})
 }
 
+// getWorkflowInputSchema returns the declared inputSchema for a workflow so
+// the UI can render an input form instead of a raw JSON textarea. Workflows
+// without a schema return inputSchema: null.
+func (h *APIHandlers) getWorkflowInputSchema(c *gin.Context) {
+	workflowID := c.Param("workflowId")
+	record, err := h.workflowService.GetWorkflow(c.Request.Context(), workflowID, 0)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workflow not found"})
+		return
+	}
+
+	var def workflows.Definition
+	if err := json.Unmarshal(record.Definition, &def); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse workflow definition"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workflowId":  workflowID,
+		"inputSchema": def.InputSchema,
+	})
+}
+
 func (h *APIHandlers) getWorkflowVersion(c *gin.Context) {
 	workflowID := c.Param("workflowId")
 	versionStr := c.Param("version")
@@ -26,7 +26,8 @@ type Config struct {
 	SSHHostKeyPath string
 	AdminUsername  string
 	Environment    string
-	Debug          bool // Debug mode enables verbose logging
+	Debug          bool   // Debug mode enables verbose logging
+	LogFormat      string // Log output format: "text" (default) or "json"
 	// Workspace Configuration
 	Workspace string // Custom workspace path (overrides XDG paths)
 	// AI Provider Configuration
@@ -38,6 +39,10 @@ type Config struct {
 	AIOAuthToken        string // OAuth access token (when AIAuthType == "oauth")
 	AIOAuthRefreshToken string // OAuth refresh token for token renewal
 	AIOAuthExpiresAt    int64  // OAuth token expiry timestamp (Unix ms)
+	// Fallback AI Provider (used when the primary provider keeps failing with
+	// rate limits, 5xx errors, or timeouts; e.g. openai -> gemini)
+	FallbackAIProvider string // Secondary provider to retry with (optional)
+	FallbackAIModel    string // Model name for the fallback provider
 	// CloudShip Integration
 	CloudShip CloudShipConfig
 	// Telemetry Configuration (distributed tracing)
@@ -58,6 +63,22 @@ type Config struct {
 	Secrets SecretsConfig
 	// Faker Templates (for local development)
 	FakerTemplates map[string]FakerTemplate
+	// Tool Hooks (external hooks invoked around every MCP tool call)
+	ToolHooks []ToolHookConfig
+	// Tool Output Schemas (expected JSON schemas for specific MCP tool responses)
+	ToolOutputSchemas []ToolOutputSchemaConfig
+	// Execution Profiles (named generation settings bundles, overriding the built-ins)
+	ExecutionProfiles map[string]ExecutionProfile
+	// Run Env Allowlist (env var names that --env-var may inject into stdio MCP servers per run)
+	RunEnvAllowlist []string
+	// Soft Delete Retention Days (how long soft-deleted agents and environments stay restorable)
+	SoftDeleteRetentionDays int
+	// Remotes (other Station instances for federated CLI views)
+	Remotes map[string]RemoteConfig
+	// Context Management (token budget for accumulated tool outputs)
+	Context ContextConfig
+	// Events (internal event bus consumers: webhook, nats, kafka, jsonl)
+	Events EventsConfig
 	// Harness Configuration (agentic execution harness)
 	Harness HarnessConfig
 	// Note: Station now uses official GenKit v1.0.1 plugins (custom plugin code preserved)
@@ -76,6 +97,93 @@ type FakerTemplate struct {
 	Model       string `yaml:"model"`
 }
 
+// ContextConfig bounds how much tool output is fed back into the model
+// context during agent execution. When MaxTokens is set, tool outputs that
+// would push the approximate running total past the budget are compressed
+// according to Strategy, and each compression is recorded on the run's
+// execution steps.
+type ContextConfig struct {
+	MaxTokens int    `yaml:"max_tokens"` // Approximate token budget for tool outputs; 0 disables management
+	Strategy  string `yaml:"strategy"`   // "truncate" (default) keeps head and tail; "evict" replaces with a short notice
+}
+
+// RemoteConfig describes another Station instance whose agents and runs can
+// be aggregated into local CLI views (managed via `stn remote`)
+type RemoteConfig struct {
+	Endpoint string `yaml:"endpoint"` // Base URL of the remote Station API (e.g. http://ci-server:8585)
+	APIKey   string `yaml:"api_key"`  // Bearer token for the remote API (optional)
+}
+
+// EventsConfig enables consumers for the internal event bus. When at least
+// one consumer is declared, structured events (run lifecycle, tool calls,
+// workflow transitions, sync results) are published to each of them.
+type EventsConfig struct {
+	Consumers []EventConsumerConfig `yaml:"consumers"`
+}
+
+// EventConsumerConfig declares a single event bus consumer
+type EventConsumerConfig struct {
+	Name    string   `yaml:"name"`    // Display name for logs (defaults to the type)
+	Type    string   `yaml:"type"`    // "webhook", "nats", "kafka", or "jsonl"
+	URL     string   `yaml:"url"`     // webhook: endpoint to POST events to; nats: server URL (defaults to localhost)
+	Subject string   `yaml:"subject"` // nats: subject to publish on
+	Brokers []string `yaml:"brokers"` // kafka: broker addresses
+	Topic   string   `yaml:"topic"`   // kafka: topic to publish to
+	Path    string   `yaml:"path"`    // jsonl: file to append events to
+}
+
+// ToolHookConfig declares an external hook invoked before and after every
+// MCP tool call during agent execution. Hooks can mutate tool arguments,
+// short-circuit with a response, deny the call, or annotate results.
+type ToolHookConfig struct {
+	Name    string   `yaml:"name"`
+	Type    string   `yaml:"type"`    // "stdio" or "http"
+	Command string   `yaml:"command"` // stdio: command to run (payload on stdin, response on stdout)
+	Args    []string `yaml:"args"`    // stdio: command arguments
+	URL     string   `yaml:"url"`     // http: endpoint to POST the payload to
+	Timeout string   `yaml:"timeout"` // per-invocation timeout (e.g. "10s", default 10s)
+}
+
+// ToolOutputSchemaConfig declares the expected JSON schema for a specific
+// MCP tool's responses. Responses are validated after every call; near-miss
+// payloads are coerced when possible and violations are flagged as run
+// warnings, catching upstream MCP server regressions early.
+type ToolOutputSchemaConfig struct {
+	Tool   string `yaml:"tool"`   // MCP tool name (e.g. "__checkov_scan_directory")
+	Schema string `yaml:"schema"` // JSON schema document for the tool's output
+}
+
+// ExecutionProfile is a named bundle of model generation settings that can be
+// attached to an agent (`profile:` frontmatter) or applied to a single run
+// (`stn agent run --profile strict`). Pointer fields distinguish "unset" from
+// an explicit zero so profiles only override what they declare.
+type ExecutionProfile struct {
+	Temperature *float64 `yaml:"temperature,omitempty" json:"temperature,omitempty"`
+	TopP        *float64 `yaml:"top_p,omitempty" json:"top_p,omitempty"`
+	MaxTurns    int      `yaml:"max_turns,omitempty" json:"max_turns,omitempty"`     // Overrides the agent's max_steps when > 0
+	ToolChoice  string   `yaml:"tool_choice,omitempty" json:"tool_choice,omitempty"` // "auto", "required", or "none"
+	Seed        *int64   `yaml:"seed,omitempty" json:"seed,omitempty"`               // Applied where the provider supports it
+}
+
+// GenerateConfig converts the profile's generation settings to the config map
+// consumed by the model plugins (keys match the OpenAI-compatible generators)
+func (p *ExecutionProfile) GenerateConfig() map[string]any {
+	cfg := make(map[string]any)
+	if p.Temperature != nil {
+		cfg["temperature"] = *p.Temperature
+	}
+	if p.TopP != nil {
+		cfg["topP"] = *p.TopP
+	}
+	if p.Seed != nil {
+		cfg["seed"] = *p.Seed
+	}
+	if p.ToolChoice != "" {
+		cfg["toolChoice"] = p.ToolChoice
+	}
+	return cfg
+}
+
 type HarnessConfig struct {
 	Workspace   HarnessWorkspaceConfig   `yaml:"workspace"`
 	Compaction  HarnessCompactionConfig  `yaml:"compaction"`
@@ -508,6 +616,50 @@ type TelemetryConfig struct {
 
 	// SampleRate controls trace sampling (0.0 to 1.0, default: 1.0 for dev, 0.1 for prod)
 	SampleRate float64 `yaml:"sample_rate"`
+
+	// Sampling holds fine-grained sampling controls: per-category rates,
+	// always-sample-on-error, and per-environment overrides. When unset,
+	// SampleRate applies uniformly.
+	Sampling *TelemetrySamplingConfig `yaml:"sampling"`
+}
+
+// TelemetrySamplingRates holds head-based sampling ratios per span category.
+// A nil rate inherits the next level (environment override -> top-level rate
+// -> SampleRate).
+type TelemetrySamplingRates struct {
+	// AgentRate samples agent execution spans (agent.*, dotprompt.*)
+	AgentRate *float64 `yaml:"agent_rate"`
+	// ToolRate samples MCP tool call spans (mcp.*, tool.*)
+	ToolRate *float64 `yaml:"tool_rate"`
+	// APIRate samples API request spans (http.*, api.*)
+	APIRate *float64 `yaml:"api_rate"`
+}
+
+// TelemetrySamplingConfig configures fine-grained trace sampling so
+// high-volume deployments can keep Jaeger manageable while still capturing
+// failures.
+//
+// Example:
+//
+//	telemetry:
+//	  sampling:
+//	    agent_rate: 0.5
+//	    tool_rate: 0.05
+//	    always_sample_errors: true
+//	    environment_overrides:
+//	      production:
+//	        agent_rate: 0.1
+//	        tool_rate: 0.01
+type TelemetrySamplingConfig struct {
+	TelemetrySamplingRates `yaml:",inline"`
+
+	// AlwaysSampleErrors exports spans that end with an error status even
+	// when the head-based decision dropped them
+	AlwaysSampleErrors bool `yaml:"always_sample_errors"`
+
+	// EnvironmentOverrides adjusts rates per deployment environment
+	// (keyed by the telemetry Environment value, e.g. "production")
+	EnvironmentOverrides map[string]TelemetrySamplingRates `yaml:"environment_overrides"`
 }
 
 // InitViper initializes viper to read config from the correct location
@@ -562,6 +714,8 @@ func bindEnvVars() {
 	viper.BindEnv("ssh_port", "STATION_SSH_PORT", "STN_SSH_PORT", "SSH_PORT")
 	viper.BindEnv("admin_username", "STATION_ADMIN_USERNAME", "STN_ADMIN_USERNAME")
 	viper.BindEnv("debug", "STATION_DEBUG", "STN_DEBUG")
+	viper.BindEnv("log_format", "STATION_LOG_FORMAT", "STN_LOG_FORMAT")
+	viper.BindEnv("soft_delete_retention_days", "STATION_SOFT_DELETE_RETENTION_DAYS", "STN_SOFT_DELETE_RETENTION_DAYS")
 	viper.BindEnv("local_mode", "STATION_LOCAL_MODE", "STN_LOCAL_MODE")
 	viper.BindEnv("dev_mode", "STN_DEV_MODE", "STATION_DEV_MODE")
 
@@ -705,7 +859,10 @@ func Load() (*Config, error) {
 		SSHHostKeyPath: getEnvOrDefault("SSH_HOST_KEY_PATH", "./ssh_host_key"),
 		AdminUsername:  getEnvOrDefault("ADMIN_USERNAME", "admin"),
 		Environment:    getEnvOrDefault("ENVIRONMENT", "development"),
-		Debug:          getEnvBoolOrDefault("STN_DEBUG", false), // Default to info level
+		Debug:          getEnvBoolOrDefault("STN_DEBUG", false),   // Default to info level
+		LogFormat:      getEnvOrDefault("STN_LOG_FORMAT", "text"), // "text" or "json"
+		// Soft-deleted agents/environments are purged after this many days
+		SoftDeleteRetentionDays: getEnvIntOrDefault("STN_SOFT_DELETE_RETENTION_DAYS", 30),
 		// Workspace Configuration
 		Workspace: getEnvOrDefault("STATION_WORKSPACE", ""), // Custom workspace path
 		// AI Provider Configuration with STN_ prefix and sane defaults
@@ -883,12 +1040,21 @@ func Load() (*Config, error) {
 	if viper.IsSet("debug") {
 		cfg.Debug = viper.GetBool("debug")
 	}
+	if viper.IsSet("log_format") {
+		cfg.LogFormat = viper.GetString("log_format")
+	}
 	if viper.IsSet("ai_provider") {
 		cfg.AIProvider = viper.GetString("ai_provider")
 	}
 	if viper.IsSet("ai_model") {
 		cfg.AIModel = viper.GetString("ai_model")
 	}
+	if viper.IsSet("fallback_ai_provider") {
+		cfg.FallbackAIProvider = viper.GetString("fallback_ai_provider")
+	}
+	if viper.IsSet("fallback_ai_model") {
+		cfg.FallbackAIModel = viper.GetString("fallback_ai_model")
+	}
 	if viper.IsSet("ai_api_key") {
 		rawKey := viper.GetString("ai_api_key")
 		// Expand environment variables like ${OPENAI_API_KEY}, but preserve direct values
@@ -1271,6 +1437,73 @@ func Load() (*Config, error) {
 	// Load faker templates from config file
 	cfg.FakerTemplates = loadFakerTemplates()
 
+	// Load external tool call hooks from config file
+	if viper.IsSet("tool_hooks") {
+		var toolHooks []ToolHookConfig
+		if err := viper.UnmarshalKey("tool_hooks", &toolHooks); err == nil {
+			cfg.ToolHooks = toolHooks
+		}
+	}
+
+	// Load expected tool output schemas from config file
+	if viper.IsSet("tool_output_schemas") {
+		var toolOutputSchemas []ToolOutputSchemaConfig
+		if err := viper.UnmarshalKey("tool_output_schemas", &toolOutputSchemas); err == nil {
+			cfg.ToolOutputSchemas = toolOutputSchemas
+		}
+	}
+
+	// Load named execution profiles from config file
+	if viper.IsSet("execution_profiles") {
+		var executionProfiles map[string]ExecutionProfile
+		if err := viper.UnmarshalKey("execution_profiles", &executionProfiles); err == nil {
+			cfg.ExecutionProfiles = executionProfiles
+		}
+	}
+
+	// Load per-run env var allowlist from config file (STN_RUN_ENV_ALLOWLIST
+	// provides a comma-separated fallback for container deployments)
+	if viper.IsSet("run_env_allowlist") {
+		var runEnvAllowlist []string
+		if err := viper.UnmarshalKey("run_env_allowlist", &runEnvAllowlist); err == nil {
+			cfg.RunEnvAllowlist = runEnvAllowlist
+		}
+	} else if raw := os.Getenv("STN_RUN_ENV_ALLOWLIST"); raw != "" {
+		for _, name := range strings.Split(raw, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.RunEnvAllowlist = append(cfg.RunEnvAllowlist, name)
+			}
+		}
+	}
+
+	if viper.IsSet("soft_delete_retention_days") {
+		cfg.SoftDeleteRetentionDays = viper.GetInt("soft_delete_retention_days")
+	}
+
+	// Load event bus consumers from config file
+	if viper.IsSet("events") {
+		var eventsCfg EventsConfig
+		if err := viper.UnmarshalKey("events", &eventsCfg); err == nil {
+			cfg.Events = eventsCfg
+		}
+	}
+
+	// Load context management settings from config file
+	if viper.IsSet("context.max_tokens") {
+		cfg.Context.MaxTokens = viper.GetInt("context.max_tokens")
+	}
+	if viper.IsSet("context.strategy") {
+		cfg.Context.Strategy = viper.GetString("context.strategy")
+	}
+
+	// Load remote Station instances from config file
+	if viper.IsSet("remotes") {
+		var remotes map[string]RemoteConfig
+		if err := viper.UnmarshalKey("remotes", &remotes); err == nil {
+			cfg.Remotes = remotes
+		}
+	}
+
 	// Environment variable overrides (take precedence over config file)
 	// CRITICAL: STATION_DATABASE must override everything (including viper config)
 	// This ensures faker subprocesses with STATION_DATABASE env var use the correct database
@@ -1284,6 +1517,12 @@ func Load() (*Config, error) {
 	if envModel := os.Getenv("STN_AI_MODEL"); envModel != "" {
 		cfg.AIModel = envModel
 	}
+	if envProvider := os.Getenv("STN_FALLBACK_AI_PROVIDER"); envProvider != "" {
+		cfg.FallbackAIProvider = envProvider
+	}
+	if envModel := os.Getenv("STN_FALLBACK_AI_MODEL"); envModel != "" {
+		cfg.FallbackAIModel = envModel
+	}
 	if envBaseURL := os.Getenv("STN_AI_BASE_URL"); envBaseURL != "" {
 		cfg.AIBaseURL = envBaseURL
 	}
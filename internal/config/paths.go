@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 )
@@ -49,6 +50,18 @@ func GetWorkflowFilePath(environmentName, workflowID string) string {
 	return filepath.Join(GetWorkflowsDir(environmentName), workflowID+".workflow.yaml")
 }
 
+// GetRunAttachmentsDir returns the directory where input artifacts attached
+// to a run (stn agent run --file) are stored
+func GetRunAttachmentsDir(runID int64) string {
+	return filepath.Join(GetConfigRoot(), "attachments", fmt.Sprintf("run-%d", runID))
+}
+
+// GetMCPServerLogPath returns the path to the stderr log file for a stdio
+// MCP server, keyed by server name
+func GetMCPServerLogPath(serverName string) string {
+	return filepath.Join(GetConfigRoot(), "logs", "mcp", serverName+".log")
+}
+
 // GetVariablesPath returns the path to the variables.yml file for an environment
 func GetVariablesPath(environmentName string) string {
 	return filepath.Join(GetEnvironmentDir(environmentName), "variables.yml")
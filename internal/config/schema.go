@@ -48,7 +48,7 @@ var ConfigSections = []ConfigSection{
 
 var ConfigSchema = []ConfigField{
 	// AI Provider Settings
-	{Key: "ai_provider", Type: FieldTypeString, Description: "AI provider (openai, anthropic, ollama, gemini)", Default: "openai", Section: "ai", Options: []string{"openai", "anthropic", "ollama", "gemini"}},
+	{Key: "ai_provider", Type: FieldTypeString, Description: "AI provider (openai, anthropic, ollama, gemini, stub)", Default: "openai", Section: "ai", Options: []string{"openai", "anthropic", "ollama", "gemini", "stub"}},
 	{Key: "ai_model", Type: FieldTypeString, Description: "AI model name (e.g., gpt-4o, claude-sonnet-4-20250514)", Default: "gpt-4o", Section: "ai"},
 	{Key: "ai_api_key", Type: FieldTypeString, Description: "API key for the AI provider", Secret: true, Section: "ai"},
 	{Key: "ai_base_url", Type: FieldTypeString, Description: "Base URL for OpenAI-compatible endpoints", Section: "ai"},
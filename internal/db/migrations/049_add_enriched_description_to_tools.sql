@@ -0,0 +1,7 @@
+-- +goose Up
+-- Model-expanded tool description with parameter examples; the original
+-- MCP-provided description stays untouched in the description column
+ALTER TABLE mcp_tools ADD COLUMN enriched_description TEXT;
+
+-- +goose Down
+ALTER TABLE mcp_tools DROP COLUMN enriched_description;
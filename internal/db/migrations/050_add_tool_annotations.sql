@@ -0,0 +1,7 @@
+-- +goose Up
+-- Per-tool cost/latency annotations (JSON), set manually via `stn mcp
+-- annotate` or learned from run history; surfaced to the model at run time
+ALTER TABLE mcp_tools ADD COLUMN annotations TEXT;
+
+-- +goose Down
+ALTER TABLE mcp_tools DROP COLUMN annotations;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Generation seed used for the run (when the provider supports it, e.g.
+-- OpenAI's seed parameter); pinned via `stn agent run --seed` or inherited
+-- from the execution profile, recorded so flaky runs can be replayed
+ALTER TABLE agent_runs ADD COLUMN seed INTEGER;
+
+-- +goose Down
+ALTER TABLE agent_runs DROP COLUMN seed;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- Agent groups ("finops-team", "security-team") are a first-class entity:
+-- reports can target a group instead of a whole environment, and budgets/SLOs
+-- can be set per group (slo.group.<id> settings keys).
+CREATE TABLE IF NOT EXISTS agent_groups (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS agent_group_members (
+    group_id INTEGER NOT NULL REFERENCES agent_groups(id) ON DELETE CASCADE,
+    agent_id INTEGER NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, agent_id)
+);
+
+-- Reports may target a group's agents instead of every agent in the environment
+ALTER TABLE reports ADD COLUMN agent_group_id INTEGER REFERENCES agent_groups(id);
+
+-- +goose Down
+ALTER TABLE reports DROP COLUMN agent_group_id;
+DROP TABLE agent_group_members;
+DROP TABLE agent_groups;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Record the serverInfo name/version each MCP server reports during the
+-- initialize handshake, captured at discovery time so version changes can be
+-- tracked across syncs and surfaced by stn mcp list / stn mcp outdated
+ALTER TABLE mcp_servers ADD COLUMN server_info_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE mcp_servers ADD COLUMN server_info_version TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE mcp_servers DROP COLUMN server_info_version;
+ALTER TABLE mcp_servers DROP COLUMN server_info_name;
@@ -0,0 +1,119 @@
+-- +goose Up
+-- Human feedback on agent runs: 1-5 ratings and comments from
+-- `stn runs annotate`, thumbs up/down from the UI. Feedback is surfaced in
+-- run inspection and report evaluations, and well-rated runs can seed
+-- benchmark tasks for supervised evaluation.
+CREATE TABLE IF NOT EXISTS run_annotations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    run_id INTEGER NOT NULL REFERENCES agent_runs(id) ON DELETE CASCADE,
+    rating INTEGER,
+    thumbs TEXT,
+    comment TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_run_annotations_run_id ON run_annotations(run_id);
+
+-- Reconcile benchmark_tasks with the LLM-as-judge columns the tasks repo
+-- reads and writes (expected_output_example, evaluation_criteria, per-metric
+-- weights), which were never added after 034. Seeding tasks from annotated
+-- runs needs them; legacy columns are kept with defaults for existing rows.
+-- Rebuilt as create-copy-drop-rename so task_evaluations' foreign key
+-- reference to benchmark_tasks survives the swap.
+CREATE TABLE benchmark_tasks_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    category TEXT NOT NULL,
+    description TEXT NOT NULL,
+    expected_output_example TEXT NOT NULL DEFAULT '',
+    evaluation_criteria TEXT NOT NULL DEFAULT '',
+    task_completion_weight REAL NOT NULL DEFAULT 0.3,
+    relevancy_weight REAL NOT NULL DEFAULT 0.2,
+    hallucination_weight REAL NOT NULL DEFAULT 0.2,
+    faithfulness_weight REAL NOT NULL DEFAULT 0.2,
+    toxicity_weight REAL NOT NULL DEFAULT 0.1,
+    success_criteria TEXT NOT NULL DEFAULT '{}',
+    weight REAL NOT NULL DEFAULT 1.0,
+    environment_id INTEGER,
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (environment_id) REFERENCES environments(id) ON DELETE CASCADE
+);
+
+INSERT INTO benchmark_tasks_new (id, name, category, description, evaluation_criteria,
+    success_criteria, weight, environment_id, is_active, created_at, updated_at)
+SELECT id, name, category, description, success_criteria,
+    success_criteria, weight, environment_id, is_active, created_at, updated_at
+FROM benchmark_tasks;
+
+-- v_agent_rankings joins benchmark_tasks; drop it across the swap and
+-- recreate it against the rebuilt table. v_run_quality_metrics must also be
+-- rebuilt: it referenced ar.duration (the column is duration_seconds), which
+-- blocks any schema change that re-validates views.
+DROP VIEW IF EXISTS v_agent_rankings;
+DROP VIEW IF EXISTS v_run_quality_metrics;
+DROP TABLE benchmark_tasks;
+ALTER TABLE benchmark_tasks_new RENAME TO benchmark_tasks;
+
+-- +goose StatementBegin
+CREATE VIEW IF NOT EXISTS v_run_quality_metrics AS
+SELECT
+    ar.id AS run_id,
+    ar.agent_id,
+    a.name AS agent_name,
+    ar.status,
+    ar.duration_seconds,
+    MAX(CASE WHEN bm.metric_type = 'hallucination' THEN bm.score END) AS hallucination_score,
+    MAX(CASE WHEN bm.metric_type = 'relevancy' THEN bm.score END) AS relevancy_score,
+    MAX(CASE WHEN bm.metric_type = 'task_completion' THEN bm.score END) AS task_completion_score,
+    MAX(CASE WHEN bm.metric_type = 'faithfulness' THEN bm.score END) AS faithfulness_score,
+    MAX(CASE WHEN bm.metric_type = 'toxicity' THEN bm.score END) AS toxicity_score,
+    SUM(CASE WHEN bm.passed = 1 THEN 1 ELSE 0 END) AS metrics_passed,
+    COUNT(bm.id) AS total_metrics
+FROM agent_runs ar
+JOIN agents a ON ar.agent_id = a.id
+LEFT JOIN benchmark_metrics bm ON ar.id = bm.run_id
+GROUP BY ar.id, ar.agent_id, a.name, ar.status, ar.duration_seconds;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW IF NOT EXISTS v_agent_rankings AS
+SELECT
+    te.task_id,
+    bt.name AS task_name,
+    bt.category,
+    te.agent_id,
+    te.agent_name,
+    te.task_score,
+    te.rank,
+    te.is_champion,
+    te.runs_analyzed,
+    te.avg_hallucination,
+    te.avg_relevancy,
+    te.avg_task_completion,
+    te.avg_toxicity,
+    te.completed
+FROM task_evaluations te
+JOIN benchmark_tasks bt ON te.task_id = bt.id
+WHERE bt.is_active = 1
+ORDER BY te.task_id, te.rank;
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_benchmark_tasks_category ON benchmark_tasks(category);
+CREATE INDEX IF NOT EXISTS idx_benchmark_tasks_environment ON benchmark_tasks(environment_id);
+CREATE INDEX IF NOT EXISTS idx_benchmark_tasks_active ON benchmark_tasks(is_active);
+
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS update_benchmark_tasks_timestamp
+AFTER UPDATE ON benchmark_tasks
+BEGIN
+    UPDATE benchmark_tasks SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS update_benchmark_tasks_timestamp;
+DROP INDEX IF EXISTS idx_run_annotations_run_id;
+DROP TABLE run_annotations;
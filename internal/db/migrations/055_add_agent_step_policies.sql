@@ -0,0 +1,16 @@
+-- +goose Up
+-- Per-agent step policies: optional max turns and per-turn timeout overrides
+-- plus a "must call a tool before finalizing" check. Declared in .prompt
+-- frontmatter (step_policy block) and enforced during execution; violations
+-- surface as run warnings instead of silent behavior differences.
+CREATE TABLE IF NOT EXISTS agent_step_policies (
+    agent_id INTEGER PRIMARY KEY REFERENCES agents(id) ON DELETE CASCADE,
+    max_turns INTEGER,
+    per_turn_timeout_seconds INTEGER,
+    require_tool_call BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE agent_step_policies;
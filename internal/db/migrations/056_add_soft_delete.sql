@@ -0,0 +1,17 @@
+-- +goose Up
+-- Soft-delete for agents and environments: deletion stamps deleted_at
+-- instead of removing rows, so accidental deletions (and the run history
+-- hanging off them) stay recoverable with `stn agent restore` /
+-- `stn env restore` until the purger hard-deletes them after the
+-- retention window.
+ALTER TABLE agents ADD COLUMN deleted_at DATETIME;
+ALTER TABLE environments ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_agents_deleted_at ON agents(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_environments_deleted_at ON environments(deleted_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_environments_deleted_at;
+DROP INDEX IF EXISTS idx_agents_deleted_at;
+ALTER TABLE environments DROP COLUMN deleted_at;
+ALTER TABLE agents DROP COLUMN deleted_at;
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"station/pkg/models"
+)
+
+// AgentGroupRepo manages agent group (team) persistence
+type AgentGroupRepo struct {
+	db *sql.DB
+}
+
+func NewAgentGroupRepo(db *sql.DB) *AgentGroupRepo {
+	return &AgentGroupRepo{db: db}
+}
+
+// Create creates a new agent group
+func (r *AgentGroupRepo) Create(ctx context.Context, name, description string) (*models.AgentGroup, error) {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO agent_groups (name, description) VALUES (?, ?)`, name, description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent group: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// GetByID returns a group by ID
+func (r *AgentGroupRepo) GetByID(ctx context.Context, id int64) (*models.AgentGroup, error) {
+	return r.scanGroup(r.db.QueryRowContext(ctx,
+		`SELECT id, name, description, created_at, updated_at FROM agent_groups WHERE id = ?`, id))
+}
+
+// GetByName returns a group by name
+func (r *AgentGroupRepo) GetByName(ctx context.Context, name string) (*models.AgentGroup, error) {
+	return r.scanGroup(r.db.QueryRowContext(ctx,
+		`SELECT id, name, description, created_at, updated_at FROM agent_groups WHERE name = ?`, name))
+}
+
+// List returns all groups ordered by name
+func (r *AgentGroupRepo) List(ctx context.Context) ([]*models.AgentGroup, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, name, description, created_at, updated_at FROM agent_groups ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*models.AgentGroup
+	for rows.Next() {
+		group, err := r.scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// Delete removes a group; memberships are removed via cascade
+func (r *AgentGroupRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM agent_groups WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete agent group: %w", err)
+	}
+	return nil
+}
+
+// AddMember adds an agent to a group (idempotent)
+func (r *AgentGroupRepo) AddMember(ctx context.Context, groupID, agentID int64) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO agent_group_members (group_id, agent_id) VALUES (?, ?)
+		 ON CONFLICT(group_id, agent_id) DO NOTHING`, groupID, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to add agent %d to group %d: %w", agentID, groupID, err)
+	}
+	return nil
+}
+
+// RemoveMember removes an agent from a group
+func (r *AgentGroupRepo) RemoveMember(ctx context.Context, groupID, agentID int64) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM agent_group_members WHERE group_id = ? AND agent_id = ?`, groupID, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to remove agent %d from group %d: %w", agentID, groupID, err)
+	}
+	return nil
+}
+
+// ListMembers returns the agents in a group
+func (r *AgentGroupRepo) ListMembers(ctx context.Context, groupID int64) ([]*models.Agent, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT a.id, a.name, a.description, a.max_steps, a.environment_id
+		 FROM agent_group_members m
+		 JOIN agents a ON a.id = m.agent_id
+		 WHERE m.group_id = ?
+		 ORDER BY a.name`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %w", err)
+	}
+	defer rows.Close()
+
+	var agents []*models.Agent
+	for rows.Next() {
+		agent := &models.Agent{}
+		if err := rows.Scan(&agent.ID, &agent.Name, &agent.Description, &agent.MaxSteps, &agent.EnvironmentID); err != nil {
+			return nil, err
+		}
+		agents = append(agents, agent)
+	}
+	return agents, rows.Err()
+}
+
+// ListForAgent returns the groups an agent belongs to
+func (r *AgentGroupRepo) ListForAgent(ctx context.Context, agentID int64) ([]*models.AgentGroup, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT g.id, g.name, g.description, g.created_at, g.updated_at
+		 FROM agent_group_members m
+		 JOIN agent_groups g ON g.id = m.group_id
+		 WHERE m.agent_id = ?
+		 ORDER BY g.name`, agentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups for agent: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*models.AgentGroup
+	for rows.Next() {
+		group, err := r.scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *AgentGroupRepo) scanGroup(row rowScanner) (*models.AgentGroup, error) {
+	group := &models.AgentGroup{}
+	var createdAt, updatedAt sql.NullTime
+	if err := row.Scan(&group.ID, &group.Name, &group.Description, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if createdAt.Valid {
+		group.CreatedAt = createdAt.Time
+	}
+	if updatedAt.Valid {
+		group.UpdatedAt = updatedAt.Time
+	}
+	return group, nil
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func setupAgentGroupTestDB(t *testing.T) *sql.DB {
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+
+	schema := `
+	PRAGMA foreign_keys = ON;
+
+	CREATE TABLE agents (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL,
+		max_steps INTEGER NOT NULL DEFAULT 5,
+		environment_id INTEGER NOT NULL
+	);
+
+	CREATE TABLE agent_groups (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		description TEXT NOT NULL DEFAULT '',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE agent_group_members (
+		group_id INTEGER NOT NULL REFERENCES agent_groups(id) ON DELETE CASCADE,
+		agent_id INTEGER NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (group_id, agent_id)
+	);`
+
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("Failed to create test schema: %v", err)
+	}
+
+	_, err = db.Exec(`INSERT INTO agents (name, description, max_steps, environment_id) VALUES
+		('Cost Analyzer', 'Analyzes cloud spend', 5, 1),
+		('Budget Forecaster', 'Forecasts spend', 5, 1),
+		('Vuln Scanner', 'Scans for vulnerabilities', 8, 1)`)
+	if err != nil {
+		t.Fatalf("Failed to create test agents: %v", err)
+	}
+
+	return db
+}
+
+func TestAgentGroupRepo_CRUD(t *testing.T) {
+	db := setupAgentGroupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewAgentGroupRepo(db)
+	ctx := context.Background()
+
+	group, err := repo.Create(ctx, "finops-team", "Cloud cost agents")
+	if err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+	if group.Name != "finops-team" || group.Description != "Cloud cost agents" {
+		t.Errorf("Unexpected group: %+v", group)
+	}
+
+	// Duplicate names are rejected
+	if _, err := repo.Create(ctx, "finops-team", ""); err == nil {
+		t.Error("Expected error creating duplicate group name")
+	}
+
+	byName, err := repo.GetByName(ctx, "finops-team")
+	if err != nil || byName.ID != group.ID {
+		t.Errorf("GetByName failed: %v", err)
+	}
+
+	if _, err := repo.Create(ctx, "security-team", ""); err != nil {
+		t.Fatalf("Failed to create second group: %v", err)
+	}
+	groups, err := repo.List(ctx)
+	if err != nil || len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d (err: %v)", len(groups), err)
+	}
+
+	if err := repo.Delete(ctx, group.ID); err != nil {
+		t.Fatalf("Failed to delete group: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, group.ID); err == nil {
+		t.Error("Expected error fetching deleted group")
+	}
+}
+
+func TestAgentGroupRepo_Membership(t *testing.T) {
+	db := setupAgentGroupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewAgentGroupRepo(db)
+	ctx := context.Background()
+
+	group, err := repo.Create(ctx, "finops-team", "")
+	if err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	if err := repo.AddMember(ctx, group.ID, 1); err != nil {
+		t.Fatalf("Failed to add member: %v", err)
+	}
+	if err := repo.AddMember(ctx, group.ID, 2); err != nil {
+		t.Fatalf("Failed to add member: %v", err)
+	}
+	// Adding the same agent twice is idempotent
+	if err := repo.AddMember(ctx, group.ID, 1); err != nil {
+		t.Errorf("Expected idempotent AddMember, got: %v", err)
+	}
+
+	members, err := repo.ListMembers(ctx, group.ID)
+	if err != nil || len(members) != 2 {
+		t.Fatalf("Expected 2 members, got %d (err: %v)", len(members), err)
+	}
+
+	groupsForAgent, err := repo.ListForAgent(ctx, 1)
+	if err != nil || len(groupsForAgent) != 1 || groupsForAgent[0].ID != group.ID {
+		t.Errorf("Expected agent 1 in one group, got %d (err: %v)", len(groupsForAgent), err)
+	}
+
+	if err := repo.RemoveMember(ctx, group.ID, 1); err != nil {
+		t.Fatalf("Failed to remove member: %v", err)
+	}
+	members, _ = repo.ListMembers(ctx, group.ID)
+	if len(members) != 1 || members[0].ID != 2 {
+		t.Errorf("Expected only agent 2 remaining, got %+v", members)
+	}
+
+	// Deleting the group cascades memberships
+	if err := repo.Delete(ctx, group.ID); err != nil {
+		t.Fatalf("Failed to delete group: %v", err)
+	}
+	groupsForAgent, _ = repo.ListForAgent(ctx, 2)
+	if len(groupsForAgent) != 0 {
+		t.Errorf("Expected cascade to remove membership, got %+v", groupsForAgent)
+	}
+}
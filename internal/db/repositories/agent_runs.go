@@ -104,6 +104,11 @@ func convertAgentRunFromSQLc(run queries.AgentRun) *models.AgentRun {
 		}
 	}
 
+	if run.Error.Valid {
+		errorMsg := run.Error.String
+		result.Error = &errorMsg
+	}
+
 	return result
 }
 
@@ -756,6 +761,65 @@ func (r *AgentRunRepo) UpdateStatus(ctx context.Context, id int64, status string
 	return r.queries.UpdateAgentRunStatus(ctx, params)
 }
 
+// RunInterval is a run's start/completion pair, used for concurrency and
+// capacity analysis
+type RunInterval struct {
+	StartedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// ListIntervalsSince returns the start/completion intervals of runs that were
+// active at any point since the given time (including still-running runs)
+func (r *AgentRunRepo) ListIntervalsSince(ctx context.Context, since time.Time) ([]RunInterval, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT started_at, completed_at
+		FROM agent_runs
+		WHERE started_at >= ? OR completed_at >= ? OR completed_at IS NULL`,
+		since, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var intervals []RunInterval
+	for rows.Next() {
+		var startedAt time.Time
+		var completedAt sql.NullTime
+		if err := rows.Scan(&startedAt, &completedAt); err != nil {
+			return nil, err
+		}
+		interval := RunInterval{StartedAt: startedAt}
+		if completedAt.Valid {
+			interval.CompletedAt = &completedAt.Time
+		}
+		intervals = append(intervals, interval)
+	}
+	return intervals, rows.Err()
+}
+
+// UpdateSeed records the generation seed used for a run (only set when the
+// provider supports seeded generation, e.g. OpenAI's seed parameter)
+func (r *AgentRunRepo) UpdateSeed(ctx context.Context, id int64, seed int64) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE agent_runs SET seed = ? WHERE id = ?",
+		seed, id)
+	return err
+}
+
+// GetSeed returns the generation seed recorded for a run, or nil when no
+// seed was used
+func (r *AgentRunRepo) GetSeed(ctx context.Context, id int64) (*int64, error) {
+	var seed sql.NullInt64
+	err := r.db.QueryRowContext(ctx, "SELECT seed FROM agent_runs WHERE id = ?", id).Scan(&seed)
+	if err != nil {
+		return nil, err
+	}
+	if !seed.Valid {
+		return nil, nil
+	}
+	return &seed.Int64, nil
+}
+
 // UpdateDebugLogs updates the debug_logs field for an agent run
 func (r *AgentRunRepo) UpdateDebugLogs(ctx context.Context, id int64, debugLogs *models.JSONArray) error {
 	var debugLogsStr sql.NullString
@@ -958,6 +1022,33 @@ func (r *AgentRunRepo) CountByStatus(ctx context.Context, status string) (int64,
 	return count, nil
 }
 
+// UsageSince returns the number of runs started and tokens consumed since
+// the given time, used for org quota enforcement
+func (r *AgentRunRepo) UsageSince(ctx context.Context, since time.Time) (int64, int64, error) {
+	ctx, span := r.tracer.Start(ctx, "db.agent_runs.usage_since")
+	defer span.End()
+
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(total_tokens), 0)
+		FROM agent_runs
+		WHERE started_at >= ?
+	`
+
+	var runs, tokens int64
+	if err := r.db.QueryRowContext(ctx, query, since).Scan(&runs, &tokens); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.Bool("db.operation.success", false))
+		return 0, 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Bool("db.operation.success", true),
+		attribute.Int64("runs", runs),
+		attribute.Int64("tokens", tokens),
+	)
+	return runs, tokens, nil
+}
+
 // Delete deletes a single agent run by ID
 func (r *AgentRunRepo) Delete(ctx context.Context, id int64) error {
 	db.SQLiteWriteMutex.Lock()
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestAgentRunSeedRoundTrip(t *testing.T) {
+	testDB, cleanup := setupTestDBForModelFiltering(t)
+	defer cleanup()
+
+	agentRepo := NewAgentRepo(testDB)
+	runRepo := NewAgentRunRepo(testDB)
+	userRepo := NewUserRepo(testDB)
+
+	user, err := userRepo.Create("seeduser", "test-public-key", false, nil)
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	agentID := createTestAgent(t, agentRepo, "Seed Agent")
+	runID := createTestRunWithModel(t, runRepo, agentID, user.ID, "openai/gpt-4o-mini", "completed")
+
+	ctx := context.Background()
+
+	// Runs without a seed report nil
+	seed, err := runRepo.GetSeed(ctx, runID)
+	if err != nil {
+		t.Fatalf("GetSeed failed: %v", err)
+	}
+	if seed != nil {
+		t.Errorf("expected nil seed for fresh run, got %d", *seed)
+	}
+
+	if err := runRepo.UpdateSeed(ctx, runID, 42); err != nil {
+		t.Fatalf("UpdateSeed failed: %v", err)
+	}
+
+	seed, err = runRepo.GetSeed(ctx, runID)
+	if err != nil {
+		t.Fatalf("GetSeed after update failed: %v", err)
+	}
+	if seed == nil || *seed != 42 {
+		t.Errorf("expected seed 42, got %v", seed)
+	}
+}
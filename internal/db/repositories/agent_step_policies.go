@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"station/pkg/models"
+)
+
+// AgentStepPolicyRepo manages per-agent execution step policies
+type AgentStepPolicyRepo struct {
+	db *sql.DB
+}
+
+func NewAgentStepPolicyRepo(db *sql.DB) *AgentStepPolicyRepo {
+	return &AgentStepPolicyRepo{db: db}
+}
+
+// Upsert stores an agent's step policy, replacing any existing one.
+// Max turns and per-turn timeout must be positive when present.
+func (r *AgentStepPolicyRepo) Upsert(ctx context.Context, agentID int64, maxTurns, perTurnTimeoutSeconds *int64, requireToolCall bool) (*models.AgentStepPolicy, error) {
+	if maxTurns != nil && *maxTurns <= 0 {
+		return nil, fmt.Errorf("max_turns must be positive, got %d", *maxTurns)
+	}
+	if perTurnTimeoutSeconds != nil && *perTurnTimeoutSeconds <= 0 {
+		return nil, fmt.Errorf("per_turn_timeout_seconds must be positive, got %d", *perTurnTimeoutSeconds)
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO agent_step_policies (agent_id, max_turns, per_turn_timeout_seconds, require_tool_call)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT(agent_id) DO UPDATE SET
+		     max_turns = excluded.max_turns,
+		     per_turn_timeout_seconds = excluded.per_turn_timeout_seconds,
+		     require_tool_call = excluded.require_tool_call,
+		     updated_at = CURRENT_TIMESTAMP`,
+		agentID, maxTurns, perTurnTimeoutSeconds, requireToolCall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert agent step policy: %w", err)
+	}
+	return r.GetByAgent(ctx, agentID)
+}
+
+// GetByAgent returns an agent's step policy, or nil when none is configured
+func (r *AgentStepPolicyRepo) GetByAgent(ctx context.Context, agentID int64) (*models.AgentStepPolicy, error) {
+	policy, err := r.scanPolicy(r.db.QueryRowContext(ctx,
+		`SELECT agent_id, max_turns, per_turn_timeout_seconds, require_tool_call, created_at, updated_at
+		 FROM agent_step_policies WHERE agent_id = ?`, agentID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent step policy: %w", err)
+	}
+	return policy, nil
+}
+
+// Delete removes an agent's step policy; missing policies are a no-op
+func (r *AgentStepPolicyRepo) Delete(ctx context.Context, agentID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM agent_step_policies WHERE agent_id = ?`, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete agent step policy: %w", err)
+	}
+	return nil
+}
+
+func (r *AgentStepPolicyRepo) scanPolicy(row rowScanner) (*models.AgentStepPolicy, error) {
+	policy := &models.AgentStepPolicy{}
+	var maxTurns, perTurnTimeout sql.NullInt64
+	var createdAt, updatedAt sql.NullTime
+	if err := row.Scan(&policy.AgentID, &maxTurns, &perTurnTimeout,
+		&policy.RequireToolCall, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if maxTurns.Valid {
+		value := maxTurns.Int64
+		policy.MaxTurns = &value
+	}
+	if perTurnTimeout.Valid {
+		value := perTurnTimeout.Int64
+		policy.PerTurnTimeoutSeconds = &value
+	}
+	if createdAt.Valid {
+		policy.CreatedAt = createdAt.Time
+	}
+	if updatedAt.Valid {
+		policy.UpdatedAt = updatedAt.Time
+	}
+	return policy, nil
+}
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func setupStepPolicyTestDB(t *testing.T) *sql.DB {
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+
+	schema := `
+	PRAGMA foreign_keys = ON;
+
+	CREATE TABLE agents (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL
+	);
+
+	CREATE TABLE agent_step_policies (
+		agent_id INTEGER PRIMARY KEY REFERENCES agents(id) ON DELETE CASCADE,
+		max_turns INTEGER,
+		per_turn_timeout_seconds INTEGER,
+		require_tool_call BOOLEAN NOT NULL DEFAULT 0,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("Failed to create test schema: %v", err)
+	}
+
+	_, err = db.Exec(`INSERT INTO agents (name) VALUES ('Security Scanner'), ('Log Analyzer')`)
+	if err != nil {
+		t.Fatalf("Failed to create test agents: %v", err)
+	}
+
+	return db
+}
+
+func TestAgentStepPolicyRepo_UpsertAndGet(t *testing.T) {
+	db := setupStepPolicyTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewAgentStepPolicyRepo(db)
+	ctx := context.Background()
+
+	maxTurns := int64(10)
+	timeout := int64(60)
+	policy, err := repo.Upsert(ctx, 1, &maxTurns, &timeout, true)
+	if err != nil {
+		t.Fatalf("Failed to upsert policy: %v", err)
+	}
+	if policy.MaxTurns == nil || *policy.MaxTurns != 10 {
+		t.Errorf("Expected max_turns 10, got %+v", policy.MaxTurns)
+	}
+	if policy.PerTurnTimeoutSeconds == nil || *policy.PerTurnTimeoutSeconds != 60 {
+		t.Errorf("Expected per_turn_timeout_seconds 60, got %+v", policy.PerTurnTimeoutSeconds)
+	}
+	if !policy.RequireToolCall {
+		t.Error("Expected require_tool_call true")
+	}
+
+	// Upsert replaces the existing policy in place
+	newMaxTurns := int64(5)
+	policy, err = repo.Upsert(ctx, 1, &newMaxTurns, nil, false)
+	if err != nil {
+		t.Fatalf("Failed to re-upsert policy: %v", err)
+	}
+	if policy.MaxTurns == nil || *policy.MaxTurns != 5 {
+		t.Errorf("Expected max_turns 5 after update, got %+v", policy.MaxTurns)
+	}
+	if policy.PerTurnTimeoutSeconds != nil || policy.RequireToolCall {
+		t.Errorf("Expected timeout and require_tool_call cleared, got %+v", policy)
+	}
+
+	// Other agents are unaffected
+	other, err := repo.GetByAgent(ctx, 2)
+	if err != nil || other != nil {
+		t.Errorf("Expected no policy for agent 2, got %+v (err: %v)", other, err)
+	}
+}
+
+func TestAgentStepPolicyRepo_Validation(t *testing.T) {
+	db := setupStepPolicyTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewAgentStepPolicyRepo(db)
+	ctx := context.Background()
+
+	badTurns := int64(0)
+	if _, err := repo.Upsert(ctx, 1, &badTurns, nil, false); err == nil {
+		t.Error("Expected error for non-positive max_turns")
+	}
+
+	badTimeout := int64(-5)
+	if _, err := repo.Upsert(ctx, 1, nil, &badTimeout, false); err == nil {
+		t.Error("Expected error for negative per_turn_timeout_seconds")
+	}
+}
+
+func TestAgentStepPolicyRepo_DeleteAndCascade(t *testing.T) {
+	db := setupStepPolicyTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewAgentStepPolicyRepo(db)
+	ctx := context.Background()
+
+	maxTurns := int64(8)
+	if _, err := repo.Upsert(ctx, 1, &maxTurns, nil, true); err != nil {
+		t.Fatalf("Failed to upsert policy: %v", err)
+	}
+
+	if err := repo.Delete(ctx, 1); err != nil {
+		t.Fatalf("Failed to delete policy: %v", err)
+	}
+	policy, err := repo.GetByAgent(ctx, 1)
+	if err != nil || policy != nil {
+		t.Errorf("Expected policy removed, got %+v (err: %v)", policy, err)
+	}
+
+	// Deleting a missing policy is a no-op
+	if err := repo.Delete(ctx, 1); err != nil {
+		t.Errorf("Expected no error deleting missing policy, got %v", err)
+	}
+
+	if _, err := repo.Upsert(ctx, 2, &maxTurns, nil, false); err != nil {
+		t.Fatalf("Failed to upsert policy: %v", err)
+	}
+	if _, err := db.Exec(`DELETE FROM agents WHERE id = 2`); err != nil {
+		t.Fatalf("Failed to delete agent: %v", err)
+	}
+	policy, err = repo.GetByAgent(ctx, 2)
+	if err != nil || policy != nil {
+		t.Errorf("Expected cascade to remove policy, got %+v (err: %v)", policy, err)
+	}
+}
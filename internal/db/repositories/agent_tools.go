@@ -150,6 +150,31 @@ func (r *AgentToolRepo) ListAvailableToolsForAgent(agentID int64, agentIDParam i
 	return result, nil
 }
 
+// ListAgentNamesByTool returns the names of agents that have a specific tool
+// assigned, used to warn which agents are affected when a tool disappears
+func (r *AgentToolRepo) ListAgentNamesByTool(toolID int64) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT a.name
+		FROM agent_tools at
+		JOIN agents a ON at.agent_id = a.id
+		WHERE at.tool_id = ?
+		ORDER BY a.name`, toolID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
 // Clear removes all tool assignments for an agent
 func (r *AgentToolRepo) Clear(agentID int64) error {
 	return r.queries.ClearAgentTools(context.Background(), agentID)
@@ -124,6 +124,7 @@ func setupAgentTestDB(t *testing.T) *sql.DB {
 		memory_max_tokens INTEGER DEFAULT 2000,
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME,
 		FOREIGN KEY (environment_id) REFERENCES environments (id),
 		FOREIGN KEY (created_by) REFERENCES users (id)
 	);
@@ -130,26 +130,32 @@ func (r *AgentRepo) Create(name, description, prompt string, maxSteps, environme
 
 	created, err := r.queries.CreateAgent(context.Background(), params)
 	if err != nil {
-		return nil, err
+		return nil, r.resolveCreateConflict(name, environmentID, err)
 	}
 
 	return convertAgentFromSQLc(created), nil
 }
 
-func (r *AgentRepo) GetByID(id int64) (*models.Agent, error) {
-	agent, err := r.queries.GetAgent(context.Background(), id)
+// liveAgent hides soft-deleted agents from the single-row accessors: they
+// behave as if the agent no longer exists until it is restored
+func (r *AgentRepo) liveAgent(agent queries.Agent, err error) (*models.Agent, error) {
 	if err != nil {
 		return nil, err
 	}
+	if deleted, err := r.agentDeleted(agent.ID); err != nil {
+		return nil, err
+	} else if deleted {
+		return nil, sql.ErrNoRows
+	}
 	return convertAgentFromSQLc(agent), nil
 }
 
+func (r *AgentRepo) GetByID(id int64) (*models.Agent, error) {
+	return r.liveAgent(r.queries.GetAgent(context.Background(), id))
+}
+
 func (r *AgentRepo) GetByName(name string) (*models.Agent, error) {
-	agent, err := r.queries.GetAgentByName(context.Background(), name)
-	if err != nil {
-		return nil, err
-	}
-	return convertAgentFromSQLc(agent), nil
+	return r.liveAgent(r.queries.GetAgentByName(context.Background(), name))
 }
 
 func (r *AgentRepo) GetByNameAndEnvironment(name string, environmentID int64) (*models.Agent, error) {
@@ -157,19 +163,11 @@ func (r *AgentRepo) GetByNameAndEnvironment(name string, environmentID int64) (*
 		Name:          name,
 		EnvironmentID: environmentID,
 	}
-	agent, err := r.queries.GetAgentByNameAndEnvironment(context.Background(), params)
-	if err != nil {
-		return nil, err
-	}
-	return convertAgentFromSQLc(agent), nil
+	return r.liveAgent(r.queries.GetAgentByNameAndEnvironment(context.Background(), params))
 }
 
 func (r *AgentRepo) GetByNameGlobal(name string) (*models.Agent, error) {
-	agent, err := r.queries.GetAgentByNameGlobal(context.Background(), name)
-	if err != nil {
-		return nil, err
-	}
-	return convertAgentFromSQLc(agent), nil
+	return r.liveAgent(r.queries.GetAgentByNameGlobal(context.Background(), name))
 }
 
 func (r *AgentRepo) List() ([]*models.Agent, error) {
@@ -178,8 +176,16 @@ func (r *AgentRepo) List() ([]*models.Agent, error) {
 		return nil, err
 	}
 
+	deleted, err := r.deletedAgentIDs()
+	if err != nil {
+		return nil, err
+	}
+
 	var result []*models.Agent
 	for _, agent := range agents {
+		if deleted[agent.ID] {
+			continue
+		}
 		result = append(result, convertAgentFromSQLc(agent))
 	}
 
@@ -197,9 +203,17 @@ func (r *AgentRepo) ListByEnvironment(environmentID int64) ([]*models.Agent, err
 		return nil, err
 	}
 
-	result := make([]*models.Agent, len(agents))
-	for i, agent := range agents {
-		result[i] = convertAgentFromSQLc(agent)
+	deleted, err := r.deletedAgentIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Agent, 0, len(agents))
+	for _, agent := range agents {
+		if deleted[agent.ID] {
+			continue
+		}
+		result = append(result, convertAgentFromSQLc(agent))
 	}
 	return result, nil
 }
@@ -211,11 +225,18 @@ func (r *AgentRepo) GetByEnvironment(ctx context.Context, environmentID int64) (
 		return nil, err
 	}
 
+	deleted, err := r.deletedAgentIDs()
+	if err != nil {
+		return nil, err
+	}
+
 	// Return as []models.Agent (not pointers) for compatibility
-	result := make([]models.Agent, len(agents))
-	for i, agent := range agents {
-		converted := convertAgentFromSQLc(agent)
-		result[i] = *converted
+	result := make([]models.Agent, 0, len(agents))
+	for _, agent := range agents {
+		if deleted[agent.ID] {
+			continue
+		}
+		result = append(result, *convertAgentFromSQLc(agent))
 	}
 	return result, nil
 }
@@ -226,8 +247,16 @@ func (r *AgentRepo) ListByUser(userID int64) ([]*models.Agent, error) {
 		return nil, err
 	}
 
+	deleted, err := r.deletedAgentIDs()
+	if err != nil {
+		return nil, err
+	}
+
 	var result []*models.Agent
 	for _, agent := range agents {
+		if deleted[agent.ID] {
+			continue
+		}
 		result = append(result, convertAgentFromSQLc(agent))
 	}
 
@@ -278,7 +307,15 @@ func (r *AgentRepo) Update(id int64, name, description, prompt string, maxSteps
 	return r.queries.UpdateAgent(context.Background(), params)
 }
 
+// Delete soft-deletes the agent; run history and tool assignments are kept
+// until the purger hard-deletes it after the retention window
 func (r *AgentRepo) Delete(id int64) error {
+	return r.SoftDelete(context.Background(), id)
+}
+
+// HardDelete permanently removes the agent, cascading to its runs and tool
+// assignments. Only the soft-delete purger should call this.
+func (r *AgentRepo) HardDelete(id int64) error {
 	return r.queries.DeleteAgent(context.Background(), id)
 }
 
@@ -429,7 +466,7 @@ func (r *AgentRepo) CreateWithMemory(name, description, prompt string, maxSteps,
 
 	created, err := r.queries.CreateAgent(context.Background(), params)
 	if err != nil {
-		return nil, err
+		return nil, r.resolveCreateConflict(name, environmentID, err)
 	}
 
 	return convertAgentFromSQLc(created), nil
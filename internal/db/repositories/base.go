@@ -17,6 +17,9 @@ type Repositories struct {
 	AgentTools        *AgentToolRepo
 	AgentRuns         *AgentRunRepo
 	AgentAgents       *AgentAgentRepo
+	AgentGroups       *AgentGroupRepo
+	AgentStepPolicies *AgentStepPolicyRepo
+	RunAnnotations    *RunAnnotationRepo
 	Settings          *SettingsRepo
 	Reports           *ReportRepo
 	BenchmarkMetrics  *BenchmarkMetricsRepo
@@ -44,7 +47,10 @@ func New(database db.Database) *Repositories {
 		Agents:            NewAgentRepo(conn),
 		AgentTools:        NewAgentToolRepo(conn),
 		AgentAgents:       NewAgentAgentRepo(conn),
+		AgentGroups:       NewAgentGroupRepo(conn),
+		AgentStepPolicies: NewAgentStepPolicyRepo(conn),
 		AgentRuns:         NewAgentRunRepo(conn),
+		RunAnnotations:    NewRunAnnotationRepo(conn),
 		Settings:          NewSettingsRepo(conn),
 		Reports:           NewReportRepo(conn),
 		BenchmarkMetrics:  NewBenchmarkMetricsRepo(conn),
@@ -121,6 +121,60 @@ func (r *BenchmarkTasksRepo) GetByID(ctx context.Context, id int64) (*BenchmarkT
 	return &t, nil
 }
 
+// Upsert creates a benchmark task or updates the existing one with the same
+// name (tasks have no unique constraint on name, so matching is done here).
+// Returns true when a new task was created.
+func (r *BenchmarkTasksRepo) Upsert(ctx context.Context, t *BenchmarkTask) (bool, error) {
+	var existingID int64
+	err := r.db.QueryRowContext(ctx, "SELECT id FROM benchmark_tasks WHERE name = ?", t.Name).Scan(&existingID)
+	if err != nil && err != sql.ErrNoRows {
+		return false, fmt.Errorf("failed to look up benchmark task %q: %w", t.Name, err)
+	}
+
+	if err == sql.ErrNoRows {
+		query := `
+			INSERT INTO benchmark_tasks (
+				name, category, description,
+				expected_output_example, evaluation_criteria,
+				task_completion_weight, relevancy_weight,
+				hallucination_weight, faithfulness_weight, toxicity_weight,
+				created_at, updated_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`
+		_, err := r.db.ExecContext(ctx, query,
+			t.Name, t.Category, t.Description,
+			t.ExpectedOutputExample, t.EvaluationCriteria,
+			t.TaskCompletionWeight, t.RelevancyWeight,
+			t.HallucinationWeight, t.FaithfulnessWeight, t.ToxicityWeight,
+		)
+		if err != nil {
+			return false, fmt.Errorf("failed to create benchmark task %q: %w", t.Name, err)
+		}
+		return true, nil
+	}
+
+	query := `
+		UPDATE benchmark_tasks SET
+			category = ?, description = ?,
+			expected_output_example = ?, evaluation_criteria = ?,
+			task_completion_weight = ?, relevancy_weight = ?,
+			hallucination_weight = ?, faithfulness_weight = ?, toxicity_weight = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		t.Category, t.Description,
+		t.ExpectedOutputExample, t.EvaluationCriteria,
+		t.TaskCompletionWeight, t.RelevancyWeight,
+		t.HallucinationWeight, t.FaithfulnessWeight, t.ToxicityWeight,
+		existingID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update benchmark task %q: %w", t.Name, err)
+	}
+	return false, nil
+}
+
 // GetByCategory retrieves benchmark tasks by category
 func (r *BenchmarkTasksRepo) GetByCategory(ctx context.Context, category string) ([]BenchmarkTask, error) {
 	query := `
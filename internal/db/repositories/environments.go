@@ -66,14 +66,30 @@ func (r *EnvironmentRepo) GetByID(id int64) (*models.Environment, error) {
 	if err != nil {
 		return nil, err
 	}
+	deleted, err := r.deletedEnvironmentIDs()
+	if err != nil {
+		return nil, err
+	}
+	if deleted[env.ID] {
+		return nil, sql.ErrNoRows
+	}
 	return convertEnvironmentFromSQLc(env), nil
 }
 
+// GetByName hides soft-deleted environments so callers treat them as gone
+// until restored
 func (r *EnvironmentRepo) GetByName(name string) (*models.Environment, error) {
 	env, err := r.queries.GetEnvironmentByName(context.Background(), name)
 	if err != nil {
 		return nil, err
 	}
+	deleted, err := r.deletedEnvironmentIDs()
+	if err != nil {
+		return nil, err
+	}
+	if deleted[env.ID] {
+		return nil, sql.ErrNoRows
+	}
 	return convertEnvironmentFromSQLc(env), nil
 }
 
@@ -83,8 +99,16 @@ func (r *EnvironmentRepo) List() ([]*models.Environment, error) {
 		return nil, err
 	}
 
+	deleted, err := r.deletedEnvironmentIDs()
+	if err != nil {
+		return nil, err
+	}
+
 	var result []*models.Environment
 	for _, env := range environments {
+		if deleted[env.ID] {
+			continue
+		}
 		result = append(result, convertEnvironmentFromSQLc(env))
 	}
 
@@ -104,7 +128,15 @@ func (r *EnvironmentRepo) Update(id int64, name string, description *string) err
 	return r.queries.UpdateEnvironment(context.Background(), params)
 }
 
+// Delete soft-deletes the environment (and its agents); the purger
+// hard-deletes it after the retention window
 func (r *EnvironmentRepo) Delete(id int64) error {
+	return r.SoftDelete(context.Background(), id)
+}
+
+// HardDelete permanently removes the environment, cascading to its agents
+// and their runs. Only the soft-delete purger should call this.
+func (r *EnvironmentRepo) HardDelete(id int64) error {
 	// Check if this is the default environment
 	env, err := r.GetByID(id)
 	if err != nil {
@@ -36,7 +36,18 @@ func setupTestDB(t *testing.T) *sql.DB {
 		created_by INTEGER NOT NULL DEFAULT 1,
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME,
 		FOREIGN KEY (created_by) REFERENCES users (id)
+	);
+
+	CREATE TABLE agents (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		environment_id INTEGER NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME,
+		FOREIGN KEY (environment_id) REFERENCES environments (id)
 	);`
 
 	if _, err := db.Exec(schema); err != nil {
@@ -217,6 +217,28 @@ func (r *MCPServerRepo) Update(server *models.MCPServer) error {
 	return err
 }
 
+// UpdateServerInfo records the serverInfo name/version the server reported
+// during the MCP initialize handshake (raw SQL - column added after sqlc generation)
+func (r *MCPServerRepo) UpdateServerInfo(ctx context.Context, id int64, name, version string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE mcp_servers SET server_info_name = ?, server_info_version = ? WHERE id = ?`,
+		name, version, id)
+	return err
+}
+
+// GetServerInfo returns the recorded serverInfo name/version for a server;
+// both are empty until the server has been discovered at least once
+func (r *MCPServerRepo) GetServerInfo(ctx context.Context, id int64) (string, string, error) {
+	var name, version string
+	err := r.db.QueryRowContext(ctx,
+		`SELECT server_info_name, server_info_version FROM mcp_servers WHERE id = ?`, id).
+		Scan(&name, &version)
+	if err != nil {
+		return "", "", err
+	}
+	return name, version, nil
+}
+
 // GetAll retrieves all MCP servers across all environments
 func (r *MCPServerRepo) GetAll() ([]*models.MCPServer, error) {
 	servers, err := r.queries.ListAllMCPServers(context.Background())
@@ -156,6 +156,13 @@ func (r *MCPToolRepo) DeleteByServerIDTx(tx *sql.Tx, serverID int64) error {
 	}
 }
 
+// Delete removes a single tool by ID; agent_tools assignments referencing it
+// are removed by the foreign key cascade
+func (r *MCPToolRepo) Delete(id int64) error {
+	_, err := r.db.Exec("DELETE FROM mcp_tools WHERE id = ?", id)
+	return err
+}
+
 func (r *MCPToolRepo) GetAllWithDetails() ([]*models.MCPToolWithDetails, error) {
 	// First check if there are any servers at all using SQLC
 	serverCount, err := r.queries.GetMCPToolsWithServerCount(context.Background())
@@ -212,3 +219,96 @@ func (r *MCPToolRepo) GetAllWithDetails() ([]*models.MCPToolWithDetails, error)
 func (r *MCPToolRepo) GetServerNameForTool(toolName string) (string, error) {
 	return r.queries.GetMCPServerNameByTool(context.Background(), toolName)
 }
+
+// ListUnenrichedByEnvironment returns tools in an environment that have no
+// model-expanded description yet
+func (r *MCPToolRepo) ListUnenrichedByEnvironment(environmentID int64) ([]*models.MCPTool, error) {
+	rows, err := r.db.Query(`
+		SELECT t.id, t.mcp_server_id, t.name, t.description, t.input_schema
+		FROM mcp_tools t
+		JOIN mcp_servers s ON t.mcp_server_id = s.id
+		WHERE s.environment_id = ? AND (t.enriched_description IS NULL OR t.enriched_description = '')
+		ORDER BY t.name`, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*models.MCPTool
+	for rows.Next() {
+		tool := &models.MCPTool{}
+		var description, inputSchema sql.NullString
+		if err := rows.Scan(&tool.ID, &tool.MCPServerID, &tool.Name, &description, &inputSchema); err != nil {
+			return nil, err
+		}
+		tool.Description = description.String
+		if inputSchema.Valid {
+			tool.Schema = json.RawMessage(inputSchema.String)
+		}
+		result = append(result, tool)
+	}
+	return result, rows.Err()
+}
+
+// UpdateEnrichedDescription stores the model-expanded description for a
+// tool; the original MCP-provided description is preserved
+func (r *MCPToolRepo) UpdateEnrichedDescription(toolID int64, enriched string) error {
+	_, err := r.db.Exec(
+		"UPDATE mcp_tools SET enriched_description = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+		enriched, toolID)
+	return err
+}
+
+// GetEnrichedDescription returns the model-expanded description for a tool,
+// or empty string when the tool has not been enriched
+func (r *MCPToolRepo) GetEnrichedDescription(toolID int64) (string, error) {
+	var enriched sql.NullString
+	err := r.db.QueryRow("SELECT enriched_description FROM mcp_tools WHERE id = ?", toolID).Scan(&enriched)
+	if err != nil {
+		return "", err
+	}
+	return enriched.String, nil
+}
+
+// UpdateAnnotations stores the cost/latency annotation JSON for a tool
+func (r *MCPToolRepo) UpdateAnnotations(toolID int64, annotations string) error {
+	_, err := r.db.Exec(
+		"UPDATE mcp_tools SET annotations = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+		annotations, toolID)
+	return err
+}
+
+// GetAnnotations returns the annotation JSON for a tool, or empty string
+// when the tool has not been annotated
+func (r *MCPToolRepo) GetAnnotations(toolID int64) (string, error) {
+	var annotations sql.NullString
+	err := r.db.QueryRow("SELECT annotations FROM mcp_tools WHERE id = ?", toolID).Scan(&annotations)
+	if err != nil {
+		return "", err
+	}
+	return annotations.String, nil
+}
+
+// ListAnnotationsByEnvironment returns tool name -> annotation JSON for all
+// annotated tools in an environment
+func (r *MCPToolRepo) ListAnnotationsByEnvironment(environmentID int64) (map[string]string, error) {
+	rows, err := r.db.Query(`
+		SELECT t.name, t.annotations
+		FROM mcp_tools t
+		JOIN mcp_servers s ON t.mcp_server_id = s.id
+		WHERE s.environment_id = ? AND t.annotations IS NOT NULL AND t.annotations != ''`, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]string)
+	for rows.Next() {
+		var name, annotations string
+		if err := rows.Scan(&name, &annotations); err != nil {
+			return nil, err
+		}
+		result[name] = annotations
+	}
+	return result, rows.Err()
+}
@@ -90,3 +90,22 @@ func (r *ReportRepo) GetAgentReportDetailByAgentID(ctx context.Context, reportID
 func (r *ReportRepo) GetLatestByEnvironment(ctx context.Context, environmentID int64) (queries.Report, error) {
 	return r.queries.GetLatestReportByEnvironment(ctx, environmentID)
 }
+
+// SetAgentGroup restricts a report to the agents in the given group
+func (r *ReportRepo) SetAgentGroup(ctx context.Context, reportID, groupID int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE reports SET agent_group_id = ? WHERE id = ?`, groupID, reportID)
+	return err
+}
+
+// GetAgentGroupID returns the agent group a report targets, or nil for whole-environment reports
+func (r *ReportRepo) GetAgentGroupID(ctx context.Context, reportID int64) (*int64, error) {
+	var groupID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT agent_group_id FROM reports WHERE id = ?`, reportID).Scan(&groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !groupID.Valid {
+		return nil, nil
+	}
+	return &groupID.Int64, nil
+}
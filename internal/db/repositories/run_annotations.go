@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"station/pkg/models"
+)
+
+// Thumbs values accepted on run annotations
+const (
+	ThumbsUp   = "up"
+	ThumbsDown = "down"
+)
+
+// RunAnnotationRepo manages human feedback attached to agent runs
+type RunAnnotationRepo struct {
+	db *sql.DB
+}
+
+func NewRunAnnotationRepo(db *sql.DB) *RunAnnotationRepo {
+	return &RunAnnotationRepo{db: db}
+}
+
+// Create records feedback on a run. Rating and thumbs are optional but must
+// be valid when present (rating 1-5, thumbs "up"/"down").
+func (r *RunAnnotationRepo) Create(ctx context.Context, runID int64, rating *int64, thumbs *string, comment, createdBy string) (*models.RunAnnotation, error) {
+	if rating != nil && (*rating < 1 || *rating > 5) {
+		return nil, fmt.Errorf("rating must be between 1 and 5, got %d", *rating)
+	}
+	if thumbs != nil && *thumbs != ThumbsUp && *thumbs != ThumbsDown {
+		return nil, fmt.Errorf("thumbs must be %q or %q, got %q", ThumbsUp, ThumbsDown, *thumbs)
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO run_annotations (run_id, rating, thumbs, comment, created_by) VALUES (?, ?, ?, ?, ?)`,
+		runID, rating, thumbs, comment, createdBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run annotation: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// GetByID returns an annotation by ID
+func (r *RunAnnotationRepo) GetByID(ctx context.Context, id int64) (*models.RunAnnotation, error) {
+	return r.scanAnnotation(r.db.QueryRowContext(ctx,
+		`SELECT id, run_id, rating, thumbs, comment, created_by, created_at
+		 FROM run_annotations WHERE id = ?`, id))
+}
+
+// ListByRun returns a run's annotations in the order they were recorded
+func (r *RunAnnotationRepo) ListByRun(ctx context.Context, runID int64) ([]*models.RunAnnotation, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, run_id, rating, thumbs, comment, created_by, created_at
+		 FROM run_annotations WHERE run_id = ? ORDER BY created_at, id`, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list run annotations: %w", err)
+	}
+	defer rows.Close()
+
+	var annotations []*models.RunAnnotation
+	for rows.Next() {
+		annotation, err := r.scanAnnotation(rows)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, annotation)
+	}
+	return annotations, rows.Err()
+}
+
+// Delete removes an annotation
+func (r *RunAnnotationRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM run_annotations WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete run annotation: %w", err)
+	}
+	return nil
+}
+
+func (r *RunAnnotationRepo) scanAnnotation(row rowScanner) (*models.RunAnnotation, error) {
+	annotation := &models.RunAnnotation{}
+	var rating sql.NullInt64
+	var thumbs sql.NullString
+	var createdAt sql.NullTime
+	if err := row.Scan(&annotation.ID, &annotation.RunID, &rating, &thumbs,
+		&annotation.Comment, &annotation.CreatedBy, &createdAt); err != nil {
+		return nil, err
+	}
+	if rating.Valid {
+		value := rating.Int64
+		annotation.Rating = &value
+	}
+	if thumbs.Valid {
+		value := thumbs.String
+		annotation.Thumbs = &value
+	}
+	if createdAt.Valid {
+		annotation.CreatedAt = createdAt.Time
+	}
+	return annotation, nil
+}
@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func setupRunAnnotationTestDB(t *testing.T) *sql.DB {
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+
+	schema := `
+	PRAGMA foreign_keys = ON;
+
+	CREATE TABLE agent_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		agent_id INTEGER NOT NULL,
+		task TEXT NOT NULL,
+		status TEXT NOT NULL
+	);
+
+	CREATE TABLE run_annotations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		run_id INTEGER NOT NULL REFERENCES agent_runs(id) ON DELETE CASCADE,
+		rating INTEGER,
+		thumbs TEXT,
+		comment TEXT NOT NULL DEFAULT '',
+		created_by TEXT NOT NULL DEFAULT '',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("Failed to create test schema: %v", err)
+	}
+
+	_, err = db.Exec(`INSERT INTO agent_runs (agent_id, task, status) VALUES
+		(1, 'Scan prod cluster', 'completed'),
+		(1, 'Scan staging cluster', 'failed')`)
+	if err != nil {
+		t.Fatalf("Failed to create test runs: %v", err)
+	}
+
+	return db
+}
+
+func TestRunAnnotationRepo_CreateAndList(t *testing.T) {
+	db := setupRunAnnotationTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewRunAnnotationRepo(db)
+	ctx := context.Background()
+
+	rating := int64(4)
+	annotation, err := repo.Create(ctx, 1, &rating, nil, "missed the staging cluster", "alice")
+	if err != nil {
+		t.Fatalf("Failed to create annotation: %v", err)
+	}
+	if annotation.Rating == nil || *annotation.Rating != 4 || annotation.Comment != "missed the staging cluster" {
+		t.Errorf("Unexpected annotation: %+v", annotation)
+	}
+	if annotation.Thumbs != nil {
+		t.Errorf("Expected no thumbs, got %v", *annotation.Thumbs)
+	}
+
+	thumbs := ThumbsDown
+	if _, err := repo.Create(ctx, 1, nil, &thumbs, "", "ui"); err != nil {
+		t.Fatalf("Failed to create thumbs annotation: %v", err)
+	}
+
+	annotations, err := repo.ListByRun(ctx, 1)
+	if err != nil || len(annotations) != 2 {
+		t.Fatalf("Expected 2 annotations, got %d (err: %v)", len(annotations), err)
+	}
+	if annotations[1].Thumbs == nil || *annotations[1].Thumbs != ThumbsDown {
+		t.Errorf("Expected thumbs-down annotation second, got %+v", annotations[1])
+	}
+
+	// Other runs are unaffected
+	annotations, _ = repo.ListByRun(ctx, 2)
+	if len(annotations) != 0 {
+		t.Errorf("Expected no annotations on run 2, got %+v", annotations)
+	}
+}
+
+func TestRunAnnotationRepo_Validation(t *testing.T) {
+	db := setupRunAnnotationTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewRunAnnotationRepo(db)
+	ctx := context.Background()
+
+	badRating := int64(6)
+	if _, err := repo.Create(ctx, 1, &badRating, nil, "", "alice"); err == nil {
+		t.Error("Expected error for rating out of range")
+	}
+
+	badThumbs := "sideways"
+	if _, err := repo.Create(ctx, 1, nil, &badThumbs, "", "alice"); err == nil {
+		t.Error("Expected error for invalid thumbs value")
+	}
+}
+
+func TestRunAnnotationRepo_CascadeDelete(t *testing.T) {
+	db := setupRunAnnotationTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := NewRunAnnotationRepo(db)
+	ctx := context.Background()
+
+	rating := int64(5)
+	if _, err := repo.Create(ctx, 2, &rating, nil, "", "alice"); err != nil {
+		t.Fatalf("Failed to create annotation: %v", err)
+	}
+
+	if _, err := db.Exec(`DELETE FROM agent_runs WHERE id = 2`); err != nil {
+		t.Fatalf("Failed to delete run: %v", err)
+	}
+
+	annotations, err := repo.ListByRun(ctx, 2)
+	if err != nil || len(annotations) != 0 {
+		t.Errorf("Expected cascade to remove annotations, got %d (err: %v)", len(annotations), err)
+	}
+}
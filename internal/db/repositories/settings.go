@@ -77,3 +77,21 @@ func (r *SettingsRepo) Set(key, value, description string) error {
 func (r *SettingsRepo) Delete(key string) error {
 	return r.queries.DeleteSetting(context.Background(), key)
 }
+
+// TryAcquire inserts the key only if it does not already exist, returning
+// whether the insert won. Used for advisory locks (e.g. sync locking) where
+// the atomic insert-or-nothing semantics matter.
+func (r *SettingsRepo) TryAcquire(key, value, description string) (bool, error) {
+	result, err := r.db.ExecContext(context.Background(),
+		`INSERT INTO settings (key, value, description) VALUES (?, ?, ?)
+		 ON CONFLICT(key) DO NOTHING`,
+		key, value, sql.NullString{String: description, Valid: description != ""})
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
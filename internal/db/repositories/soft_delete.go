@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Soft-delete support for agents and environments. Deleting stamps
+// deleted_at instead of removing the row, so run history is preserved and
+// the deletion can be undone with Restore until the purger hard-deletes
+// rows older than the retention window. The regular repo accessors treat
+// soft-deleted rows as gone; only the methods here can see them.
+
+// SoftDeletedAgent is the trimmed view of a soft-deleted agent used by
+// restore and purge flows
+type SoftDeletedAgent struct {
+	ID            int64
+	Name          string
+	EnvironmentID int64
+	DeletedAt     time.Time
+}
+
+// SoftDeletedEnvironment is the trimmed view of a soft-deleted environment
+type SoftDeletedEnvironment struct {
+	ID        int64
+	Name      string
+	DeletedAt time.Time
+}
+
+// SoftDelete marks an agent deleted without touching its runs or tool
+// assignments, so a restore brings it back intact
+func (r *AgentRepo) SoftDelete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE agents SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`,
+		time.Now().UTC(), id)
+	return err
+}
+
+// Restore clears an agent's deleted_at stamp, making it visible again
+func (r *AgentRepo) Restore(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE agents SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListDeleted returns all soft-deleted agents, most recently deleted first
+func (r *AgentRepo) ListDeleted(ctx context.Context) ([]SoftDeletedAgent, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, name, environment_id, deleted_at FROM agents
+		 WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deleted []SoftDeletedAgent
+	for rows.Next() {
+		var agent SoftDeletedAgent
+		if err := rows.Scan(&agent.ID, &agent.Name, &agent.EnvironmentID, &agent.DeletedAt); err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, agent)
+	}
+	return deleted, rows.Err()
+}
+
+// PurgeDeletedBefore hard-deletes agents soft-deleted before the cutoff
+// (cascading to runs and tool assignments) and returns the purged rows so
+// callers can clean up parked .prompt files
+func (r *AgentRepo) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]SoftDeletedAgent, error) {
+	deleted, err := r.ListDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var purged []SoftDeletedAgent
+	for _, agent := range deleted {
+		if !agent.DeletedAt.Before(cutoff) {
+			continue
+		}
+		if err := r.queries.DeleteAgent(ctx, agent.ID); err != nil {
+			return purged, err
+		}
+		purged = append(purged, agent)
+	}
+	return purged, nil
+}
+
+// deletedAgentIDs returns the IDs of all soft-deleted agents so the
+// generated list queries (which predate deleted_at) can filter them out
+func (r *AgentRepo) deletedAgentIDs() (map[int64]bool, error) {
+	rows, err := r.db.Query(`SELECT id FROM agents WHERE deleted_at IS NOT NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deleted := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		deleted[id] = true
+	}
+	return deleted, rows.Err()
+}
+
+// resolveCreateConflict turns the UNIQUE(name, environment_id) violation a
+// soft-deleted name-holder causes into an actionable error
+func (r *AgentRepo) resolveCreateConflict(name string, environmentID int64, err error) error {
+	if err == nil || !strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return err
+	}
+	var deletedAt sql.NullTime
+	scanErr := r.db.QueryRow(
+		`SELECT deleted_at FROM agents WHERE name = ? AND environment_id = ?`,
+		name, environmentID).Scan(&deletedAt)
+	if scanErr == nil && deletedAt.Valid {
+		return fmt.Errorf("an agent named %q was recently deleted in this environment; restore it with 'stn agent restore %s' or wait for it to be purged", name, name)
+	}
+	return err
+}
+
+// agentDeleted reports whether a single agent is soft-deleted
+func (r *AgentRepo) agentDeleted(id int64) (bool, error) {
+	var deletedAt sql.NullTime
+	err := r.db.QueryRow(`SELECT deleted_at FROM agents WHERE id = ?`, id).Scan(&deletedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return deletedAt.Valid, nil
+}
+
+// SoftDelete marks an environment and its live agents deleted with the
+// same timestamp, so restoring the environment brings exactly those
+// agents back
+func (r *EnvironmentRepo) SoftDelete(ctx context.Context, id int64) error {
+	env, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if env.Name == "default" {
+		return fmt.Errorf("cannot delete the default environment")
+	}
+
+	deletedAt := time.Now().UTC()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE environments SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`,
+		deletedAt, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE agents SET deleted_at = ? WHERE environment_id = ? AND deleted_at IS NULL`,
+		deletedAt, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Restore clears an environment's deleted_at stamp along with the agents
+// that were soft-deleted with it (matching timestamps); agents deleted
+// individually beforehand stay deleted
+func (r *EnvironmentRepo) Restore(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var deletedAt sql.NullTime
+	if err := tx.QueryRowContext(ctx,
+		`SELECT deleted_at FROM environments WHERE id = ?`, id).Scan(&deletedAt); err != nil {
+		return err
+	}
+	if !deletedAt.Valid {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE environments SET deleted_at = NULL WHERE id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE agents SET deleted_at = NULL WHERE environment_id = ? AND deleted_at = ?`,
+		id, deletedAt.Time); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListDeleted returns all soft-deleted environments, most recent first
+func (r *EnvironmentRepo) ListDeleted(ctx context.Context) ([]SoftDeletedEnvironment, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, name, deleted_at FROM environments
+		 WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deleted []SoftDeletedEnvironment
+	for rows.Next() {
+		var env SoftDeletedEnvironment
+		if err := rows.Scan(&env.ID, &env.Name, &env.DeletedAt); err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, env)
+	}
+	return deleted, rows.Err()
+}
+
+// PurgeDeletedBefore hard-deletes environments soft-deleted before the
+// cutoff (cascading to their agents and runs) and returns the purged rows
+// so callers can remove trashed environment directories
+func (r *EnvironmentRepo) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]SoftDeletedEnvironment, error) {
+	deleted, err := r.ListDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var purged []SoftDeletedEnvironment
+	for _, env := range deleted {
+		if !env.DeletedAt.Before(cutoff) {
+			continue
+		}
+		if err := r.queries.DeleteEnvironment(ctx, env.ID); err != nil {
+			return purged, err
+		}
+		purged = append(purged, env)
+	}
+	return purged, nil
+}
+
+// deletedEnvironmentIDs mirrors deletedAgentIDs for environment listings
+func (r *EnvironmentRepo) deletedEnvironmentIDs() (map[int64]bool, error) {
+	rows, err := r.db.Query(`SELECT id FROM environments WHERE deleted_at IS NOT NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deleted := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		deleted[id] = true
+	}
+	return deleted, rows.Err()
+}
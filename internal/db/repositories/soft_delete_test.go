@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"station/internal/db"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func setupSoftDeleteTest(t *testing.T) *Repositories {
+	t.Helper()
+
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = testDB.Close() })
+
+	return New(testDB)
+}
+
+func createSoftDeleteTestAgent(t *testing.T, repos *Repositories, name string, environmentID int64) int64 {
+	t.Helper()
+
+	agent, err := repos.Agents.Create(name, "soft delete test agent", "prompt", 5, environmentID, 1,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	return agent.ID
+}
+
+func TestAgentSoftDeleteHidesAndRestores(t *testing.T) {
+	repos := setupSoftDeleteTest(t)
+	ctx := context.Background()
+
+	env, err := repos.Environments.Create("soft-delete-env", nil, 1)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	agentID := createSoftDeleteTestAgent(t, repos, "recoverable", env.ID)
+
+	if err := repos.Agents.SoftDelete(ctx, agentID); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+
+	if _, err := repos.Agents.GetByID(agentID); err == nil {
+		t.Error("GetByID should not return a soft-deleted agent")
+	}
+	if _, err := repos.Agents.GetByNameAndEnvironment("recoverable", env.ID); err == nil {
+		t.Error("GetByNameAndEnvironment should not return a soft-deleted agent")
+	}
+	agents, err := repos.Agents.ListByEnvironment(env.ID)
+	if err != nil {
+		t.Fatalf("ListByEnvironment failed: %v", err)
+	}
+	if len(agents) != 0 {
+		t.Errorf("expected no visible agents, got %d", len(agents))
+	}
+
+	deleted, err := repos.Agents.ListDeleted(ctx)
+	if err != nil {
+		t.Fatalf("ListDeleted failed: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].Name != "recoverable" {
+		t.Fatalf("unexpected deleted agents: %+v", deleted)
+	}
+
+	if err := repos.Agents.Restore(ctx, agentID); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	restored, err := repos.Agents.GetByID(agentID)
+	if err != nil {
+		t.Fatalf("GetByID after restore failed: %v", err)
+	}
+	if restored.Name != "recoverable" {
+		t.Errorf("unexpected restored agent: %+v", restored)
+	}
+}
+
+func TestAgentCreateConflictWithSoftDeleted(t *testing.T) {
+	repos := setupSoftDeleteTest(t)
+	ctx := context.Background()
+
+	env, err := repos.Environments.Create("conflict-env", nil, 1)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	agentID := createSoftDeleteTestAgent(t, repos, "held-name", env.ID)
+	if err := repos.Agents.SoftDelete(ctx, agentID); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+
+	_, err = repos.Agents.Create("held-name", "replacement", "prompt", 5, env.ID, 1,
+		nil, nil, false, nil, nil, "", "")
+	if err == nil {
+		t.Fatal("expected create to fail while a soft-deleted agent holds the name")
+	}
+	if !strings.Contains(err.Error(), "stn agent restore") {
+		t.Errorf("error should point at restore: %v", err)
+	}
+}
+
+func TestAgentPurgeDeletedBefore(t *testing.T) {
+	repos := setupSoftDeleteTest(t)
+	ctx := context.Background()
+
+	env, err := repos.Environments.Create("purge-env", nil, 1)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	agentID := createSoftDeleteTestAgent(t, repos, "expired", env.ID)
+	if err := repos.Agents.SoftDelete(ctx, agentID); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+
+	// Cutoff in the past: nothing old enough to purge yet
+	purged, err := repos.Agents.PurgeDeletedBefore(ctx, time.Now().UTC().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("PurgeDeletedBefore failed: %v", err)
+	}
+	if len(purged) != 0 {
+		t.Errorf("expected nothing purged before retention expires, got %d", len(purged))
+	}
+
+	// Cutoff in the future: the deletion is now past retention
+	purged, err = repos.Agents.PurgeDeletedBefore(ctx, time.Now().UTC().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PurgeDeletedBefore failed: %v", err)
+	}
+	if len(purged) != 1 || purged[0].ID != agentID {
+		t.Fatalf("unexpected purged agents: %+v", purged)
+	}
+
+	deleted, err := repos.Agents.ListDeleted(ctx)
+	if err != nil {
+		t.Fatalf("ListDeleted failed: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("purged agent should be gone from the trash, got %+v", deleted)
+	}
+}
+
+func TestEnvironmentSoftDeleteCascadesToAgents(t *testing.T) {
+	repos := setupSoftDeleteTest(t)
+	ctx := context.Background()
+
+	env, err := repos.Environments.Create("doomed-env", nil, 1)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	agentID := createSoftDeleteTestAgent(t, repos, "env-member", env.ID)
+	earlierID := createSoftDeleteTestAgent(t, repos, "deleted-earlier", env.ID)
+	if err := repos.Agents.SoftDelete(ctx, earlierID); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+
+	if err := repos.Environments.SoftDelete(ctx, env.ID); err != nil {
+		t.Fatalf("Environment SoftDelete failed: %v", err)
+	}
+
+	if _, err := repos.Environments.GetByName("doomed-env"); err == nil {
+		t.Error("GetByName should not return a soft-deleted environment")
+	}
+	if _, err := repos.Agents.GetByID(agentID); err == nil {
+		t.Error("agents should be soft-deleted with their environment")
+	}
+
+	if err := repos.Environments.Restore(ctx, env.ID); err != nil {
+		t.Fatalf("Environment Restore failed: %v", err)
+	}
+	if _, err := repos.Agents.GetByID(agentID); err != nil {
+		t.Errorf("agent deleted with the environment should be restored with it: %v", err)
+	}
+	if _, err := repos.Agents.GetByID(earlierID); err == nil {
+		t.Error("agent deleted individually beforehand should stay deleted")
+	}
+}
+
+func TestEnvironmentSoftDeleteRefusesDefault(t *testing.T) {
+	repos := setupSoftDeleteTest(t)
+
+	defaultEnv, err := repos.Environments.GetByName("default")
+	if err != nil {
+		t.Fatalf("Failed to get default environment: %v", err)
+	}
+	if err := repos.Environments.SoftDelete(context.Background(), defaultEnv.ID); err == nil {
+		t.Error("soft-deleting the default environment should be refused")
+	}
+}
@@ -44,6 +44,8 @@ type DeployOptions struct {
 	SSHKey       string            // SSH private key path for Ansible
 	SSHUser      string            // SSH user for Ansible (default: root)
 	BundlePath   string            // Local bundle file to copy to remote host
+	IngressHost  string            // Kubernetes: generate an Ingress for this host (empty = no Ingress)
+	Litestream   bool              // Kubernetes: replicate the DB with a Litestream sidecar instead of a PVC
 }
 
 // DeploymentStatus represents the current state of a deployment
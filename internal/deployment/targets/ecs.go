@@ -0,0 +1,411 @@
+package targets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"station/internal/deployment"
+)
+
+// ECSTarget deploys Station as an AWS ECS/Fargate service via a generated
+// CloudFormation stack: task definition with secrets from SSM Parameter Store,
+// EFS (or Litestream-to-S3) for DB persistence, an ALB listener, and a log group.
+type ECSTarget struct{}
+
+func NewECSTarget() *ECSTarget {
+	return &ECSTarget{}
+}
+
+func (e *ECSTarget) Name() string {
+	return "ecs"
+}
+
+func (e *ECSTarget) Validate(ctx context.Context) error {
+	if _, err := exec.LookPath("aws"); err != nil {
+		return fmt.Errorf("aws CLI not found: install from https://docs.aws.amazon.com/cli/")
+	}
+	return nil
+}
+
+func (e *ECSTarget) GenerateConfig(ctx context.Context, config *deployment.DeploymentConfig, secrets map[string]string) (map[string]string, error) {
+	return e.GenerateConfigWithOptions(ctx, config, secrets, deployment.DeployOptions{})
+}
+
+func (e *ECSTarget) GenerateConfigWithOptions(ctx context.Context, config *deployment.DeploymentConfig, secrets map[string]string, options deployment.DeployOptions) (map[string]string, error) {
+	files := make(map[string]string)
+	appName := fmt.Sprintf("station-%s", config.EnvironmentName)
+
+	files["ecs-stack.yaml"] = e.generateStackTemplate(appName, config, secrets, options)
+
+	return files, nil
+}
+
+func (e *ECSTarget) Deploy(ctx context.Context, config *deployment.DeploymentConfig, secrets map[string]string, options deployment.DeployOptions) error {
+	files, err := e.GenerateConfigWithOptions(ctx, config, secrets, options)
+	if err != nil {
+		return fmt.Errorf("failed to generate config: %w", err)
+	}
+
+	appName := fmt.Sprintf("station-%s", config.EnvironmentName)
+
+	outputDir := options.OutputDir
+	if outputDir == "" {
+		outputDir = fmt.Sprintf("ecs-%s", config.EnvironmentName)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for filename, content := range files {
+		path := fmt.Sprintf("%s/%s", outputDir, filename)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filename, err)
+		}
+		fmt.Printf("   ✓ Generated %s\n", path)
+	}
+
+	if options.DryRun {
+		fmt.Printf("\n📄 Dry run - CloudFormation template generated in %s/\n", outputDir)
+		fmt.Printf("   To apply: aws cloudformation deploy --template-file %s/ecs-stack.yaml --stack-name %s \\\n", outputDir, appName)
+		fmt.Printf("     --capabilities CAPABILITY_NAMED_IAM --parameter-overrides VpcId=<vpc-id> SubnetIds=<subnet-1,subnet-2>\n")
+		return nil
+	}
+
+	vpcID := os.Getenv("STN_ECS_VPC_ID")
+	subnetIDs := os.Getenv("STN_ECS_SUBNET_IDS")
+	if vpcID == "" || subnetIDs == "" {
+		return fmt.Errorf("ECS deployment requires STN_ECS_VPC_ID and STN_ECS_SUBNET_IDS (comma-separated) environment variables, or use --dry-run to generate the template only")
+	}
+
+	// Push secrets into SSM Parameter Store; the task definition references them
+	// by path so secret values never appear in the CloudFormation template
+	fmt.Printf("\n🔐 Writing %d secrets to SSM Parameter Store under /%s/...\n", len(secrets), appName)
+	for key, value := range secrets {
+		paramName := fmt.Sprintf("/%s/%s", appName, key)
+		cmd := exec.CommandContext(ctx, "aws", "ssm", "put-parameter",
+			"--name", paramName, "--value", value, "--type", "SecureString", "--overwrite")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to write SSM parameter %s: %w\n%s", paramName, err, string(output))
+		}
+	}
+
+	fmt.Printf("\n🚀 Deploying CloudFormation stack '%s'...\n", appName)
+
+	args := []string{
+		"cloudformation", "deploy",
+		"--template-file", fmt.Sprintf("%s/ecs-stack.yaml", outputDir),
+		"--stack-name", appName,
+		"--capabilities", "CAPABILITY_NAMED_IAM",
+		"--parameter-overrides",
+		fmt.Sprintf("VpcId=%s", vpcID),
+		fmt.Sprintf("SubnetIds=%s", subnetIDs),
+	}
+	if options.Region != "" {
+		args = append(args, "--region", options.Region)
+	}
+
+	cmd := exec.CommandContext(ctx, "aws", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("cloudformation deploy failed: %w", err)
+	}
+
+	fmt.Printf("\n✅ Deployment complete!\n")
+	return nil
+}
+
+func (e *ECSTarget) Destroy(ctx context.Context, config *deployment.DeploymentConfig) error {
+	appName := fmt.Sprintf("station-%s", config.EnvironmentName)
+
+	fmt.Printf("🗑️  Deleting CloudFormation stack '%s'...\n", appName)
+
+	cmd := exec.CommandContext(ctx, "aws", "cloudformation", "delete-stack", "--stack-name", appName)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("cloudformation delete-stack failed: %w", err)
+	}
+
+	fmt.Printf("✅ Stack deletion initiated\n")
+	return nil
+}
+
+func (e *ECSTarget) Status(ctx context.Context, config *deployment.DeploymentConfig) (*deployment.DeploymentStatus, error) {
+	appName := fmt.Sprintf("station-%s", config.EnvironmentName)
+
+	cmd := exec.CommandContext(ctx, "aws", "cloudformation", "describe-stacks",
+		"--stack-name", appName, "--query", "Stacks[0].StackStatus", "--output", "text")
+	output, err := cmd.Output()
+	if err != nil {
+		return &deployment.DeploymentStatus{State: "unknown", Message: err.Error()}, nil
+	}
+
+	stackStatus := strings.TrimSpace(string(output))
+	status := &deployment.DeploymentStatus{
+		State:    "running",
+		Message:  stackStatus,
+		Metadata: map[string]string{"stack_status": stackStatus},
+	}
+	if strings.Contains(stackStatus, "IN_PROGRESS") {
+		status.State = "pending"
+	} else if strings.Contains(stackStatus, "FAILED") || strings.Contains(stackStatus, "ROLLBACK") {
+		status.State = "failed"
+	}
+
+	return status, nil
+}
+
+func (e *ECSTarget) generateStackTemplate(appName string, config *deployment.DeploymentConfig, secrets map[string]string, options deployment.DeployOptions) string {
+	var secretRefs strings.Builder
+	for key := range secrets {
+		secretRefs.WriteString(fmt.Sprintf(`            - Name: %s
+              ValueFrom: !Sub arn:aws:ssm:${AWS::Region}:${AWS::AccountId}:parameter/%s/%s
+`, key, appName, key))
+	}
+
+	cpu := "512"
+	memory := "1024"
+	if config.ResourceSize == "medium" {
+		cpu = "1024"
+		memory = "2048"
+	} else if config.ResourceSize == "large" {
+		cpu = "2048"
+		memory = "4096"
+	}
+
+	desiredCount := 1
+	if config.Replicas > 0 {
+		desiredCount = config.Replicas
+	}
+
+	persistence := e.generateEFSResources(appName)
+	volumeConfig := `      Volumes:
+        - Name: data
+          EFSVolumeConfiguration:
+            FilesystemId: !Ref FileSystem
+            TransitEncryption: ENABLED
+            AuthorizationConfig:
+              AccessPointId: !Ref AccessPoint
+              IAM: ENABLED
+`
+	mountPoints := `          MountPoints:
+            - SourceVolume: data
+              ContainerPath: /home/station/.config/station
+`
+	if options.Litestream {
+		// Litestream replicates the DB to S3, so the task can run on ephemeral
+		// storage without EFS; LITESTREAM_* settings come from SSM like any secret
+		persistence = ""
+		volumeConfig = ""
+		mountPoints = ""
+	}
+
+	return fmt.Sprintf(`AWSTemplateFormatVersion: "2010-09-09"
+Description: Station (%s) on ECS Fargate - generated by station-cli
+
+Parameters:
+  VpcId:
+    Type: AWS::EC2::VPC::Id
+  SubnetIds:
+    Type: List<AWS::EC2::Subnet::Id>
+
+Resources:
+  LogGroup:
+    Type: AWS::Logs::LogGroup
+    Properties:
+      LogGroupName: /ecs/%s
+      RetentionInDays: 30
+
+  Cluster:
+    Type: AWS::ECS::Cluster
+    Properties:
+      ClusterName: %s
+
+  TaskExecutionRole:
+    Type: AWS::IAM::Role
+    Properties:
+      RoleName: %s-execution
+      AssumeRolePolicyDocument:
+        Version: "2012-10-17"
+        Statement:
+          - Effect: Allow
+            Principal:
+              Service: ecs-tasks.amazonaws.com
+            Action: sts:AssumeRole
+      ManagedPolicyArns:
+        - arn:aws:iam::aws:policy/service-role/AmazonECSTaskExecutionRolePolicy
+      Policies:
+        - PolicyName: ssm-parameters
+          PolicyDocument:
+            Version: "2012-10-17"
+            Statement:
+              - Effect: Allow
+                Action:
+                  - ssm:GetParameters
+                Resource: !Sub arn:aws:ssm:${AWS::Region}:${AWS::AccountId}:parameter/%s/*
+
+  ServiceSecurityGroup:
+    Type: AWS::EC2::SecurityGroup
+    Properties:
+      GroupDescription: Station service
+      VpcId: !Ref VpcId
+      SecurityGroupIngress:
+        - IpProtocol: tcp
+          FromPort: 8586
+          ToPort: 8587
+          SourceSecurityGroupId: !Ref LoadBalancerSecurityGroup
+
+  LoadBalancerSecurityGroup:
+    Type: AWS::EC2::SecurityGroup
+    Properties:
+      GroupDescription: Station ALB
+      VpcId: !Ref VpcId
+      SecurityGroupIngress:
+        - IpProtocol: tcp
+          FromPort: 80
+          ToPort: 80
+          CidrIp: 0.0.0.0/0
+
+  LoadBalancer:
+    Type: AWS::ElasticLoadBalancingV2::LoadBalancer
+    Properties:
+      Name: %s
+      Scheme: internet-facing
+      Subnets: !Ref SubnetIds
+      SecurityGroups:
+        - !Ref LoadBalancerSecurityGroup
+
+  TargetGroup:
+    Type: AWS::ElasticLoadBalancingV2::TargetGroup
+    Properties:
+      VpcId: !Ref VpcId
+      Port: 8587
+      Protocol: HTTP
+      TargetType: ip
+      HealthCheckPath: /health
+
+  Listener:
+    Type: AWS::ElasticLoadBalancingV2::Listener
+    Properties:
+      LoadBalancerArn: !Ref LoadBalancer
+      Port: 80
+      Protocol: HTTP
+      DefaultActions:
+        - Type: forward
+          TargetGroupArn: !Ref TargetGroup
+
+%s  TaskDefinition:
+    Type: AWS::ECS::TaskDefinition
+    Properties:
+      Family: %s
+      Cpu: "%s"
+      Memory: "%s"
+      NetworkMode: awsvpc
+      RequiresCompatibilities:
+        - FARGATE
+      ExecutionRoleArn: !GetAtt TaskExecutionRole.Arn
+      ContainerDefinitions:
+        - Name: station
+          Image: %s
+          PortMappings:
+            - ContainerPort: 8586
+            - ContainerPort: 8587
+          Secrets:
+%s          LogConfiguration:
+            LogDriver: awslogs
+            Options:
+              awslogs-group: !Ref LogGroup
+              awslogs-region: !Ref AWS::Region
+              awslogs-stream-prefix: station
+%s%s
+  Service:
+    Type: AWS::ECS::Service
+    DependsOn: Listener
+    Properties:
+      ServiceName: %s
+      Cluster: !Ref Cluster
+      LaunchType: FARGATE
+      DesiredCount: %d
+      TaskDefinition: !Ref TaskDefinition
+      NetworkConfiguration:
+        AwsvpcConfiguration:
+          AssignPublicIp: ENABLED
+          Subnets: !Ref SubnetIds
+          SecurityGroups:
+            - !Ref ServiceSecurityGroup
+      LoadBalancers:
+        - ContainerName: station
+          ContainerPort: 8587
+          TargetGroupArn: !Ref TargetGroup
+
+Outputs:
+  LoadBalancerDNS:
+    Value: !GetAtt LoadBalancer.DNSName
+`, config.EnvironmentName, appName, appName, appName, appName, appName, persistence, appName, cpu, memory, config.DockerImage, secretRefs.String(), mountPoints, volumeConfig, appName, desiredCount)
+}
+
+// generateEFSResources produces the EFS filesystem, mount targets, and access
+// point used for SQLite persistence when Litestream mode is not enabled.
+func (e *ECSTarget) generateEFSResources(appName string) string {
+	return fmt.Sprintf(`  FileSystem:
+    Type: AWS::EFS::FileSystem
+    Properties:
+      Encrypted: true
+      FileSystemTags:
+        - Key: Name
+          Value: %s-data
+
+  MountTargetA:
+    Type: AWS::EFS::MountTarget
+    Properties:
+      FileSystemId: !Ref FileSystem
+      SubnetId: !Select [0, !Ref SubnetIds]
+      SecurityGroups:
+        - !Ref EFSSecurityGroup
+
+  MountTargetB:
+    Type: AWS::EFS::MountTarget
+    Properties:
+      FileSystemId: !Ref FileSystem
+      SubnetId: !Select [1, !Ref SubnetIds]
+      SecurityGroups:
+        - !Ref EFSSecurityGroup
+
+  EFSSecurityGroup:
+    Type: AWS::EC2::SecurityGroup
+    Properties:
+      GroupDescription: Station EFS access
+      VpcId: !Ref VpcId
+      SecurityGroupIngress:
+        - IpProtocol: tcp
+          FromPort: 2049
+          ToPort: 2049
+          SourceSecurityGroupId: !Ref ServiceSecurityGroup
+
+  AccessPoint:
+    Type: AWS::EFS::AccessPoint
+    Properties:
+      FileSystemId: !Ref FileSystem
+      PosixUser:
+        Uid: "1000"
+        Gid: "1000"
+      RootDirectory:
+        Path: /station
+        CreationInfo:
+          OwnerUid: "1000"
+          OwnerGid: "1000"
+          Permissions: "0755"
+
+`, appName)
+}
+
+func init() {
+	deployment.RegisterDeploymentTarget(NewECSTarget())
+}
@@ -0,0 +1,100 @@
+package targets
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"station/internal/deployment"
+)
+
+func TestECSTarget_Name(t *testing.T) {
+	target := NewECSTarget()
+	if target.Name() != "ecs" {
+		t.Errorf("expected name 'ecs', got '%s'", target.Name())
+	}
+}
+
+func TestECSTarget_GenerateConfig(t *testing.T) {
+	target := NewECSTarget()
+	ctx := context.Background()
+
+	config := &deployment.DeploymentConfig{
+		EnvironmentName: "test-env",
+		DockerImage:     "station:latest",
+		AIProvider:      "openai",
+		AIModel:         "gpt-4o-mini",
+	}
+
+	secrets := map[string]string{
+		"STN_AI_PROVIDER": "openai",
+		"STN_AI_API_KEY":  "sk-test-key",
+	}
+
+	files, err := target.GenerateConfig(ctx, config, secrets)
+	if err != nil {
+		t.Fatalf("GenerateConfig failed: %v", err)
+	}
+
+	stack, ok := files["ecs-stack.yaml"]
+	if !ok {
+		t.Fatal("expected file 'ecs-stack.yaml' not generated")
+	}
+
+	if !strings.Contains(stack, "station:latest") {
+		t.Error("stack should contain the docker image")
+	}
+	if !strings.Contains(stack, "AWS::ECS::TaskDefinition") {
+		t.Error("stack should contain a task definition")
+	}
+	if !strings.Contains(stack, "AWS::Logs::LogGroup") {
+		t.Error("stack should contain a log group")
+	}
+	if !strings.Contains(stack, "AWS::ElasticLoadBalancingV2::Listener") {
+		t.Error("stack should contain an ALB listener")
+	}
+	if !strings.Contains(stack, "parameter/station-test-env/STN_AI_API_KEY") {
+		t.Error("stack should reference secrets from SSM parameters")
+	}
+	if strings.Contains(stack, "sk-test-key") {
+		t.Error("stack must not contain secret values inline")
+	}
+	if !strings.Contains(stack, "AWS::EFS::FileSystem") {
+		t.Error("stack should use EFS for persistence by default")
+	}
+}
+
+func TestECSTarget_GenerateConfigWithLitestream(t *testing.T) {
+	target := NewECSTarget()
+	ctx := context.Background()
+
+	config := &deployment.DeploymentConfig{
+		EnvironmentName: "test-env",
+		DockerImage:     "station:latest",
+	}
+
+	files, err := target.GenerateConfigWithOptions(ctx, config, map[string]string{}, deployment.DeployOptions{
+		Litestream: true,
+	})
+	if err != nil {
+		t.Fatalf("GenerateConfigWithOptions failed: %v", err)
+	}
+
+	stack := files["ecs-stack.yaml"]
+	if strings.Contains(stack, "AWS::EFS::FileSystem") {
+		t.Error("stack should not provision EFS in Litestream mode")
+	}
+	if strings.Contains(stack, "EFSVolumeConfiguration") {
+		t.Error("task definition should not mount EFS in Litestream mode")
+	}
+}
+
+func TestECSTarget_Registration(t *testing.T) {
+	target, ok := deployment.GetDeploymentTarget("ecs")
+	if !ok {
+		t.Fatal("ecs target not registered")
+	}
+	if target.Name() != "ecs" {
+		t.Errorf("expected name 'ecs', got '%s'", target.Name())
+	}
+}
@@ -47,10 +47,20 @@ func (k *KubernetesTarget) GenerateConfigWithOptions(ctx context.Context, config
 	files["secret.yaml"] = k.generateSecret(appName, namespace, secrets)
 	files["deployment.yaml"] = k.generateDeployment(appName, namespace, config, secrets, options)
 	files["service.yaml"] = k.generateService(appName, namespace, config)
-	files["ingress.yaml"] = k.generateIngress(appName, namespace, config)
-	files["pvc.yaml"] = k.generatePVC(appName, namespace)
 	files["kustomization.yaml"] = k.generateKustomization(appName, options)
 
+	if options.IngressHost != "" {
+		files["ingress.yaml"] = k.generateIngress(appName, namespace, options.IngressHost)
+	}
+
+	if options.Litestream {
+		// Litestream replicates the SQLite DB to object storage, so the pod
+		// can run on ephemeral storage without a PVC
+		files["litestream-configmap.yaml"] = k.generateLitestreamConfigMap(appName, namespace)
+	} else {
+		files["pvc.yaml"] = k.generatePVC(appName, namespace)
+	}
+
 	if options.BundlePath != "" {
 		bundleConfigMap, err := k.generateBundleConfigMap(appName, namespace, options.BundlePath)
 		if err != nil {
@@ -221,6 +231,44 @@ func (k *KubernetesTarget) generateDeployment(appName, namespace string, config
 		replicas = config.Replicas
 	}
 
+	dataVolumeSource := fmt.Sprintf(`        persistentVolumeClaim:
+          claimName: %s-data
+`, appName)
+	initContainers := ""
+	litestreamSidecar := ""
+	litestreamVolume := ""
+
+	if options.Litestream {
+		dataVolumeSource = `        emptyDir: {}
+`
+		litestreamMounts := `        volumeMounts:
+        - name: data
+          mountPath: /home/station/.config/station
+        - name: litestream-config
+          mountPath: /etc/litestream.yml
+          subPath: litestream.yml
+`
+		initContainers = fmt.Sprintf(`      initContainers:
+      - name: litestream-restore
+        image: litestream/litestream:0.3
+        args: ["restore", "-if-db-not-exists", "-if-replica-exists", "-config", "/etc/litestream.yml", "/home/station/.config/station/station.db"]
+        envFrom:
+        - secretRef:
+            name: %s-secrets
+%s`, appName, litestreamMounts)
+		litestreamSidecar = fmt.Sprintf(`      - name: litestream
+        image: litestream/litestream:0.3
+        args: ["replicate", "-config", "/etc/litestream.yml"]
+        envFrom:
+        - secretRef:
+            name: %s-secrets
+%s`, appName, litestreamMounts)
+		litestreamVolume = fmt.Sprintf(`      - name: litestream-config
+        configMap:
+          name: %s-litestream
+`, appName)
+	}
+
 	bundleVolumeMount := ""
 	bundleVolume := ""
 	commandOverride := ""
@@ -261,7 +309,7 @@ spec:
       labels:
         app: %s
     spec:
-      containers:
+%s      containers:
       - name: station
         image: %s
         imagePullPolicy: IfNotPresent
@@ -294,11 +342,9 @@ spec:
             port: 8587
           initialDelaySeconds: 10
           periodSeconds: 5
-      volumes:
+%s      volumes:
       - name: data
-        persistentVolumeClaim:
-          claimName: %s-data
-%s`, appName, namespace, appName, replicas, appName, appName, config.DockerImage, commandOverride, envVars.String(), cpu, memory, cpu, memory, bundleVolumeMount, appName, bundleVolume)
+%s%s%s`, appName, namespace, appName, replicas, appName, appName, initContainers, config.DockerImage, commandOverride, envVars.String(), cpu, memory, cpu, memory, bundleVolumeMount, litestreamSidecar, dataVolumeSource, bundleVolume, litestreamVolume)
 }
 
 func (k *KubernetesTarget) generateService(appName, namespace string, config *deployment.DeploymentConfig) string {
@@ -321,7 +367,7 @@ spec:
 `, appName, namespace, appName)
 }
 
-func (k *KubernetesTarget) generateIngress(appName, namespace string, config *deployment.DeploymentConfig) string {
+func (k *KubernetesTarget) generateIngress(appName, namespace, host string) string {
 	return fmt.Sprintf(`apiVersion: networking.k8s.io/v1
 kind: Ingress
 metadata:
@@ -334,7 +380,7 @@ metadata:
 spec:
   ingressClassName: nginx
   rules:
-  - host: %s.example.com
+  - host: %s
     http:
       paths:
       - path: /
@@ -351,7 +397,7 @@ spec:
             name: %s
             port:
               number: 8586
-`, appName, namespace, appName, appName, appName)
+`, appName, namespace, host, appName, appName)
 }
 
 func (k *KubernetesTarget) generatePVC(appName, namespace string) string {
@@ -369,13 +415,48 @@ spec:
 `, appName, namespace)
 }
 
+func (k *KubernetesTarget) generateLitestreamConfigMap(appName, namespace string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s-litestream
+  namespace: %s
+data:
+  litestream.yml: |
+    # Litestream replication for Station's SQLite database
+    # Set LITESTREAM_S3_* keys in the %s-secrets Secret
+    dbs:
+      - path: /home/station/.config/station/station.db
+        replicas:
+          - type: s3
+            bucket: ${LITESTREAM_S3_BUCKET}
+            path: %s-db
+            region: ${LITESTREAM_S3_REGION}
+            access-key-id: ${LITESTREAM_S3_ACCESS_KEY_ID}
+            secret-access-key: ${LITESTREAM_S3_SECRET_ACCESS_KEY}
+            sync-interval: 10s
+            retention: 24h
+`, appName, namespace, appName, appName)
+}
+
 func (k *KubernetesTarget) generateKustomization(appName string, options deployment.DeployOptions) string {
 	resources := `- namespace.yaml
 - secret.yaml
-- pvc.yaml
 - deployment.yaml
-- service.yaml
+- service.yaml`
+
+	if options.Litestream {
+		resources += `
+- litestream-configmap.yaml`
+	} else {
+		resources += `
+- pvc.yaml`
+	}
+
+	if options.IngressHost != "" {
+		resources += `
 - ingress.yaml`
+	}
 
 	if options.BundlePath != "" {
 		resources += `
@@ -43,7 +43,6 @@ func TestKubernetesTarget_GenerateConfig(t *testing.T) {
 		"secret.yaml",
 		"deployment.yaml",
 		"service.yaml",
-		"ingress.yaml",
 		"pvc.yaml",
 		"kustomization.yaml",
 	}
@@ -54,6 +53,10 @@ func TestKubernetesTarget_GenerateConfig(t *testing.T) {
 		}
 	}
 
+	if _, ok := files["ingress.yaml"]; ok {
+		t.Error("ingress.yaml should not be generated without an ingress host")
+	}
+
 	deployment := files["deployment.yaml"]
 	if !strings.Contains(deployment, "station:latest") {
 		t.Error("deployment.yaml should contain the docker image")
@@ -68,6 +71,71 @@ func TestKubernetesTarget_GenerateConfig(t *testing.T) {
 	}
 }
 
+func TestKubernetesTarget_GenerateConfigWithIngress(t *testing.T) {
+	target := NewKubernetesTarget()
+	ctx := context.Background()
+
+	config := &deployment.DeploymentConfig{
+		EnvironmentName: "test-env",
+		DockerImage:     "station:latest",
+		Namespace:       "station-test",
+	}
+
+	files, err := target.GenerateConfigWithOptions(ctx, config, map[string]string{}, deployment.DeployOptions{
+		IngressHost: "station.example.org",
+	})
+	if err != nil {
+		t.Fatalf("GenerateConfigWithOptions failed: %v", err)
+	}
+
+	ingress, ok := files["ingress.yaml"]
+	if !ok {
+		t.Fatal("ingress.yaml should be generated when an ingress host is set")
+	}
+	if !strings.Contains(ingress, "host: station.example.org") {
+		t.Error("ingress.yaml should use the configured host")
+	}
+	if !strings.Contains(files["kustomization.yaml"], "ingress.yaml") {
+		t.Error("kustomization.yaml should include ingress.yaml")
+	}
+}
+
+func TestKubernetesTarget_GenerateConfigWithLitestream(t *testing.T) {
+	target := NewKubernetesTarget()
+	ctx := context.Background()
+
+	config := &deployment.DeploymentConfig{
+		EnvironmentName: "test-env",
+		DockerImage:     "station:latest",
+		Namespace:       "station-test",
+	}
+
+	files, err := target.GenerateConfigWithOptions(ctx, config, map[string]string{}, deployment.DeployOptions{
+		Litestream: true,
+	})
+	if err != nil {
+		t.Fatalf("GenerateConfigWithOptions failed: %v", err)
+	}
+
+	if _, ok := files["pvc.yaml"]; ok {
+		t.Error("pvc.yaml should not be generated in Litestream mode")
+	}
+	if _, ok := files["litestream-configmap.yaml"]; !ok {
+		t.Fatal("litestream-configmap.yaml should be generated in Litestream mode")
+	}
+
+	deploymentYAML := files["deployment.yaml"]
+	if !strings.Contains(deploymentYAML, "name: litestream") {
+		t.Error("deployment.yaml should contain the Litestream sidecar")
+	}
+	if !strings.Contains(deploymentYAML, "litestream-restore") {
+		t.Error("deployment.yaml should contain the Litestream restore init container")
+	}
+	if !strings.Contains(deploymentYAML, "emptyDir: {}") {
+		t.Error("deployment.yaml should use an emptyDir data volume in Litestream mode")
+	}
+}
+
 func TestKubernetesTarget_Registration(t *testing.T) {
 	target, ok := deployment.GetDeploymentTarget("kubernetes")
 	if !ok {
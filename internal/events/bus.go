@@ -0,0 +1,167 @@
+// Package events provides a lightweight in-process event bus that publishes
+// structured execution events (run lifecycle, tool calls, workflow
+// transitions, sync results) to consumers declared in the Station config.
+// Publishing is non-blocking: when the buffer is full events are dropped
+// rather than slowing down agent execution.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"station/internal/logging"
+)
+
+// EventType identifies the kind of event flowing through the bus
+type EventType string
+
+const (
+	RunStarted         EventType = "run.started"
+	RunStep            EventType = "run.step"
+	RunToolCall        EventType = "run.tool_call"
+	RunCompleted       EventType = "run.completed"
+	WorkflowTransition EventType = "workflow.transition"
+	SyncCompleted      EventType = "sync.completed"
+)
+
+// Event is the structured document delivered to every consumer
+type Event struct {
+	Type      EventType              `json:"type"`
+	Timestamp time.Time              `json:"timestamp"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+}
+
+// Consumer receives events published on the bus. Consume errors are logged
+// and do not affect other consumers or the publisher.
+type Consumer interface {
+	// Name identifies the consumer in logs
+	Name() string
+	// Consume delivers a single event
+	Consume(event Event) error
+}
+
+const defaultBufferSize = 256
+
+// Bus fans events out to its consumers from a single dispatcher goroutine
+type Bus struct {
+	ch        chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu        sync.RWMutex
+	consumers []Consumer
+}
+
+// NewBus creates a bus and starts its dispatcher goroutine
+func NewBus(buffer int) *Bus {
+	if buffer <= 0 {
+		buffer = defaultBufferSize
+	}
+	b := &Bus{
+		ch:   make(chan Event, buffer),
+		done: make(chan struct{}),
+	}
+	go b.dispatch()
+	return b
+}
+
+// AddConsumer registers a consumer for all subsequently published events
+func (b *Bus) AddConsumer(c Consumer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consumers = append(b.consumers, c)
+	logging.Info("Registered event consumer: %s", c.Name())
+}
+
+// Publish enqueues an event for delivery. Never blocks: the event is dropped
+// (with a debug log) when the buffer is full.
+func (b *Bus) Publish(eventType EventType, payload map[string]interface{}) {
+	event := Event{Type: eventType, Timestamp: time.Now().UTC(), Payload: payload}
+	select {
+	case b.ch <- event:
+	default:
+		logging.Debug("Event bus buffer full, dropping %s event", eventType)
+	}
+}
+
+func (b *Bus) dispatch() {
+	for {
+		select {
+		case event := <-b.ch:
+			b.deliver(event)
+		case <-b.done:
+			// Drain anything already buffered before exiting
+			for {
+				select {
+				case event := <-b.ch:
+					b.deliver(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b *Bus) deliver(event Event) {
+	b.mu.RLock()
+	consumers := make([]Consumer, len(b.consumers))
+	copy(consumers, b.consumers)
+	b.mu.RUnlock()
+
+	for _, c := range consumers {
+		if err := c.Consume(event); err != nil {
+			logging.Debug("Event consumer %s failed for %s: %v", c.Name(), event.Type, err)
+		}
+	}
+}
+
+// Close stops the dispatcher after draining buffered events
+func (b *Bus) Close() {
+	b.closeOnce.Do(func() { close(b.done) })
+}
+
+// The default bus is wired from config at engine startup. Publish is a no-op
+// until at least one consumer registers, so instrumented call sites stay
+// cheap when the bus is unconfigured.
+var (
+	defaultMu  sync.RWMutex
+	defaultBus *Bus
+)
+
+// Register adds consumers to the process-wide default bus, creating it on
+// first use
+func Register(consumers ...Consumer) {
+	if len(consumers) == 0 {
+		return
+	}
+	defaultMu.Lock()
+	if defaultBus == nil {
+		defaultBus = NewBus(defaultBufferSize)
+	}
+	bus := defaultBus
+	defaultMu.Unlock()
+
+	for _, c := range consumers {
+		bus.AddConsumer(c)
+	}
+}
+
+// Enabled reports whether any consumer is registered on the default bus
+func Enabled() bool {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultBus != nil
+}
+
+// Publish sends an event on the default bus; no-op when no consumers are
+// configured
+func Publish(eventType EventType, payload map[string]interface{}) {
+	defaultMu.RLock()
+	bus := defaultBus
+	defaultMu.RUnlock()
+	if bus == nil {
+		return
+	}
+	bus.Publish(eventType, payload)
+}
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"station/internal/config"
+)
+
+type recordingConsumer struct {
+	name   string
+	events chan Event
+}
+
+func (r *recordingConsumer) Name() string { return r.name }
+
+func (r *recordingConsumer) Consume(event Event) error {
+	r.events <- event
+	return nil
+}
+
+func TestBusDeliversToAllConsumers(t *testing.T) {
+	bus := NewBus(8)
+	defer bus.Close()
+
+	first := &recordingConsumer{name: "first", events: make(chan Event, 1)}
+	second := &recordingConsumer{name: "second", events: make(chan Event, 1)}
+	bus.AddConsumer(first)
+	bus.AddConsumer(second)
+
+	bus.Publish(RunStarted, map[string]interface{}{"run_id": int64(42)})
+
+	for _, consumer := range []*recordingConsumer{first, second} {
+		select {
+		case event := <-consumer.events:
+			if event.Type != RunStarted {
+				t.Errorf("consumer %s got type %q, want %q", consumer.name, event.Type, RunStarted)
+			}
+			if event.Timestamp.IsZero() {
+				t.Errorf("consumer %s got zero timestamp", consumer.name)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("consumer %s did not receive the event", consumer.name)
+		}
+	}
+}
+
+func TestBusDropsWhenBufferFull(t *testing.T) {
+	// A bus with no consumers and a tiny buffer must never block the publisher
+	bus := NewBus(1)
+	defer bus.Close()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			bus.Publish(RunStep, nil)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish blocked with a full buffer")
+	}
+}
+
+func TestJSONLConsumer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	consumer, err := NewConsumerFromConfig(config.EventConsumerConfig{Type: "jsonl", Path: path})
+	if err != nil {
+		t.Fatalf("NewConsumerFromConfig failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		event := Event{Type: RunStep, Timestamp: time.Now().UTC(), Payload: map[string]interface{}{"n": i}}
+		if err := consumer.Consume(event); err != nil {
+			t.Fatalf("Consume failed: %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open event log: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lines := 0
+	for scanner.Scan() {
+		lines++
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", lines, err)
+		}
+		if event.Type != RunStep {
+			t.Errorf("line %d: type = %q, want %q", lines, event.Type, RunStep)
+		}
+	}
+	if lines != 3 {
+		t.Errorf("got %d JSONL records, want 3", lines)
+	}
+}
+
+func TestNewConsumerFromConfigValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.EventConsumerConfig
+	}{
+		{"unknown type", config.EventConsumerConfig{Type: "pubsub"}},
+		{"webhook without url", config.EventConsumerConfig{Type: "webhook"}},
+		{"nats without subject", config.EventConsumerConfig{Type: "nats"}},
+		{"kafka without brokers", config.EventConsumerConfig{Type: "kafka", Topic: "events"}},
+		{"jsonl without path", config.EventConsumerConfig{Type: "jsonl"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewConsumerFromConfig(tt.cfg); err == nil {
+				t.Error("expected an error for invalid consumer config")
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	kafka "github.com/segmentio/kafka-go"
+
+	"station/internal/config"
+)
+
+const consumerTimeout = 10 * time.Second
+
+// NewConsumerFromConfig builds a single consumer from its config declaration.
+// Supported types: "webhook" (POST each event as JSON), "nats" (publish to a
+// subject), "kafka" (produce to a topic), and "jsonl" (append to a local
+// file, one event per line).
+func NewConsumerFromConfig(cfg config.EventConsumerConfig) (Consumer, error) {
+	switch cfg.Type {
+	case "webhook":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("webhook event consumer requires a url")
+		}
+		return newWebhookConsumer(cfg), nil
+	case "nats":
+		if cfg.Subject == "" {
+			return nil, fmt.Errorf("nats event consumer requires a subject")
+		}
+		return newNATSConsumer(cfg)
+	case "kafka":
+		if len(cfg.Brokers) == 0 || cfg.Topic == "" {
+			return nil, fmt.Errorf("kafka event consumer requires brokers and a topic")
+		}
+		return newKafkaConsumer(cfg), nil
+	case "jsonl":
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("jsonl event consumer requires a path")
+		}
+		return newJSONLConsumer(cfg)
+	default:
+		return nil, fmt.Errorf("unknown event consumer type %q (expected webhook, nats, kafka, or jsonl)", cfg.Type)
+	}
+}
+
+// consumerName prefers the configured display name, falling back to the type
+func consumerName(cfg config.EventConsumerConfig) string {
+	if cfg.Name != "" {
+		return cfg.Name
+	}
+	return cfg.Type
+}
+
+// webhookConsumer POSTs each event as a JSON document to an HTTP endpoint
+type webhookConsumer struct {
+	name   string
+	url    string
+	client *http.Client
+}
+
+func newWebhookConsumer(cfg config.EventConsumerConfig) *webhookConsumer {
+	return &webhookConsumer{
+		name:   consumerName(cfg),
+		url:    cfg.URL,
+		client: &http.Client{Timeout: consumerTimeout},
+	}
+}
+
+func (c *webhookConsumer) Name() string { return c.name }
+
+func (c *webhookConsumer) Consume(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// natsConsumer publishes each event as JSON on a NATS subject
+type natsConsumer struct {
+	name    string
+	conn    *nats.Conn
+	subject string
+}
+
+func newNATSConsumer(cfg config.EventConsumerConfig) (*natsConsumer, error) {
+	url := cfg.URL
+	if url == "" {
+		url = nats.DefaultURL
+	}
+	conn, err := nats.Connect(url, nats.Name("station-events"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", url, err)
+	}
+	return &natsConsumer{name: consumerName(cfg), conn: conn, subject: cfg.Subject}, nil
+}
+
+func (c *natsConsumer) Name() string { return c.name }
+
+func (c *natsConsumer) Consume(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return c.conn.Publish(c.subject, payload)
+}
+
+// kafkaConsumer produces each event as JSON to a Kafka topic, keyed by event
+// type so consumers can partition on it
+type kafkaConsumer struct {
+	name   string
+	writer *kafka.Writer
+}
+
+func newKafkaConsumer(cfg config.EventConsumerConfig) *kafkaConsumer {
+	return &kafkaConsumer{
+		name: consumerName(cfg),
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(cfg.Brokers...),
+			Topic:                  cfg.Topic,
+			Balancer:               &kafka.LeastBytes{},
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+func (c *kafkaConsumer) Name() string { return c.name }
+
+func (c *kafkaConsumer) Consume(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), consumerTimeout)
+	defer cancel()
+
+	return c.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.Type),
+		Value: payload,
+	})
+}
+
+// jsonlConsumer appends each event as one JSON line to a local file, giving a
+// zero-dependency audit trail of everything the bus publishes
+type jsonlConsumer struct {
+	name string
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newJSONLConsumer(cfg config.EventConsumerConfig) (*jsonlConsumer, error) {
+	if dir := filepath.Dir(cfg.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create event log directory: %w", err)
+		}
+	}
+	file, err := os.OpenFile(cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log file: %w", err)
+	}
+	return &jsonlConsumer{name: consumerName(cfg), file: file}, nil
+}
+
+func (c *jsonlConsumer) Name() string { return c.name }
+
+func (c *jsonlConsumer) Consume(event Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return json.NewEncoder(c.file).Encode(event)
+}
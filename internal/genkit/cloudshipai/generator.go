@@ -210,6 +210,15 @@ func (g *Generator) applyConfig(params *openai.ChatCompletionNewParams, config a
 		if topP, ok := configMap["topP"].(float64); ok {
 			params.TopP = openai.Float(topP)
 		}
+		switch seed := configMap["seed"].(type) {
+		case int64:
+			params.Seed = openai.Int(seed)
+		case float64:
+			params.Seed = openai.Int(int64(seed))
+		}
+		if toolChoice, ok := configMap["toolChoice"].(string); ok && toolChoice != "" {
+			params.ToolChoice = openai.ChatCompletionToolChoiceOptionUnionParam{OfAuto: openai.String(toolChoice)}
+		}
 	}
 }
 
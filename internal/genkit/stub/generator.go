@@ -0,0 +1,75 @@
+package stub
+
+import (
+	"context"
+
+	"github.com/firebase/genkit/go/ai"
+)
+
+// Generator produces deterministic responses without any API calls
+type Generator struct {
+	modelID string
+	script  *Script
+}
+
+// NewGenerator creates a new Generator instance
+func NewGenerator(modelID string, script *Script) *Generator {
+	return &Generator{modelID: modelID, script: script}
+}
+
+// Generate resolves the scripted response for the latest user message. When a
+// streaming callback is provided the response is delivered as a single chunk.
+func (g *Generator) Generate(ctx context.Context, req *ai.ModelRequest, cb func(context.Context, *ai.ModelResponseChunk) error) (*ai.ModelResponse, error) {
+	task := latestUserText(req)
+	text := g.script.Respond(task, g.modelID)
+
+	if cb != nil {
+		chunk := &ai.ModelResponseChunk{
+			Content: []*ai.Part{ai.NewTextPart(text)},
+		}
+		if err := cb(ctx, chunk); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ai.ModelResponse{
+		Request: req,
+		Message: &ai.Message{
+			Role:    ai.RoleModel,
+			Content: []*ai.Part{ai.NewTextPart(text)},
+		},
+		Usage: &ai.GenerationUsage{
+			InputTokens:  estimateTokens(task),
+			OutputTokens: estimateTokens(text),
+			TotalTokens:  estimateTokens(task) + estimateTokens(text),
+		},
+		FinishReason: ai.FinishReasonStop,
+	}, nil
+}
+
+// latestUserText extracts the text of the most recent user message, which is
+// what scripted patterns match against
+func latestUserText(req *ai.ModelRequest) string {
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		msg := req.Messages[i]
+		if msg.Role != ai.RoleUser {
+			continue
+		}
+		var text string
+		for _, part := range msg.Content {
+			if part.IsText() {
+				text += part.Text
+			}
+		}
+		if text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// estimateTokens approximates token usage at ~4 characters per token so run
+// metadata stays populated in offline mode
+func estimateTokens(text string) int {
+	return len(text) / 4
+}
@@ -0,0 +1,96 @@
+// Package stub provides a deterministic GenKit plugin for offline development.
+// The stub provider returns canned or template-driven completions without any
+// network calls, so `stn develop`, unit tests, and demos work with zero API
+// cost. Responses can be scripted per prompt pattern from a YAML file (see
+// Script) pointed to by the STN_STUB_RESPONSES environment variable.
+package stub
+
+import (
+	"context"
+	"log"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/core/api"
+)
+
+const (
+	ProviderName = "stub"
+
+	// DefaultModel is always registered so `provider: stub` works without a
+	// model configured
+	DefaultModel = "stub"
+)
+
+// stubModelOptions describes what the stub model claims to support. Tools are
+// advertised so agents with assigned tools still initialize, though the stub
+// never emits tool calls.
+var stubModelOptions = ai.ModelOptions{
+	Label: "Station Stub (deterministic, offline)",
+	Supports: &ai.ModelSupports{
+		Multiturn:  true,
+		Tools:      true,
+		SystemRole: true,
+		Media:      false,
+		ToolChoice: true,
+	},
+}
+
+// Stub is a GenKit plugin that serves deterministic completions
+type Stub struct {
+	// Models lists additional model names to register (the configured
+	// ai_model); DefaultModel is always registered
+	Models []string
+
+	// ScriptPath optionally points to a YAML script of per-pattern responses
+	ScriptPath string
+
+	script      *Script
+	initialized bool
+}
+
+// Name implements genkit.Plugin
+func (s *Stub) Name() string {
+	return ProviderName
+}
+
+// Init implements genkit.Plugin - loads the response script and registers models
+func (s *Stub) Init(ctx context.Context) []api.Action {
+	if s.initialized {
+		return nil
+	}
+
+	if s.ScriptPath != "" {
+		script, err := LoadScript(s.ScriptPath)
+		if err != nil {
+			log.Printf("[Stub] Failed to load response script %s: %v (using built-in responses)", s.ScriptPath, err)
+		} else {
+			log.Printf("[Stub] Loaded %d scripted responses from %s", len(script.Responses), s.ScriptPath)
+			s.script = script
+		}
+	}
+	s.initialized = true
+
+	registered := map[string]bool{}
+	var actions []api.Action
+	for _, modelID := range append([]string{DefaultModel}, s.Models...) {
+		if modelID == "" || registered[modelID] {
+			continue
+		}
+		registered[modelID] = true
+		model := s.DefineModel(modelID, stubModelOptions)
+		actions = append(actions, model.(api.Action))
+	}
+	return actions
+}
+
+// DefineModel creates a GenKit model backed by the deterministic generator
+func (s *Stub) DefineModel(modelID string, opts ai.ModelOptions) ai.Model {
+	return ai.NewModel(
+		api.NewName(ProviderName, modelID),
+		&opts,
+		func(ctx context.Context, req *ai.ModelRequest, cb func(context.Context, *ai.ModelResponseChunk) error) (*ai.ModelResponse, error) {
+			generator := NewGenerator(modelID, s.script)
+			return generator.Generate(ctx, req, cb)
+		},
+	)
+}
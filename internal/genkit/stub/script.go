@@ -0,0 +1,93 @@
+package stub
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ResponseRule maps a prompt pattern to a templated response. Pattern is a
+// regular expression matched against the latest user message; Response is a
+// Go template rendered with {{.Task}} (the matched message) and {{.Model}}.
+type ResponseRule struct {
+	Pattern  string `yaml:"pattern"`
+	Response string `yaml:"response"`
+
+	compiled *regexp.Regexp
+}
+
+// Script is a YAML-scripted set of stub responses:
+//
+//	default: "All done: {{ .Task }}"
+//	responses:
+//	  - pattern: "(?i)deploy"
+//	    response: "Deployment simulated for {{ .Model }}"
+type Script struct {
+	Default   string         `yaml:"default"`
+	Responses []ResponseRule `yaml:"responses"`
+}
+
+// LoadScript reads and validates a response script from a YAML file
+func LoadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+
+	var script Script
+	if err := yaml.Unmarshal(data, &script); err != nil {
+		return nil, fmt.Errorf("failed to parse script: %w", err)
+	}
+
+	for i := range script.Responses {
+		rule := &script.Responses[i]
+		compiled, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", rule.Pattern, err)
+		}
+		rule.compiled = compiled
+	}
+	return &script, nil
+}
+
+// responseData is the template context for scripted responses
+type responseData struct {
+	Task  string
+	Model string
+}
+
+// Respond resolves the response for a task: the first matching rule wins,
+// then the script default, then a built-in deterministic completion.
+func (s *Script) Respond(task, model string) string {
+	data := responseData{Task: task, Model: model}
+
+	if s != nil {
+		for _, rule := range s.Responses {
+			if rule.compiled.MatchString(task) {
+				return renderResponse(rule.Response, data)
+			}
+		}
+		if s.Default != "" {
+			return renderResponse(s.Default, data)
+		}
+	}
+	return fmt.Sprintf("Stub completion for task: %s", task)
+}
+
+// renderResponse renders a response template, falling back to the raw text
+// when it fails to parse or execute
+func renderResponse(text string, data responseData) string {
+	tmpl, err := template.New("stub-response").Parse(text)
+	if err != nil {
+		return text
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return text
+	}
+	return out.String()
+}
@@ -0,0 +1,99 @@
+package stub
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/firebase/genkit/go/ai"
+)
+
+func writeScript(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "responses.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	return path
+}
+
+func TestLoadScriptAndRespond(t *testing.T) {
+	path := writeScript(t, `
+default: "Default answer for {{ .Task }}"
+responses:
+  - pattern: "(?i)deploy"
+    response: "Deployment simulated on {{ .Model }}"
+  - pattern: "scan"
+    response: "No issues found"
+`)
+
+	script, err := LoadScript(path)
+	if err != nil {
+		t.Fatalf("LoadScript failed: %v", err)
+	}
+
+	if got := script.Respond("please DEPLOY the service", "stub"); got != "Deployment simulated on stub" {
+		t.Errorf("expected deploy rule to match, got %q", got)
+	}
+	if got := script.Respond("scan the repo", "stub"); got != "No issues found" {
+		t.Errorf("expected scan rule to match, got %q", got)
+	}
+	if got := script.Respond("something else", "stub"); got != "Default answer for something else" {
+		t.Errorf("expected templated default, got %q", got)
+	}
+}
+
+func TestLoadScriptInvalidPattern(t *testing.T) {
+	path := writeScript(t, `
+responses:
+  - pattern: "["
+    response: "never"
+`)
+	if _, err := LoadScript(path); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestRespondWithoutScript(t *testing.T) {
+	var script *Script
+	if got := script.Respond("do the thing", "stub"); got != "Stub completion for task: do the thing" {
+		t.Errorf("expected built-in completion, got %q", got)
+	}
+}
+
+func TestGeneratorGenerate(t *testing.T) {
+	script := &Script{Default: "Scripted: {{ .Task }}"}
+	generator := NewGenerator("stub", script)
+
+	req := &ai.ModelRequest{
+		Messages: []*ai.Message{
+			{Role: ai.RoleSystem, Content: []*ai.Part{ai.NewTextPart("You are a stub")}},
+			{Role: ai.RoleUser, Content: []*ai.Part{ai.NewTextPart("audit the repo")}},
+		},
+	}
+
+	var streamed string
+	resp, err := generator.Generate(context.Background(), req, func(ctx context.Context, chunk *ai.ModelResponseChunk) error {
+		for _, part := range chunk.Content {
+			streamed += part.Text
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if resp.FinishReason != ai.FinishReasonStop {
+		t.Errorf("expected stop finish reason, got %s", resp.FinishReason)
+	}
+	if got := resp.Message.Content[0].Text; got != "Scripted: audit the repo" {
+		t.Errorf("expected scripted response, got %q", got)
+	}
+	if streamed != "Scripted: audit the repo" {
+		t.Errorf("expected response streamed as one chunk, got %q", streamed)
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens == 0 {
+		t.Errorf("expected estimated token usage, got %+v", resp.Usage)
+	}
+}
@@ -37,8 +37,20 @@ type LighthouseClient struct {
 	runBuffer    chan *proto.SendRunRequest
 	healthBuffer chan *proto.SystemHealthRequest
 
+	// Incremental run update streaming (SendRunStream)
+	streamBuffer   chan *proto.SendRunRequest
+	runStream      proto.LighthouseService_SendRunStreamClient
+	streamMu       sync.Mutex
+	streamInFlight int32
+
 	// Reconnection callback - called when heartbeat is rejected indicating ManagementChannel is dead
 	onReconnectNeeded func()
+
+	// Org settings pushed by CloudShip at registration (quota limits etc.)
+	// and the handler that applies them locally; registration can complete
+	// before the handler is wired, so the last push is kept for replay
+	pushedOrgSettings  map[string]string
+	orgSettingsHandler func(map[string]string)
 }
 
 // NewLighthouseClient creates a new Lighthouse client
@@ -72,6 +84,7 @@ func NewLighthouseClient(config *LighthouseConfig, mode DeploymentMode) (*Lighth
 		cancel:       cancel,
 		runBuffer:    make(chan *proto.SendRunRequest, config.BufferSize),
 		healthBuffer: make(chan *proto.SystemHealthRequest, 10),
+		streamBuffer: make(chan *proto.SendRunRequest, config.BufferSize),
 	}
 
 	// Attempt connection
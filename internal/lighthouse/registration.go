@@ -42,8 +42,20 @@ func (lc *LighthouseClient) register() error {
 	lc.mu.Lock()
 	lc.registered = true
 	lc.stationID = resp.StationId
+	var orgSettings map[string]string
+	var orgSettingsHandler func(map[string]string)
+	if resp.CloudshipConfig != nil && len(resp.CloudshipConfig.Settings) > 0 {
+		lc.pushedOrgSettings = resp.CloudshipConfig.Settings
+		orgSettings = resp.CloudshipConfig.Settings
+		orgSettingsHandler = lc.orgSettingsHandler
+	}
 	lc.mu.Unlock()
 
+	// Apply org settings pushed by CloudShip (e.g. quota limits) outside the lock
+	if orgSettingsHandler != nil && orgSettings != nil {
+		orgSettingsHandler(orgSettings)
+	}
+
 	// Update global lighthouse status
 	SetConnected(true, lc.config.Endpoint)
 	SetRegistered(true, lc.config.RegistrationKey)
@@ -53,6 +65,23 @@ func (lc *LighthouseClient) register() error {
 	return nil
 }
 
+// SetOrgSettingsHandler registers the callback applying org settings pushed
+// by CloudShip (quota limits etc.). If registration already delivered
+// settings before the handler was wired, they are replayed immediately.
+func (lc *LighthouseClient) SetOrgSettingsHandler(handler func(map[string]string)) {
+	if lc == nil {
+		return
+	}
+	lc.mu.Lock()
+	lc.orgSettingsHandler = handler
+	pending := lc.pushedOrgSettings
+	lc.mu.Unlock()
+
+	if handler != nil && pending != nil {
+		handler(pending)
+	}
+}
+
 // IsRegistered returns true if successfully registered with CloudShip
 func (lc *LighthouseClient) IsRegistered() bool {
 	if lc == nil {
@@ -0,0 +1,188 @@
+package lighthouse
+
+import (
+	"sync/atomic"
+	"time"
+
+	"station/internal/lighthouse/proto"
+	"station/internal/logging"
+	"station/pkg/types"
+)
+
+// maxInFlightRunUpdates bounds the number of unacknowledged stream updates.
+// Progress updates are lossy by design - when CloudShip falls behind we drop
+// updates rather than backpressure agent execution.
+const maxInFlightRunUpdates = 32
+
+// streamReconnectBackoffMax caps the exponential backoff between attempts to
+// re-establish a failed SendRunStream
+const streamReconnectBackoffMax = 30 * time.Second
+
+// RunUpdate carries an incremental progress event for an in-flight agent run.
+// Updates stream over the existing SendRunStream gRPC channel so CloudShip
+// dashboards show progress before the run completes; the final SendRun still
+// reports the complete record.
+type RunUpdate struct {
+	RunID      string // Run UUID (matches the final SendRun run ID)
+	AgentID    string
+	AgentName  string
+	Task       string
+	Status     string // running, completed, failed, cancelled, timed_out
+	Steps      []types.ExecutionStep
+	TokenUsage *types.TokenUsage
+	StartedAt  time.Time
+}
+
+// StreamRunUpdate enqueues an incremental run progress update for streaming
+// to CloudShip (async, lossy). No-op when CloudShip integration is not
+// configured or the client is disconnected.
+func (lc *LighthouseClient) StreamRunUpdate(update *RunUpdate, environment string, labels map[string]string) {
+	if lc == nil || update == nil || !lc.IsConnected() {
+		return
+	}
+	if !lc.IsRegistered() && lc.mode != ModeServe {
+		return
+	}
+
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels["update_type"] = "progress"
+
+	req := &proto.SendRunRequest{
+		RegistrationKey: lc.config.RegistrationKey,
+		Environment:     environment,
+		Mode:            convertDeploymentModeToProto(lc.mode),
+		Source:          proto.RunSource_RUN_SOURCE_ANALYTICS,
+		RunData: &proto.LighthouseAgentRunData{
+			RunId:          update.RunID,
+			AgentId:        update.AgentID,
+			AgentName:      update.AgentName,
+			Task:           update.Task,
+			ExecutionSteps: convertExecutionStepsToProto(update.Steps),
+			TokenUsage:     convertTokenUsageToLighthouseProto(update.TokenUsage),
+			Status:         convertRunStatusToLighthouseProto(update.Status),
+			StartedAt:      timestampFromTime(update.StartedAt),
+			StationVersion: "v0.11.0",
+		},
+		Labels: labels,
+	}
+
+	// Non-blocking enqueue - drop when the buffer is full
+	select {
+	case lc.streamBuffer <- req:
+	default:
+		logging.Debug("Lighthouse stream buffer full, dropping run update (run_id: %s)", update.RunID)
+	}
+}
+
+// runStreamWorker drains the stream buffer onto a long-lived SendRunStream,
+// re-establishing the stream with exponential backoff when it fails
+func (lc *LighthouseClient) runStreamWorker() {
+	defer lc.wg.Done()
+
+	backoff := time.Second
+	for {
+		select {
+		case <-lc.ctx.Done():
+			lc.closeRunStream()
+			return
+		case req := <-lc.streamBuffer:
+			// Flow control: drop updates when too many are unacknowledged
+			if atomic.LoadInt32(&lc.streamInFlight) >= maxInFlightRunUpdates {
+				logging.Debug("Lighthouse stream has %d unacknowledged updates, dropping update", maxInFlightRunUpdates)
+				continue
+			}
+
+			if err := lc.sendStreamUpdate(req); err != nil {
+				logging.Debug("Failed to stream run update to CloudShip: %v (reconnecting in %v)", err, backoff)
+				lc.closeRunStream()
+
+				select {
+				case <-lc.ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+				backoff *= 2
+				if backoff > streamReconnectBackoffMax {
+					backoff = streamReconnectBackoffMax
+				}
+
+				// Retry once on a fresh stream, then drop
+				if err := lc.sendStreamUpdate(req); err != nil {
+					logging.Debug("Retry failed, dropping run update: %v", err)
+					lc.closeRunStream()
+				}
+				continue
+			}
+			backoff = time.Second
+		}
+	}
+}
+
+// sendStreamUpdate sends one update on the stream, opening it if needed
+func (lc *LighthouseClient) sendStreamUpdate(req *proto.SendRunRequest) error {
+	stream, err := lc.ensureRunStream()
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(req); err != nil {
+		return err
+	}
+	atomic.AddInt32(&lc.streamInFlight, 1)
+	return nil
+}
+
+// ensureRunStream returns the active SendRunStream, establishing it and its
+// response receiver on first use
+func (lc *LighthouseClient) ensureRunStream() (proto.LighthouseService_SendRunStreamClient, error) {
+	lc.streamMu.Lock()
+	defer lc.streamMu.Unlock()
+
+	if lc.runStream != nil {
+		return lc.runStream, nil
+	}
+
+	stream, err := lc.client.SendRunStream(lc.ctx)
+	if err != nil {
+		return nil, err
+	}
+	lc.runStream = stream
+	logging.Debug("Established SendRunStream with CloudShip for incremental run updates")
+
+	// Receiver drains acknowledgements to keep the in-flight counter accurate
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				// Stream died - the worker will re-establish it on next send
+				lc.streamMu.Lock()
+				if lc.runStream == stream {
+					lc.runStream = nil
+				}
+				lc.streamMu.Unlock()
+				atomic.StoreInt32(&lc.streamInFlight, 0)
+				return
+			}
+			atomic.AddInt32(&lc.streamInFlight, -1)
+			if !resp.Success {
+				logging.Debug("CloudShip rejected streamed run update: %s", resp.Message)
+			}
+		}
+	}()
+
+	return stream, nil
+}
+
+// closeRunStream tears down the current stream, if any
+func (lc *LighthouseClient) closeRunStream() {
+	lc.streamMu.Lock()
+	defer lc.streamMu.Unlock()
+
+	if lc.runStream != nil {
+		_ = lc.runStream.CloseSend()
+		lc.runStream = nil
+	}
+	atomic.StoreInt32(&lc.streamInFlight, 0)
+}
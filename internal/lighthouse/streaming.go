@@ -59,6 +59,10 @@ func (lc *LighthouseClient) startBackgroundWorkers() {
 		}
 	}()
 
+	// Incremental run update streamer (SendRunStream)
+	lc.wg.Add(1)
+	go lc.runStreamWorker()
+
 	// Health data worker (serve mode only - stdio mode doesn't connect to platform)
 	if lc.mode == ModeServe {
 		lc.wg.Add(1)
@@ -1,14 +1,36 @@
 package logging
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"sync"
+	"time"
 )
 
+// Log output formats. Text keeps the pretty human-readable output; JSON emits
+// one structured object per line with consistent fields (component, run_id,
+// env, trace_id, ...) so container logs can be parsed by Loki/CloudWatch
+// without regexes on the pretty output.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Fields attaches structured context to a log entry (e.g. run_id, env,
+// trace_id). In JSON mode they become top-level keys; in text mode they are
+// appended to the message.
+type Fields map[string]interface{}
+
 // Logger provides level-based logging functionality
 type Logger struct {
 	debugEnabled bool
+	format       string
+	component    string
+	output       io.Writer
+	mu           sync.Mutex
 	infoLogger   *log.Logger
 	debugLogger  *log.Logger
 }
@@ -22,31 +44,123 @@ func Initialize(debugMode bool) {
 	// Always use stderr for logging to avoid interfering with MCP stdio protocol
 	var output io.Writer = os.Stderr
 
+	// Re-initialization (e.g. dev mode forcing debug on) keeps any format and
+	// component already configured
+	format, component := FormatText, "cli"
+	if globalLogger != nil {
+		format, component = globalLogger.format, globalLogger.component
+	}
+
 	globalLogger = &Logger{
 		debugEnabled: debugMode,
+		format:       format,
+		component:    component,
+		output:       output,
 		infoLogger:   log.New(output, "", log.LstdFlags),
 		debugLogger:  log.New(output, "", log.LstdFlags),
 	}
 }
 
-// Info logs informational messages (always shown)
-func Info(format string, args ...interface{}) {
+// SetFormat switches the global logger's output format ("text" or "json")
+func SetFormat(format string) error {
+	if format != FormatText && format != FormatJSON {
+		return fmt.Errorf("invalid log format %q (expected %q or %q)", format, FormatText, FormatJSON)
+	}
 	if globalLogger != nil {
-		globalLogger.infoLogger.Printf(format, args...)
+		globalLogger.format = format
 	}
+	return nil
+}
+
+// SetComponent names the process component ("cli", "serve", "mcp") included
+// in every JSON log entry
+func SetComponent(name string) {
+	if globalLogger != nil && name != "" {
+		globalLogger.component = name
+	}
+}
+
+// Info logs informational messages (always shown)
+func Info(format string, args ...interface{}) {
+	emit("info", nil, format, args...)
 }
 
 // Debug logs debug messages (only shown when debug mode is enabled)
 func Debug(format string, args ...interface{}) {
-	if globalLogger != nil && globalLogger.debugEnabled {
-		globalLogger.debugLogger.Printf("DEBUG: "+format, args...)
+	if globalLogger == nil || !globalLogger.debugEnabled {
+		return
 	}
+	emit("debug", nil, format, args...)
 }
 
 // Error logs error messages (always shown)
 func Error(format string, args ...interface{}) {
-	if globalLogger != nil {
-		globalLogger.infoLogger.Printf("ERROR: "+format, args...)
+	emit("error", nil, format, args...)
+}
+
+// WithFields returns a logger that attaches structured context to every entry
+func WithFields(fields Fields) *FieldLogger {
+	return &FieldLogger{fields: fields}
+}
+
+// FieldLogger carries structured context for a group of related log entries
+type FieldLogger struct {
+	fields Fields
+}
+
+func (l *FieldLogger) Info(format string, args ...interface{}) {
+	emit("info", l.fields, format, args...)
+}
+
+func (l *FieldLogger) Debug(format string, args ...interface{}) {
+	if globalLogger == nil || !globalLogger.debugEnabled {
+		return
+	}
+	emit("debug", l.fields, format, args...)
+}
+
+func (l *FieldLogger) Error(format string, args ...interface{}) {
+	emit("error", l.fields, format, args...)
+}
+
+// emit writes one log entry in the configured format
+func emit(level string, fields Fields, format string, args ...interface{}) {
+	if globalLogger == nil {
+		return
+	}
+
+	message := fmt.Sprintf(format, args...)
+	if globalLogger.format == FormatJSON {
+		entry := map[string]interface{}{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"level":     level,
+			"component": globalLogger.component,
+			"message":   message,
+		}
+		for key, value := range fields {
+			entry[key] = value
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return
+		}
+		globalLogger.mu.Lock()
+		fmt.Fprintln(globalLogger.output, string(line))
+		globalLogger.mu.Unlock()
+		return
+	}
+
+	// Text format preserves the original pretty output with level prefixes
+	for key, value := range fields {
+		message += fmt.Sprintf(" %s=%v", key, value)
+	}
+	switch level {
+	case "debug":
+		globalLogger.debugLogger.Printf("DEBUG: %s", message)
+	case "error":
+		globalLogger.infoLogger.Printf("ERROR: %s", message)
+	default:
+		globalLogger.infoLogger.Printf("%s", message)
 	}
 }
 
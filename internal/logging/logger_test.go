@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+)
+
+// redirect points the global logger at a buffer so tests can inspect output
+func redirect(t *testing.T, debugMode bool) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	globalLogger = &Logger{
+		debugEnabled: debugMode,
+		format:       FormatText,
+		component:    "cli",
+		output:       &buf,
+		infoLogger:   log.New(&buf, "", log.LstdFlags),
+		debugLogger:  log.New(&buf, "", log.LstdFlags),
+	}
+	t.Cleanup(func() { globalLogger = nil })
+	return &buf
+}
+
+func TestJSONFormatEntries(t *testing.T) {
+	buf := redirect(t, false)
+	if err := SetFormat(FormatJSON); err != nil {
+		t.Fatalf("SetFormat failed: %v", err)
+	}
+	SetComponent("serve")
+
+	WithFields(Fields{"run_id": int64(42), "env": "default"}).Info("run %s started", "demo")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected JSON log entry, got %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "info" || entry["component"] != "serve" {
+		t.Errorf("unexpected level/component: %v", entry)
+	}
+	if entry["message"] != "run demo started" {
+		t.Errorf("unexpected message: %v", entry["message"])
+	}
+	if entry["run_id"] != float64(42) || entry["env"] != "default" {
+		t.Errorf("expected run_id/env fields, got %v", entry)
+	}
+	if _, ok := entry["timestamp"]; !ok {
+		t.Errorf("expected timestamp field, got %v", entry)
+	}
+}
+
+func TestJSONFormatErrorLevel(t *testing.T) {
+	buf := redirect(t, false)
+	if err := SetFormat(FormatJSON); err != nil {
+		t.Fatalf("SetFormat failed: %v", err)
+	}
+
+	Error("boom: %d", 7)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected JSON log entry, got %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "error" || entry["message"] != "boom: 7" {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+}
+
+func TestTextFormatUnchanged(t *testing.T) {
+	buf := redirect(t, true)
+
+	Info("hello %s", "world")
+	Debug("details")
+	Error("bad thing")
+
+	out := buf.String()
+	if !strings.Contains(out, "hello world") {
+		t.Errorf("missing info line: %q", out)
+	}
+	if !strings.Contains(out, "DEBUG: details") {
+		t.Errorf("missing debug prefix: %q", out)
+	}
+	if !strings.Contains(out, "ERROR: bad thing") {
+		t.Errorf("missing error prefix: %q", out)
+	}
+	if strings.Contains(out, "{") {
+		t.Errorf("text mode should not emit JSON: %q", out)
+	}
+}
+
+func TestSetFormatRejectsUnknown(t *testing.T) {
+	redirect(t, false)
+	if err := SetFormat("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestDebugSuppressedInJSONMode(t *testing.T) {
+	buf := redirect(t, false)
+	if err := SetFormat(FormatJSON); err != nil {
+		t.Fatalf("SetFormat failed: %v", err)
+	}
+
+	Debug("should not appear")
+	if buf.Len() != 0 {
+		t.Errorf("debug output emitted with debug disabled: %q", buf.String())
+	}
+}
+
+func TestInitializePreservesFormatAndComponent(t *testing.T) {
+	redirect(t, false)
+	if err := SetFormat(FormatJSON); err != nil {
+		t.Fatalf("SetFormat failed: %v", err)
+	}
+	SetComponent("mcp")
+
+	Initialize(true)
+	t.Cleanup(func() { globalLogger = nil })
+
+	if globalLogger.format != FormatJSON || globalLogger.component != "mcp" {
+		t.Errorf("re-initialization dropped format/component: %+v", globalLogger)
+	}
+}
@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+
+	"station/internal/auth"
+	"station/internal/config"
+)
+
+// Station's management MCP server exposes tools with side effects (create
+// agent, delete environment, ...) to any connected client. mcp_rbac.yml in
+// the Station config directory scopes tools per client, keyed by the client
+// name reported during the MCP initialize handshake or by the API-key user
+// in server mode:
+//
+//	default_policy: allow
+//	clients:
+//	  claude-desktop:
+//	    allow:
+//	      - call_agent
+//	      - list_*
+//	  ci-runner:
+//	    deny:
+//	      - delete_*
+//
+// A client entry may set allow (everything else denied) or deny (everything
+// else allowed); patterns support a trailing "*" wildcard. Without the file
+// every client keeps full access, so RBAC is strictly opt-in.
+const rbacConfigFileName = "mcp_rbac.yml"
+
+// ToolPolicy scopes one client's access to management tools
+type ToolPolicy struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// RBACConfig is the parsed mcp_rbac.yml
+type RBACConfig struct {
+	DefaultPolicy string                `yaml:"default_policy"` // "allow" (default) or "deny" for unlisted clients
+	Clients       map[string]ToolPolicy `yaml:"clients"`
+}
+
+// loadRBACConfig reads mcp_rbac.yml from the Station config directory.
+// A missing file yields nil (RBAC disabled).
+func loadRBACConfig() (*RBACConfig, error) {
+	return loadRBACConfigFromPath(filepath.Join(config.GetStationConfigDir(), rbacConfigFileName))
+}
+
+func loadRBACConfigFromPath(path string) (*RBACConfig, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rbacConfigFileName, err)
+	}
+
+	rbac := &RBACConfig{}
+	if err := yaml.Unmarshal(data, rbac); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", rbacConfigFileName, err)
+	}
+	if rbac.DefaultPolicy == "" {
+		rbac.DefaultPolicy = "allow"
+	}
+	if rbac.DefaultPolicy != "allow" && rbac.DefaultPolicy != "deny" {
+		return nil, fmt.Errorf("invalid default_policy %q in %s (expected allow or deny)", rbac.DefaultPolicy, rbacConfigFileName)
+	}
+	return rbac, nil
+}
+
+// Allows reports whether the named client may call the given tool
+func (r *RBACConfig) Allows(clientName, toolName string) bool {
+	policy, configured := r.Clients[clientName]
+	if !configured {
+		return r.DefaultPolicy == "allow"
+	}
+
+	if matchesToolPattern(policy.Deny, toolName) {
+		return false
+	}
+	if len(policy.Allow) > 0 {
+		return matchesToolPattern(policy.Allow, toolName)
+	}
+	return true
+}
+
+// matchesToolPattern checks a tool name against patterns with optional
+// trailing "*" wildcards (e.g. "delete_*")
+func matchesToolPattern(patterns []string, toolName string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(toolName, prefix) {
+				return true
+			}
+			continue
+		}
+		if pattern == toolName {
+			return true
+		}
+	}
+	return false
+}
+
+// rbacClientName identifies the calling client: the API-key user in server
+// mode, otherwise the name the client reported during the MCP initialize
+// handshake
+func rbacClientName(ctx context.Context) string {
+	if user, err := auth.GetUserFromHTTPContext(ctx); err == nil && user != nil {
+		return user.Username
+	}
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		if withInfo, ok := session.(server.SessionWithClientInfo); ok {
+			if name := withInfo.GetClientInfo().Name; name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// toolAccessMiddleware enforces mcp_rbac.yml on every tool call; denied
+// calls are logged with the client identity and surfaced as tool errors
+func toolAccessMiddleware(rbac *RBACConfig) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			clientName := rbacClientName(ctx)
+			if rbac.Allows(clientName, request.Params.Name) {
+				return next(ctx, request)
+			}
+
+			if clientName == "" {
+				clientName = "(unidentified client)"
+			}
+			log.Printf("🚫 RBAC: denied tool '%s' for client '%s' (see %s)", request.Params.Name, clientName, rbacConfigFileName)
+			return mcp.NewToolResultError(fmt.Sprintf("Access denied: client '%s' is not allowed to call '%s' (configured in %s)",
+				clientName, request.Params.Name, rbacConfigFileName)), nil
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestLoadRBACConfigFromPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), rbacConfigFileName)
+	content := `
+default_policy: deny
+clients:
+  claude-desktop:
+    allow:
+      - call_agent
+      - list_*
+  ci-runner:
+    deny:
+      - delete_*
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write rbac config: %v", err)
+	}
+
+	rbac, err := loadRBACConfigFromPath(path)
+	if err != nil {
+		t.Fatalf("loadRBACConfigFromPath failed: %v", err)
+	}
+	if rbac.DefaultPolicy != "deny" || len(rbac.Clients) != 2 {
+		t.Errorf("unexpected config: %+v", rbac)
+	}
+}
+
+func TestLoadRBACConfigMissingFile(t *testing.T) {
+	rbac, err := loadRBACConfigFromPath(filepath.Join(t.TempDir(), rbacConfigFileName))
+	if err != nil {
+		t.Fatalf("expected missing file to disable RBAC, got %v", err)
+	}
+	if rbac != nil {
+		t.Errorf("expected nil config for missing file, got %+v", rbac)
+	}
+}
+
+func TestLoadRBACConfigInvalidPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), rbacConfigFileName)
+	if err := os.WriteFile(path, []byte("default_policy: maybe\n"), 0644); err != nil {
+		t.Fatalf("failed to write rbac config: %v", err)
+	}
+	if _, err := loadRBACConfigFromPath(path); err == nil {
+		t.Error("expected error for invalid default_policy")
+	}
+}
+
+func TestRBACAllows(t *testing.T) {
+	rbac := &RBACConfig{
+		DefaultPolicy: "allow",
+		Clients: map[string]ToolPolicy{
+			"claude-desktop": {Allow: []string{"call_agent", "list_*"}},
+			"ci-runner":      {Deny: []string{"delete_*"}},
+		},
+	}
+
+	// Allow-list client: only listed tools pass
+	if !rbac.Allows("claude-desktop", "call_agent") {
+		t.Error("expected call_agent allowed for claude-desktop")
+	}
+	if !rbac.Allows("claude-desktop", "list_agents") {
+		t.Error("expected list_* wildcard to match list_agents")
+	}
+	if rbac.Allows("claude-desktop", "delete_environment") {
+		t.Error("expected delete_environment denied for allow-list client")
+	}
+
+	// Deny-list client: everything but the listed tools passes
+	if rbac.Allows("ci-runner", "delete_agent") {
+		t.Error("expected delete_* denied for ci-runner")
+	}
+	if !rbac.Allows("ci-runner", "create_agent") {
+		t.Error("expected create_agent allowed for ci-runner")
+	}
+
+	// Unlisted clients follow the default policy
+	if !rbac.Allows("unknown", "delete_agent") {
+		t.Error("expected unlisted client allowed under default allow")
+	}
+	rbac.DefaultPolicy = "deny"
+	if rbac.Allows("unknown", "call_agent") {
+		t.Error("expected unlisted client denied under default deny")
+	}
+}
+
+func TestToolAccessMiddleware(t *testing.T) {
+	rbac := &RBACConfig{
+		DefaultPolicy: "deny",
+		Clients: map[string]ToolPolicy{
+			// No identifiable client in a bare context, so key the empty name
+			"": {Allow: []string{"call_agent"}},
+		},
+	}
+
+	called := false
+	handler := toolAccessMiddleware(rbac)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "call_agent"
+	result, err := handler(context.Background(), request)
+	if err != nil || result.IsError {
+		t.Fatalf("expected allowed call to pass through, got %+v (err: %v)", result, err)
+	}
+	if !called {
+		t.Error("expected wrapped handler to run for allowed tool")
+	}
+
+	called = false
+	request.Params.Name = "delete_environment"
+	result, err = handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("expected denial as tool error, got transport error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected denied call to return a tool error")
+	}
+	if called {
+		t.Error("expected wrapped handler not to run for denied tool")
+	}
+}
@@ -47,6 +47,23 @@ func (s *Server) handleCreateReport(ctx context.Context, request mcp.CallToolReq
 	description := request.GetString("description", "")
 	agentCriteria := request.GetString("agent_criteria", "")
 	filterModel := request.GetString("filter_model", "")
+	agentGroup := request.GetString("agent_group", "")
+
+	// Resolve agent group (by name, falling back to numeric ID) before
+	// creating the report so a typo fails fast
+	var groupID *int64
+	if agentGroup != "" {
+		group, err := s.repos.AgentGroups.GetByName(ctx, agentGroup)
+		if err != nil {
+			if id, parseErr := strconv.ParseInt(agentGroup, 10, 64); parseErr == nil {
+				group, err = s.repos.AgentGroups.GetByID(ctx, id)
+			}
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Agent group '%s' not found", agentGroup)), nil
+			}
+		}
+		groupID = &group.ID
+	}
 
 	// Create report in database
 	var descSQL sql.NullString
@@ -90,16 +107,27 @@ func (s *Server) handleCreateReport(ctx context.Context, request mcp.CallToolReq
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to create report: %v", err)), nil
 	}
 
+	if groupID != nil {
+		if err := s.repos.Reports.SetAgentGroup(ctx, report.ID, *groupID); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to set agent group on report: %v", err)), nil
+		}
+	}
+
+	reportInfo := map[string]interface{}{
+		"id":             report.ID,
+		"name":           report.Name,
+		"environment_id": report.EnvironmentID,
+		"status":         report.Status,
+		"created_at":     report.CreatedAt,
+	}
+	if groupID != nil {
+		reportInfo["agent_group_id"] = *groupID
+	}
+
 	response := map[string]interface{}{
 		"success": true,
 		"message": fmt.Sprintf("Report '%s' created successfully", name),
-		"report": map[string]interface{}{
-			"id":             report.ID,
-			"name":           report.Name,
-			"environment_id": report.EnvironmentID,
-			"status":         report.Status,
-			"created_at":     report.CreatedAt,
-		},
+		"report":  reportInfo,
 	}
 
 	resultJSON, _ := json.MarshalIndent(response, "", "  ")
@@ -41,12 +41,24 @@ type Server struct {
 
 func NewServer(database db.Database, agentService services.AgentServiceInterface, repos *repositories.Repositories, cfg *config.Config, localMode bool) *Server {
 	// Create MCP server using the official mcp-go library
-	mcpServer := server.NewMCPServer(
-		"Station MCP Server",
-		"1.0.0",
+	serverOptions := []server.ServerOption{
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
 		server.WithRecovery(),
+	}
+
+	// Per-client tool scoping (mcp_rbac.yml); absent file means full access
+	if rbac, err := loadRBACConfig(); err != nil {
+		log.Printf("Warning: MCP RBAC config ignored: %v", err)
+	} else if rbac != nil {
+		log.Printf("MCP RBAC enabled: %d client polic(ies), default %s", len(rbac.Clients), rbac.DefaultPolicy)
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(toolAccessMiddleware(rbac)))
+	}
+
+	mcpServer := server.NewMCPServer(
+		"Station MCP Server",
+		"1.0.0",
+		serverOptions...,
 	)
 
 	toolDiscoverySvc := NewToolDiscoveryService(database, repos)
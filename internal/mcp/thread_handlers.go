@@ -0,0 +1,225 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"station/internal/logging"
+	"station/internal/services"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Conversation Thread Handlers
+// Threads let MCP clients hold multi-turn conversations with an agent: each
+// message executes as a normal agent run linked to the previous one, and the
+// thread replays prior turns so context carries across messages.
+
+func (s *Server) handleCreateThread(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if s.repos == nil {
+		return mcp.NewToolResultError("Server repositories not initialized"), nil
+	}
+
+	agentIDStr, err := request.RequireString("agent_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing 'agent_id' parameter: %v", err)), nil
+	}
+
+	agentID, err := strconv.ParseInt(agentIDStr, 10, 64)
+	if err != nil {
+		return mcp.NewToolResultError("Invalid agent_id format"), nil
+	}
+
+	thread, err := services.NewThreadService(s.repos).CreateThread(agentID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create thread: %v", err)), nil
+	}
+
+	response := map[string]interface{}{
+		"success": true,
+		"thread":  thread,
+		"message": fmt.Sprintf("Thread created for agent '%s'. Send messages with send_thread_message using thread_id %s", thread.AgentName, thread.ID),
+	}
+
+	resultJSON, _ := json.MarshalIndent(response, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+func (s *Server) handleSendThreadMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if s.repos == nil {
+		return mcp.NewToolResultError("Server repositories not initialized"), nil
+	}
+
+	threadID, err := request.RequireString("thread_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing 'thread_id' parameter: %v", err)), nil
+	}
+
+	message, err := request.RequireString("message")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing 'message' parameter: %v", err)), nil
+	}
+
+	// Extract variables for dotprompt rendering (same shape as call_agent)
+	var userVariables map[string]interface{}
+	if request.Params.Arguments != nil {
+		if argsMap, ok := request.Params.Arguments.(map[string]interface{}); ok {
+			if variablesArg, ok := argsMap["variables"]; ok {
+				if variables, ok := variablesArg.(map[string]interface{}); ok {
+					userVariables = variables
+				}
+			}
+		}
+	}
+	if userVariables == nil {
+		userVariables = make(map[string]interface{})
+	}
+
+	threadService := services.NewThreadService(s.repos)
+	thread, err := threadService.GetThread(threadID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Thread not found: %v", err)), nil
+	}
+	if thread.Status == services.ThreadStatusClosed {
+		return mcp.NewToolResultError(fmt.Sprintf("Thread %s is closed and no longer accepts messages", threadID)), nil
+	}
+
+	agent, err := s.repos.Agents.GetByID(thread.AgentID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Agent not found: %v", err)), nil
+	}
+
+	// Compose the task from the thread's prior turns plus the new message,
+	// and link the run to the previous one so the thread is a run chain
+	var userID int64 = 1
+	task := threadService.BuildThreadTask(ctx, thread, message)
+	run, err := s.repos.AgentRuns.CreateWithMetadata(ctx, thread.AgentID, userID, task, "", 0, nil, nil, "running", nil,
+		nil, nil, nil, nil, nil, nil, thread.LastRunID())
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create agent run: %v", err)), nil
+	}
+	runID := run.ID
+
+	// Same unified execution flow as call_agent
+	agentService := services.NewAgentService(s.repos, s.lighthouseClient)
+	engine := agentService.GetExecutionEngine()
+	result, execErr := engine.Execute(ctx, agent, task, runID, userVariables)
+	if execErr != nil {
+		completedAt := time.Now()
+		errorMsg := fmt.Sprintf("Thread message execution failed: %v", execErr)
+		updateErr := s.repos.AgentRuns.UpdateCompletionWithMetadata(
+			ctx, runID, errorMsg, 0, nil, nil, "failed", &completedAt,
+			nil, nil, nil, nil, nil, nil, &errorMsg,
+		)
+		if updateErr != nil {
+			logging.Info("Warning: Failed to update failed run %d: %v", runID, updateErr)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to execute agent: %v", execErr)), nil
+	}
+
+	completedAt := time.Now()
+	durationSeconds := result.Duration.Seconds()
+
+	var inputTokens, outputTokens, totalTokens *int64
+	var toolsUsed *int64
+	if result.TokenUsage != nil {
+		inputTokens = extractInt64FromTokenUsage(result.TokenUsage["input_tokens"])
+		outputTokens = extractInt64FromTokenUsage(result.TokenUsage["output_tokens"])
+		totalTokens = extractInt64FromTokenUsage(result.TokenUsage["total_tokens"])
+	}
+	if result.StepsUsed > 0 {
+		toolsUsedVal := int64(result.StepsUsed)
+		toolsUsed = &toolsUsedVal
+	}
+
+	status := "completed"
+	var errorMsg *string
+	if !result.Success {
+		status = "failed"
+		if result.Error != "" {
+			errorMsg = &result.Error
+		}
+	}
+
+	err = s.repos.AgentRuns.UpdateCompletionWithMetadata(
+		ctx, runID, result.Response, result.StepsTaken, result.ToolCalls, result.ExecutionSteps,
+		status, &completedAt, inputTokens, outputTokens, totalTokens, &durationSeconds,
+		&result.ModelName, toolsUsed, errorMsg,
+	)
+	if err != nil {
+		logging.Info("Warning: Failed to update run %d completion metadata: %v", runID, err)
+	}
+
+	// Link the run to the thread even on failed runs so the history is complete
+	thread, err = threadService.AppendRun(threadID, runID)
+	if err != nil {
+		logging.Info("Warning: Failed to link run %d to thread %s: %v", runID, threadID, err)
+	}
+
+	response := map[string]interface{}{
+		"success": result.Success,
+		"thread": map[string]interface{}{
+			"id":       threadID,
+			"agent_id": thread.AgentID,
+			"turns":    len(thread.RunIDs),
+		},
+		"message":   message,
+		"response":  result.Response,
+		"run_id":    runID,
+		"timestamp": time.Now(),
+	}
+
+	resultJSON, _ := json.MarshalIndent(response, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+func (s *Server) handleListThreads(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if s.repos == nil {
+		return mcp.NewToolResultError("Server repositories not initialized"), nil
+	}
+
+	threads, err := services.NewThreadService(s.repos).ListThreads()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list threads: %v", err)), nil
+	}
+	if threads == nil {
+		threads = []*services.Thread{}
+	}
+
+	response := map[string]interface{}{
+		"success": true,
+		"threads": threads,
+		"count":   len(threads),
+	}
+
+	resultJSON, _ := json.MarshalIndent(response, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+func (s *Server) handleCloseThread(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if s.repos == nil {
+		return mcp.NewToolResultError("Server repositories not initialized"), nil
+	}
+
+	threadID, err := request.RequireString("thread_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing 'thread_id' parameter: %v", err)), nil
+	}
+
+	thread, err := services.NewThreadService(s.repos).CloseThread(threadID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to close thread: %v", err)), nil
+	}
+
+	response := map[string]interface{}{
+		"success": true,
+		"thread":  thread,
+		"message": fmt.Sprintf("Thread %s closed after %d message(s)", thread.ID, len(thread.RunIDs)),
+	}
+
+	resultJSON, _ := json.MarshalIndent(response, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
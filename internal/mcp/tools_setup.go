@@ -177,6 +177,32 @@ func (s *Server) setupTools() {
 	)
 	s.mcpServer.AddTool(inspectRunTool, s.handleInspectRun)
 
+	// Conversation thread tools (multi-turn agent conversations stored as linked runs)
+	createThreadTool := mcp.NewTool("create_thread",
+		mcp.WithDescription("Create a persistent conversation thread bound to an agent. Messages sent to the thread share context and are stored as linked runs."),
+		mcp.WithString("agent_id", mcp.Required(), mcp.Description("ID of the agent this thread converses with")),
+	)
+	s.mcpServer.AddTool(createThreadTool, s.handleCreateThread)
+
+	sendThreadMessageTool := mcp.NewTool("send_thread_message",
+		mcp.WithDescription("Send a message to a conversation thread. The agent sees the thread's previous turns as context, and the exchange is stored as a run linked to the thread."),
+		mcp.WithString("thread_id", mcp.Required(), mcp.Description("ID of the thread (from create_thread)")),
+		mcp.WithString("message", mcp.Required(), mcp.Description("Message to send to the agent")),
+		mcp.WithObject("variables", mcp.Description("Variables for dotprompt rendering (e.g., {\"my_folder\": \"/tmp\"})")),
+	)
+	s.mcpServer.AddTool(sendThreadMessageTool, s.handleSendThreadMessage)
+
+	listThreadsTool := mcp.NewTool("list_threads",
+		mcp.WithDescription("List conversation threads with their agents, status, and message run IDs"),
+	)
+	s.mcpServer.AddTool(listThreadsTool, s.handleListThreads)
+
+	closeThreadTool := mcp.NewTool("close_thread",
+		mcp.WithDescription("Close a conversation thread. Its runs stay in history but it no longer accepts messages."),
+		mcp.WithString("thread_id", mcp.Required(), mcp.Description("ID of the thread to close")),
+	)
+	s.mcpServer.AddTool(closeThreadTool, s.handleCloseThread)
+
 	// Environment management tools
 	createEnvironmentTool := mcp.NewTool("create_environment",
 		mcp.WithDescription("Create a new environment with file-based configuration"),
@@ -286,6 +312,7 @@ func (s *Server) setupTools() {
 		mcp.WithString("team_criteria", mcp.Required(), mcp.Description("JSON defining team's business goals and success criteria. Measure against the PURPOSE of this agent team. Examples: FinOps team → cost_reduction, forecasting_accuracy; DevOps team → deployment_insights, failure_prediction; Security team → vulnerability_detection, compliance_coverage. Format: {\"goal\": \"team's purpose\", \"criteria\": {\"business_metric\": {\"weight\": 0.4, \"description\": \"what success looks like\", \"threshold\": 0.8}}}")),
 		mcp.WithString("agent_criteria", mcp.Description("JSON defining how individual agents contribute to team goals. Examples: cost analyzer → savings_identified, execution_cost; PR reviewer → bugs_caught, review_speed, false_positive_rate. Measures agent's VALUE vs LABOR COST. Same format as team_criteria (optional)")),
 		mcp.WithString("filter_model", mcp.Description("Filter agent runs by model name (e.g., 'openai/gpt-4o-mini', 'openai/gpt-4o'). Use list_models tool to see available models. Allows comparing performance across different models.")),
+		mcp.WithString("agent_group", mcp.Description("Name or ID of an agent group (e.g. 'finops-team') to restrict the report to, instead of every agent in the environment (optional)")),
 	)
 	s.mcpServer.AddTool(createReportTool, s.handleCreateReport)
 
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+const (
+	// agentDocsStatsWindow is how many recent runs feed the performance section
+	agentDocsStatsWindow = 20
+
+	// agentDocsExampleRuns is how many recent completed runs are shown as examples
+	agentDocsExampleRuns = 3
+
+	// agentDocsResponseLimit truncates example run responses so docs stay readable
+	agentDocsResponseLimit = 600
+)
+
+// AgentDocsService generates Markdown documentation for agents so teams can
+// keep an up-to-date agent catalog in version control (e.g. regenerated in CI
+// via `stn agent docs --env prod -o docs/`).
+type AgentDocsService struct {
+	repos *repositories.Repositories
+}
+
+func NewAgentDocsService(repos *repositories.Repositories) *AgentDocsService {
+	return &AgentDocsService{repos: repos}
+}
+
+// GenerateMarkdown renders the documentation page for a single agent:
+// description, system prompt, tool inventory with parameter schemas,
+// schedule, recent performance stats, and example runs.
+func (s *AgentDocsService) GenerateMarkdown(ctx context.Context, agent *models.Agent, environmentName string) (string, error) {
+	var doc strings.Builder
+
+	fmt.Fprintf(&doc, "# %s\n\n", agent.Name)
+	if agent.Description != "" {
+		fmt.Fprintf(&doc, "%s\n\n", agent.Description)
+	}
+
+	fmt.Fprintf(&doc, "| | |\n|---|---|\n")
+	fmt.Fprintf(&doc, "| Environment | %s |\n", environmentName)
+	fmt.Fprintf(&doc, "| Max steps | %d |\n", agent.MaxSteps)
+	fmt.Fprintf(&doc, "| Schedule | %s |\n", s.scheduleSummary(agent))
+	fmt.Fprintf(&doc, "| Updated | %s |\n\n", agent.UpdatedAt.Format("2006-01-02 15:04"))
+
+	fmt.Fprintf(&doc, "## System Prompt\n\n```\n%s\n```\n\n", strings.TrimSpace(agent.Prompt))
+
+	if err := s.writeToolInventory(&doc, agent.ID); err != nil {
+		return "", err
+	}
+
+	runs, err := s.repos.AgentRuns.ListByAgent(ctx, agent.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list runs for agent %s: %w", agent.Name, err)
+	}
+	s.writePerformanceStats(&doc, runs)
+	s.writeExampleRuns(&doc, runs)
+
+	return doc.String(), nil
+}
+
+// scheduleSummary describes the agent's cron schedule in one cell
+func (s *AgentDocsService) scheduleSummary(agent *models.Agent) string {
+	if agent.CronSchedule == nil || *agent.CronSchedule == "" {
+		return "none"
+	}
+	if !agent.ScheduleEnabled {
+		return fmt.Sprintf("`%s` (disabled)", *agent.CronSchedule)
+	}
+	return fmt.Sprintf("`%s` (enabled)", *agent.CronSchedule)
+}
+
+// writeToolInventory lists assigned tools with their parameter schemas
+func (s *AgentDocsService) writeToolInventory(doc *strings.Builder, agentID int64) error {
+	tools, err := s.repos.AgentTools.ListAgentTools(agentID)
+	if err != nil {
+		return fmt.Errorf("failed to list agent tools: %w", err)
+	}
+
+	fmt.Fprintf(doc, "## Tools (%d)\n\n", len(tools))
+	if len(tools) == 0 {
+		doc.WriteString("This agent has no tools assigned.\n\n")
+		return nil
+	}
+
+	for _, tool := range tools {
+		fmt.Fprintf(doc, "### `%s`\n\n", tool.ToolName)
+		if tool.ServerName != "" {
+			fmt.Fprintf(doc, "Server: `%s`\n\n", tool.ServerName)
+		}
+		if tool.ToolDescription != "" {
+			fmt.Fprintf(doc, "%s\n\n", tool.ToolDescription)
+		}
+		if schema := prettySchema(tool.ToolSchema); schema != "" {
+			fmt.Fprintf(doc, "<details>\n<summary>Parameter schema</summary>\n\n```json\n%s\n```\n\n</details>\n\n", schema)
+		}
+	}
+	return nil
+}
+
+// writePerformanceStats summarizes success rate, latency, and token usage over
+// the most recent runs
+func (s *AgentDocsService) writePerformanceStats(doc *strings.Builder, runs []*models.AgentRun) {
+	doc.WriteString("## Recent Performance\n\n")
+	if len(runs) == 0 {
+		doc.WriteString("No runs recorded yet.\n\n")
+		return
+	}
+
+	window := runs
+	if len(window) > agentDocsStatsWindow {
+		window = window[:agentDocsStatsWindow]
+	}
+
+	var completed, failed int
+	var totalDuration float64
+	var durationCount int
+	var totalTokens, tokenCount int64
+	for _, run := range window {
+		switch run.Status {
+		case "completed":
+			completed++
+		case "failed":
+			failed++
+		}
+		if run.DurationSeconds != nil {
+			totalDuration += *run.DurationSeconds
+			durationCount++
+		}
+		if run.TotalTokens != nil {
+			totalTokens += *run.TotalTokens
+			tokenCount++
+		}
+	}
+
+	fmt.Fprintf(doc, "Based on the last %d run(s):\n\n", len(window))
+	fmt.Fprintf(doc, "- Success rate: %.0f%% (%d completed, %d failed)\n",
+		float64(completed)/float64(len(window))*100, completed, failed)
+	if durationCount > 0 {
+		fmt.Fprintf(doc, "- Average duration: %.1fs\n", totalDuration/float64(durationCount))
+	}
+	if tokenCount > 0 {
+		fmt.Fprintf(doc, "- Average tokens per run: %d\n", totalTokens/tokenCount)
+	}
+	if model := latestModelName(window); model != "" {
+		fmt.Fprintf(doc, "- Model: %s\n", model)
+	}
+	doc.WriteString("\n")
+}
+
+// writeExampleRuns shows recent completed runs as usage examples
+func (s *AgentDocsService) writeExampleRuns(doc *strings.Builder, runs []*models.AgentRun) {
+	doc.WriteString("## Example Runs\n\n")
+
+	shown := 0
+	for _, run := range runs {
+		if run.Status != "completed" || run.FinalResponse == "" {
+			continue
+		}
+		fmt.Fprintf(doc, "### Run %d (%s)\n\n", run.ID, run.StartedAt.Format("2006-01-02"))
+		fmt.Fprintf(doc, "**Task:** %s\n\n", run.Task)
+		fmt.Fprintf(doc, "**Response:**\n\n> %s\n\n",
+			strings.ReplaceAll(truncateResponse(run.FinalResponse), "\n", "\n> "))
+		shown++
+		if shown >= agentDocsExampleRuns {
+			break
+		}
+	}
+	if shown == 0 {
+		doc.WriteString("No completed runs to show yet.\n\n")
+	}
+}
+
+// AgentDocFilename derives a stable Markdown filename from an agent name
+func AgentDocFilename(agentName string) string {
+	slug := strings.ToLower(agentName)
+	slug = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(slug, "-")
+	slug = strings.Trim(slug, "-")
+	if slug == "" {
+		slug = "agent"
+	}
+	return slug + ".md"
+}
+
+// prettySchema re-indents a JSON parameter schema, returning "" when the
+// schema is absent or unparseable
+func prettySchema(schema string) string {
+	if strings.TrimSpace(schema) == "" {
+		return ""
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+		return schema
+	}
+	pretty, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		return schema
+	}
+	return string(pretty)
+}
+
+// latestModelName returns the model recorded on the most recent run that has one
+func latestModelName(runs []*models.AgentRun) string {
+	for _, run := range runs {
+		if run.ModelName != nil && *run.ModelName != "" {
+			return *run.ModelName
+		}
+	}
+	return ""
+}
+
+// truncateResponse caps example responses at agentDocsResponseLimit characters
+func truncateResponse(response string) string {
+	response = strings.TrimSpace(response)
+	if len(response) <= agentDocsResponseLimit {
+		return response
+	}
+	return response[:agentDocsResponseLimit] + "…"
+}
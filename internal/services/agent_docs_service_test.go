@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+func TestGenerateMarkdown(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	ctx := context.Background()
+
+	user, err := repos.Users.GetByUsername("console")
+	if err != nil {
+		t.Fatalf("failed to load console user: %v", err)
+	}
+	env, err := repos.Environments.GetByName("default")
+	if err != nil {
+		t.Fatalf("failed to load default environment: %v", err)
+	}
+
+	cron := "0 0 * * * *"
+	agent, err := repos.Agents.Create("Cost Analyzer", "Analyzes cloud spend", "You analyze costs", 8, env.ID, user.ID, nil, &cron, true, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	// One completed run with metadata and one failed run
+	run, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "summarize spend", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+	completedAt := time.Now()
+	inputTokens, outputTokens, totalTokens := int64(100), int64(50), int64(150)
+	duration := 4.0
+	modelName := "openai/gpt-4o-mini"
+	toolsUsed := int64(2)
+	if err := repos.AgentRuns.UpdateCompletionWithMetadata(ctx, run.ID, "Spend is $42", 2, nil, nil, "completed", &completedAt,
+		&inputTokens, &outputTokens, &totalTokens, &duration, &modelName, &toolsUsed, nil); err != nil {
+		t.Fatalf("failed to complete run: %v", err)
+	}
+	if _, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "broken task", "", 0, nil, nil, "failed", &completedAt); err != nil {
+		t.Fatalf("failed to create failed run: %v", err)
+	}
+
+	service := NewAgentDocsService(repos)
+	doc, err := service.GenerateMarkdown(ctx, agent, env.Name)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"# Cost Analyzer",
+		"Analyzes cloud spend",
+		"| Environment | default |",
+		"| Max steps | 8 |",
+		"`0 0 * * * *` (enabled)",
+		"## System Prompt",
+		"You analyze costs",
+		"## Tools (0)",
+		"## Recent Performance",
+		"Success rate: 50% (1 completed, 1 failed)",
+		"Average duration: 4.0s",
+		"Average tokens per run: 150",
+		"Model: openai/gpt-4o-mini",
+		"## Example Runs",
+		"**Task:** summarize spend",
+		"> Spend is $42",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("expected doc to contain %q\n---\n%s", expected, doc)
+		}
+	}
+}
+
+func TestAgentDocFilename(t *testing.T) {
+	cases := map[string]string{
+		"Cost Analyzer":         "cost-analyzer.md",
+		"CICD Security Scanner": "cicd-security-scanner.md",
+		"already-slugged":       "already-slugged.md",
+		"!!!":                   "agent.md",
+	}
+	for name, expected := range cases {
+		if got := AgentDocFilename(name); got != expected {
+			t.Errorf("AgentDocFilename(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
+
+func TestPrettySchema(t *testing.T) {
+	if got := prettySchema(""); got != "" {
+		t.Errorf("expected empty schema to stay empty, got %q", got)
+	}
+	if got := prettySchema("not json"); got != "not json" {
+		t.Errorf("expected unparseable schema returned as-is, got %q", got)
+	}
+	pretty := prettySchema(`{"type":"object","properties":{"path":{"type":"string"}}}`)
+	if !strings.Contains(pretty, "\n  \"properties\"") {
+		t.Errorf("expected indented JSON, got %q", pretty)
+	}
+}
+
+func TestScheduleSummary(t *testing.T) {
+	service := NewAgentDocsService(nil)
+
+	if got := service.scheduleSummary(&models.Agent{}); got != "none" {
+		t.Errorf("expected 'none' without a schedule, got %q", got)
+	}
+	cron := "0 * * * * *"
+	if got := service.scheduleSummary(&models.Agent{CronSchedule: &cron}); got != "`0 * * * * *` (disabled)" {
+		t.Errorf("expected disabled schedule summary, got %q", got)
+	}
+	if got := service.scheduleSummary(&models.Agent{CronSchedule: &cron, ScheduleEnabled: true}); got != "`0 * * * * *` (enabled)" {
+		t.Errorf("expected enabled schedule summary, got %q", got)
+	}
+}
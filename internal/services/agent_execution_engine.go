@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -10,6 +11,7 @@ import (
 
 	"station/internal/config"
 	"station/internal/db/repositories"
+	"station/internal/events"
 	"station/internal/lighthouse"
 	"station/internal/logging"
 	"station/internal/storage"
@@ -51,6 +53,9 @@ type AgentExecutionResult struct {
 	// Metadata from dotprompt for data ingestion classification
 	App     string `json:"app,omitempty"`      // CloudShip data ingestion app classification
 	AppType string `json:"app_type,omitempty"` // CloudShip data ingestion app_type classification
+	// CitationReport holds tool citation validation results when the run
+	// required citations (see ExecutionOptions.RequireCitations)
+	CitationReport *CitationReport `json:"citation_report,omitempty"`
 }
 
 // ExecutionOptions contains optional parameters for agent execution
@@ -60,6 +65,32 @@ type ExecutionOptions struct {
 	// CloudShipRunID is the run ID provided by CloudShip for management channel executions
 	// This is used for telemetry correlation - when set, spans will include cloudship.run_id attribute
 	CloudShipRunID string
+	// Timeout overrides the execution time limit for this run (0 = use the
+	// agent's `timeout` frontmatter value or the 15 minute default)
+	Timeout time.Duration
+	// Profile overrides the agent's execution profile for this run
+	// (named generation settings bundle, e.g. "strict")
+	Profile string
+	// RequireCitations instructs the model to end its response with a
+	// structured citations block mapping each claim to supporting tool
+	// calls, which is validated against the tools that actually executed
+	RequireCitations bool
+	// FeatureFlags is the resolved experimental-flag set for this run
+	// (settings-backed defaults merged with --flag overrides); enabled
+	// flags are recorded on the run for traceable experiments
+	FeatureFlags FeatureFlags
+	// PreferCheapTools strengthens the tool annotation hint into an
+	// explicit instruction to order tool usage cheapest/fastest first
+	PreferCheapTools bool
+	// Seed pins the generation seed for this run where the provider supports
+	// it (OpenAI's seed parameter), overriding any profile-level seed; the
+	// effective seed is stored on the run record for later reproduction
+	Seed *int64
+	// EnvOverrides injects/overrides environment variables passed to stdio
+	// MCP servers for this run only (allowlisted via run_env_allowlist);
+	// forces fresh server connections since pooled processes keep the env
+	// they were started with
+	EnvOverrides map[string]string
 }
 
 // AgentExecutionEngine handles the execution of agents using GenKit and MCP
@@ -152,6 +183,50 @@ func NewAgentExecutionEngineWithLighthouse(repos *repositories.Repositories, age
 		}
 	}
 
+	// Register config-declared external tool call hooks (first constructor wins)
+	if cfg := config.GetLoadedConfig(); cfg != nil && len(cfg.ToolHooks) > 0 {
+		RegisterExternalToolHooks(cfg.ToolHooks)
+	}
+
+	// Register the token-budget context manager when configured
+	if cfg := config.GetLoadedConfig(); cfg != nil && cfg.Context.MaxTokens > 0 {
+		RegisterContextManager(cfg.Context)
+	}
+
+	// Wire config-declared event bus consumers (first constructor wins)
+	if cfg := config.GetLoadedConfig(); cfg != nil && len(cfg.Events.Consumers) > 0 {
+		RegisterEventConsumers(cfg.Events)
+	}
+
+	// Register declared tool output schemas for response validation
+	if cfg := config.GetLoadedConfig(); cfg != nil && len(cfg.ToolOutputSchemas) > 0 {
+		RegisterToolOutputSchemas(cfg.ToolOutputSchemas)
+	}
+
+	// Enforce agent-declared tool parameter constraints at call time (first
+	// constructor wins)
+	EnableToolConstraintEnforcement(repos)
+
+	// Pause gated tool calls (approval_tools frontmatter) for operator
+	// approval before they execute (first constructor wins)
+	EnableToolApprovalGate(repos)
+
+	// Checkpoint completed tool calls incrementally so crashed runs can be
+	// resumed via `stn runs resume` (first constructor wins)
+	EnableRunCheckpointing(repos)
+
+	// Reuse identical tool call results within a run for agents that opt in
+	// via memoize_tools frontmatter (first constructor wins)
+	EnableToolMemoization(repos)
+
+	// Attach the failing MCP server's recent stderr output to run debug logs
+	// on tool errors (first constructor wins)
+	EnableMCPStderrDiagnostics(repos)
+
+	// Fail fast on tools that keep failing so agents stop retrying dead
+	// servers (first constructor wins)
+	EnableToolCircuitBreaker(repos)
+
 	return &AgentExecutionEngine{
 		repos:                    repos,
 		agentService:             agentService,
@@ -238,9 +313,47 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 		return nil, fmt.Errorf("agent cannot be nil")
 	}
 
+	// Release context budget tracking on every exit path (success paths
+	// consume it earlier via takeContextCompressionSteps)
+	defer releaseContextBudget(runID)
+
+	// When citations are required, instruct the model up front so the
+	// citations block arrives with the final response
+	if opts.RequireCitations {
+		task += CitationInstruction()
+	}
+
+	// Surface tool cost/latency annotations so the model can plan around
+	// expensive or slow tools (see `stn mcp annotate`)
+	if annotations, annErr := NewToolAnnotationService(aee.repos).GetEnvironmentAnnotations(agent.EnvironmentID); annErr == nil {
+		task += ToolAnnotationHint(annotations, opts.PreferCheapTools)
+	}
+
+	// Enforce singleton concurrency control if configured in the agent's .prompt file
+	var agentTimeout time.Duration
+	if env, envErr := aee.repos.Environments.GetByID(agent.EnvironmentID); envErr == nil {
+		agentTimeout = loadAgentTimeout(agent, env.Name)
+		if sc := loadSingletonConfig(agent, env.Name); sc.Enabled {
+			logging.Info("Singleton agent '%s' (ID: %d): acquiring run lock (policy: %s)", agent.Name, agent.ID, sc.Policy)
+			runCtx, release, lockErr := singletonGuard.Acquire(ctx, agent.ID, sc.Policy)
+			if lockErr != nil {
+				return nil, lockErr
+			}
+			defer release()
+			ctx = runCtx
+		}
+	}
+
 	// Add execution timeout at top level (15 minutes default)
-	// This ensures ALL agent executions have a maximum time limit
+	// This ensures ALL agent executions have a maximum time limit; a per-agent
+	// `timeout` frontmatter value or per-run override takes precedence
 	timeout := 15 * time.Minute
+	if agentTimeout > 0 {
+		timeout = agentTimeout
+	}
+	if opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
 
 	// Check if context already has a deadline and use the shorter timeout
 	if deadline, ok := ctx.Deadline(); ok {
@@ -254,6 +367,16 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 	execCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	// Register the run so `stn runs cancel` and the cancel API can abort it.
+	// Cancelling the context tears down the GenKit generation and any
+	// in-flight MCP tool calls with it.
+	if runID > 0 {
+		var cancelRun context.CancelCauseFunc
+		execCtx, cancelRun = context.WithCancelCause(execCtx)
+		registerRunCancellation(runID, cancelRun)
+		defer unregisterRunCancellation(runID)
+	}
+
 	startTime := time.Now()
 
 	// Generate run UUID early so it can be used for both telemetry spans and SendRun
@@ -300,6 +423,17 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 		defer span.End()
 	}
 
+	// Structured entry so JSON log consumers can correlate this run across
+	// components without parsing the pretty messages above
+	runLogFields := logging.Fields{
+		"run_id": runID,
+		"env":    agent.EnvironmentID,
+	}
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		runLogFields["trace_id"] = spanCtx.TraceID().String()
+	}
+	logging.WithFields(runLogFields).Info("Agent execution started for '%s'", agent.Name)
+
 	// Log execution start
 	err := aee.repos.AgentRuns.AppendDebugLog(ctx, runID, map[string]interface{}{
 		"timestamp": time.Now().Format(time.RFC3339),
@@ -314,6 +448,24 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 		logging.Debug("Failed to log execution start: %v", err)
 	}
 
+	// Record the run's feature flags so experiments stay traceable
+	if enabledFlags := opts.FeatureFlags.Names(); len(enabledFlags) > 0 {
+		if span != nil {
+			span.SetAttributes(attribute.StringSlice("run.feature_flags", enabledFlags))
+		}
+		err := aee.repos.AgentRuns.AppendDebugLog(ctx, runID, map[string]interface{}{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"level":     "info",
+			"message":   fmt.Sprintf("Feature flags enabled: %s", strings.Join(enabledFlags, ", ")),
+			"details": map[string]interface{}{
+				"feature_flags": enabledFlags,
+			},
+		})
+		if err != nil {
+			logging.Debug("Failed to log feature flags: %v", err)
+		}
+	}
+
 	// All agents now use unified dotprompt execution system
 
 	// Note: MCP cleanup will happen after dotprompt execution completes
@@ -381,7 +533,7 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 	}
 
 	logging.Debug("About to call GetEnvironmentMCPTools for env %d", agent.EnvironmentID)
-	allMCPTools, mcpClients, err := aee.mcpConnManager.GetEnvironmentMCPTools(ctx, agent.EnvironmentID)
+	allMCPTools, mcpClients, err := aee.mcpConnManager.GetEnvironmentMCPToolsWithEnvOverrides(ctx, agent.EnvironmentID, opts.EnvOverrides)
 	fmt.Printf("✅ [ENGINE] GetEnvironmentMCPTools returned %d tools, %d clients, err=%v\n", len(allMCPTools), len(mcpClients), err != nil)
 	logging.Debug("GetEnvironmentMCPTools returned %d tools, %d clients, err=%v", len(allMCPTools), len(mcpClients), err != nil)
 	if err != nil {
@@ -476,6 +628,9 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 
 	logging.Debug("Dotprompt execution using %d tools (filtered from %d available)", len(mcpTools), len(allMCPTools))
 
+	// Route tool calls through registered middleware hooks (no-op when none registered)
+	mcpTools = wrapToolsWithMiddleware(mcpTools, agent, runID)
+
 	if span != nil {
 		span.SetAttributes(attribute.Int("agent.filtered_tools_count", len(mcpTools)))
 	}
@@ -483,6 +638,16 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 	logging.Debug("Creating dotprompt executor")
 	executor := dotprompt.NewGenKitExecutor()
 
+	// Stream initial run status so CloudShip dashboards show the run as live
+	aee.streamRunProgress(agent, task, runUUID, "running", startTime, nil, nil)
+	events.Publish(events.RunStarted, map[string]interface{}{
+		"agent_id":   agent.ID,
+		"agent_name": agent.Name,
+		"run_id":     runID,
+		"run_uuid":   runUUID,
+		"task":       task,
+	})
+
 	// Create a logging callback for real-time progress updates
 	logCallback := func(logEntry map[string]interface{}) {
 		// Store all logs in database for UI display (filtering handled by UI layer if needed)
@@ -490,6 +655,21 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 		if err != nil {
 			logging.Debug("Failed to append debug log: %v", err)
 		}
+
+		// Stream step events to CloudShip for near real-time progress (lossy)
+		if message, ok := logEntry["message"].(string); ok && message != "" {
+			aee.streamRunProgress(agent, "", runUUID, "running", startTime, []types.ExecutionStep{{
+				Description: message,
+				Timestamp:   time.Now(),
+			}}, nil)
+			events.Publish(events.RunStep, map[string]interface{}{
+				"agent_id":   agent.ID,
+				"agent_name": agent.Name,
+				"run_id":     runID,
+				"run_uuid":   runUUID,
+				"message":    message,
+			})
+		}
 	}
 
 	// Set the logging callback on the OpenAI plugin for detailed API call logging
@@ -527,6 +707,74 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 		}
 	}
 
+	// Resolve the execution profile: --profile run override wins over the
+	// agent's `profile:` frontmatter. The resolved settings are recorded in
+	// the run's debug logs so results stay comparable across profile changes.
+	profileName := opts.Profile
+	if profileName == "" {
+		profileName = aee.parseProfileFromAgent(agent, environment.Name)
+	}
+	var profile *config.ExecutionProfile
+	if profileName != "" {
+		cfg, _ := config.Load()
+		var profErr error
+		profile, profErr = ResolveExecutionProfile(cfg, profileName)
+		if profErr != nil {
+			return nil, fmt.Errorf("failed to resolve execution profile for agent %s: %w", agent.Name, profErr)
+		}
+	}
+	// A --seed run override wins over the profile's seed; a seed without a
+	// profile still needs a profile object to reach the generate config
+	if opts.Seed != nil {
+		if profile == nil {
+			profile = &config.ExecutionProfile{}
+		}
+		seed := *opts.Seed
+		profile.Seed = &seed
+	}
+	if profile != nil {
+		executor.SetExecutionProfile(profileName, profile)
+	}
+	if profileName != "" {
+		logCallback(map[string]interface{}{
+			"event":   "execution_profile",
+			"message": fmt.Sprintf("Using execution profile '%s'", profileName),
+			"profile": profileName,
+			"settings": map[string]interface{}{
+				"temperature": profile.Temperature,
+				"top_p":       profile.TopP,
+				"max_turns":   profile.MaxTurns,
+				"tool_choice": profile.ToolChoice,
+				"seed":        profile.Seed,
+			},
+		})
+	}
+	// Record the effective seed on the run so flaky behavior can be replayed
+	// with `stn agent run --seed <n>` (only meaningful on providers that
+	// honor it, e.g. OpenAI)
+	if profile != nil && profile.Seed != nil {
+		if seedErr := aee.repos.AgentRuns.UpdateSeed(ctx, runID, *profile.Seed); seedErr != nil {
+			logging.Debug("Failed to record run seed: %v", seedErr)
+		}
+	}
+
+	// Apply the agent's step policy (max turns, per-turn timeout,
+	// require-tool-call check); violations surface as run warnings
+	if stepPolicy, policyErr := aee.repos.AgentStepPolicies.GetByAgent(ctx, agent.ID); policyErr != nil {
+		logging.Debug("Failed to load step policy for agent %s: %v", agent.Name, policyErr)
+	} else if stepPolicy != nil {
+		executor.SetStepPolicy(stepPolicy)
+		logCallback(map[string]interface{}{
+			"event":   "step_policy",
+			"message": fmt.Sprintf("Applying step policy for agent '%s'", agent.Name),
+			"settings": map[string]interface{}{
+				"max_turns":                stepPolicy.MaxTurns,
+				"per_turn_timeout_seconds": stepPolicy.PerTurnTimeoutSeconds,
+				"require_tool_call":        stepPolicy.RequireToolCall,
+			},
+		})
+	}
+
 	harnessMode := aee.parseHarnessFromAgent(agent, environment.Name)
 	if harnessMode == "agentic" {
 		logging.Info("Agent %s configured with agentic harness mode", agent.Name)
@@ -659,10 +907,39 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 	}
 
 	if err != nil {
+		// Check if the run was cancelled on demand (stn runs cancel / cancel API)
+		if errors.Is(context.Cause(ctx), ErrRunCancelled) {
+			cancelErr := fmt.Errorf("%w: agent %s (ID: %d)", ErrRunCancelled, agent.Name, agent.ID)
+			logging.Info("🛑 CANCELLED: %v", cancelErr)
+
+			if execSpan != nil {
+				execSpan.RecordError(cancelErr)
+				execSpan.SetStatus(codes.Error, "execution cancelled")
+			}
+			if span != nil {
+				span.RecordError(cancelErr)
+				span.SetStatus(codes.Error, "execution cancelled")
+			}
+
+			if logCallback != nil {
+				logCallback(map[string]interface{}{
+					"timestamp": time.Now().Format(time.RFC3339),
+					"level":     "error",
+					"message":   "Agent execution cancelled",
+					"details": map[string]interface{}{
+						"error":    cancelErr.Error(),
+						"duration": time.Since(startTime).String(),
+					},
+				})
+			}
+
+			return nil, cancelErr
+		}
+
 		// Check if this was a timeout
 		if ctx.Err() == context.DeadlineExceeded {
-			timeoutErr := fmt.Errorf("agent execution timed out after %v: agent %s (ID: %d) exceeded maximum execution time",
-				timeout, agent.Name, agent.ID)
+			timeoutErr := fmt.Errorf("%w after %v: agent %s (ID: %d) exceeded maximum execution time",
+				ErrRunTimedOut, timeout, agent.Name, agent.ID)
 			logging.Error("⏱️ TIMEOUT: %v", timeoutErr)
 
 			if execSpan != nil {
@@ -719,6 +996,15 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 				},
 			})
 		}
+		events.Publish(events.RunCompleted, map[string]interface{}{
+			"agent_id":    agent.ID,
+			"agent_name":  agent.Name,
+			"run_id":      runID,
+			"run_uuid":    runUUID,
+			"status":      "failed",
+			"error":       err.Error(),
+			"duration_ms": time.Since(startTime).Milliseconds(),
+		})
 		return nil, fmt.Errorf("dotprompt execution failed: %w", err)
 	}
 
@@ -765,6 +1051,58 @@ func (aee *AgentExecutionEngine) ExecuteWithOptions(ctx context.Context, agent *
 
 	logging.Debug("🔍 ENGINE: AgentExecutionResult created - result.App='%s', result.AppType='%s'", result.App, result.AppType)
 
+	// Surface step policy violations in the run's debug logs
+	for _, warning := range response.Warnings {
+		logging.Info("⚠️ Step policy warning for agent %s: %s", agent.Name, warning)
+		logCallback(map[string]interface{}{
+			"event":   "step_policy_warning",
+			"message": warning,
+		})
+	}
+
+	// Validate tool citations when the run required them: uncited claims and
+	// citations of tools that never ran are flagged on the final response
+	if opts.RequireCitations {
+		result.CitationReport = ValidateToolCitations(result.Response, ExecutedToolNames(result.ToolCalls))
+		if summary := result.CitationReport.Summary(); summary != "" {
+			result.Response += summary
+		}
+	}
+
+	// Record context compression events (if any) on the run's execution steps
+	// and release the run's context budget tracking
+	if compressionSteps := takeContextCompressionSteps(runID); len(compressionSteps) > 0 {
+		merged := models.JSONArray{}
+		if result.ExecutionSteps != nil {
+			merged = append(merged, *result.ExecutionSteps...)
+		}
+		merged = append(merged, compressionSteps...)
+		result.ExecutionSteps = &merged
+	}
+
+	// Stream final status with token usage so dashboards update immediately;
+	// the full run record follows via SendRun below
+	finalStatus := "completed"
+	if !result.Success {
+		finalStatus = "failed"
+	}
+
+	// Successful runs no longer need their resume checkpoint
+	if result.Success {
+		NewRunCheckpointService(aee.repos).Clear(runID)
+	}
+	aee.streamRunProgress(agent, task, runUUID, finalStatus, startTime, nil, aee.convertTokenUsage(result.TokenUsage))
+	events.Publish(events.RunCompleted, map[string]interface{}{
+		"agent_id":    agent.ID,
+		"agent_name":  agent.Name,
+		"run_id":      runID,
+		"run_uuid":    runUUID,
+		"status":      finalStatus,
+		"duration_ms": duration.Milliseconds(),
+		"steps_used":  result.StepsUsed,
+		"tools_used":  result.ToolsUsed,
+	})
+
 	// 🚀 Lighthouse Integration: Send run data to CloudShip (async, non-blocking)
 	// Send to CloudShip Lighthouse (dual flow: SendRun always + IngestData conditionally)
 	// Skip if management channel is handling the SendRun (they use their own run_id)
@@ -784,6 +1122,25 @@ func (aee *AgentExecutionEngine) GetGenkitProvider() *GenKitProvider {
 	return aee.genkitProvider
 }
 
+// streamRunProgress streams an incremental run update to CloudShip over the
+// SendRunStream channel (async, lossy, no-op without a Lighthouse client)
+func (aee *AgentExecutionEngine) streamRunProgress(agent *models.Agent, task, runUUID, status string, startTime time.Time, steps []types.ExecutionStep, tokenUsage *types.TokenUsage) {
+	if aee.lighthouseClient == nil {
+		return
+	}
+
+	aee.lighthouseClient.StreamRunUpdate(&lighthouse.RunUpdate{
+		RunID:      runUUID,
+		AgentID:    fmt.Sprintf("%d", agent.ID),
+		AgentName:  agent.Name,
+		Task:       task,
+		Status:     status,
+		Steps:      steps,
+		TokenUsage: tokenUsage,
+		StartedAt:  startTime,
+	}, "default", nil)
+}
+
 // sendToLighthouse sends agent run data to CloudShip Lighthouse (async, non-blocking)
 // runUUID is the UUID that will be used as the run ID in CloudShip (matches span.run.uuid attribute)
 func (aee *AgentExecutionEngine) sendToLighthouse(agent *models.Agent, task string, runID int64, runUUID string, startTime time.Time, result *AgentExecutionResult) {
@@ -1168,6 +1525,10 @@ type latticeFrontmatter struct {
 	Lattice bool `yaml:"lattice"`
 }
 
+type profileFrontmatter struct {
+	Profile string `yaml:"profile"`
+}
+
 type harnessFrontmatter struct {
 	Harness       string                   `yaml:"harness"`
 	HarnessConfig *agentHarnessFrontmatter `yaml:"harness_config"`
@@ -1261,6 +1622,34 @@ func (aee *AgentExecutionEngine) parseNotifyEnabledFromAgent(agent *models.Agent
 	return fm.Notify
 }
 
+func (aee *AgentExecutionEngine) parseProfileFromAgent(agent *models.Agent, environmentName string) string {
+	promptPath := config.GetAgentPromptPath(environmentName, agent.Name)
+
+	content, err := os.ReadFile(promptPath)
+	if err != nil {
+		logging.Debug("Failed to read dotprompt file for profile config: %v", err)
+		return ""
+	}
+
+	parts := strings.Split(string(content), "---")
+	if len(parts) < 3 {
+		return ""
+	}
+
+	yamlContent := strings.TrimSpace(parts[1])
+	if yamlContent == "" {
+		return ""
+	}
+
+	var fm profileFrontmatter
+	if err := yaml.Unmarshal([]byte(yamlContent), &fm); err != nil {
+		logging.Debug("Failed to parse profile config from dotprompt: %v", err)
+		return ""
+	}
+
+	return fm.Profile
+}
+
 func (aee *AgentExecutionEngine) parseSandboxConfigFromAgent(agent *models.Agent, environmentName string) *dotprompt.SandboxConfig {
 	promptPath := config.GetAgentPromptPath(environmentName, agent.Name)
 
@@ -23,6 +23,8 @@ type DotPromptConfig struct {
 	Model        string                 `yaml:"model"`
 	Config       map[string]interface{} `yaml:"config"`
 	Tools        []string               `yaml:"tools"`
+	Capabilities []string               `yaml:"capabilities"`
+	Skills       []string               `yaml:"skills"`
 	Agents       []string               `yaml:"agents"`
 	Metadata     map[string]interface{} `yaml:"metadata"`
 	Station      map[string]interface{} `yaml:"station"`
@@ -34,6 +36,17 @@ type DotPromptConfig struct {
 	AppType      string                 `yaml:"app_type"`
 	CloudShipAI  CloudShipAIConfig      `yaml:"cloudshipai"`
 	Schedule     ScheduleConfig         `yaml:"schedule"`
+	Singleton    SingletonConfig        `yaml:"singleton"`
+	StepPolicy   StepPolicyConfig       `yaml:"step_policy"`
+}
+
+// StepPolicyConfig represents the step_policy frontmatter block controlling
+// how the agent's execution loop behaves. Violations are recorded as run
+// warnings rather than silently changing behavior
+type StepPolicyConfig struct {
+	MaxTurns              int64 `yaml:"max_turns"`
+	PerTurnTimeoutSeconds int64 `yaml:"per_turn_timeout_seconds"`
+	RequireToolCall       bool  `yaml:"require_tool_call"`
 }
 
 type ScheduleConfig struct {
@@ -74,6 +87,11 @@ func (s *DeclarativeSync) syncAgents(ctx context.Context, agentsDir, environment
 
 	result.AgentsProcessed = len(promptFiles)
 
+	// Version prompt partial changes and report affected agents up front
+	if err := s.syncPromptPartials(agentsDir, promptFiles, options.DryRun); err != nil {
+		logging.Info("Warning: Failed to sync prompt partials: %v", err)
+	}
+
 	// Process each .prompt file
 	for _, promptFile := range promptFiles {
 		agentName := strings.TrimSuffix(filepath.Base(promptFile), ".prompt")
@@ -137,6 +155,43 @@ func (s *DeclarativeSync) syncSingleAgent(ctx context.Context, filePath, agentNa
 		return nil, fmt.Errorf("failed to parse prompt file: %w", err)
 	}
 
+	// Compose attached skills into the prompt and toolset before syncing
+	if len(config.Skills) > 0 {
+		skills, err := loadAgentSkills(skillsDirForAgentFile(filePath), config.Skills)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load skills for agent '%s': %w", agentName, err)
+		}
+		promptContent = composeSkillPrompt(promptContent, skills)
+		config.Tools = mergeSkillTools(config.Tools, skills)
+	}
+
+	// Resolve abstract capabilities to this environment's concrete tools via
+	// its capability mapping, so agent definitions stay environment-portable
+	if len(config.Capabilities) > 0 {
+		capMap, err := loadCapabilityMap(capabilitiesPathForAgentFile(filePath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load capability mapping for agent '%s': %w", agentName, err)
+		}
+		resolved, unmapped := expandCapabilityTools(capMap, config.Capabilities)
+		for _, capability := range unmapped {
+			fmt.Printf("   ⚠️  Capability '%s' has no mapping in %s for environment '%s'\n",
+				capability, capabilitiesFileName, environmentName)
+		}
+		config.Tools = mergeCapabilityTools(config.Tools, resolved)
+	}
+
+	// Expand {{> partial}} includes so shared fragment edits reach every agent
+	if strings.Contains(promptContent, "{{>") {
+		partials, err := loadPromptPartials(promptsDirForAgentFile(filePath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load prompt partials for agent '%s': %w", agentName, err)
+		}
+		promptContent, _, err = expandPromptPartials(promptContent, partials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand prompt partials for agent '%s': %w", agentName, err)
+		}
+	}
+
 	// 3. Calculate file checksum
 	checksum, err := s.calculateFileChecksum(filePath)
 	if err != nil {
@@ -174,7 +229,7 @@ func (s *DeclarativeSync) syncSingleAgent(ctx context.Context, filePath, agentNa
 	// 7. Create or update agent
 	if existingAgent != nil {
 		// Update existing agent
-		return s.updateAgentFromFile(ctx, existingAgent, config, promptContent, checksum)
+		return s.updateAgentFromFile(ctx, existingAgent, environmentName, config, promptContent, checksum, options)
 	} else {
 		// Create new agent
 		return s.createAgentFromFile(ctx, filePath, agentName, environmentName, config, promptContent, checksum)
@@ -343,8 +398,9 @@ func (s *DeclarativeSync) createAgentFromFile(ctx context.Context, filePath, age
 				continue
 			}
 
-			// Find tool by name in environment
-			tool, err := s.repos.MCPTools.FindByNameInEnvironment(env.ID, toolName)
+			// Find tool by name in environment (supports fully-qualified
+			// serverName__toolName for disambiguating collisions)
+			tool, err := resolveToolInEnvironment(s.repos, env.ID, toolName)
 			if err != nil {
 				return nil, fmt.Errorf("tool %s not found in environment: %w", toolName, err)
 			}
@@ -362,6 +418,10 @@ func (s *DeclarativeSync) createAgentFromFile(ctx context.Context, filePath, age
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	if err := s.syncAgentStepPolicy(ctx, createdAgent.ID, config); err != nil {
+		logging.Info("Warning: Failed to sync step policy for agent %s: %v", agentName, err)
+	}
+
 	logging.Info("✅ Created agent: %s", agentName)
 	return &SyncOperation{
 		Type:        OpTypeCreate,
@@ -371,7 +431,7 @@ func (s *DeclarativeSync) createAgentFromFile(ctx context.Context, filePath, age
 }
 
 // updateAgentFromFile updates an existing agent in the database from a .prompt file
-func (s *DeclarativeSync) updateAgentFromFile(ctx context.Context, existingAgent *models.Agent, config *DotPromptConfig, promptContent, checksum string) (*SyncOperation, error) {
+func (s *DeclarativeSync) updateAgentFromFile(ctx context.Context, existingAgent *models.Agent, environmentName string, config *DotPromptConfig, promptContent, checksum string, options SyncOptions) (*SyncOperation, error) {
 	// Extract configuration values with defaults
 	maxSteps := existingAgent.MaxSteps // keep existing
 
@@ -617,6 +677,17 @@ func (s *DeclarativeSync) updateAgentFromFile(ctx context.Context, existingAgent
 		}, nil
 	}
 
+	// Build the diff of what this sync is about to change and confirm it
+	// before applying (--yes skips the prompt)
+	diff := s.buildAgentSyncDiff(existingAgent, environmentName, config, promptContent, description, maxSteps, newCronSchedule)
+	if diff.HasChanges() && !confirmAgentUpdate(diff, options) {
+		return &SyncOperation{
+			Type:        OpTypeSkip,
+			Target:      existingAgent.Name,
+			Description: "Update declined by user",
+		}, nil
+	}
+
 	// Start transaction for atomic agent update + tool sync
 	tx, err := s.repos.BeginTx()
 	if err != nil {
@@ -702,8 +773,9 @@ func (s *DeclarativeSync) updateAgentFromFile(ctx context.Context, existingAgent
 					continue
 				}
 
-				// Find tool by name in environment
-				tool, err := s.repos.MCPTools.FindByNameInEnvironment(existingAgent.EnvironmentID, toolName)
+				// Find tool by name in environment (supports fully-qualified
+				// serverName__toolName for disambiguating collisions)
+				tool, err := resolveToolInEnvironment(s.repos, existingAgent.EnvironmentID, toolName)
 				if err != nil {
 					// Log warning but continue - tool might be added later when MCP server connects
 					fmt.Printf("   ⚠️  Tool %s not found in environment (will retry on next sync)\n", toolName)
@@ -798,6 +870,17 @@ func (s *DeclarativeSync) updateAgentFromFile(ctx context.Context, existingAgent
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	if err := s.syncAgentStepPolicy(ctx, existingAgent.ID, config); err != nil {
+		logging.Info("Warning: Failed to sync step policy for agent %s: %v", existingAgent.Name, err)
+	}
+
+	// Record the applied diff in the sync history for auditing
+	if diff.HasChanges() {
+		if err := recordAgentSyncDiff(s.repos, diff); err != nil {
+			logging.Debug("Failed to record sync diff for agent %s: %v", existingAgent.Name, err)
+		}
+	}
+
 	updateType := OpTypeUpdate
 	if !needsUpdate && toolsNeedSync {
 		updateType = OpTypeUpdate // Still an update even if only tools changed
@@ -811,6 +894,90 @@ func (s *DeclarativeSync) updateAgentFromFile(ctx context.Context, existingAgent
 	}, nil
 }
 
+// syncAgentStepPolicy applies the step_policy frontmatter block to the
+// database, removing any stored policy when the block is absent or empty
+func (s *DeclarativeSync) syncAgentStepPolicy(ctx context.Context, agentID int64, config *DotPromptConfig) error {
+	policy := config.StepPolicy
+	if policy.MaxTurns <= 0 && policy.PerTurnTimeoutSeconds <= 0 && !policy.RequireToolCall {
+		return s.repos.AgentStepPolicies.Delete(ctx, agentID)
+	}
+
+	var maxTurns, perTurnTimeout *int64
+	if policy.MaxTurns > 0 {
+		maxTurns = &policy.MaxTurns
+	}
+	if policy.PerTurnTimeoutSeconds > 0 {
+		perTurnTimeout = &policy.PerTurnTimeoutSeconds
+	}
+	_, err := s.repos.AgentStepPolicies.Upsert(ctx, agentID, maxTurns, perTurnTimeout, policy.RequireToolCall)
+	return err
+}
+
+// buildAgentSyncDiff collects the prompt, metadata, and tool list changes a
+// sync is about to apply to an existing agent
+func (s *DeclarativeSync) buildAgentSyncDiff(existingAgent *models.Agent, environmentName string, config *DotPromptConfig, promptContent, description string, maxSteps int64, newCronSchedule string) *AgentSyncDiff {
+	diff := &AgentSyncDiff{
+		Environment: environmentName,
+		AgentName:   existingAgent.Name,
+	}
+
+	if existingAgent.Description != description {
+		diff.Fields = append(diff.Fields, AgentFieldChange{
+			Field: "description",
+			Old:   existingAgent.Description,
+			New:   description,
+		})
+	}
+	if existingAgent.MaxSteps != maxSteps {
+		diff.Fields = append(diff.Fields, AgentFieldChange{
+			Field: "max_steps",
+			Old:   fmt.Sprintf("%d", existingAgent.MaxSteps),
+			New:   fmt.Sprintf("%d", maxSteps),
+		})
+	}
+	currentCron := ""
+	if existingAgent.CronSchedule != nil {
+		currentCron = *existingAgent.CronSchedule
+	}
+	if currentCron != newCronSchedule {
+		diff.Fields = append(diff.Fields, AgentFieldChange{
+			Field: "cron_schedule",
+			Old:   currentCron,
+			New:   newCronSchedule,
+		})
+	}
+
+	if existingAgent.Prompt != promptContent {
+		diff.PromptDiff = diffLines(existingAgent.Prompt, promptContent)
+	}
+
+	// Tool list changes
+	currentTools, err := s.repos.AgentTools.ListAgentTools(existingAgent.ID)
+	if err == nil {
+		currentToolSet := make(map[string]bool)
+		for _, tool := range currentTools {
+			currentToolSet[tool.ToolName] = true
+		}
+		configToolSet := make(map[string]bool)
+		for _, toolName := range config.Tools {
+			configToolSet[toolName] = true
+		}
+
+		for _, tool := range currentTools {
+			if !configToolSet[tool.ToolName] {
+				diff.ToolsRemoved = append(diff.ToolsRemoved, tool.ToolName)
+			}
+		}
+		for _, toolName := range config.Tools {
+			if !currentToolSet[toolName] {
+				diff.ToolsAdded = append(diff.ToolsAdded, toolName)
+			}
+		}
+	}
+
+	return diff
+}
+
 // calculateFileChecksum calculates MD5 checksum of a file
 func (s *DeclarativeSync) calculateFileChecksum(filePath string) (string, error) {
 	file, err := os.Open(filePath)
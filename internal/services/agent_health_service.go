@@ -0,0 +1,347 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+const (
+	// Health probe definitions live in the settings table: one JSON
+	// AgentHealthProbe document per agent under "health.agent.<agent_id>"
+	healthProbeSettingPrefix = "health.agent."
+	// Probe results are stored under "health.status.agent.<agent_id>"
+	healthStatusSettingPrefix = "health.status.agent."
+	// Optional alert webhook override (falls back to notify.webhook_url)
+	healthAlertWebhookSettingKey = "health.alert_webhook_url"
+
+	healthProbeTickInterval     = time.Minute
+	healthProbeDefaultInterval  = 60 // minutes
+	healthProbeResponsePreview  = 500
+	healthProbeExecutionTimeout = 5 * time.Minute
+)
+
+// AgentHealthProbe defines a synthetic heartbeat task for a scheduled agent:
+// a minimal known-good input executed on its own cadence with
+// expected-output assertions, catching broken MCP credentials or providers
+// before the real scheduled run matters. Set via:
+//
+//	stn settings set health.agent.2 '{"task": "List the files in /workspace", "expect_contains": ["main.go"], "interval_minutes": 60}'
+type AgentHealthProbe struct {
+	Task            string   `json:"task"`
+	ExpectContains  []string `json:"expect_contains,omitempty"`
+	IntervalMinutes int      `json:"interval_minutes,omitempty"`
+}
+
+// AgentHealthStatus is the stored result of the most recent heartbeat probe
+type AgentHealthStatus struct {
+	AgentID             int64  `json:"agent_id"`
+	Status              string `json:"status"` // healthy | unhealthy
+	Message             string `json:"message,omitempty"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	LastProbeAt         string `json:"last_probe_at"`
+}
+
+// Healthy reports whether the last probe passed
+func (s *AgentHealthStatus) Healthy() bool {
+	return s.Status == "healthy"
+}
+
+// heartbeatExecutor runs a probe task against an agent; satisfied by
+// AgentServiceInterface.ExecuteAgent in serve mode and stubbed in tests
+type heartbeatExecutor func(ctx context.Context, agentID int64, task string) (string, error)
+
+// AgentHealthService executes synthetic heartbeat tasks for scheduled agents
+// on their own cadence. Failed probes (execution errors or missing expected
+// output) flip the agent to unhealthy — visible in `stn agent list` — and
+// fire a webhook alert on the transition.
+type AgentHealthService struct {
+	repos      *repositories.Repositories
+	execute    heartbeatExecutor
+	webhookURL string
+	client     *http.Client
+	stopChan   chan struct{}
+
+	mu         sync.Mutex
+	lastProbes map[int64]time.Time
+}
+
+// NewAgentHealthService creates a health probe service. The alert webhook
+// comes from the "health.alert_webhook_url" setting when present, otherwise
+// from the notify webhook config.
+func NewAgentHealthService(repos *repositories.Repositories, agentService AgentServiceInterface, cfg *config.Config) *AgentHealthService {
+	webhookURL := ""
+	if cfg != nil {
+		webhookURL = cfg.Notify.WebhookURL
+	}
+	if setting, err := repos.Settings.GetByKey(healthAlertWebhookSettingKey); err == nil && setting.Value != "" {
+		webhookURL = setting.Value
+	}
+
+	svc := &AgentHealthService{
+		repos:      repos,
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		stopChan:   make(chan struct{}),
+		lastProbes: make(map[int64]time.Time),
+	}
+	if agentService != nil {
+		svc.execute = func(ctx context.Context, agentID int64, task string) (string, error) {
+			message, err := agentService.ExecuteAgent(ctx, agentID, task, nil)
+			if err != nil {
+				return "", err
+			}
+			return message.Content, nil
+		}
+	}
+	return svc
+}
+
+// Start begins periodic heartbeat probing (serve mode)
+func (s *AgentHealthService) Start() {
+	go func() {
+		ticker := time.NewTicker(healthProbeTickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.probeAll(context.Background())
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+	logging.Info("Agent health probes started (checking cadences every %s)", healthProbeTickInterval)
+}
+
+// Stop halts periodic probing
+func (s *AgentHealthService) Stop() {
+	close(s.stopChan)
+}
+
+// GetProbe loads the heartbeat definition for an agent from settings;
+// returns nil when no probe is defined
+func (s *AgentHealthService) GetProbe(agentID int64) (*AgentHealthProbe, error) {
+	setting, err := s.repos.Settings.GetByKey(fmt.Sprintf("%s%d", healthProbeSettingPrefix, agentID))
+	if err != nil {
+		return nil, nil
+	}
+
+	var probe AgentHealthProbe
+	if err := json.Unmarshal([]byte(setting.Value), &probe); err != nil {
+		return nil, fmt.Errorf("invalid health probe for agent %d: %w", agentID, err)
+	}
+	if probe.Task == "" {
+		return nil, fmt.Errorf("health probe for agent %d has no task", agentID)
+	}
+	return &probe, nil
+}
+
+// GetStatus returns the stored health status for an agent, or nil if it was
+// never probed
+func (s *AgentHealthService) GetStatus(agentID int64) (*AgentHealthStatus, error) {
+	setting, err := s.repos.Settings.GetByKey(fmt.Sprintf("%s%d", healthStatusSettingPrefix, agentID))
+	if err != nil {
+		return nil, nil
+	}
+
+	var status AgentHealthStatus
+	if err := json.Unmarshal([]byte(setting.Value), &status); err != nil {
+		return nil, fmt.Errorf("invalid stored health status for agent %d: %w", agentID, err)
+	}
+	return &status, nil
+}
+
+// UnhealthyAgents returns the set of agents currently failing their
+// heartbeat, keyed by agent ID (used by `stn agent list`)
+func UnhealthyAgents(repos *repositories.Repositories) map[int64]string {
+	settings, err := repos.Settings.GetAll()
+	if err != nil {
+		return nil
+	}
+
+	unhealthy := make(map[int64]string)
+	for _, setting := range settings {
+		if !strings.HasPrefix(setting.Key, healthStatusSettingPrefix) {
+			continue
+		}
+		var status AgentHealthStatus
+		if err := json.Unmarshal([]byte(setting.Value), &status); err != nil {
+			continue
+		}
+		if !status.Healthy() {
+			unhealthy[status.AgentID] = status.Message
+		}
+	}
+	return unhealthy
+}
+
+// ProbeAgent executes one heartbeat probe and stores the resulting status,
+// alerting when the agent transitions from healthy to unhealthy
+func (s *AgentHealthService) ProbeAgent(ctx context.Context, agentID int64, probe *AgentHealthProbe) (*AgentHealthStatus, error) {
+	if s.execute == nil {
+		return nil, fmt.Errorf("health probes require an agent execution backend")
+	}
+
+	previous, _ := s.GetStatus(agentID)
+
+	probeCtx, cancel := context.WithTimeout(ctx, healthProbeExecutionTimeout)
+	defer cancel()
+
+	response, err := s.execute(probeCtx, agentID, probe.Task)
+	status := &AgentHealthStatus{
+		AgentID:     agentID,
+		Status:      "healthy",
+		LastProbeAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if err != nil {
+		status.Status = "unhealthy"
+		status.Message = fmt.Sprintf("heartbeat execution failed: %v", err)
+	} else {
+		for _, expected := range probe.ExpectContains {
+			if !strings.Contains(response, expected) {
+				status.Status = "unhealthy"
+				status.Message = fmt.Sprintf("heartbeat response missing expected output %q (got: %s)",
+					expected, truncateForPrompt(response, healthProbeResponsePreview))
+				break
+			}
+		}
+	}
+
+	if !status.Healthy() {
+		if previous != nil {
+			status.ConsecutiveFailures = previous.ConsecutiveFailures + 1
+		} else {
+			status.ConsecutiveFailures = 1
+		}
+		// Alert once per transition, not on every failing probe
+		if previous == nil || previous.Healthy() {
+			s.sendAlert(agentID, status)
+		}
+	}
+
+	if err := s.saveStatus(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// probeAll runs due heartbeats for every scheduled agent with a probe
+func (s *AgentHealthService) probeAll(ctx context.Context) {
+	settings, err := s.repos.Settings.GetAll()
+	if err != nil {
+		logging.Debug("Health probes: failed to load settings: %v", err)
+		return
+	}
+
+	for _, setting := range settings {
+		if !strings.HasPrefix(setting.Key, healthProbeSettingPrefix) {
+			continue
+		}
+		agentID, err := strconv.ParseInt(strings.TrimPrefix(setting.Key, healthProbeSettingPrefix), 10, 64)
+		if err != nil {
+			logging.Debug("Health probes: skipping malformed setting key %s", setting.Key)
+			continue
+		}
+
+		probe, err := s.GetProbe(agentID)
+		if err != nil || probe == nil {
+			if err != nil {
+				logging.Info("Health probes: %v", err)
+			}
+			continue
+		}
+
+		// Heartbeats only matter for scheduled agents: they exist to catch
+		// broken credentials before the real scheduled run does
+		agent, err := s.repos.Agents.GetByID(agentID)
+		if err != nil || !agent.ScheduleEnabled {
+			continue
+		}
+
+		if !s.probeDue(agentID, probe) {
+			continue
+		}
+
+		if _, err := s.ProbeAgent(ctx, agentID, probe); err != nil {
+			logging.Debug("Health probes: failed to probe agent %d: %v", agentID, err)
+		}
+	}
+}
+
+// probeDue enforces the per-agent probe cadence
+func (s *AgentHealthService) probeDue(agentID int64, probe *AgentHealthProbe) bool {
+	interval := time.Duration(probe.IntervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = healthProbeDefaultInterval * time.Minute
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.lastProbes[agentID]; ok && time.Since(last) < interval {
+		return false
+	}
+	s.lastProbes[agentID] = time.Now()
+	return true
+}
+
+func (s *AgentHealthService) saveStatus(status *AgentHealthStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s%d", healthStatusSettingPrefix, status.AgentID)
+	if err := s.repos.Settings.Set(key, string(data), "Agent heartbeat probe status"); err != nil {
+		return fmt.Errorf("failed to store health status for agent %d: %w", status.AgentID, err)
+	}
+	return nil
+}
+
+// sendAlert posts an unhealthy-transition notification to the configured
+// webhook, mirroring the SLO monitor's Slack-compatible payload shape
+func (s *AgentHealthService) sendAlert(agentID int64, status *AgentHealthStatus) {
+	agentName := fmt.Sprintf("%d", agentID)
+	if agent, err := s.repos.Agents.GetByID(agentID); err == nil {
+		agentName = agent.Name
+	}
+
+	if s.webhookURL == "" {
+		logging.Info("Agent '%s' (%d) is unhealthy: %s (no alert webhook configured)", agentName, agentID, status.Message)
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"text":       fmt.Sprintf("🚨 Station agent '%s' failed its heartbeat probe: %s", agentName, status.Message),
+		"agent_id":   agentID,
+		"agent_name": agentName,
+		"status":     status.Status,
+		"message":    status.Message,
+	})
+	if err != nil {
+		return
+	}
+
+	resp, err := s.client.Post(s.webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		logging.Info("Health probes: failed to send alert for agent %d: %v", agentID, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		logging.Info("Health probes: alert webhook returned status %d for agent %d", resp.StatusCode, agentID)
+	} else {
+		logging.Info("Health probes: alert sent for agent %s (%d)", agentName, agentID)
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func setupHealthTest(t *testing.T) (*AgentHealthService, *repositories.Repositories, int64) {
+	t.Helper()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("health-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("health-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	cron := "0 0 * * * *"
+	agent, err := repos.Agents.Create("Scheduled Agent", "heartbeat target", "prompt", 5, env.ID, user.ID,
+		nil, &cron, true, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	return NewAgentHealthService(repos, nil, nil), repos, agent.ID
+}
+
+func TestGetProbeFromSettings(t *testing.T) {
+	svc, repos, agentID := setupHealthTest(t)
+
+	// No probe defined
+	probe, err := svc.GetProbe(agentID)
+	if err != nil || probe != nil {
+		t.Fatalf("expected nil probe for unconfigured agent, got %+v, %v", probe, err)
+	}
+
+	key := fmt.Sprintf("%s%d", healthProbeSettingPrefix, agentID)
+	if err := repos.Settings.Set(key, `{"task": "list files", "expect_contains": ["main.go"], "interval_minutes": 30}`, ""); err != nil {
+		t.Fatalf("failed to store probe: %v", err)
+	}
+
+	probe, err = svc.GetProbe(agentID)
+	if err != nil {
+		t.Fatalf("GetProbe returned error: %v", err)
+	}
+	if probe.Task != "list files" || probe.IntervalMinutes != 30 || len(probe.ExpectContains) != 1 {
+		t.Errorf("unexpected probe: %+v", probe)
+	}
+
+	// A probe without a task is rejected
+	if err := repos.Settings.Set(key, `{"expect_contains": ["x"]}`, ""); err != nil {
+		t.Fatalf("failed to store probe: %v", err)
+	}
+	if _, err := svc.GetProbe(agentID); err == nil {
+		t.Error("expected error for probe without task")
+	}
+}
+
+func TestProbeAgentAssertsExpectedOutput(t *testing.T) {
+	svc, repos, agentID := setupHealthTest(t)
+	probe := &AgentHealthProbe{Task: "heartbeat", ExpectContains: []string{"OK"}}
+
+	// Passing probe: response contains the expected output
+	svc.execute = func(ctx context.Context, id int64, task string) (string, error) {
+		return "status: OK", nil
+	}
+	status, err := svc.ProbeAgent(context.Background(), agentID, probe)
+	if err != nil {
+		t.Fatalf("ProbeAgent returned error: %v", err)
+	}
+	if !status.Healthy() {
+		t.Fatalf("expected healthy status, got %+v", status)
+	}
+
+	// Failing probe: expected output missing
+	svc.execute = func(ctx context.Context, id int64, task string) (string, error) {
+		return "authentication failed", nil
+	}
+	status, err = svc.ProbeAgent(context.Background(), agentID, probe)
+	if err != nil {
+		t.Fatalf("ProbeAgent returned error: %v", err)
+	}
+	if status.Healthy() || status.ConsecutiveFailures != 1 {
+		t.Fatalf("expected unhealthy status with 1 failure, got %+v", status)
+	}
+	if !strings.Contains(status.Message, "missing expected output") {
+		t.Errorf("expected assertion message, got %q", status.Message)
+	}
+
+	// Execution errors also flip the agent and increment the failure count
+	svc.execute = func(ctx context.Context, id int64, task string) (string, error) {
+		return "", fmt.Errorf("MCP server unreachable")
+	}
+	status, err = svc.ProbeAgent(context.Background(), agentID, probe)
+	if err != nil {
+		t.Fatalf("ProbeAgent returned error: %v", err)
+	}
+	if status.Healthy() || status.ConsecutiveFailures != 2 {
+		t.Fatalf("expected unhealthy status with 2 failures, got %+v", status)
+	}
+
+	// The stored status surfaces in the unhealthy set for `stn agent list`
+	unhealthy := UnhealthyAgents(repos)
+	if _, bad := unhealthy[agentID]; !bad {
+		t.Errorf("expected agent %d in unhealthy set, got %+v", agentID, unhealthy)
+	}
+
+	// Recovery clears the unhealthy state
+	svc.execute = func(ctx context.Context, id int64, task string) (string, error) {
+		return "OK", nil
+	}
+	if _, err := svc.ProbeAgent(context.Background(), agentID, probe); err != nil {
+		t.Fatalf("ProbeAgent returned error: %v", err)
+	}
+	if unhealthy := UnhealthyAgents(repos); len(unhealthy) != 0 {
+		t.Errorf("expected no unhealthy agents after recovery, got %+v", unhealthy)
+	}
+}
+
+func TestProbeDueEnforcesCadence(t *testing.T) {
+	svc, _, agentID := setupHealthTest(t)
+	probe := &AgentHealthProbe{Task: "heartbeat", IntervalMinutes: 30}
+
+	if !svc.probeDue(agentID, probe) {
+		t.Fatal("expected first probe to be due")
+	}
+	if svc.probeDue(agentID, probe) {
+		t.Error("expected second probe within the interval to be skipped")
+	}
+}
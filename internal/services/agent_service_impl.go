@@ -197,6 +197,13 @@ func NewAgentServiceWithLighthouse(repos *repositories.Repositories, lighthouseC
 
 // ExecuteAgent executes an agent with a specific task and optional user variables
 func (s *AgentService) ExecuteAgent(ctx context.Context, agentID int64, task string, userVariables map[string]interface{}) (*Message, error) {
+	// Reject new executions while the server is draining for a restart
+	drain := SharedDrainCoordinator()
+	if err := drain.TrackExecution(); err != nil {
+		return nil, err
+	}
+	defer drain.UntrackExecution()
+
 	// Start telemetry span
 	startTime := time.Now()
 	ctx, span := s.telemetry.StartSpan(ctx, "agent.execute",
@@ -318,6 +325,13 @@ func (s *AgentService) ExecuteAgent(ctx context.Context, agentID int64, task str
 
 // ExecuteAgentWithRunID executes an agent with proper run ID for logging - used by ExecutionQueueService
 func (s *AgentService) ExecuteAgentWithRunID(ctx context.Context, agentID int64, task string, runID int64, userVariables map[string]interface{}) (*Message, error) {
+	// Reject new executions while the server is draining for a restart
+	drain := SharedDrainCoordinator()
+	if err := drain.TrackExecution(); err != nil {
+		return nil, err
+	}
+	defer drain.UntrackExecution()
+
 	// Start telemetry span with parent-child relationship tracking
 	startTime := time.Now()
 
@@ -569,9 +583,11 @@ func (s *AgentService) UpdateAgent(ctx context.Context, agentID int64, config *A
 	return s.repos.Agents.GetByID(agentID)
 }
 
-// DeleteAgent deletes an agent
+// DeleteAgent soft-deletes an agent: the database row (with its run history
+// and tool assignments) is kept until the retention window expires, and the
+// .prompt file is parked so a restore brings the agent back intact
 func (s *AgentService) DeleteAgent(ctx context.Context, agentID int64) error {
-	// Get agent details before deletion for file cleanup
+	// Get agent details before deletion for file parking
 	agent, err := s.repos.Agents.GetByID(agentID)
 	if err != nil {
 		return fmt.Errorf("failed to get agent %d for deletion: %w", agentID, err)
@@ -583,22 +599,89 @@ func (s *AgentService) DeleteAgent(ctx context.Context, agentID int64) error {
 		return fmt.Errorf("failed to get environment %d for agent %d: %w", agent.EnvironmentID, agentID, err)
 	}
 
-	// Clear tool assignments first
-	if err := s.repos.AgentTools.Clear(agentID); err != nil {
-		return fmt.Errorf("failed to clear tool assignments: %w", err)
+	// Soft-delete the agent in the database
+	if err := s.repos.Agents.SoftDelete(ctx, agentID); err != nil {
+		return fmt.Errorf("failed to delete agent from database: %w", err)
+	}
+
+	// Park the .prompt file so sync stops recreating the agent but a
+	// restore can bring the file back
+	if err := s.parkAgentPromptFile(agent.Name, environment.Name); err != nil {
+		log.Printf("Warning: Failed to park .prompt file for agent %s: %v", agent.Name, err)
+		// Don't fail the entire operation if file parking fails
 	}
 
-	// Delete the agent from database
-	if err := s.repos.Agents.Delete(agentID); err != nil {
-		return fmt.Errorf("failed to delete agent from database: %w", err)
+	return nil
+}
+
+// RestoreAgent undoes a soft deletion and moves the parked .prompt file
+// back into the environment's agents directory
+func (s *AgentService) RestoreAgent(ctx context.Context, agentID int64) error {
+	if err := s.repos.Agents.Restore(ctx, agentID); err != nil {
+		return fmt.Errorf("failed to restore agent %d: %w", agentID, err)
 	}
 
-	// Clean up .prompt file from filesystem
-	if err := s.deleteAgentPromptFile(agent.Name, environment.Name); err != nil {
-		log.Printf("Warning: Failed to delete .prompt file for agent %s: %v", agent.Name, err)
-		// Don't fail the entire operation if file cleanup fails
+	agent, err := s.repos.Agents.GetByID(agentID)
+	if err != nil {
+		return fmt.Errorf("failed to get restored agent %d: %w", agentID, err)
+	}
+	environment, err := s.repos.Environments.GetByID(agent.EnvironmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get environment %d for agent %d: %w", agent.EnvironmentID, agentID, err)
 	}
 
+	if err := s.unparkAgentPromptFile(agent.Name, environment.Name); err != nil {
+		log.Printf("Warning: Failed to restore .prompt file for agent %s: %v", agent.Name, err)
+	}
+	return nil
+}
+
+// agentPromptFilePath returns the live .prompt file location for an agent
+func agentPromptFilePath(agentName, environmentName string) string {
+	return filepath.Join(config.GetEnvironmentDir(environmentName), "agents", agentName+".prompt")
+}
+
+// parkedAgentPromptPath is where a soft-deleted agent's .prompt file is
+// parked until the agent is restored or purged
+func parkedAgentPromptPath(agentName, environmentName string) string {
+	return filepath.Join(config.GetEnvironmentDir(environmentName), "agents", ".trash", agentName+".prompt")
+}
+
+// parkAgentPromptFile moves an agent's .prompt file into the trash
+// directory so declarative sync no longer sees it
+func (s *AgentService) parkAgentPromptFile(agentName, environmentName string) error {
+	promptFilePath := agentPromptFilePath(agentName, environmentName)
+	if _, err := os.Stat(promptFilePath); os.IsNotExist(err) {
+		return nil // Not an error if file doesn't exist
+	}
+
+	parkedPath := parkedAgentPromptPath(agentName, environmentName)
+	if err := os.MkdirAll(filepath.Dir(parkedPath), 0755); err != nil {
+		return fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	if err := os.Rename(promptFilePath, parkedPath); err != nil {
+		return fmt.Errorf("failed to park .prompt file %s: %w", promptFilePath, err)
+	}
+
+	log.Printf("Parked .prompt file: %s", parkedPath)
+	return nil
+}
+
+// unparkAgentPromptFile moves a parked .prompt file back, unless a new
+// file with the same name has appeared in the meantime
+func (s *AgentService) unparkAgentPromptFile(agentName, environmentName string) error {
+	parkedPath := parkedAgentPromptPath(agentName, environmentName)
+	if _, err := os.Stat(parkedPath); os.IsNotExist(err) {
+		return nil // Nothing parked
+	}
+
+	promptFilePath := agentPromptFilePath(agentName, environmentName)
+	if _, err := os.Stat(promptFilePath); err == nil {
+		return fmt.Errorf("%s already exists; parked file left at %s", promptFilePath, parkedPath)
+	}
+	if err := os.Rename(parkedPath, promptFilePath); err != nil {
+		return fmt.Errorf("failed to restore .prompt file %s: %w", promptFilePath, err)
+	}
 	return nil
 }
 
@@ -642,7 +725,8 @@ func (s *AgentService) assignToolsToAgent(agentID int64, toolNames []string, env
 
 	for _, toolName := range toolNames {
 		// Try to find the tool in the MCP tools table within the specified environment
-		tool, err := s.repos.MCPTools.FindByNameInEnvironment(environmentID, toolName)
+		// (supports fully-qualified serverName__toolName for disambiguating collisions)
+		tool, err := resolveToolInEnvironment(s.repos, environmentID, toolName)
 		if err != nil {
 			// Tool doesn't exist in MCP tools table, create it
 			log.Printf("Creating new MCP tool entry for: %s", toolName)
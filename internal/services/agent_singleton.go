@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"station/internal/config"
+	"station/internal/logging"
+	"station/pkg/models"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Singleton overlap policies control what happens when a run is requested
+// while another run of the same agent is still active.
+const (
+	SingletonPolicyQueue   = "queue"   // wait for the active run to finish (default)
+	SingletonPolicySkip    = "skip"    // reject the new run request
+	SingletonPolicyReplace = "replace" // cancel the active run, then start the new one
+)
+
+// SingletonConfig represents the `singleton` section of a .prompt file's
+// frontmatter. When enabled, overlapping runs of the agent are serialized
+// according to the configured policy. This prevents scheduled agents that
+// overrun their interval from piling up concurrent executions.
+type SingletonConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Policy  string `yaml:"policy"` // queue (default), skip, or replace
+}
+
+// ErrAgentRunActive is returned when a singleton agent with the `skip`
+// policy receives a run request while another run is still active.
+var ErrAgentRunActive = fmt.Errorf("agent already has an active run (singleton policy: skip)")
+
+// singletonSlot tracks the active run of one singleton agent
+type singletonSlot struct {
+	mu     sync.Mutex         // held for the duration of the active run
+	cancel context.CancelFunc // cancels the active run (used by the replace policy)
+}
+
+// agentSingletonGuard serializes runs of singleton agents per agent ID.
+// It is process-wide so CLI, API, MCP, and scheduler executions all share
+// the same locks.
+type agentSingletonGuard struct {
+	mu    sync.Mutex
+	slots map[int64]*singletonSlot
+}
+
+var singletonGuard = &agentSingletonGuard{slots: make(map[int64]*singletonSlot)}
+
+func (g *agentSingletonGuard) slot(agentID int64) *singletonSlot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s, ok := g.slots[agentID]
+	if !ok {
+		s = &singletonSlot{}
+		g.slots[agentID] = s
+	}
+	return s
+}
+
+// Acquire takes the agent's run lock according to the given policy. On
+// success it returns a derived context for the run and a release function
+// that must be called when the run finishes.
+func (g *agentSingletonGuard) Acquire(ctx context.Context, agentID int64, policy string) (context.Context, func(), error) {
+	slot := g.slot(agentID)
+
+	switch policy {
+	case SingletonPolicySkip:
+		if !slot.mu.TryLock() {
+			return nil, nil, fmt.Errorf("agent %d: %w", agentID, ErrAgentRunActive)
+		}
+
+	case SingletonPolicyReplace:
+		if !slot.mu.TryLock() {
+			g.mu.Lock()
+			if slot.cancel != nil {
+				logging.Info("Singleton agent %d: cancelling active run (policy: replace)", agentID)
+				slot.cancel()
+			}
+			g.mu.Unlock()
+			slot.mu.Lock()
+		}
+
+	default: // SingletonPolicyQueue
+		slot.mu.Lock()
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	g.mu.Lock()
+	slot.cancel = cancel
+	g.mu.Unlock()
+
+	release := func() {
+		g.mu.Lock()
+		if slot.cancel != nil {
+			slot.cancel()
+			slot.cancel = nil
+		}
+		g.mu.Unlock()
+		slot.mu.Unlock()
+	}
+
+	return runCtx, release, nil
+}
+
+// loadSingletonConfig reads the agent's singleton settings from its .prompt
+// file frontmatter. The filesystem is the source of truth for agent config,
+// so this is read at execution time rather than stored in the database.
+// Any error (missing file, bad YAML) is treated as singleton disabled.
+func loadSingletonConfig(agent *models.Agent, environmentName string) SingletonConfig {
+	environmentDir := config.GetEnvironmentDir(environmentName)
+	promptFilePath := filepath.Join(environmentDir, "agents", agent.Name+".prompt")
+
+	content, err := os.ReadFile(promptFilePath)
+	if err != nil {
+		logging.Debug("Singleton config: could not read %s: %v", promptFilePath, err)
+		return SingletonConfig{}
+	}
+
+	return parseSingletonConfig(string(content))
+}
+
+// parseSingletonConfig extracts the `singleton` section from .prompt file
+// content. Unknown policies fall back to queue.
+func parseSingletonConfig(content string) SingletonConfig {
+	parts := strings.Split(content, "---")
+	if len(parts) < 3 {
+		return SingletonConfig{}
+	}
+
+	var frontmatter struct {
+		Singleton SingletonConfig `yaml:"singleton"`
+	}
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(parts[1])), &frontmatter); err != nil {
+		logging.Debug("Singleton config: failed to parse frontmatter: %v", err)
+		return SingletonConfig{}
+	}
+
+	cfg := frontmatter.Singleton
+	switch cfg.Policy {
+	case SingletonPolicyQueue, SingletonPolicySkip, SingletonPolicyReplace:
+	default:
+		cfg.Policy = SingletonPolicyQueue
+	}
+	return cfg
+}
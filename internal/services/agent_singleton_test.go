@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseSingletonConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected SingletonConfig
+	}{
+		{
+			name: "enabled with skip policy",
+			content: `---
+model: gpt-4o-mini
+singleton:
+  enabled: true
+  policy: skip
+---
+prompt body`,
+			expected: SingletonConfig{Enabled: true, Policy: "skip"},
+		},
+		{
+			name: "enabled without policy defaults to queue",
+			content: `---
+singleton:
+  enabled: true
+---
+prompt body`,
+			expected: SingletonConfig{Enabled: true, Policy: "queue"},
+		},
+		{
+			name: "unknown policy falls back to queue",
+			content: `---
+singleton:
+  enabled: true
+  policy: bogus
+---
+prompt body`,
+			expected: SingletonConfig{Enabled: true, Policy: "queue"},
+		},
+		{
+			name:     "no frontmatter",
+			content:  "just a prompt",
+			expected: SingletonConfig{},
+		},
+		{
+			name: "no singleton section",
+			content: `---
+model: gpt-4o-mini
+---
+prompt body`,
+			expected: SingletonConfig{Enabled: false, Policy: "queue"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := parseSingletonConfig(tt.content)
+			if cfg.Enabled != tt.expected.Enabled {
+				t.Errorf("Enabled = %v, want %v", cfg.Enabled, tt.expected.Enabled)
+			}
+			if cfg.Policy != tt.expected.Policy {
+				t.Errorf("Policy = %q, want %q", cfg.Policy, tt.expected.Policy)
+			}
+		})
+	}
+}
+
+func TestSingletonGuard_SkipPolicy(t *testing.T) {
+	guard := &agentSingletonGuard{slots: make(map[int64]*singletonSlot)}
+
+	_, release, err := guard.Acquire(context.Background(), 1, SingletonPolicySkip)
+	if err != nil {
+		t.Fatalf("first acquire failed: %v", err)
+	}
+
+	_, _, err = guard.Acquire(context.Background(), 1, SingletonPolicySkip)
+	if !errors.Is(err, ErrAgentRunActive) {
+		t.Errorf("expected ErrAgentRunActive, got %v", err)
+	}
+
+	// A different agent is unaffected
+	_, otherRelease, err := guard.Acquire(context.Background(), 2, SingletonPolicySkip)
+	if err != nil {
+		t.Errorf("other agent acquire failed: %v", err)
+	}
+	otherRelease()
+
+	release()
+
+	// Lock is reacquirable after release
+	_, release, err = guard.Acquire(context.Background(), 1, SingletonPolicySkip)
+	if err != nil {
+		t.Errorf("acquire after release failed: %v", err)
+	}
+	release()
+}
+
+func TestSingletonGuard_QueuePolicy(t *testing.T) {
+	guard := &agentSingletonGuard{slots: make(map[int64]*singletonSlot)}
+
+	_, release, err := guard.Acquire(context.Background(), 1, SingletonPolicyQueue)
+	if err != nil {
+		t.Fatalf("first acquire failed: %v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		_, secondRelease, err := guard.Acquire(context.Background(), 1, SingletonPolicyQueue)
+		if err == nil {
+			secondRelease()
+		}
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second acquire should block while the first run is active")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("second acquire should proceed after release")
+	}
+}
+
+func TestSingletonGuard_ReplacePolicy(t *testing.T) {
+	guard := &agentSingletonGuard{slots: make(map[int64]*singletonSlot)}
+
+	firstCtx, _, err := guard.Acquire(context.Background(), 1, SingletonPolicyReplace)
+	if err != nil {
+		t.Fatalf("first acquire failed: %v", err)
+	}
+
+	// The replacing run cancels the active one and takes over the lock once
+	// the cancelled run releases it
+	done := make(chan struct{})
+	go func() {
+		_, secondRelease, err := guard.Acquire(context.Background(), 1, SingletonPolicyReplace)
+		if err != nil {
+			t.Errorf("replace acquire failed: %v", err)
+		} else {
+			secondRelease()
+		}
+		close(done)
+	}()
+
+	select {
+	case <-firstCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("first run context should be cancelled by the replace policy")
+	}
+
+	// Simulate the cancelled run finishing and releasing its lock
+	guard.slot(1).mu.Unlock()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("replacing run should acquire the lock after the old run exits")
+	}
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// AgentSkill is a shareable package of tool assignments plus a prompt
+// fragment. Skills live as .skill files in the environment's skills/
+// directory (next to agents/), so bundles carry them automatically. Agents
+// attach skills via a "skills:" list in their .prompt frontmatter and sync
+// composes the agent's prompt and toolset from them — removing copy-paste
+// across similar agents.
+type AgentSkill struct {
+	Name        string
+	Description string
+	Tools       []string
+	Prompt      string
+}
+
+// skillFrontmatter is the YAML frontmatter of a .skill file. The format
+// mirrors agent .prompt files: frontmatter declares tools, the body is the
+// prompt fragment appended to attaching agents.
+type skillFrontmatter struct {
+	Metadata map[string]interface{} `yaml:"metadata"`
+	Tools    []string               `yaml:"tools"`
+}
+
+// skillsDirForAgentFile resolves the environment's skills directory from an
+// agent .prompt file path (agents/ and skills/ are siblings)
+func skillsDirForAgentFile(agentFilePath string) string {
+	return filepath.Join(filepath.Dir(filepath.Dir(agentFilePath)), "skills")
+}
+
+// loadAgentSkills loads the named skills from the environment's skills
+// directory. A missing skill is an error: a typo should fail sync loudly
+// rather than silently produce an agent without its tools.
+func loadAgentSkills(skillsDir string, names []string) ([]*AgentSkill, error) {
+	skills := make([]*AgentSkill, 0, len(names))
+	for _, name := range names {
+		skillPath := filepath.Join(skillsDir, name+".skill")
+		content, err := os.ReadFile(skillPath)
+		if err != nil {
+			return nil, fmt.Errorf("skill '%s' not found at %s: %w", name, skillPath, err)
+		}
+
+		skill, err := parseSkillFile(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse skill '%s': %w", name, err)
+		}
+		if skill.Name == "" {
+			skill.Name = name
+		}
+		skills = append(skills, skill)
+	}
+	return skills, nil
+}
+
+// parseSkillFile parses a .skill file with YAML frontmatter and a prompt
+// fragment body, using the same "---" delimiter convention as .prompt files
+func parseSkillFile(content string) (*AgentSkill, error) {
+	parts := strings.Split(content, "---")
+	if len(parts) < 3 {
+		// No frontmatter: the whole file is a prompt fragment
+		return &AgentSkill{Prompt: strings.TrimSpace(content)}, nil
+	}
+
+	yamlContent := strings.TrimSpace(parts[1])
+	promptFragment := strings.TrimSpace(strings.Join(parts[2:], "---"))
+
+	var frontmatter skillFrontmatter
+	if yamlContent != "" {
+		if err := yaml.Unmarshal([]byte(yamlContent), &frontmatter); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML frontmatter: %w", err)
+		}
+	}
+
+	skill := &AgentSkill{
+		Tools:  frontmatter.Tools,
+		Prompt: promptFragment,
+	}
+	if frontmatter.Metadata != nil {
+		if name, ok := frontmatter.Metadata["name"].(string); ok {
+			skill.Name = name
+		}
+		if desc, ok := frontmatter.Metadata["description"].(string); ok {
+			skill.Description = desc
+		}
+	}
+
+	return skill, nil
+}
+
+// mergeSkillTools appends skill tools to the agent's own tool list,
+// preserving order and dropping duplicates
+func mergeSkillTools(agentTools []string, skills []*AgentSkill) []string {
+	seen := make(map[string]bool, len(agentTools))
+	merged := make([]string, 0, len(agentTools))
+	for _, tool := range agentTools {
+		if !seen[tool] {
+			seen[tool] = true
+			merged = append(merged, tool)
+		}
+	}
+	for _, skill := range skills {
+		for _, tool := range skill.Tools {
+			if !seen[tool] {
+				seen[tool] = true
+				merged = append(merged, tool)
+			}
+		}
+	}
+	return merged
+}
+
+// composeSkillPrompt appends each skill's prompt fragment to the agent's
+// prompt under a labeled section so composed content is traceable
+func composeSkillPrompt(agentPrompt string, skills []*AgentSkill) string {
+	var builder strings.Builder
+	builder.WriteString(agentPrompt)
+	for _, skill := range skills {
+		if skill.Prompt == "" {
+			continue
+		}
+		builder.WriteString("\n\n<!-- skill: ")
+		builder.WriteString(skill.Name)
+		builder.WriteString(" -->\n")
+		builder.WriteString(skill.Prompt)
+	}
+	return builder.String()
+}
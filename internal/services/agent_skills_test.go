@@ -0,0 +1,125 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const terraformSkillFixture = `---
+metadata:
+  name: "terraform-analysis"
+  description: "Terraform security scanning tools and guidance"
+tools:
+  - "__checkov_scan_directory"
+  - "__tflint_directory"
+---
+
+When analyzing Terraform, always run checkov before tflint and prioritize
+critical misconfigurations over style issues.`
+
+func TestParseSkillFile(t *testing.T) {
+	skill, err := parseSkillFile(terraformSkillFixture)
+	if err != nil {
+		t.Fatalf("parseSkillFile failed: %v", err)
+	}
+
+	if skill.Name != "terraform-analysis" {
+		t.Errorf("Expected name terraform-analysis, got %q", skill.Name)
+	}
+	if skill.Description != "Terraform security scanning tools and guidance" {
+		t.Errorf("Unexpected description: %q", skill.Description)
+	}
+	if len(skill.Tools) != 2 || skill.Tools[0] != "__checkov_scan_directory" {
+		t.Errorf("Unexpected tools: %v", skill.Tools)
+	}
+	if !strings.Contains(skill.Prompt, "run checkov before tflint") {
+		t.Errorf("Expected prompt fragment preserved, got %q", skill.Prompt)
+	}
+}
+
+func TestParseSkillFileWithoutFrontmatter(t *testing.T) {
+	skill, err := parseSkillFile("Just a prompt fragment with no frontmatter.")
+	if err != nil {
+		t.Fatalf("parseSkillFile failed: %v", err)
+	}
+	if len(skill.Tools) != 0 {
+		t.Errorf("Expected no tools, got %v", skill.Tools)
+	}
+	if skill.Prompt != "Just a prompt fragment with no frontmatter." {
+		t.Errorf("Unexpected prompt: %q", skill.Prompt)
+	}
+}
+
+func TestLoadAgentSkills(t *testing.T) {
+	envDir := t.TempDir()
+	skillsDir := filepath.Join(envDir, "skills")
+	if err := os.MkdirAll(skillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillsDir, "terraform-analysis.skill"), []byte(terraformSkillFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	skills, err := loadAgentSkills(skillsDir, []string{"terraform-analysis"})
+	if err != nil {
+		t.Fatalf("loadAgentSkills failed: %v", err)
+	}
+	if len(skills) != 1 || skills[0].Name != "terraform-analysis" {
+		t.Errorf("Unexpected skills: %+v", skills)
+	}
+
+	if _, err := loadAgentSkills(skillsDir, []string{"missing-skill"}); err == nil {
+		t.Error("Expected error for missing skill")
+	}
+}
+
+func TestSkillsDirForAgentFile(t *testing.T) {
+	agentFile := "/home/user/.config/station/environments/default/agents/Scanner.prompt"
+	expected := "/home/user/.config/station/environments/default/skills"
+	if got := skillsDirForAgentFile(agentFile); got != expected {
+		t.Errorf("skillsDirForAgentFile = %q, want %q", got, expected)
+	}
+}
+
+func TestMergeSkillTools(t *testing.T) {
+	skills := []*AgentSkill{
+		{Name: "terraform-analysis", Tools: []string{"__checkov_scan_directory", "__read_text_file"}},
+		{Name: "k8s-debugging", Tools: []string{"__kube_bench", "__checkov_scan_directory"}},
+	}
+
+	merged := mergeSkillTools([]string{"__read_text_file", "__list_directory"}, skills)
+
+	expected := []string{"__read_text_file", "__list_directory", "__checkov_scan_directory", "__kube_bench"}
+	if len(merged) != len(expected) {
+		t.Fatalf("Expected %d tools, got %d: %v", len(expected), len(merged), merged)
+	}
+	for i, tool := range expected {
+		if merged[i] != tool {
+			t.Errorf("Expected tool %d to be %s, got %s", i, tool, merged[i])
+		}
+	}
+}
+
+func TestComposeSkillPrompt(t *testing.T) {
+	skills := []*AgentSkill{
+		{Name: "terraform-analysis", Prompt: "Run checkov first."},
+		{Name: "empty-skill"},
+	}
+
+	composed := composeSkillPrompt("You are a security scanner.", skills)
+
+	if !strings.HasPrefix(composed, "You are a security scanner.") {
+		t.Errorf("Expected agent prompt first, got %q", composed)
+	}
+	if !strings.Contains(composed, "<!-- skill: terraform-analysis -->") {
+		t.Errorf("Expected skill section marker, got %q", composed)
+	}
+	if !strings.Contains(composed, "Run checkov first.") {
+		t.Errorf("Expected skill fragment, got %q", composed)
+	}
+	if strings.Contains(composed, "empty-skill") {
+		t.Errorf("Skill with no prompt should not add a section, got %q", composed)
+	}
+}
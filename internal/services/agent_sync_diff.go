@@ -0,0 +1,182 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"station/internal/db/repositories"
+)
+
+const (
+	// agentSyncDiffHistoryKey is the settings key holding the audit trail of
+	// applied agent diffs, newest first
+	agentSyncDiffHistoryKey = "sync.agent_diffs"
+	// agentSyncDiffHistoryLimit caps how many applied diffs are retained
+	agentSyncDiffHistoryLimit = 50
+
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiBold  = "\033[1m"
+	ansiReset = "\033[0m"
+)
+
+// AgentFieldChange records one changed metadata field on an agent
+type AgentFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// AgentSyncDiff captures what a sync run is about to change (or changed) on an
+// agent: metadata fields, the prompt, and the tool list
+type AgentSyncDiff struct {
+	Environment  string             `json:"environment"`
+	AgentName    string             `json:"agent_name"`
+	Fields       []AgentFieldChange `json:"fields,omitempty"`
+	PromptDiff   []string           `json:"prompt_diff,omitempty"`
+	ToolsAdded   []string           `json:"tools_added,omitempty"`
+	ToolsRemoved []string           `json:"tools_removed,omitempty"`
+	AppliedAt    string             `json:"applied_at,omitempty"`
+}
+
+// HasChanges reports whether the diff contains anything to show
+func (d *AgentSyncDiff) HasChanges() bool {
+	return len(d.Fields) > 0 || len(d.PromptDiff) > 0 || len(d.ToolsAdded) > 0 || len(d.ToolsRemoved) > 0
+}
+
+// Render formats the diff for the terminal with removed lines in red and
+// added lines in green
+func (d *AgentSyncDiff) Render() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s📝 Changes for agent '%s':%s\n", ansiBold, d.AgentName, ansiReset)
+
+	for _, change := range d.Fields {
+		fmt.Fprintf(&b, "   %s:\n", change.Field)
+		fmt.Fprintf(&b, "   %s- %s%s\n", ansiRed, change.Old, ansiReset)
+		fmt.Fprintf(&b, "   %s+ %s%s\n", ansiGreen, change.New, ansiReset)
+	}
+
+	if len(d.PromptDiff) > 0 {
+		fmt.Fprintf(&b, "   prompt:\n")
+		for _, line := range d.PromptDiff {
+			switch {
+			case strings.HasPrefix(line, "-"):
+				fmt.Fprintf(&b, "   %s%s%s\n", ansiRed, line, ansiReset)
+			case strings.HasPrefix(line, "+"):
+				fmt.Fprintf(&b, "   %s%s%s\n", ansiGreen, line, ansiReset)
+			default:
+				fmt.Fprintf(&b, "   %s\n", line)
+			}
+		}
+	}
+
+	for _, tool := range d.ToolsRemoved {
+		fmt.Fprintf(&b, "   %s- tool: %s%s\n", ansiRed, tool, ansiReset)
+	}
+	for _, tool := range d.ToolsAdded {
+		fmt.Fprintf(&b, "   %s+ tool: %s%s\n", ansiGreen, tool, ansiReset)
+	}
+
+	return b.String()
+}
+
+// diffLines produces a minimal line diff between two texts using an LCS walk:
+// removed lines prefixed with "-", added lines with "+", and unchanged lines
+// omitted (only the changed hunks matter for a sync preview)
+func diffLines(oldText, newText string) []string {
+	oldLines := strings.Split(oldText, "\n")
+	newLines := strings.Split(newText, "\n")
+
+	// LCS table
+	m, n := len(oldLines), len(newLines)
+	lcs := make([][]int, m+1)
+	for i := range lcs {
+		lcs[i] = make([]int, n+1)
+	}
+	for i := m - 1; i >= 0; i-- {
+		for j := n - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < m && j < n {
+		if oldLines[i] == newLines[j] {
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			diff = append(diff, "-"+oldLines[i])
+			i++
+		} else {
+			diff = append(diff, "+"+newLines[j])
+			j++
+		}
+	}
+	for ; i < m; i++ {
+		diff = append(diff, "-"+oldLines[i])
+	}
+	for ; j < n; j++ {
+		diff = append(diff, "+"+newLines[j])
+	}
+	return diff
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal; prompts
+// are skipped for piped input, tests, and server-side syncs
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirmAgentUpdate shows the diff and asks the user to approve it; --yes
+// (SyncOptions.Confirm) skips the prompt, and non-interactive syncs apply
+// without asking
+func confirmAgentUpdate(diff *AgentSyncDiff, options SyncOptions) bool {
+	fmt.Print(diff.Render())
+
+	if options.Confirm || !options.Interactive || !stdinIsTerminal() {
+		return true
+	}
+
+	fmt.Printf("Apply these changes to agent '%s'? (y/N): ", diff.AgentName)
+	var response string
+	fmt.Scanln(&response)
+	return strings.ToLower(strings.TrimSpace(response)) == "y"
+}
+
+// recordAgentSyncDiff appends an applied diff to the sync history so changes
+// can be audited later (`stn settings get sync.agent_diffs`)
+func recordAgentSyncDiff(repos *repositories.Repositories, diff *AgentSyncDiff) error {
+	diff.AppliedAt = time.Now().UTC().Format(time.RFC3339)
+
+	var history []AgentSyncDiff
+	if setting, err := repos.Settings.GetByKey(agentSyncDiffHistoryKey); err == nil {
+		if err := json.Unmarshal([]byte(setting.Value), &history); err != nil {
+			history = nil
+		}
+	}
+
+	history = append([]AgentSyncDiff{*diff}, history...)
+	if len(history) > agentSyncDiffHistoryLimit {
+		history = history[:agentSyncDiffHistoryLimit]
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return repos.Settings.Set(agentSyncDiffHistoryKey, string(data), "Applied agent sync diffs, newest first")
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []string
+	}{
+		{
+			name: "no changes",
+			old:  "a\nb",
+			new:  "a\nb",
+			want: nil,
+		},
+		{
+			name: "line changed",
+			old:  "You are a scanner.\nScan terraform files.",
+			new:  "You are a scanner.\nScan docker files.",
+			want: []string{"-Scan terraform files.", "+Scan docker files."},
+		},
+		{
+			name: "line added",
+			old:  "a",
+			new:  "a\nb",
+			want: []string{"+b"},
+		},
+		{
+			name: "line removed",
+			old:  "a\nb",
+			new:  "b",
+			want: []string{"-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffLines(tt.old, tt.new)
+			if len(got) != len(tt.want) {
+				t.Fatalf("diffLines() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("diffLines()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAgentSyncDiffRender(t *testing.T) {
+	diff := &AgentSyncDiff{
+		AgentName:    "Security Scanner",
+		Fields:       []AgentFieldChange{{Field: "max_steps", Old: "5", New: "8"}},
+		PromptDiff:   []string{"-old instruction", "+new instruction"},
+		ToolsAdded:   []string{"__semgrep_scan"},
+		ToolsRemoved: []string{"__tflint_directory"},
+	}
+
+	if !diff.HasChanges() {
+		t.Fatal("expected diff to have changes")
+	}
+
+	out := diff.Render()
+	for _, expected := range []string{"max_steps", "-old instruction", "+new instruction", "+ tool: __semgrep_scan", "- tool: __tflint_directory"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("rendered diff missing %q:\n%s", expected, out)
+		}
+	}
+
+	empty := &AgentSyncDiff{AgentName: "Unchanged"}
+	if empty.HasChanges() {
+		t.Error("expected empty diff to have no changes")
+	}
+}
+
+func TestRecordAgentSyncDiffHistory(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+	repos := repositories.New(testDB)
+
+	first := &AgentSyncDiff{Environment: "default", AgentName: "Scanner", ToolsAdded: []string{"__semgrep_scan"}}
+	second := &AgentSyncDiff{Environment: "default", AgentName: "Auditor", Fields: []AgentFieldChange{{Field: "max_steps", Old: "5", New: "8"}}}
+
+	if err := recordAgentSyncDiff(repos, first); err != nil {
+		t.Fatalf("failed to record first diff: %v", err)
+	}
+	if err := recordAgentSyncDiff(repos, second); err != nil {
+		t.Fatalf("failed to record second diff: %v", err)
+	}
+
+	setting, err := repos.Settings.GetByKey(agentSyncDiffHistoryKey)
+	if err != nil {
+		t.Fatalf("failed to load sync history: %v", err)
+	}
+
+	var history []AgentSyncDiff
+	if err := json.Unmarshal([]byte(setting.Value), &history); err != nil {
+		t.Fatalf("invalid stored history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(history))
+	}
+	// Newest first
+	if history[0].AgentName != "Auditor" || history[1].AgentName != "Scanner" {
+		t.Errorf("unexpected history order: %s, %s", history[0].AgentName, history[1].AgentName)
+	}
+	if history[0].AppliedAt == "" {
+		t.Error("expected AppliedAt timestamp on recorded diff")
+	}
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+const (
+	// Default registry queried alongside any private registries
+	officialBundleRegistryURL = "https://registry.cloudshipai.com"
+
+	// Private registries are configured as a JSON array in settings:
+	//
+	//	stn settings set bundle.registries '[{"name": "internal", "url": "https://bundles.corp.example.com"}]'
+	bundleRegistriesSettingKey = "bundle.registries"
+)
+
+// BundleRegistry is a registry endpoint that serves an index.json of bundles
+type BundleRegistry struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// RegistryBundle is a bundle listing from a registry index
+type RegistryBundle struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Version     string   `json:"version"`
+	Author      string   `json:"author"`
+	Tags        []string `json:"tags"`
+	Downloads   int64    `json:"downloads"`
+	Rating      float64  `json:"rating"`
+	DownloadURL string   `json:"download_url"`
+	MetadataURL string   `json:"metadata_url"`
+	Registry    string   `json:"registry"`
+}
+
+// RegistryBundleVariable describes a template variable a bundle requires
+type RegistryBundleVariable struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Default     string `json:"default"`
+}
+
+// RegistryBundleAgent describes an agent included in a bundle manifest
+type RegistryBundleAgent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Model       string `json:"model"`
+}
+
+// RegistryBundleDetails combines the index listing with the bundle manifest
+type RegistryBundleDetails struct {
+	RegistryBundle
+	Variables map[string]RegistryBundleVariable `json:"variables"`
+	Agents    []RegistryBundleAgent             `json:"agents"`
+}
+
+type bundleRegistryIndex struct {
+	Bundles []RegistryBundle `json:"bundles"`
+}
+
+// BundleRegistryService queries configured bundle registries for search,
+// info, and install-by-name. The official registry is always queried;
+// private registries come from the bundle.registries setting.
+type BundleRegistryService struct {
+	repos  *repositories.Repositories
+	client *http.Client
+}
+
+// NewBundleRegistryService creates a bundle registry client
+func NewBundleRegistryService(repos *repositories.Repositories) *BundleRegistryService {
+	return &BundleRegistryService{
+		repos:  repos,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Registries returns the official registry plus any configured private ones
+func (s *BundleRegistryService) Registries() []BundleRegistry {
+	registries := []BundleRegistry{{Name: "official", URL: officialBundleRegistryURL}}
+
+	setting, err := s.repos.Settings.GetByKey(bundleRegistriesSettingKey)
+	if err != nil {
+		return registries
+	}
+
+	var private []BundleRegistry
+	if err := json.Unmarshal([]byte(setting.Value), &private); err != nil {
+		logging.Info("Bundle registry: invalid %s setting: %v", bundleRegistriesSettingKey, err)
+		return registries
+	}
+	for _, reg := range private {
+		if reg.Name != "" && reg.URL != "" {
+			registries = append(registries, reg)
+		}
+	}
+	return registries
+}
+
+// Search queries every configured registry and returns bundles matching the
+// term in their name, ID, description, or tags. An empty term lists
+// everything. Unreachable registries are skipped with a log line so a dead
+// private registry doesn't hide official results.
+func (s *BundleRegistryService) Search(ctx context.Context, term string) ([]RegistryBundle, error) {
+	registries := s.Registries()
+	var results []RegistryBundle
+	var failures []string
+
+	for _, registry := range registries {
+		bundles, err := s.fetchIndex(ctx, registry)
+		if err != nil {
+			logging.Info("Bundle registry: %s unavailable: %v", registry.Name, err)
+			failures = append(failures, registry.Name)
+			continue
+		}
+		for _, bundle := range bundles {
+			if bundleMatchesTerm(bundle, term) {
+				results = append(results, bundle)
+			}
+		}
+	}
+
+	if len(failures) == len(registries) {
+		return nil, fmt.Errorf("no registries reachable (%s)", strings.Join(failures, ", "))
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Downloads != results[j].Downloads {
+			return results[i].Downloads > results[j].Downloads
+		}
+		return results[i].Name < results[j].Name
+	})
+	return results, nil
+}
+
+// Resolve finds a bundle by exact ID or name across all registries
+func (s *BundleRegistryService) Resolve(ctx context.Context, name string) (*RegistryBundle, error) {
+	bundles, err := s.Search(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bundle := range bundles {
+		if bundle.ID == name || strings.EqualFold(bundle.Name, name) {
+			return &bundle, nil
+		}
+	}
+	return nil, fmt.Errorf("bundle %q not found in any configured registry (try 'stn bundle search %s')", name, name)
+}
+
+// Info resolves a bundle and enriches it with its manifest (required
+// variables and included agents) when the registry serves one
+func (s *BundleRegistryService) Info(ctx context.Context, name string) (*RegistryBundleDetails, error) {
+	bundle, err := s.Resolve(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &RegistryBundleDetails{RegistryBundle: *bundle}
+	if bundle.MetadataURL == "" {
+		return details, nil
+	}
+
+	var manifest struct {
+		Variables map[string]RegistryBundleVariable `json:"variables"`
+		Agents    []RegistryBundleAgent             `json:"agents"`
+	}
+	if err := s.fetchJSON(ctx, bundle.MetadataURL, &manifest); err != nil {
+		logging.Info("Bundle registry: failed to fetch manifest for %s: %v", bundle.Name, err)
+		return details, nil
+	}
+	details.Variables = manifest.Variables
+	details.Agents = manifest.Agents
+	return details, nil
+}
+
+// fetchIndex downloads a registry's index.json and labels each bundle with
+// the registry it came from
+func (s *BundleRegistryService) fetchIndex(ctx context.Context, registry BundleRegistry) ([]RegistryBundle, error) {
+	indexURL := strings.TrimSuffix(registry.URL, "/") + "/index.json"
+
+	var index bundleRegistryIndex
+	if err := s.fetchJSON(ctx, indexURL, &index); err != nil {
+		return nil, err
+	}
+
+	for i := range index.Bundles {
+		index.Bundles[i].Registry = registry.Name
+	}
+	return index.Bundles, nil
+}
+
+func (s *BundleRegistryService) fetchJSON(ctx context.Context, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d from %s", resp.StatusCode, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// bundleMatchesTerm reports whether a search term matches the bundle's ID,
+// name, description, or tags (case-insensitive substring match)
+func bundleMatchesTerm(bundle RegistryBundle, term string) bool {
+	if term == "" {
+		return true
+	}
+	term = strings.ToLower(term)
+	if strings.Contains(strings.ToLower(bundle.ID), term) ||
+		strings.Contains(strings.ToLower(bundle.Name), term) ||
+		strings.Contains(strings.ToLower(bundle.Description), term) {
+		return true
+	}
+	for _, tag := range bundle.Tags {
+		if strings.Contains(strings.ToLower(tag), term) {
+			return true
+		}
+	}
+	return false
+}
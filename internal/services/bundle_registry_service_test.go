@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func newRegistryTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"bundles":[
+			{"id":"security-bundle","name":"Security Bundle","description":"Terraform and container scanning","version":"1.2.0","author":"cloudshipai","tags":["security","terraform"],"downloads":420,"rating":4.5,"download_url":"https://example.com/security-bundle.tar.gz","metadata_url":"METADATA_URL"},
+			{"id":"finops-bundle","name":"FinOps Bundle","description":"Cloud cost analysis agents","version":"0.9.0","author":"cloudshipai","tags":["finops"],"downloads":87,"download_url":"https://example.com/finops-bundle.tar.gz"}
+		]}`)
+	})
+	mux.HandleFunc("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"variables":{"PROJECT_ROOT":{"type":"string","description":"Root path to scan","required":true}},
+			"agents":[{"name":"Security Scanner","description":"Scans terraform and containers","model":"gpt-4o-mini"}]
+		}`)
+	})
+	return httptest.NewServer(mux)
+}
+
+func setupRegistryService(t *testing.T, registryURL string) (*BundleRegistryService, *repositories.Repositories) {
+	t.Helper()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+	if err := repos.Settings.Set(bundleRegistriesSettingKey,
+		fmt.Sprintf(`[{"name": "test", "url": "%s"}]`, registryURL), "test registry"); err != nil {
+		t.Fatalf("failed to configure registry: %v", err)
+	}
+
+	return &BundleRegistryService{repos: repos, client: &http.Client{Timeout: 5 * time.Second}}, repos
+}
+
+func TestBundleRegistrySearch(t *testing.T) {
+	server := newRegistryTestServer()
+	defer server.Close()
+
+	svc, _ := setupRegistryService(t, server.URL)
+	ctx := context.Background()
+
+	// Empty term lists everything from the reachable registry; the official
+	// registry is unreachable in tests and must not fail the search
+	bundles, err := svc.Search(ctx, "")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(bundles) != 2 {
+		t.Fatalf("expected 2 bundles, got %d", len(bundles))
+	}
+	if bundles[0].ID != "security-bundle" {
+		t.Errorf("expected most-downloaded bundle first, got %s", bundles[0].ID)
+	}
+	if bundles[0].Registry != "test" {
+		t.Errorf("expected registry label 'test', got %s", bundles[0].Registry)
+	}
+
+	// Term matches tags as well as names
+	bundles, err = svc.Search(ctx, "terraform")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(bundles) != 1 || bundles[0].ID != "security-bundle" {
+		t.Errorf("expected only security-bundle for 'terraform', got %d results", len(bundles))
+	}
+
+	bundles, err = svc.Search(ctx, "nonexistent")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(bundles) != 0 {
+		t.Errorf("expected no results for 'nonexistent', got %d", len(bundles))
+	}
+}
+
+func TestBundleRegistryResolve(t *testing.T) {
+	server := newRegistryTestServer()
+	defer server.Close()
+
+	svc, _ := setupRegistryService(t, server.URL)
+	ctx := context.Background()
+
+	bundle, err := svc.Resolve(ctx, "finops-bundle")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if bundle.DownloadURL != "https://example.com/finops-bundle.tar.gz" {
+		t.Errorf("unexpected download URL: %s", bundle.DownloadURL)
+	}
+
+	// Resolves by display name too, case-insensitively
+	if _, err := svc.Resolve(ctx, "security bundle"); err != nil {
+		t.Errorf("expected name resolution to succeed: %v", err)
+	}
+
+	if _, err := svc.Resolve(ctx, "missing-bundle"); err == nil {
+		t.Error("expected error for unknown bundle")
+	}
+}
+
+func TestBundleRegistryInfo(t *testing.T) {
+	server := newRegistryTestServer()
+	defer server.Close()
+
+	// Point the index's metadata_url at the test server's manifest
+	manifestURL := server.URL + "/manifest.json"
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"bundles":[{"id":"security-bundle","name":"Security Bundle","description":"scanning","version":"1.2.0","download_url":"https://example.com/b.tar.gz","metadata_url":"%s"}]}`, manifestURL)
+	}))
+	defer proxy.Close()
+
+	svc, _ := setupRegistryService(t, proxy.URL)
+	details, err := svc.Info(context.Background(), "security-bundle")
+	if err != nil {
+		t.Fatalf("Info returned error: %v", err)
+	}
+	if len(details.Agents) != 1 || details.Agents[0].Name != "Security Scanner" {
+		t.Errorf("expected manifest agents to be resolved, got %+v", details.Agents)
+	}
+	variable, ok := details.Variables["PROJECT_ROOT"]
+	if !ok || !variable.Required {
+		t.Errorf("expected required PROJECT_ROOT variable, got %+v", details.Variables)
+	}
+}
+
+func TestBundleRegistryRegistries(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	svc := NewBundleRegistryService(repos)
+
+	// Official registry is always present even with nothing configured
+	registries := svc.Registries()
+	if len(registries) != 1 || registries[0].Name != "official" {
+		t.Fatalf("expected only the official registry, got %+v", registries)
+	}
+
+	if err := repos.Settings.Set(bundleRegistriesSettingKey,
+		`[{"name": "internal", "url": "https://bundles.corp.example.com"}, {"name": "", "url": "ignored"}]`, ""); err != nil {
+		t.Fatalf("failed to save registries: %v", err)
+	}
+	registries = svc.Registries()
+	if len(registries) != 2 {
+		t.Fatalf("expected official + internal, got %+v", registries)
+	}
+	if registries[1].Name != "internal" {
+		t.Errorf("expected internal registry, got %s", registries[1].Name)
+	}
+}
+
+func TestBundleMatchesTerm(t *testing.T) {
+	bundle := RegistryBundle{
+		ID:          "security-bundle",
+		Name:        "Security Bundle",
+		Description: "Terraform scanning",
+		Tags:        []string{"devops"},
+	}
+
+	tests := []struct {
+		term string
+		want bool
+	}{
+		{term: "", want: true},
+		{term: "SECURITY", want: true},
+		{term: "terraform", want: true},
+		{term: "devops", want: true},
+		{term: "finops", want: false},
+	}
+	for _, tt := range tests {
+		if got := bundleMatchesTerm(bundle, tt.term); got != tt.want {
+			t.Errorf("bundleMatchesTerm(%q) = %v, want %v", tt.term, got, tt.want)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"station/internal/config"
 	"station/internal/db/repositories"
 	"station/internal/version"
+	pkgbenchmark "station/pkg/benchmark"
 )
 
 // BundleService handles environment bundling using the same logic as the API
@@ -341,6 +342,7 @@ func (s *BundleService) generateManifest(sourceDir string) (*BundleManifest, err
 		MCPServers:            []MCPServerManifestInfo{},
 		Tools:                 []ToolManifestInfo{},
 		Workflows:             []WorkflowManifestInfo{},
+		BenchmarkSuites:       []BenchmarkSuiteManifestInfo{},
 		AgentMCPRelationships: []AgentMCPRelationship{},
 		RequiredVariables:     []VariableRequirement{},
 		Reports:               []ReportManifestInfo{},
@@ -454,9 +456,82 @@ func (s *BundleService) generateManifest(sourceDir string) (*BundleManifest, err
 		}
 	}
 
+	// Parse benchmark suite files
+	benchmarksDir := filepath.Join(sourceDir, "benchmarks")
+	if _, err := os.Stat(benchmarksDir); err == nil {
+		suiteFiles, err := os.ReadDir(benchmarksDir)
+		if err == nil {
+			for _, sf := range suiteFiles {
+				if isBenchmarkSuiteFile(sf.Name()) {
+					suiteInfo, err := s.parseBenchmarkSuiteFile(filepath.Join(benchmarksDir, sf.Name()))
+					if err == nil {
+						manifest.BenchmarkSuites = append(manifest.BenchmarkSuites, *suiteInfo)
+					}
+				}
+			}
+		}
+	}
+
+	// A bundle is a complete capability: every agent its workflows run must
+	// be packaged alongside them
+	if err := manifest.validateAgentReferences(); err != nil {
+		return nil, err
+	}
+
 	return manifest, nil
 }
 
+// isBenchmarkSuiteFile reports whether a benchmarks/ entry is a suite file
+func isBenchmarkSuiteFile(name string) bool {
+	return strings.HasSuffix(name, ".yaml") ||
+		strings.HasSuffix(name, ".yml") ||
+		strings.HasSuffix(name, ".json")
+}
+
+// parseBenchmarkSuiteFile extracts metadata from a benchmark suite file
+func (s *BundleService) parseBenchmarkSuiteFile(filePath string) (*BenchmarkSuiteManifestInfo, error) {
+	suite, err := pkgbenchmark.LoadSuiteFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &BenchmarkSuiteManifestInfo{
+		Path:        filepath.Base(filePath),
+		Name:        suite.Name,
+		Description: suite.Description,
+		TaskCount:   len(suite.Tasks),
+	}
+
+	categorySet := make(map[string]bool)
+	for _, task := range suite.Tasks {
+		if task.Category != "" && !categorySet[task.Category] {
+			categorySet[task.Category] = true
+			info.Categories = append(info.Categories, task.Category)
+		}
+	}
+
+	return info, nil
+}
+
+// validateAgentReferences checks that every agent referenced by a bundled
+// workflow is itself included in the bundle, so an installed bundle does not
+// ship workflows that cannot run
+func (m *BundleManifest) validateAgentReferences() error {
+	agentNames := make(map[string]bool, len(m.Agents))
+	for _, agent := range m.Agents {
+		agentNames[agent.Name] = true
+	}
+
+	for _, workflow := range m.Workflows {
+		for _, agent := range workflow.Agents {
+			if !agentNames[agent] {
+				return fmt.Errorf("workflow %q references agent %q which is not included in the bundle", workflow.ID, agent)
+			}
+		}
+	}
+	return nil
+}
+
 // parseDatasetDir extracts metadata from a dataset directory
 func (s *BundleService) parseDatasetDir(dirPath string) (*DatasetManifestInfo, error) {
 	datasetFile := filepath.Join(dirPath, "dataset.json")
@@ -1022,6 +1097,7 @@ func (s *BundleService) GetBundleInfo(environmentPath string) (*BundleInfo, erro
 		AgentFiles:      []string{},
 		MCPConfigs:      []string{},
 		WorkflowFiles:   []string{},
+		BenchmarkFiles:  []string{},
 		OtherFiles:      []string{},
 	}
 
@@ -1058,6 +1134,8 @@ func (s *BundleService) GetBundleInfo(environmentPath string) (*BundleInfo, erro
 				strings.HasSuffix(relPath, ".workflow.yml") ||
 				strings.HasSuffix(relPath, ".workflow.json")) {
 			info.WorkflowFiles = append(info.WorkflowFiles, relPath)
+		} else if strings.HasPrefix(relPath, "benchmarks/") && isBenchmarkSuiteFile(relPath) {
+			info.BenchmarkFiles = append(info.BenchmarkFiles, relPath)
 		} else if strings.HasSuffix(relPath, ".json") && !strings.Contains(relPath, "/") {
 			info.MCPConfigs = append(info.MCPConfigs, relPath)
 		} else {
@@ -1079,21 +1157,23 @@ type BundleInfo struct {
 	AgentFiles      []string `json:"agent_files"`
 	MCPConfigs      []string `json:"mcp_configs"`
 	WorkflowFiles   []string `json:"workflow_files"`
+	BenchmarkFiles  []string `json:"benchmark_files"`
 	OtherFiles      []string `json:"other_files"`
 }
 
 // BundleManifest represents the complete metadata for a bundle
 type BundleManifest struct {
-	Version               string                  `json:"version"`
-	Bundle                BundleMetadata          `json:"bundle"`
-	Agents                []AgentManifestInfo     `json:"agents"`
-	MCPServers            []MCPServerManifestInfo `json:"mcp_servers"`
-	Tools                 []ToolManifestInfo      `json:"tools"`
-	Workflows             []WorkflowManifestInfo  `json:"workflows,omitempty"`
-	AgentMCPRelationships []AgentMCPRelationship  `json:"agent_mcp_relationships"`
-	RequiredVariables     []VariableRequirement   `json:"required_variables"`
-	Reports               []ReportManifestInfo    `json:"reports,omitempty"`
-	Datasets              []DatasetManifestInfo   `json:"datasets,omitempty"`
+	Version               string                       `json:"version"`
+	Bundle                BundleMetadata               `json:"bundle"`
+	Agents                []AgentManifestInfo          `json:"agents"`
+	MCPServers            []MCPServerManifestInfo      `json:"mcp_servers"`
+	Tools                 []ToolManifestInfo           `json:"tools"`
+	Workflows             []WorkflowManifestInfo       `json:"workflows,omitempty"`
+	BenchmarkSuites       []BenchmarkSuiteManifestInfo `json:"benchmark_suites,omitempty"`
+	AgentMCPRelationships []AgentMCPRelationship       `json:"agent_mcp_relationships"`
+	RequiredVariables     []VariableRequirement        `json:"required_variables"`
+	Reports               []ReportManifestInfo         `json:"reports,omitempty"`
+	Datasets              []DatasetManifestInfo        `json:"datasets,omitempty"`
 }
 
 // ReportManifestInfo contains report metadata for the manifest
@@ -1122,6 +1202,15 @@ type DatasetManifestInfo struct {
 	QualityScore  *float64 `json:"quality_score,omitempty"`
 }
 
+// BenchmarkSuiteManifestInfo contains benchmark suite metadata for the manifest
+type BenchmarkSuiteManifestInfo struct {
+	Path        string   `json:"path"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	TaskCount   int      `json:"task_count"`
+	Categories  []string `json:"categories,omitempty"`
+}
+
 // WorkflowManifestInfo contains workflow metadata for the manifest
 type WorkflowManifestInfo struct {
 	ID          string   `json:"id"`
@@ -1180,13 +1269,18 @@ type AgentMCPRelationship struct {
 	MCPServers []string `json:"mcp_servers"`
 }
 
-// VariableRequirement describes a required template variable
+// VariableRequirement describes a required template variable.
+// Type is one of: string, int, bool, enum, secret, url (empty means string).
+// Enum lists the allowed values for enum variables; Pattern is an optional
+// regex every provided value must match.
 type VariableRequirement struct {
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
 	Type        string      `json:"type"`
 	Required    bool        `json:"required"`
 	Default     interface{} `json:"default,omitempty"`
+	Enum        []string    `json:"enum,omitempty"`
+	Pattern     string      `json:"pattern,omitempty"`
 }
 
 type BundleInstallResult struct {
@@ -1197,6 +1291,7 @@ type BundleInstallResult struct {
 	InstalledAgents    int    `json:"installed_agents"`
 	InstalledMCPs      int    `json:"installed_mcps"`
 	InstalledWorkflows int    `json:"installed_workflows"`
+	InstalledSuites    int    `json:"installed_benchmark_suites"`
 	Error              string `json:"error,omitempty"`
 }
 
@@ -1244,6 +1339,20 @@ func (s *BundleService) InstallBundleWithOptions(bundleLocation, environmentName
 		}
 	}
 
+	// Validate bundle integrity before touching the environment: workflows
+	// must not reference agents missing from the bundle. Legacy bundles
+	// without a manifest skip this check.
+	if bundleData, err := os.ReadFile(bundlePath); err == nil {
+		if manifest, err := s.ExtractManifestFromTarGz(bundleData); err == nil {
+			if err := manifest.validateAgentReferences(); err != nil {
+				return &BundleInstallResult{
+					Success: false,
+					Error:   fmt.Sprintf("Invalid bundle: %v", err),
+				}, err
+			}
+		}
+	}
+
 	// Environment directory path - use config helper
 	envDir := config.GetEnvironmentDir(environmentName)
 
@@ -1300,7 +1409,7 @@ func (s *BundleService) InstallBundleWithOptions(bundleLocation, environmentName
 			}
 		}
 
-		agentCount, mcpCount, workflowCount, err := s.extractBundle(bundlePath, envDir)
+		agentCount, mcpCount, workflowCount, suiteCount, err := s.extractBundle(bundlePath, envDir)
 		if err != nil {
 			return &BundleInstallResult{
 				Success: false,
@@ -1308,6 +1417,13 @@ func (s *BundleService) InstallBundleWithOptions(bundleLocation, environmentName
 			}, err
 		}
 
+		// Import bundled benchmark suites into the database
+		if taskCount, err := s.importBenchmarkSuites(envDir); err != nil {
+			log.Printf("Warning: failed to import benchmark suites: %v", err)
+		} else if taskCount > 0 {
+			log.Printf("Imported %d benchmark task(s) from bundled suites", taskCount)
+		}
+
 		action := "installed"
 		if envExists {
 			action = "merged"
@@ -1320,9 +1436,10 @@ func (s *BundleService) InstallBundleWithOptions(bundleLocation, environmentName
 			InstalledAgents:    agentCount,
 			InstalledMCPs:      mcpCount,
 			InstalledWorkflows: workflowCount,
+			InstalledSuites:    suiteCount,
 		}, nil
 	} else {
-		agentCount, mcpCount, workflowCount, err := s.extractBundle(bundlePath, envDir)
+		agentCount, mcpCount, workflowCount, suiteCount, err := s.extractBundle(bundlePath, envDir)
 		if err != nil {
 			os.RemoveAll(envDir)
 			return &BundleInstallResult{
@@ -1339,6 +1456,7 @@ func (s *BundleService) InstallBundleWithOptions(bundleLocation, environmentName
 			InstalledAgents:    agentCount,
 			InstalledMCPs:      mcpCount,
 			InstalledWorkflows: workflowCount,
+			InstalledSuites:    suiteCount,
 		}, nil
 	}
 }
@@ -1438,20 +1556,20 @@ func (s *BundleService) copyBundle(srcPath, bundlesDir string) (string, error) {
 	return destPath, nil
 }
 
-func (s *BundleService) extractBundle(bundlePath, envDir string) (int, int, int, error) {
+func (s *BundleService) extractBundle(bundlePath, envDir string) (int, int, int, int, error) {
 	if err := os.MkdirAll(envDir, 0755); err != nil {
-		return 0, 0, 0, fmt.Errorf("failed to create environment directory: %v", err)
+		return 0, 0, 0, 0, fmt.Errorf("failed to create environment directory: %v", err)
 	}
 
 	file, err := os.Open(bundlePath)
 	if err != nil {
-		return 0, 0, 0, fmt.Errorf("failed to open bundle: %v", err)
+		return 0, 0, 0, 0, fmt.Errorf("failed to open bundle: %v", err)
 	}
 	defer func() { _ = file.Close() }()
 
 	gzipReader, err := gzip.NewReader(file)
 	if err != nil {
-		return 0, 0, 0, fmt.Errorf("failed to create gzip reader: %v", err)
+		return 0, 0, 0, 0, fmt.Errorf("failed to create gzip reader: %v", err)
 	}
 	defer func() { _ = gzipReader.Close() }()
 
@@ -1460,6 +1578,7 @@ func (s *BundleService) extractBundle(bundlePath, envDir string) (int, int, int,
 	agentCount := 0
 	mcpCount := 0
 	workflowCount := 0
+	suiteCount := 0
 
 	for {
 		header, err := tarReader.Next()
@@ -1467,26 +1586,26 @@ func (s *BundleService) extractBundle(bundlePath, envDir string) (int, int, int,
 			break
 		}
 		if err != nil {
-			return 0, 0, 0, fmt.Errorf("failed to read tar entry: %v", err)
+			return 0, 0, 0, 0, fmt.Errorf("failed to read tar entry: %v", err)
 		}
 
 		destPath := filepath.Join(envDir, header.Name)
 
 		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-			return 0, 0, 0, fmt.Errorf("failed to create directory: %v", err)
+			return 0, 0, 0, 0, fmt.Errorf("failed to create directory: %v", err)
 		}
 
 		switch header.Typeflag {
 		case tar.TypeReg:
 			outFile, err := os.Create(destPath)
 			if err != nil {
-				return 0, 0, 0, fmt.Errorf("failed to create file %s: %v", destPath, err)
+				return 0, 0, 0, 0, fmt.Errorf("failed to create file %s: %v", destPath, err)
 			}
 
 			_, err = io.Copy(outFile, tarReader)
 			outFile.Close()
 			if err != nil {
-				return 0, 0, 0, fmt.Errorf("failed to write file %s: %v", destPath, err)
+				return 0, 0, 0, 0, fmt.Errorf("failed to write file %s: %v", destPath, err)
 			}
 
 			name := header.Name
@@ -1497,18 +1616,72 @@ func (s *BundleService) extractBundle(bundlePath, envDir string) (int, int, int,
 					strings.HasSuffix(name, ".workflow.yml") ||
 					strings.HasSuffix(name, ".workflow.json")) {
 				workflowCount++
+			} else if (strings.HasPrefix(name, "benchmarks/") || strings.HasPrefix(name, "./benchmarks/")) &&
+				isBenchmarkSuiteFile(name) {
+				suiteCount++
 			} else if strings.HasSuffix(name, ".json") && !strings.Contains(name, "/") {
 				mcpCount++
 			}
 
 		case tar.TypeDir:
 			if err := os.MkdirAll(destPath, 0755); err != nil {
-				return 0, 0, 0, fmt.Errorf("failed to create directory %s: %v", destPath, err)
+				return 0, 0, 0, 0, fmt.Errorf("failed to create directory %s: %v", destPath, err)
 			}
 		}
 	}
 
-	return agentCount, mcpCount, workflowCount, nil
+	return agentCount, mcpCount, workflowCount, suiteCount, nil
+}
+
+// importBenchmarkSuites imports bundled benchmark suites into the database so
+// an installed bundle is ready to evaluate without a separate import step
+func (s *BundleService) importBenchmarkSuites(envDir string) (int, error) {
+	benchmarksDir := filepath.Join(envDir, "benchmarks")
+	entries, err := os.ReadDir(benchmarksDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	imported := 0
+	for _, entry := range entries {
+		if !isBenchmarkSuiteFile(entry.Name()) {
+			continue
+		}
+
+		suite, err := pkgbenchmark.LoadSuiteFile(filepath.Join(benchmarksDir, entry.Name()))
+		if err != nil {
+			log.Printf("Warning: skipping invalid benchmark suite %s: %v", entry.Name(), err)
+			continue
+		}
+
+		for _, task := range suite.Tasks {
+			weights := task.Weights
+			if weights == (pkgbenchmark.SuiteWeights{}) {
+				weights = pkgbenchmark.DefaultWeights
+			}
+
+			_, err := s.repos.BenchmarkTasks.Upsert(context.Background(), &repositories.BenchmarkTask{
+				Name:                  task.Name,
+				Category:              task.Category,
+				Description:           task.Description,
+				ExpectedOutputExample: task.ExpectedOutputExample,
+				EvaluationCriteria:    task.EvaluationCriteria,
+				TaskCompletionWeight:  weights.TaskCompletion,
+				RelevancyWeight:       weights.Relevancy,
+				HallucinationWeight:   weights.Hallucination,
+				FaithfulnessWeight:    weights.Faithfulness,
+				ToxicityWeight:        weights.Toxicity,
+			})
+			if err != nil {
+				return imported, fmt.Errorf("failed to import benchmark task %q: %w", task.Name, err)
+			}
+			imported++
+		}
+	}
+	return imported, nil
 }
 
 // generateBundleNameFromURL generates a meaningful filename from URL
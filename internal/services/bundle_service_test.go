@@ -1254,7 +1254,7 @@ Content`)
 			bundlePath := tt.createBundle(tmpDir)
 			envDir := filepath.Join(tmpDir, "test-env")
 
-			agentCount, mcpCount, _, err := service.extractBundle(bundlePath, envDir)
+			agentCount, mcpCount, _, _, err := service.extractBundle(bundlePath, envDir)
 
 			if (err != nil) != tt.expectError {
 				t.Errorf("extractBundle() error = %v, expectError %v", err, tt.expectError)
@@ -1284,6 +1284,153 @@ Content`)
 	}
 }
 
+// TestBundleBenchmarkSuites tests benchmark suite packaging in bundles
+func TestBundleBenchmarkSuites(t *testing.T) {
+	service := NewBundleService()
+
+	sourceDir := t.TempDir()
+	benchmarksDir := filepath.Join(sourceDir, "benchmarks")
+	os.MkdirAll(benchmarksDir, 0755)
+
+	suiteContent := `name: payments
+description: Payment workflow evaluation tasks
+tasks:
+  - name: refund-processing
+    category: finops
+    description: Process a refund request end to end
+  - name: invoice-audit
+    category: finops
+    description: Audit an invoice for anomalies
+`
+	os.WriteFile(filepath.Join(benchmarksDir, "payments.yaml"), []byte(suiteContent), 0644)
+
+	manifest, err := service.generateManifest(sourceDir)
+	if err != nil {
+		t.Fatalf("generateManifest() error = %v", err)
+	}
+
+	if len(manifest.BenchmarkSuites) != 1 {
+		t.Fatalf("BenchmarkSuites count = %d, want 1", len(manifest.BenchmarkSuites))
+	}
+	suite := manifest.BenchmarkSuites[0]
+	if suite.Name != "payments" || suite.TaskCount != 2 {
+		t.Errorf("unexpected suite metadata: %+v", suite)
+	}
+	if len(suite.Categories) != 1 || suite.Categories[0] != "finops" {
+		t.Errorf("unexpected suite categories: %v", suite.Categories)
+	}
+
+	// Suite files are counted during extraction
+	bundle, err := service.CreateBundle(sourceDir)
+	if err != nil {
+		t.Fatalf("CreateBundle() error = %v", err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "bench-bundle.tar.gz")
+	os.WriteFile(bundlePath, bundle, 0644)
+
+	_, _, _, suiteCount, err := service.extractBundle(bundlePath, filepath.Join(t.TempDir(), "env"))
+	if err != nil {
+		t.Fatalf("extractBundle() error = %v", err)
+	}
+	if suiteCount != 1 {
+		t.Errorf("suite count = %d, want 1", suiteCount)
+	}
+}
+
+// TestBundleWorkflowAgentValidation tests that bundles reject workflows
+// referencing agents not packaged in the bundle
+func TestBundleWorkflowAgentValidation(t *testing.T) {
+	service := NewBundleService()
+
+	writeWorkflow := func(dir, agentName string) {
+		workflowsDir := filepath.Join(dir, "workflows")
+		os.MkdirAll(workflowsDir, 0755)
+		workflowContent := fmt.Sprintf(`id: cost-review
+name: Cost Review
+start: analyze
+states:
+  - name: analyze
+    type: task
+    action: agent.run
+    input:
+      agent: %q
+`, agentName)
+		os.WriteFile(filepath.Join(workflowsDir, "cost-review.workflow.yaml"), []byte(workflowContent), 0644)
+	}
+
+	writeAgent := func(dir string) {
+		agentsDir := filepath.Join(dir, "agents")
+		os.MkdirAll(agentsDir, 0755)
+		agentContent := `---
+metadata:
+  name: "Cost Analyzer"
+  description: "Analyzes costs"
+model: gpt-4o-mini
+max_steps: 5
+tools: []
+---
+Content`
+		os.WriteFile(filepath.Join(agentsDir, "cost-analyzer.prompt"), []byte(agentContent), 0644)
+	}
+
+	t.Run("workflow referencing bundled agent passes", func(t *testing.T) {
+		sourceDir := t.TempDir()
+		writeAgent(sourceDir)
+		writeWorkflow(sourceDir, "Cost Analyzer")
+
+		if _, err := service.CreateBundle(sourceDir); err != nil {
+			t.Errorf("CreateBundle() error = %v", err)
+		}
+	})
+
+	t.Run("workflow referencing missing agent fails", func(t *testing.T) {
+		sourceDir := t.TempDir()
+		writeWorkflow(sourceDir, "Missing Agent")
+
+		_, err := service.CreateBundle(sourceDir)
+		if err == nil {
+			t.Fatal("CreateBundle() should fail when a workflow references a missing agent")
+		}
+		if !strings.Contains(err.Error(), "Missing Agent") {
+			t.Errorf("error should name the missing agent, got: %v", err)
+		}
+	})
+
+	t.Run("install rejects bundle with missing agent reference", func(t *testing.T) {
+		// Build an invalid bundle by hand (CreateBundle refuses to make one)
+		sourceDir := t.TempDir()
+		writeWorkflow(sourceDir, "Missing Agent")
+
+		manifest := &BundleManifest{
+			Version: "1.0",
+			Workflows: []WorkflowManifestInfo{
+				{ID: "cost-review", Agents: []string{"Missing Agent"}},
+			},
+		}
+		manifestJSON, _ := json.Marshal(manifest)
+
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		tw.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0644, Size: int64(len(manifestJSON))})
+		tw.Write(manifestJSON)
+		tw.Close()
+		gw.Close()
+
+		bundlePath := filepath.Join(t.TempDir(), "invalid-bundle.tar.gz")
+		os.WriteFile(bundlePath, buf.Bytes(), 0644)
+
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		result, err := service.InstallBundleWithOptions(bundlePath, "invalid-env", false)
+		if err == nil || result.Success {
+			t.Fatal("InstallBundleWithOptions() should reject bundle with missing agent reference")
+		}
+		if !strings.Contains(result.Error, "Missing Agent") {
+			t.Errorf("install error should name the missing agent, got: %s", result.Error)
+		}
+	})
+}
+
 func TestContains(t *testing.T) {
 	tests := []struct {
 		name  string
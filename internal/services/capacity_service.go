@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"station/internal/db/repositories"
+)
+
+// How far back capacity analysis looks when measuring peak concurrency,
+// arrival rate, and average run duration
+const capacityWindow = 24 * time.Hour
+
+// CapacityReport summarizes current execution load and a projection of how
+// many worker replicas are needed, shaped so external autoscalers (K8s HPA
+// via external metrics, Nomad) can scale on the flat numeric fields.
+type CapacityReport struct {
+	GeneratedAt string `json:"generated_at"`
+	WindowHours int    `json:"window_hours"`
+	// CurrentConcurrency is the number of runs executing right now
+	// (including runs paused on an approval gate)
+	CurrentConcurrency int64 `json:"current_concurrency"`
+	// PeakConcurrency is the highest number of simultaneously active runs
+	// observed inside the window
+	PeakConcurrency int64 `json:"peak_concurrency"`
+	// QueueDepth is the number of runs accepted but not yet executing
+	QueueDepth int64 `json:"queue_depth"`
+	// AvgRunDurationSeconds is the mean duration of runs completed inside
+	// the window (0 when none completed)
+	AvgRunDurationSeconds float64 `json:"avg_run_duration_seconds"`
+	// RunsStartedLastHour is the recent arrival rate
+	RunsStartedLastHour int64 `json:"runs_started_last_hour"`
+	// ProjectedConcurrency estimates steady-state concurrency from the
+	// window's arrival rate and average duration (Little's law)
+	ProjectedConcurrency float64 `json:"projected_concurrency"`
+	// RecommendedWorkers is the suggested replica count: enough slots for
+	// whichever is larger — the projection or the current load plus the
+	// backlog — never below 1
+	RecommendedWorkers int64 `json:"recommended_workers"`
+}
+
+// CapacityService derives autoscaling hints from run history. All figures
+// come from the runs table, so reports are consistent whether execution
+// happens in-process (CLI) or behind `stn serve`.
+type CapacityService struct {
+	repos *repositories.Repositories
+}
+
+// NewCapacityService creates a capacity service
+func NewCapacityService(repos *repositories.Repositories) *CapacityService {
+	return &CapacityService{repos: repos}
+}
+
+// Report computes the current capacity report over the standard window
+func (s *CapacityService) Report(ctx context.Context) (*CapacityReport, error) {
+	now := time.Now().UTC()
+	since := now.Add(-capacityWindow)
+
+	running, err := s.repos.AgentRuns.CountByStatus(ctx, "running")
+	if err != nil {
+		return nil, err
+	}
+	awaiting, err := s.repos.AgentRuns.CountByStatus(ctx, "awaiting_approval")
+	if err != nil {
+		return nil, err
+	}
+	queued, err := s.repos.AgentRuns.CountByStatus(ctx, "queued")
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.repos.AgentRuns.CountByStatus(ctx, "pending")
+	if err != nil {
+		return nil, err
+	}
+
+	intervals, err := s.repos.AgentRuns.ListIntervalsSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CapacityReport{
+		GeneratedAt:        now.Format(time.RFC3339),
+		WindowHours:        int(capacityWindow.Hours()),
+		CurrentConcurrency: running + awaiting,
+		QueueDepth:         queued + pending,
+	}
+
+	report.PeakConcurrency = peakConcurrency(intervals, since, now)
+	report.AvgRunDurationSeconds = avgCompletedDuration(intervals, since)
+
+	var startedLastHour, startedInWindow int64
+	hourAgo := now.Add(-time.Hour)
+	for _, interval := range intervals {
+		if interval.StartedAt.After(since) {
+			startedInWindow++
+		}
+		if interval.StartedAt.After(hourAgo) {
+			startedLastHour++
+		}
+	}
+	report.RunsStartedLastHour = startedLastHour
+
+	// Little's law: steady-state concurrency = arrival rate × avg duration
+	if startedInWindow > 0 && report.AvgRunDurationSeconds > 0 {
+		arrivalsPerSecond := float64(startedInWindow) / capacityWindow.Seconds()
+		report.ProjectedConcurrency = arrivalsPerSecond * report.AvgRunDurationSeconds
+	}
+
+	demand := math.Max(report.ProjectedConcurrency, float64(report.CurrentConcurrency+report.QueueDepth))
+	report.RecommendedWorkers = int64(math.Max(1, math.Ceil(demand)))
+
+	return report, nil
+}
+
+// peakConcurrency sweeps the run intervals clipped to [since, now] and
+// returns the highest number of simultaneously active runs
+func peakConcurrency(intervals []repositories.RunInterval, since, now time.Time) int64 {
+	type boundary struct {
+		at    time.Time
+		delta int
+	}
+
+	var boundaries []boundary
+	for _, interval := range intervals {
+		start := interval.StartedAt
+		if start.Before(since) {
+			start = since
+		}
+		end := now
+		if interval.CompletedAt != nil {
+			end = *interval.CompletedAt
+			if end.Before(since) {
+				continue
+			}
+		}
+		boundaries = append(boundaries, boundary{at: start, delta: 1}, boundary{at: end, delta: -1})
+	}
+
+	// Completions sort before starts at the same instant so back-to-back
+	// runs do not count as overlapping
+	sort.Slice(boundaries, func(i, j int) bool {
+		if boundaries[i].at.Equal(boundaries[j].at) {
+			return boundaries[i].delta < boundaries[j].delta
+		}
+		return boundaries[i].at.Before(boundaries[j].at)
+	})
+
+	var active, peak int64
+	for _, b := range boundaries {
+		active += int64(b.delta)
+		if active > peak {
+			peak = active
+		}
+	}
+	return peak
+}
+
+// avgCompletedDuration returns the mean duration in seconds of runs
+// completed inside the window
+func avgCompletedDuration(intervals []repositories.RunInterval, since time.Time) float64 {
+	var total float64
+	var samples int64
+	for _, interval := range intervals {
+		if interval.CompletedAt == nil || interval.CompletedAt.Before(since) {
+			continue
+		}
+		duration := interval.CompletedAt.Sub(interval.StartedAt).Seconds()
+		if duration < 0 {
+			continue
+		}
+		total += duration
+		samples++
+	}
+	if samples == 0 {
+		return 0
+	}
+	return total / float64(samples)
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"station/internal/config"
+	"station/internal/logging"
+)
+
+const (
+	// charsPerTokenEstimate is the rough chars-per-token ratio used for
+	// approximate accounting (no tokenizer dependency)
+	charsPerTokenEstimate = 4
+	// contextBudgetRatio is the fraction of the budget at which compression
+	// kicks in, leaving headroom for the model's own responses
+	contextBudgetRatio = 0.8
+	// minKeptTokens is the floor kept from a compressed tool output so the
+	// model always sees at least a usable fragment
+	minKeptTokens = 128
+	// evictNoticePreviewChars is how much of an evicted output survives as a
+	// preview in the eviction notice
+	evictNoticePreviewChars = 200
+)
+
+// contextCompressionEvent records one tool output compression for the run's
+// execution steps
+type contextCompressionEvent struct {
+	ToolName     string    `json:"tool_name"`
+	Strategy     string    `json:"strategy"`
+	TokensBefore int       `json:"tokens_before"`
+	TokensAfter  int       `json:"tokens_after"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// runContextUsage tracks approximate token consumption for one run
+type runContextUsage struct {
+	tokens int
+	events []contextCompressionEvent
+}
+
+// contextManagerMiddleware keeps accumulated tool outputs within an
+// approximate token budget so long executions don't blow past the model's
+// context window. Outputs that would exceed the budget are truncated or
+// evicted (configurable), and every compression is recorded so it shows up
+// in the run's execution steps.
+type contextManagerMiddleware struct {
+	maxTokens int
+	strategy  string
+
+	mu      sync.Mutex
+	budgets map[int64]*runContextUsage
+}
+
+func (cm *contextManagerMiddleware) Name() string { return "context-manager" }
+
+func (cm *contextManagerMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	return nil, nil
+}
+
+func (cm *contextManagerMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	if callErr != nil || output == nil {
+		return nil, nil
+	}
+
+	serialized := serializeToolOutput(output)
+	tokens := estimateTokens(serialized)
+	threshold := int(float64(cm.maxTokens) * contextBudgetRatio)
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	usage := cm.budgets[call.RunID]
+	if usage == nil {
+		usage = &runContextUsage{}
+		if cm.budgets == nil {
+			cm.budgets = make(map[int64]*runContextUsage)
+		}
+		cm.budgets[call.RunID] = usage
+	}
+
+	// Within budget - account for the output and pass it through unchanged
+	if usage.tokens+tokens <= threshold {
+		usage.tokens += tokens
+		return nil, nil
+	}
+
+	var compressed string
+	switch cm.strategy {
+	case "evict":
+		compressed = evictOutput(serialized, tokens)
+	default: // "truncate"
+		remaining := threshold - usage.tokens
+		if remaining < minKeptTokens {
+			remaining = minKeptTokens
+		}
+		compressed = truncateOutput(serialized, remaining)
+	}
+
+	tokensAfter := estimateTokens(compressed)
+	usage.tokens += tokensAfter
+	event := contextCompressionEvent{
+		ToolName:     call.ToolName,
+		Strategy:     cm.strategyName(),
+		TokensBefore: tokens,
+		TokensAfter:  tokensAfter,
+		Timestamp:    time.Now(),
+	}
+	usage.events = append(usage.events, event)
+
+	call.Annotations["context_compression"] = map[string]interface{}{
+		"strategy":      event.Strategy,
+		"tokens_before": tokens,
+		"tokens_after":  tokensAfter,
+	}
+
+	logging.Info("Context manager compressed %s output for run %d (~%d -> ~%d tokens, strategy: %s)",
+		call.ToolName, call.RunID, tokens, tokensAfter, event.Strategy)
+
+	return compressed, nil
+}
+
+func (cm *contextManagerMiddleware) strategyName() string {
+	if cm.strategy == "evict" {
+		return "evict"
+	}
+	return "truncate"
+}
+
+var (
+	contextManagerOnce sync.Once
+	contextManager     *contextManagerMiddleware
+)
+
+// RegisterContextManager installs the token-budget middleware for all
+// subsequently started agent executions. Guarded by a sync.Once since engine
+// constructors run per CLI command and per server service.
+func RegisterContextManager(cfg config.ContextConfig) {
+	contextManagerOnce.Do(func() {
+		contextManager = &contextManagerMiddleware{
+			maxTokens: cfg.MaxTokens,
+			strategy:  cfg.Strategy,
+			budgets:   make(map[int64]*runContextUsage),
+		}
+		RegisterToolCallMiddleware(contextManager)
+		logging.Info("Context manager enabled (budget: ~%d tokens, strategy: %s)",
+			cfg.MaxTokens, contextManager.strategyName())
+	})
+}
+
+// takeContextCompressionSteps returns the run's compression events formatted
+// as execution-step entries and releases the run's budget tracking. Safe to
+// call when no context manager is registered.
+func takeContextCompressionSteps(runID int64) []interface{} {
+	if contextManager == nil {
+		return nil
+	}
+
+	contextManager.mu.Lock()
+	usage := contextManager.budgets[runID]
+	delete(contextManager.budgets, runID)
+	contextManager.mu.Unlock()
+
+	if usage == nil || len(usage.events) == 0 {
+		return nil
+	}
+
+	steps := make([]interface{}, 0, len(usage.events))
+	for i, event := range usage.events {
+		steps = append(steps, map[string]interface{}{
+			"step_number": i + 1,
+			"action":      "context_compression",
+			"response": fmt.Sprintf("Compressed %s output (~%d -> ~%d tokens, strategy: %s) to stay within the context budget",
+				event.ToolName, event.TokensBefore, event.TokensAfter, event.Strategy),
+			"timestamp": event.Timestamp,
+		})
+	}
+	return steps
+}
+
+// releaseContextBudget drops budget tracking for a run without reading its
+// events (cleanup for failed or cancelled executions)
+func releaseContextBudget(runID int64) {
+	if contextManager == nil {
+		return
+	}
+	contextManager.mu.Lock()
+	delete(contextManager.budgets, runID)
+	contextManager.mu.Unlock()
+}
+
+// estimateTokens approximates token count from character length
+func estimateTokens(s string) int {
+	return (len(s) + charsPerTokenEstimate - 1) / charsPerTokenEstimate
+}
+
+// serializeToolOutput renders a tool output to the string form the model
+// would consume, for token accounting
+func serializeToolOutput(output interface{}) string {
+	if s, ok := output.(string); ok {
+		return s
+	}
+	if data, err := json.Marshal(output); err == nil {
+		return string(data)
+	}
+	return fmt.Sprintf("%v", output)
+}
+
+// truncateOutput keeps the head and tail of an oversized output within the
+// given token allowance, marking the elision so the model knows content was
+// dropped
+func truncateOutput(s string, maxTokens int) string {
+	maxChars := maxTokens * charsPerTokenEstimate
+	if len(s) <= maxChars {
+		return s
+	}
+
+	head := maxChars * 3 / 4
+	tail := maxChars - head
+	return fmt.Sprintf("%s\n...[%d characters elided to stay within the context budget]...\n%s",
+		s[:head], len(s)-head-tail, s[len(s)-tail:])
+}
+
+// evictOutput replaces an output with a short notice and preview once the
+// context budget is exhausted
+func evictOutput(s string, tokens int) string {
+	preview := s
+	if len(preview) > evictNoticePreviewChars {
+		preview = preview[:evictNoticePreviewChars]
+	}
+	return fmt.Sprintf("[tool output evicted: ~%d tokens exceeded the context budget; preview: %s...]", tokens, preview)
+}
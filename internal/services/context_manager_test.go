@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func newTestContextManager(maxTokens int, strategy string) *contextManagerMiddleware {
+	return &contextManagerMiddleware{
+		maxTokens: maxTokens,
+		strategy:  strategy,
+		budgets:   make(map[int64]*runContextUsage),
+	}
+}
+
+func TestContextManagerPassesSmallOutputsThrough(t *testing.T) {
+	cm := newTestContextManager(10000, "truncate")
+	call := &ToolCall{RunID: 1, ToolName: "__read_text_file", Annotations: map[string]interface{}{}}
+
+	replacement, err := cm.AfterToolCall(context.Background(), call, "short output", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replacement != nil {
+		t.Errorf("expected small output to pass through unchanged, got %v", replacement)
+	}
+	if cm.budgets[1] == nil || cm.budgets[1].tokens == 0 {
+		t.Error("expected output tokens to be accounted against the run budget")
+	}
+}
+
+func TestContextManagerTruncatesWhenOverBudget(t *testing.T) {
+	cm := newTestContextManager(100, "truncate")
+	call := &ToolCall{RunID: 2, ToolName: "__directory_tree", Annotations: map[string]interface{}{}}
+
+	large := strings.Repeat("x", 4000) // ~1000 tokens against a 100-token budget
+	replacement, err := cm.AfterToolCall(context.Background(), call, large, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compressed, ok := replacement.(string)
+	if !ok {
+		t.Fatalf("expected string replacement, got %T", replacement)
+	}
+	if len(compressed) >= len(large) {
+		t.Error("expected compressed output to be smaller than the original")
+	}
+	if !strings.Contains(compressed, "elided") {
+		t.Error("expected truncation marker in compressed output")
+	}
+	if _, annotated := call.Annotations["context_compression"]; !annotated {
+		t.Error("expected context_compression annotation on the call")
+	}
+
+	// Compression must be recorded as an execution step and the budget released
+	saved := contextManager
+	contextManager = cm
+	defer func() { contextManager = saved }()
+
+	steps := takeContextCompressionSteps(2)
+	if len(steps) != 1 {
+		t.Fatalf("expected 1 compression step, got %d", len(steps))
+	}
+	step := steps[0].(map[string]interface{})
+	if step["action"] != "context_compression" {
+		t.Errorf("expected context_compression action, got %v", step["action"])
+	}
+	if cm.budgets[2] != nil {
+		t.Error("expected run budget to be released after taking steps")
+	}
+}
+
+func TestContextManagerEvictStrategy(t *testing.T) {
+	cm := newTestContextManager(100, "evict")
+	call := &ToolCall{RunID: 3, ToolName: "__search_files", Annotations: map[string]interface{}{}}
+
+	large := strings.Repeat("y", 4000)
+	replacement, err := cm.AfterToolCall(context.Background(), call, large, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compressed, ok := replacement.(string)
+	if !ok {
+		t.Fatalf("expected string replacement, got %T", replacement)
+	}
+	if !strings.Contains(compressed, "evicted") {
+		t.Error("expected eviction notice in replacement output")
+	}
+	if len(compressed) > evictNoticePreviewChars+200 {
+		t.Errorf("expected eviction notice to be short, got %d chars", len(compressed))
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if got := estimateTokens(""); got != 0 {
+		t.Errorf("estimateTokens(\"\") = %d, want 0", got)
+	}
+	if got := estimateTokens(strings.Repeat("a", 400)); got != 100 {
+		t.Errorf("estimateTokens(400 chars) = %d, want 100", got)
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+// CostReportGroup is one row of the cost breakdown: the grouping key values
+// plus aggregated usage for the window and the preceding window of the same
+// length (for deltas).
+type CostReportGroup struct {
+	Environment string `json:"environment,omitempty"`
+	Model       string `json:"model,omitempty"`
+	Agent       string `json:"agent,omitempty"`
+
+	Runs         int64   `json:"runs"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	TotalTokens  int64   `json:"total_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+
+	PreviousCostUSD float64 `json:"previous_cost_usd"`
+	// DeltaPercent is the cost change versus the preceding window; nil when
+	// there was no spend to compare against
+	DeltaPercent *float64 `json:"delta_percent,omitempty"`
+}
+
+// CostReport is the full breakdown produced by `stn costs`
+type CostReport struct {
+	Since        string            `json:"since"`
+	GroupBy      []string          `json:"group_by"`
+	Groups       []CostReportGroup `json:"groups"`
+	TotalRuns    int64             `json:"total_runs"`
+	TotalTokens  int64             `json:"total_tokens"`
+	TotalCostUSD float64           `json:"total_cost_usd"`
+	// UnpricedModels lists models billed at fallback rates (not in the pricing table)
+	UnpricedModels []string `json:"unpriced_models,omitempty"`
+}
+
+// CostReportService aggregates historical run costs by environment, model,
+// and agent using recorded token counts and the model pricing table
+type CostReportService struct {
+	repos *repositories.Repositories
+	// now is replaceable in tests
+	now func() time.Time
+}
+
+func NewCostReportService(repos *repositories.Repositories) *CostReportService {
+	return &CostReportService{repos: repos, now: time.Now}
+}
+
+// validCostGroupings are the dimensions accepted by --group-by
+var validCostGroupings = map[string]bool{"env": true, "model": true, "agent": true}
+
+// Report builds the cost breakdown for runs started within the window,
+// grouped by the requested dimensions (any of env, model, agent)
+func (s *CostReportService) Report(ctx context.Context, since time.Duration, groupBy []string) (*CostReport, error) {
+	for _, dim := range groupBy {
+		if !validCostGroupings[dim] {
+			return nil, fmt.Errorf("unknown grouping %q (valid: env, model, agent)", dim)
+		}
+	}
+
+	runs, err := s.repos.AgentRuns.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	agentEnv, agentName, err := s.agentLookups()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := s.now().Add(-since)
+	previousCutoff := cutoff.Add(-since)
+
+	report := &CostReport{
+		Since:   since.String(),
+		GroupBy: groupBy,
+	}
+	groups := map[string]*CostReportGroup{}
+	unpriced := map[string]bool{}
+
+	for _, run := range runs {
+		if run.StartedAt.Before(previousCutoff) {
+			continue
+		}
+
+		model := ""
+		if run.ModelName != nil {
+			model = *run.ModelName
+		}
+		var inputTokens, outputTokens int64
+		if run.InputTokens != nil {
+			inputTokens = *run.InputTokens
+		}
+		if run.OutputTokens != nil {
+			outputTokens = *run.OutputTokens
+		}
+		cost := RunCostUSD(inputTokens, outputTokens, model)
+		if _, priced := LookupModelPricing(model); !priced && (inputTokens > 0 || outputTokens > 0) {
+			unpriced[model] = true
+		}
+
+		group := s.groupFor(groups, groupBy, run, agentEnv, agentName, model)
+		if run.StartedAt.Before(cutoff) {
+			// Preceding window: only feeds the delta baseline
+			group.PreviousCostUSD += cost
+			continue
+		}
+
+		group.Runs++
+		group.InputTokens += inputTokens
+		group.OutputTokens += outputTokens
+		group.TotalTokens += inputTokens + outputTokens
+		group.CostUSD += cost
+
+		report.TotalRuns++
+		report.TotalTokens += inputTokens + outputTokens
+		report.TotalCostUSD += cost
+	}
+
+	for _, group := range groups {
+		if group.Runs == 0 && group.PreviousCostUSD == 0 {
+			continue
+		}
+		if group.PreviousCostUSD > 0 {
+			delta := (group.CostUSD - group.PreviousCostUSD) / group.PreviousCostUSD * 100
+			group.DeltaPercent = &delta
+		}
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return report.Groups[i].CostUSD > report.Groups[j].CostUSD
+	})
+
+	for model := range unpriced {
+		report.UnpricedModels = append(report.UnpricedModels, model)
+	}
+	sort.Strings(report.UnpricedModels)
+
+	return report, nil
+}
+
+// agentLookups maps agent IDs to their environment and agent names
+func (s *CostReportService) agentLookups() (map[int64]string, map[int64]string, error) {
+	agents, err := s.repos.Agents.List()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	environments, err := s.repos.Environments.List()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list environments: %w", err)
+	}
+
+	envNames := map[int64]string{}
+	for _, env := range environments {
+		envNames[env.ID] = env.Name
+	}
+
+	agentEnv := map[int64]string{}
+	agentName := map[int64]string{}
+	for _, agent := range agents {
+		agentName[agent.ID] = agent.Name
+		if name, ok := envNames[agent.EnvironmentID]; ok {
+			agentEnv[agent.ID] = name
+		}
+	}
+	return agentEnv, agentName, nil
+}
+
+// groupFor returns (creating if needed) the group row a run belongs to
+func (s *CostReportService) groupFor(groups map[string]*CostReportGroup, groupBy []string, run *models.AgentRun, agentEnv, agentName map[int64]string, model string) *CostReportGroup {
+	group := CostReportGroup{}
+	var keyParts []string
+	for _, dim := range groupBy {
+		switch dim {
+		case "env":
+			group.Environment = valueOrUnknown(agentEnv[run.AgentID])
+			keyParts = append(keyParts, group.Environment)
+		case "model":
+			group.Model = valueOrUnknown(model)
+			keyParts = append(keyParts, group.Model)
+		case "agent":
+			group.Agent = valueOrUnknown(agentName[run.AgentID])
+			keyParts = append(keyParts, group.Agent)
+		}
+	}
+	key := strings.Join(keyParts, "\x00")
+	if existing, ok := groups[key]; ok {
+		return existing
+	}
+	groups[key] = &group
+	return &group
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "(unknown)"
+	}
+	return value
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestLookupModelPricing(t *testing.T) {
+	if _, ok := LookupModelPricing("gpt-4o-mini"); !ok {
+		t.Error("expected gpt-4o-mini in the pricing table")
+	}
+	if _, ok := LookupModelPricing("openai/gpt-4o-mini"); !ok {
+		t.Error("expected provider prefix to be stripped")
+	}
+	if pricing, ok := LookupModelPricing("gpt-4o-2024-08-06"); !ok || pricing.InputPerMTok != 2.50 {
+		t.Errorf("expected dated variant to resolve to gpt-4o rates, got %+v (%v)", pricing, ok)
+	}
+	if pricing, ok := LookupModelPricing("gpt-5-mini-2025-08-07"); !ok || pricing.InputPerMTok != 0.25 {
+		t.Errorf("expected longest prefix match to pick gpt-5-mini, got %+v (%v)", pricing, ok)
+	}
+	if _, ok := LookupModelPricing("cloudshipai/cloudship/glm-4.7"); !ok {
+		t.Error("expected cloudship model with provider prefix to resolve")
+	}
+	if pricing, ok := LookupModelPricing("some-unknown-model"); ok || pricing != defaultModelPricing {
+		t.Errorf("expected fallback rates for unknown model, got %+v (%v)", pricing, ok)
+	}
+}
+
+func TestRunCostUSD(t *testing.T) {
+	// 1M input + 1M output on gpt-4o-mini: $0.15 + $0.60
+	if got := RunCostUSD(1_000_000, 1_000_000, "openai/gpt-4o-mini"); got != 0.75 {
+		t.Errorf("expected $0.75, got $%f", got)
+	}
+	if got := RunCostUSD(0, 0, "gpt-4o"); got != 0 {
+		t.Errorf("expected zero cost for zero tokens, got $%f", got)
+	}
+}
+
+func TestCostReportGrouping(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	ctx := context.Background()
+
+	user, err := repos.Users.GetByUsername("console")
+	if err != nil {
+		t.Fatalf("failed to load console user: %v", err)
+	}
+	defaultEnv, err := repos.Environments.GetByName("default")
+	if err != nil {
+		t.Fatalf("failed to load default environment: %v", err)
+	}
+	prodDesc := "production"
+	prodEnv, err := repos.Environments.Create("prod", &prodDesc, user.ID)
+	if err != nil {
+		t.Fatalf("failed to create prod environment: %v", err)
+	}
+
+	devAgent, err := repos.Agents.Create("dev-agent", "dev", "prompt", 5, defaultEnv.ID, user.ID, nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create dev agent: %v", err)
+	}
+	prodAgent, err := repos.Agents.Create("prod-agent", "prod", "prompt", 5, prodEnv.ID, user.ID, nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create prod agent: %v", err)
+	}
+
+	model := "openai/gpt-4o-mini"
+	addRun := func(agentID int64, inputTokens, outputTokens int64) {
+		t.Helper()
+		run, err := repos.AgentRuns.Create(ctx, agentID, user.ID, "task", "", 0, nil, nil, "running", nil)
+		if err != nil {
+			t.Fatalf("failed to create run: %v", err)
+		}
+		completedAt := time.Now()
+		totalTokens := inputTokens + outputTokens
+		if err := repos.AgentRuns.UpdateCompletionWithMetadata(ctx, run.ID, "done", 1, nil, nil, "completed", &completedAt,
+			&inputTokens, &outputTokens, &totalTokens, nil, &model, nil, nil); err != nil {
+			t.Fatalf("failed to complete run: %v", err)
+		}
+	}
+
+	addRun(devAgent.ID, 1_000_000, 0)  // $0.15
+	addRun(prodAgent.ID, 0, 1_000_000) // $0.60
+	addRun(prodAgent.ID, 1_000_000, 0) // $0.15
+
+	service := NewCostReportService(repos)
+	report, err := service.Report(ctx, 30*24*time.Hour, []string{"env", "model"})
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	if report.TotalRuns != 3 || report.TotalTokens != 3_000_000 {
+		t.Errorf("expected 3 runs / 3M tokens, got %d / %d", report.TotalRuns, report.TotalTokens)
+	}
+	if len(report.Groups) != 2 {
+		t.Fatalf("expected 2 groups (default+prod), got %+v", report.Groups)
+	}
+	// Sorted by cost descending: prod ($0.75) before default ($0.15)
+	if report.Groups[0].Environment != "prod" || report.Groups[0].Runs != 2 {
+		t.Errorf("expected prod group first with 2 runs, got %+v", report.Groups[0])
+	}
+	if report.Groups[0].CostUSD != 0.75 || report.Groups[1].CostUSD != 0.15 {
+		t.Errorf("expected costs $0.75 and $0.15, got %+v", report.Groups)
+	}
+	if report.Groups[0].Model != model {
+		t.Errorf("expected model column populated, got %+v", report.Groups[0])
+	}
+	if len(report.UnpricedModels) != 0 {
+		t.Errorf("expected no unpriced models, got %v", report.UnpricedModels)
+	}
+
+	// Grouping by agent splits the prod runs per agent
+	byAgent, err := service.Report(ctx, 30*24*time.Hour, []string{"agent"})
+	if err != nil {
+		t.Fatalf("Report by agent failed: %v", err)
+	}
+	if len(byAgent.Groups) != 2 || byAgent.Groups[0].Agent != "prod-agent" {
+		t.Errorf("expected per-agent grouping with prod-agent first, got %+v", byAgent.Groups)
+	}
+
+	if _, err := service.Report(ctx, time.Hour, []string{"bogus"}); err == nil {
+		t.Error("expected error for unknown grouping dimension")
+	}
+}
+
+func TestCostReportDeltas(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	ctx := context.Background()
+
+	user, err := repos.Users.GetByUsername("console")
+	if err != nil {
+		t.Fatalf("failed to load console user: %v", err)
+	}
+	env, err := repos.Environments.GetByName("default")
+	if err != nil {
+		t.Fatalf("failed to load default environment: %v", err)
+	}
+	agent, err := repos.Agents.Create("delta-agent", "d", "prompt", 5, env.ID, user.ID, nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	model := "openai/gpt-4o-mini"
+	addRun := func(inputTokens int64) {
+		t.Helper()
+		run, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "task", "", 0, nil, nil, "running", nil)
+		if err != nil {
+			t.Fatalf("failed to create run: %v", err)
+		}
+		completedAt := time.Now()
+		var zero int64
+		if err := repos.AgentRuns.UpdateCompletionWithMetadata(ctx, run.ID, "done", 1, nil, nil, "completed", &completedAt,
+			&inputTokens, &zero, &inputTokens, nil, &model, nil, nil); err != nil {
+			t.Fatalf("failed to complete run: %v", err)
+		}
+	}
+
+	addRun(1_000_000) // $0.15
+	addRun(2_000_000) // $0.30
+
+	service := NewCostReportService(repos)
+	// Pretend "now" is 45s ahead so window sizes control which side of the
+	// cutoff the runs (started at real now) fall on
+	service.now = func() time.Time { return time.Now().Add(45 * time.Second) }
+
+	// 1m window: both runs are current, no previous spend, so no delta
+	report, err := service.Report(ctx, time.Minute, []string{"env"})
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if len(report.Groups) != 1 {
+		t.Fatalf("expected 1 group, got %+v", report.Groups)
+	}
+	if report.Groups[0].DeltaPercent != nil {
+		t.Errorf("expected no delta without previous-window spend, got %+v", *report.Groups[0].DeltaPercent)
+	}
+
+	// 40s window: both runs fall in the previous window, current is empty
+	report, err = service.Report(ctx, 40*time.Second, []string{"env"})
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if report.TotalRuns != 0 {
+		t.Errorf("expected no current-window runs, got %d", report.TotalRuns)
+	}
+	if len(report.Groups) != 1 || report.Groups[0].PreviousCostUSD == 0 {
+		t.Fatalf("expected previous-window spend retained for delta, got %+v", report.Groups)
+	}
+	if report.Groups[0].DeltaPercent == nil || *report.Groups[0].DeltaPercent != -100 {
+		t.Errorf("expected -100%% delta when spend stops, got %+v", report.Groups[0].DeltaPercent)
+	}
+}
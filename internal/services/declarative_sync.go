@@ -12,6 +12,7 @@ import (
 
 	"station/internal/config"
 	"station/internal/db/repositories"
+	"station/internal/events"
 	"station/internal/logging"
 	"station/pkg/models"
 	"station/pkg/openapi"
@@ -25,6 +26,11 @@ type DeclarativeSync struct {
 	workflowScheduler      *WorkflowSchedulerService
 }
 
+// ProgressFunc reports incremental progress on a long operation (completed
+// of total items, task naming the item in flight) so the CLI can render a
+// bar or emit machine-readable events
+type ProgressFunc func(completed, total int, task string)
+
 // SyncOptions controls sync behavior
 type SyncOptions struct {
 	DryRun      bool
@@ -33,6 +39,18 @@ type SyncOptions struct {
 	Verbose     bool
 	Interactive bool
 	Confirm     bool
+	// Full forces tool re-discovery for every MCP server, bypassing the
+	// per-server content hash delta check
+	Full bool
+	// EnrichTools expands terse tool descriptions with the configured model
+	// after discovery (originals are preserved)
+	EnrichTools bool
+	// Source labels who is syncing (e.g. "cli", "serve") in the advisory
+	// sync lock so contention messages identify the holder
+	Source string
+	// Progress, when set, is called as each MCP server's tools are
+	// discovered so the caller can show per-server progress
+	Progress ProgressFunc
 }
 
 type SyncResult struct {
@@ -113,16 +131,47 @@ func (s *DeclarativeSync) SyncEnvironment(ctx context.Context, environmentName s
 	fmt.Printf("Starting declarative sync for environment: %s\n", environmentName)
 
 	// 1. Validate environment exists in database
-	_, err := s.repos.Environments.GetByName(environmentName)
+	env, err := s.repos.Environments.GetByName(environmentName)
 	if err != nil {
 		return nil, fmt.Errorf("environment '%s' not found: %w", environmentName, err)
 	}
 
+	// 1.5. Take the advisory sync lock so a CLI sync and serve mode's hot
+	// reload cannot interleave and corrupt tool assignments. Dry runs and
+	// validation-only passes write nothing, so they skip the lock.
+	if !options.DryRun && !options.Validate {
+		release, lockErr := NewSyncLockService(s.repos).Acquire(environmentName, options.Source)
+		if lockErr != nil {
+			return nil, lockErr
+		}
+		defer release()
+	}
+
 	// 2. Determine paths for this environment - use config helpers
 	envDir := config.GetEnvironmentDir(environmentName)
 	agentsDir := config.GetAgentsDir(environmentName)
 
-	// 3. Sync MCP template files FIRST (JSON files with potential variables)
+	// 2.5. Refresh remote-sourced environments (source.yml) before reading
+	// any configs, so the sync below sees the latest archive contents
+	if refreshed, err := RefreshEnvironmentSource(environmentName); err != nil {
+		return nil, fmt.Errorf("failed to refresh environment source for '%s': %w", environmentName, err)
+	} else if refreshed {
+		fmt.Printf("☁️ Refreshed environment '%s' from its remote source\n", environmentName)
+	}
+
+	// 3. Validate variables.yml values against the bundle manifest's declared
+	// variable types (string, int, bool, enum, secret, url) on every sync
+	if requirements, reqErr := loadVariableRequirements(envDir); reqErr != nil {
+		return nil, reqErr
+	} else if len(requirements) > 0 {
+		vars, _ := NewTemplateVariableService(config.GetConfigRoot(), s.repos).loadEnvironmentVariables(environmentName)
+		if validationErrors := validateEnvironmentVariables(requirements, vars); len(validationErrors) > 0 {
+			return nil, fmt.Errorf("variable validation failed for environment '%s':\n  %s",
+				environmentName, strings.Join(validationErrors, "\n  "))
+		}
+	}
+
+	// 4. Sync MCP template files FIRST (JSON files with potential variables)
 	// This must happen before agent sync so tools have stable IDs
 	mcpResult, err := s.syncMCPTemplateFiles(ctx, envDir, environmentName, options)
 	if err != nil {
@@ -134,7 +183,27 @@ func (s *DeclarativeSync) SyncEnvironment(ctx context.Context, environmentName s
 	result.MCPServersConnected = mcpResult.MCPServersConnected
 	result.Operations = append(result.Operations, mcpResult.Operations...)
 
-	// 4. Sync agents from .prompt files AFTER MCP tools are stable
+	// 4.5. Optionally expand terse tool descriptions with the configured
+	// model (--enrich); failures are non-fatal so sync still completes
+	if options.EnrichTools && !options.DryRun {
+		enriched, enrichErr := NewToolEnrichmentService(s.repos).EnrichEnvironmentTools(ctx, env.ID)
+		if enrichErr != nil {
+			fmt.Printf("⚠️  Tool description enrichment failed: %v\n", enrichErr)
+			result.ValidationMessages = append(result.ValidationMessages,
+				fmt.Sprintf("Tool enrichment failed: %v", enrichErr))
+		} else if enriched > 0 {
+			fmt.Printf("✨ Enriched descriptions for %d tools\n", enriched)
+		}
+	}
+
+	// Report tool name collisions across servers so users know which agents
+	// need fully-qualified serverName__toolName assignments
+	for _, warning := range reportToolCollisions(s.repos, env.ID) {
+		fmt.Printf("⚠️  %s\n", warning)
+		result.ValidationMessages = append(result.ValidationMessages, warning)
+	}
+
+	// 5. Sync agents from .prompt files AFTER MCP tools are stable
 	agentResult, err := s.syncAgents(ctx, agentsDir, environmentName, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sync agents: %w", err)
@@ -148,7 +217,7 @@ func (s *DeclarativeSync) SyncEnvironment(ctx context.Context, environmentName s
 	result.ValidationMessages = append(result.ValidationMessages, agentResult.ValidationMessages...)
 	result.Operations = append(result.Operations, agentResult.Operations...)
 
-	// 5. Sync workflow definition files from workflows/ directory
+	// 6. Sync workflow definition files from workflows/ directory
 	workflowsDir := config.GetWorkflowsDir(environmentName)
 	workflowResult, err := s.syncWorkflows(ctx, workflowsDir, environmentName, options)
 	if err != nil {
@@ -173,7 +242,7 @@ func (s *DeclarativeSync) SyncEnvironment(ctx context.Context, environmentName s
 		}
 	}
 
-	// 6. Cleanup orphaned configs, servers, and tools (declarative sync)
+	// 7. Cleanup orphaned configs, servers, and tools (declarative sync)
 	cleanupResult, err := s.cleanupOrphanedResources(ctx, envDir, environmentName, options)
 	if err != nil {
 		fmt.Printf("Warning: Failed to cleanup orphaned resources for %s: %v\n", environmentName, err)
@@ -189,6 +258,16 @@ func (s *DeclarativeSync) SyncEnvironment(ctx context.Context, environmentName s
 	fmt.Printf("Completed sync for environment %s: %d agents processed, %d errors\n",
 		environmentName, result.AgentsProcessed, result.ValidationErrors)
 
+	// Publish the sync outcome on the internal event bus (no-op when unconfigured)
+	events.Publish(events.SyncCompleted, map[string]interface{}{
+		"environment":       environmentName,
+		"agents_processed":  result.AgentsProcessed,
+		"agents_synced":     result.AgentsSynced,
+		"mcp_servers":       result.MCPServersProcessed,
+		"validation_errors": result.ValidationErrors,
+		"duration_ms":       result.Duration.Milliseconds(),
+	})
+
 	return result, nil
 }
 
@@ -472,6 +551,18 @@ func (s *DeclarativeSync) processTemplateJob(ctx context.Context, jsonFile, conf
 		return result
 	}
 
+	// Delta sync: compare per-server content hashes of the rendered config
+	// against the last sync so unchanged servers skip tool re-discovery
+	// (--full bypasses the check)
+	currentHashes := computeServerConfigHashes(mcpConfig)
+	var changedServers []string
+	fileConfig, fileConfigErr := s.repos.FileMCPConfigs.GetByEnvironmentAndName(env.ID, configName)
+	if fileConfigErr == nil {
+		changedServers = changedServerNames(loadStoredServerHashes(fileConfig), currentHashes)
+	} else {
+		changedServers = changedServerNames(nil, currentHashes)
+	}
+
 	// 2. Extract and sync MCP servers from the template
 	serversCount, err := s.syncMCPServersFromTemplate(ctx, mcpConfig, env.ID, configName, options)
 	if err != nil {
@@ -481,15 +572,31 @@ func (s *DeclarativeSync) processTemplateJob(ctx context.Context, jsonFile, conf
 
 	// 3. Perform tool discovery for the servers that were created/updated
 	if serversCount > 0 && !options.DryRun {
-		toolsDiscovered, err := s.performToolDiscovery(ctx, env.ID, configName)
-		if err != nil {
-			// Tool discovery failure IS fatal - broken servers are auto-cleaned by performToolDiscovery
-			fmt.Printf("   ❌ Tool discovery failed for %s: %v\n", configName, err)
-			result.error = fmt.Errorf("tool discovery failed: %w", err)
-			return result
+		if len(changedServers) == 0 && !options.Full {
+			fmt.Printf("   ⏭️  Server configs unchanged for %s - skipping tool discovery (use --full to force)\n", configName)
+			result.mcpServersCount = serversCount
+		} else {
+			if len(changedServers) > 0 && options.Verbose {
+				fmt.Printf("   🔁 Changed servers: %s\n", strings.Join(changedServers, ", "))
+			}
+			toolsDiscovered, err := s.performToolDiscovery(ctx, env.ID, configName, options.Progress)
+			if err != nil {
+				// Tool discovery failure IS fatal - broken servers are auto-cleaned by performToolDiscovery
+				fmt.Printf("   ❌ Tool discovery failed for %s: %v\n", configName, err)
+				result.error = fmt.Errorf("tool discovery failed: %w", err)
+				return result
+			}
+			fmt.Printf("   🔧 Discovered %d tools from MCP servers\n", toolsDiscovered)
+			result.mcpServersCount = serversCount
+
+			// Record the hashes only after successful discovery so a failed
+			// sync retries the same servers next time
+			if fileConfig != nil {
+				if hashErr := s.storeServerHashes(fileConfig, currentHashes); hashErr != nil {
+					fmt.Printf("   ⚠️  Failed to record server config hashes: %v\n", hashErr)
+				}
+			}
 		}
-		fmt.Printf("   🔧 Discovered %d tools from MCP servers\n", toolsDiscovered)
-		result.mcpServersCount = serversCount
 	} else if serversCount > 0 {
 		result.mcpServersCount = serversCount
 	}
@@ -770,6 +877,13 @@ func (s *DeclarativeSync) processOpenAPISpecs(ctx context.Context, openapiFiles
 			continue
 		}
 
+		// Specs bundled by `stn mcp add-openapi-dir` are already wired into a
+		// merged template; converting them again would duplicate their tools
+		if bundleFile, bundled := s.specReferencedByBundle(envDir, specName); bundled {
+			fmt.Printf("  Skipping OpenAPI spec %s - bundled in %s\n", specName, bundleFile)
+			continue
+		}
+
 		fmt.Printf("  Converting OpenAPI spec: %s\n", specName)
 
 		// Read the OpenAPI spec
@@ -878,3 +992,31 @@ func (s *DeclarativeSync) processOpenAPISpecs(ctx context.Context, openapiFiles
 
 	return result, nil
 }
+
+// specReferencedByBundle reports whether an OpenAPI spec is already referenced
+// by a merged bundle template in the environment (created by
+// `stn mcp add-openapi-dir`). Generated per-spec configs (*-openapi-mcp.json)
+// and the specs themselves are excluded so the single-spec flow is unaffected.
+func (s *DeclarativeSync) specReferencedByBundle(envDir, specName string) (string, bool) {
+	templateFiles, err := filepath.Glob(filepath.Join(envDir, "*.json"))
+	if err != nil {
+		return "", false
+	}
+
+	specFileName := specName + ".openapi.json"
+	for _, templateFile := range templateFiles {
+		baseName := filepath.Base(templateFile)
+		if strings.HasSuffix(baseName, ".openapi.json") || strings.HasSuffix(baseName, "-openapi-mcp.json") {
+			continue
+		}
+
+		data, err := os.ReadFile(templateFile)
+		if err != nil {
+			continue
+		}
+		if strings.Contains(string(data), specFileName) {
+			return baseName, true
+		}
+	}
+	return "", false
+}
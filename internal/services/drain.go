@@ -0,0 +1,115 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrServerDraining is returned when a new execution is requested while the
+// server is draining ahead of a restart
+var ErrServerDraining = errors.New("server is draining; not accepting new executions")
+
+// DrainCoordinator tracks in-flight agent executions so serve mode can drain
+// before a restart: once draining begins, new runs and workflow starts are
+// rejected while active executions are allowed to finish. Queued work (queued
+// runs in the database, pending JetStream messages) survives the restart and
+// is picked up by the next process, so only in-flight executions need to be
+// waited on.
+type DrainCoordinator struct {
+	mu       sync.Mutex
+	draining bool
+	inFlight int64
+	idle     chan struct{}
+}
+
+var (
+	sharedDrainCoordinator     *DrainCoordinator
+	sharedDrainCoordinatorOnce sync.Once
+)
+
+// SharedDrainCoordinator returns the process-wide drain coordinator.
+// Executions enter through several paths (CLI, MCP, API, execution queue),
+// so the coordinator must be shared for draining to cover all of them.
+func SharedDrainCoordinator() *DrainCoordinator {
+	sharedDrainCoordinatorOnce.Do(func() {
+		sharedDrainCoordinator = &DrainCoordinator{}
+	})
+	return sharedDrainCoordinator
+}
+
+// NewDrainCoordinator creates an independent coordinator (used by tests)
+func NewDrainCoordinator() *DrainCoordinator {
+	return &DrainCoordinator{}
+}
+
+// TrackExecution registers a new in-flight execution. It returns
+// ErrServerDraining once draining has begun; callers must not start the
+// execution in that case. Every successful call must be paired with
+// UntrackExecution.
+func (d *DrainCoordinator) TrackExecution() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return ErrServerDraining
+	}
+	d.inFlight++
+	return nil
+}
+
+// UntrackExecution marks an in-flight execution as finished
+func (d *DrainCoordinator) UntrackExecution() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.inFlight > 0 {
+		d.inFlight--
+	}
+	if d.inFlight == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// BeginDrain stops new executions from being accepted. Safe to call more
+// than once.
+func (d *DrainCoordinator) BeginDrain() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.draining = true
+}
+
+// IsDraining reports whether drain mode is active
+func (d *DrainCoordinator) IsDraining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// InFlight returns the number of executions currently running
+func (d *DrainCoordinator) InFlight() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.inFlight
+}
+
+// Wait blocks until all in-flight executions finish or the deadline passes,
+// returning true if the server drained fully
+func (d *DrainCoordinator) Wait(timeout time.Duration) bool {
+	d.mu.Lock()
+	if d.inFlight == 0 {
+		d.mu.Unlock()
+		return true
+	}
+	if d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	idle := d.idle
+	d.mu.Unlock()
+
+	select {
+	case <-idle:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
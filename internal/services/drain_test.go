@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDrainCoordinatorRejectsNewExecutionsWhileDraining(t *testing.T) {
+	d := NewDrainCoordinator()
+
+	if err := d.TrackExecution(); err != nil {
+		t.Fatalf("TrackExecution returned error before drain: %v", err)
+	}
+	if d.InFlight() != 1 {
+		t.Errorf("expected 1 in-flight execution, got %d", d.InFlight())
+	}
+
+	d.BeginDrain()
+	if !d.IsDraining() {
+		t.Error("expected IsDraining to be true after BeginDrain")
+	}
+	if err := d.TrackExecution(); !errors.Is(err, ErrServerDraining) {
+		t.Errorf("expected ErrServerDraining, got %v", err)
+	}
+
+	// The already-running execution still counts and can finish
+	if d.InFlight() != 1 {
+		t.Errorf("expected 1 in-flight execution after rejected start, got %d", d.InFlight())
+	}
+	d.UntrackExecution()
+	if d.InFlight() != 0 {
+		t.Errorf("expected 0 in-flight executions, got %d", d.InFlight())
+	}
+}
+
+func TestDrainCoordinatorWaitReturnsWhenIdle(t *testing.T) {
+	d := NewDrainCoordinator()
+
+	// Nothing running: Wait returns immediately
+	if !d.Wait(10 * time.Millisecond) {
+		t.Fatal("expected Wait to succeed with no in-flight executions")
+	}
+
+	if err := d.TrackExecution(); err != nil {
+		t.Fatalf("TrackExecution returned error: %v", err)
+	}
+	d.BeginDrain()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		d.UntrackExecution()
+	}()
+
+	if !d.Wait(2 * time.Second) {
+		t.Error("expected Wait to succeed once the execution finished")
+	}
+	if d.InFlight() != 0 {
+		t.Errorf("expected 0 in-flight executions after drain, got %d", d.InFlight())
+	}
+}
+
+func TestDrainCoordinatorWaitDeadline(t *testing.T) {
+	d := NewDrainCoordinator()
+
+	if err := d.TrackExecution(); err != nil {
+		t.Fatalf("TrackExecution returned error: %v", err)
+	}
+	defer d.UntrackExecution()
+	d.BeginDrain()
+
+	if d.Wait(20 * time.Millisecond) {
+		t.Error("expected Wait to report timeout while an execution is still running")
+	}
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+	"station/pkg/models"
+)
+
+// EnvironmentCloneService deep-copies one environment into a new one:
+// MCP template configs, agent .prompt files, and workflow definitions are
+// copied on disk, while variables.yml is deliberately left to the caller so
+// environment-specific values (endpoints, credentials, regions) can be
+// re-entered for the clone instead of silently inherited. After the caller
+// syncs the new environment, AssignToolsFromSource restores the DB-side
+// agent-tool assignments that a plain directory copy misses.
+type EnvironmentCloneService struct {
+	repos       *repositories.Repositories
+	envMgmt     *EnvironmentManagementService
+	copyService *EnvironmentCopyService
+}
+
+// NewEnvironmentCloneService creates a new environment clone service
+func NewEnvironmentCloneService(repos *repositories.Repositories) *EnvironmentCloneService {
+	return &EnvironmentCloneService{
+		repos:       repos,
+		envMgmt:     NewEnvironmentManagementService(repos),
+		copyService: NewEnvironmentCopyService(repos),
+	}
+}
+
+// CloneFilesResult counts the file-based artifacts copied into the clone
+type CloneFilesResult struct {
+	TemplatesCopied int `json:"templates_copied"`
+	AgentsCopied    int `json:"agents_copied"`
+	WorkflowsCopied int `json:"workflows_copied"`
+}
+
+// PrepareClone creates the target environment and copies the source
+// environment's file-based configuration (MCP templates, agents, workflows)
+// into it. variables.yml is NOT copied — write it with WriteVariables before
+// syncing the target. On a file copy failure the half-created target
+// environment is removed again.
+func (s *EnvironmentCloneService) PrepareClone(sourceName, targetName string, createdByUserID int64) (*models.Environment, *CloneFilesResult, error) {
+	if _, err := s.repos.Environments.GetByName(sourceName); err != nil {
+		return nil, nil, fmt.Errorf("source environment '%s' not found: %w", sourceName, err)
+	}
+	if _, err := s.repos.Environments.GetByName(targetName); err == nil {
+		return nil, nil, fmt.Errorf("target environment '%s' already exists", targetName)
+	}
+
+	sourceDir := config.GetEnvironmentDir(sourceName)
+	if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("source environment directory not found: %s", sourceDir)
+	}
+
+	targetEnv, _, err := s.envMgmt.CreateEnvironment(targetName, nil, createdByUserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create target environment: %w", err)
+	}
+
+	files, err := cloneEnvironmentFiles(sourceDir, config.GetEnvironmentDir(targetName))
+	if err != nil {
+		// Roll back so a failed clone doesn't leave a half-populated environment
+		s.envMgmt.DeleteEnvironment(targetName)
+		return nil, nil, fmt.Errorf("failed to copy environment files: %w", err)
+	}
+
+	logging.Info("Cloned environment files from '%s' to '%s': %d templates, %d agents, %d workflows",
+		sourceName, targetName, files.TemplatesCopied, files.AgentsCopied, files.WorkflowsCopied)
+
+	return targetEnv, files, nil
+}
+
+// SourceVariables reads the source environment's variables.yml so callers can
+// re-prompt for each value. Returns an empty map when the file doesn't exist.
+func (s *EnvironmentCloneService) SourceVariables(sourceName string) (map[string]string, error) {
+	return loadVariablesFile(filepath.Join(config.GetEnvironmentDir(sourceName), "variables.yml"))
+}
+
+// WriteVariables writes the clone's variables.yml with the given values
+func (s *EnvironmentCloneService) WriteVariables(targetName string, vars map[string]string) error {
+	path := filepath.Join(config.GetEnvironmentDir(targetName), "variables.yml")
+	return os.WriteFile(path, []byte(renderVariablesYAML(targetName, vars)), 0644)
+}
+
+// CopyVariablesFile validates a caller-supplied variables file and installs it
+// as the clone's variables.yml
+func (s *EnvironmentCloneService) CopyVariablesFile(targetName, varsFilePath string) error {
+	content, err := os.ReadFile(varsFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read variables file: %w", err)
+	}
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal(content, &parsed); err != nil {
+		return fmt.Errorf("variables file %s is not valid YAML: %w", varsFilePath, err)
+	}
+	path := filepath.Join(config.GetEnvironmentDir(targetName), "variables.yml")
+	return os.WriteFile(path, content, 0644)
+}
+
+// AssignToolsFromSource copies DB-side agent-tool assignments from the source
+// environment onto the clone's agents. Call this after syncing the target so
+// its tools have been discovered.
+func (s *EnvironmentCloneService) AssignToolsFromSource(sourceName, targetName string) (int, error) {
+	sourceEnv, err := s.repos.Environments.GetByName(sourceName)
+	if err != nil {
+		return 0, fmt.Errorf("source environment '%s' not found: %w", sourceName, err)
+	}
+	targetEnv, err := s.repos.Environments.GetByName(targetName)
+	if err != nil {
+		return 0, fmt.Errorf("target environment '%s' not found: %w", targetName, err)
+	}
+	return s.copyService.AssignToolsFromSource(targetEnv.ID, sourceEnv.ID)
+}
+
+// cloneEnvironmentFiles copies template configs (root *.json), agent .prompt
+// files, and the workflows/ directory from sourceDir into targetDir.
+// variables.yml is skipped on purpose — its values are environment-specific.
+func cloneEnvironmentFiles(sourceDir, targetDir string) (*CloneFilesResult, error) {
+	result := &CloneFilesResult{}
+
+	entries, err := os.ReadDir(sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		if err := copyEnvironmentFile(filepath.Join(sourceDir, entry.Name()), filepath.Join(targetDir, entry.Name())); err != nil {
+			return nil, err
+		}
+		result.TemplatesCopied++
+	}
+
+	sourceAgentsDir := filepath.Join(sourceDir, "agents")
+	if agentEntries, err := os.ReadDir(sourceAgentsDir); err == nil {
+		targetAgentsDir := filepath.Join(targetDir, "agents")
+		if err := os.MkdirAll(targetAgentsDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create agents directory: %w", err)
+		}
+		for _, entry := range agentEntries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".prompt") {
+				continue
+			}
+			if err := copyEnvironmentFile(filepath.Join(sourceAgentsDir, entry.Name()), filepath.Join(targetAgentsDir, entry.Name())); err != nil {
+				return nil, err
+			}
+			result.AgentsCopied++
+		}
+	}
+
+	sourceWorkflowsDir := filepath.Join(sourceDir, "workflows")
+	if _, err := os.Stat(sourceWorkflowsDir); err == nil {
+		targetWorkflowsDir := filepath.Join(targetDir, "workflows")
+		copied, err := copyDirectoryFiles(sourceWorkflowsDir, targetWorkflowsDir)
+		if err != nil {
+			return nil, err
+		}
+		result.WorkflowsCopied = copied
+	}
+
+	return result, nil
+}
+
+// copyDirectoryFiles recursively copies all regular files under sourceDir
+// into targetDir, preserving the directory layout, and returns the file count
+func copyDirectoryFiles(sourceDir, targetDir string) (int, error) {
+	copied := 0
+	err := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(targetDir, relPath)
+		if info.IsDir() {
+			return os.MkdirAll(targetPath, 0755)
+		}
+		if err := copyEnvironmentFile(path, targetPath); err != nil {
+			return err
+		}
+		copied++
+		return nil
+	})
+	return copied, err
+}
+
+// copyEnvironmentFile copies one file, creating parent directories as needed
+func copyEnvironmentFile(sourcePath, targetPath string) error {
+	content, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", sourcePath, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", targetPath, err)
+	}
+	if err := os.WriteFile(targetPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", targetPath, err)
+	}
+	return nil
+}
+
+// renderVariablesYAML renders a variables.yml body with stable key ordering
+func renderVariablesYAML(envName string, vars map[string]string) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("# Environment variables for %s\n", envName))
+
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		line, err := yaml.Marshal(map[string]string{key: vars[key]})
+		if err != nil {
+			continue
+		}
+		builder.Write(line)
+	}
+	return builder.String()
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCloneEnvironmentFiles tests that the file-based clone copies templates,
+// agents, and workflows while skipping variables.yml
+func TestCloneEnvironmentFiles(t *testing.T) {
+	sourceDir := t.TempDir()
+	targetDir := t.TempDir()
+
+	writeCloneFixture(t, sourceDir, "template.json", `{"name":"prod"}`)
+	writeCloneFixture(t, sourceDir, "aws-tools.json", `{"mcpServers":{}}`)
+	writeCloneFixture(t, sourceDir, "variables.yml", "REGION: us-east-1\n")
+	writeCloneFixture(t, sourceDir, "README.md", "notes")
+	writeCloneFixture(t, sourceDir, filepath.Join("agents", "scanner.prompt"), "---\nmodel: gpt-4o-mini\n---\nscan")
+	writeCloneFixture(t, sourceDir, filepath.Join("agents", "notes.txt"), "not an agent")
+	writeCloneFixture(t, sourceDir, filepath.Join("workflows", "pipeline.workflow.yaml"), "steps: []")
+	writeCloneFixture(t, sourceDir, filepath.Join("workflows", "nested", "other.workflow.yaml"), "steps: []")
+
+	result, err := cloneEnvironmentFiles(sourceDir, targetDir)
+	if err != nil {
+		t.Fatalf("cloneEnvironmentFiles() error: %v", err)
+	}
+
+	if result.TemplatesCopied != 2 {
+		t.Errorf("TemplatesCopied = %d, want 2", result.TemplatesCopied)
+	}
+	if result.AgentsCopied != 1 {
+		t.Errorf("AgentsCopied = %d, want 1", result.AgentsCopied)
+	}
+	if result.WorkflowsCopied != 2 {
+		t.Errorf("WorkflowsCopied = %d, want 2", result.WorkflowsCopied)
+	}
+
+	if _, err := os.Stat(filepath.Join(targetDir, "template.json")); err != nil {
+		t.Error("template.json should be copied")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "agents", "scanner.prompt")); err != nil {
+		t.Error("agents/scanner.prompt should be copied")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "workflows", "nested", "other.workflow.yaml")); err != nil {
+		t.Error("nested workflow files should be copied")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "variables.yml")); !os.IsNotExist(err) {
+		t.Error("variables.yml must not be copied — its values are environment-specific")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "README.md")); !os.IsNotExist(err) {
+		t.Error("non-template root files should not be copied")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "agents", "notes.txt")); !os.IsNotExist(err) {
+		t.Error("non-.prompt files in agents/ should not be copied")
+	}
+}
+
+// TestCloneEnvironmentFilesEmptySource tests cloning a bare environment
+func TestCloneEnvironmentFilesEmptySource(t *testing.T) {
+	result, err := cloneEnvironmentFiles(t.TempDir(), t.TempDir())
+	if err != nil {
+		t.Fatalf("cloneEnvironmentFiles() error: %v", err)
+	}
+	if result.TemplatesCopied != 0 || result.AgentsCopied != 0 || result.WorkflowsCopied != 0 {
+		t.Errorf("empty source should copy nothing, got %+v", result)
+	}
+}
+
+// TestRenderVariablesYAML tests that the rendered variables.yml round-trips
+// through the standard variables loader with stable key ordering
+func TestRenderVariablesYAML(t *testing.T) {
+	body := renderVariablesYAML("prod-dr", map[string]string{
+		"REGION":       "us-west-2",
+		"DATABASE_URL": "postgres://dr-host/app",
+	})
+
+	if !strings.HasPrefix(body, "# Environment variables for prod-dr") {
+		t.Errorf("rendered variables should carry the environment header, got %q", body)
+	}
+	if strings.Index(body, "DATABASE_URL") > strings.Index(body, "REGION") {
+		t.Error("keys should be rendered in sorted order")
+	}
+
+	path := filepath.Join(t.TempDir(), "variables.yml")
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatalf("failed to write rendered variables: %v", err)
+	}
+	vars, err := loadVariablesFile(path)
+	if err != nil {
+		t.Fatalf("rendered variables.yml should parse: %v", err)
+	}
+	if vars["REGION"] != "us-west-2" || vars["DATABASE_URL"] != "postgres://dr-host/app" {
+		t.Errorf("round-tripped variables = %v", vars)
+	}
+}
+
+func writeCloneFixture(t *testing.T, root, relPath, content string) {
+	t.Helper()
+	path := filepath.Join(root, relPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create fixture directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write fixture %s: %v", relPath, err)
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -62,7 +63,7 @@ func (s *EnvironmentManagementService) CreateEnvironment(name string, descriptio
 	// Create environment directory structure
 	if err := os.MkdirAll(agentsDir, 0755); err != nil {
 		// Cleanup database entry if directory creation fails
-		s.repos.Environments.Delete(env.ID)
+		s.repos.Environments.HardDelete(env.ID)
 		return nil, &EnvironmentOperationResult{
 			Success: false,
 			Message: fmt.Sprintf("Failed to create environment directory: %v", err),
@@ -76,7 +77,7 @@ func (s *EnvironmentManagementService) CreateEnvironment(name string, descriptio
 	if err := os.WriteFile(variablesPath, []byte(defaultVariables), 0644); err != nil {
 		// Cleanup if variables file creation fails
 		os.RemoveAll(envDir)
-		s.repos.Environments.Delete(env.ID)
+		s.repos.Environments.HardDelete(env.ID)
 		return nil, &EnvironmentOperationResult{
 			Success: false,
 			Message: fmt.Sprintf("Failed to create variables.yml: %v", err),
@@ -113,16 +114,23 @@ func (s *EnvironmentManagementService) DeleteEnvironment(name string) *Environme
 		}
 	}
 
-	// Delete file-based configuration first
+	// Park the environment directory in the trash so a restore can bring
+	// the file-based configuration back; the purger removes it for good
 	envDir := config.GetEnvironmentDir(name)
 
-	// Remove environment directory and all contents
 	var fileCleanupError error
 	if _, err := os.Stat(envDir); err == nil {
-		fileCleanupError = os.RemoveAll(envDir)
+		trashDir := trashedEnvironmentDir(name)
+		if err := os.MkdirAll(filepath.Dir(trashDir), 0755); err != nil {
+			fileCleanupError = err
+		} else {
+			os.RemoveAll(trashDir)
+			fileCleanupError = os.Rename(envDir, trashDir)
+		}
 	}
 
-	// Delete database entries (this also deletes associated agents, runs, etc. via foreign key constraints)
+	// Soft-delete database entries; agents and runs are kept until the
+	// retention window expires
 	dbDeleteError := s.repos.Environments.Delete(env.ID)
 
 	// Prepare response with cleanup status
@@ -142,7 +150,7 @@ func (s *EnvironmentManagementService) DeleteEnvironment(name string) *Environme
 	}
 
 	if dbDeleteError == nil && fileCleanupError == nil {
-		result.Message = fmt.Sprintf("Environment '%s' deleted successfully", name)
+		result.Message = fmt.Sprintf("Environment '%s' deleted (recoverable with 'stn env restore %s' until purged)", name, name)
 	} else if dbDeleteError == nil {
 		result.Message = fmt.Sprintf("Environment '%s' deleted from database, but file cleanup failed", name)
 	} else {
@@ -152,6 +160,71 @@ func (s *EnvironmentManagementService) DeleteEnvironment(name string) *Environme
 	return result
 }
 
+// trashedEnvironmentDir is where a deleted environment's directory is
+// parked until the environment is restored or purged
+func trashedEnvironmentDir(name string) string {
+	envDir := config.GetEnvironmentDir(name)
+	return filepath.Join(filepath.Dir(envDir), ".trash", name)
+}
+
+// RestoreEnvironment undoes a soft deletion: the database entry (and the
+// agents deleted with it) come back and the parked directory is moved into
+// place
+func (s *EnvironmentManagementService) RestoreEnvironment(name string) *EnvironmentOperationResult {
+	deleted, err := s.repos.Environments.ListDeleted(context.Background())
+	if err != nil {
+		return &EnvironmentOperationResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to list deleted environments: %v", err),
+		}
+	}
+
+	var target *repositories.SoftDeletedEnvironment
+	for i := range deleted {
+		if deleted[i].Name == name {
+			target = &deleted[i]
+			break
+		}
+	}
+	if target == nil {
+		return &EnvironmentOperationResult{
+			Success: false,
+			Message: fmt.Sprintf("No deleted environment named '%s' found", name),
+		}
+	}
+
+	if err := s.repos.Environments.Restore(context.Background(), target.ID); err != nil {
+		return &EnvironmentOperationResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to restore environment '%s': %v", name, err),
+		}
+	}
+
+	// Move the parked directory back if it exists and nothing took its place
+	envDir := config.GetEnvironmentDir(name)
+	trashDir := trashedEnvironmentDir(name)
+	var fileRestoreError error
+	if _, err := os.Stat(trashDir); err == nil {
+		if _, err := os.Stat(envDir); os.IsNotExist(err) {
+			fileRestoreError = os.Rename(trashDir, envDir)
+		} else {
+			fileRestoreError = fmt.Errorf("directory %s already exists; parked files left in %s", envDir, trashDir)
+		}
+	}
+
+	result := &EnvironmentOperationResult{
+		Success:       true,
+		Environment:   name,
+		DirectoryPath: envDir,
+		Message:       fmt.Sprintf("Environment '%s' restored", name),
+	}
+	if fileRestoreError != nil {
+		result.FileCleanupError = fileRestoreError.Error()
+		result.Message = fmt.Sprintf("Environment '%s' restored in database, but file restore failed", name)
+	}
+	return result
+}
+
 // DeleteEnvironmentByID deletes environment by ID (for API compatibility)
 func (s *EnvironmentManagementService) DeleteEnvironmentByID(id int64) *EnvironmentOperationResult {
 	// Get environment by ID to get the name
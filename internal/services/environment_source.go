@@ -0,0 +1,248 @@
+package services
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"station/internal/config"
+	"station/internal/logging"
+)
+
+// environmentSourceFile is the per-environment file declaring a remote
+// storage backend for the environment's configs
+const environmentSourceFile = "source.yml"
+
+// EnvironmentSource declares a remote storage backend for an environment's
+// file configs (MCP templates, agents, workflows). An environment with a
+// source.yml is materialized from a remote tar.gz archive instead of being
+// authored locally — needed for containerized deployments with read-only
+// images. The archive is cached locally and refreshed with ETag conditional
+// requests, so syncs while the remote is unchanged (or unreachable) reuse
+// the cache.
+//
+// Supported URL schemes:
+//   - https:// / http://  — any HTTP archive endpoint
+//   - s3://bucket/key     — fetched via the bucket's HTTPS endpoint
+//     (public buckets or presigned access)
+//   - gs://bucket/key     — fetched via storage.googleapis.com
+//
+// variables.yml is never overwritten from the archive: like bundles, the
+// archive carries structure while secrets stay local.
+type EnvironmentSource struct {
+	URL string `yaml:"url"`
+	// Headers are sent with every request (e.g. Authorization for private archives)
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// LoadEnvironmentSource reads the environment's source.yml. A missing file
+// means the environment is purely local — (nil, nil).
+func LoadEnvironmentSource(envDir string) (*EnvironmentSource, error) {
+	content, err := os.ReadFile(filepath.Join(envDir, environmentSourceFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read environment source config: %w", err)
+	}
+
+	var source EnvironmentSource
+	if err := yaml.Unmarshal(content, &source); err != nil {
+		return nil, fmt.Errorf("failed to parse environment source config: %w", err)
+	}
+	if source.URL == "" {
+		return nil, fmt.Errorf("environment source config is missing a url")
+	}
+	return &source, nil
+}
+
+// fetchURL translates s3:// and gs:// URLs to their HTTPS endpoints; plain
+// HTTP(S) URLs pass through unchanged
+func (s *EnvironmentSource) fetchURL() (string, error) {
+	parsed, err := url.Parse(s.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid environment source url %q: %w", s.URL, err)
+	}
+
+	switch parsed.Scheme {
+	case "http", "https":
+		return s.URL, nil
+	case "s3":
+		if parsed.Host == "" || parsed.Path == "" {
+			return "", fmt.Errorf("s3 source url must be s3://bucket/key, got %q", s.URL)
+		}
+		return fmt.Sprintf("https://%s.s3.amazonaws.com%s", parsed.Host, parsed.Path), nil
+	case "gs":
+		if parsed.Host == "" || parsed.Path == "" {
+			return "", fmt.Errorf("gcs source url must be gs://bucket/object, got %q", s.URL)
+		}
+		return fmt.Sprintf("https://storage.googleapis.com/%s%s", parsed.Host, parsed.Path), nil
+	default:
+		return "", fmt.Errorf("unsupported environment source scheme %q (use http(s)://, s3:// or gs://)", parsed.Scheme)
+	}
+}
+
+// environmentSourceCacheDir is where downloaded archives and their ETags live
+func environmentSourceCacheDir() string {
+	return filepath.Join(config.GetConfigRoot(), "cache", "environment-sources")
+}
+
+// RefreshEnvironmentSource fetches the environment's remote archive if its
+// configs come from a remote source, extracting it over the environment
+// directory. Returns whether fresh content was downloaded. Environments
+// without a source.yml return (false, nil) untouched. When the remote
+// responds 304 Not Modified — or is unreachable but a cached archive exists
+// — the cached copy keeps serving.
+func RefreshEnvironmentSource(environmentName string) (bool, error) {
+	envDir := config.GetEnvironmentDir(environmentName)
+	source, err := LoadEnvironmentSource(envDir)
+	if err != nil {
+		return false, err
+	}
+	if source == nil {
+		return false, nil
+	}
+
+	fetchURL, err := source.fetchURL()
+	if err != nil {
+		return false, err
+	}
+
+	cacheDir := environmentSourceCacheDir()
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return false, fmt.Errorf("failed to create source cache directory: %w", err)
+	}
+	archivePath := filepath.Join(cacheDir, environmentName+".tar.gz")
+	etagPath := filepath.Join(cacheDir, environmentName+".etag")
+
+	req, err := http.NewRequest("GET", fetchURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to build source request: %w", err)
+	}
+	for key, value := range source.Headers {
+		req.Header.Set(key, value)
+	}
+	if etag, err := os.ReadFile(etagPath); err == nil && len(etag) > 0 {
+		req.Header.Set("If-None-Match", strings.TrimSpace(string(etag)))
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		// Unreachable remote: fall back to the cached archive when present so
+		// air-gapped restarts still sync
+		if _, statErr := os.Stat(archivePath); statErr == nil {
+			logging.Info("⚠️  Environment source unreachable (%v); using cached archive for '%s'", err, environmentName)
+			return false, extractEnvironmentArchive(archivePath, envDir)
+		}
+		return false, fmt.Errorf("failed to fetch environment source: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		logging.Debug("Environment source for '%s' unchanged (ETag match)", environmentName)
+		return false, nil
+	case http.StatusOK:
+		// Fall through to download
+	default:
+		return false, fmt.Errorf("environment source fetch failed with status %d", resp.StatusCode)
+	}
+
+	outFile, err := os.Create(archivePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to create archive cache file: %w", err)
+	}
+	_, copyErr := io.Copy(outFile, resp.Body)
+	_ = outFile.Close()
+	if copyErr != nil {
+		return false, fmt.Errorf("failed to download environment archive: %w", copyErr)
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		if err := os.WriteFile(etagPath, []byte(etag), 0644); err != nil {
+			logging.Debug("Failed to record source ETag: %v", err)
+		}
+	} else {
+		_ = os.Remove(etagPath)
+	}
+
+	if err := extractEnvironmentArchive(archivePath, envDir); err != nil {
+		return false, err
+	}
+	logging.Info("☁️ Refreshed environment '%s' from remote source", environmentName)
+	return true, nil
+}
+
+// extractEnvironmentArchive extracts a cached tar.gz archive over the
+// environment directory, skipping variables.yml and the source config so
+// local values survive refreshes
+func extractEnvironmentArchive(archivePath, envDir string) error {
+	if err := os.MkdirAll(envDir, 0755); err != nil {
+		return fmt.Errorf("failed to create environment directory: %w", err)
+	}
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open cached archive: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer func() { _ = gzipReader.Close() }()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar entry: %w", err)
+		}
+
+		name := strings.TrimPrefix(header.Name, "./")
+		if name == "" || name == "variables.yml" || name == environmentSourceFile {
+			continue
+		}
+
+		destPath := filepath.Join(envDir, name)
+		// Guard against path traversal from a hostile archive
+		if !strings.HasPrefix(filepath.Clean(destPath), filepath.Clean(envDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("archive entry %q escapes the environment directory", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(destPath, 0755); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", destPath, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+			outFile, err := os.Create(destPath)
+			if err != nil {
+				return fmt.Errorf("failed to create file %s: %w", destPath, err)
+			}
+			_, copyErr := io.Copy(outFile, tarReader)
+			_ = outFile.Close()
+			if copyErr != nil {
+				return fmt.Errorf("failed to write file %s: %w", destPath, copyErr)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSourceArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(content)),
+		}); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write tar content: %v", err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func setupSourceEnv(t *testing.T, envName, sourceURL string) string {
+	t.Helper()
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	envDir := filepath.Join(configHome, "station", "environments", envName)
+	if err := os.MkdirAll(envDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(envDir, "source.yml"),
+		[]byte("url: "+sourceURL+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return envDir
+}
+
+func TestLoadEnvironmentSourceMissing(t *testing.T) {
+	source, err := LoadEnvironmentSource(t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected no error for local-only environment, got %v", err)
+	}
+	if source != nil {
+		t.Errorf("Expected nil source without source.yml, got %+v", source)
+	}
+}
+
+func TestEnvironmentSourceFetchURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+		wantErr  bool
+	}{
+		{"https passthrough", "https://example.com/env.tar.gz", "https://example.com/env.tar.gz", false},
+		{"s3 translation", "s3://my-bucket/configs/prod.tar.gz", "https://my-bucket.s3.amazonaws.com/configs/prod.tar.gz", false},
+		{"gcs translation", "gs://my-bucket/configs/prod.tar.gz", "https://storage.googleapis.com/my-bucket/configs/prod.tar.gz", false},
+		{"unsupported scheme", "ftp://example.com/env.tar.gz", "", true},
+		{"s3 missing key", "s3://my-bucket", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &EnvironmentSource{URL: tt.url}
+			got, err := source.fetchURL()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q", tt.url)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRefreshEnvironmentSourceDownloadsAndExtracts(t *testing.T) {
+	archive := writeSourceArchive(t, map[string]string{
+		"template.json":        `{"name": "remote-env"}`,
+		"agents/Remote.prompt": "---\nmodel: gpt-4o-mini\n---\nremote agent",
+		"variables.yml":        "SHOULD_NOT: overwrite",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	envDir := setupSourceEnv(t, "remote-env", server.URL)
+	localVars := "LOCAL_SECRET: keep-me\n"
+	if err := os.WriteFile(filepath.Join(envDir, "variables.yml"), []byte(localVars), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := RefreshEnvironmentSource("remote-env")
+	if err != nil {
+		t.Fatalf("RefreshEnvironmentSource failed: %v", err)
+	}
+	if !refreshed {
+		t.Error("Expected refresh on first fetch")
+	}
+
+	if _, err := os.Stat(filepath.Join(envDir, "template.json")); err != nil {
+		t.Errorf("Expected template.json to be extracted: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(envDir, "agents", "Remote.prompt")); err != nil {
+		t.Errorf("Expected agent prompt to be extracted: %v", err)
+	}
+	vars, err := os.ReadFile(filepath.Join(envDir, "variables.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(vars) != localVars {
+		t.Errorf("Expected local variables.yml to survive refresh, got %q", vars)
+	}
+}
+
+func TestRefreshEnvironmentSourceHonorsETag(t *testing.T) {
+	archive := writeSourceArchive(t, map[string]string{"template.json": `{"name": "etag-env"}`})
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	setupSourceEnv(t, "etag-env", server.URL)
+
+	if refreshed, err := RefreshEnvironmentSource("etag-env"); err != nil || !refreshed {
+		t.Fatalf("Expected initial download, got refreshed=%v err=%v", refreshed, err)
+	}
+	if refreshed, err := RefreshEnvironmentSource("etag-env"); err != nil || refreshed {
+		t.Fatalf("Expected 304 reuse on second sync, got refreshed=%v err=%v", refreshed, err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 conditional requests, got %d", requests)
+	}
+}
+
+func TestRefreshEnvironmentSourceFallsBackToCache(t *testing.T) {
+	archive := writeSourceArchive(t, map[string]string{"template.json": `{"name": "cache-env"}`})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(archive)
+	}))
+
+	envDir := setupSourceEnv(t, "cache-env", server.URL)
+	if refreshed, err := RefreshEnvironmentSource("cache-env"); err != nil || !refreshed {
+		t.Fatalf("Expected initial download, got refreshed=%v err=%v", refreshed, err)
+	}
+
+	// Remote goes away; the extracted file is removed to prove re-extraction
+	// from the cached archive
+	server.Close()
+	if err := os.Remove(filepath.Join(envDir, "template.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := RefreshEnvironmentSource("cache-env")
+	if err != nil {
+		t.Fatalf("Expected cache fallback when remote is unreachable, got %v", err)
+	}
+	if refreshed {
+		t.Error("Cache fallback should not report fresh content")
+	}
+	if _, err := os.Stat(filepath.Join(envDir, "template.json")); err != nil {
+		t.Errorf("Expected template.json restored from cached archive: %v", err)
+	}
+}
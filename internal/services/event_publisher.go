@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"station/internal/config"
+	"station/internal/events"
+	"station/internal/logging"
+)
+
+var registerEventConsumersOnce sync.Once
+
+// RegisterEventConsumers wires config-declared consumers onto the internal
+// event bus and enables tool-call event publishing. Safe to call from
+// multiple engine constructors; only the first call takes effect.
+func RegisterEventConsumers(cfg config.EventsConfig) {
+	registerEventConsumersOnce.Do(func() {
+		registered := 0
+		for _, consumerCfg := range cfg.Consumers {
+			consumer, err := events.NewConsumerFromConfig(consumerCfg)
+			if err != nil {
+				logging.Info("Skipping event consumer %s: %v", consumerCfg.Name, err)
+				continue
+			}
+			events.Register(consumer)
+			registered++
+		}
+		if registered > 0 {
+			RegisterToolCallMiddleware(&toolCallEventMiddleware{})
+		}
+	})
+}
+
+// toolCallEventMiddleware publishes a run.tool_call event on the bus for
+// every MCP tool invocation during agent execution
+type toolCallEventMiddleware struct{}
+
+func (m *toolCallEventMiddleware) Name() string { return "event-bus" }
+
+func (m *toolCallEventMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	return nil, nil
+}
+
+func (m *toolCallEventMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	payload := map[string]interface{}{
+		"agent_id":   call.AgentID,
+		"agent_name": call.AgentName,
+		"run_id":     call.RunID,
+		"tool_name":  call.ToolName,
+		"success":    callErr == nil,
+	}
+	if callErr != nil {
+		payload["error"] = callErr.Error()
+	}
+	events.Publish(events.RunToolCall, payload)
+	return nil, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"station/internal/config"
+)
+
+// builtinExecutionProfiles are the profiles available without any config.
+// "strict" pins the run down for reproducible, comparable results;
+// "creative" loosens sampling for exploratory tasks. Config-declared
+// profiles with the same name override these.
+var builtinExecutionProfiles = map[string]config.ExecutionProfile{
+	"strict": {
+		Temperature: floatPtr(0),
+		TopP:        floatPtr(1),
+		Seed:        int64Ptr(42),
+	},
+	"creative": {
+		Temperature: floatPtr(1),
+		TopP:        floatPtr(0.95),
+	},
+}
+
+func floatPtr(v float64) *float64 { return &v }
+func int64Ptr(v int64) *int64     { return &v }
+
+// ResolveExecutionProfile looks up a named execution profile, preferring
+// config-declared profiles over the built-ins. Unknown names list the
+// available profiles so typos fail with something actionable.
+func ResolveExecutionProfile(cfg *config.Config, name string) (*config.ExecutionProfile, error) {
+	if cfg != nil {
+		if profile, ok := cfg.ExecutionProfiles[name]; ok {
+			if err := validateExecutionProfile(&profile); err != nil {
+				return nil, fmt.Errorf("execution profile '%s': %w", name, err)
+			}
+			return &profile, nil
+		}
+	}
+	if profile, ok := builtinExecutionProfiles[name]; ok {
+		return &profile, nil
+	}
+	return nil, fmt.Errorf("unknown execution profile '%s' (available: %s)", name, availableExecutionProfiles(cfg))
+}
+
+// validateExecutionProfile rejects settings the model plugins cannot honor
+func validateExecutionProfile(profile *config.ExecutionProfile) error {
+	switch profile.ToolChoice {
+	case "", "auto", "required", "none":
+	default:
+		return fmt.Errorf("invalid tool_choice '%s' (must be auto, required, or none)", profile.ToolChoice)
+	}
+	if profile.Temperature != nil && (*profile.Temperature < 0 || *profile.Temperature > 2) {
+		return fmt.Errorf("temperature %v out of range [0, 2]", *profile.Temperature)
+	}
+	if profile.TopP != nil && (*profile.TopP <= 0 || *profile.TopP > 1) {
+		return fmt.Errorf("top_p %v out of range (0, 1]", *profile.TopP)
+	}
+	if profile.MaxTurns < 0 {
+		return fmt.Errorf("max_turns must not be negative")
+	}
+	return nil
+}
+
+// availableExecutionProfiles returns a sorted list of resolvable profile names
+func availableExecutionProfiles(cfg *config.Config) string {
+	seen := make(map[string]bool)
+	for name := range builtinExecutionProfiles {
+		seen[name] = true
+	}
+	if cfg != nil {
+		for name := range cfg.ExecutionProfiles {
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := ""
+	for i, name := range names {
+		if i > 0 {
+			result += ", "
+		}
+		result += name
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"station/internal/config"
+)
+
+func TestResolveExecutionProfileBuiltins(t *testing.T) {
+	profile, err := ResolveExecutionProfile(nil, "strict")
+	if err != nil {
+		t.Fatalf("ResolveExecutionProfile failed: %v", err)
+	}
+	if profile.Temperature == nil || *profile.Temperature != 0 {
+		t.Errorf("Expected strict profile temperature 0, got %v", profile.Temperature)
+	}
+	if profile.Seed == nil {
+		t.Errorf("Expected strict profile to pin a seed")
+	}
+}
+
+func TestResolveExecutionProfileConfigOverridesBuiltin(t *testing.T) {
+	temp := 0.3
+	cfg := &config.Config{
+		ExecutionProfiles: map[string]config.ExecutionProfile{
+			"strict": {Temperature: &temp, MaxTurns: 10},
+		},
+	}
+
+	profile, err := ResolveExecutionProfile(cfg, "strict")
+	if err != nil {
+		t.Fatalf("ResolveExecutionProfile failed: %v", err)
+	}
+	if profile.Temperature == nil || *profile.Temperature != 0.3 {
+		t.Errorf("Expected config-declared profile to win, got temperature %v", profile.Temperature)
+	}
+	if profile.MaxTurns != 10 {
+		t.Errorf("Expected max_turns 10, got %d", profile.MaxTurns)
+	}
+}
+
+func TestResolveExecutionProfileUnknownListsAvailable(t *testing.T) {
+	cfg := &config.Config{
+		ExecutionProfiles: map[string]config.ExecutionProfile{
+			"batch": {},
+		},
+	}
+
+	_, err := ResolveExecutionProfile(cfg, "nope")
+	if err == nil {
+		t.Fatal("Expected error for unknown profile")
+	}
+	for _, name := range []string{"batch", "creative", "strict"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected error to list available profile '%s', got: %v", name, err)
+		}
+	}
+}
+
+func TestResolveExecutionProfileRejectsInvalidSettings(t *testing.T) {
+	badTemp := 5.0
+	tests := []struct {
+		name    string
+		profile config.ExecutionProfile
+	}{
+		{"bad tool choice", config.ExecutionProfile{ToolChoice: "always"}},
+		{"temperature out of range", config.ExecutionProfile{Temperature: &badTemp}},
+		{"negative max turns", config.ExecutionProfile{MaxTurns: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				ExecutionProfiles: map[string]config.ExecutionProfile{"bad": tt.profile},
+			}
+			if _, err := ResolveExecutionProfile(cfg, "bad"); err == nil {
+				t.Errorf("Expected validation error for %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestExecutionProfileGenerateConfig(t *testing.T) {
+	temp := 0.0
+	topP := 1.0
+	seed := int64(42)
+	profile := config.ExecutionProfile{
+		Temperature: &temp,
+		TopP:        &topP,
+		Seed:        &seed,
+		ToolChoice:  "required",
+		MaxTurns:    5,
+	}
+
+	genCfg := profile.GenerateConfig()
+	if genCfg["temperature"] != 0.0 {
+		t.Errorf("Expected temperature 0, got %v", genCfg["temperature"])
+	}
+	if genCfg["topP"] != 1.0 {
+		t.Errorf("Expected topP 1, got %v", genCfg["topP"])
+	}
+	if genCfg["seed"] != int64(42) {
+		t.Errorf("Expected seed 42, got %v", genCfg["seed"])
+	}
+	if genCfg["toolChoice"] != "required" {
+		t.Errorf("Expected toolChoice required, got %v", genCfg["toolChoice"])
+	}
+	if _, ok := genCfg["max_turns"]; ok {
+		t.Error("max_turns is not a generation parameter and must not be in the config map")
+	}
+
+	empty := config.ExecutionProfile{MaxTurns: 5}
+	if len(empty.GenerateConfig()) != 0 {
+		t.Errorf("Expected empty config map for profile without generation settings, got %v", empty.GenerateConfig())
+	}
+}
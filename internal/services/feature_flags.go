@@ -0,0 +1,107 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"station/internal/db/repositories"
+)
+
+// featureFlagsSettingKey stores the persistent flag defaults in the settings
+// table as a JSON object of flag name -> bool
+const featureFlagsSettingKey = "feature_flags"
+
+// Known experimental flags gating execution paths that are not ready to be
+// on by default. Unknown names are accepted too so flags can gate code that
+// ships in a later release.
+const (
+	FlagStreamingMiddleware = "streaming_middleware"
+	FlagContextCompression  = "context_compression"
+)
+
+// FeatureFlags is the resolved flag set for a single run: persistent
+// defaults from the settings table merged with per-run --flag overrides
+type FeatureFlags map[string]bool
+
+// Enabled reports whether a flag is on for this run. Unset flags are off.
+func (f FeatureFlags) Enabled(name string) bool {
+	return f[name]
+}
+
+// Names returns the enabled flag names sorted for stable logging
+func (f FeatureFlags) Names() []string {
+	names := []string{}
+	for name, enabled := range f {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FeatureFlagService resolves feature flags from settings-backed defaults
+// and per-run overrides, so experiments are toggled without code changes:
+//
+//	stn settings set feature_flags '{"streaming_middleware": true}'
+//	stn agent run my-agent "task" --flag context_compression=on
+type FeatureFlagService struct {
+	repos *repositories.Repositories
+}
+
+// NewFeatureFlagService creates a new feature flag service
+func NewFeatureFlagService(repos *repositories.Repositories) *FeatureFlagService {
+	return &FeatureFlagService{repos: repos}
+}
+
+// Resolve merges the persistent flag defaults with per-run overrides
+// (overrides win). A missing or malformed setting yields just the overrides.
+func (s *FeatureFlagService) Resolve(overrides map[string]bool) FeatureFlags {
+	flags := FeatureFlags{}
+
+	if setting, err := s.repos.Settings.GetByKey(featureFlagsSettingKey); err == nil && setting.Value != "" {
+		var persisted map[string]bool
+		if err := json.Unmarshal([]byte(setting.Value), &persisted); err == nil {
+			for name, enabled := range persisted {
+				flags[name] = enabled
+			}
+		}
+	}
+
+	for name, enabled := range overrides {
+		flags[name] = enabled
+	}
+	return flags
+}
+
+// ParseFeatureFlagOverrides parses repeated --flag values of the form
+// "name=on|off|true|false|1|0"; a bare "name" enables the flag
+func ParseFeatureFlagOverrides(values []string) (map[string]bool, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	overrides := map[string]bool{}
+	for _, value := range values {
+		name, state, hasState := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid flag %q: expected name=on|off", value)
+		}
+		if !hasState {
+			overrides[name] = true
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(state)) {
+		case "on", "true", "1":
+			overrides[name] = true
+		case "off", "false", "0":
+			overrides[name] = false
+		default:
+			return nil, fmt.Errorf("invalid flag value %q for %s: expected on|off|true|false|1|0", state, name)
+		}
+	}
+	return overrides, nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func setupFeatureFlagTest(t *testing.T) (*FeatureFlagService, *repositories.Repositories) {
+	t.Helper()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+	return NewFeatureFlagService(repos), repos
+}
+
+func TestResolveWithoutSettingOrOverrides(t *testing.T) {
+	svc, _ := setupFeatureFlagTest(t)
+
+	flags := svc.Resolve(nil)
+	if flags.Enabled(FlagStreamingMiddleware) {
+		t.Error("Expected all flags off by default")
+	}
+	if len(flags.Names()) != 0 {
+		t.Errorf("Expected no enabled flags, got %v", flags.Names())
+	}
+}
+
+func TestResolveFromSettings(t *testing.T) {
+	svc, repos := setupFeatureFlagTest(t)
+
+	if err := repos.Settings.Set("feature_flags", `{"streaming_middleware": true, "context_compression": false}`, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := svc.Resolve(nil)
+	if !flags.Enabled(FlagStreamingMiddleware) {
+		t.Error("Expected settings-backed flag to be enabled")
+	}
+	if flags.Enabled(FlagContextCompression) {
+		t.Error("Expected explicitly-off flag to be disabled")
+	}
+}
+
+func TestResolveOverridesWin(t *testing.T) {
+	svc, repos := setupFeatureFlagTest(t)
+
+	if err := repos.Settings.Set("feature_flags", `{"streaming_middleware": true}`, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := svc.Resolve(map[string]bool{"streaming_middleware": false, "context_compression": true})
+	if flags.Enabled(FlagStreamingMiddleware) {
+		t.Error("Expected per-run override to disable a settings-backed flag")
+	}
+	if !flags.Enabled(FlagContextCompression) {
+		t.Error("Expected per-run override to enable a flag")
+	}
+}
+
+func TestResolveIgnoresMalformedSetting(t *testing.T) {
+	svc, repos := setupFeatureFlagTest(t)
+
+	if err := repos.Settings.Set("feature_flags", "{not json", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := svc.Resolve(map[string]bool{"context_compression": true})
+	if !flags.Enabled(FlagContextCompression) || len(flags.Names()) != 1 {
+		t.Errorf("Expected only the override to apply, got %v", flags.Names())
+	}
+}
+
+func TestParseFeatureFlagOverrides(t *testing.T) {
+	overrides, err := ParseFeatureFlagOverrides([]string{
+		"streaming_middleware=on",
+		"context_compression=false",
+		"bare_flag",
+	})
+	if err != nil {
+		t.Fatalf("ParseFeatureFlagOverrides failed: %v", err)
+	}
+	if !overrides["streaming_middleware"] {
+		t.Error("Expected name=on to enable")
+	}
+	if overrides["context_compression"] {
+		t.Error("Expected name=false to disable")
+	}
+	if !overrides["bare_flag"] {
+		t.Error("Expected bare name to enable")
+	}
+}
+
+func TestParseFeatureFlagOverridesInvalid(t *testing.T) {
+	for _, value := range []string{"=on", "name=maybe"} {
+		if _, err := ParseFeatureFlagOverrides([]string{value}); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+	if overrides, err := ParseFeatureFlagOverrides(nil); err != nil || overrides != nil {
+		t.Errorf("Expected nil overrides for no values, got %v, %v", overrides, err)
+	}
+}
+
+func TestFeatureFlagsNamesSorted(t *testing.T) {
+	flags := FeatureFlags{"zeta": true, "alpha": true, "disabled": false}
+	names := flags.Names()
+	if len(names) != 2 || names[0] != "alpha" || names[1] != "zeta" {
+		t.Errorf("Expected sorted enabled names, got %v", names)
+	}
+}
@@ -11,8 +11,10 @@ import (
 	"station/internal/config"
 	"station/internal/genkit/anthropic_oauth"
 	"station/internal/genkit/cloudshipai"
+	"station/internal/genkit/stub"
 	"station/internal/logging"
 
+	"github.com/firebase/genkit/go/core/api"
 	"github.com/firebase/genkit/go/genkit"
 	"github.com/firebase/genkit/go/plugins/compat_oai/openai"
 	"github.com/firebase/genkit/go/plugins/googlegenai"
@@ -26,6 +28,7 @@ type GenKitProvider struct {
 	currentProvider string         // Track current AI provider to detect changes
 	currentAPIKey   string         // Track current API key to detect changes
 	currentBaseURL  string         // Track current base URL to detect changes
+	currentFallback string         // Track current fallback provider/model to detect changes
 	openaiPlugin    *openai.OpenAI // Official GenKit v1.0.1 OpenAI plugin
 	// Note: Station custom plugin code preserved in internal/genkit/ but not used in production
 }
@@ -88,7 +91,8 @@ func (gp *GenKitProvider) Initialize(ctx context.Context) error {
 	gp.mu.RLock()
 	configChanged := gp.currentProvider != strings.ToLower(cfg.AIProvider) ||
 		gp.currentAPIKey != cfg.AIAPIKey ||
-		gp.currentBaseURL != cfg.AIBaseURL
+		gp.currentBaseURL != cfg.AIBaseURL ||
+		gp.currentFallback != fallbackKey(cfg)
 	alreadyInitialized := gp.genkitApp != nil
 	gp.mu.RUnlock()
 
@@ -124,6 +128,7 @@ func (gp *GenKitProvider) Initialize(ctx context.Context) error {
 	gp.currentProvider = strings.ToLower(cfg.AIProvider)
 	gp.currentAPIKey = cfg.AIAPIKey
 	gp.currentBaseURL = cfg.AIBaseURL
+	gp.currentFallback = fallbackKey(cfg)
 
 	// Auto-detect provider based on model name if needed
 	detectedProvider := detectProviderFromModel(cfg.AIModel, cfg.AIProvider)
@@ -172,7 +177,7 @@ func (gp *GenKitProvider) Initialize(ctx context.Context) error {
 
 		logging.Debug("About to call genkit.Init for official OpenAI plugin with prompt directory support, GENKIT_ENV='%s'", os.Getenv("GENKIT_ENV"))
 		genkitApp = genkit.Init(ctx,
-			genkit.WithPlugins(openaiPlugin),
+			genkit.WithPlugins(withFallbackPlugin(cfg, openaiPlugin)...),
 			genkit.WithPromptDir(promptDir))
 		err = nil // GenKit v1.0.1 Init doesn't return error
 
@@ -188,7 +193,7 @@ func (gp *GenKitProvider) Initialize(ctx context.Context) error {
 		geminiPlugin := &googlegenai.GoogleAI{}
 
 		genkitApp = genkit.Init(ctx,
-			genkit.WithPlugins(geminiPlugin),
+			genkit.WithPlugins(withFallbackPlugin(cfg, geminiPlugin)...),
 			genkit.WithPromptDir(promptDir))
 		err = nil // GenKit v1.0.1 Init doesn't return error
 
@@ -219,7 +224,25 @@ func (gp *GenKitProvider) Initialize(ctx context.Context) error {
 
 		logging.Debug("Initializing GenKit with native Anthropic plugin...")
 		genkitApp = genkit.Init(ctx,
-			genkit.WithPlugins(anthropicPlugin),
+			genkit.WithPlugins(withFallbackPlugin(cfg, anthropicPlugin)...),
+			genkit.WithPromptDir(promptDir))
+		err = nil
+
+	case "stub":
+		// Deterministic offline provider - canned/template responses, zero API cost
+		logging.Debug("Setting up stub plugin with model: %s", cfg.AIModel)
+
+		promptDir := "/tmp/station-prompts"
+		_ = os.MkdirAll(promptDir, 0755)
+
+		stubPlugin := &stub.Stub{
+			Models:     []string{cfg.AIModel},
+			ScriptPath: os.Getenv("STN_STUB_RESPONSES"),
+		}
+
+		logging.Info("Using stub AI provider (deterministic responses, no API calls)")
+		genkitApp = genkit.Init(ctx,
+			genkit.WithPlugins(stubPlugin),
 			genkit.WithPromptDir(promptDir))
 		err = nil
 
@@ -250,7 +273,7 @@ func (gp *GenKitProvider) Initialize(ctx context.Context) error {
 
 		logging.Info("Using CloudShip AI inference endpoint at inference.cloudshipai.com (full tool support)")
 		genkitApp = genkit.Init(ctx,
-			genkit.WithPlugins(cloudshipPlugin),
+			genkit.WithPlugins(withFallbackPlugin(cfg, cloudshipPlugin)...),
 			genkit.WithPromptDir(promptDir))
 		err = nil
 
@@ -266,6 +289,7 @@ func (gp *GenKitProvider) Initialize(ctx context.Context) error {
 			"  • Anthropic models: Use any claude-* model name with ANTHROPIC_API_KEY\n"+
 			"  • Gemini models: Use any gemini-* model name with GEMINI_API_KEY or GOOGLE_API_KEY\n"+
 			"  • CloudShip AI: Use cloudship/* models with STN_CLOUDSHIP_KEY\n"+
+			"  • Stub provider: Set ai_provider to 'stub' for deterministic offline responses (no API key)\n"+
 			"  • OpenAI-compatible APIs: Use any model name with ai_base_url configured\n"+
 			"    Examples: Ollama, Together AI, etc.\n\n"+
 			"Set ai_base_url in config.yml or use --base-url with 'stn init' for custom endpoints.",
@@ -297,7 +321,63 @@ func (gp *GenKitProvider) GetPluginInfo() map[string]interface{} {
 	}
 }
 
+// fallbackKey summarizes the fallback provider/model config for change detection
+func fallbackKey(cfg *config.Config) string {
+	if cfg.FallbackAIProvider == "" {
+		return ""
+	}
+	return strings.ToLower(cfg.FallbackAIProvider) + "/" + cfg.FallbackAIModel
+}
+
+// withFallbackPlugin appends the fallback provider's plugin (if configured) so
+// its models are registered alongside the primary provider's. This lets
+// execution retry against the fallback model mid-run when the primary provider
+// keeps failing with rate limits, 5xx errors, or timeouts.
+func withFallbackPlugin(cfg *config.Config, primary api.Plugin) []api.Plugin {
+	plugins := []api.Plugin{primary}
+
+	fbProvider := strings.ToLower(cfg.FallbackAIProvider)
+	if fbProvider == "" || fbProvider == strings.ToLower(cfg.AIProvider) {
+		return plugins
+	}
+
+	switch fbProvider {
+	case "openai":
+		if key := os.Getenv("OPENAI_API_KEY"); key != "" {
+			plugins = append(plugins, &openai.OpenAI{APIKey: key})
+		} else {
+			logging.Info("Fallback provider 'openai' configured but OPENAI_API_KEY not set - fallback disabled")
+		}
+	case "googlegenai", "gemini":
+		if os.Getenv("GEMINI_API_KEY") != "" || os.Getenv("GOOGLE_API_KEY") != "" {
+			plugins = append(plugins, &googlegenai.GoogleAI{})
+		} else {
+			logging.Info("Fallback provider 'gemini' configured but GEMINI_API_KEY/GOOGLE_API_KEY not set - fallback disabled")
+		}
+	case "anthropic":
+		if key := os.Getenv("ANTHROPIC_API_KEY"); key != "" {
+			plugins = append(plugins, &anthropic_oauth.AnthropicOAuth{APIKey: key})
+		} else {
+			logging.Info("Fallback provider 'anthropic' configured but ANTHROPIC_API_KEY not set - fallback disabled")
+		}
+	default:
+		logging.Info("Unsupported fallback AI provider '%s' - fallback disabled", cfg.FallbackAIProvider)
+	}
+
+	if len(plugins) > 1 {
+		logging.Info("Registered fallback AI provider '%s' (model: %s) alongside primary '%s'",
+			cfg.FallbackAIProvider, cfg.FallbackAIModel, cfg.AIProvider)
+	}
+
+	return plugins
+}
+
 func detectProviderFromModel(modelName, configuredProvider string) string {
+	// The stub provider serves any model name offline - never auto-route away from it
+	if strings.ToLower(configuredProvider) == "stub" {
+		return configuredProvider
+	}
+
 	modelLower := strings.ToLower(modelName)
 
 	if strings.HasPrefix(modelLower, "gemini") {
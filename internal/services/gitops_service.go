@@ -0,0 +1,394 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+const (
+	// GitOps state lives in the settings table so it survives restarts
+	// without a schema change
+	gitOpsConfigSettingKey  = "gitops.config"
+	gitOpsHistorySettingKey = "gitops.history"
+
+	gitOpsDefaultIntervalMinutes = 5
+	gitOpsHistoryLimit           = 20
+	gitOpsCommandTimeout         = 2 * time.Minute
+)
+
+// GitOpsConfig declares the git repository that is the source of truth for
+// environment configuration. Set via `stn gitops enable`.
+type GitOpsConfig struct {
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+	// Path is the directory inside the repo containing one subdirectory per
+	// environment (e.g. "envs/")
+	Path string `json:"path"`
+	// IntervalMinutes between automatic pulls in serve mode (default 5)
+	IntervalMinutes int `json:"interval_minutes,omitempty"`
+}
+
+// GitOpsSyncRecord captures one completed GitOps sync so operators can see
+// which commit produced the current configuration and roll back to a
+// previous SHA
+type GitOpsSyncRecord struct {
+	SHA          string    `json:"sha"`
+	SyncedAt     time.Time `json:"synced_at"`
+	Environments []string  `json:"environments"`
+	Rollback     bool      `json:"rollback,omitempty"`
+}
+
+// GitOpsService pulls environment configuration from a git repository,
+// validates and syncs it through the regular DeclarativeSync pipeline, and
+// records the commit SHA of every applied sync
+type GitOpsService struct {
+	repos *repositories.Repositories
+	cfg   *config.Config
+
+	mu       sync.Mutex
+	stopChan chan struct{}
+}
+
+// NewGitOpsService creates a GitOps service backed by the settings table
+func NewGitOpsService(repos *repositories.Repositories, cfg *config.Config) *GitOpsService {
+	return &GitOpsService{
+		repos:    repos,
+		cfg:      cfg,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Enable stores the GitOps configuration. Subsequent serve starts (and
+// `stn gitops sync`) pull from the configured repository.
+func (s *GitOpsService) Enable(cfg GitOpsConfig) error {
+	if cfg.Repo == "" {
+		return fmt.Errorf("repository URL is required")
+	}
+	if cfg.Branch == "" {
+		cfg.Branch = "main"
+	}
+	if cfg.IntervalMinutes <= 0 {
+		cfg.IntervalMinutes = gitOpsDefaultIntervalMinutes
+	}
+
+	value, err := json.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to encode GitOps config: %w", err)
+	}
+	return s.repos.Settings.Set(gitOpsConfigSettingKey, string(value), "GitOps environment sync configuration")
+}
+
+// Disable removes the GitOps configuration; sync history is kept
+func (s *GitOpsService) Disable() error {
+	return s.repos.Settings.Delete(gitOpsConfigSettingKey)
+}
+
+// GetConfig loads the GitOps configuration; returns nil when GitOps is not
+// enabled
+func (s *GitOpsService) GetConfig() (*GitOpsConfig, error) {
+	setting, err := s.repos.Settings.GetByKey(gitOpsConfigSettingKey)
+	if err != nil {
+		return nil, nil
+	}
+
+	var cfg GitOpsConfig
+	if err := json.Unmarshal([]byte(setting.Value), &cfg); err != nil {
+		return nil, fmt.Errorf("invalid GitOps config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// History returns recorded syncs, newest first
+func (s *GitOpsService) History() ([]GitOpsSyncRecord, error) {
+	setting, err := s.repos.Settings.GetByKey(gitOpsHistorySettingKey)
+	if err != nil {
+		return nil, nil
+	}
+
+	var history []GitOpsSyncRecord
+	if err := json.Unmarshal([]byte(setting.Value), &history); err != nil {
+		return nil, fmt.Errorf("invalid GitOps history: %w", err)
+	}
+	return history, nil
+}
+
+// Start begins periodic pull-and-sync in the background when GitOps is
+// enabled. No-op otherwise.
+func (s *GitOpsService) Start() {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		logging.Info("GitOps disabled: %v", err)
+		return
+	}
+	if cfg == nil {
+		return
+	}
+
+	interval := time.Duration(cfg.IntervalMinutes) * time.Minute
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.SyncOnce(context.Background()); err != nil {
+					logging.Info("GitOps sync failed: %v", err)
+				}
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+	logging.Info("GitOps sync started for %s@%s (every %s)", cfg.Repo, cfg.Branch, interval)
+}
+
+// Stop halts periodic syncing
+func (s *GitOpsService) Stop() {
+	close(s.stopChan)
+}
+
+// SyncOnce pulls the configured repository, copies each environment
+// directory into the station config tree, runs validation + sync through
+// DeclarativeSync, and records the commit SHA. Returns the applied record.
+func (s *GitOpsService) SyncOnce(ctx context.Context) (*GitOpsSyncRecord, error) {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("GitOps is not enabled (run 'stn gitops enable' first)")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkout, err := s.ensureCheckout(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.gitCommand(ctx, checkout, "fetch", "origin", cfg.Branch); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", cfg.Branch, err)
+	}
+	if err := s.gitCommand(ctx, checkout, "checkout", "--force", "origin/"+cfg.Branch); err != nil {
+		return nil, fmt.Errorf("failed to check out origin/%s: %w", cfg.Branch, err)
+	}
+
+	sha, err := s.headSHA(ctx, checkout)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyCheckout(ctx, cfg, checkout, sha, false)
+}
+
+// Rollback checks out a previously synced SHA and re-applies it, recording
+// the rollback in the sync history
+func (s *GitOpsService) Rollback(ctx context.Context, sha string) (*GitOpsSyncRecord, error) {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("GitOps is not enabled (run 'stn gitops enable' first)")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkout, err := s.ensureCheckout(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.gitCommand(ctx, checkout, "checkout", "--force", sha); err != nil {
+		return nil, fmt.Errorf("failed to check out %s: %w", sha, err)
+	}
+
+	resolved, err := s.headSHA(ctx, checkout)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyCheckout(ctx, cfg, checkout, resolved, true)
+}
+
+// applyCheckout copies environment directories from the checkout into the
+// station config tree, validates and syncs each one, and records the result
+func (s *GitOpsService) applyCheckout(ctx context.Context, cfg *GitOpsConfig, checkout, sha string, rollback bool) (*GitOpsSyncRecord, error) {
+	sourceDir := filepath.Join(checkout, cfg.Path)
+	entries, err := os.ReadDir(sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read environments path %s in repo: %w", cfg.Path, err)
+	}
+
+	syncer := NewDeclarativeSync(s.repos, s.cfg)
+	var environments []string
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		envName := entry.Name()
+
+		if err := s.ensureEnvironmentExists(envName); err != nil {
+			return nil, err
+		}
+		if err := copyDirOverwrite(filepath.Join(sourceDir, envName), config.GetEnvironmentDir(envName)); err != nil {
+			return nil, fmt.Errorf("failed to copy environment %s: %w", envName, err)
+		}
+
+		// Validate first (no database writes), then apply
+		if _, err := syncer.SyncEnvironment(ctx, envName, SyncOptions{Validate: true, Interactive: false}); err != nil {
+			return nil, fmt.Errorf("validation failed for environment %s at %s: %w", envName, shortSHA(sha), err)
+		}
+		if _, err := syncer.SyncEnvironment(ctx, envName, SyncOptions{Interactive: false}); err != nil {
+			return nil, fmt.Errorf("sync failed for environment %s at %s: %w", envName, shortSHA(sha), err)
+		}
+		environments = append(environments, envName)
+	}
+
+	record := GitOpsSyncRecord{
+		SHA:          sha,
+		SyncedAt:     time.Now(),
+		Environments: environments,
+		Rollback:     rollback,
+	}
+	if err := s.recordSync(record); err != nil {
+		return nil, err
+	}
+
+	logging.Info("GitOps applied %s (%d environments)", shortSHA(sha), len(environments))
+	return &record, nil
+}
+
+// ensureCheckout clones the repository on first use; the checkout lives
+// under <config>/gitops/checkout
+func (s *GitOpsService) ensureCheckout(ctx context.Context, cfg *GitOpsConfig) (string, error) {
+	checkout := filepath.Join(config.GetConfigRoot(), "gitops", "checkout")
+	if _, err := os.Stat(filepath.Join(checkout, ".git")); err == nil {
+		return checkout, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(checkout), 0755); err != nil {
+		return "", fmt.Errorf("failed to create gitops directory: %w", err)
+	}
+	if err := s.gitCommand(ctx, "", "clone", "--branch", cfg.Branch, cfg.Repo, checkout); err != nil {
+		return "", fmt.Errorf("failed to clone %s: %w", cfg.Repo, err)
+	}
+	return checkout, nil
+}
+
+// ensureEnvironmentExists creates the database record for environments that
+// only exist in the git repository
+func (s *GitOpsService) ensureEnvironmentExists(envName string) error {
+	if _, err := s.repos.Environments.GetByName(envName); err == nil {
+		return nil
+	}
+	description := "Managed by GitOps"
+	if _, err := s.repos.Environments.Create(envName, &description, 1); err != nil {
+		return fmt.Errorf("failed to create environment %s: %w", envName, err)
+	}
+	return nil
+}
+
+// recordSync prepends the record to the capped sync history
+func (s *GitOpsService) recordSync(record GitOpsSyncRecord) error {
+	history, err := s.History()
+	if err != nil {
+		return err
+	}
+	history = append([]GitOpsSyncRecord{record}, history...)
+	if len(history) > gitOpsHistoryLimit {
+		history = history[:gitOpsHistoryLimit]
+	}
+
+	value, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to encode GitOps history: %w", err)
+	}
+	return s.repos.Settings.Set(gitOpsHistorySettingKey, string(value), "GitOps sync history (newest first)")
+}
+
+// gitCommand runs git with a timeout; dir == "" runs from the working directory
+func (s *GitOpsService) gitCommand(ctx context.Context, dir string, args ...string) error {
+	cmdCtx, cancel := context.WithTimeout(ctx, gitOpsCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(cmdCtx, "git", args...)
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s: %w (%s)", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// headSHA returns the full SHA of the checkout's HEAD
+func (s *GitOpsService) headSHA(ctx context.Context, checkout string) (string, error) {
+	cmdCtx, cancel := context.WithTimeout(ctx, gitOpsCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(cmdCtx, "git", "rev-parse", "HEAD")
+	cmd.Dir = checkout
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// shortSHA abbreviates a commit SHA for log and CLI output
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// copyDirOverwrite recursively copies src into dst, overwriting existing
+// files. Files present only in dst are left alone so locally-entered
+// variables.yml values survive when the repo omits them.
+func copyDirOverwrite(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		_, err = io.Copy(out, in)
+		return err
+	})
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestShortSHA(t *testing.T) {
+	if got := shortSHA("0123456789abcdef"); got != "01234567" {
+		t.Errorf("Expected 01234567, got %s", got)
+	}
+	if got := shortSHA("abc"); got != "abc" {
+		t.Errorf("Expected short input unchanged, got %s", got)
+	}
+}
+
+func TestCopyDirOverwrite(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	writeDriftFile(t, filepath.Join(src, "template.json"), `{"from": "repo"}`)
+	writeDriftFile(t, filepath.Join(src, "agents", "scanner.prompt"), "---\nmodel: gpt-4o-mini\n---\nprompt")
+	// Existing file must be overwritten, local-only file preserved
+	writeDriftFile(t, filepath.Join(dst, "template.json"), `{"from": "local"}`)
+	writeDriftFile(t, filepath.Join(dst, "variables.yml"), "SECRET: local-only\n")
+
+	if err := copyDirOverwrite(src, dst); err != nil {
+		t.Fatalf("copyDirOverwrite failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dst, "template.json"))
+	if err != nil || string(content) != `{"from": "repo"}` {
+		t.Errorf("Expected template.json overwritten from repo, got %q (err %v)", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "agents", "scanner.prompt")); err != nil {
+		t.Errorf("Expected nested agent prompt copied: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "variables.yml")); err != nil {
+		t.Errorf("Expected local-only variables.yml preserved: %v", err)
+	}
+}
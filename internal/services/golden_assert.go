@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"station/internal/config"
+	"station/pkg/benchmark"
+)
+
+// Golden assertions turn agent runs into CI steps: `stn agent run --assert-golden`
+// compares the final response against a checked-in expectation and exits
+// non-zero on mismatch.
+const (
+	GoldenAssertModeExact    = "exact"
+	GoldenAssertModeJudge    = "judge"
+	GoldenAssertModeJSONPath = "jsonpath"
+)
+
+// Minimum LLM-judge similarity score for judge mode to pass
+const goldenJudgeThreshold = 0.8
+
+// ValidateGoldenAssertMode rejects unknown --assert-mode values before the
+// run executes, so a typo fails fast instead of after a paid run
+func ValidateGoldenAssertMode(mode string) error {
+	switch mode {
+	case GoldenAssertModeExact, GoldenAssertModeJudge, GoldenAssertModeJSONPath:
+		return nil
+	default:
+		return fmt.Errorf("invalid assert mode '%s' (expected %s, %s, or %s)",
+			mode, GoldenAssertModeExact, GoldenAssertModeJudge, GoldenAssertModeJSONPath)
+	}
+}
+
+// AssertGolden compares a run's final response against the golden file using
+// the given mode. A nil error means the assertion passed.
+func AssertGolden(ctx context.Context, cfg *config.Config, goldenPath, mode, output string) error {
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		return fmt.Errorf("failed to read golden file %s: %w", goldenPath, err)
+	}
+
+	switch mode {
+	case GoldenAssertModeExact:
+		return assertGoldenExact(string(golden), output)
+	case GoldenAssertModeJSONPath:
+		return assertGoldenJSONPath(string(golden), output)
+	case GoldenAssertModeJudge:
+		return assertGoldenJudge(ctx, cfg, string(golden), output)
+	default:
+		return ValidateGoldenAssertMode(mode)
+	}
+}
+
+// assertGoldenExact requires the output to match the golden content. When
+// both sides parse as JSON they are compared structurally, so key order and
+// formatting differences do not fail CI.
+func assertGoldenExact(golden, output string) error {
+	golden = strings.TrimSpace(golden)
+	output = strings.TrimSpace(output)
+
+	var goldenJSON, outputJSON interface{}
+	if json.Unmarshal([]byte(golden), &goldenJSON) == nil && json.Unmarshal([]byte(output), &outputJSON) == nil {
+		if reflect.DeepEqual(goldenJSON, outputJSON) {
+			return nil
+		}
+		return fmt.Errorf("golden assertion failed (exact): output JSON differs from golden file\nExpected: %s\nActual:   %s",
+			truncateForAssert(golden), truncateForAssert(output))
+	}
+
+	if golden == output {
+		return nil
+	}
+	return fmt.Errorf("golden assertion failed (exact): output differs from golden file\nExpected: %s\nActual:   %s",
+		truncateForAssert(golden), truncateForAssert(output))
+}
+
+// assertGoldenJSONPath treats the golden file as a JSON object mapping gjson
+// paths (e.g. "summary.total", "findings.0.severity") to expected values and
+// checks each against the output
+func assertGoldenJSONPath(golden, output string) error {
+	var assertions map[string]interface{}
+	if err := json.Unmarshal([]byte(golden), &assertions); err != nil {
+		return fmt.Errorf("golden file must be a JSON object of path -> expected value for jsonpath mode: %w", err)
+	}
+	if !gjson.Valid(output) {
+		return fmt.Errorf("golden assertion failed (jsonpath): agent output is not valid JSON\nActual: %s", truncateForAssert(output))
+	}
+
+	var failures []string
+	for path, expected := range assertions {
+		actual := gjson.Get(output, path)
+		if !actual.Exists() {
+			failures = append(failures, fmt.Sprintf("path %q not found in output", path))
+			continue
+		}
+		if !jsonValuesEqual(expected, actual.Value()) {
+			failures = append(failures, fmt.Sprintf("path %q: expected %v, got %v", path, expected, actual.Value()))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("golden assertion failed (jsonpath): %d of %d assertion(s) failed\n  %s",
+			len(failures), len(assertions), strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+// assertGoldenJudge asks the configured LLM judge whether the output is
+// semantically equivalent to the golden expectation, passing at or above the
+// similarity threshold
+func assertGoldenJudge(ctx context.Context, cfg *config.Config, golden, output string) error {
+	judge, err := benchmark.NewJudge(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create judge for golden assertion: %w", err)
+	}
+
+	prompt := fmt.Sprintf(`You are grading an AI agent's output against a golden expected output for a CI assertion.
+Score how semantically equivalent the actual output is to the expected output on a 0.0-1.0 scale:
+1.0 means same facts and conclusions (wording may differ), 0.0 means contradictory or unrelated.
+
+Expected output:
+%s
+
+Actual output:
+%s
+
+Respond with JSON only: {"score": <0.0-1.0>, "reasoning": "<one sentence>"}`, golden, output)
+
+	var verdict struct {
+		Score     float64 `json:"score"`
+		Reasoning string  `json:"reasoning"`
+	}
+	if _, _, err := judge.Evaluate(ctx, prompt, &verdict); err != nil {
+		return fmt.Errorf("golden judge evaluation failed: %w", err)
+	}
+
+	if verdict.Score < goldenJudgeThreshold {
+		return fmt.Errorf("golden assertion failed (judge): similarity %.2f below threshold %.2f: %s",
+			verdict.Score, goldenJudgeThreshold, verdict.Reasoning)
+	}
+	fmt.Printf("⚖️  Judge similarity %.2f (threshold %.2f): %s\n", verdict.Score, goldenJudgeThreshold, verdict.Reasoning)
+	return nil
+}
+
+// jsonValuesEqual compares an expected golden value with a gjson result value
+// through JSON round-tripping so numeric types compare consistently
+func jsonValuesEqual(expected, actual interface{}) bool {
+	expectedJSON, err1 := json.Marshal(expected)
+	actualJSON, err2 := json.Marshal(actual)
+	if err1 != nil || err2 != nil {
+		return reflect.DeepEqual(expected, actual)
+	}
+	return string(expectedJSON) == string(actualJSON)
+}
+
+// truncateForAssert keeps assertion failure messages readable for long outputs
+func truncateForAssert(s string) string {
+	const maxLen = 400
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen] + "... (truncated)"
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateGoldenAssertMode(t *testing.T) {
+	for _, mode := range []string{GoldenAssertModeExact, GoldenAssertModeJudge, GoldenAssertModeJSONPath} {
+		if err := ValidateGoldenAssertMode(mode); err != nil {
+			t.Errorf("expected mode %s to be valid: %v", mode, err)
+		}
+	}
+	if err := ValidateGoldenAssertMode("fuzzy"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestAssertGoldenExact(t *testing.T) {
+	// Plain text: whitespace-insensitive equality
+	if err := assertGoldenExact("hello world\n", "hello world"); err != nil {
+		t.Errorf("expected trimmed text match: %v", err)
+	}
+	if err := assertGoldenExact("hello", "goodbye"); err == nil {
+		t.Error("expected mismatch error for differing text")
+	}
+
+	// JSON: structural comparison ignores key order and formatting
+	golden := `{"status": "ok", "count": 2}`
+	output := "{\n  \"count\": 2,\n  \"status\": \"ok\"\n}"
+	if err := assertGoldenExact(golden, output); err != nil {
+		t.Errorf("expected structural JSON match: %v", err)
+	}
+	if err := assertGoldenExact(golden, `{"status": "ok", "count": 3}`); err == nil {
+		t.Error("expected mismatch error for differing JSON values")
+	}
+}
+
+func TestAssertGoldenJSONPath(t *testing.T) {
+	output := `{"summary": {"total": 3, "status": "clean"}, "findings": [{"severity": "high"}]}`
+
+	golden := `{"summary.total": 3, "summary.status": "clean", "findings.0.severity": "high"}`
+	if err := assertGoldenJSONPath(golden, output); err != nil {
+		t.Errorf("expected jsonpath assertions to pass: %v", err)
+	}
+
+	err := assertGoldenJSONPath(`{"summary.total": 5, "summary.missing": "x"}`, output)
+	if err == nil {
+		t.Fatal("expected jsonpath failures")
+	}
+	if !strings.Contains(err.Error(), "summary.total") || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected value mismatch and missing path in error, got: %v", err)
+	}
+
+	if err := assertGoldenJSONPath(golden, "not json"); err == nil {
+		t.Error("expected error for non-JSON output")
+	}
+	if err := assertGoldenJSONPath("[1,2]", output); err == nil {
+		t.Error("expected error for golden file that is not a JSON object")
+	}
+}
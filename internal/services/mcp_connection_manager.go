@@ -61,6 +61,7 @@ type MCPConnectionManager struct {
 	serverPool       *MCPServerPool
 	poolingEnabled   bool                  // Feature flag for connection pooling
 	agentService     AgentServiceInterface // For agent tool integration
+	keepAliveStop    chan struct{}         // Signals the keep-alive loop to exit
 }
 
 // getMapKeys returns the keys of a map for debugging
@@ -82,7 +83,7 @@ func NewMCPConnectionManager(repos *repositories.Repositories, genkitApp *genkit
 		genkitApp:      genkitApp,
 		toolCache:      make(map[int64]*EnvironmentToolCache),
 		agentToolCache: make(map[string]*AgentToolCache),
-		serverPool:     NewMCPServerPool(),
+		serverPool:     SharedMCPServerPool(),
 		poolingEnabled: poolingEnabled,
 	}
 }
@@ -261,12 +262,20 @@ func (mcm *MCPConnectionManager) createServerClient(ctx context.Context, serverN
 		}
 
 		logging.Info("[MCP-DEBUG] Creating Stdio-based MCP client for '%s' with command: %s %v", serverName, serverConfig.Command, serverConfig.Args)
+		command, args := serverConfig.Command, serverConfig.Args
+		if serverConfig.Runner != "" {
+			command, args, err = wrapStdioCommandForRunner(mcm.repos, serverConfig.Runner, command, args, serverConfig.Env)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to prepare remote runner for %s: %w", serverName, err)
+			}
+		}
+		command, args = wrapStdioCommandForStderrCapture(serverName, command, args)
 		mcpClient, err = mcp.NewGenkitMCPClient(mcp.MCPClientOptions{
 			Name:    "_",
 			Version: "1.0.0",
 			Stdio: &mcp.StdioConfig{
-				Command: serverConfig.Command,
-				Args:    serverConfig.Args,
+				Command: command,
+				Args:    args,
 				Env:     envSlice,
 			},
 		})
@@ -348,6 +357,72 @@ func (mcm *MCPConnectionManager) GetEnvironmentMCPTools(ctx context.Context, env
 	return allTools, allClients, nil
 }
 
+// GetEnvironmentMCPToolsWithEnvOverrides loads tools for a run that injects
+// per-run environment variables into stdio MCP servers (stn agent run
+// --env-var). Pooled servers are shared processes started with the template's
+// env, so overridden runs always get fresh connections; the clients are torn
+// down with the run like any legacy connection.
+func (mcm *MCPConnectionManager) GetEnvironmentMCPToolsWithEnvOverrides(ctx context.Context, environmentID int64, overrides map[string]string) ([]ai.Tool, []*mcp.GenkitMCPClient, error) {
+	if len(overrides) == 0 {
+		return mcm.GetEnvironmentMCPTools(ctx, environmentID)
+	}
+
+	fileConfigs, err := mcm.repos.FileMCPConfigs.ListByEnvironment(environmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get file configs for environment %d: %w", environmentID, err)
+	}
+
+	logging.Info("Per-run env overrides active (%d vars): creating fresh MCP connections for environment %d", len(overrides), environmentID)
+
+	var allTools []ai.Tool
+	var allClients []*mcp.GenkitMCPClient
+	for _, fileConfig := range fileConfigs {
+		for serverName, serverConfig := range mcm.parseFileConfig(fileConfig) {
+			tools, client := mcm.connectToMCPServer(ctx, serverName, applyEnvOverrides(serverConfig, overrides))
+			if tools != nil {
+				allTools = append(allTools, tools...)
+			}
+			if client != nil {
+				allClients = append(allClients, client)
+			}
+		}
+	}
+
+	agentTools := mcm.getAgentToolsForEnvironment(ctx, environmentID, mcm.agentService)
+	allTools = append(allTools, agentTools...)
+
+	return allTools, allClients, nil
+}
+
+// applyEnvOverrides merges per-run env vars into a stdio server config,
+// leaving HTTP servers and non-map configs untouched. The original config is
+// never mutated so cached/pooled views stay consistent.
+func applyEnvOverrides(serverConfigRaw interface{}, overrides map[string]string) interface{} {
+	serverConfig, ok := serverConfigRaw.(map[string]interface{})
+	if !ok {
+		return serverConfigRaw
+	}
+	if command, _ := serverConfig["command"].(string); command == "" {
+		return serverConfigRaw
+	}
+
+	merged := make(map[string]interface{}, len(serverConfig))
+	for key, value := range serverConfig {
+		merged[key] = value
+	}
+	env := make(map[string]interface{})
+	if existing, ok := serverConfig["env"].(map[string]interface{}); ok {
+		for key, value := range existing {
+			env[key] = value
+		}
+	}
+	for key, value := range overrides {
+		env[key] = value
+	}
+	merged["env"] = env
+	return merged
+}
+
 // getAgentToolsForEnvironment creates AgentToolWrapper instances for all agents in an environment
 // with caching for improved performance
 func (mcm *MCPConnectionManager) getAgentToolsForEnvironment(ctx context.Context, environmentID int64, agentService AgentServiceInterface) []ai.Tool {
@@ -1072,12 +1147,21 @@ func (mcm *MCPConnectionManager) connectToMCPServer(ctx context.Context, serverN
 				envSlice = append(envSlice, key+"="+value)
 			}
 
+			command, args := serverConfig.Command, serverConfig.Args
+			if serverConfig.Runner != "" {
+				command, args, err = wrapStdioCommandForRunner(mcm.repos, serverConfig.Runner, command, args, serverConfig.Env)
+				if err != nil {
+					clientChan <- clientResult{client: nil, err: fmt.Errorf("failed to prepare remote runner: %w", err)}
+					return
+				}
+			}
+			command, args = wrapStdioCommandForStderrCapture(serverName, command, args)
 			client, err = mcp.NewGenkitMCPClient(mcp.MCPClientOptions{
 				Name:    "_",
 				Version: "1.0.0",
 				Stdio: &mcp.StdioConfig{
-					Command: serverConfig.Command,
-					Args:    serverConfig.Args,
+					Command: command,
+					Args:    args,
 					Env:     envSlice,
 				},
 			})
@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"station/internal/logging"
 
@@ -20,18 +23,70 @@ type MCPServerPool struct {
 	servers         map[string]*mcp.GenkitMCPClient // serverKey -> persistent client
 	serverConfigs   map[string]interface{}          // serverKey -> config for restart
 	tools           map[string][]ai.Tool            // serverKey -> cached tools
+	stats           map[string]*MCPPoolServerStats  // serverKey -> lifecycle stats
 	mutex           sync.RWMutex
 	initialized     bool            // prevent multiple initializations (deprecated - use initializedEnvs)
 	initializedEnvs map[string]bool // track which environments have been initialized
 }
 
+// MCPPoolServerStats tracks the lifecycle of one pooled server for the
+// pool stats API
+type MCPPoolServerStats struct {
+	Key             string    `json:"key"`
+	Name            string    `json:"name"`
+	EnvironmentID   int64     `json:"environment_id"`
+	ToolCount       int       `json:"tool_count"`
+	Healthy         bool      `json:"healthy"`
+	StartedAt       time.Time `json:"started_at"`
+	LastHealthCheck time.Time `json:"last_health_check,omitempty"`
+	Restarts        int       `json:"restarts"`
+	Reuses          int64     `json:"reuses"`
+}
+
 // NewMCPServerPool creates a new server pool
 func NewMCPServerPool() *MCPServerPool {
 	return &MCPServerPool{
 		servers:       make(map[string]*mcp.GenkitMCPClient),
 		serverConfigs: make(map[string]interface{}),
 		tools:         make(map[string][]ai.Tool),
+		stats:         make(map[string]*MCPPoolServerStats),
+	}
+}
+
+var (
+	sharedServerPool     *MCPServerPool
+	sharedServerPoolOnce sync.Once
+)
+
+// SharedMCPServerPool returns the process-wide server pool. Connection
+// managers are created per execution, so the pool itself must be shared
+// for warm stdio servers to actually be reused across runs.
+func SharedMCPServerPool() *MCPServerPool {
+	sharedServerPoolOnce.Do(func() {
+		sharedServerPool = NewMCPServerPool()
+	})
+	return sharedServerPool
+}
+
+// Stats returns a snapshot of every pooled server's lifecycle stats,
+// sorted by server name for stable API output
+func (pool *MCPServerPool) Stats() []MCPPoolServerStats {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	snapshot := make([]MCPPoolServerStats, 0, len(pool.stats))
+	for _, stats := range pool.stats {
+		entry := *stats
+		entry.Reuses = atomic.LoadInt64(&stats.Reuses)
+		snapshot = append(snapshot, entry)
 	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Name != snapshot[j].Name {
+			return snapshot[i].Name < snapshot[j].Name
+		}
+		return snapshot[i].Key < snapshot[j].Key
+	})
+	return snapshot
 }
 
 // serverDefinition represents a unique server configuration
@@ -157,6 +212,18 @@ func (mcm *MCPConnectionManager) startPooledServer(ctx context.Context, server s
 	mcm.serverPool.servers[server.key] = client
 	mcm.serverPool.serverConfigs[server.key] = server.config
 	mcm.serverPool.tools[server.key] = tools
+	mcm.serverPool.stats[server.key] = &MCPPoolServerStats{
+		Key:           server.key,
+		Name:          server.name,
+		EnvironmentID: server.environmentID,
+		ToolCount:     len(tools),
+		Healthy:       true,
+		StartedAt:     time.Now(),
+	}
+
+	// Dynamic servers announce runtime tool changes via
+	// notifications/tools/list_changed; keep the pool and DB in sync with them
+	mcm.watchToolListChanges(server.key, server.name, server.environmentID, client)
 
 	span.SetAttributes(
 		attribute.Bool("mcp.server.success", true),
@@ -218,6 +285,9 @@ func (mcm *MCPConnectionManager) getPooledEnvironmentMCPTools(ctx context.Contex
 				if tools, toolsExist := mcm.serverPool.tools[serverKey]; toolsExist {
 					allTools = append(allTools, tools...)
 					allClients = append(allClients, pooledClient) // Reuse pooled client
+					if stats, ok := mcm.serverPool.stats[serverKey]; ok {
+						atomic.AddInt64(&stats.Reuses, 1)
+					}
 					logging.Info(" Using pooled server %s with %d tools", serverKey, len(tools))
 				}
 			} else {
@@ -266,6 +336,8 @@ func (mcm *MCPConnectionManager) ShutdownServerPool() {
 	mcm.serverPool.servers = make(map[string]*mcp.GenkitMCPClient)
 	mcm.serverPool.serverConfigs = make(map[string]interface{})
 	mcm.serverPool.tools = make(map[string][]ai.Tool)
+	mcm.serverPool.stats = make(map[string]*MCPPoolServerStats)
+	mcm.serverPool.initializedEnvs = nil
 
 	logging.Info("MCP server pool shutdown complete")
 }
@@ -528,6 +528,61 @@ func TestShutdownServerPool(t *testing.T) {
 	}
 }
 
+// TestSharedMCPServerPool tests the process-wide pool singleton
+func TestSharedMCPServerPool(t *testing.T) {
+	first := SharedMCPServerPool()
+	second := SharedMCPServerPool()
+
+	if first == nil {
+		t.Fatal("SharedMCPServerPool() returned nil")
+	}
+	if first != second {
+		t.Error("SharedMCPServerPool() should return the same pool instance")
+	}
+}
+
+// TestMCPServerPoolStats tests pool stats snapshots
+func TestMCPServerPoolStats(t *testing.T) {
+	pool := NewMCPServerPool()
+
+	if stats := pool.Stats(); len(stats) != 0 {
+		t.Errorf("Expected empty stats for new pool, got %d entries", len(stats))
+	}
+
+	pool.mutex.Lock()
+	pool.stats["fs:1"] = &MCPPoolServerStats{Key: "fs:1", Name: "filesystem", ToolCount: 14, Healthy: true, Reuses: 3}
+	pool.stats["ship:1"] = &MCPPoolServerStats{Key: "ship:1", Name: "ship", ToolCount: 307, Healthy: false, Restarts: 2}
+	pool.mutex.Unlock()
+
+	stats := pool.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("Expected 2 stats entries, got %d", len(stats))
+	}
+
+	// Sorted by server name for stable API output
+	if stats[0].Name != "filesystem" || stats[1].Name != "ship" {
+		t.Errorf("Expected stats sorted by name, got %s, %s", stats[0].Name, stats[1].Name)
+	}
+
+	if stats[0].Reuses != 3 {
+		t.Errorf("Expected 3 reuses for filesystem, got %d", stats[0].Reuses)
+	}
+
+	if !stats[0].Healthy || stats[1].Healthy {
+		t.Error("Expected filesystem healthy and ship unhealthy")
+	}
+
+	if stats[1].Restarts != 2 {
+		t.Errorf("Expected 2 restarts for ship, got %d", stats[1].Restarts)
+	}
+
+	// Snapshot should be detached from pool state
+	stats[0].Reuses = 99
+	if fresh := pool.Stats(); fresh[0].Reuses != 3 {
+		t.Errorf("Stats() should return a copy, got %d reuses after mutation", fresh[0].Reuses)
+	}
+}
+
 // Benchmark tests
 func BenchmarkNewMCPServerPool(b *testing.B) {
 	b.ResetTimer()
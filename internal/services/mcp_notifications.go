@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"station/internal/logging"
+	"station/pkg/models"
+
+	"github.com/firebase/genkit/go/ai"
+	genkitmcp "github.com/firebase/genkit/go/plugins/mcp"
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// underlyingMCPClient digs the mark3labs client out of a GenkitMCPClient so
+// notification handlers can be registered on the live session. The Genkit
+// plugin keeps its ServerRef in an unexported field and offers no accessor
+// (the same limitation that forces discovery to probe serverInfo separately),
+// but tools/list_changed notifications only arrive on the pooled session
+// itself, so there is no side channel to subscribe on instead.
+func underlyingMCPClient(client *genkitmcp.GenkitMCPClient) *mcpclient.Client {
+	if client == nil {
+		return nil
+	}
+	field := reflect.ValueOf(client).Elem().FieldByName("server")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+	ref := (*genkitmcp.ServerRef)(field.UnsafePointer())
+	if ref == nil {
+		return nil
+	}
+	return ref.Client
+}
+
+// watchToolListChanges subscribes to MCP notifications on a pooled server and
+// refreshes Station's tool inventory when the server emits
+// notifications/tools/list_changed, so dynamic servers that add or remove
+// tools at runtime don't require a manual re-sync.
+func (mcm *MCPConnectionManager) watchToolListChanges(serverKey, serverName string, environmentID int64, client *genkitmcp.GenkitMCPClient) {
+	raw := underlyingMCPClient(client)
+	if raw == nil {
+		logging.Debug("Cannot watch tool list changes for %s: underlying MCP client unavailable", serverKey)
+		return
+	}
+
+	raw.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != mcp.MethodNotificationToolsListChanged {
+			return
+		}
+		logging.Info("MCP server '%s' reported tools/list_changed, refreshing tool inventory", serverName)
+		go mcm.refreshPooledServerTools(context.Background(), serverKey, serverName, environmentID)
+	})
+}
+
+// refreshPooledServerTools re-fetches a pooled server's tool list after a
+// tools/list_changed notification, replacing the pool's cached tools and
+// updating the database inventory
+func (mcm *MCPConnectionManager) refreshPooledServerTools(ctx context.Context, serverKey, serverName string, environmentID int64) {
+	mcm.serverPool.mutex.RLock()
+	client := mcm.serverPool.servers[serverKey]
+	mcm.serverPool.mutex.RUnlock()
+	if client == nil {
+		return
+	}
+
+	refreshCtx, cancel := context.WithTimeout(ctx, poolHealthCheckTimeout)
+	defer cancel()
+	tools, err := client.GetActiveTools(refreshCtx, mcm.genkitApp)
+	if err != nil {
+		logging.Info("Warning: failed to refresh tools for %s after tools/list_changed: %v", serverKey, err)
+		return
+	}
+
+	mcm.serverPool.mutex.Lock()
+	previous := len(mcm.serverPool.tools[serverKey])
+	mcm.serverPool.tools[serverKey] = tools
+	if stats, ok := mcm.serverPool.stats[serverKey]; ok {
+		stats.ToolCount = len(tools)
+	}
+	mcm.serverPool.mutex.Unlock()
+
+	// Cached environment tool lists may now reference stale tools
+	mcm.cacheMutex.Lock()
+	delete(mcm.toolCache, environmentID)
+	mcm.cacheMutex.Unlock()
+
+	if err := mcm.updateToolInventory(environmentID, serverName, tools); err != nil {
+		logging.Info("Warning: failed to update tool inventory for server '%s': %v", serverName, err)
+	}
+
+	logging.Info("Refreshed pooled MCP server %s after tools/list_changed: %d -> %d tools", serverKey, previous, len(tools))
+}
+
+// updateToolInventory reconciles the database tool inventory with the tools a
+// server currently reports. New tools are added; vanished tools are removed
+// after warning about agents that still had them assigned (the assignment
+// rows cascade with the tool).
+func (mcm *MCPConnectionManager) updateToolInventory(environmentID int64, serverName string, tools []ai.Tool) error {
+	server, err := mcm.repos.MCPServers.GetByNameAndEnvironment(serverName, environmentID)
+	if err != nil {
+		return fmt.Errorf("server '%s' not found in database: %w", serverName, err)
+	}
+
+	existingTools, err := mcm.repos.MCPTools.GetByServerID(server.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing tools for server %s: %w", serverName, err)
+	}
+
+	existingByName := make(map[string]*models.MCPTool)
+	for _, tool := range existingTools {
+		existingByName[tool.Name] = tool
+	}
+	discoveredNames := make(map[string]bool)
+	for _, tool := range tools {
+		discoveredNames[tool.Name()] = true
+	}
+
+	added, removed := 0, 0
+	for _, tool := range tools {
+		if _, exists := existingByName[tool.Name()]; exists {
+			continue
+		}
+		toolModel := &models.MCPTool{
+			MCPServerID: server.ID,
+			Name:        tool.Name(),
+		}
+		if _, err := mcm.repos.MCPTools.Create(toolModel); err != nil {
+			logging.Info("Warning: failed to save new tool '%s' for server '%s': %v", tool.Name(), serverName, err)
+			continue
+		}
+		added++
+	}
+
+	for name, existing := range existingByName {
+		if discoveredNames[name] {
+			continue
+		}
+		if agentNames, err := mcm.repos.AgentTools.ListAgentNamesByTool(existing.ID); err == nil && len(agentNames) > 0 {
+			logging.Info("Warning: tool '%s' removed by server '%s' was assigned to agents %v - they will lose it until the server re-adds it or they are re-synced", name, serverName, agentNames)
+		}
+		if err := mcm.repos.MCPTools.Delete(existing.ID); err != nil {
+			logging.Info("Warning: failed to remove vanished tool '%s' for server '%s': %v", name, serverName, err)
+			continue
+		}
+		removed++
+	}
+
+	if added > 0 || removed > 0 {
+		logging.Info("Tool inventory updated for server '%s': +%d added, -%d removed", serverName, added, removed)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+
+	"github.com/firebase/genkit/go/ai"
+)
+
+func setupNotificationTest(t *testing.T) (*repositories.Repositories, int64) {
+	t.Helper()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("notify-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	if _, err := repos.MCPServers.Create(&models.MCPServer{
+		Name:          "dynamic",
+		Command:       "npx",
+		EnvironmentID: env.ID,
+	}); err != nil {
+		t.Fatalf("failed to create MCP server: %v", err)
+	}
+
+	return repos, env.ID
+}
+
+func newNamedTool(name string) ai.Tool {
+	schema := map[string]any{"type": "object"}
+	return ai.NewToolWithInputSchema[any](name, "test tool", schema,
+		func(toolCtx *ai.ToolContext, input any) (any, error) {
+			return input, nil
+		})
+}
+
+func TestUpdateToolInventory(t *testing.T) {
+	repos, envID := setupNotificationTest(t)
+
+	server, err := repos.MCPServers.GetByNameAndEnvironment("dynamic", envID)
+	if err != nil {
+		t.Fatalf("failed to get server: %v", err)
+	}
+
+	// Seed the DB inventory with two tools, one of which the server will drop
+	keptID, err := repos.MCPTools.Create(&models.MCPTool{MCPServerID: server.ID, Name: "__kept_tool"})
+	if err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+	droppedID, err := repos.MCPTools.Create(&models.MCPTool{MCPServerID: server.ID, Name: "__dropped_tool"})
+	if err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+
+	// Assign the soon-to-vanish tool to an agent so the warning path runs
+	agent, err := repos.Agents.Create("Watcher", "", "prompt", 5, envID, 1, nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	if _, err := repos.AgentTools.AddAgentTool(agent.ID, droppedID); err != nil {
+		t.Fatalf("failed to assign tool: %v", err)
+	}
+
+	names, err := repos.AgentTools.ListAgentNamesByTool(droppedID)
+	if err != nil || len(names) != 1 || names[0] != "Watcher" {
+		t.Fatalf("Expected [Watcher] assigned to tool, got %v (err: %v)", names, err)
+	}
+
+	// Server now reports the kept tool plus a brand new one
+	mcm := NewMCPConnectionManager(repos, nil)
+	liveTools := []ai.Tool{newNamedTool("__kept_tool"), newNamedTool("__new_tool")}
+	if err := mcm.updateToolInventory(envID, "dynamic", liveTools); err != nil {
+		t.Fatalf("updateToolInventory failed: %v", err)
+	}
+
+	current, err := repos.MCPTools.GetByServerID(server.ID)
+	if err != nil {
+		t.Fatalf("failed to list tools: %v", err)
+	}
+	byName := make(map[string]int64)
+	for _, tool := range current {
+		byName[tool.Name] = tool.ID
+	}
+	if len(current) != 2 {
+		t.Fatalf("Expected 2 tools after reconcile, got %v", byName)
+	}
+	if id, ok := byName["__kept_tool"]; !ok || id != keptID {
+		t.Errorf("Expected __kept_tool to keep ID %d, got %v", keptID, byName)
+	}
+	if _, ok := byName["__new_tool"]; !ok {
+		t.Errorf("Expected __new_tool to be added, got %v", byName)
+	}
+	if _, ok := byName["__dropped_tool"]; ok {
+		t.Errorf("Expected __dropped_tool to be removed, got %v", byName)
+	}
+}
+
+func TestUpdateToolInventoryUnknownServer(t *testing.T) {
+	repos, envID := setupNotificationTest(t)
+
+	mcm := NewMCPConnectionManager(repos, nil)
+	if err := mcm.updateToolInventory(envID, "missing", nil); err == nil {
+		t.Error("Expected error for unknown server")
+	}
+}
+
+func TestUnderlyingMCPClientNil(t *testing.T) {
+	if underlyingMCPClient(nil) != nil {
+		t.Error("Expected nil for nil client")
+	}
+}
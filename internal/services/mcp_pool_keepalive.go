@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"station/internal/logging"
+)
+
+const (
+	// How often the keep-alive loop health-checks pooled servers; override
+	// with STATION_MCP_KEEPALIVE_SECONDS
+	defaultPoolKeepAliveInterval = 60 * time.Second
+
+	// Per-server timeout for a health check ping (tools/list round trip)
+	poolHealthCheckTimeout = 10 * time.Second
+)
+
+// PrewarmEnvironments starts pooled MCP servers for every environment so
+// the first agent execution after boot skips uvx/npm cold-start costs.
+// Individual environment failures are logged but do not abort pre-warming.
+func (mcm *MCPConnectionManager) PrewarmEnvironments(ctx context.Context) error {
+	if !mcm.poolingEnabled {
+		return nil
+	}
+
+	environments, err := mcm.repos.Environments.List()
+	if err != nil {
+		return fmt.Errorf("failed to list environments for pre-warming: %w", err)
+	}
+
+	for _, env := range environments {
+		if err := mcm.InitializeServerPool(ctx, env.ID); err != nil {
+			logging.Info("Warning: failed to pre-warm MCP servers for environment %s: %v", env.Name, err)
+		}
+	}
+
+	logging.Info("MCP server pool pre-warmed across %d environments (%d servers)",
+		len(environments), len(mcm.serverPool.Stats()))
+	return nil
+}
+
+// StartPoolKeepAlive begins periodic health checks of pooled servers
+// (serve mode). Unhealthy servers are restarted from their stored config
+// so warm connections survive server-side crashes.
+func (mcm *MCPConnectionManager) StartPoolKeepAlive() {
+	if !mcm.poolingEnabled {
+		return
+	}
+
+	interval := time.Duration(getEnvIntOrDefault("STATION_MCP_KEEPALIVE_SECONDS", 0)) * time.Second
+	if interval <= 0 {
+		interval = defaultPoolKeepAliveInterval
+	}
+
+	mcm.keepAliveStop = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				mcm.checkPoolHealth(context.Background())
+			case <-mcm.keepAliveStop:
+				return
+			}
+		}
+	}()
+	logging.Info("MCP pool keep-alive started (checking every %s)", interval)
+}
+
+// StopPoolKeepAlive halts periodic health checks
+func (mcm *MCPConnectionManager) StopPoolKeepAlive() {
+	if mcm.keepAliveStop != nil {
+		close(mcm.keepAliveStop)
+		mcm.keepAliveStop = nil
+	}
+}
+
+// checkPoolHealth pings every pooled server and restarts any that fail
+func (mcm *MCPConnectionManager) checkPoolHealth(ctx context.Context) {
+	mcm.serverPool.mutex.RLock()
+	keys := make([]string, 0, len(mcm.serverPool.servers))
+	for key := range mcm.serverPool.servers {
+		keys = append(keys, key)
+	}
+	mcm.serverPool.mutex.RUnlock()
+
+	for _, key := range keys {
+		mcm.serverPool.mutex.RLock()
+		client := mcm.serverPool.servers[key]
+		mcm.serverPool.mutex.RUnlock()
+		if client == nil {
+			continue
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, poolHealthCheckTimeout)
+		_, err := client.GetActiveTools(checkCtx, mcm.genkitApp)
+		cancel()
+
+		mcm.serverPool.mutex.Lock()
+		if stats, ok := mcm.serverPool.stats[key]; ok {
+			stats.LastHealthCheck = time.Now()
+			stats.Healthy = err == nil
+		}
+		mcm.serverPool.mutex.Unlock()
+
+		if err != nil {
+			logging.Info("Warning: pooled MCP server %s failed health check: %v", key, err)
+			if restartErr := mcm.restartPooledServer(ctx, key); restartErr != nil {
+				logging.Info("Warning: failed to restart pooled MCP server %s: %v", key, restartErr)
+			}
+		}
+	}
+}
+
+// restartPooledServer replaces a dead pooled client with a fresh one
+// created from the server's stored config
+func (mcm *MCPConnectionManager) restartPooledServer(ctx context.Context, key string) error {
+	mcm.serverPool.mutex.RLock()
+	config, hasConfig := mcm.serverPool.serverConfigs[key]
+	oldClient := mcm.serverPool.servers[key]
+	serverName := key
+	var environmentID int64
+	if stats, ok := mcm.serverPool.stats[key]; ok {
+		serverName = stats.Name
+		environmentID = stats.EnvironmentID
+	}
+	mcm.serverPool.mutex.RUnlock()
+
+	if !hasConfig {
+		return fmt.Errorf("no stored config for server %s", key)
+	}
+
+	if oldClient != nil {
+		_ = oldClient.Disconnect()
+	}
+
+	client, tools, err := mcm.createServerClient(ctx, serverName, config)
+	if err != nil {
+		if client != nil {
+			_ = client.Disconnect()
+		}
+		return err
+	}
+
+	mcm.serverPool.mutex.Lock()
+	mcm.serverPool.servers[key] = client
+	mcm.serverPool.tools[key] = tools
+	if stats, ok := mcm.serverPool.stats[key]; ok {
+		stats.Restarts++
+		stats.Healthy = true
+		stats.ToolCount = len(tools)
+		stats.LastHealthCheck = time.Now()
+	}
+	mcm.serverPool.mutex.Unlock()
+
+	// Notification handlers die with the old session; re-register on the
+	// fresh client so tools/list_changed keeps working after a restart
+	mcm.watchToolListChanges(key, serverName, environmentID, client)
+
+	logging.Info("Restarted pooled MCP server %s with %d tools", key, len(tools))
+	return nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"station/internal/db/repositories"
+)
+
+// Remote runners let an MCP server's stdio subprocess execute on another host
+// over SSH — needed when tools must run inside a VPC or next to production
+// data. Runners are registered once (`stn runner add prod-bastion
+// ssh://deploy@bastion:22`) and referenced per server via a `runner:` field in
+// the MCP config; the JSON-RPC stream proxies transparently over the SSH
+// connection's stdin/stdout.
+const runnerSettingPrefix = "runner."
+
+// RemoteRunner is a named SSH target that stdio MCP servers can execute on
+type RemoteRunner struct {
+	Name   string `json:"name"`
+	SSHURL string `json:"ssh_url"`
+}
+
+// parseRunnerSSHURL validates a runner URL and returns the SSH target
+// (user@host) and port, e.g. ssh://deploy@bastion.internal:2222
+func parseRunnerSSHURL(sshURL string) (target, port string, err error) {
+	parsed, err := url.Parse(sshURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid runner URL: %w", err)
+	}
+	if parsed.Scheme != "ssh" {
+		return "", "", fmt.Errorf("runner URL must use the ssh:// scheme, got '%s'", parsed.Scheme)
+	}
+	if parsed.Hostname() == "" {
+		return "", "", fmt.Errorf("runner URL must include a host")
+	}
+
+	target = parsed.Hostname()
+	if parsed.User != nil && parsed.User.Username() != "" {
+		target = parsed.User.Username() + "@" + target
+	}
+	return target, parsed.Port(), nil
+}
+
+// AddRemoteRunner registers a named SSH runner after validating its URL
+func AddRemoteRunner(repos *repositories.Repositories, name, sshURL string) error {
+	if name == "" {
+		return fmt.Errorf("runner name is required")
+	}
+	if _, _, err := parseRunnerSSHURL(sshURL); err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(&RemoteRunner{Name: name, SSHURL: sshURL})
+	if err != nil {
+		return fmt.Errorf("failed to encode runner: %w", err)
+	}
+	return repos.Settings.Set(runnerSettingPrefix+name, string(encoded),
+		fmt.Sprintf("Remote SSH runner '%s' for MCP server execution", name))
+}
+
+// GetRemoteRunner loads a registered runner by name
+func GetRemoteRunner(repos *repositories.Repositories, name string) (*RemoteRunner, error) {
+	setting, err := repos.Settings.GetByKey(runnerSettingPrefix + name)
+	if err != nil {
+		return nil, fmt.Errorf("runner '%s' not registered (use 'stn runner add %s ssh://...')", name, name)
+	}
+
+	var runner RemoteRunner
+	if err := json.Unmarshal([]byte(setting.Value), &runner); err != nil {
+		return nil, fmt.Errorf("failed to decode runner '%s': %w", name, err)
+	}
+	return &runner, nil
+}
+
+// ListRemoteRunners returns all registered runners sorted by name
+func ListRemoteRunners(repos *repositories.Repositories) ([]*RemoteRunner, error) {
+	settings, err := repos.Settings.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var runners []*RemoteRunner
+	for _, setting := range settings {
+		if !strings.HasPrefix(setting.Key, runnerSettingPrefix) {
+			continue
+		}
+		var runner RemoteRunner
+		if err := json.Unmarshal([]byte(setting.Value), &runner); err != nil {
+			continue
+		}
+		runners = append(runners, &runner)
+	}
+	sort.Slice(runners, func(i, j int) bool { return runners[i].Name < runners[j].Name })
+	return runners, nil
+}
+
+// RemoveRemoteRunner deletes a registered runner
+func RemoveRemoteRunner(repos *repositories.Repositories, name string) error {
+	if _, err := GetRemoteRunner(repos, name); err != nil {
+		return err
+	}
+	return repos.Settings.Delete(runnerSettingPrefix + name)
+}
+
+// shellQuoteArg quotes an argument for the remote shell that SSH invokes, so
+// arguments with spaces or metacharacters survive the hop intact
+func shellQuoteArg(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// wrapStdioCommandForRunner rewrites a stdio server command to execute on the
+// named runner over SSH. Env vars are forwarded via `env` on the remote side
+// since the SSH daemon does not inherit the local process environment.
+func wrapStdioCommandForRunner(repos *repositories.Repositories, runnerName, command string, args []string, env map[string]string) (string, []string, error) {
+	runner, err := GetRemoteRunner(repos, runnerName)
+	if err != nil {
+		return "", nil, err
+	}
+	target, port, err := parseRunnerSSHURL(runner.SSHURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sshArgs := []string{"-o", "BatchMode=yes"}
+	if port != "" {
+		sshArgs = append(sshArgs, "-p", port)
+	}
+	sshArgs = append(sshArgs, target, "--")
+
+	if len(env) > 0 {
+		keys := make([]string, 0, len(env))
+		for key := range env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		sshArgs = append(sshArgs, "env")
+		for _, key := range keys {
+			sshArgs = append(sshArgs, shellQuoteArg(key+"="+env[key]))
+		}
+	}
+
+	sshArgs = append(sshArgs, shellQuoteArg(command))
+	for _, arg := range args {
+		sshArgs = append(sshArgs, shellQuoteArg(arg))
+	}
+	return "ssh", sshArgs, nil
+}
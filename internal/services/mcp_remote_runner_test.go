@@ -0,0 +1,119 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestParseRunnerSSHURL(t *testing.T) {
+	target, port, err := parseRunnerSSHURL("ssh://deploy@bastion.internal:2222")
+	if err != nil || target != "deploy@bastion.internal" || port != "2222" {
+		t.Errorf("unexpected parse result: %q / %q / %v", target, port, err)
+	}
+
+	target, port, err = parseRunnerSSHURL("ssh://bastion.internal")
+	if err != nil || target != "bastion.internal" || port != "" {
+		t.Errorf("user and port should be optional: %q / %q / %v", target, port, err)
+	}
+
+	if _, _, err := parseRunnerSSHURL("https://bastion.internal"); err == nil {
+		t.Error("expected error for non-ssh scheme")
+	}
+	if _, _, err := parseRunnerSSHURL("ssh://"); err == nil {
+		t.Error("expected error for missing host")
+	}
+}
+
+func TestRemoteRunnerRegistration(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	if err := AddRemoteRunner(repos, "prod-bastion", "not-a-url"); err == nil {
+		t.Error("expected error for invalid runner URL")
+	}
+	if err := AddRemoteRunner(repos, "", "ssh://bastion"); err == nil {
+		t.Error("expected error for missing runner name")
+	}
+
+	if err := AddRemoteRunner(repos, "prod-bastion", "ssh://deploy@bastion.internal:22"); err != nil {
+		t.Fatalf("AddRemoteRunner failed: %v", err)
+	}
+	if err := AddRemoteRunner(repos, "staging", "ssh://staging.internal"); err != nil {
+		t.Fatalf("AddRemoteRunner failed: %v", err)
+	}
+
+	runner, err := GetRemoteRunner(repos, "prod-bastion")
+	if err != nil || runner.SSHURL != "ssh://deploy@bastion.internal:22" {
+		t.Errorf("unexpected runner: %+v / %v", runner, err)
+	}
+
+	runners, err := ListRemoteRunners(repos)
+	if err != nil || len(runners) != 2 {
+		t.Fatalf("expected 2 runners, got %d / %v", len(runners), err)
+	}
+	if runners[0].Name != "prod-bastion" || runners[1].Name != "staging" {
+		t.Errorf("runners should be sorted by name: %+v", runners)
+	}
+
+	if err := RemoveRemoteRunner(repos, "staging"); err != nil {
+		t.Fatalf("RemoveRemoteRunner failed: %v", err)
+	}
+	if _, err := GetRemoteRunner(repos, "staging"); err == nil {
+		t.Error("removed runner should not resolve")
+	}
+	if err := RemoveRemoteRunner(repos, "absent"); err == nil {
+		t.Error("expected error removing unregistered runner")
+	}
+}
+
+func TestWrapStdioCommandForRunner(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	if err := AddRemoteRunner(repos, "prod-bastion", "ssh://deploy@bastion.internal:2222"); err != nil {
+		t.Fatalf("AddRemoteRunner failed: %v", err)
+	}
+
+	command, args, err := wrapStdioCommandForRunner(repos, "prod-bastion", "npx",
+		[]string{"-y", "@modelcontextprotocol/server-filesystem@latest", "/data dir"},
+		map[string]string{"API_KEY": "secret"})
+	if err != nil {
+		t.Fatalf("wrapStdioCommandForRunner failed: %v", err)
+	}
+	if command != "ssh" {
+		t.Errorf("expected ssh command, got %s", command)
+	}
+
+	joined := strings.Join(args, " ")
+	if !strings.Contains(joined, "-p 2222") {
+		t.Error("expected port forwarded to ssh")
+	}
+	if !strings.Contains(joined, "deploy@bastion.internal") {
+		t.Error("expected ssh target in args")
+	}
+	if !strings.Contains(joined, "env 'API_KEY=secret'") {
+		t.Error("expected env vars forwarded via remote env")
+	}
+	if !strings.Contains(joined, "'/data dir'") {
+		t.Error("expected arguments shell-quoted for the remote shell")
+	}
+	if !strings.Contains(joined, "'npx'") {
+		t.Error("expected original command in remote invocation")
+	}
+
+	if _, _, err := wrapStdioCommandForRunner(repos, "absent", "npx", nil, nil); err == nil {
+		t.Error("expected error for unregistered runner")
+	}
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+// Stdio MCP subprocesses log errors to stderr, which would otherwise be
+// lost: the genkit client only wires stdin/stdout for JSON-RPC. Station
+// redirects each server's stderr to a per-server log file (with ring-buffer
+// trimming) so crashes and tool failures can be diagnosed after the fact via
+// `stn mcp logs <server>` and run debug logs.
+const (
+	// Log files are trimmed to the keep size once they exceed the max
+	mcpStderrLogMaxBytes  = 256 * 1024
+	mcpStderrLogKeepBytes = 128 * 1024
+
+	// Window of recent stderr attached to run diagnostics on tool failure
+	mcpStderrDiagnosticBytes = 4 * 1024
+)
+
+// wrapStdioCommandForStderrCapture rewrites a stdio server command so the
+// subprocess appends its stderr to the server's log file. Requires a POSIX
+// shell; on systems without /bin/sh the command runs unwrapped and stderr is
+// discarded as before.
+func wrapStdioCommandForStderrCapture(serverName, command string, args []string) (string, []string) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		return command, args
+	}
+
+	logPath := config.GetMCPServerLogPath(serverName)
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		logging.Debug("Failed to create MCP log directory for %s: %v", serverName, err)
+		return command, args
+	}
+	trimMCPStderrLog(logPath)
+
+	// exec keeps the server as the shell's process so signals propagate;
+	// "$0"/"$@" avoid quoting issues in the original command and args
+	wrapped := append([]string{"-c", fmt.Sprintf(`exec "$0" "$@" 2>>"%s"`, logPath), command}, args...)
+	return "/bin/sh", wrapped
+}
+
+// trimMCPStderrLog enforces ring-buffer semantics on a stderr log: once the
+// file exceeds the max size, only the most recent bytes are kept
+func trimMCPStderrLog(logPath string) {
+	info, err := os.Stat(logPath)
+	if err != nil || info.Size() <= mcpStderrLogMaxBytes {
+		return
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		return
+	}
+	tail := data[len(data)-mcpStderrLogKeepBytes:]
+	if err := os.WriteFile(logPath, tail, 0644); err != nil {
+		logging.Debug("Failed to trim MCP stderr log %s: %v", logPath, err)
+	}
+}
+
+// ReadMCPServerStderr returns up to maxBytes of the most recent stderr
+// output captured from a stdio MCP server, or "" when nothing was captured
+func ReadMCPServerStderr(serverName string, maxBytes int) string {
+	data, err := os.ReadFile(config.GetMCPServerLogPath(serverName))
+	if err != nil {
+		return ""
+	}
+	if len(data) > maxBytes {
+		data = data[len(data)-maxBytes:]
+	}
+	return string(data)
+}
+
+// mcpStderrDiagnosticsMiddleware attaches the failing server's recent stderr
+// window to the run's debug logs when a tool call errors, so `stn runs
+// inspect -v` shows what the subprocess reported
+type mcpStderrDiagnosticsMiddleware struct {
+	repos *repositories.Repositories
+}
+
+func (m *mcpStderrDiagnosticsMiddleware) Name() string {
+	return "mcp-stderr-diagnostics"
+}
+
+func (m *mcpStderrDiagnosticsMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	return nil, nil
+}
+
+func (m *mcpStderrDiagnosticsMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	if callErr == nil || call.RunID == 0 || call.AgentID == 0 {
+		return nil, nil
+	}
+
+	serverName := m.serverNameForTool(call)
+	if serverName == "" {
+		return nil, nil
+	}
+	stderr := ReadMCPServerStderr(serverName, mcpStderrDiagnosticBytes)
+	if stderr == "" {
+		return nil, nil
+	}
+
+	logEntry := map[string]interface{}{
+		"level":      "warn",
+		"message":    fmt.Sprintf("Tool %s failed; recent stderr from MCP server '%s' attached", call.ToolName, serverName),
+		"tool_name":  call.ToolName,
+		"mcp_server": serverName,
+		"stderr":     stderr,
+		"timestamp":  time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := m.repos.AgentRuns.AppendDebugLog(ctx, call.RunID, logEntry); err != nil {
+		logging.Debug("Failed to attach MCP stderr to run %d: %v", call.RunID, err)
+	}
+	return nil, nil
+}
+
+// serverNameForTool resolves which MCP server in the agent's environment
+// provides the failing tool
+func (m *mcpStderrDiagnosticsMiddleware) serverNameForTool(call *ToolCall) string {
+	agent, err := m.repos.Agents.GetByID(call.AgentID)
+	if err != nil {
+		return ""
+	}
+	tool, err := resolveToolInEnvironment(m.repos, agent.EnvironmentID, call.ToolName)
+	if err != nil {
+		return ""
+	}
+	server, err := m.repos.MCPServers.GetByID(tool.MCPServerID)
+	if err != nil {
+		return ""
+	}
+	return server.Name
+}
+
+var enableMCPStderrDiagnosticsOnce sync.Once
+
+// EnableMCPStderrDiagnostics registers the stderr diagnostics middleware so
+// tool failures carry the failing server's recent stderr output (first
+// constructor wins)
+func EnableMCPStderrDiagnostics(repos *repositories.Repositories) {
+	enableMCPStderrDiagnosticsOnce.Do(func() {
+		RegisterToolCallMiddleware(&mcpStderrDiagnosticsMiddleware{repos: repos})
+	})
+}
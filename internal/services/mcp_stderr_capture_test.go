@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"station/internal/config"
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+func TestWrapStdioCommandForStderrCapture(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	command, args := wrapStdioCommandForStderrCapture("filesystem", "npx", []string{"-y", "@modelcontextprotocol/server-filesystem@latest"})
+	if command != "/bin/sh" {
+		t.Fatalf("expected shell wrapper, got %s", command)
+	}
+	if args[0] != "-c" || !strings.Contains(args[1], "2>>") {
+		t.Errorf("expected stderr redirection in wrapped command, got %v", args)
+	}
+	if args[2] != "npx" || args[3] != "-y" {
+		t.Errorf("original command and args should pass through as positionals, got %v", args)
+	}
+	if !strings.Contains(args[1], config.GetMCPServerLogPath("filesystem")) {
+		t.Error("wrapped command should redirect to the server's log path")
+	}
+}
+
+func TestTrimMCPStderrLog(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	logPath := config.GetMCPServerLogPath("noisy")
+	if err := os.MkdirAll(strings.TrimSuffix(logPath, "/noisy.log"), 0755); err != nil {
+		t.Fatalf("failed to create log directory: %v", err)
+	}
+
+	// Under the max size the file is untouched
+	small := []byte("error: something failed\n")
+	if err := os.WriteFile(logPath, small, 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	trimMCPStderrLog(logPath)
+	data, _ := os.ReadFile(logPath)
+	if string(data) != string(small) {
+		t.Error("logs under the size cap should not be trimmed")
+	}
+
+	// Oversized files keep only the most recent bytes
+	big := append(make([]byte, mcpStderrLogMaxBytes), []byte("recent tail output")...)
+	if err := os.WriteFile(logPath, big, 0644); err != nil {
+		t.Fatalf("failed to write oversized log: %v", err)
+	}
+	trimMCPStderrLog(logPath)
+	data, _ = os.ReadFile(logPath)
+	if len(data) != mcpStderrLogKeepBytes {
+		t.Errorf("expected log trimmed to %d bytes, got %d", mcpStderrLogKeepBytes, len(data))
+	}
+	if !strings.HasSuffix(string(data), "recent tail output") {
+		t.Error("trimming should keep the most recent output")
+	}
+}
+
+func TestReadMCPServerStderr(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if ReadMCPServerStderr("absent", 1024) != "" {
+		t.Error("servers without captured output should return empty")
+	}
+
+	logPath := config.GetMCPServerLogPath("chatty")
+	if err := os.MkdirAll(strings.TrimSuffix(logPath, "/chatty.log"), 0755); err != nil {
+		t.Fatalf("failed to create log directory: %v", err)
+	}
+	if err := os.WriteFile(logPath, []byte("old output\nnew output\n"), 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	full := ReadMCPServerStderr("chatty", 1024)
+	if full != "old output\nnew output\n" {
+		t.Errorf("unexpected full read: %q", full)
+	}
+	tail := ReadMCPServerStderr("chatty", 11)
+	if tail != "new output\n" {
+		t.Errorf("expected most recent bytes, got %q", tail)
+	}
+}
+
+func TestMCPStderrDiagnosticsMiddleware(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("stderr-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("stderr-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Failing Agent", "fails", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	run, err := repos.AgentRuns.Create(context.Background(), agent.ID, user.ID, "task", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+
+	serverID, err := repos.MCPServers.Create(&models.MCPServer{
+		Name:          "flaky",
+		Command:       "npx",
+		Args:          []string{"-y", "flaky-server"},
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create MCP server: %v", err)
+	}
+	if _, err := repos.MCPTools.Create(&models.MCPTool{
+		MCPServerID: serverID,
+		Name:        "__flaky_tool",
+		Description: "A tool that fails",
+	}); err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+
+	logPath := config.GetMCPServerLogPath("flaky")
+	if err := os.MkdirAll(strings.TrimSuffix(logPath, "/flaky.log"), 0755); err != nil {
+		t.Fatalf("failed to create log directory: %v", err)
+	}
+	if err := os.WriteFile(logPath, []byte("panic: connection refused\n"), 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	m := &mcpStderrDiagnosticsMiddleware{repos: repos}
+	call := &ToolCall{AgentID: agent.ID, RunID: run.ID, ToolName: "__flaky_tool", Annotations: map[string]interface{}{}}
+
+	// Successful calls attach nothing
+	if _, err := m.AfterToolCall(context.Background(), call, "ok", nil); err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+	updated, err := repos.AgentRuns.GetByID(context.Background(), run.ID)
+	if err != nil {
+		t.Fatalf("failed to load run: %v", err)
+	}
+	if updated.DebugLogs != nil && len(*updated.DebugLogs) != 0 {
+		t.Fatal("successful calls should not attach stderr diagnostics")
+	}
+
+	// Failed calls attach the server's recent stderr window
+	if _, err := m.AfterToolCall(context.Background(), call, nil, context.DeadlineExceeded); err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+	updated, err = repos.AgentRuns.GetByID(context.Background(), run.ID)
+	if err != nil {
+		t.Fatalf("failed to load run: %v", err)
+	}
+	if updated.DebugLogs == nil || len(*updated.DebugLogs) == 0 {
+		t.Fatal("expected stderr diagnostics in debug logs after tool failure")
+	}
+	entry, ok := (*updated.DebugLogs)[0].(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected debug log entry: %+v", (*updated.DebugLogs)[0])
+	}
+	if entry["mcp_server"] != "flaky" {
+		t.Errorf("expected server name on entry, got %v", entry["mcp_server"])
+	}
+	if stderr, _ := entry["stderr"].(string); !strings.Contains(stderr, "connection refused") {
+		t.Errorf("expected captured stderr on entry, got %v", entry["stderr"])
+	}
+
+	// Unresolvable tools are skipped quietly
+	unknown := &ToolCall{AgentID: agent.ID, RunID: run.ID, ToolName: "__unknown_tool", Annotations: map[string]interface{}{}}
+	if _, err := m.AfterToolCall(context.Background(), unknown, nil, context.DeadlineExceeded); err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"station/internal/config"
 	"station/internal/db/repositories"
@@ -17,7 +18,7 @@ import (
 )
 
 // performToolDiscovery performs MCP tool discovery for a specific config with proper server-to-tool mapping
-func (s *DeclarativeSync) performToolDiscovery(ctx context.Context, envID int64, configName string) (int, error) {
+func (s *DeclarativeSync) performToolDiscovery(ctx context.Context, envID int64, configName string, progress ProgressFunc) (int, error) {
 	// Create MCP connection manager for tool discovery
 	mcpConnManager := NewMCPConnectionManager(s.repos, nil)
 
@@ -35,7 +36,7 @@ func (s *DeclarativeSync) performToolDiscovery(ctx context.Context, envID int64,
 	}
 
 	// Discover tools per server (preserving server-to-tool mapping)
-	serverToolMappings, clients, err := s.discoverToolsPerServer(ctx, mcpConnManager, fileConfig)
+	serverToolMappings, serverInfos, clients, err := s.discoverToolsPerServer(ctx, mcpConnManager, fileConfig, progress)
 
 	// Clean up connections immediately
 	defer mcpConnManager.CleanupConnections(clients)
@@ -84,6 +85,12 @@ func (s *DeclarativeSync) performToolDiscovery(ctx context.Context, envID int64,
 		}
 		logging.Info("     ✅ Saved %d tools for server '%s'", toolsSaved, serverName)
 		totalToolsSaved += toolsSaved
+
+		// Record the serverInfo reported during the initialize handshake and
+		// surface version changes since the last sync
+		if info := serverInfos[serverName]; info != nil {
+			s.recordServerInfo(ctx, envID, serverName, info)
+		}
 	}
 
 	logging.Info("   🔍 Tool discovery completed for %s: %d tools saved across %d servers", configName, totalToolsSaved, len(serverToolMappings))
@@ -147,14 +154,32 @@ func (s *DeclarativeSync) cleanupBrokenMCPServers(ctx context.Context, envID int
 	return nil
 }
 
+// recordServerInfo persists the serverInfo a server reported during discovery
+// and logs when the version changed since the previous sync
+func (s *DeclarativeSync) recordServerInfo(ctx context.Context, envID int64, serverName string, info *MCPServerInfo) {
+	server, err := s.repos.MCPServers.GetByNameAndEnvironment(serverName, envID)
+	if err != nil {
+		return
+	}
+
+	_, prevVersion, err := s.repos.MCPServers.GetServerInfo(ctx, server.ID)
+	if err == nil && prevVersion != "" && prevVersion != info.Version {
+		fmt.Printf("     📦 MCP server '%s' version changed: %s → %s\n", serverName, prevVersion, info.Version)
+	}
+
+	if err := s.repos.MCPServers.UpdateServerInfo(ctx, server.ID, info.Name, info.Version); err != nil {
+		logging.Info("     ⚠️  Failed to record server info for %s: %v", serverName, err)
+	}
+}
+
 // discoverToolsPerServer connects to each MCP server individually and returns tools mapped by server name
-func (s *DeclarativeSync) discoverToolsPerServer(ctx context.Context, mcpConnManager *MCPConnectionManager, fileConfig *repositories.FileConfigRecord) (map[string][]ai.Tool, []*mcp.GenkitMCPClient, error) {
+func (s *DeclarativeSync) discoverToolsPerServer(ctx context.Context, mcpConnManager *MCPConnectionManager, fileConfig *repositories.FileConfigRecord, progress ProgressFunc) (map[string][]ai.Tool, map[string]*MCPServerInfo, []*mcp.GenkitMCPClient, error) {
 	// Resolve the template path (handles both relative and absolute paths)
 	absolutePath := s.resolveConfigPath(fileConfig.TemplatePath)
 
 	rawContent, err := os.ReadFile(absolutePath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	// Process template variables using centralized path resolution
@@ -162,13 +187,13 @@ func (s *DeclarativeSync) discoverToolsPerServer(ctx context.Context, mcpConnMan
 	templateService := NewTemplateVariableService(configDir, s.repos)
 	result, err := templateService.ProcessTemplateWithVariables(fileConfig.EnvironmentID, fileConfig.ConfigName, string(rawContent), false)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to process template variables: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to process template variables: %w", err)
 	}
 
 	// Parse the config
 	var rawConfig map[string]interface{}
 	if err := json.Unmarshal([]byte(result.RenderedContent), &rawConfig); err != nil {
-		return nil, nil, fmt.Errorf("failed to parse config: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
 	// Extract servers
@@ -178,16 +203,21 @@ func (s *DeclarativeSync) discoverToolsPerServer(ctx context.Context, mcpConnMan
 	} else if servers, ok := rawConfig["servers"].(map[string]interface{}); ok {
 		serversData = servers
 	} else {
-		return nil, nil, fmt.Errorf("no MCP servers found in config")
+		return nil, nil, nil, fmt.Errorf("no MCP servers found in config")
 	}
 
 	serverToolMappings := make(map[string][]ai.Tool)
+	serverInfos := make(map[string]*MCPServerInfo)
 	var allClients []*mcp.GenkitMCPClient
 	var connectionErrors []string
 
 	// Process each server individually to preserve server-to-tool mapping
 	logging.Info("     🔍 Discovering tools from %d servers individually...", len(serversData))
+	discovered := 0
 	for serverName, serverConfigRaw := range serversData {
+		if progress != nil {
+			progress(discovered, len(serversData), serverName)
+		}
 		logging.Info("       🖥️  Connecting to server: %s", serverName)
 
 		tools, client := mcpConnManager.connectToMCPServer(ctx, serverName, serverConfigRaw)
@@ -198,6 +228,17 @@ func (s *DeclarativeSync) discoverToolsPerServer(ctx context.Context, mcpConnMan
 		if tools != nil && len(tools) > 0 {
 			serverToolMappings[serverName] = tools
 			logging.Info("       ✅ Discovered %d tools from server '%s'", len(tools), serverName)
+
+			// Capture the serverInfo reported during the initialize handshake
+			// (the Genkit client does not expose it, so probe separately)
+			probeCtx, cancelProbe := context.WithTimeout(ctx, 30*time.Second)
+			if info, probeErr := NewMCPClient().ProbeServerInfo(probeCtx, serverConfigRaw); probeErr == nil {
+				serverInfos[serverName] = info
+				logging.Info("       ℹ️  Server '%s' reports %s %s", serverName, info.Name, info.Version)
+			} else {
+				logging.Debug("       Failed to probe server info for '%s': %v", serverName, probeErr)
+			}
+			cancelProbe()
 			// Log first few tool names for debugging
 			for i, tool := range tools {
 				if i < 3 { // Show first 3 tools
@@ -213,14 +254,18 @@ func (s *DeclarativeSync) discoverToolsPerServer(ctx context.Context, mcpConnMan
 			logging.Info("       ❌ %s", errorMsg)
 			connectionErrors = append(connectionErrors, errorMsg)
 		}
+		discovered++
+	}
+	if progress != nil {
+		progress(discovered, len(serversData), "")
 	}
 
 	// If any server failed to connect, return error
 	if len(connectionErrors) > 0 {
-		return serverToolMappings, allClients, fmt.Errorf("tool discovery failed for %d server(s): %v", len(connectionErrors), connectionErrors)
+		return serverToolMappings, serverInfos, allClients, fmt.Errorf("tool discovery failed for %d server(s): %v", len(connectionErrors), connectionErrors)
 	}
 
-	return serverToolMappings, allClients, nil
+	return serverToolMappings, serverInfos, allClients, nil
 }
 
 // saveToolsForServer saves tools for a specific server (idempotent - preserves IDs when possible)
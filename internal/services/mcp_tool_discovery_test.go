@@ -331,7 +331,7 @@ func TestPerformToolDiscovery(t *testing.T) {
 
 			// Note: This will likely fail without actual MCP server running
 			// We're testing the workflow structure, not full integration
-			count, err := syncService.performToolDiscovery(ctx, env.ID, tt.configName)
+			count, err := syncService.performToolDiscovery(ctx, env.ID, tt.configName, nil)
 
 			if err != nil {
 				t.Logf("Expected failure in test environment: %v", err)
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+// MCPVersionChecker powers `stn mcp outdated`: it looks up the latest
+// published version of package-runner based MCP servers (npx → npm registry,
+// uvx → PyPI) and compares it against the version each server reported
+// during the initialize handshake at discovery time.
+type MCPVersionChecker struct {
+	repos  *repositories.Repositories
+	client *http.Client
+}
+
+// MCPServerVersionStatus is the outdated-check result for one server
+type MCPServerVersionStatus struct {
+	ServerName     string `json:"server_name"`
+	Package        string `json:"package,omitempty"`
+	Registry       string `json:"registry,omitempty"`
+	CurrentVersion string `json:"current_version,omitempty"`
+	LatestVersion  string `json:"latest_version,omitempty"`
+	Outdated       bool   `json:"outdated"`
+	// Skipped explains why a server could not be checked (no package runner,
+	// never discovered, registry lookup failed)
+	Skipped string `json:"skipped,omitempty"`
+}
+
+func NewMCPVersionChecker(repos *repositories.Repositories) *MCPVersionChecker {
+	return &MCPVersionChecker{
+		repos:  repos,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// CheckEnvironment checks every MCP server in an environment against its
+// package registry
+func (c *MCPVersionChecker) CheckEnvironment(ctx context.Context, envID int64) ([]MCPServerVersionStatus, error) {
+	servers, err := c.repos.MCPServers.GetByEnvironmentID(envID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MCP servers: %w", err)
+	}
+
+	var statuses []MCPServerVersionStatus
+	for _, server := range servers {
+		statuses = append(statuses, c.checkServer(ctx, server))
+	}
+	return statuses, nil
+}
+
+// checkServer resolves one server's package and compares versions
+func (c *MCPVersionChecker) checkServer(ctx context.Context, server *models.MCPServer) MCPServerVersionStatus {
+	status := MCPServerVersionStatus{ServerName: server.Name}
+
+	registry, pkg := extractServerPackage(server.Command, server.Args)
+	if pkg == "" {
+		status.Skipped = "not launched via a package runner (npx/uvx)"
+		return status
+	}
+	status.Registry = registry
+	status.Package = pkg
+
+	_, currentVersion, err := c.repos.MCPServers.GetServerInfo(ctx, server.ID)
+	if err != nil || currentVersion == "" {
+		status.Skipped = "no recorded version - run 'stn sync' to discover"
+		return status
+	}
+	status.CurrentVersion = currentVersion
+
+	latest, err := c.latestVersion(ctx, registry, pkg)
+	if err != nil {
+		status.Skipped = fmt.Sprintf("registry lookup failed: %v", err)
+		return status
+	}
+	status.LatestVersion = latest
+	status.Outdated = latest != "" && latest != currentVersion
+	return status
+}
+
+// extractServerPackage returns the registry and package name for servers
+// launched via a package runner; empty when the command is not one
+func extractServerPackage(command string, args []string) (string, string) {
+	var registry string
+	switch command {
+	case "npx":
+		registry = "npm"
+	case "uvx":
+		registry = "pypi"
+	default:
+		return "", ""
+	}
+
+	// The package spec is the first non-flag argument
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		return registry, stripPackageVersion(arg)
+	}
+	return "", ""
+}
+
+// stripPackageVersion removes a pinned version or tag suffix from a package
+// spec (e.g. "@modelcontextprotocol/server-filesystem@latest" or "mcp-server-git==1.0")
+func stripPackageVersion(spec string) string {
+	if idx := strings.Index(spec, "=="); idx > 0 {
+		return spec[:idx]
+	}
+	// Split on the last '@' that is not the scope prefix
+	if idx := strings.LastIndex(spec, "@"); idx > 0 {
+		return spec[:idx]
+	}
+	return spec
+}
+
+// latestVersion queries the package registry for the latest published version
+func (c *MCPVersionChecker) latestVersion(ctx context.Context, registry, pkg string) (string, error) {
+	switch registry {
+	case "npm":
+		var result struct {
+			Version string `json:"version"`
+		}
+		url := fmt.Sprintf("https://registry.npmjs.org/%s/latest", pkg)
+		if err := c.fetchJSON(ctx, url, &result); err != nil {
+			return "", err
+		}
+		return result.Version, nil
+	case "pypi":
+		var result struct {
+			Info struct {
+				Version string `json:"version"`
+			} `json:"info"`
+		}
+		url := fmt.Sprintf("https://pypi.org/pypi/%s/json", pkg)
+		if err := c.fetchJSON(ctx, url, &result); err != nil {
+			return "", err
+		}
+		return result.Info.Version, nil
+	}
+	return "", fmt.Errorf("unknown registry: %s", registry)
+}
+
+func (c *MCPVersionChecker) fetchJSON(ctx context.Context, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry returned status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
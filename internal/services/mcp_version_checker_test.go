@@ -0,0 +1,70 @@
+package services
+
+import "testing"
+
+func TestExtractServerPackage(t *testing.T) {
+	tests := []struct {
+		name         string
+		command      string
+		args         []string
+		wantRegistry string
+		wantPackage  string
+	}{
+		{
+			name:         "npx with flags and pinned tag",
+			command:      "npx",
+			args:         []string{"-y", "@modelcontextprotocol/server-filesystem@latest", "/workspace"},
+			wantRegistry: "npm",
+			wantPackage:  "@modelcontextprotocol/server-filesystem",
+		},
+		{
+			name:         "npx unscoped package without pin",
+			command:      "npx",
+			args:         []string{"-y", "mcp-server-sqlite"},
+			wantRegistry: "npm",
+			wantPackage:  "mcp-server-sqlite",
+		},
+		{
+			name:         "uvx with pinned version",
+			command:      "uvx",
+			args:         []string{"mcp-server-git==1.0.2"},
+			wantRegistry: "pypi",
+			wantPackage:  "mcp-server-git",
+		},
+		{
+			name:    "custom binary is not checkable",
+			command: "ship",
+			args:    []string{"mcp", "security", "--stdio"},
+		},
+		{
+			name:    "npx with only flags",
+			command: "npx",
+			args:    []string{"-y"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, pkg := extractServerPackage(tt.command, tt.args)
+			if registry != tt.wantRegistry || pkg != tt.wantPackage {
+				t.Errorf("extractServerPackage(%q, %v) = (%q, %q), want (%q, %q)",
+					tt.command, tt.args, registry, pkg, tt.wantRegistry, tt.wantPackage)
+			}
+		})
+	}
+}
+
+func TestStripPackageVersion(t *testing.T) {
+	cases := map[string]string{
+		"@modelcontextprotocol/server-filesystem@latest": "@modelcontextprotocol/server-filesystem",
+		"@modelcontextprotocol/server-filesystem":        "@modelcontextprotocol/server-filesystem",
+		"mcp-server-sqlite@1.2.3":                        "mcp-server-sqlite",
+		"mcp-server-git==1.0.2":                          "mcp-server-git",
+		"plain-package":                                  "plain-package",
+	}
+	for spec, want := range cases {
+		if got := stripPackageVersion(spec); got != want {
+			t.Errorf("stripPackageVersion(%q) = %q, want %q", spec, got, want)
+		}
+	}
+}
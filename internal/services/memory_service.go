@@ -24,17 +24,17 @@ type MemoryService struct {
 	memoryAPIClient *lighthouse.MemoryAPIClient
 
 	// Local fallback cache
-	localCache     map[string]*cachedMemory
-	localCacheMu   sync.RWMutex
-	cacheExpiry    time.Duration
-	workspaceDir   string
-	maxTokens      int // Default max tokens if not specified
+	localCache   map[string]*cachedMemory
+	localCacheMu sync.RWMutex
+	cacheExpiry  time.Duration
+	workspaceDir string
+	maxTokens    int // Default max tokens if not specified
 }
 
 // cachedMemory represents cached memory context
 type cachedMemory struct {
-	content   string
-	loadedAt  time.Time
+	content    string
+	loadedAt   time.Time
 	tokenCount int
 }
 
@@ -0,0 +1,86 @@
+package services
+
+import "strings"
+
+// ModelPricing holds per-model token rates in USD per million tokens
+type ModelPricing struct {
+	InputPerMTok  float64 `json:"input_per_mtok"`
+	OutputPerMTok float64 `json:"output_per_mtok"`
+}
+
+// modelPricingTable maps model names (without provider prefix) to published
+// API rates. Lookups match the longest prefix so dated variants like
+// gpt-4o-2024-08-06 resolve to their base model's rates.
+var modelPricingTable = map[string]ModelPricing{
+	// OpenAI
+	"gpt-5":        {InputPerMTok: 1.25, OutputPerMTok: 10.00},
+	"gpt-5-mini":   {InputPerMTok: 0.25, OutputPerMTok: 2.00},
+	"gpt-5-nano":   {InputPerMTok: 0.05, OutputPerMTok: 0.40},
+	"gpt-4o":       {InputPerMTok: 2.50, OutputPerMTok: 10.00},
+	"gpt-4o-mini":  {InputPerMTok: 0.15, OutputPerMTok: 0.60},
+	"gpt-4.1":      {InputPerMTok: 2.00, OutputPerMTok: 8.00},
+	"gpt-4.1-mini": {InputPerMTok: 0.40, OutputPerMTok: 1.60},
+	"o3":           {InputPerMTok: 2.00, OutputPerMTok: 8.00},
+	"o4-mini":      {InputPerMTok: 1.10, OutputPerMTok: 4.40},
+
+	// Anthropic
+	"claude-opus-4":     {InputPerMTok: 15.00, OutputPerMTok: 75.00},
+	"claude-sonnet-4":   {InputPerMTok: 3.00, OutputPerMTok: 15.00},
+	"claude-3-7-sonnet": {InputPerMTok: 3.00, OutputPerMTok: 15.00},
+	"claude-3-5-sonnet": {InputPerMTok: 3.00, OutputPerMTok: 15.00},
+	"claude-3-5-haiku":  {InputPerMTok: 0.80, OutputPerMTok: 4.00},
+
+	// Google
+	"gemini-2.5-pro":   {InputPerMTok: 1.25, OutputPerMTok: 10.00},
+	"gemini-2.5-flash": {InputPerMTok: 0.30, OutputPerMTok: 2.50},
+	"gemini-2.0-flash": {InputPerMTok: 0.10, OutputPerMTok: 0.40},
+
+	// CloudShip AI (Together AI backend)
+	"cloudship/glm-4.7":       {InputPerMTok: 0.60, OutputPerMTok: 2.20},
+	"cloudship/deepseek-v3.1": {InputPerMTok: 0.60, OutputPerMTok: 1.70},
+	"cloudship/qwen3-235b":    {InputPerMTok: 0.20, OutputPerMTok: 0.60},
+	"cloudship/glm-4.5-air":   {InputPerMTok: 0.20, OutputPerMTok: 1.10},
+	"cloudship/llama-3.1-70b": {InputPerMTok: 0.88, OutputPerMTok: 0.88},
+	"cloudship/llama-3.1-8b":  {InputPerMTok: 0.18, OutputPerMTok: 0.18},
+}
+
+// defaultModelPricing is the blended fallback for unknown models, matching the
+// rough $0.002/1K-token approximation the report projections used
+var defaultModelPricing = ModelPricing{InputPerMTok: 2.00, OutputPerMTok: 2.00}
+
+// LookupModelPricing resolves the rates for a model name, tolerating provider
+// prefixes ("openai/gpt-4o-mini") and dated variants ("gpt-4o-2024-08-06").
+// The bool reports whether a table entry matched (false means fallback rates).
+func LookupModelPricing(modelName string) (ModelPricing, bool) {
+	name := strings.ToLower(strings.TrimSpace(modelName))
+
+	// Strip a provider prefix unless the table keys include it (cloudship/*)
+	if _, ok := modelPricingTable[name]; !ok {
+		if idx := strings.Index(name, "/"); idx >= 0 && !strings.HasPrefix(name, "cloudship/") {
+			name = name[idx+1:]
+		}
+	}
+
+	if pricing, ok := modelPricingTable[name]; ok {
+		return pricing, true
+	}
+
+	// Longest prefix match for dated/suffixed variants
+	var bestKey string
+	for key := range modelPricingTable {
+		if strings.HasPrefix(name, key) && len(key) > len(bestKey) {
+			bestKey = key
+		}
+	}
+	if bestKey != "" {
+		return modelPricingTable[bestKey], true
+	}
+	return defaultModelPricing, false
+}
+
+// RunCostUSD computes the cost of a run from its token counts and model
+func RunCostUSD(inputTokens, outputTokens int64, modelName string) float64 {
+	pricing, _ := LookupModelPricing(modelName)
+	return float64(inputTokens)/1_000_000*pricing.InputPerMTok +
+		float64(outputTokens)/1_000_000*pricing.OutputPerMTok
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"station/internal/config"
+)
+
+const defaultOllamaBaseURL = "http://localhost:11434"
+
+// OllamaService talks to a local Ollama server's native API for model
+// management. The server address comes from the configured OpenAI-compatible
+// base URL (e.g. http://localhost:11434/v1) with the /v1 suffix stripped.
+type OllamaService struct {
+	baseURL string
+	client  *http.Client
+}
+
+// OllamaModel describes one installed model as reported by the server
+type OllamaModel struct {
+	Name          string    `json:"name"`
+	SizeBytes     int64     `json:"size"`
+	ModifiedAt    time.Time `json:"modified_at"`
+	SupportsTools bool      `json:"-"`
+}
+
+// NewOllamaService creates a client for the configured Ollama server
+func NewOllamaService(cfg *config.Config) *OllamaService {
+	baseURL := defaultOllamaBaseURL
+	if cfg != nil && cfg.AIBaseURL != "" {
+		baseURL = strings.TrimSuffix(strings.TrimSuffix(cfg.AIBaseURL, "/"), "/v1")
+	}
+	return &OllamaService{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// IsOllamaConfigured reports whether the loaded config points Station at an
+// Ollama server (custom OpenAI-compatible endpoint on the Ollama port or
+// with "ollama" in the URL)
+func IsOllamaConfigured(cfg *config.Config) bool {
+	if cfg == nil || cfg.AIBaseURL == "" {
+		return false
+	}
+	baseURL := strings.ToLower(cfg.AIBaseURL)
+	return strings.Contains(baseURL, ":11434") || strings.Contains(baseURL, "ollama")
+}
+
+// ListModels returns the models installed on the server with tool-calling
+// support resolved for each
+func (s *OllamaService) ListModels(ctx context.Context) ([]OllamaModel, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/api/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Ollama server at %s: %w", s.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Ollama server returned status %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		Models []OllamaModel `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to parse model list: %w", err)
+	}
+
+	for i := range payload.Models {
+		supports, err := s.ModelSupportsTools(ctx, payload.Models[i].Name)
+		if err == nil {
+			payload.Models[i].SupportsTools = supports
+		}
+	}
+	return payload.Models, nil
+}
+
+// PullModel downloads a model, streaming progress lines to the writer
+func (s *OllamaService) PullModel(ctx context.Context, name string, progress io.Writer) error {
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/api/pull", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Pulls can take a long time; stream without the default client timeout
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Ollama server at %s: %w", s.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Ollama server returned status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lastStatus := ""
+	for scanner.Scan() {
+		var update struct {
+			Status    string `json:"status"`
+			Error     string `json:"error"`
+			Completed int64  `json:"completed"`
+			Total     int64  `json:"total"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &update); err != nil {
+			continue
+		}
+		if update.Error != "" {
+			return fmt.Errorf("pull failed: %s", update.Error)
+		}
+		if update.Total > 0 {
+			fmt.Fprintf(progress, "\r%s: %.1f%%", update.Status, float64(update.Completed)/float64(update.Total)*100)
+		} else if update.Status != lastStatus {
+			if lastStatus != "" {
+				fmt.Fprintln(progress)
+			}
+			fmt.Fprintf(progress, "%s", update.Status)
+			lastStatus = update.Status
+		}
+	}
+	fmt.Fprintln(progress)
+	return scanner.Err()
+}
+
+// DeleteModel removes an installed model from the server
+func (s *OllamaService) DeleteModel(ctx context.Context, name string) error {
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.baseURL+"/api/delete", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Ollama server at %s: %w", s.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("model '%s' not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Ollama server returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ModelSupportsTools reports whether a model advertises tool calling.
+// Newer Ollama servers list "tools" in the model's capabilities; older
+// ones are detected by a .Tools reference in the chat template.
+func (s *OllamaService) ModelSupportsTools(ctx context.Context, name string) (bool, error) {
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/api/show", bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to reach Ollama server at %s: %w", s.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("Ollama server returned status %d for model '%s'", resp.StatusCode, name)
+	}
+
+	var payload struct {
+		Capabilities []string `json:"capabilities"`
+		Template     string   `json:"template"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return false, fmt.Errorf("failed to parse model info: %w", err)
+	}
+
+	return ollamaModelSupportsTools(payload.Capabilities, payload.Template), nil
+}
+
+// ollamaModelSupportsTools applies the capability/template heuristics
+func ollamaModelSupportsTools(capabilities []string, template string) bool {
+	for _, capability := range capabilities {
+		if capability == "tools" {
+			return true
+		}
+	}
+	return strings.Contains(template, ".Tools")
+}
+
+// FormatModelSize renders a model size in human-readable units
+func FormatModelSize(sizeBytes int64) string {
+	const gb = 1024 * 1024 * 1024
+	const mb = 1024 * 1024
+	switch {
+	case sizeBytes >= gb:
+		return fmt.Sprintf("%.1f GB", float64(sizeBytes)/gb)
+	case sizeBytes >= mb:
+		return fmt.Sprintf("%.0f MB", float64(sizeBytes)/mb)
+	default:
+		return fmt.Sprintf("%d B", sizeBytes)
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"station/internal/config"
+)
+
+func TestNewOllamaServiceBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{name: "default when unset", baseURL: "", want: "http://localhost:11434"},
+		{name: "strips /v1 suffix", baseURL: "http://localhost:11434/v1", want: "http://localhost:11434"},
+		{name: "strips trailing slash and /v1", baseURL: "http://ollama.local:11434/v1/", want: "http://ollama.local:11434"},
+		{name: "keeps plain URL", baseURL: "http://ollama.local:11434", want: "http://ollama.local:11434"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewOllamaService(&config.Config{AIBaseURL: tt.baseURL})
+			if svc.baseURL != tt.want {
+				t.Errorf("baseURL = %s, want %s", svc.baseURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsOllamaConfigured(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		want bool
+	}{
+		{name: "nil config", cfg: nil, want: false},
+		{name: "no base URL", cfg: &config.Config{}, want: false},
+		{name: "ollama port", cfg: &config.Config{AIBaseURL: "http://localhost:11434/v1"}, want: true},
+		{name: "ollama hostname", cfg: &config.Config{AIBaseURL: "http://ollama.internal:8080/v1"}, want: true},
+		{name: "other endpoint", cfg: &config.Config{AIBaseURL: "https://api.together.xyz/v1"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsOllamaConfigured(tt.cfg); got != tt.want {
+				t.Errorf("IsOllamaConfigured() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOllamaModelSupportsTools(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities []string
+		template     string
+		want         bool
+	}{
+		{name: "tools capability", capabilities: []string{"completion", "tools"}, want: true},
+		{name: "template references tools", template: "{{ if .Tools }}...{{ end }}", want: true},
+		{name: "neither", capabilities: []string{"completion"}, template: "{{ .Prompt }}", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ollamaModelSupportsTools(tt.capabilities, tt.template); got != tt.want {
+				t.Errorf("ollamaModelSupportsTools() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOllamaListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tags":
+			w.Write([]byte(`{"models":[{"name":"qwen2.5:7b","size":4683073184},{"name":"llama2:7b","size":3826793677}]}`))
+		case "/api/show":
+			w.Write([]byte(`{"capabilities":["completion","tools"],"template":""}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	svc := &OllamaService{baseURL: server.URL, client: &http.Client{Timeout: 5 * time.Second}}
+	models, err := svc.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels returned error: %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("expected 2 models, got %d", len(models))
+	}
+	if models[0].Name != "qwen2.5:7b" {
+		t.Errorf("expected first model qwen2.5:7b, got %s", models[0].Name)
+	}
+	if !models[0].SupportsTools {
+		t.Error("expected tool support to be resolved from /api/show")
+	}
+}
+
+func TestOllamaDeleteModelNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	svc := &OllamaService{baseURL: server.URL, client: &http.Client{Timeout: 5 * time.Second}}
+	if err := svc.DeleteModel(context.Background(), "missing:7b"); err == nil {
+		t.Fatal("expected error for missing model")
+	}
+}
+
+func TestFormatModelSize(t *testing.T) {
+	tests := []struct {
+		sizeBytes int64
+		want      string
+	}{
+		{sizeBytes: 4683073184, want: "4.4 GB"},
+		{sizeBytes: 150 * 1024 * 1024, want: "150 MB"},
+		{sizeBytes: 512, want: "512 B"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatModelSize(tt.sizeBytes); got != tt.want {
+			t.Errorf("FormatModelSize(%d) = %s, want %s", tt.sizeBytes, got, tt.want)
+		}
+	}
+}
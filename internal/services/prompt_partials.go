@@ -0,0 +1,241 @@
+package services
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"station/internal/logging"
+)
+
+// PromptPartial is a reusable prompt fragment stored as a .partial file in
+// the environment's prompts/ directory (next to agents/), so bundles carry
+// partials automatically. Agents include partials in their .prompt body with
+// {{> name}} directives, optionally passing key="value" arguments that are
+// interpolated into {{key}} placeholders inside the partial. Sync expands
+// includes before agents are stored, so editing a partial flows to every
+// agent that includes it on the next sync.
+type PromptPartial struct {
+	Name     string
+	Body     string
+	Checksum string
+}
+
+// maxPartialIncludeDepth bounds recursive includes so a partial that
+// (transitively) includes itself fails sync instead of looping forever
+const maxPartialIncludeDepth = 8
+
+// partialsLockFile records the last-synced version and checksum of each
+// partial, letting sync report which partials changed since the previous run
+const partialsLockFile = ".partials.lock"
+
+var (
+	partialIncludePattern = regexp.MustCompile(`\{\{>\s*([A-Za-z0-9_-]+)((?:\s+[A-Za-z0-9_]+="[^"]*")*)\s*\}\}`)
+	partialArgPattern     = regexp.MustCompile(`([A-Za-z0-9_]+)="([^"]*)"`)
+)
+
+// partialLockEntry is one partial's record in the prompts/.partials.lock file
+type partialLockEntry struct {
+	Version  int    `json:"version"`
+	Checksum string `json:"checksum"`
+}
+
+// promptsDirForAgentFile resolves the environment's prompts directory from an
+// agent .prompt file path (agents/ and prompts/ are siblings)
+func promptsDirForAgentFile(agentFilePath string) string {
+	return filepath.Join(filepath.Dir(filepath.Dir(agentFilePath)), "prompts")
+}
+
+// loadPromptPartials loads every .partial file from the environment's
+// prompts directory. A missing directory is not an error — most environments
+// have no partials.
+func loadPromptPartials(promptsDir string) (map[string]*PromptPartial, error) {
+	partials := make(map[string]*PromptPartial)
+
+	partialFiles, err := filepath.Glob(filepath.Join(promptsDir, "*.partial"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan prompts directory: %w", err)
+	}
+
+	for _, partialFile := range partialFiles {
+		content, err := os.ReadFile(partialFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read partial %s: %w", partialFile, err)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(partialFile), ".partial")
+		body := strings.TrimSpace(string(content))
+		partials[name] = &PromptPartial{
+			Name:     name,
+			Body:     body,
+			Checksum: fmt.Sprintf("%x", md5.Sum([]byte(body))),
+		}
+	}
+
+	return partials, nil
+}
+
+// expandPromptPartials replaces {{> name}} include directives in a prompt
+// with the named partial's body, recursively, and returns the expanded prompt
+// plus the names of every partial used (transitively). An unknown partial is
+// an error: a typo should fail sync loudly rather than ship a prompt with a
+// dangling include.
+func expandPromptPartials(prompt string, partials map[string]*PromptPartial) (string, []string, error) {
+	used := make(map[string]bool)
+	expanded, err := expandPartialIncludes(prompt, partials, used, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return expanded, names, nil
+}
+
+func expandPartialIncludes(text string, partials map[string]*PromptPartial, used map[string]bool, depth int) (string, error) {
+	if depth > maxPartialIncludeDepth {
+		return "", fmt.Errorf("prompt partial include depth exceeds %d (include cycle?)", maxPartialIncludeDepth)
+	}
+
+	var expandErr error
+	result := partialIncludePattern.ReplaceAllStringFunc(text, func(directive string) string {
+		if expandErr != nil {
+			return directive
+		}
+
+		match := partialIncludePattern.FindStringSubmatch(directive)
+		name := match[1]
+		partial, ok := partials[name]
+		if !ok {
+			expandErr = fmt.Errorf("prompt partial '%s' not found in prompts/ directory", name)
+			return directive
+		}
+		used[name] = true
+
+		body := partial.Body
+		for _, arg := range partialArgPattern.FindAllStringSubmatch(match[2], -1) {
+			body = strings.ReplaceAll(body, "{{"+arg[1]+"}}", arg[2])
+		}
+
+		nested, err := expandPartialIncludes(body, partials, used, depth+1)
+		if err != nil {
+			expandErr = err
+			return directive
+		}
+		return nested
+	})
+
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return result, nil
+}
+
+// syncPromptPartials compares the environment's partials against the
+// prompts/.partials.lock file, bumps versions for new or changed partials,
+// and reports which agents include each changed partial so the operator can
+// see the blast radius before agents are updated. The lock file is written
+// back unless this is a dry run.
+func (s *DeclarativeSync) syncPromptPartials(agentsDir string, promptFiles []string, dryRun bool) error {
+	promptsDir := filepath.Join(filepath.Dir(agentsDir), "prompts")
+	partials, err := loadPromptPartials(promptsDir)
+	if err != nil {
+		return err
+	}
+	if len(partials) == 0 {
+		return nil
+	}
+
+	lock, err := readPartialsLock(promptsDir)
+	if err != nil {
+		return err
+	}
+
+	// Map each partial to the agents that (transitively) include it
+	affectedBy := make(map[string][]string)
+	for _, promptFile := range promptFiles {
+		content, err := os.ReadFile(promptFile)
+		if err != nil {
+			continue
+		}
+		_, usedNames, err := expandPromptPartials(string(content), partials)
+		if err != nil {
+			// Unknown partials surface per-agent during syncSingleAgent
+			continue
+		}
+		agentName := strings.TrimSuffix(filepath.Base(promptFile), ".prompt")
+		for _, name := range usedNames {
+			affectedBy[name] = append(affectedBy[name], agentName)
+		}
+	}
+
+	names := make([]string, 0, len(partials))
+	for name := range partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	updatedLock := make(map[string]partialLockEntry, len(partials))
+	for _, name := range names {
+		partial := partials[name]
+		previous, known := lock[name]
+
+		switch {
+		case !known:
+			updatedLock[name] = partialLockEntry{Version: 1, Checksum: partial.Checksum}
+			logging.Info("📝 New prompt partial '%s' (v1), included by: %s", name, formatAffectedAgents(affectedBy[name]))
+		case previous.Checksum != partial.Checksum:
+			updatedLock[name] = partialLockEntry{Version: previous.Version + 1, Checksum: partial.Checksum}
+			logging.Info("📝 Prompt partial '%s' changed (v%d → v%d), affects: %s", name, previous.Version, previous.Version+1, formatAffectedAgents(affectedBy[name]))
+		default:
+			updatedLock[name] = previous
+		}
+	}
+
+	if dryRun {
+		return nil
+	}
+	return writePartialsLock(promptsDir, updatedLock)
+}
+
+func formatAffectedAgents(agents []string) string {
+	if len(agents) == 0 {
+		return "no agents"
+	}
+	sort.Strings(agents)
+	return strings.Join(agents, ", ")
+}
+
+func readPartialsLock(promptsDir string) (map[string]partialLockEntry, error) {
+	lock := make(map[string]partialLockEntry)
+	content, err := os.ReadFile(filepath.Join(promptsDir, partialsLockFile))
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partials lock file: %w", err)
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse partials lock file: %w", err)
+	}
+	return lock, nil
+}
+
+func writePartialsLock(promptsDir string, lock map[string]partialLockEntry) error {
+	content, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode partials lock file: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, partialsLockFile), content, 0644); err != nil {
+		return fmt.Errorf("failed to write partials lock file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePartialFixtures(t *testing.T, partials map[string]string) string {
+	t.Helper()
+	envDir := t.TempDir()
+	promptsDir := filepath.Join(envDir, "prompts")
+	if err := os.MkdirAll(promptsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range partials {
+		if err := os.WriteFile(filepath.Join(promptsDir, name+".partial"), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return promptsDir
+}
+
+func TestLoadPromptPartials(t *testing.T) {
+	promptsDir := writePartialFixtures(t, map[string]string{
+		"security-preamble": "Always report findings by severity.",
+		"output-format":     "Respond in markdown with a Findings section.",
+	})
+
+	partials, err := loadPromptPartials(promptsDir)
+	if err != nil {
+		t.Fatalf("loadPromptPartials failed: %v", err)
+	}
+	if len(partials) != 2 {
+		t.Fatalf("Expected 2 partials, got %d", len(partials))
+	}
+	if partials["security-preamble"].Body != "Always report findings by severity." {
+		t.Errorf("Unexpected body: %q", partials["security-preamble"].Body)
+	}
+	if partials["security-preamble"].Checksum == "" {
+		t.Error("Expected checksum to be populated")
+	}
+}
+
+func TestLoadPromptPartialsMissingDirectory(t *testing.T) {
+	partials, err := loadPromptPartials(filepath.Join(t.TempDir(), "prompts"))
+	if err != nil {
+		t.Fatalf("Expected missing prompts dir to be tolerated, got: %v", err)
+	}
+	if len(partials) != 0 {
+		t.Errorf("Expected no partials, got %d", len(partials))
+	}
+}
+
+func TestExpandPromptPartials(t *testing.T) {
+	promptsDir := writePartialFixtures(t, map[string]string{
+		"preamble": "You scan {{target}} for issues.",
+	})
+	partials, err := loadPromptPartials(promptsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expanded, used, err := expandPromptPartials(`{{role "system"}}
+{{> preamble target="terraform"}}
+Focus on critical findings.`, partials)
+	if err != nil {
+		t.Fatalf("expandPromptPartials failed: %v", err)
+	}
+	if !strings.Contains(expanded, "You scan terraform for issues.") {
+		t.Errorf("Expected interpolated partial body, got %q", expanded)
+	}
+	if strings.Contains(expanded, "{{>") {
+		t.Errorf("Expected include directive to be replaced, got %q", expanded)
+	}
+	if len(used) != 1 || used[0] != "preamble" {
+		t.Errorf("Unexpected used partials: %v", used)
+	}
+}
+
+func TestExpandPromptPartialsNested(t *testing.T) {
+	promptsDir := writePartialFixtures(t, map[string]string{
+		"outer": "Outer section.\n{{> inner}}",
+		"inner": "Inner section.",
+	})
+	partials, err := loadPromptPartials(promptsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expanded, used, err := expandPromptPartials("{{> outer}}", partials)
+	if err != nil {
+		t.Fatalf("expandPromptPartials failed: %v", err)
+	}
+	if !strings.Contains(expanded, "Inner section.") {
+		t.Errorf("Expected nested partial expanded, got %q", expanded)
+	}
+	if len(used) != 2 {
+		t.Errorf("Expected both partials reported as used, got %v", used)
+	}
+}
+
+func TestExpandPromptPartialsUnknownPartial(t *testing.T) {
+	_, _, err := expandPromptPartials("{{> does-not-exist}}", map[string]*PromptPartial{})
+	if err == nil {
+		t.Fatal("Expected error for unknown partial")
+	}
+	if !strings.Contains(err.Error(), "does-not-exist") {
+		t.Errorf("Expected error to name the missing partial, got: %v", err)
+	}
+}
+
+func TestExpandPromptPartialsCycle(t *testing.T) {
+	promptsDir := writePartialFixtures(t, map[string]string{
+		"a": "{{> b}}",
+		"b": "{{> a}}",
+	})
+	partials, err := loadPromptPartials(promptsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := expandPromptPartials("{{> a}}", partials); err == nil {
+		t.Fatal("Expected error for include cycle")
+	}
+}
+
+func TestPartialsLockVersioning(t *testing.T) {
+	promptsDir := writePartialFixtures(t, map[string]string{
+		"preamble": "Original body.",
+	})
+	agentsDir := filepath.Join(filepath.Dir(promptsDir), "agents")
+	if err := os.MkdirAll(agentsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	agentFile := filepath.Join(agentsDir, "scanner.prompt")
+	if err := os.WriteFile(agentFile, []byte("{{> preamble}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &DeclarativeSync{}
+	if err := s.syncPromptPartials(agentsDir, []string{agentFile}, false); err != nil {
+		t.Fatalf("syncPromptPartials failed: %v", err)
+	}
+
+	lock, err := readPartialsLock(promptsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock["preamble"].Version != 1 {
+		t.Errorf("Expected version 1 for new partial, got %d", lock["preamble"].Version)
+	}
+
+	// Edit the partial and resync: version bumps
+	if err := os.WriteFile(filepath.Join(promptsDir, "preamble.partial"), []byte("Edited body."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.syncPromptPartials(agentsDir, []string{agentFile}, false); err != nil {
+		t.Fatalf("syncPromptPartials failed: %v", err)
+	}
+	lock, err = readPartialsLock(promptsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock["preamble"].Version != 2 {
+		t.Errorf("Expected version 2 after edit, got %d", lock["preamble"].Version)
+	}
+
+	// Unchanged resync keeps the version
+	if err := s.syncPromptPartials(agentsDir, []string{agentFile}, false); err != nil {
+		t.Fatalf("syncPromptPartials failed: %v", err)
+	}
+	lock, err = readPartialsLock(promptsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock["preamble"].Version != 2 {
+		t.Errorf("Expected version to stay at 2, got %d", lock["preamble"].Version)
+	}
+}
+
+func TestSyncPromptPartialsDryRunDoesNotWriteLock(t *testing.T) {
+	promptsDir := writePartialFixtures(t, map[string]string{
+		"preamble": "Body.",
+	})
+	agentsDir := filepath.Join(filepath.Dir(promptsDir), "agents")
+	if err := os.MkdirAll(agentsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &DeclarativeSync{}
+	if err := s.syncPromptPartials(agentsDir, nil, true); err != nil {
+		t.Fatalf("syncPromptPartials failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, partialsLockFile)); !os.IsNotExist(err) {
+		t.Error("Expected no lock file after dry run")
+	}
+}
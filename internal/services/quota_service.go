@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+	"station/pkg/models"
+)
+
+// orgQuotaSettingKey stores the org-level quota pushed from CloudShip
+// Lighthouse as JSON in the settings table
+const orgQuotaSettingKey = "cloudship_org_quota"
+
+// orgQuotaPushedSettingName is the key CloudShip uses when pushing the quota
+// document inside the registration response's settings map
+const orgQuotaPushedSettingName = "org_quota"
+
+// ErrQuotaExceeded wraps every quota rejection so callers can distinguish
+// quota errors from execution failures
+var ErrQuotaExceeded = errors.New("org quota exceeded")
+
+// OrgQuota is the org-level quota CloudShip Lighthouse pushes to registered
+// Stations. Zero limits mean unlimited; an empty AllowedModels list permits
+// any model. Quotas are enforced locally in the run queue so a Station
+// rejects over-quota runs with a clear error instead of only reporting usage
+// upstream.
+type OrgQuota struct {
+	MaxRunsPerDay   int64     `json:"max_runs_per_day"`
+	MaxTokensPerDay int64     `json:"max_tokens_per_day"`
+	AllowedModels   []string  `json:"allowed_models,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// QuotaUsage is the current day's consumption against the org quota. The
+// day window resets at midnight UTC to match CloudShip's accounting.
+type QuotaUsage struct {
+	RunsToday   int64 `json:"runs_today"`
+	TokensToday int64 `json:"tokens_today"`
+}
+
+// QuotaService enforces org-level quotas pushed from CloudShip Lighthouse
+type QuotaService struct {
+	repos *repositories.Repositories
+}
+
+// NewQuotaService creates a new quota service
+func NewQuotaService(repos *repositories.Repositories) *QuotaService {
+	return &QuotaService{repos: repos}
+}
+
+// ApplyPushedSettings applies org settings pushed by CloudShip at
+// registration, persisting the quota document so enforcement survives
+// restarts and works while temporarily disconnected
+func (s *QuotaService) ApplyPushedSettings(settings map[string]string) {
+	quotaJSON, ok := settings[orgQuotaPushedSettingName]
+	if !ok || quotaJSON == "" {
+		return
+	}
+
+	var quota OrgQuota
+	if err := json.Unmarshal([]byte(quotaJSON), &quota); err != nil {
+		logging.Info("Warning: Ignoring malformed org quota pushed from CloudShip: %v", err)
+		return
+	}
+	if err := s.ApplyPushedQuota(&quota); err != nil {
+		logging.Info("Warning: Failed to store org quota pushed from CloudShip: %v", err)
+		return
+	}
+	logging.Info("☁️ Applied org quota from CloudShip: %s", describeQuota(&quota))
+}
+
+// ApplyPushedQuota persists an org quota pushed from Lighthouse
+func (s *QuotaService) ApplyPushedQuota(quota *OrgQuota) error {
+	if quota.UpdatedAt.IsZero() {
+		quota.UpdatedAt = time.Now().UTC()
+	}
+	quotaJSON, err := json.Marshal(quota)
+	if err != nil {
+		return fmt.Errorf("failed to encode org quota: %w", err)
+	}
+	return s.repos.Settings.Set(orgQuotaSettingKey, string(quotaJSON), "Org-level quota pushed from CloudShip Lighthouse")
+}
+
+// GetQuota returns the stored org quota, or nil when CloudShip has not
+// pushed one (unregistered Stations run unrestricted)
+func (s *QuotaService) GetQuota() (*OrgQuota, error) {
+	setting, err := s.repos.Settings.GetByKey(orgQuotaSettingKey)
+	if err != nil || setting == nil || setting.Value == "" {
+		return nil, nil
+	}
+
+	var quota OrgQuota
+	if err := json.Unmarshal([]byte(setting.Value), &quota); err != nil {
+		return nil, fmt.Errorf("failed to parse stored org quota: %w", err)
+	}
+	return &quota, nil
+}
+
+// UsageToday returns runs and tokens consumed since midnight UTC
+func (s *QuotaService) UsageToday(ctx context.Context) (*QuotaUsage, error) {
+	runs, tokens, err := s.repos.AgentRuns.UsageSince(ctx, startOfDayUTC(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load today's usage: %w", err)
+	}
+	return &QuotaUsage{RunsToday: runs, TokensToday: tokens}, nil
+}
+
+// CheckRun rejects a run that would exceed the org quota. Returns nil when
+// no quota has been pushed or the run fits within the remaining budget.
+func (s *QuotaService) CheckRun(ctx context.Context, agentID int64) error {
+	quota, err := s.GetQuota()
+	if err != nil {
+		return err
+	}
+	if quota == nil {
+		return nil
+	}
+
+	if len(quota.AllowedModels) > 0 {
+		model := s.resolveAgentModel(agentID)
+		if model != "" && !modelAllowed(model, quota.AllowedModels) {
+			return fmt.Errorf("%w: model %q is not in the org's allowed models (%s); update the agent's model or the org quota in CloudShip",
+				ErrQuotaExceeded, model, strings.Join(quota.AllowedModels, ", "))
+		}
+	}
+
+	if quota.MaxRunsPerDay <= 0 && quota.MaxTokensPerDay <= 0 {
+		return nil
+	}
+
+	usage, err := s.UsageToday(ctx)
+	if err != nil {
+		return err
+	}
+
+	if quota.MaxRunsPerDay > 0 && usage.RunsToday >= quota.MaxRunsPerDay {
+		return fmt.Errorf("%w: daily run limit reached (%d/%d runs since midnight UTC); the window resets at 00:00 UTC",
+			ErrQuotaExceeded, usage.RunsToday, quota.MaxRunsPerDay)
+	}
+	if quota.MaxTokensPerDay > 0 && usage.TokensToday >= quota.MaxTokensPerDay {
+		return fmt.Errorf("%w: daily token ceiling reached (%d/%d tokens since midnight UTC); the window resets at 00:00 UTC",
+			ErrQuotaExceeded, usage.TokensToday, quota.MaxTokensPerDay)
+	}
+	return nil
+}
+
+// resolveAgentModel reads the agent's `model` frontmatter value from its
+// .prompt file. Empty when the agent or its file cannot be resolved; model
+// checks are then skipped rather than failing the run.
+func (s *QuotaService) resolveAgentModel(agentID int64) string {
+	agent, err := s.repos.Agents.GetByID(agentID)
+	if err != nil {
+		return ""
+	}
+	env, err := s.repos.Environments.GetByID(agent.EnvironmentID)
+	if err != nil {
+		return ""
+	}
+	return loadAgentModel(agent, env.Name)
+}
+
+// loadAgentModel reads the `model` frontmatter value from the agent's
+// .prompt file, mirroring loadAgentTimeout
+func loadAgentModel(agent *models.Agent, environmentName string) string {
+	environmentDir := config.GetEnvironmentDir(environmentName)
+	promptFilePath := filepath.Join(environmentDir, "agents", agent.Name+".prompt")
+
+	content, err := os.ReadFile(promptFilePath)
+	if err != nil {
+		return ""
+	}
+
+	parts := strings.Split(string(content), "---")
+	if len(parts) < 3 {
+		return ""
+	}
+	var frontmatter struct {
+		Model string `yaml:"model"`
+	}
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(parts[1])), &frontmatter); err != nil {
+		return ""
+	}
+	return frontmatter.Model
+}
+
+// modelAllowed checks a model name against the org's allowed list
+func modelAllowed(model string, allowed []string) bool {
+	for _, candidate := range allowed {
+		if strings.EqualFold(candidate, model) {
+			return true
+		}
+	}
+	return false
+}
+
+// startOfDayUTC truncates a time to midnight UTC, the quota window boundary
+func startOfDayUTC(t time.Time) time.Time {
+	utc := t.UTC()
+	return time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// describeQuota renders a quota for log and status output
+func describeQuota(quota *OrgQuota) string {
+	var parts []string
+	if quota.MaxRunsPerDay > 0 {
+		parts = append(parts, fmt.Sprintf("%d runs/day", quota.MaxRunsPerDay))
+	}
+	if quota.MaxTokensPerDay > 0 {
+		parts = append(parts, fmt.Sprintf("%d tokens/day", quota.MaxTokensPerDay))
+	}
+	if len(quota.AllowedModels) > 0 {
+		parts = append(parts, fmt.Sprintf("models: %s", strings.Join(quota.AllowedModels, ", ")))
+	}
+	if len(parts) == 0 {
+		return "no limits"
+	}
+	return strings.Join(parts, ", ")
+}
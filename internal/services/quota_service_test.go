@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func setupQuotaTest(t *testing.T) (*QuotaService, *repositories.Repositories, int64) {
+	t.Helper()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("quota-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("quota-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Quota Agent", "quota target", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	return NewQuotaService(repos), repos, agent.ID
+}
+
+func TestCheckRunWithoutQuota(t *testing.T) {
+	svc, _, agentID := setupQuotaTest(t)
+
+	if err := svc.CheckRun(context.Background(), agentID); err != nil {
+		t.Errorf("Expected no error without a pushed quota, got %v", err)
+	}
+}
+
+func TestApplyPushedSettingsAndGetQuota(t *testing.T) {
+	svc, _, _ := setupQuotaTest(t)
+
+	svc.ApplyPushedSettings(map[string]string{
+		"org_quota": `{"max_runs_per_day": 100, "max_tokens_per_day": 500000, "allowed_models": ["gpt-4o-mini"]}`,
+	})
+
+	quota, err := svc.GetQuota()
+	if err != nil {
+		t.Fatalf("GetQuota failed: %v", err)
+	}
+	if quota == nil {
+		t.Fatal("Expected stored quota after push")
+	}
+	if quota.MaxRunsPerDay != 100 || quota.MaxTokensPerDay != 500000 {
+		t.Errorf("Unexpected limits: %+v", quota)
+	}
+	if len(quota.AllowedModels) != 1 || quota.AllowedModels[0] != "gpt-4o-mini" {
+		t.Errorf("Unexpected allowed models: %v", quota.AllowedModels)
+	}
+}
+
+func TestApplyPushedSettingsIgnoresMalformedQuota(t *testing.T) {
+	svc, _, _ := setupQuotaTest(t)
+
+	svc.ApplyPushedSettings(map[string]string{"org_quota": "{not json"})
+
+	quota, err := svc.GetQuota()
+	if err != nil {
+		t.Fatalf("GetQuota failed: %v", err)
+	}
+	if quota != nil {
+		t.Errorf("Expected malformed quota to be ignored, got %+v", quota)
+	}
+}
+
+func TestCheckRunDailyRunLimit(t *testing.T) {
+	svc, repos, agentID := setupQuotaTest(t)
+	ctx := context.Background()
+
+	if err := svc.ApplyPushedQuota(&OrgQuota{MaxRunsPerDay: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := repos.AgentRuns.Create(ctx, agentID, 1, "task", "", 0, nil, nil, "completed", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := svc.CheckRun(ctx, agentID)
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Expected ErrQuotaExceeded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "2/2 runs") {
+		t.Errorf("Expected clear usage in error, got: %v", err)
+	}
+}
+
+func TestCheckRunUnderLimit(t *testing.T) {
+	svc, repos, agentID := setupQuotaTest(t)
+	ctx := context.Background()
+
+	if err := svc.ApplyPushedQuota(&OrgQuota{MaxRunsPerDay: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repos.AgentRuns.Create(ctx, agentID, 1, "task", "", 0, nil, nil, "completed", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.CheckRun(ctx, agentID); err != nil {
+		t.Errorf("Expected run under limit to pass, got %v", err)
+	}
+}
+
+func TestModelAllowed(t *testing.T) {
+	allowed := []string{"gpt-4o-mini", "gemini-2.0-flash"}
+
+	if !modelAllowed("GPT-4o-Mini", allowed) {
+		t.Error("Expected case-insensitive model match")
+	}
+	if modelAllowed("o3-pro", allowed) {
+		t.Error("Expected unlisted model to be rejected")
+	}
+}
+
+func TestStartOfDayUTC(t *testing.T) {
+	ts := time.Date(2026, 8, 29, 17, 45, 12, 0, time.UTC)
+	start := startOfDayUTC(ts)
+	if start.Hour() != 0 || start.Minute() != 0 || start.Day() != 29 {
+		t.Errorf("Unexpected day start: %v", start)
+	}
+}
+
+func TestDescribeQuota(t *testing.T) {
+	desc := describeQuota(&OrgQuota{MaxRunsPerDay: 10, AllowedModels: []string{"gpt-4o-mini"}})
+	if !strings.Contains(desc, "10 runs/day") || !strings.Contains(desc, "gpt-4o-mini") {
+		t.Errorf("Unexpected description: %q", desc)
+	}
+	if describeQuota(&OrgQuota{}) != "no limits" {
+		t.Errorf("Expected 'no limits' for empty quota")
+	}
+}
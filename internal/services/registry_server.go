@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"station/internal/logging"
+)
+
+const (
+	// How long an S3 mirror sync (aws subprocess) may take after a publish
+	registryS3SyncTimeout = 5 * time.Minute
+
+	registryBundlesSubdir   = "bundles"
+	registryManifestsSubdir = "manifests"
+	registryDownloadsFile   = "downloads.json"
+)
+
+// RegistryServerOptions configures a self-hosted bundle registry
+type RegistryServerOptions struct {
+	Port       int
+	StorageDir string // bundles/ and manifests/ live under this directory
+	Token      string // required for publish when set; empty disables auth
+	S3Bucket   string // optional s3://bucket/prefix mirrored after each publish
+}
+
+// RegistryServer is a lightweight bundle registry compatible with the
+// registry client (stn bundle search/info/install): it serves /index.json,
+// bundle downloads, and manifests, and accepts authenticated publishes.
+// Enterprises can point private registries at it via the bundle.registries
+// setting.
+type RegistryServer struct {
+	opts RegistryServerOptions
+
+	// Guards the download counter file
+	mu sync.Mutex
+}
+
+// NewRegistryServer creates a registry server over the given storage directory
+func NewRegistryServer(opts RegistryServerOptions) (*RegistryServer, error) {
+	if opts.StorageDir == "" {
+		return nil, fmt.Errorf("storage directory is required")
+	}
+	for _, subdir := range []string{registryBundlesSubdir, registryManifestsSubdir} {
+		if err := os.MkdirAll(filepath.Join(opts.StorageDir, subdir), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create registry storage: %w", err)
+		}
+	}
+	return &RegistryServer{opts: opts}, nil
+}
+
+// Router builds the gin router (exposed separately from Serve for tests)
+func (s *RegistryServer) Router() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/index.json", s.handleIndex)
+	router.GET("/bundles/:filename", s.handleDownload)
+	router.GET("/manifests/:filename", s.handleManifest)
+	router.POST("/api/bundles", s.requirePublishToken, s.handlePublish)
+
+	return router
+}
+
+// Serve runs the registry server until the context is canceled
+func (s *RegistryServer) Serve(ctx context.Context) error {
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", s.opts.Port),
+		Handler: s.Router(),
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- err
+		}
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// requirePublishToken rejects publishes without the configured token.
+// Accepts both Authorization: Bearer <token> and X-Registry-Token headers.
+func (s *RegistryServer) requirePublishToken(c *gin.Context) {
+	if s.opts.Token == "" {
+		c.Next()
+		return
+	}
+
+	provided := c.GetHeader("X-Registry-Token")
+	if provided == "" {
+		provided = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	}
+	if provided != s.opts.Token {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or missing registry token"})
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// handleIndex serves the registry index in the format the bundle registry
+// client consumes, built from the bundles on disk
+func (s *RegistryServer) handleIndex(c *gin.Context) {
+	bundles, err := s.buildIndex(c.Request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"bundles": bundles})
+}
+
+// handleDownload serves a bundle archive and increments its download counter
+func (s *RegistryServer) handleDownload(c *gin.Context) {
+	filename := filepath.Base(c.Param("filename"))
+	if !strings.HasSuffix(filename, ".tar.gz") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bundle downloads must end in .tar.gz"})
+		return
+	}
+
+	path := filepath.Join(s.opts.StorageDir, registryBundlesSubdir, filename)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "bundle not found"})
+		return
+	}
+
+	s.incrementDownloads(strings.TrimSuffix(filename, ".tar.gz"))
+	c.FileAttachment(path, filename)
+}
+
+// handleManifest serves a bundle's metadata manifest
+func (s *RegistryServer) handleManifest(c *gin.Context) {
+	filename := filepath.Base(c.Param("filename"))
+	if !strings.HasSuffix(filename, ".json") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "manifests must end in .json"})
+		return
+	}
+
+	path := filepath.Join(s.opts.StorageDir, registryManifestsSubdir, filename)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "manifest not found"})
+		return
+	}
+	c.File(path)
+}
+
+// handlePublish accepts a multipart publish: a required "bundle" .tar.gz
+// part and an optional "manifest" JSON part with name/description/version
+func (s *RegistryServer) handlePublish(c *gin.Context) {
+	bundleFile, header, err := c.Request.FormFile("bundle")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing bundle file (multipart field 'bundle')"})
+		return
+	}
+	defer bundleFile.Close()
+
+	name := c.PostForm("name")
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(header.Filename), ".tar.gz")
+	}
+	if name == "" || strings.ContainsAny(name, "/\\") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid bundle name"})
+		return
+	}
+
+	bundlePath := filepath.Join(s.opts.StorageDir, registryBundlesSubdir, name+".tar.gz")
+	out, err := os.Create(bundlePath)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store bundle"})
+		return
+	}
+	size, err := io.Copy(out, bundleFile)
+	out.Close()
+	if err != nil {
+		os.Remove(bundlePath)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store bundle"})
+		return
+	}
+
+	if manifestFile, _, err := c.Request.FormFile("manifest"); err == nil {
+		defer manifestFile.Close()
+		manifestData, err := io.ReadAll(manifestFile)
+		if err == nil && json.Valid(manifestData) {
+			manifestPath := filepath.Join(s.opts.StorageDir, registryManifestsSubdir, name+".json")
+			if err := os.WriteFile(manifestPath, manifestData, 0644); err != nil {
+				logging.Info("Registry: failed to store manifest for %s: %v", name, err)
+			}
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "manifest must be valid JSON"})
+			return
+		}
+	}
+
+	if s.opts.S3Bucket != "" {
+		if err := s.syncToS3(); err != nil {
+			logging.Info("Registry: S3 sync failed after publishing %s: %v", name, err)
+		}
+	}
+
+	logging.Info("Registry: published bundle %s (%d bytes)", name, size)
+	c.JSON(http.StatusCreated, gin.H{
+		"name": name,
+		"size": size,
+		"url":  "/bundles/" + name + ".tar.gz",
+	})
+}
+
+// registryIndexEntry mirrors the fields the bundle registry client reads
+type registryIndexEntry struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Version     string   `json:"version"`
+	Author      string   `json:"author"`
+	Tags        []string `json:"tags,omitempty"`
+	Downloads   int64    `json:"downloads"`
+	DownloadURL string   `json:"download_url"`
+	MetadataURL string   `json:"metadata_url,omitempty"`
+}
+
+// buildIndex scans the storage directory and assembles the index, enriching
+// entries from their manifests when present
+func (s *RegistryServer) buildIndex(req *http.Request) ([]registryIndexEntry, error) {
+	entries, err := os.ReadDir(filepath.Join(s.opts.StorageDir, registryBundlesSubdir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle storage: %w", err)
+	}
+
+	baseURL := registryBaseURL(req)
+	downloads := s.loadDownloads()
+
+	var bundles []registryIndexEntry
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tar.gz") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".tar.gz")
+
+		bundle := registryIndexEntry{
+			ID:          name,
+			Name:        name,
+			Downloads:   downloads[name],
+			DownloadURL: baseURL + "/bundles/" + name + ".tar.gz",
+		}
+
+		manifestPath := filepath.Join(s.opts.StorageDir, registryManifestsSubdir, name+".json")
+		if data, err := os.ReadFile(manifestPath); err == nil {
+			var manifest struct {
+				Name        string   `json:"name"`
+				Description string   `json:"description"`
+				Version     string   `json:"version"`
+				Author      string   `json:"author"`
+				Tags        []string `json:"tags"`
+			}
+			if err := json.Unmarshal(data, &manifest); err == nil {
+				if manifest.Name != "" {
+					bundle.Name = manifest.Name
+				}
+				bundle.Description = manifest.Description
+				bundle.Version = manifest.Version
+				bundle.Author = manifest.Author
+				bundle.Tags = manifest.Tags
+			}
+			bundle.MetadataURL = baseURL + "/manifests/" + name + ".json"
+		}
+
+		bundles = append(bundles, bundle)
+	}
+
+	sort.Slice(bundles, func(i, j int) bool { return bundles[i].ID < bundles[j].ID })
+	return bundles, nil
+}
+
+// registryBaseURL reconstructs the externally visible base URL so index
+// download links work from wherever the client is connecting
+func registryBaseURL(req *http.Request) string {
+	scheme := "http"
+	if req.TLS != nil || req.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + req.Host
+}
+
+// loadDownloads reads the per-bundle download counters
+func (s *RegistryServer) loadDownloads() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[string]int64)
+	data, err := os.ReadFile(filepath.Join(s.opts.StorageDir, registryDownloadsFile))
+	if err != nil {
+		return counts
+	}
+	_ = json.Unmarshal(data, &counts)
+	return counts
+}
+
+// incrementDownloads bumps a bundle's download counter on disk
+func (s *RegistryServer) incrementDownloads(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(s.opts.StorageDir, registryDownloadsFile)
+	counts := make(map[string]int64)
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &counts)
+	}
+	counts[name]++
+
+	if data, err := json.Marshal(counts); err == nil {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			logging.Info("Registry: failed to persist download counts: %v", err)
+		}
+	}
+}
+
+// syncToS3 mirrors the storage directory to the configured bucket via the
+// aws CLI, matching how run exports push to buckets
+func (s *RegistryServer) syncToS3() error {
+	ctx, cancel := context.WithTimeout(context.Background(), registryS3SyncTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "aws", "s3", "sync", s.opts.StorageDir, s.opts.S3Bucket)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("aws s3 sync failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
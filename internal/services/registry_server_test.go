@@ -0,0 +1,236 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestRegistryServer(t *testing.T, token string) (*RegistryServer, *httptest.Server) {
+	t.Helper()
+	server, err := NewRegistryServer(RegistryServerOptions{
+		StorageDir: t.TempDir(),
+		Token:      token,
+	})
+	if err != nil {
+		t.Fatalf("NewRegistryServer returned error: %v", err)
+	}
+	ts := httptest.NewServer(server.Router())
+	t.Cleanup(ts.Close)
+	return server, ts
+}
+
+func publishTestBundle(t *testing.T, ts *httptest.Server, token, name string, manifest string) *http.Response {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("bundle", name+".tar.gz")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	part.Write([]byte("fake-tar-gz-content"))
+
+	if manifest != "" {
+		manifestPart, err := writer.CreateFormFile("manifest", name+".json")
+		if err != nil {
+			t.Fatalf("failed to create manifest part: %v", err)
+		}
+		manifestPart.Write([]byte(manifest))
+	}
+	writer.Close()
+
+	req, err := http.NewRequest("POST", ts.URL+"/api/bundles", body)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if token != "" {
+		req.Header.Set("X-Registry-Token", token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("publish request failed: %v", err)
+	}
+	return resp
+}
+
+func TestRegistryServerPublishAndIndex(t *testing.T) {
+	_, ts := newTestRegistryServer(t, "")
+
+	manifest := `{"name": "Security Bundle", "description": "scanning", "version": "1.0.0", "tags": ["security"]}`
+	resp := publishTestBundle(t, ts, "", "security-bundle", manifest)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 201, got %d: %s", resp.StatusCode, body)
+	}
+
+	indexResp, err := http.Get(ts.URL + "/index.json")
+	if err != nil {
+		t.Fatalf("index request failed: %v", err)
+	}
+	defer indexResp.Body.Close()
+
+	var index struct {
+		Bundles []registryIndexEntry `json:"bundles"`
+	}
+	if err := json.NewDecoder(indexResp.Body).Decode(&index); err != nil {
+		t.Fatalf("failed to decode index: %v", err)
+	}
+	if len(index.Bundles) != 1 {
+		t.Fatalf("expected 1 bundle in index, got %d", len(index.Bundles))
+	}
+	bundle := index.Bundles[0]
+	if bundle.ID != "security-bundle" {
+		t.Errorf("expected ID security-bundle, got %s", bundle.ID)
+	}
+	if bundle.Name != "Security Bundle" || bundle.Version != "1.0.0" {
+		t.Errorf("expected manifest metadata in index, got %+v", bundle)
+	}
+	if bundle.DownloadURL != ts.URL+"/bundles/security-bundle.tar.gz" {
+		t.Errorf("unexpected download URL: %s", bundle.DownloadURL)
+	}
+	if bundle.MetadataURL != ts.URL+"/manifests/security-bundle.json" {
+		t.Errorf("unexpected metadata URL: %s", bundle.MetadataURL)
+	}
+}
+
+func TestRegistryServerTokenAuth(t *testing.T) {
+	_, ts := newTestRegistryServer(t, "secret-token")
+
+	// Missing token is rejected
+	resp := publishTestBundle(t, ts, "", "private-bundle", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 without token, got %d", resp.StatusCode)
+	}
+
+	// Wrong token is rejected
+	resp = publishTestBundle(t, ts, "wrong", "private-bundle", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 with wrong token, got %d", resp.StatusCode)
+	}
+
+	// Correct token succeeds
+	resp = publishTestBundle(t, ts, "secret-token", "private-bundle", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("expected 201 with correct token, got %d", resp.StatusCode)
+	}
+
+	// Reads stay open — downloads are not gated by the publish token
+	indexResp, err := http.Get(ts.URL + "/index.json")
+	if err != nil {
+		t.Fatalf("index request failed: %v", err)
+	}
+	indexResp.Body.Close()
+	if indexResp.StatusCode != http.StatusOK {
+		t.Errorf("expected open index access, got %d", indexResp.StatusCode)
+	}
+}
+
+func TestRegistryServerDownloadCounting(t *testing.T) {
+	_, ts := newTestRegistryServer(t, "")
+
+	resp := publishTestBundle(t, ts, "", "counted-bundle", "")
+	resp.Body.Close()
+
+	for i := 0; i < 3; i++ {
+		dlResp, err := http.Get(ts.URL + "/bundles/counted-bundle.tar.gz")
+		if err != nil {
+			t.Fatalf("download failed: %v", err)
+		}
+		io.Copy(io.Discard, dlResp.Body)
+		dlResp.Body.Close()
+		if dlResp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200 download, got %d", dlResp.StatusCode)
+		}
+	}
+
+	indexResp, err := http.Get(ts.URL + "/index.json")
+	if err != nil {
+		t.Fatalf("index request failed: %v", err)
+	}
+	defer indexResp.Body.Close()
+	var index struct {
+		Bundles []registryIndexEntry `json:"bundles"`
+	}
+	json.NewDecoder(indexResp.Body).Decode(&index)
+	if len(index.Bundles) != 1 || index.Bundles[0].Downloads != 3 {
+		t.Errorf("expected 3 downloads recorded, got %+v", index.Bundles)
+	}
+
+	// Missing bundles 404
+	missingResp, err := http.Get(ts.URL + "/bundles/nope.tar.gz")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	missingResp.Body.Close()
+	if missingResp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for missing bundle, got %d", missingResp.StatusCode)
+	}
+}
+
+func TestRegistryServerCompatibleWithRegistryClient(t *testing.T) {
+	_, ts := newTestRegistryServer(t, "")
+
+	manifest := `{"name": "Client Bundle", "description": "end to end", "version": "2.0.0", "variables": {"PROJECT_ROOT": {"type": "string", "required": true}}}`
+	resp := publishTestBundle(t, ts, "", "client-bundle", manifest)
+	resp.Body.Close()
+
+	// The bundle registry client should be able to search and resolve
+	// bundles served by this registry
+	svc, _ := setupRegistryService(t, ts.URL)
+	ctx := context.Background()
+
+	bundles, err := svc.Search(ctx, "client")
+	if err != nil {
+		t.Fatalf("Search against registry server failed: %v", err)
+	}
+	if len(bundles) != 1 || bundles[0].Name != "Client Bundle" {
+		t.Fatalf("expected client bundle via search, got %+v", bundles)
+	}
+
+	details, err := svc.Info(ctx, "client-bundle")
+	if err != nil {
+		t.Fatalf("Info against registry server failed: %v", err)
+	}
+	if _, ok := details.Variables["PROJECT_ROOT"]; !ok {
+		t.Errorf("expected PROJECT_ROOT variable from manifest, got %+v", details.Variables)
+	}
+}
+
+func TestRegistryServerShutdown(t *testing.T) {
+	server, err := NewRegistryServer(RegistryServerOptions{
+		Port:       0,
+		StorageDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("NewRegistryServer returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve returned error on shutdown: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down within 5s")
+	}
+}
@@ -26,13 +26,15 @@ type ReportGenerator struct {
 	genkitProvider     *GenKitProvider
 	judgeModel         string
 	maxConcurrentEvals int
+	progress           ProgressFunc
 	db                 *sql.DB // Database connection for benchmark evaluations
 }
 
 // ReportGeneratorConfig configures the report generator
 type ReportGeneratorConfig struct {
-	JudgeModel         string // Default: "gpt-5-mini"
-	MaxConcurrentEvals int    // Default: 10
+	JudgeModel         string       // Default: "gpt-5-mini"
+	MaxConcurrentEvals int          // Default: 10
+	Progress           ProgressFunc // Optional per-agent evaluation progress callback
 }
 
 // NewReportGenerator creates a new report generator
@@ -66,6 +68,7 @@ func NewReportGenerator(repos *repositories.Repositories, db *sql.DB, cfg *Repor
 		genkitProvider:     NewGenKitProvider(),
 		judgeModel:         cfg.JudgeModel,
 		maxConcurrentEvals: cfg.MaxConcurrentEvals,
+		progress:           cfg.Progress,
 		db:                 db,
 	}
 }
@@ -190,6 +193,8 @@ type AgentEvaluation struct {
 	FailurePatterns   []FailurePattern    `json:"failure_patterns,omitempty"`
 	ImprovementPlan   []ImprovementAction `json:"improvement_plan,omitempty"`
 	QualityMetrics    *QualityMetrics     `json:"quality_metrics,omitempty"`
+	HumanFeedback     *FeedbackMetrics    `json:"human_feedback,omitempty"`
+	Trend             *AgentTrend         `json:"trend,omitempty"`
 
 	Error error
 }
@@ -226,6 +231,29 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, reportID int64) e
 		return rg.failReport(ctx, reportID, fmt.Errorf("no agents found in environment"))
 	}
 
+	// 3.5. Restrict to group members if the report targets an agent group
+	if groupID, err := rg.repos.Reports.GetAgentGroupID(ctx, reportID); err == nil && groupID != nil {
+		members, err := rg.repos.AgentGroups.ListMembers(ctx, *groupID)
+		if err != nil {
+			return rg.failReport(ctx, reportID, fmt.Errorf("failed to get agent group members: %w", err))
+		}
+		memberIDs := make(map[int64]bool, len(members))
+		for _, member := range members {
+			memberIDs[member.ID] = true
+		}
+		grouped := make([]models.Agent, 0, len(members))
+		for _, agent := range agents {
+			if memberIDs[agent.ID] {
+				grouped = append(grouped, agent)
+			}
+		}
+		if len(grouped) == 0 {
+			return rg.failReport(ctx, reportID, fmt.Errorf("no agents from group %d found in environment", *groupID))
+		}
+		logging.Info("Report %d restricted to agent group %d (%d of %d agents)", reportID, *groupID, len(grouped), len(agents))
+		agents = grouped
+	}
+
 	// 4. Update status: generating_team
 	if err := rg.updateReportStatus(ctx, reportID, "generating_team", 10, "Evaluating team performance..."); err != nil {
 		logging.Info("Failed to update report status: %v", err)
@@ -316,6 +344,10 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, reportID int64) e
 		costAnalysis.CurrentAvgCostPerRun,
 		costAnalysis.CurrentAvgCostPerRun*float64(len(agents))*30)
 
+	// 12.5. Compare this window against previous completed reports so the
+	// report shows improvement or regression per agent, not just a snapshot
+	rg.calculateAgentTrends(ctx, report.EnvironmentID, reportID, agentEvals)
+
 	// 13. Save agent evaluation results
 	totalLLMTokens := int64(0)
 	totalLLMCost := float64(0)
@@ -372,18 +404,30 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, reportID int64) e
 			continue
 		}
 
-		// Add to summary map
+		// Add to summary map (trend carries sparkline data for dashboards)
 		summaryLen := min(200, len(eval.Reasoning))
-		agentReportsMap[fmt.Sprintf("%d", eval.AgentID)] = map[string]interface{}{
+		agentSummary := map[string]interface{}{
 			"score":   eval.Score,
 			"summary": eval.Reasoning[:summaryLen],
 		}
+		if eval.Trend != nil {
+			agentSummary["trend"] = eval.Trend
+		}
+		agentReportsMap[fmt.Sprintf("%d", eval.AgentID)] = agentSummary
 
 		// Estimate token usage
 		totalLLMTokens += 1000
 		totalLLMCost += 0.001
 	}
 
+	// 13.5. Aggregate per-tool spend from annotated cost per call so the
+	// report shows where tool budget goes, not just token totals
+	if annotations, annErr := NewToolAnnotationService(rg.repos).GetEnvironmentAnnotations(report.EnvironmentID); annErr == nil && len(annotations) > 0 {
+		if toolSpend := AggregateToolSpend(allRuns, annotations); len(toolSpend) > 0 {
+			agentReportsMap["tool_spend"] = toolSpend
+		}
+	}
+
 	// 14. Complete report
 	agentReportsJSON, _ := json.Marshal(agentReportsMap)
 	duration := time.Since(startTime).Seconds()
@@ -652,6 +696,10 @@ func (rg *ReportGenerator) evaluateAgentsParallel(ctx context.Context, reportID
 	completed := 0
 	progressMutex := sync.Mutex{}
 
+	if rg.progress != nil && len(agents) > 0 {
+		rg.progress(0, len(agents), "")
+	}
+
 	for i, agent := range agents {
 		wg.Add(1)
 
@@ -668,6 +716,9 @@ func (rg *ReportGenerator) evaluateAgentsParallel(ctx context.Context, reportID
 			completed++
 			progress := 30 + int((float64(completed)/float64(len(agents)))*60)
 			step := fmt.Sprintf("Evaluated %d/%d agents", completed, len(agents))
+			if rg.progress != nil {
+				rg.progress(completed, len(agents), ag.Name)
+			}
 			progressMutex.Unlock()
 
 			if err := rg.updateReportStatus(ctx, reportID, "generating_agents", progress, step); err != nil {
@@ -773,6 +824,7 @@ func (rg *ReportGenerator) evaluateAgent(ctx context.Context, agent models.Agent
 		FailurePatterns:   failurePatterns,
 		ImprovementPlan:   improvementPlan,
 		QualityMetrics:    metrics.QualityMetrics,
+		HumanFeedback:     metrics.HumanFeedback,
 	}
 }
 
@@ -784,6 +836,20 @@ type AgentMetrics struct {
 
 	// LLM-as-Judge Quality Metrics (from benchmark_metrics table)
 	QualityMetrics *QualityMetrics `json:"quality_metrics,omitempty"`
+
+	// Human feedback (from run_annotations table)
+	HumanFeedback *FeedbackMetrics `json:"human_feedback,omitempty"`
+}
+
+// FeedbackMetrics aggregates human feedback recorded on runs via
+// `stn runs annotate` and the UI's thumbs up/down
+type FeedbackMetrics struct {
+	AvgRating      float64  `json:"avg_rating"`      // Average 1-5 rating across rated runs
+	RatedRuns      int      `json:"rated_runs"`      // Runs with at least one rating
+	ThumbsUp       int      `json:"thumbs_up"`       // Thumbs-up annotations
+	ThumbsDown     int      `json:"thumbs_down"`     // Thumbs-down annotations
+	AnnotatedRuns  int      `json:"annotated_runs"`  // Runs with any feedback
+	RecentComments []string `json:"recent_comments"` // Latest free-form comments
 }
 
 // QualityMetrics represents aggregate quality scores from LLM-as-judge evaluations
@@ -845,9 +911,67 @@ func (rg *ReportGenerator) calculateAgentMetrics(runs []queries.AgentRun) AgentM
 		metrics.QualityMetrics = qualityMetrics
 	}
 
+	// Aggregate human feedback from run_annotations table
+	if feedback := rg.calculateFeedbackMetrics(runs); feedback != nil {
+		metrics.HumanFeedback = feedback
+	}
+
 	return metrics
 }
 
+// calculateFeedbackMetrics aggregates human feedback (stn runs annotate,
+// UI thumbs) for the analyzed runs; nil when no feedback has been recorded
+func (rg *ReportGenerator) calculateFeedbackMetrics(runs []queries.AgentRun) *FeedbackMetrics {
+	if rg.db == nil || len(runs) == 0 {
+		return nil
+	}
+
+	runIDs := make([]string, len(runs))
+	for i, run := range runs {
+		runIDs[i] = fmt.Sprintf("%d", run.ID)
+	}
+
+	feedback := &FeedbackMetrics{}
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(DISTINCT run_id) as annotated_runs,
+			COUNT(DISTINCT CASE WHEN rating IS NOT NULL THEN run_id END) as rated_runs,
+			COALESCE(AVG(rating), 0) as avg_rating,
+			SUM(CASE WHEN thumbs = 'up' THEN 1 ELSE 0 END) as thumbs_up,
+			SUM(CASE WHEN thumbs = 'down' THEN 1 ELSE 0 END) as thumbs_down
+		FROM run_annotations
+		WHERE run_id IN (%s)
+	`, strings.Join(runIDs, ","))
+
+	if err := rg.db.QueryRow(query).Scan(&feedback.AnnotatedRuns, &feedback.RatedRuns,
+		&feedback.AvgRating, &feedback.ThumbsUp, &feedback.ThumbsDown); err != nil {
+		logging.Info("Failed to query run annotations: %v", err)
+		return nil
+	}
+	if feedback.AnnotatedRuns == 0 {
+		return nil
+	}
+
+	commentQuery := fmt.Sprintf(`
+		SELECT comment FROM run_annotations
+		WHERE run_id IN (%s) AND comment != ''
+		ORDER BY created_at DESC LIMIT 3
+	`, strings.Join(runIDs, ","))
+
+	if rows, err := rg.db.Query(commentQuery); err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var comment string
+			if rows.Scan(&comment) == nil {
+				feedback.RecentComments = append(feedback.RecentComments, comment)
+			}
+		}
+	}
+
+	return feedback
+}
+
 // calculateQualityMetrics aggregates LLM-as-judge scores from benchmark_metrics
 func (rg *ReportGenerator) calculateQualityMetrics(runs []queries.AgentRun) *QualityMetrics {
 	if rg.db == nil || len(runs) == 0 {
@@ -1044,6 +1168,21 @@ These scores come from automated LLM-based evaluation of actual agent outputs an
 		)
 	}
 
+	// Build human feedback section if operators have annotated runs
+	if metrics.HumanFeedback != nil {
+		f := metrics.HumanFeedback
+		feedbackDesc := fmt.Sprintf(`
+**Human Feedback (run annotations):**
+- Annotated runs: %d (avg rating %.1f/5 across %d rated runs)
+- Thumbs: %d up / %d down
+`, f.AnnotatedRuns, f.AvgRating, f.RatedRuns, f.ThumbsUp, f.ThumbsDown)
+		for _, comment := range f.RecentComments {
+			feedbackDesc += fmt.Sprintf("- Comment: %q\n", comment)
+		}
+		feedbackDesc += "\nThis feedback came directly from human operators reviewing agent output; weigh it heavily.\n"
+		qualityMetricsDesc += feedbackDesc
+	}
+
 	return fmt.Sprintf(`You are an expert evaluator assessing individual agent performance.
 
 **Agent:** %s
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+
+	"station/internal/logging"
+)
+
+// trendWindowCount is how many previous completed reports each new report is
+// compared against per agent
+const trendWindowCount = 5
+
+// AgentTrend compares an agent's current report window against its previous
+// report windows so reports show whether the agent is improving or
+// regressing instead of only a snapshot. Deltas are current minus the most
+// recent previous window; sparklines run oldest → newest and end with the
+// current window, ready for charting from the report JSON.
+type AgentTrend struct {
+	PreviousWindows      int       `json:"previous_windows"`
+	ScoreDelta           float64   `json:"score_delta"`
+	CostDelta            float64   `json:"cost_delta"`
+	LatencyDelta         float64   `json:"latency_delta"`
+	SuccessRateDelta     float64   `json:"success_rate_delta"`
+	ScoreSparkline       []float64 `json:"score_sparkline"`
+	CostSparkline        []float64 `json:"cost_sparkline"`
+	LatencySparkline     []float64 `json:"latency_sparkline"`
+	SuccessRateSparkline []float64 `json:"success_rate_sparkline"`
+	// Direction summarizes the score delta: "improving", "regressing", or "stable"
+	Direction string `json:"direction"`
+}
+
+// scoreStableBand is the score delta (on the 0-100 scale) within which a
+// trend is reported as stable rather than improving/regressing
+const scoreStableBand = 2.0
+
+// trendWindow is one previous report's persisted metrics for an agent
+type trendWindow struct {
+	Score       float64
+	AvgCost     float64
+	AvgDuration float64
+	SuccessRate float64
+}
+
+// calculateAgentTrends attaches trend data to each agent evaluation by
+// loading the agent's details from the environment's previous completed
+// reports. Agents with no report history get no trend rather than a
+// zero-delta one.
+func (rg *ReportGenerator) calculateAgentTrends(ctx context.Context, environmentID, currentReportID int64, agentEvals []AgentEvaluation) {
+	reports, err := rg.repos.Reports.ListByEnvironment(ctx, environmentID)
+	if err != nil {
+		logging.Info("Failed to load previous reports for trend analysis: %v", err)
+		return
+	}
+
+	// Previous completed reports, newest first (the list query orders by
+	// created_at DESC)
+	var previousReportIDs []int64
+	for _, report := range reports {
+		if report.ID == currentReportID || report.Status != "completed" {
+			continue
+		}
+		previousReportIDs = append(previousReportIDs, report.ID)
+		if len(previousReportIDs) == trendWindowCount {
+			break
+		}
+	}
+	if len(previousReportIDs) == 0 {
+		return
+	}
+
+	for i := range agentEvals {
+		eval := &agentEvals[i]
+		if eval.Error != nil {
+			continue
+		}
+
+		// Collect this agent's windows newest-first; reports that predate the
+		// agent simply don't contribute a window
+		var windows []trendWindow
+		for _, reportID := range previousReportIDs {
+			detail, err := rg.repos.Reports.GetAgentReportDetailByAgentID(ctx, reportID, eval.AgentID)
+			if err != nil {
+				continue
+			}
+			windows = append(windows, trendWindow{
+				Score:       detail.Score,
+				AvgCost:     detail.AvgCost.Float64,
+				AvgDuration: detail.AvgDurationSeconds.Float64,
+				SuccessRate: detail.SuccessRate.Float64,
+			})
+		}
+		if len(windows) == 0 {
+			continue
+		}
+
+		eval.Trend = buildAgentTrend(*eval, windows)
+	}
+}
+
+// buildAgentTrend computes deltas against the most recent previous window
+// and sparklines spanning every window plus the current one
+func buildAgentTrend(eval AgentEvaluation, previousNewestFirst []trendWindow) *AgentTrend {
+	latest := previousNewestFirst[0]
+
+	trend := &AgentTrend{
+		PreviousWindows:  len(previousNewestFirst),
+		ScoreDelta:       eval.Score - latest.Score,
+		CostDelta:        eval.AvgCost - latest.AvgCost,
+		LatencyDelta:     eval.AvgDuration - latest.AvgDuration,
+		SuccessRateDelta: eval.SuccessRate - latest.SuccessRate,
+	}
+
+	switch {
+	case trend.ScoreDelta > scoreStableBand:
+		trend.Direction = "improving"
+	case trend.ScoreDelta < -scoreStableBand:
+		trend.Direction = "regressing"
+	default:
+		trend.Direction = "stable"
+	}
+
+	// Sparklines oldest → newest, ending with the current window
+	for i := len(previousNewestFirst) - 1; i >= 0; i-- {
+		window := previousNewestFirst[i]
+		trend.ScoreSparkline = append(trend.ScoreSparkline, window.Score)
+		trend.CostSparkline = append(trend.CostSparkline, window.AvgCost)
+		trend.LatencySparkline = append(trend.LatencySparkline, window.AvgDuration)
+		trend.SuccessRateSparkline = append(trend.SuccessRateSparkline, window.SuccessRate)
+	}
+	trend.ScoreSparkline = append(trend.ScoreSparkline, eval.Score)
+	trend.CostSparkline = append(trend.CostSparkline, eval.AvgCost)
+	trend.LatencySparkline = append(trend.LatencySparkline, eval.AvgDuration)
+	trend.SuccessRateSparkline = append(trend.SuccessRateSparkline, eval.SuccessRate)
+
+	return trend
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+)
+
+func TestBuildAgentTrendImproving(t *testing.T) {
+	eval := AgentEvaluation{Score: 85, AvgCost: 0.010, AvgDuration: 30, SuccessRate: 0.9}
+	previous := []trendWindow{
+		{Score: 78, AvgCost: 0.012, AvgDuration: 42, SuccessRate: 0.8}, // newest
+		{Score: 70, AvgCost: 0.015, AvgDuration: 55, SuccessRate: 0.7}, // oldest
+	}
+
+	trend := buildAgentTrend(eval, previous)
+
+	if trend.Direction != "improving" {
+		t.Errorf("Expected improving, got %s", trend.Direction)
+	}
+	if trend.ScoreDelta != 7 {
+		t.Errorf("Expected score delta 7, got %f", trend.ScoreDelta)
+	}
+	if trend.SuccessRateDelta < 0.099 || trend.SuccessRateDelta > 0.101 {
+		t.Errorf("Expected success rate delta ~0.1, got %f", trend.SuccessRateDelta)
+	}
+	if trend.PreviousWindows != 2 {
+		t.Errorf("Expected 2 previous windows, got %d", trend.PreviousWindows)
+	}
+}
+
+func TestBuildAgentTrendSparklineOrder(t *testing.T) {
+	eval := AgentEvaluation{Score: 85}
+	previous := []trendWindow{
+		{Score: 78}, // newest
+		{Score: 70}, // oldest
+	}
+
+	trend := buildAgentTrend(eval, previous)
+
+	expected := []float64{70, 78, 85}
+	if len(trend.ScoreSparkline) != len(expected) {
+		t.Fatalf("Expected %d sparkline points, got %d", len(expected), len(trend.ScoreSparkline))
+	}
+	for i, want := range expected {
+		if trend.ScoreSparkline[i] != want {
+			t.Errorf("Sparkline[%d]: expected %f, got %f", i, want, trend.ScoreSparkline[i])
+		}
+	}
+}
+
+func TestBuildAgentTrendRegressing(t *testing.T) {
+	eval := AgentEvaluation{Score: 60}
+	trend := buildAgentTrend(eval, []trendWindow{{Score: 80}})
+
+	if trend.Direction != "regressing" {
+		t.Errorf("Expected regressing, got %s", trend.Direction)
+	}
+	if trend.ScoreDelta != -20 {
+		t.Errorf("Expected score delta -20, got %f", trend.ScoreDelta)
+	}
+}
+
+func TestBuildAgentTrendStableWithinBand(t *testing.T) {
+	eval := AgentEvaluation{Score: 81}
+	trend := buildAgentTrend(eval, []trendWindow{{Score: 80}})
+
+	if trend.Direction != "stable" {
+		t.Errorf("Expected stable for delta within band, got %s", trend.Direction)
+	}
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+)
+
+// Attachment metadata is stored per run in the settings table under
+// attachments.run.<run_id> so 'stn runs inspect' can list what was attached
+const runAttachmentSettingPrefix = "attachments.run."
+
+const (
+	// Attachment kinds: text files are injected into the task context,
+	// binaries are stored on disk as input artifacts
+	AttachmentKindContext  = "context"
+	AttachmentKindArtifact = "artifact"
+
+	// Maximum bytes of a text attachment injected into the task context;
+	// larger files are chunked to head + tail with a truncation marker
+	attachmentContextLimitBytes = 32 * 1024
+
+	// Bytes sniffed from the start of a file to classify text vs binary
+	attachmentSniffBytes = 8192
+)
+
+// RunAttachment describes a single file attached to an agent run
+type RunAttachment struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	SizeBytes  int64  `json:"size_bytes"`
+	StoredPath string `json:"stored_path,omitempty"`
+	Truncated  bool   `json:"truncated,omitempty"`
+
+	// content holds the (possibly chunked) text for context attachments;
+	// it is injected into the task, not persisted with the metadata
+	content    string
+	sourcePath string
+	data       []byte
+}
+
+// InlineAttachment is the API payload form of an attachment: text content or
+// base64-encoded binary content sent inline with the queue request
+type InlineAttachment struct {
+	Name          string `json:"name"`
+	Content       string `json:"content,omitempty"`
+	ContentBase64 string `json:"content_base64,omitempty"`
+}
+
+// RunAttachmentService prepares local files attached to a run: text files
+// become task context, binaries become input artifacts the agent can read
+// with filesystem MCP tools
+type RunAttachmentService struct {
+	repos *repositories.Repositories
+}
+
+// NewRunAttachmentService creates a run attachment service
+func NewRunAttachmentService(repos *repositories.Repositories) *RunAttachmentService {
+	return &RunAttachmentService{repos: repos}
+}
+
+// PrepareAttachments classifies each path as text (context) or binary
+// (artifact) and loads text content with size-aware chunking. Artifacts are
+// not copied here — StoreArtifacts places them once the run ID exists.
+func (s *RunAttachmentService) PrepareAttachments(paths []string) ([]*RunAttachment, error) {
+	var attachments []*RunAttachment
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %s: %w", path, err)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("attachment %s is a directory; only files can be attached", path)
+		}
+
+		attachment := &RunAttachment{
+			Name:       filepath.Base(path),
+			SizeBytes:  info.Size(),
+			sourcePath: path,
+		}
+
+		sniff, err := readAttachmentSniff(path)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %s: %w", path, err)
+		}
+
+		if isTextAttachment(sniff) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %s: %w", path, err)
+			}
+			attachment.Kind = AttachmentKindContext
+			attachment.content, attachment.Truncated = chunkAttachmentText(string(data), attachmentContextLimitBytes)
+		} else {
+			attachment.Kind = AttachmentKindArtifact
+		}
+
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// PrepareInlineAttachments builds attachments from API payloads: Content is
+// treated as text context, ContentBase64 as a binary artifact
+func (s *RunAttachmentService) PrepareInlineAttachments(inline []InlineAttachment) ([]*RunAttachment, error) {
+	var attachments []*RunAttachment
+	for _, item := range inline {
+		if item.Name == "" {
+			return nil, fmt.Errorf("attachment name is required")
+		}
+		if (item.Content == "") == (item.ContentBase64 == "") {
+			return nil, fmt.Errorf("attachment %s: exactly one of content or content_base64 is required", item.Name)
+		}
+
+		attachment := &RunAttachment{Name: filepath.Base(item.Name)}
+		if item.Content != "" {
+			attachment.Kind = AttachmentKindContext
+			attachment.SizeBytes = int64(len(item.Content))
+			attachment.content, attachment.Truncated = chunkAttachmentText(item.Content, attachmentContextLimitBytes)
+		} else {
+			data, err := base64.StdEncoding.DecodeString(item.ContentBase64)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %s: invalid base64 content: %w", item.Name, err)
+			}
+			attachment.Kind = AttachmentKindArtifact
+			attachment.SizeBytes = int64(len(data))
+			attachment.data = data
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// readAttachmentSniff reads the classification window from the file head
+func readAttachmentSniff(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	sniff := make([]byte, attachmentSniffBytes)
+	n, err := file.Read(sniff)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return sniff[:n], nil
+}
+
+// isTextAttachment reports whether the sniffed bytes look like text: no NUL
+// bytes and valid UTF-8 (allowing a rune cut at the window boundary)
+func isTextAttachment(sniff []byte) bool {
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		return false
+	}
+	// Trim up to 3 trailing bytes so a multi-byte rune split by the sniff
+	// window does not misclassify the file
+	for trim := 0; trim < 4 && trim < len(sniff); trim++ {
+		if utf8.Valid(sniff[:len(sniff)-trim]) {
+			return true
+		}
+	}
+	return len(sniff) == 0
+}
+
+// chunkAttachmentText keeps oversized text attachments bounded by retaining
+// the head and tail halves around a truncation marker
+func chunkAttachmentText(text string, limit int) (string, bool) {
+	if len(text) <= limit {
+		return text, false
+	}
+	half := limit / 2
+	marker := fmt.Sprintf("\n…[%d bytes truncated]…\n", len(text)-limit)
+	return text[:half] + marker + text[len(text)-half:], true
+}
+
+// StoreArtifacts copies binary attachments into the run's attachment
+// directory so filesystem MCP tools (and sandboxed executions mounting the
+// config root) can read them by path
+func (s *RunAttachmentService) StoreArtifacts(runID int64, attachments []*RunAttachment) error {
+	for _, attachment := range attachments {
+		if attachment.Kind != AttachmentKindArtifact {
+			continue
+		}
+
+		dir := config.GetRunAttachmentsDir(runID)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create attachment directory: %w", err)
+		}
+
+		storedPath := filepath.Join(dir, attachment.Name)
+		if attachment.sourcePath != "" {
+			if err := copyAttachmentFile(attachment.sourcePath, storedPath); err != nil {
+				return fmt.Errorf("failed to store attachment %s: %w", attachment.Name, err)
+			}
+		} else {
+			if err := os.WriteFile(storedPath, attachment.data, 0644); err != nil {
+				return fmt.Errorf("failed to store attachment %s: %w", attachment.Name, err)
+			}
+		}
+
+		attachment.StoredPath = storedPath
+	}
+	return nil
+}
+
+// copyAttachmentFile copies a source file into the attachment directory
+func copyAttachmentFile(sourcePath, storedPath string) error {
+	src, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	dst, err := os.Create(storedPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// AttachmentTaskContext appends the attachment context block to the task:
+// text file contents inline, artifact paths as pointers for filesystem tools
+func AttachmentTaskContext(task string, attachments []*RunAttachment) string {
+	if len(attachments) == 0 {
+		return task
+	}
+
+	var b strings.Builder
+	b.WriteString(task)
+	b.WriteString("\n\n## Attached Files\n")
+	for _, attachment := range attachments {
+		switch attachment.Kind {
+		case AttachmentKindContext:
+			fmt.Fprintf(&b, "\n### %s (%d bytes", attachment.Name, attachment.SizeBytes)
+			if attachment.Truncated {
+				b.WriteString(", truncated")
+			}
+			fmt.Fprintf(&b, ")\n```\n%s\n```\n", attachment.content)
+		case AttachmentKindArtifact:
+			fmt.Fprintf(&b, "\n- Binary input artifact '%s' (%d bytes) stored at: %s — read it with filesystem tools\n",
+				attachment.Name, attachment.SizeBytes, attachment.StoredPath)
+		}
+	}
+	return b.String()
+}
+
+// RecordRunAttachments persists attachment metadata for run inspection
+func (s *RunAttachmentService) RecordRunAttachments(runID int64, attachments []*RunAttachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(attachments)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s%d", runAttachmentSettingPrefix, runID)
+	if err := s.repos.Settings.Set(key, string(data), "Files attached to the run"); err != nil {
+		return fmt.Errorf("failed to record run attachments: %w", err)
+	}
+	return nil
+}
+
+// GetRunAttachments returns the attachments recorded for a run, or nil if
+// nothing was attached
+func (s *RunAttachmentService) GetRunAttachments(runID int64) ([]RunAttachment, error) {
+	setting, err := s.repos.Settings.GetByKey(fmt.Sprintf("%s%d", runAttachmentSettingPrefix, runID))
+	if err != nil {
+		return nil, nil
+	}
+
+	var attachments []RunAttachment
+	if err := json.Unmarshal([]byte(setting.Value), &attachments); err != nil {
+		return nil, fmt.Errorf("invalid stored attachments for run %d: %w", runID, err)
+	}
+	return attachments, nil
+}
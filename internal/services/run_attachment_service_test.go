@@ -0,0 +1,169 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestPrepareAttachmentsClassification(t *testing.T) {
+	dir := t.TempDir()
+
+	textPath := filepath.Join(dir, "report.txt")
+	if err := os.WriteFile(textPath, []byte("line one\nline two\n"), 0644); err != nil {
+		t.Fatalf("failed to write text file: %v", err)
+	}
+
+	binaryPath := filepath.Join(dir, "data.bin")
+	if err := os.WriteFile(binaryPath, []byte{0x89, 0x50, 0x4e, 0x47, 0x00, 0x01, 0x02}, 0644); err != nil {
+		t.Fatalf("failed to write binary file: %v", err)
+	}
+
+	service := &RunAttachmentService{}
+	attachments, err := service.PrepareAttachments([]string{textPath, binaryPath})
+	if err != nil {
+		t.Fatalf("PrepareAttachments failed: %v", err)
+	}
+	if len(attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(attachments))
+	}
+
+	if attachments[0].Kind != AttachmentKindContext {
+		t.Errorf("text file should be classified as context, got %s", attachments[0].Kind)
+	}
+	if attachments[0].content != "line one\nline two\n" {
+		t.Errorf("unexpected text content: %q", attachments[0].content)
+	}
+	if attachments[1].Kind != AttachmentKindArtifact {
+		t.Errorf("binary file should be classified as artifact, got %s", attachments[1].Kind)
+	}
+
+	if _, err := service.PrepareAttachments([]string{filepath.Join(dir, "missing.txt")}); err == nil {
+		t.Error("expected error for missing attachment")
+	}
+	if _, err := service.PrepareAttachments([]string{dir}); err == nil {
+		t.Error("expected error for directory attachment")
+	}
+}
+
+func TestChunkAttachmentText(t *testing.T) {
+	short, truncated := chunkAttachmentText("small file", 100)
+	if truncated || short != "small file" {
+		t.Errorf("small files should pass through untouched, got %q (truncated=%t)", short, truncated)
+	}
+
+	big := strings.Repeat("a", 500) + strings.Repeat("z", 500)
+	chunked, truncated := chunkAttachmentText(big, 100)
+	if !truncated {
+		t.Error("oversized text should be marked truncated")
+	}
+	if !strings.HasPrefix(chunked, "aaaaa") || !strings.HasSuffix(chunked, "zzzzz") {
+		t.Error("chunked text should retain head and tail")
+	}
+	if !strings.Contains(chunked, "truncated") {
+		t.Error("chunked text should carry a truncation marker")
+	}
+}
+
+func TestPrepareInlineAttachments(t *testing.T) {
+	service := &RunAttachmentService{}
+
+	attachments, err := service.PrepareInlineAttachments([]InlineAttachment{
+		{Name: "notes.md", Content: "some findings"},
+		{Name: "image.png", ContentBase64: "iVBORw0KGgo="},
+	})
+	if err != nil {
+		t.Fatalf("PrepareInlineAttachments failed: %v", err)
+	}
+	if attachments[0].Kind != AttachmentKindContext || attachments[1].Kind != AttachmentKindArtifact {
+		t.Errorf("unexpected kinds: %s, %s", attachments[0].Kind, attachments[1].Kind)
+	}
+
+	if _, err := service.PrepareInlineAttachments([]InlineAttachment{{Name: "x", Content: "a", ContentBase64: "YQ=="}}); err == nil {
+		t.Error("expected error when both content forms are provided")
+	}
+	if _, err := service.PrepareInlineAttachments([]InlineAttachment{{Name: "x", ContentBase64: "not base64!"}}); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+	if _, err := service.PrepareInlineAttachments([]InlineAttachment{{Content: "unnamed"}}); err == nil {
+		t.Error("expected error for missing name")
+	}
+}
+
+func TestAttachmentTaskContext(t *testing.T) {
+	task := "Analyze the attached report"
+	attachments := []*RunAttachment{
+		{Name: "report.txt", Kind: AttachmentKindContext, SizeBytes: 12, content: "scan results"},
+		{Name: "data.bin", Kind: AttachmentKindArtifact, SizeBytes: 7, StoredPath: "/tmp/run-1/data.bin"},
+	}
+
+	augmented := AttachmentTaskContext(task, attachments)
+	if !strings.HasPrefix(augmented, task) {
+		t.Error("augmented task should start with the original task")
+	}
+	if !strings.Contains(augmented, "scan results") {
+		t.Error("text attachment content should be injected into the task")
+	}
+	if !strings.Contains(augmented, "/tmp/run-1/data.bin") {
+		t.Error("artifact stored path should be referenced in the task")
+	}
+
+	if AttachmentTaskContext(task, nil) != task {
+		t.Error("task should be unchanged without attachments")
+	}
+}
+
+func TestStoreAndRecordRunAttachments(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+	repos := repositories.New(testDB)
+	service := NewRunAttachmentService(repos)
+
+	sourceDir := t.TempDir()
+	binaryPath := filepath.Join(sourceDir, "data.bin")
+	payload := []byte{0x00, 0x01, 0x02, 0x03}
+	if err := os.WriteFile(binaryPath, payload, 0644); err != nil {
+		t.Fatalf("failed to write binary file: %v", err)
+	}
+
+	attachments, err := service.PrepareAttachments([]string{binaryPath})
+	if err != nil {
+		t.Fatalf("PrepareAttachments failed: %v", err)
+	}
+	if err := service.StoreArtifacts(42, attachments); err != nil {
+		t.Fatalf("StoreArtifacts failed: %v", err)
+	}
+
+	stored, err := os.ReadFile(attachments[0].StoredPath)
+	if err != nil {
+		t.Fatalf("stored artifact not readable: %v", err)
+	}
+	if string(stored) != string(payload) {
+		t.Error("stored artifact content does not match source")
+	}
+
+	if err := service.RecordRunAttachments(42, attachments); err != nil {
+		t.Fatalf("RecordRunAttachments failed: %v", err)
+	}
+	recorded, err := service.GetRunAttachments(42)
+	if err != nil {
+		t.Fatalf("GetRunAttachments failed: %v", err)
+	}
+	if len(recorded) != 1 || recorded[0].Name != "data.bin" || recorded[0].StoredPath == "" {
+		t.Errorf("unexpected recorded attachments: %+v", recorded)
+	}
+
+	if missing, err := service.GetRunAttachments(999); err != nil || missing != nil {
+		t.Errorf("runs without attachments should return nil, got %+v (%v)", missing, err)
+	}
+}
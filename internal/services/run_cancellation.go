@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"station/internal/config"
+	"station/internal/logging"
+	"station/pkg/models"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Run statuses written when an execution does not complete normally
+const (
+	RunStatusTimedOut  = "timed_out"
+	RunStatusCancelled = "cancelled"
+	RunStatusFailed    = "failed"
+)
+
+// Sentinel errors used to classify how a run ended. The execution engine
+// wraps its returned error with these so callers can map the failure to
+// the right run status.
+var (
+	ErrRunTimedOut  = errors.New("agent run timed out")
+	ErrRunCancelled = errors.New("agent run cancelled")
+)
+
+// runCancellationRegistry tracks the cancel functions of in-flight runs so
+// `stn runs cancel` and the cancel API endpoint can abort them on demand.
+// Process-wide, like the singleton guard, so all execution paths share it.
+type runCancellationRegistry struct {
+	mu      sync.Mutex
+	cancels map[int64]context.CancelCauseFunc
+}
+
+var runCancellations = &runCancellationRegistry{cancels: make(map[int64]context.CancelCauseFunc)}
+
+func registerRunCancellation(runID int64, cancel context.CancelCauseFunc) {
+	runCancellations.mu.Lock()
+	defer runCancellations.mu.Unlock()
+	runCancellations.cancels[runID] = cancel
+}
+
+func unregisterRunCancellation(runID int64) {
+	runCancellations.mu.Lock()
+	defer runCancellations.mu.Unlock()
+	delete(runCancellations.cancels, runID)
+}
+
+// CancelActiveRun cancels a run executing in this process. The execution
+// engine observes the cancellation, aborts the GenKit generation and any
+// in-flight MCP tool calls, and returns ErrRunCancelled so the caller marks
+// the run cancelled. Returns false if the run is not active in this process.
+func CancelActiveRun(runID int64) bool {
+	runCancellations.mu.Lock()
+	cancel, ok := runCancellations.cancels[runID]
+	runCancellations.mu.Unlock()
+	if !ok {
+		return false
+	}
+	logging.Info("🛑 Cancelling active run %d on demand", runID)
+	cancel(ErrRunCancelled)
+	return true
+}
+
+// ClassifyRunFailure maps an execution error to the run status that should
+// be recorded, distinguishing timeouts and on-demand cancellations from
+// ordinary failures.
+func ClassifyRunFailure(err error) string {
+	switch {
+	case errors.Is(err, ErrRunTimedOut):
+		return RunStatusTimedOut
+	case errors.Is(err, ErrRunCancelled):
+		return RunStatusCancelled
+	default:
+		return RunStatusFailed
+	}
+}
+
+// loadAgentTimeout reads the agent's `timeout` frontmatter value (a Go
+// duration string like "5m" or "90s") from its .prompt file. Zero means no
+// per-agent override; the engine's default applies.
+func loadAgentTimeout(agent *models.Agent, environmentName string) time.Duration {
+	environmentDir := config.GetEnvironmentDir(environmentName)
+	promptFilePath := filepath.Join(environmentDir, "agents", agent.Name+".prompt")
+
+	content, err := os.ReadFile(promptFilePath)
+	if err != nil {
+		return 0
+	}
+
+	return parseTimeoutConfig(string(content))
+}
+
+// parseTimeoutConfig extracts the `timeout` duration from .prompt file
+// content. Invalid or missing values yield zero.
+func parseTimeoutConfig(content string) time.Duration {
+	parts := strings.Split(content, "---")
+	if len(parts) < 3 {
+		return 0
+	}
+
+	var frontmatter struct {
+		Timeout string `yaml:"timeout"`
+	}
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(parts[1])), &frontmatter); err != nil {
+		return 0
+	}
+	if frontmatter.Timeout == "" {
+		return 0
+	}
+
+	timeout, err := time.ParseDuration(frontmatter.Timeout)
+	if err != nil || timeout <= 0 {
+		logging.Debug("Timeout config: invalid duration %q: %v", frontmatter.Timeout, err)
+		return 0
+	}
+	return timeout
+}
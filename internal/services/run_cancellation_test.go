@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseTimeoutConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected time.Duration
+	}{
+		{
+			name: "timeout set",
+			content: `---
+metadata:
+  name: "Test Agent"
+model: gpt-4o-mini
+timeout: 5m
+---
+
+{{role "system"}}
+Test prompt`,
+			expected: 5 * time.Minute,
+		},
+		{
+			name: "no timeout",
+			content: `---
+metadata:
+  name: "Test Agent"
+model: gpt-4o-mini
+---
+
+{{role "system"}}
+Test prompt`,
+			expected: 0,
+		},
+		{
+			name: "invalid timeout ignored",
+			content: `---
+metadata:
+  name: "Test Agent"
+timeout: soon
+---
+
+{{role "system"}}
+Test prompt`,
+			expected: 0,
+		},
+		{
+			name:     "no frontmatter",
+			content:  "just a prompt",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseTimeoutConfig(tt.content)
+			if got != tt.expected {
+				t.Errorf("parseTimeoutConfig() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClassifyRunFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"timed out", fmt.Errorf("%w after 5m: agent X", ErrRunTimedOut), RunStatusTimedOut},
+		{"cancelled", fmt.Errorf("%w: agent X", ErrRunCancelled), RunStatusCancelled},
+		{"generic failure", fmt.Errorf("model call failed"), RunStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyRunFailure(tt.err); got != tt.expected {
+				t.Errorf("ClassifyRunFailure() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunCancellationRegistry(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	registerRunCancellation(42, cancel)
+	defer unregisterRunCancellation(42)
+
+	if !CancelActiveRun(42) {
+		t.Fatal("expected CancelActiveRun to find registered run")
+	}
+
+	select {
+	case <-ctx.Done():
+		if cause := context.Cause(ctx); cause != ErrRunCancelled {
+			t.Errorf("expected cause ErrRunCancelled, got %v", cause)
+		}
+	default:
+		t.Fatal("expected context to be cancelled")
+	}
+
+	if CancelActiveRun(99) {
+		t.Error("expected CancelActiveRun to return false for unknown run")
+	}
+}
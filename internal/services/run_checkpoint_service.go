@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+// Checkpoints are stored per run in the settings table under
+// checkpoint.run.<run_id>, written incrementally as tool calls complete and
+// cleared when the run finishes successfully
+const runCheckpointSettingPrefix = "checkpoint.run."
+
+// Tool results in checkpoints are truncated so checkpoints stay small even
+// for file-heavy runs; the resume prompt only needs enough to avoid re-work
+const checkpointResultMaxChars = 2000
+
+// CheckpointToolCall records one completed tool call in a run checkpoint
+type CheckpointToolCall struct {
+	ToolName  string                 `json:"tool_name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Result    string                 `json:"result,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	Timestamp string                 `json:"timestamp"`
+}
+
+// RunCheckpoint is the incrementally persisted execution state for a run,
+// enough to resume after a crash without re-paying for completed tool calls
+type RunCheckpoint struct {
+	RunID     int64                `json:"run_id"`
+	StepIndex int                  `json:"step_index"`
+	ToolCalls []CheckpointToolCall `json:"tool_calls"`
+	UpdatedAt string               `json:"updated_at"`
+}
+
+// RunCheckpointService persists execution state incrementally during a run
+// so `stn runs resume <id>` can continue after a crash or restart
+type RunCheckpointService struct {
+	repos *repositories.Repositories
+}
+
+// NewRunCheckpointService creates a run checkpoint service
+func NewRunCheckpointService(repos *repositories.Repositories) *RunCheckpointService {
+	return &RunCheckpointService{repos: repos}
+}
+
+// GetCheckpoint returns the stored checkpoint for a run, or nil if the run
+// never checkpointed (or already completed and was cleared)
+func (s *RunCheckpointService) GetCheckpoint(runID int64) (*RunCheckpoint, error) {
+	setting, err := s.repos.Settings.GetByKey(checkpointKey(runID))
+	if err != nil {
+		return nil, nil
+	}
+
+	var checkpoint RunCheckpoint
+	if err := json.Unmarshal([]byte(setting.Value), &checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid stored checkpoint for run %d: %w", runID, err)
+	}
+	return &checkpoint, nil
+}
+
+// AppendToolCall records a completed tool call on the run's checkpoint,
+// creating the checkpoint on first use
+func (s *RunCheckpointService) AppendToolCall(runID int64, call CheckpointToolCall) error {
+	checkpoint, err := s.GetCheckpoint(runID)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		checkpoint = &RunCheckpoint{RunID: runID}
+	}
+
+	call.Result = truncateForPrompt(call.Result, checkpointResultMaxChars)
+	if call.Timestamp == "" {
+		call.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+	checkpoint.ToolCalls = append(checkpoint.ToolCalls, call)
+	checkpoint.StepIndex = len(checkpoint.ToolCalls)
+	checkpoint.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	return s.saveCheckpoint(checkpoint)
+}
+
+// Clear removes a run's checkpoint, called when the run completes
+// successfully and the state is no longer needed
+func (s *RunCheckpointService) Clear(runID int64) {
+	if err := s.repos.Settings.Delete(checkpointKey(runID)); err != nil {
+		logging.Debug("Failed to clear checkpoint for run %d: %v", runID, err)
+	}
+}
+
+func (s *RunCheckpointService) saveCheckpoint(checkpoint *RunCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := s.repos.Settings.Set(checkpointKey(checkpoint.RunID), string(data), "Run execution checkpoint"); err != nil {
+		return fmt.Errorf("failed to store checkpoint for run %d: %w", checkpoint.RunID, err)
+	}
+	return nil
+}
+
+func checkpointKey(runID int64) string {
+	return fmt.Sprintf("%s%d", runCheckpointSettingPrefix, runID)
+}
+
+// BuildResumeTask composes the task for a resumed run: the original task plus
+// the checkpointed progress, so the model continues from where the crashed
+// run stopped instead of repeating completed tool calls
+func BuildResumeTask(originalTask string, checkpoint *RunCheckpoint) string {
+	if checkpoint == nil || len(checkpoint.ToolCalls) == 0 {
+		return originalTask
+	}
+
+	var b strings.Builder
+	b.WriteString(originalTask)
+	b.WriteString("\n\n---\n")
+	fmt.Fprintf(&b, "This is a resumed run. A previous attempt completed %d tool call(s) before being interrupted. Their results are below — do NOT repeat them; continue from this state:\n", len(checkpoint.ToolCalls))
+	for i, call := range checkpoint.ToolCalls {
+		fmt.Fprintf(&b, "\n%d. %s", i+1, call.ToolName)
+		if len(call.Arguments) > 0 {
+			if encoded, err := json.Marshal(call.Arguments); err == nil {
+				fmt.Fprintf(&b, " %s", truncateForPrompt(string(encoded), 300))
+			}
+		}
+		if call.Error != "" {
+			fmt.Fprintf(&b, "\n   Error: %s", truncateForPrompt(call.Error, 300))
+		} else if call.Result != "" {
+			fmt.Fprintf(&b, "\n   Result: %s", call.Result)
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// checkpointMiddleware persists each completed tool call to the run's
+// checkpoint as it happens, giving `stn runs resume` state to continue from
+type checkpointMiddleware struct {
+	service *RunCheckpointService
+}
+
+func (m *checkpointMiddleware) Name() string {
+	return "run-checkpoint"
+}
+
+func (m *checkpointMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	return nil, nil
+}
+
+func (m *checkpointMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	// Runs without a database record (runID 0) have nothing to resume
+	if call.RunID == 0 {
+		return nil, nil
+	}
+
+	entry := CheckpointToolCall{
+		ToolName:  call.ToolName,
+		Arguments: call.Arguments,
+	}
+	if callErr != nil {
+		entry.Error = callErr.Error()
+	} else if output != nil {
+		if encoded, err := json.Marshal(output); err == nil {
+			entry.Result = string(encoded)
+		}
+	}
+
+	if err := m.service.AppendToolCall(call.RunID, entry); err != nil {
+		logging.Debug("Failed to checkpoint tool call for run %d: %v", call.RunID, err)
+	}
+	return nil, nil
+}
+
+var enableRunCheckpointingOnce sync.Once
+
+// EnableRunCheckpointing registers the checkpoint middleware so every tool
+// call is persisted incrementally (first constructor wins)
+func EnableRunCheckpointing(repos *repositories.Repositories) {
+	enableRunCheckpointingOnce.Do(func() {
+		RegisterToolCallMiddleware(&checkpointMiddleware{service: NewRunCheckpointService(repos)})
+	})
+}
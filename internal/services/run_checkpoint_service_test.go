@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestRunCheckpointRoundTrip(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	svc := NewRunCheckpointService(repos)
+
+	// No checkpoint stored yet
+	checkpoint, err := svc.GetCheckpoint(9)
+	if err != nil {
+		t.Fatalf("GetCheckpoint returned error: %v", err)
+	}
+	if checkpoint != nil {
+		t.Fatal("expected nil checkpoint for fresh run")
+	}
+
+	// Tool calls append incrementally and bump the step index
+	if err := svc.AppendToolCall(9, CheckpointToolCall{
+		ToolName:  "__list_directory",
+		Arguments: map[string]interface{}{"path": "/workspace"},
+		Result:    `["main.go"]`,
+	}); err != nil {
+		t.Fatalf("AppendToolCall returned error: %v", err)
+	}
+	if err := svc.AppendToolCall(9, CheckpointToolCall{
+		ToolName: "__read_text_file",
+		Error:    "file not found",
+	}); err != nil {
+		t.Fatalf("AppendToolCall returned error: %v", err)
+	}
+
+	checkpoint, err = svc.GetCheckpoint(9)
+	if err != nil {
+		t.Fatalf("GetCheckpoint returned error: %v", err)
+	}
+	if checkpoint == nil || checkpoint.StepIndex != 2 || len(checkpoint.ToolCalls) != 2 {
+		t.Fatalf("expected 2 checkpointed tool calls, got %+v", checkpoint)
+	}
+	if checkpoint.ToolCalls[1].Error != "file not found" {
+		t.Errorf("expected tool error preserved, got %+v", checkpoint.ToolCalls[1])
+	}
+
+	// Clear removes the checkpoint
+	svc.Clear(9)
+	checkpoint, err = svc.GetCheckpoint(9)
+	if err != nil {
+		t.Fatalf("GetCheckpoint returned error after clear: %v", err)
+	}
+	if checkpoint != nil {
+		t.Fatal("expected checkpoint cleared")
+	}
+}
+
+func TestRunCheckpointTruncatesLargeResults(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	svc := NewRunCheckpointService(repos)
+
+	if err := svc.AppendToolCall(11, CheckpointToolCall{
+		ToolName: "__read_text_file",
+		Result:   strings.Repeat("x", checkpointResultMaxChars*2),
+	}); err != nil {
+		t.Fatalf("AppendToolCall returned error: %v", err)
+	}
+
+	checkpoint, err := svc.GetCheckpoint(11)
+	if err != nil {
+		t.Fatalf("GetCheckpoint returned error: %v", err)
+	}
+	if len(checkpoint.ToolCalls[0].Result) > checkpointResultMaxChars+len("…") {
+		t.Errorf("expected result truncated to %d chars, got %d", checkpointResultMaxChars, len(checkpoint.ToolCalls[0].Result))
+	}
+}
+
+func TestBuildResumeTask(t *testing.T) {
+	// Without a checkpoint the original task is returned unchanged
+	if got := BuildResumeTask("scan the repo", nil); got != "scan the repo" {
+		t.Errorf("expected original task without checkpoint, got %q", got)
+	}
+
+	checkpoint := &RunCheckpoint{
+		RunID:     3,
+		StepIndex: 1,
+		ToolCalls: []CheckpointToolCall{
+			{ToolName: "__list_directory", Arguments: map[string]interface{}{"path": "/workspace"}, Result: `["main.go"]`},
+		},
+	}
+	task := BuildResumeTask("scan the repo", checkpoint)
+	for _, fragment := range []string{"scan the repo", "resumed run", "__list_directory", `["main.go"]`, "do NOT repeat"} {
+		if !strings.Contains(task, fragment) {
+			t.Errorf("expected resume task to contain %q", fragment)
+		}
+	}
+}
+
+func TestCheckpointMiddlewareRecordsCompletedCalls(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	svc := NewRunCheckpointService(repos)
+	m := &checkpointMiddleware{service: svc}
+
+	call := &ToolCall{RunID: 21, ToolName: "__search_files", Arguments: map[string]interface{}{"pattern": "*.tf"}}
+	if _, err := m.AfterToolCall(nil, call, map[string]interface{}{"matches": 3}, nil); err != nil {
+		t.Fatalf("AfterToolCall returned error: %v", err)
+	}
+	if _, err := m.AfterToolCall(nil, call, nil, fmt.Errorf("server disconnected")); err != nil {
+		t.Fatalf("AfterToolCall returned error: %v", err)
+	}
+
+	// runID 0 (no database record) is skipped
+	if _, err := m.AfterToolCall(nil, &ToolCall{RunID: 0, ToolName: "x"}, nil, nil); err != nil {
+		t.Fatalf("AfterToolCall returned error for runID 0: %v", err)
+	}
+
+	checkpoint, err := svc.GetCheckpoint(21)
+	if err != nil {
+		t.Fatalf("GetCheckpoint returned error: %v", err)
+	}
+	if checkpoint == nil || len(checkpoint.ToolCalls) != 2 {
+		t.Fatalf("expected 2 checkpointed calls, got %+v", checkpoint)
+	}
+	if !strings.Contains(checkpoint.ToolCalls[0].Result, "matches") {
+		t.Errorf("expected serialized tool output, got %q", checkpoint.ToolCalls[0].Result)
+	}
+	if checkpoint.ToolCalls[1].Error != "server disconnected" {
+		t.Errorf("expected tool error recorded, got %+v", checkpoint.ToolCalls[1])
+	}
+}
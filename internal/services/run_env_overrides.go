@@ -0,0 +1,40 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseRunEnvOverrides parses repeated --env-var values of the form KEY=VALUE
+// into per-run environment variable overrides for stdio MCP servers, and
+// enforces the configured allowlist (run_env_allowlist in config.yaml or
+// STN_RUN_ENV_ALLOWLIST). The allowlist keeps per-run injection limited to
+// variables an operator explicitly opted in — credential profiles, regions —
+// instead of letting any run rewrite arbitrary server environment.
+func ParseRunEnvOverrides(values []string, allowlist []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	allowed := make(map[string]bool, len(allowlist))
+	for _, name := range allowlist {
+		allowed[name] = true
+	}
+
+	overrides := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, hasValue := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if key == "" || !hasValue {
+			return nil, fmt.Errorf("invalid env var %q: expected KEY=VALUE", value)
+		}
+		if len(allowlist) == 0 {
+			return nil, fmt.Errorf("per-run env vars are disabled: add %s to run_env_allowlist in config.yaml (or STN_RUN_ENV_ALLOWLIST) to allow it", key)
+		}
+		if !allowed[key] {
+			return nil, fmt.Errorf("env var %s is not allowlisted for per-run injection (allowed: %s)", key, strings.Join(allowlist, ", "))
+		}
+		overrides[key] = val
+	}
+	return overrides, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRunEnvOverrides(t *testing.T) {
+	allowlist := []string{"AWS_PROFILE", "AWS_REGION"}
+
+	overrides, err := ParseRunEnvOverrides([]string{"AWS_PROFILE=staging", "AWS_REGION=us-west-2"}, allowlist)
+	if err != nil {
+		t.Fatalf("ParseRunEnvOverrides returned error: %v", err)
+	}
+	if overrides["AWS_PROFILE"] != "staging" || overrides["AWS_REGION"] != "us-west-2" {
+		t.Errorf("unexpected overrides: %v", overrides)
+	}
+}
+
+func TestParseRunEnvOverridesRejectsUnlisted(t *testing.T) {
+	_, err := ParseRunEnvOverrides([]string{"SECRET_KEY=oops"}, []string{"AWS_PROFILE"})
+	if err == nil {
+		t.Fatal("expected error for unlisted env var")
+	}
+	if !strings.Contains(err.Error(), "not allowlisted") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseRunEnvOverridesEmptyAllowlist(t *testing.T) {
+	_, err := ParseRunEnvOverrides([]string{"AWS_PROFILE=staging"}, nil)
+	if err == nil {
+		t.Fatal("expected error with empty allowlist")
+	}
+	if !strings.Contains(err.Error(), "run_env_allowlist") {
+		t.Errorf("error should point at run_env_allowlist config: %v", err)
+	}
+}
+
+func TestParseRunEnvOverridesMalformed(t *testing.T) {
+	for _, value := range []string{"NOVALUE", "=bare", " =x"} {
+		if _, err := ParseRunEnvOverrides([]string{value}, []string{"NOVALUE"}); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
+
+func TestParseRunEnvOverridesEmptyInput(t *testing.T) {
+	overrides, err := ParseRunEnvOverrides(nil, nil)
+	if err != nil || overrides != nil {
+		t.Errorf("expected nil, nil for no values, got %v, %v", overrides, err)
+	}
+}
+
+func TestApplyEnvOverridesStdioServer(t *testing.T) {
+	serverConfig := map[string]interface{}{
+		"command": "npx",
+		"args":    []interface{}{"-y", "some-server"},
+		"env":     map[string]interface{}{"AWS_PROFILE": "default", "KEEP": "yes"},
+	}
+
+	result := applyEnvOverrides(serverConfig, map[string]string{"AWS_PROFILE": "staging"})
+
+	merged, ok := result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected map result, got %T", result)
+	}
+	env := merged["env"].(map[string]interface{})
+	if env["AWS_PROFILE"] != "staging" || env["KEEP"] != "yes" {
+		t.Errorf("unexpected merged env: %v", env)
+	}
+
+	// Original config must not be mutated (pooled views share it)
+	originalEnv := serverConfig["env"].(map[string]interface{})
+	if originalEnv["AWS_PROFILE"] != "default" {
+		t.Errorf("original config was mutated: %v", originalEnv)
+	}
+}
+
+func TestApplyEnvOverridesSkipsHTTPServer(t *testing.T) {
+	serverConfig := map[string]interface{}{
+		"url": "https://example.com/mcp",
+	}
+
+	result := applyEnvOverrides(serverConfig, map[string]string{"AWS_PROFILE": "staging"})
+	merged := result.(map[string]interface{})
+	if _, hasEnv := merged["env"]; hasEnv {
+		t.Errorf("HTTP server config should be untouched, got %v", merged)
+	}
+}
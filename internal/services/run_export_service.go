@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"station/internal/db/repositories"
+	"station/internal/logging"
+	"station/pkg/models"
+)
+
+const (
+	// Scheduled export config lives in the settings table as one JSON
+	// RunExportSchedule document:
+	//
+	//	stn settings set runs.export.config '{"since_hours": 24, "destination": "s3://bucket/station-runs", "interval_hours": 24}'
+	runExportSettingKey = "runs.export.config"
+
+	// How long a bucket push (aws/gsutil subprocess) may take
+	runExportPushTimeout = 5 * time.Minute
+)
+
+// RunExportOptions controls a single export
+type RunExportOptions struct {
+	Format      string        // "jsonl" (parquet reserved; see Export)
+	Since       time.Duration // only runs started within this window; 0 = all
+	OutputDir   string        // directory the export files are written to
+	Destination string        // optional s3://... or gs://... bucket to push to
+}
+
+// RunExportSchedule configures periodic exports in serve mode
+type RunExportSchedule struct {
+	SinceHours    int    `json:"since_hours"`
+	OutputDir     string `json:"output_dir,omitempty"`
+	Destination   string `json:"destination,omitempty"`
+	IntervalHours int    `json:"interval_hours"`
+}
+
+// RunExportResult summarizes what an export produced
+type RunExportResult struct {
+	Runs             int
+	ToolCalls        int
+	ExecutionSteps   int
+	BenchmarkMetrics int
+	Files            []string
+}
+
+// RunExportService exports runs, tool calls, execution steps, and benchmark
+// metrics as flattened, schema-stable files for loading into data
+// warehouses. In serve mode it can run on a ticker and push to a bucket.
+type RunExportService struct {
+	repos    *repositories.Repositories
+	stopChan chan struct{}
+}
+
+// NewRunExportService creates a run export service
+func NewRunExportService(repos *repositories.Repositories) *RunExportService {
+	return &RunExportService{
+		repos:    repos,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// exportedRun is the flattened runs.jsonl record. Field order and names are
+// part of the export contract — add new fields at the end, never rename.
+type exportedRun struct {
+	RunID           int64    `json:"run_id"`
+	AgentID         int64    `json:"agent_id"`
+	AgentName       string   `json:"agent_name"`
+	Status          string   `json:"status"`
+	Task            string   `json:"task"`
+	StartedAt       string   `json:"started_at"`
+	CompletedAt     string   `json:"completed_at,omitempty"`
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+	StepsTaken      int64    `json:"steps_taken"`
+	InputTokens     *int64   `json:"input_tokens,omitempty"`
+	OutputTokens    *int64   `json:"output_tokens,omitempty"`
+	TotalTokens     *int64   `json:"total_tokens,omitempty"`
+	ModelName       string   `json:"model_name,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// exportedToolCall is the flattened tool_calls.jsonl record. Parameters
+// stay serialized so the column is schema-stable across tools.
+type exportedToolCall struct {
+	RunID           int64  `json:"run_id"`
+	Seq             int    `json:"seq"`
+	ToolName        string `json:"tool_name"`
+	Turn            *int64 `json:"turn,omitempty"`
+	EstimatedTokens *int64 `json:"estimated_tokens,omitempty"`
+	ParametersJSON  string `json:"parameters_json,omitempty"`
+}
+
+// exportedStep is the flattened execution_steps.jsonl record; the raw step
+// is kept serialized because step shapes vary by type
+type exportedStep struct {
+	RunID       int64  `json:"run_id"`
+	Seq         int    `json:"seq"`
+	Type        string `json:"type,omitempty"`
+	DetailsJSON string `json:"details_json"`
+}
+
+// exportedBenchmarkMetric is the flattened benchmark_metrics.jsonl record
+type exportedBenchmarkMetric struct {
+	RunID      int64   `json:"run_id"`
+	MetricName string  `json:"metric_name"`
+	Score      float64 `json:"score"`
+	Threshold  float64 `json:"threshold"`
+	Passed     bool    `json:"passed"`
+	JudgeModel string  `json:"judge_model,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// Export writes the export files and optionally pushes them to a bucket
+func (s *RunExportService) Export(ctx context.Context, opts RunExportOptions) (*RunExportResult, error) {
+	switch opts.Format {
+	case "", "jsonl":
+		// supported
+	case "parquet":
+		return nil, fmt.Errorf("parquet export is not supported in this build yet; use --format jsonl (BigQuery and Snowflake both load newline-delimited JSON natively)")
+	default:
+		return nil, fmt.Errorf("unsupported export format %q (supported: jsonl)", opts.Format)
+	}
+	if opts.OutputDir == "" {
+		return nil, fmt.Errorf("output directory is required")
+	}
+
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	runs, err := s.repos.AgentRuns.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	var cutoff time.Time
+	if opts.Since > 0 {
+		cutoff = time.Now().Add(-opts.Since)
+	}
+
+	agentNames := make(map[int64]string)
+	result := &RunExportResult{}
+	var exportedRuns []exportedRun
+	var toolCalls []exportedToolCall
+	var steps []exportedStep
+	var metrics []exportedBenchmarkMetric
+
+	for _, run := range runs {
+		if !cutoff.IsZero() && run.StartedAt.Before(cutoff) {
+			continue
+		}
+
+		name, cached := agentNames[run.AgentID]
+		if !cached {
+			if agent, err := s.repos.Agents.GetByID(run.AgentID); err == nil {
+				name = agent.Name
+			}
+			agentNames[run.AgentID] = name
+		}
+
+		record := exportedRun{
+			RunID:           run.ID,
+			AgentID:         run.AgentID,
+			AgentName:       name,
+			Status:          run.Status,
+			Task:            run.Task,
+			StartedAt:       run.StartedAt.UTC().Format(time.RFC3339),
+			DurationSeconds: run.DurationSeconds,
+			StepsTaken:      run.StepsTaken,
+			InputTokens:     run.InputTokens,
+			OutputTokens:    run.OutputTokens,
+			TotalTokens:     run.TotalTokens,
+		}
+		if run.CompletedAt != nil {
+			record.CompletedAt = run.CompletedAt.UTC().Format(time.RFC3339)
+		}
+		if run.ModelName != nil {
+			record.ModelName = *run.ModelName
+		}
+		if run.Error != nil {
+			record.Error = *run.Error
+		}
+		exportedRuns = append(exportedRuns, record)
+
+		toolCalls = append(toolCalls, flattenToolCalls(run)...)
+		steps = append(steps, flattenExecutionSteps(run)...)
+
+		if runMetrics, err := s.repos.BenchmarkMetrics.GetByRunID(ctx, run.ID); err == nil {
+			for _, m := range runMetrics {
+				metrics = append(metrics, exportedBenchmarkMetric{
+					RunID:      m.RunID,
+					MetricName: m.MetricName,
+					Score:      m.Score,
+					Threshold:  m.Threshold,
+					Passed:     m.Passed,
+					JudgeModel: m.JudgeModel,
+					CreatedAt:  m.CreatedAt.UTC().Format(time.RFC3339),
+				})
+			}
+		}
+	}
+
+	result.Runs = len(exportedRuns)
+	result.ToolCalls = len(toolCalls)
+	result.ExecutionSteps = len(steps)
+	result.BenchmarkMetrics = len(metrics)
+
+	files := map[string]interface{}{
+		"runs.jsonl":              exportedRuns,
+		"tool_calls.jsonl":        toolCalls,
+		"execution_steps.jsonl":   steps,
+		"benchmark_metrics.jsonl": metrics,
+	}
+	for filename, records := range files {
+		path := filepath.Join(opts.OutputDir, filename)
+		if err := writeJSONLFile(path, records); err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, path)
+	}
+
+	if opts.Destination != "" {
+		if err := s.pushToBucket(ctx, opts.OutputDir, opts.Destination); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// flattenToolCalls extracts schema-stable tool call records from a run
+func flattenToolCalls(run *models.AgentRun) []exportedToolCall {
+	if run.ToolCalls == nil {
+		return nil
+	}
+
+	var records []exportedToolCall
+	for i, entry := range *run.ToolCalls {
+		call, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		record := exportedToolCall{RunID: run.ID, Seq: i}
+		record.ToolName, _ = call["tool_name"].(string)
+		if turn, ok := call["turn"].(float64); ok {
+			t := int64(turn)
+			record.Turn = &t
+		}
+		if tokens, ok := call["estimated_tokens"].(float64); ok {
+			t := int64(tokens)
+			record.EstimatedTokens = &t
+		}
+		if params, ok := call["parameters"]; ok && params != nil {
+			if encoded, err := json.Marshal(params); err == nil {
+				record.ParametersJSON = string(encoded)
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// flattenExecutionSteps extracts schema-stable step records from a run
+func flattenExecutionSteps(run *models.AgentRun) []exportedStep {
+	if run.ExecutionSteps == nil {
+		return nil
+	}
+
+	var records []exportedStep
+	for i, entry := range *run.ExecutionSteps {
+		record := exportedStep{RunID: run.ID, Seq: i}
+		if step, ok := entry.(map[string]interface{}); ok {
+			record.Type, _ = step["type"].(string)
+		}
+		if encoded, err := json.Marshal(entry); err == nil {
+			record.DetailsJSON = string(encoded)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// writeJSONLFile writes records as newline-delimited JSON
+func writeJSONLFile(path string, records interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	switch typed := records.(type) {
+	case []exportedRun:
+		for _, r := range typed {
+			if err := encoder.Encode(r); err != nil {
+				return err
+			}
+		}
+	case []exportedToolCall:
+		for _, r := range typed {
+			if err := encoder.Encode(r); err != nil {
+				return err
+			}
+		}
+	case []exportedStep:
+		for _, r := range typed {
+			if err := encoder.Encode(r); err != nil {
+				return err
+			}
+		}
+	case []exportedBenchmarkMetric:
+		for _, r := range typed {
+			if err := encoder.Encode(r); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported record type %T", records)
+	}
+	return nil
+}
+
+// pushToBucket copies the export directory to an S3 or GCS bucket using the
+// installed cloud CLI (aws/gsutil), matching how GitOps shells out to git
+func (s *RunExportService) pushToBucket(ctx context.Context, dir, destination string) error {
+	ctx, cancel := context.WithTimeout(ctx, runExportPushTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch {
+	case strings.HasPrefix(destination, "s3://"):
+		cmd = exec.CommandContext(ctx, "aws", "s3", "cp", "--recursive", dir, destination)
+	case strings.HasPrefix(destination, "gs://"):
+		cmd = exec.CommandContext(ctx, "gsutil", "-m", "cp", "-r", filepath.Join(dir, "*.jsonl"), destination)
+	default:
+		return fmt.Errorf("unsupported destination %q (expected s3:// or gs:// URL)", destination)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to push export to %s: %w\n%s", destination, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// GetSchedule loads the scheduled export config from settings; nil when
+// scheduled exports are not configured
+func (s *RunExportService) GetSchedule() (*RunExportSchedule, error) {
+	setting, err := s.repos.Settings.GetByKey(runExportSettingKey)
+	if err != nil {
+		return nil, nil
+	}
+
+	var schedule RunExportSchedule
+	if err := json.Unmarshal([]byte(setting.Value), &schedule); err != nil {
+		return nil, fmt.Errorf("invalid run export config: %w", err)
+	}
+	if schedule.IntervalHours <= 0 {
+		schedule.IntervalHours = 24
+	}
+	if schedule.OutputDir == "" {
+		schedule.OutputDir = filepath.Join(os.TempDir(), "station-run-export")
+	}
+	return &schedule, nil
+}
+
+// Start begins scheduled exports when configured (serve mode). The settings
+// document is re-read every hour so schedule changes apply without restart.
+func (s *RunExportService) Start() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		var lastExport time.Time
+		for {
+			select {
+			case <-ticker.C:
+				schedule, err := s.GetSchedule()
+				if err != nil {
+					logging.Info("Run export: %v", err)
+					continue
+				}
+				if schedule == nil {
+					continue
+				}
+				if time.Since(lastExport) < time.Duration(schedule.IntervalHours)*time.Hour {
+					continue
+				}
+
+				opts := RunExportOptions{
+					Format:      "jsonl",
+					OutputDir:   schedule.OutputDir,
+					Destination: schedule.Destination,
+				}
+				if schedule.SinceHours > 0 {
+					opts.Since = time.Duration(schedule.SinceHours) * time.Hour
+				}
+				if result, err := s.Export(context.Background(), opts); err != nil {
+					logging.Info("Run export: scheduled export failed: %v", err)
+				} else {
+					lastExport = time.Now()
+					logging.Info("Run export: exported %d runs (%d tool calls) to %s", result.Runs, result.ToolCalls, schedule.OutputDir)
+				}
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts scheduled exports
+func (s *RunExportService) Stop() {
+	close(s.stopChan)
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+func setupExportTestRuns(t *testing.T) (*RunExportService, *repositories.Repositories, *db.TestDB, context.Context) {
+	t.Helper()
+	ctx := context.Background()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("export-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("export-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Export Agent", "exports", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	toolCalls := models.JSONArray{
+		map[string]interface{}{
+			"tool_name":        "__read_text_file",
+			"turn":             float64(1),
+			"estimated_tokens": float64(120),
+			"parameters":       map[string]interface{}{"path": "/tmp/a.txt"},
+		},
+		map[string]interface{}{
+			"tool_name": "__list_directory",
+		},
+	}
+	steps := models.JSONArray{
+		map[string]interface{}{"type": "tool_call", "tool": "__read_text_file"},
+	}
+	run, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "export test task", "", 0,
+		nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+	completedAt := time.Now()
+	if err := repos.AgentRuns.UpdateCompletionWithMetadata(ctx, run.ID, "done", 2,
+		&toolCalls, &steps, "completed", &completedAt,
+		nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("failed to complete run: %v", err)
+	}
+
+	return NewRunExportService(repos), repos, testDB, ctx
+}
+
+func countJSONLRecords(t *testing.T, path string) int {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid JSONL line in %s: %v", path, err)
+		}
+		count++
+	}
+	return count
+}
+
+func TestRunExportWritesJSONLFiles(t *testing.T) {
+	svc, _, _, ctx := setupExportTestRuns(t)
+	outputDir := t.TempDir()
+
+	result, err := svc.Export(ctx, RunExportOptions{Format: "jsonl", OutputDir: outputDir})
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+
+	if result.Runs != 1 {
+		t.Errorf("expected 1 run, got %d", result.Runs)
+	}
+	if result.ToolCalls != 2 {
+		t.Errorf("expected 2 tool calls, got %d", result.ToolCalls)
+	}
+	if result.ExecutionSteps != 1 {
+		t.Errorf("expected 1 execution step, got %d", result.ExecutionSteps)
+	}
+
+	for _, filename := range []string{"runs.jsonl", "tool_calls.jsonl", "execution_steps.jsonl", "benchmark_metrics.jsonl"} {
+		if _, err := os.Stat(filepath.Join(outputDir, filename)); err != nil {
+			t.Errorf("expected %s to exist: %v", filename, err)
+		}
+	}
+	if got := countJSONLRecords(t, filepath.Join(outputDir, "runs.jsonl")); got != 1 {
+		t.Errorf("expected 1 record in runs.jsonl, got %d", got)
+	}
+	if got := countJSONLRecords(t, filepath.Join(outputDir, "tool_calls.jsonl")); got != 2 {
+		t.Errorf("expected 2 records in tool_calls.jsonl, got %d", got)
+	}
+}
+
+func TestRunExportFlattensToolCalls(t *testing.T) {
+	svc, _, _, ctx := setupExportTestRuns(t)
+	outputDir := t.TempDir()
+
+	if _, err := svc.Export(ctx, RunExportOptions{OutputDir: outputDir}); err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "tool_calls.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to read tool_calls.jsonl: %v", err)
+	}
+
+	var first map[string]interface{}
+	if err := json.Unmarshal([]byte(splitFirstLine(string(data))), &first); err != nil {
+		t.Fatalf("failed to parse first tool call record: %v", err)
+	}
+	if first["tool_name"] != "__read_text_file" {
+		t.Errorf("expected tool_name __read_text_file, got %v", first["tool_name"])
+	}
+	if first["turn"] != float64(1) {
+		t.Errorf("expected turn 1, got %v", first["turn"])
+	}
+	if first["estimated_tokens"] != float64(120) {
+		t.Errorf("expected estimated_tokens 120, got %v", first["estimated_tokens"])
+	}
+	params, ok := first["parameters_json"].(string)
+	if !ok || params == "" {
+		t.Fatalf("expected parameters_json to be a serialized string, got %v", first["parameters_json"])
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(params), &parsed); err != nil {
+		t.Errorf("parameters_json is not valid JSON: %v", err)
+	}
+}
+
+func TestRunExportSinceFilter(t *testing.T) {
+	svc, _, testDB, ctx := setupExportTestRuns(t)
+
+	// Backdate the run so it falls outside the window
+	if _, err := testDB.Conn().Exec("UPDATE agent_runs SET started_at = ?", time.Now().Add(-48*time.Hour)); err != nil {
+		t.Fatalf("failed to backdate run: %v", err)
+	}
+
+	outputDir := t.TempDir()
+	result, err := svc.Export(ctx, RunExportOptions{OutputDir: outputDir, Since: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	if result.Runs != 0 {
+		t.Errorf("expected 0 runs inside 24h window, got %d", result.Runs)
+	}
+}
+
+func TestRunExportRejectsUnsupportedFormats(t *testing.T) {
+	svc, _, _, ctx := setupExportTestRuns(t)
+
+	if _, err := svc.Export(ctx, RunExportOptions{Format: "parquet", OutputDir: t.TempDir()}); err == nil {
+		t.Error("expected error for parquet format")
+	}
+	if _, err := svc.Export(ctx, RunExportOptions{Format: "csv", OutputDir: t.TempDir()}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if _, err := svc.Export(ctx, RunExportOptions{Format: "jsonl"}); err == nil {
+		t.Error("expected error when output directory is missing")
+	}
+}
+
+func TestRunExportScheduleDefaults(t *testing.T) {
+	svc, repos, _, _ := setupExportTestRuns(t)
+
+	schedule, err := svc.GetSchedule()
+	if err != nil {
+		t.Fatalf("GetSchedule returned error: %v", err)
+	}
+	if schedule != nil {
+		t.Fatal("expected nil schedule when unconfigured")
+	}
+
+	if err := repos.Settings.Set(runExportSettingKey, `{"since_hours": 24, "destination": "s3://bucket/runs"}`, "test"); err != nil {
+		t.Fatalf("failed to save schedule: %v", err)
+	}
+	schedule, err = svc.GetSchedule()
+	if err != nil {
+		t.Fatalf("GetSchedule returned error: %v", err)
+	}
+	if schedule == nil {
+		t.Fatal("expected schedule after configuring")
+	}
+	if schedule.IntervalHours != 24 {
+		t.Errorf("expected default interval of 24h, got %d", schedule.IntervalHours)
+	}
+	if schedule.OutputDir == "" {
+		t.Error("expected default output directory")
+	}
+}
+
+func splitFirstLine(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			return s[:i]
+		}
+	}
+	return s
+}
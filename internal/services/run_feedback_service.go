@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"station/internal/db/repositories"
+	pkgbenchmark "station/pkg/benchmark"
+	"station/pkg/models"
+)
+
+// feedbackBenchmarkCategory groups benchmark tasks seeded from annotated runs
+const feedbackBenchmarkCategory = "human-feedback"
+
+// RunFeedbackService records human feedback (ratings, thumbs, comments) on
+// runs and can promote annotated runs into benchmark tasks so supervised
+// evaluation covers the cases operators actually cared about
+type RunFeedbackService struct {
+	repos *repositories.Repositories
+}
+
+// NewRunFeedbackService creates a run feedback service
+func NewRunFeedbackService(repos *repositories.Repositories) *RunFeedbackService {
+	return &RunFeedbackService{repos: repos}
+}
+
+// Annotate attaches feedback to a run. At least one of rating, thumbs, or
+// comment must be provided; the run must exist.
+func (s *RunFeedbackService) Annotate(ctx context.Context, runID int64, rating *int64, thumbs *string, comment, createdBy string) (*models.RunAnnotation, error) {
+	if rating == nil && thumbs == nil && strings.TrimSpace(comment) == "" {
+		return nil, fmt.Errorf("nothing to record: provide a rating, thumbs, or comment")
+	}
+
+	if _, err := s.repos.AgentRuns.GetByID(ctx, runID); err != nil {
+		return nil, fmt.Errorf("run %d not found: %w", runID, err)
+	}
+
+	return s.repos.RunAnnotations.Create(ctx, runID, rating, thumbs, strings.TrimSpace(comment), createdBy)
+}
+
+// SeedBenchmarkTask creates (or refreshes) a benchmark task from an annotated
+// run: the run's task becomes the benchmark description, its final response
+// the expected output example, and the annotation comment the evaluation
+// criteria. Only completed runs make useful supervised examples.
+func (s *RunFeedbackService) SeedBenchmarkTask(ctx context.Context, runID int64, annotation *models.RunAnnotation) (string, error) {
+	run, err := s.repos.AgentRuns.GetByID(ctx, runID)
+	if err != nil {
+		return "", fmt.Errorf("run %d not found: %w", runID, err)
+	}
+	if run.Status != "completed" {
+		return "", fmt.Errorf("run %d is %s; only completed runs can seed benchmark tasks", runID, run.Status)
+	}
+	if run.FinalResponse == "" {
+		return "", fmt.Errorf("run %d has no final response to use as an expected output example", runID)
+	}
+
+	criteria := "Output should match the quality of the human-approved example response."
+	if annotation != nil && annotation.Comment != "" {
+		criteria = fmt.Sprintf("Human feedback on the reference run: %s", annotation.Comment)
+	}
+
+	name := fmt.Sprintf("feedback-run-%d", runID)
+	weights := pkgbenchmark.DefaultWeights
+	if _, err := s.repos.BenchmarkTasks.Upsert(ctx, &repositories.BenchmarkTask{
+		Name:                  name,
+		Category:              feedbackBenchmarkCategory,
+		Description:           run.Task,
+		ExpectedOutputExample: run.FinalResponse,
+		EvaluationCriteria:    criteria,
+		TaskCompletionWeight:  weights.TaskCompletion,
+		RelevancyWeight:       weights.Relevancy,
+		HallucinationWeight:   weights.Hallucination,
+		FaithfulnessWeight:    weights.Faithfulness,
+		ToxicityWeight:        weights.Toxicity,
+	}); err != nil {
+		return "", fmt.Errorf("failed to seed benchmark task: %w", err)
+	}
+	return name, nil
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestRunFeedbackAnnotate(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	ctx := context.Background()
+
+	user, err := repos.Users.GetByUsername("console")
+	if err != nil {
+		t.Fatalf("failed to load console user: %v", err)
+	}
+	env, err := repos.Environments.GetByName("default")
+	if err != nil {
+		t.Fatalf("failed to load default environment: %v", err)
+	}
+	agent, err := repos.Agents.Create("feedback-agent", "d", "prompt", 5, env.ID, user.ID, nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	run, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "scan the clusters", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+
+	service := NewRunFeedbackService(repos)
+
+	rating := int64(4)
+	annotation, err := service.Annotate(ctx, run.ID, &rating, nil, "  missed the staging cluster  ", "alice")
+	if err != nil {
+		t.Fatalf("Annotate failed: %v", err)
+	}
+	if annotation.Comment != "missed the staging cluster" {
+		t.Errorf("expected trimmed comment, got %q", annotation.Comment)
+	}
+	if annotation.CreatedBy != "alice" {
+		t.Errorf("expected created_by recorded, got %q", annotation.CreatedBy)
+	}
+
+	// Empty feedback is rejected
+	if _, err := service.Annotate(ctx, run.ID, nil, nil, "   ", "alice"); err == nil {
+		t.Error("expected error for annotation with no content")
+	}
+
+	// Unknown runs are rejected
+	if _, err := service.Annotate(ctx, 99999, &rating, nil, "", "alice"); err == nil {
+		t.Error("expected error annotating a missing run")
+	}
+}
+
+func TestRunFeedbackSeedBenchmarkTask(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	ctx := context.Background()
+
+	user, err := repos.Users.GetByUsername("console")
+	if err != nil {
+		t.Fatalf("failed to load console user: %v", err)
+	}
+	env, err := repos.Environments.GetByName("default")
+	if err != nil {
+		t.Fatalf("failed to load default environment: %v", err)
+	}
+	agent, err := repos.Agents.Create("seed-agent", "d", "prompt", 5, env.ID, user.ID, nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	run, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "summarize cloud spend", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+
+	service := NewRunFeedbackService(repos)
+
+	// Incomplete runs cannot seed tasks
+	if _, err := service.SeedBenchmarkTask(ctx, run.ID, nil); err == nil {
+		t.Error("expected error seeding from a running run")
+	}
+
+	completedAt := time.Now()
+	if err := repos.AgentRuns.UpdateCompletionWithMetadata(ctx, run.ID, "Spend was $42k, up 3%", 1, nil, nil, "completed", &completedAt,
+		nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("failed to complete run: %v", err)
+	}
+
+	rating := int64(5)
+	annotation, err := service.Annotate(ctx, run.ID, &rating, nil, "great summary, keep the delta callout", "alice")
+	if err != nil {
+		t.Fatalf("Annotate failed: %v", err)
+	}
+
+	taskName, err := service.SeedBenchmarkTask(ctx, run.ID, annotation)
+	if err != nil {
+		t.Fatalf("SeedBenchmarkTask failed: %v", err)
+	}
+
+	tasks, err := repos.BenchmarkTasks.GetByCategory(ctx, feedbackBenchmarkCategory)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("expected 1 seeded benchmark task, got %d (err: %v)", len(tasks), err)
+	}
+	task := tasks[0]
+	if task.Name != taskName || task.Description != "summarize cloud spend" {
+		t.Errorf("unexpected seeded task: %+v", task)
+	}
+	if task.ExpectedOutputExample != "Spend was $42k, up 3%" {
+		t.Errorf("expected final response as expected output, got %q", task.ExpectedOutputExample)
+	}
+	if task.EvaluationCriteria == "" || task.TaskCompletionWeight == 0 {
+		t.Errorf("expected criteria and default weights populated, got %+v", task)
+	}
+
+	// Seeding again upserts rather than duplicating
+	if _, err := service.SeedBenchmarkTask(ctx, run.ID, annotation); err != nil {
+		t.Fatalf("re-seeding failed: %v", err)
+	}
+	tasks, _ = repos.BenchmarkTasks.GetByCategory(ctx, feedbackBenchmarkCategory)
+	if len(tasks) != 1 {
+		t.Errorf("expected upsert to keep a single task, got %d", len(tasks))
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+// Webhook and CI triggered executions can double-fire identical requests.
+// Callers pass an idempotency key with the run request (API body/header or
+// `stn agent run --idempotency-key`); when the same agent+key arrives again
+// within the dedup window the original run is returned instead of starting a
+// new one. Records live in the settings table under idempotency.run.* and the
+// window is adjustable via `stn settings set run_dedup.window 30m`.
+const (
+	runIdempotencySettingPrefix = "idempotency.run."
+	runDedupWindowSettingKey    = "run_dedup.window"
+
+	defaultRunDedupWindow = 10 * time.Minute
+)
+
+// RunIdempotencyRecord associates an idempotency key with the run it created
+type RunIdempotencyRecord struct {
+	AgentID   int64  `json:"agent_id"`
+	Key       string `json:"key"`
+	RunID     int64  `json:"run_id"`
+	Task      string `json:"task"`
+	CreatedAt string `json:"created_at"`
+}
+
+// RunIdempotencyService deduplicates run-creation requests by idempotency key
+type RunIdempotencyService struct {
+	repos *repositories.Repositories
+	// now is replaceable in tests
+	now func() time.Time
+}
+
+func NewRunIdempotencyService(repos *repositories.Repositories) *RunIdempotencyService {
+	return &RunIdempotencyService{repos: repos, now: time.Now}
+}
+
+// runIdempotencyKey derives the settings key for an agent+key pair; the raw
+// key is hashed so arbitrary caller-supplied strings stay storable
+func runIdempotencyKey(agentID int64, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("%s%d.%s", runIdempotencySettingPrefix, agentID, hex.EncodeToString(sum[:8]))
+}
+
+// Window returns the dedup window, honoring the run_dedup.window setting
+func (s *RunIdempotencyService) Window() time.Duration {
+	setting, err := s.repos.Settings.GetByKey(runDedupWindowSettingKey)
+	if err != nil {
+		return defaultRunDedupWindow
+	}
+	window, err := time.ParseDuration(setting.Value)
+	if err != nil || window <= 0 {
+		logging.Debug("Invalid %s value %q, using default %s", runDedupWindowSettingKey, setting.Value, defaultRunDedupWindow)
+		return defaultRunDedupWindow
+	}
+	return window
+}
+
+// Check returns the record of a previous run created with the same agent and
+// idempotency key inside the dedup window, or nil when the request is new.
+// Expired records are cleared so the key can be reused.
+func (s *RunIdempotencyService) Check(agentID int64, key string) (*RunIdempotencyRecord, error) {
+	settingKey := runIdempotencyKey(agentID, key)
+	setting, err := s.repos.Settings.GetByKey(settingKey)
+	if err != nil {
+		return nil, nil
+	}
+
+	var record RunIdempotencyRecord
+	if err := json.Unmarshal([]byte(setting.Value), &record); err != nil {
+		return nil, fmt.Errorf("failed to parse idempotency record: %w", err)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, record.CreatedAt)
+	if err != nil || s.now().Sub(createdAt) > s.Window() {
+		if err := s.repos.Settings.Delete(settingKey); err != nil {
+			logging.Debug("Failed to clear expired idempotency record %s: %v", settingKey, err)
+		}
+		return nil, nil
+	}
+	return &record, nil
+}
+
+// Record associates an idempotency key with the run it created
+func (s *RunIdempotencyService) Record(agentID int64, key string, runID int64, task string) error {
+	record := RunIdempotencyRecord{
+		AgentID:   agentID,
+		Key:       key,
+		RunID:     runID,
+		Task:      task,
+		CreatedAt: s.now().UTC().Format(time.RFC3339),
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to serialize idempotency record: %w", err)
+	}
+	return s.repos.Settings.Set(runIdempotencyKey(agentID, key), string(data), "Run idempotency record")
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestRunIdempotencyDedupWindow(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	service := NewRunIdempotencyService(repos)
+
+	clock := time.Now()
+	service.now = func() time.Time { return clock }
+
+	const agentID = int64(1)
+	const key = "deploy-webhook-abc123"
+
+	// Unknown key is a new request
+	if record, err := service.Check(agentID, key); err != nil || record != nil {
+		t.Fatalf("expected no record for a new key, got %v (%v)", record, err)
+	}
+
+	if err := service.Record(agentID, key, 42, "deploy to prod"); err != nil {
+		t.Fatalf("failed to record idempotency key: %v", err)
+	}
+
+	// Inside the window the original run is returned
+	clock = clock.Add(time.Minute)
+	record, err := service.Check(agentID, key)
+	if err != nil || record == nil {
+		t.Fatalf("expected duplicate record inside the window, got %v (%v)", record, err)
+	}
+	if record.RunID != 42 || record.Task != "deploy to prod" {
+		t.Errorf("expected run 42 with original task, got %+v", record)
+	}
+
+	// The same key for a different agent is a separate request
+	if record, _ := service.Check(2, key); record != nil {
+		t.Errorf("expected per-agent key scoping, got %+v", record)
+	}
+
+	// Past the window the record expires and the key is reusable
+	clock = clock.Add(defaultRunDedupWindow)
+	if record, _ := service.Check(agentID, key); record != nil {
+		t.Errorf("expected record expired after the dedup window, got %+v", record)
+	}
+	if record, _ := service.Check(agentID, key); record != nil {
+		t.Errorf("expected expired record cleared, got %+v", record)
+	}
+}
+
+func TestRunIdempotencyWindowSetting(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	service := NewRunIdempotencyService(repos)
+
+	if got := service.Window(); got != defaultRunDedupWindow {
+		t.Errorf("expected default window %s, got %s", defaultRunDedupWindow, got)
+	}
+
+	if err := repos.Settings.Set(runDedupWindowSettingKey, "30m", "test"); err != nil {
+		t.Fatalf("failed to set window: %v", err)
+	}
+	if got := service.Window(); got != 30*time.Minute {
+		t.Errorf("expected 30m window from setting, got %s", got)
+	}
+
+	if err := repos.Settings.Set(runDedupWindowSettingKey, "not-a-duration", "test"); err != nil {
+		t.Fatalf("failed to set window: %v", err)
+	}
+	if got := service.Window(); got != defaultRunDedupWindow {
+		t.Errorf("expected default window for invalid setting, got %s", got)
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+const (
+	runQueueStream   = "STATION_RUNS"
+	runQueueSubject  = "station.runs.execute"
+	runQueueConsumer = "station-run-worker"
+
+	// Agent runs are long; keep the message claimed while a worker heartbeats
+	// and only redeliver when a worker dies mid-run
+	runQueueAckWait      = 2 * time.Minute
+	runQueueHeartbeat    = 30 * time.Second
+	runQueueMaxDeliver   = 2
+	runQueueFetchTimeout = 5 * time.Second
+)
+
+// runQueueRequest is the JSON document published for each queued run. The
+// run record lives in the shared database; the message only carries what a
+// worker needs to pick it up.
+type runQueueRequest struct {
+	RunID     int64                  `json:"run_id"`
+	AgentID   int64                  `json:"agent_id"`
+	Task      string                 `json:"task"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// RunQueueService distributes agent run requests over NATS JetStream so any
+// number of worker Stations sharing the same database can execute them
+// (`stn serve --worker`). The publishing Station creates the run record and
+// enqueues a request; whichever worker receives it executes the agent and
+// updates the shared run row. Workers bind to one durable work-queue
+// consumer, so JetStream spreads requests across however many are running.
+type RunQueueService struct {
+	js           nats.JetStreamContext
+	repos        *repositories.Repositories
+	agentService AgentServiceInterface
+	sub          *nats.Subscription
+}
+
+// NewRunQueueService creates the run queue on an existing JetStream
+// connection (typically the workflow engine's), creating the stream when it
+// does not exist yet
+func NewRunQueueService(js nats.JetStreamContext, repos *repositories.Repositories, agentService AgentServiceInterface) (*RunQueueService, error) {
+	if js == nil {
+		return nil, fmt.Errorf("run queue requires a JetStream connection")
+	}
+
+	// Work-queue retention: each request is delivered to exactly one worker
+	streamConfig := &nats.StreamConfig{
+		Name:      runQueueStream,
+		Subjects:  []string{runQueueSubject},
+		Storage:   nats.FileStorage,
+		Retention: nats.WorkQueuePolicy,
+	}
+	if _, err := js.AddStream(streamConfig); err != nil && !errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		// Embedded NATS servers run without a store directory; fall back to
+		// memory storage so single-process setups still work
+		streamConfig.Storage = nats.MemoryStorage
+		if _, memErr := js.AddStream(streamConfig); memErr != nil && !errors.Is(memErr, nats.ErrStreamNameAlreadyInUse) {
+			return nil, fmt.Errorf("failed to create run queue stream: %w", err)
+		}
+		log.Printf("Run queue: using in-memory stream (queued runs will not survive a NATS restart)")
+	}
+
+	return &RunQueueService{js: js, repos: repos, agentService: agentService}, nil
+}
+
+// Enqueue creates the run record in the shared database and publishes a
+// request for a worker to pick up. Runs that would exceed the org quota
+// pushed from CloudShip are rejected before a run record is created.
+func (s *RunQueueService) Enqueue(ctx context.Context, agentID, userID int64, task string, variables map[string]interface{}) (*models.AgentRun, error) {
+	if err := NewQuotaService(s.repos).CheckRun(ctx, agentID); err != nil {
+		return nil, err
+	}
+
+	agentRun, err := s.repos.AgentRuns.Create(ctx, agentID, userID, task, "", 0, nil, nil, "queued", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent run: %w", err)
+	}
+
+	payload, err := json.Marshal(runQueueRequest{
+		RunID:     agentRun.ID,
+		AgentID:   agentID,
+		Task:      task,
+		Variables: variables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal run request: %w", err)
+	}
+
+	if _, err := s.js.Publish(runQueueSubject, payload); err != nil {
+		errMsg := fmt.Sprintf("failed to publish run request: %v", err)
+		completedAt := time.Now()
+		_ = s.repos.AgentRuns.UpdateCompletionWithMetadata(
+			ctx, agentRun.ID, errMsg, 0, nil, nil, "failed", &completedAt,
+			nil, nil, nil, nil, nil, nil, &errMsg,
+		)
+		return nil, fmt.Errorf("failed to publish run request: %w", err)
+	}
+
+	log.Printf("Run queue: enqueued run %d for agent %d", agentRun.ID, agentID)
+	return agentRun, nil
+}
+
+// StartWorker binds to the shared durable consumer and executes queued runs
+// until the context is cancelled. Multiple workers bind to the same
+// consumer; JetStream distributes requests among them.
+func (s *RunQueueService) StartWorker(ctx context.Context) error {
+	consumerConfig := &nats.ConsumerConfig{
+		Durable:       runQueueConsumer,
+		FilterSubject: runQueueSubject,
+		AckPolicy:     nats.AckExplicitPolicy,
+		AckWait:       runQueueAckWait,
+		MaxDeliver:    runQueueMaxDeliver,
+	}
+	if _, err := s.js.AddConsumer(runQueueStream, consumerConfig); err != nil {
+		// Already exists when another worker set it up first
+		log.Printf("Run queue: consumer setup note: %v", err)
+	}
+
+	sub, err := s.js.PullSubscribe(runQueueSubject, runQueueConsumer, nats.Bind(runQueueStream, runQueueConsumer))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to run queue: %w", err)
+	}
+	s.sub = sub
+
+	go s.fetchLoop(ctx)
+	return nil
+}
+
+func (s *RunQueueService) fetchLoop(ctx context.Context) {
+	for {
+		if ctx.Err() != nil || !s.sub.IsValid() {
+			return
+		}
+
+		msgs, err := s.sub.Fetch(1, nats.MaxWait(runQueueFetchTimeout))
+		if err != nil {
+			if err == nats.ErrTimeout {
+				continue
+			}
+			if err == nats.ErrConnectionClosed || err == nats.ErrConsumerDeleted {
+				log.Printf("Run queue: connection or consumer closed, stopping worker")
+				return
+			}
+			log.Printf("Run queue: fetch error: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, msg := range msgs {
+			s.handleMessage(ctx, msg)
+		}
+	}
+}
+
+func (s *RunQueueService) handleMessage(ctx context.Context, msg *nats.Msg) {
+	var req runQueueRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		log.Printf("Run queue: dropping malformed run request: %v", err)
+		_ = msg.Ack()
+		return
+	}
+
+	// Heartbeat the in-flight message so JetStream does not redeliver the run
+	// to another worker while the agent is still executing
+	heartbeatDone := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(runQueueHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_ = msg.InProgress()
+			case <-heartbeatDone:
+				return
+			}
+		}
+	}()
+
+	log.Printf("Run queue: executing run %d (agent %d)", req.RunID, req.AgentID)
+	if err := s.repos.AgentRuns.UpdateStatus(ctx, req.RunID, "running"); err != nil {
+		log.Printf("Run queue: failed to mark run %d running: %v", req.RunID, err)
+	}
+
+	_, err := s.agentService.ExecuteAgentWithRunID(ctx, req.AgentID, req.Task, req.RunID, req.Variables)
+	close(heartbeatDone)
+	if err != nil {
+		log.Printf("Run queue: run %d failed: %v", req.RunID, err)
+	} else {
+		log.Printf("Run queue: run %d completed", req.RunID)
+	}
+
+	// Ack regardless of outcome - failures are recorded on the run row and
+	// should not be re-executed by another worker
+	_ = msg.Ack()
+}
+
+// Stop unsubscribes the worker from the run queue
+func (s *RunQueueService) Stop() {
+	if s.sub != nil {
+		_ = s.sub.Unsubscribe()
+	}
+}
@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+// RunTimelineService renders a run's execution history as a self-contained
+// HTML Gantt-style timeline (stn runs inspect <id> --timeline). Tool call
+// durations come from OTEL span timings when the run captured them
+// (duration_ms per call); runs without per-call timings fall back to evenly
+// distributing calls across the run's total duration.
+type RunTimelineService struct {
+	repos *repositories.Repositories
+}
+
+func NewRunTimelineService(repos *repositories.Repositories) *RunTimelineService {
+	return &RunTimelineService{repos: repos}
+}
+
+// timelineBar is one row in the rendered Gantt chart
+type timelineBar struct {
+	Label       string
+	Lane        string // "turn" or "tool"
+	LeftPct     float64
+	WidthPct    float64
+	DurationMs  int64
+	HasDuration bool
+	Tokens      int64
+	Failed      bool
+}
+
+// tokenPoint is one step of the cumulative token accumulation chart
+type tokenPoint struct {
+	Label      string
+	Cumulative int64
+	Pct        float64
+}
+
+type timelinePage struct {
+	RunID       int64
+	AgentName   string
+	Status      string
+	StatusClass string
+	Task        string
+	StartedAt   string
+	TotalMs     int64
+	TotalLabel  string
+	ModelName   string
+	TotalTokens int64
+	Bars        []timelineBar
+	TokenPoints []tokenPoint
+	Estimated   bool
+	GeneratedAt string
+}
+
+// GenerateHTML builds the standalone timeline page for a run
+func (s *RunTimelineService) GenerateHTML(ctx context.Context, runID int64) (string, error) {
+	run, err := s.repos.AgentRuns.GetByIDWithDetails(ctx, runID)
+	if err != nil {
+		return "", fmt.Errorf("run %d not found: %w", runID, err)
+	}
+
+	totalMs := runTotalMs(&run.AgentRun)
+	if totalMs <= 0 {
+		return "", fmt.Errorf("run %d has no recorded duration (still running?)", runID)
+	}
+
+	page := timelinePage{
+		RunID:       run.ID,
+		AgentName:   run.AgentName,
+		Status:      run.Status,
+		StatusClass: statusClass(run.Status),
+		Task:        run.Task,
+		StartedAt:   run.StartedAt.Format("Jan 2, 2006 15:04:05 MST"),
+		TotalMs:     totalMs,
+		TotalLabel:  formatDurationMs(totalMs),
+		GeneratedAt: time.Now().Format("2006-01-02 15:04:05 MST"),
+	}
+	if run.ModelName != nil {
+		page.ModelName = *run.ModelName
+	}
+	if run.TotalTokens != nil {
+		page.TotalTokens = *run.TotalTokens
+	}
+
+	page.Bars, page.Estimated = buildTimelineBars(run, totalMs)
+	page.TokenPoints = buildTokenPoints(run.ToolCalls)
+
+	var out strings.Builder
+	if err := runTimelineTemplate.Execute(&out, page); err != nil {
+		return "", fmt.Errorf("failed to render timeline: %w", err)
+	}
+	return out.String(), nil
+}
+
+// runTotalMs returns the run's wall-clock duration in milliseconds
+func runTotalMs(run *models.AgentRun) int64 {
+	if run.DurationSeconds != nil && *run.DurationSeconds > 0 {
+		return int64(*run.DurationSeconds * 1000)
+	}
+	if run.CompletedAt != nil {
+		return run.CompletedAt.Sub(run.StartedAt).Milliseconds()
+	}
+	return 0
+}
+
+// buildTimelineBars lays the run's turns and tool calls out on the timeline.
+// When per-call durations (OTEL span timings) are missing, the calls are
+// distributed evenly across the run and the chart is marked as estimated.
+func buildTimelineBars(run *models.AgentRunWithDetails, totalMs int64) ([]timelineBar, bool) {
+	type callInfo struct {
+		name       string
+		turn       int
+		durationMs int64
+		tokens     int64
+		failed     bool
+	}
+
+	var calls []callInfo
+	haveDurations := true
+	if run.ToolCalls != nil {
+		for _, entry := range *run.ToolCalls {
+			call, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := call["tool_name"].(string)
+			if name == "" {
+				continue
+			}
+			info := callInfo{name: name}
+			if turn, ok := call["turn"].(float64); ok {
+				info.turn = int(turn)
+			}
+			if duration, ok := call["duration_ms"].(float64); ok && duration > 0 {
+				info.durationMs = int64(duration)
+			} else {
+				haveDurations = false
+			}
+			if tokens, ok := call["estimated_tokens"].(float64); ok {
+				info.tokens = int64(tokens)
+			}
+			if success, ok := call["success"].(bool); ok && !success {
+				info.failed = true
+			}
+			calls = append(calls, info)
+		}
+	}
+
+	if len(calls) == 0 {
+		return nil, false
+	}
+
+	// Without real timings, give every call an equal slice of the run
+	if !haveDurations {
+		slice := totalMs / int64(len(calls))
+		for i := range calls {
+			calls[i].durationMs = slice
+		}
+	}
+
+	var bars []timelineBar
+	var offsetMs int64
+	currentTurn := -1
+	turnStartMs := int64(0)
+	flushTurn := func(endMs int64) {
+		if currentTurn < 0 {
+			return
+		}
+		bars = append(bars, timelineBar{
+			Label:       fmt.Sprintf("Turn %d", currentTurn+1),
+			Lane:        "turn",
+			LeftPct:     pct(turnStartMs, totalMs),
+			WidthPct:    pct(endMs-turnStartMs, totalMs),
+			DurationMs:  endMs - turnStartMs,
+			HasDuration: haveDurations,
+		})
+	}
+
+	for _, call := range calls {
+		if call.turn != currentTurn {
+			flushTurn(offsetMs)
+			currentTurn = call.turn
+			turnStartMs = offsetMs
+		}
+		bars = append(bars, timelineBar{
+			Label:       call.name,
+			Lane:        "tool",
+			LeftPct:     pct(offsetMs, totalMs),
+			WidthPct:    pct(call.durationMs, totalMs),
+			DurationMs:  call.durationMs,
+			HasDuration: haveDurations,
+			Tokens:      call.tokens,
+			Failed:      call.failed,
+		})
+		offsetMs += call.durationMs
+	}
+	flushTurn(offsetMs)
+
+	return bars, !haveDurations
+}
+
+// buildTokenPoints computes the cumulative token accumulation across tool calls
+func buildTokenPoints(toolCalls *models.JSONArray) []tokenPoint {
+	if toolCalls == nil {
+		return nil
+	}
+
+	var points []tokenPoint
+	var cumulative int64
+	for _, entry := range *toolCalls {
+		call, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := call["tool_name"].(string)
+		tokens, _ := call["estimated_tokens"].(float64)
+		if name == "" || tokens <= 0 {
+			continue
+		}
+		cumulative += int64(tokens)
+		points = append(points, tokenPoint{Label: name, Cumulative: cumulative})
+	}
+
+	if cumulative == 0 {
+		return nil
+	}
+	for i := range points {
+		points[i].Pct = float64(points[i].Cumulative) / float64(cumulative) * 100
+	}
+	return points
+}
+
+func pct(value, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	p := float64(value) / float64(total) * 100
+	if p < 0.5 && value > 0 {
+		return 0.5 // keep tiny bars visible
+	}
+	return p
+}
+
+func formatDurationMs(ms int64) string {
+	if ms < 1000 {
+		return fmt.Sprintf("%dms", ms)
+	}
+	return fmt.Sprintf("%.1fs", float64(ms)/1000)
+}
+
+func statusClass(status string) string {
+	switch status {
+	case "completed":
+		return "completed"
+	case "failed":
+		return "failed"
+	default:
+		return "other"
+	}
+}
+
+// runTimelineTemplate renders the standalone timeline page. Same Tokyo Night
+// palette as the share page so exported artifacts look consistent.
+var runTimelineTemplate = template.Must(template.New("timeline").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Run {{.RunID}} Timeline - Station</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; background: #1a1b26; color: #c0caf5; margin: 0; padding: 2rem; }
+.container { max-width: 1100px; margin: 0 auto; }
+h1 { color: #7aa2f7; font-size: 1.4rem; }
+h2 { color: #bb9af7; font-size: 1rem; }
+.meta { color: #565f89; font-size: 0.85rem; margin-bottom: 1.5rem; }
+.status { display: inline-block; padding: 0.15rem 0.6rem; border-radius: 4px; font-size: 0.8rem; }
+.status-completed { background: #1f3d2e; color: #9ece6a; }
+.status-failed { background: #3d1f28; color: #f7768e; }
+.status-other { background: #2d3148; color: #e0af68; }
+.section { background: #24283b; border-radius: 8px; padding: 1rem 1.25rem; margin-bottom: 1rem; }
+.task { white-space: pre-wrap; word-wrap: break-word; font-size: 0.85rem; }
+.row { display: flex; align-items: center; margin: 0.2rem 0; }
+.row-label { flex: 0 0 220px; font-size: 0.8rem; white-space: nowrap; overflow: hidden; text-overflow: ellipsis; padding-right: 0.75rem; }
+.row-track { flex: 1; position: relative; height: 18px; background: #1a1b26; border-radius: 3px; }
+.bar { position: absolute; top: 2px; height: 14px; border-radius: 3px; }
+.bar-turn { background: #3d59a1; opacity: 0.55; }
+.bar-tool { background: #7aa2f7; }
+.bar-failed { background: #f7768e; }
+.row-duration { flex: 0 0 80px; font-size: 0.75rem; color: #565f89; text-align: right; }
+.turn-label { color: #bb9af7; }
+.token-row { display: flex; align-items: center; margin: 0.2rem 0; }
+.token-bar { height: 12px; background: #9ece6a; border-radius: 3px; }
+.token-value { font-size: 0.75rem; color: #565f89; margin-left: 0.5rem; white-space: nowrap; }
+.note { color: #e0af68; font-size: 0.8rem; margin-top: 0.5rem; }
+.footer { color: #565f89; font-size: 0.75rem; margin-top: 2rem; }
+</style>
+</head>
+<body>
+<div class="container">
+<h1>Run {{.RunID}} — {{.AgentName}}</h1>
+<div class="meta">
+<span class="status status-{{.StatusClass}}">{{.Status}}</span> &middot;
+started {{.StartedAt}} &middot; duration {{.TotalLabel}}{{if .ModelName}} &middot; {{.ModelName}}{{end}}{{if .TotalTokens}} &middot; {{.TotalTokens}} tokens{{end}}
+</div>
+
+<div class="section">
+<h2>Task</h2>
+<div class="task">{{.Task}}</div>
+</div>
+
+<div class="section">
+<h2>Execution Timeline ({{.TotalLabel}})</h2>
+{{if .Bars}}
+{{range .Bars}}
+<div class="row">
+<div class="row-label{{if eq .Lane "turn"}} turn-label{{end}}">{{.Label}}</div>
+<div class="row-track"><div class="bar bar-{{.Lane}}{{if .Failed}} bar-failed{{end}}" style="left: {{printf "%.2f" .LeftPct}}%; width: {{printf "%.2f" .WidthPct}}%;"></div></div>
+<div class="row-duration">{{if .HasDuration}}{{.DurationMs}}ms{{end}}</div>
+</div>
+{{end}}
+{{if .Estimated}}<div class="note">⚠ Per-call durations were not captured for this run; tool calls are distributed evenly across the run duration. Re-run with --enable-telemetry for exact span timings.</div>{{end}}
+{{else}}
+<div class="task">No tool calls were captured for this run.</div>
+{{end}}
+</div>
+
+{{if .TokenPoints}}
+<div class="section">
+<h2>Token Accumulation (estimated from tool outputs)</h2>
+{{range .TokenPoints}}
+<div class="row">
+<div class="row-label">{{.Label}}</div>
+<div class="row-track"><div class="token-bar" style="width: {{printf "%.2f" .Pct}}%;"></div></div>
+<div class="token-value">~{{.Cumulative}}</div>
+</div>
+{{end}}
+</div>
+{{end}}
+
+<div class="footer">Generated by Station on {{.GeneratedAt}} &middot; stn runs inspect {{.RunID}} --timeline</div>
+</div>
+</body>
+</html>`))
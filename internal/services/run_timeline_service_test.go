@@ -0,0 +1,134 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"station/pkg/models"
+)
+
+func timelineTestRun(toolCalls models.JSONArray, durationSeconds float64) *models.AgentRunWithDetails {
+	completed := time.Now()
+	return &models.AgentRunWithDetails{
+		AgentRun: models.AgentRun{
+			ID:              1,
+			AgentID:         1,
+			Task:            "test task",
+			Status:          "completed",
+			StartedAt:       completed.Add(-time.Duration(durationSeconds) * time.Second),
+			CompletedAt:     &completed,
+			DurationSeconds: &durationSeconds,
+			ToolCalls:       &toolCalls,
+		},
+		AgentName: "Test Agent",
+	}
+}
+
+func TestBuildTimelineBars_WithDurations(t *testing.T) {
+	run := timelineTestRun(models.JSONArray{
+		map[string]interface{}{"tool_name": "__read_text_file", "turn": float64(0), "duration_ms": float64(400), "estimated_tokens": float64(100)},
+		map[string]interface{}{"tool_name": "__list_directory", "turn": float64(0), "duration_ms": float64(600), "success": false},
+		map[string]interface{}{"tool_name": "__search_files", "turn": float64(1), "duration_ms": float64(1000)},
+	}, 2.0)
+
+	bars, estimated := buildTimelineBars(run, 2000)
+	if estimated {
+		t.Error("expected exact timings when every call has duration_ms")
+	}
+	// 2 turn lanes + 3 tool lanes
+	if len(bars) != 5 {
+		t.Fatalf("expected 5 bars, got %d", len(bars))
+	}
+
+	var turns, tools int
+	for _, bar := range bars {
+		switch bar.Lane {
+		case "turn":
+			turns++
+		case "tool":
+			tools++
+		}
+	}
+	if turns != 2 || tools != 3 {
+		t.Errorf("expected 2 turn bars and 3 tool bars, got %d and %d", turns, tools)
+	}
+
+	for _, bar := range bars {
+		if bar.Label == "__list_directory" && !bar.Failed {
+			t.Error("expected failed tool call to be marked Failed")
+		}
+		if bar.Label == "__search_files" && bar.WidthPct != 50 {
+			t.Errorf("expected 1000ms call to span 50%% of a 2000ms run, got %.2f%%", bar.WidthPct)
+		}
+	}
+}
+
+func TestBuildTimelineBars_WithoutDurations(t *testing.T) {
+	run := timelineTestRun(models.JSONArray{
+		map[string]interface{}{"tool_name": "__read_text_file", "turn": float64(0), "estimated_tokens": float64(100)},
+		map[string]interface{}{"tool_name": "__search_files", "turn": float64(1), "estimated_tokens": float64(50)},
+	}, 4.0)
+
+	bars, estimated := buildTimelineBars(run, 4000)
+	if !estimated {
+		t.Error("expected estimated layout when calls have no duration_ms")
+	}
+	for _, bar := range bars {
+		if bar.Lane == "tool" && bar.DurationMs != 2000 {
+			t.Errorf("expected calls to split the run evenly at 2000ms each, got %dms", bar.DurationMs)
+		}
+		if bar.HasDuration {
+			t.Error("estimated bars should not claim real durations")
+		}
+	}
+}
+
+func TestBuildTimelineBars_NoToolCalls(t *testing.T) {
+	run := timelineTestRun(models.JSONArray{}, 1.0)
+	bars, estimated := buildTimelineBars(run, 1000)
+	if bars != nil || estimated {
+		t.Errorf("expected no bars for a run without tool calls, got %d", len(bars))
+	}
+}
+
+func TestBuildTokenPoints(t *testing.T) {
+	toolCalls := models.JSONArray{
+		map[string]interface{}{"tool_name": "__read_text_file", "estimated_tokens": float64(100)},
+		map[string]interface{}{"tool_name": "__list_directory"},
+		map[string]interface{}{"tool_name": "__search_files", "estimated_tokens": float64(300)},
+	}
+
+	points := buildTokenPoints(&toolCalls)
+	if len(points) != 2 {
+		t.Fatalf("expected 2 token points (calls without tokens skipped), got %d", len(points))
+	}
+	if points[0].Cumulative != 100 || points[1].Cumulative != 400 {
+		t.Errorf("expected cumulative totals 100 and 400, got %d and %d", points[0].Cumulative, points[1].Cumulative)
+	}
+	if points[1].Pct != 100 {
+		t.Errorf("expected final point at 100%%, got %.2f%%", points[1].Pct)
+	}
+
+	if got := buildTokenPoints(nil); got != nil {
+		t.Errorf("expected nil for nil tool calls, got %v", got)
+	}
+}
+
+func TestRunTotalMs(t *testing.T) {
+	seconds := 2.5
+	run := &models.AgentRun{DurationSeconds: &seconds}
+	if got := runTotalMs(run); got != 2500 {
+		t.Errorf("expected 2500ms from DurationSeconds, got %d", got)
+	}
+
+	started := time.Now().Add(-3 * time.Second)
+	completed := started.Add(3 * time.Second)
+	run = &models.AgentRun{StartedAt: started, CompletedAt: &completed}
+	if got := runTotalMs(run); got != 3000 {
+		t.Errorf("expected 3000ms from timestamps, got %d", got)
+	}
+
+	if got := runTotalMs(&models.AgentRun{}); got != 0 {
+		t.Errorf("expected 0 for an incomplete run, got %d", got)
+	}
+}
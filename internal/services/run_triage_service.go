@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/genkit"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+// Triage results are stored per run in the settings table under
+// triage.run.<run_id> so re-running triage overwrites the previous verdict
+const runTriageSettingPrefix = "triage.run."
+
+// Failure categories the triage model must choose from
+var triageCategories = []string{"config", "provider", "mcp_server", "prompt", "other"}
+
+// TriageResult is the structured root-cause verdict for a failed run
+type TriageResult struct {
+	RunID        int64   `json:"run_id"`
+	Category     string  `json:"category"`
+	RootCause    string  `json:"root_cause"`
+	SuggestedFix string  `json:"suggested_fix"`
+	Confidence   float64 `json:"confidence"`
+	Model        string  `json:"model"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+// TriageSummary aggregates triage verdicts across runs for reporting
+type TriageSummary struct {
+	TriagedRuns int            `json:"triaged_runs"`
+	ByCategory  map[string]int `json:"by_category"`
+	Recent      []TriageResult `json:"recent"`
+}
+
+// RunTriageService feeds a failed run's steps, tool errors, and config
+// context to an LLM and stores a structured root-cause verdict on the run
+type RunTriageService struct {
+	repos          *repositories.Repositories
+	genkitProvider *GenKitProvider
+}
+
+// NewRunTriageService creates a run triage service
+func NewRunTriageService(repos *repositories.Repositories) *RunTriageService {
+	return &RunTriageService{
+		repos:          repos,
+		genkitProvider: NewGenKitProvider(),
+	}
+}
+
+// Triage analyzes a failed run and stores the verdict. Completed runs are
+// rejected — there is nothing to triage.
+func (s *RunTriageService) Triage(ctx context.Context, runID int64) (*TriageResult, error) {
+	run, err := s.repos.AgentRuns.GetByID(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("run %d not found: %w", runID, err)
+	}
+	if run.Status == "completed" {
+		return nil, fmt.Errorf("run %d completed successfully; triage is for failed runs", runID)
+	}
+
+	prompt := s.buildTriagePrompt(run)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	genkitApp, err := s.genkitProvider.GetApp(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize GenKit: %w", err)
+	}
+
+	modelName := cfg.AIModel
+	if !strings.Contains(modelName, "/") {
+		provider := strings.ToLower(cfg.AIProvider)
+		if provider == "gemini" {
+			provider = "googleai"
+		}
+		modelName = fmt.Sprintf("%s/%s", provider, modelName)
+	}
+
+	response, err := genkit.Generate(ctx, genkitApp,
+		ai.WithPrompt(prompt),
+		ai.WithModelName(modelName))
+	if err != nil {
+		return nil, fmt.Errorf("triage model call failed: %w", err)
+	}
+
+	result, err := parseTriageResponse(response.Text())
+	if err != nil {
+		return nil, err
+	}
+	result.RunID = runID
+	result.Model = modelName
+	result.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	if err := s.saveTriage(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTriage returns the stored verdict for a run, or nil if never triaged
+func (s *RunTriageService) GetTriage(runID int64) (*TriageResult, error) {
+	setting, err := s.repos.Settings.GetByKey(fmt.Sprintf("%s%d", runTriageSettingPrefix, runID))
+	if err != nil {
+		return nil, nil
+	}
+
+	var result TriageResult
+	if err := json.Unmarshal([]byte(setting.Value), &result); err != nil {
+		return nil, fmt.Errorf("invalid stored triage for run %d: %w", runID, err)
+	}
+	return &result, nil
+}
+
+// Summary aggregates all stored triage verdicts by category for reports
+func (s *RunTriageService) Summary() (*TriageSummary, error) {
+	settings, err := s.repos.Settings.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings: %w", err)
+	}
+
+	summary := &TriageSummary{ByCategory: make(map[string]int)}
+	for _, setting := range settings {
+		if !strings.HasPrefix(setting.Key, runTriageSettingPrefix) {
+			continue
+		}
+		var result TriageResult
+		if err := json.Unmarshal([]byte(setting.Value), &result); err != nil {
+			continue
+		}
+		summary.TriagedRuns++
+		summary.ByCategory[result.Category]++
+		summary.Recent = append(summary.Recent, result)
+	}
+	return summary, nil
+}
+
+// buildTriagePrompt assembles the run's failure context: task, error, tool
+// calls, execution steps, and the agent's configuration
+func (s *RunTriageService) buildTriagePrompt(run *models.AgentRun) string {
+	var b strings.Builder
+
+	b.WriteString("You are a root-cause analyst for AI agent execution failures.\n\n")
+	b.WriteString("**Failed Run Context:**\n")
+	fmt.Fprintf(&b, "- Run ID: %d\n", run.ID)
+	fmt.Fprintf(&b, "- Status: %s\n", run.Status)
+	fmt.Fprintf(&b, "- Task: %s\n", run.Task)
+	fmt.Fprintf(&b, "- Steps taken: %d\n", run.StepsTaken)
+	if run.ModelName != nil {
+		fmt.Fprintf(&b, "- Model: %s\n", *run.ModelName)
+	}
+	if run.Error != nil {
+		fmt.Fprintf(&b, "- Error: %s\n", *run.Error)
+	}
+
+	if agent, err := s.repos.Agents.GetByID(run.AgentID); err == nil {
+		fmt.Fprintf(&b, "\n**Agent Configuration:**\n- Name: %s\n- Max steps: %d\n", agent.Name, agent.MaxSteps)
+	}
+
+	if run.ToolCalls != nil && len(*run.ToolCalls) > 0 {
+		b.WriteString("\n**Tool Calls:**\n")
+		for i, entry := range *run.ToolCalls {
+			if encoded, err := json.Marshal(entry); err == nil {
+				fmt.Fprintf(&b, "%d. %s\n", i+1, truncateForPrompt(string(encoded), 500))
+			}
+		}
+	}
+
+	if run.ExecutionSteps != nil && len(*run.ExecutionSteps) > 0 {
+		b.WriteString("\n**Execution Steps (most recent last):**\n")
+		steps := *run.ExecutionSteps
+		start := 0
+		if len(steps) > 10 {
+			start = len(steps) - 10
+		}
+		for _, entry := range steps[start:] {
+			if encoded, err := json.Marshal(entry); err == nil {
+				fmt.Fprintf(&b, "- %s\n", truncateForPrompt(string(encoded), 500))
+			}
+		}
+	}
+
+	if run.FinalResponse != "" {
+		fmt.Fprintf(&b, "\n**Final Response:**\n%s\n", truncateForPrompt(run.FinalResponse, 1000))
+	}
+
+	b.WriteString(`
+**Instructions:**
+Determine the most probable root cause of this failure and how to fix it.
+The category must be one of: config (missing/wrong variables, credentials, environment setup), provider (AI provider errors, rate limits, model issues), mcp_server (MCP server unreachable, tool errors, tool not found), prompt (agent prompt or task issues, step limits, bad tool usage).
+Use "other" only if none fit.
+
+**Output Format (JSON only, no prose):**
+{
+  "category": "<config|provider|mcp_server|prompt|other>",
+  "root_cause": "<one or two sentences>",
+  "suggested_fix": "<concrete action the operator should take>",
+  "confidence": <0.0-1.0>
+}`)
+
+	return b.String()
+}
+
+// parseTriageResponse validates the model's structured output
+func parseTriageResponse(response string) (*TriageResult, error) {
+	cleaned := stripMarkdownCodeBlocks(response)
+
+	var result TriageResult
+	if err := json.Unmarshal([]byte(cleaned), &result); err != nil {
+		return nil, fmt.Errorf("triage model returned invalid JSON: %w", err)
+	}
+
+	valid := false
+	for _, category := range triageCategories {
+		if result.Category == category {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("triage model returned unknown category %q", result.Category)
+	}
+	if result.RootCause == "" {
+		return nil, fmt.Errorf("triage model returned no root cause")
+	}
+	return &result, nil
+}
+
+func (s *RunTriageService) saveTriage(result *TriageResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s%d", runTriageSettingPrefix, result.RunID)
+	if err := s.repos.Settings.Set(key, string(data), "Run failure triage verdict"); err != nil {
+		return fmt.Errorf("failed to store triage result: %w", err)
+	}
+	return nil
+}
+
+// truncateForPrompt keeps prompt sections bounded for large runs
+func truncateForPrompt(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "…"
+}
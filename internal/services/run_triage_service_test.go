@@ -0,0 +1,140 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+func TestParseTriageResponse(t *testing.T) {
+	result, err := parseTriageResponse(`{"category": "mcp_server", "root_cause": "filesystem server exited on startup", "suggested_fix": "check npx availability in PATH", "confidence": 0.85}`)
+	if err != nil {
+		t.Fatalf("parseTriageResponse returned error: %v", err)
+	}
+	if result.Category != "mcp_server" {
+		t.Errorf("expected category mcp_server, got %s", result.Category)
+	}
+	if result.Confidence != 0.85 {
+		t.Errorf("expected confidence 0.85, got %f", result.Confidence)
+	}
+
+	// Markdown fences from chatty models are stripped
+	fenced := "```json\n{\"category\": \"config\", \"root_cause\": \"missing PROJECT_ROOT\", \"suggested_fix\": \"set it in variables.yml\", \"confidence\": 0.9}\n```"
+	result, err = parseTriageResponse(fenced)
+	if err != nil {
+		t.Fatalf("parseTriageResponse returned error for fenced JSON: %v", err)
+	}
+	if result.Category != "config" {
+		t.Errorf("expected category config, got %s", result.Category)
+	}
+}
+
+func TestParseTriageResponseRejectsBadOutput(t *testing.T) {
+	if _, err := parseTriageResponse("the run failed because of reasons"); err == nil {
+		t.Error("expected error for non-JSON output")
+	}
+	if _, err := parseTriageResponse(`{"category": "gremlins", "root_cause": "x", "suggested_fix": "y"}`); err == nil {
+		t.Error("expected error for unknown category")
+	}
+	if _, err := parseTriageResponse(`{"category": "prompt", "root_cause": "", "suggested_fix": "y"}`); err == nil {
+		t.Error("expected error for empty root cause")
+	}
+}
+
+func TestTriageStorageRoundTrip(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	svc := NewRunTriageService(repos)
+
+	// No verdict stored yet
+	triage, err := svc.GetTriage(42)
+	if err != nil {
+		t.Fatalf("GetTriage returned error: %v", err)
+	}
+	if triage != nil {
+		t.Fatal("expected nil triage for untriaged run")
+	}
+
+	stored := &TriageResult{
+		RunID:        42,
+		Category:     "provider",
+		RootCause:    "rate limited by provider",
+		SuggestedFix: "retry with backoff or switch model",
+		Confidence:   0.7,
+	}
+	if err := svc.saveTriage(stored); err != nil {
+		t.Fatalf("saveTriage returned error: %v", err)
+	}
+
+	triage, err = svc.GetTriage(42)
+	if err != nil {
+		t.Fatalf("GetTriage returned error: %v", err)
+	}
+	if triage == nil || triage.Category != "provider" {
+		t.Fatalf("expected stored provider verdict, got %+v", triage)
+	}
+
+	// Summary aggregates by category
+	second := &TriageResult{RunID: 43, Category: "provider", RootCause: "timeout", SuggestedFix: "raise timeout"}
+	if err := svc.saveTriage(second); err != nil {
+		t.Fatalf("saveTriage returned error: %v", err)
+	}
+	summary, err := svc.Summary()
+	if err != nil {
+		t.Fatalf("Summary returned error: %v", err)
+	}
+	if summary.TriagedRuns != 2 || summary.ByCategory["provider"] != 2 {
+		t.Errorf("expected 2 provider verdicts, got %+v", summary)
+	}
+}
+
+func TestBuildTriagePromptIncludesFailureContext(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	svc := NewRunTriageService(repos)
+
+	errorMsg := "tool __read_text_file not found"
+	toolCalls := models.JSONArray{
+		map[string]interface{}{"tool_name": "__read_text_file", "parameters": map[string]interface{}{"path": "/tmp/x"}},
+	}
+	run := &models.AgentRun{
+		ID:         7,
+		AgentID:    1,
+		Task:       "scan the repository",
+		Status:     "failed",
+		StepsTaken: 3,
+		Error:      &errorMsg,
+		ToolCalls:  &toolCalls,
+	}
+
+	prompt := svc.buildTriagePrompt(run)
+	for _, fragment := range []string{"scan the repository", errorMsg, "__read_text_file", "config|provider|mcp_server|prompt|other"} {
+		if !strings.Contains(prompt, fragment) {
+			t.Errorf("expected prompt to contain %q", fragment)
+		}
+	}
+}
+
+func TestTruncateForPrompt(t *testing.T) {
+	if got := truncateForPrompt("short", 10); got != "short" {
+		t.Errorf("expected unchanged string, got %q", got)
+	}
+	long := strings.Repeat("a", 20)
+	got := truncateForPrompt(long, 10)
+	if len([]rune(got)) != 11 || !strings.HasSuffix(got, "…") {
+		t.Errorf("expected truncated string with ellipsis, got %q", got)
+	}
+}
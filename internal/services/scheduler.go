@@ -138,7 +138,14 @@ func (s *SchedulerService) loadScheduledAgents() error {
 
 	log.Printf("Loading %d scheduled agents from database", len(agents))
 
+	now := time.Now()
 	for _, agent := range agents {
+		// Apply the missed-run policy for occurrences that fired while the
+		// scheduler was down, before the schedule is refreshed below
+		if agent.CronSchedule.Valid && agent.ScheduleEnabled.Bool {
+			s.handleMissedRuns(agent, now)
+		}
+
 		// Convert to models.Agent
 		modelAgent := &models.Agent{
 			ID:              agent.ID,
@@ -275,10 +282,11 @@ func (s *SchedulerService) executeScheduledAgent(agentID int64) {
 		result, err := s.agentService.ExecuteAgentWithRunID(execCtx, agent.ID, task, run.ID, execVars)
 		if err != nil {
 			log.Printf("❌ Scheduled execution failed for agent %d (%s): %v", agent.ID, agent.Name, err)
-			// Update run status to failed with error message
+			// Update run status with error message, distinguishing timeouts
+			// and cancellations from ordinary failures
 			errorMsg := err.Error()
 			_ = s.repos.AgentRuns.UpdateCompletionWithMetadata(
-				execCtx, run.ID, "", 0, nil, nil, "failed", &now,
+				execCtx, run.ID, "", 0, nil, nil, ClassifyRunFailure(err), &now,
 				nil, nil, nil, nil, nil, nil, &errorMsg,
 			)
 		} else {
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"station/internal/db/queries"
+	"station/internal/db/repositories"
+)
+
+// If serve mode is down when a schedule fires, the occurrence is lost: nothing
+// runs and nothing is recorded. The scheduler persists next_scheduled_run, so
+// on startup we compare the stored time against the clock and apply a
+// missed-run policy per agent:
+//
+//	skip                - record the gap only (default)
+//	run-once-on-startup - record the gap and run the agent once to catch up
+//	backfill-up-to-N    - record the gap and run once per missed occurrence,
+//	                      capped at N (e.g. backfill-up-to-3)
+//
+// The policy is resolved through the scoped settings hierarchy (agent >
+// environment > global) under the key scheduler.missed_run_policy. Detected
+// gaps are recorded in the settings table under scheduler.missed.<agent-id>
+// so operators can inspect them with `stn settings get`.
+const (
+	// MissedRunPolicyKey is the settings key holding the missed-run policy
+	MissedRunPolicyKey = "scheduler.missed_run_policy"
+
+	// MissedRunPolicySkip records the gap without running the agent
+	MissedRunPolicySkip = "skip"
+	// MissedRunPolicyRunOnce records the gap and runs the agent once
+	MissedRunPolicyRunOnce = "run-once-on-startup"
+	// missedRunPolicyBackfillPrefix precedes the backfill cap, e.g. backfill-up-to-3
+	missedRunPolicyBackfillPrefix = "backfill-up-to-"
+
+	// missedRunRecordPrefix precedes the agent ID in gap record keys
+	missedRunRecordPrefix = "scheduler.missed."
+	// missedRunRecordCap bounds how many gap records are kept per agent
+	missedRunRecordCap = 50
+	// missedRunScanCap bounds occurrence enumeration for tight schedules
+	// (a per-second schedule down for a day would otherwise enumerate 86400)
+	missedRunScanCap = 1000
+)
+
+// MissedRunRecord captures one startup gap detected for a scheduled agent
+type MissedRunRecord struct {
+	DetectedAt  time.Time   `json:"detected_at"`
+	Occurrences []time.Time `json:"occurrences"`
+	Policy      string      `json:"policy"`
+	RunsStarted int         `json:"runs_started"`
+}
+
+// parseMissedRunPolicy validates a policy string and returns the canonical
+// policy name plus the backfill cap (0 unless the policy is backfill-up-to-N)
+func parseMissedRunPolicy(value string) (string, int, error) {
+	switch {
+	case value == MissedRunPolicySkip:
+		return MissedRunPolicySkip, 0, nil
+	case value == MissedRunPolicyRunOnce:
+		return MissedRunPolicyRunOnce, 0, nil
+	case strings.HasPrefix(value, missedRunPolicyBackfillPrefix):
+		n, err := strconv.Atoi(strings.TrimPrefix(value, missedRunPolicyBackfillPrefix))
+		if err != nil || n < 1 {
+			return "", 0, fmt.Errorf("invalid backfill cap in policy '%s' (expected %s<N> with N >= 1)", value, missedRunPolicyBackfillPrefix)
+		}
+		return value, n, nil
+	default:
+		return "", 0, fmt.Errorf("invalid missed-run policy '%s' (expected %s, %s, or %s<N>)",
+			value, MissedRunPolicySkip, MissedRunPolicyRunOnce, missedRunPolicyBackfillPrefix)
+	}
+}
+
+// missedRunPolicy resolves the missed-run policy for an agent via the scoped
+// settings hierarchy, falling back to skip when unset or invalid
+func (s *SchedulerService) missedRunPolicy(agentID int64) (string, int) {
+	setting, _, err := ResolveAgentSetting(s.repos, agentID, MissedRunPolicyKey)
+	if err != nil || setting == nil {
+		return MissedRunPolicySkip, 0
+	}
+
+	policy, backfillCap, err := parseMissedRunPolicy(setting.Value)
+	if err != nil {
+		log.Printf("Warning: %v for agent %d, falling back to %s", err, agentID, MissedRunPolicySkip)
+		return MissedRunPolicySkip, 0
+	}
+	return policy, backfillCap
+}
+
+// missedOccurrences enumerates schedule occurrences from the persisted next
+// run time up to now, capped at missedRunScanCap
+func missedOccurrences(schedule cron.Schedule, from, now time.Time) []time.Time {
+	var occurrences []time.Time
+	cursor := from.Add(-time.Nanosecond)
+	for len(occurrences) < missedRunScanCap {
+		next := schedule.Next(cursor)
+		if !next.Before(now) {
+			break
+		}
+		occurrences = append(occurrences, next)
+		cursor = next
+	}
+	return occurrences
+}
+
+// recordMissedRuns appends a gap record to the agent's missed-run history in
+// the settings table, keeping the most recent missedRunRecordCap entries
+func (s *SchedulerService) recordMissedRuns(agentID int64, record MissedRunRecord) error {
+	history, err := MissedRunHistory(s.repos, agentID)
+	if err != nil {
+		return err
+	}
+
+	history = append(history, record)
+	if len(history) > missedRunRecordCap {
+		history = history[len(history)-missedRunRecordCap:]
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to serialize missed-run history: %w", err)
+	}
+
+	key := fmt.Sprintf("%s%d", missedRunRecordPrefix, agentID)
+	return s.repos.Settings.Set(key, string(data),
+		fmt.Sprintf("Missed scheduled runs for agent %d detected at scheduler startup", agentID))
+}
+
+// MissedRunHistory returns the recorded startup gaps for an agent, oldest
+// first. An agent with no recorded gaps returns an empty history.
+func MissedRunHistory(repos *repositories.Repositories, agentID int64) ([]MissedRunRecord, error) {
+	key := fmt.Sprintf("%s%d", missedRunRecordPrefix, agentID)
+	setting, err := repos.Settings.GetByKey(key)
+	if err != nil || setting == nil {
+		return nil, nil
+	}
+
+	var history []MissedRunRecord
+	if err := json.Unmarshal([]byte(setting.Value), &history); err != nil {
+		return nil, fmt.Errorf("corrupt missed-run history for agent %d: %w", agentID, err)
+	}
+	return history, nil
+}
+
+// handleMissedRuns checks whether an agent's persisted next run time passed
+// while the scheduler was down and applies the configured missed-run policy.
+// Called from loadScheduledAgents before the agent is (re)scheduled, which
+// refreshes next_scheduled_run so the same gap is not detected twice.
+func (s *SchedulerService) handleMissedRuns(agent queries.Agent, now time.Time) {
+	if !agent.NextScheduledRun.Valid || !agent.NextScheduledRun.Time.Before(now) {
+		return
+	}
+	if !agent.CronSchedule.Valid || agent.CronSchedule.String == "" {
+		return
+	}
+
+	parser := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	schedule, err := parser.Parse(agent.CronSchedule.String)
+	if err != nil {
+		log.Printf("Warning: cannot check missed runs for agent %d, invalid cron expression '%s': %v",
+			agent.ID, agent.CronSchedule.String, err)
+		return
+	}
+
+	occurrences := missedOccurrences(schedule, agent.NextScheduledRun.Time, now)
+	if len(occurrences) == 0 {
+		return
+	}
+
+	policy, backfillCap := s.missedRunPolicy(agent.ID)
+
+	runsToStart := 0
+	switch policy {
+	case MissedRunPolicyRunOnce:
+		runsToStart = 1
+	case MissedRunPolicySkip:
+		// Record only
+	default: // backfill-up-to-N
+		runsToStart = len(occurrences)
+		if runsToStart > backfillCap {
+			runsToStart = backfillCap
+		}
+	}
+
+	log.Printf("⏰ Agent %d (%s) missed %d scheduled run(s) while the scheduler was down (policy: %s, catching up: %d)",
+		agent.ID, agent.Name, len(occurrences), policy, runsToStart)
+
+	if err := s.recordMissedRuns(agent.ID, MissedRunRecord{
+		DetectedAt:  now,
+		Occurrences: occurrences,
+		Policy:      policy,
+		RunsStarted: runsToStart,
+	}); err != nil {
+		log.Printf("Warning: failed to record missed runs for agent %d: %v", agent.ID, err)
+	}
+
+	for i := 0; i < runsToStart; i++ {
+		s.executeScheduledAgent(agent.ID)
+	}
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+// TestParseMissedRunPolicy tests missed-run policy string validation
+func TestParseMissedRunPolicy(t *testing.T) {
+	tests := []struct {
+		value       string
+		wantPolicy  string
+		wantCap     int
+		expectError bool
+	}{
+		{"skip", MissedRunPolicySkip, 0, false},
+		{"run-once-on-startup", MissedRunPolicyRunOnce, 0, false},
+		{"backfill-up-to-3", "backfill-up-to-3", 3, false},
+		{"backfill-up-to-1", "backfill-up-to-1", 1, false},
+		{"backfill-up-to-0", "", 0, true},
+		{"backfill-up-to-lots", "", 0, true},
+		{"run-always", "", 0, true},
+		{"", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			policy, backfillCap, err := parseMissedRunPolicy(tt.value)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("parseMissedRunPolicy(%q) error = %v, expectError %v", tt.value, err, tt.expectError)
+			}
+			if policy != tt.wantPolicy {
+				t.Errorf("policy = %q, want %q", policy, tt.wantPolicy)
+			}
+			if backfillCap != tt.wantCap {
+				t.Errorf("backfill cap = %d, want %d", backfillCap, tt.wantCap)
+			}
+		})
+	}
+}
+
+// TestMissedOccurrences tests occurrence enumeration between the persisted
+// next run time and now
+func TestMissedOccurrences(t *testing.T) {
+	parser := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+	everyMinute, err := parser.Parse("0 * * * * *")
+	if err != nil {
+		t.Fatalf("Failed to parse cron expression: %v", err)
+	}
+
+	from := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// Scheduler down for 3.5 minutes: occurrences at 10:00, 10:01, 10:02, 10:03
+	occurrences := missedOccurrences(everyMinute, from, from.Add(3*time.Minute+30*time.Second))
+	if len(occurrences) != 4 {
+		t.Fatalf("missedOccurrences() = %d occurrences, want 4", len(occurrences))
+	}
+	if !occurrences[0].Equal(from) {
+		t.Errorf("first occurrence = %v, want %v", occurrences[0], from)
+	}
+	if !occurrences[3].Equal(from.Add(3 * time.Minute)) {
+		t.Errorf("last occurrence = %v, want %v", occurrences[3], from.Add(3*time.Minute))
+	}
+
+	// Next run still in the future: no missed occurrences
+	occurrences = missedOccurrences(everyMinute, from, from.Add(-time.Minute))
+	if len(occurrences) != 0 {
+		t.Errorf("missedOccurrences() with future next run = %d occurrences, want 0", len(occurrences))
+	}
+
+	// A per-second schedule down for hours hits the scan cap instead of
+	// enumerating every occurrence
+	everySecond, err := parser.Parse("* * * * * *")
+	if err != nil {
+		t.Fatalf("Failed to parse cron expression: %v", err)
+	}
+	occurrences = missedOccurrences(everySecond, from, from.Add(2*time.Hour))
+	if len(occurrences) != missedRunScanCap {
+		t.Errorf("missedOccurrences() = %d occurrences, want scan cap %d", len(occurrences), missedRunScanCap)
+	}
+}
+
+// TestMissedRunPolicyResolution tests settings-driven policy lookup with
+// agent-scoped overrides
+func TestMissedRunPolicyResolution(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer func() { _ = testDB.Close() }()
+
+	repos := repositories.New(testDB)
+	agentService := NewAgentService(repos)
+	scheduler := NewSchedulerService(testDB, repos, agentService)
+
+	env, err := repos.Environments.Create("test-missed-policy-env", nil, 1)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+
+	agent, err := agentService.CreateAgent(context.Background(), &AgentConfig{
+		Name:          "test-missed-policy-agent",
+		Prompt:        "Test",
+		MaxSteps:      5,
+		EnvironmentID: env.ID,
+		CreatedBy:     1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// Unset: defaults to skip
+	policy, backfillCap := scheduler.missedRunPolicy(agent.ID)
+	if policy != MissedRunPolicySkip || backfillCap != 0 {
+		t.Errorf("default policy = (%q, %d), want (%q, 0)", policy, backfillCap, MissedRunPolicySkip)
+	}
+
+	// Global setting applies
+	if err := repos.Settings.Set(MissedRunPolicyKey, "backfill-up-to-5", "test"); err != nil {
+		t.Fatalf("Failed to set global policy: %v", err)
+	}
+	policy, backfillCap = scheduler.missedRunPolicy(agent.ID)
+	if policy != "backfill-up-to-5" || backfillCap != 5 {
+		t.Errorf("global policy = (%q, %d), want (backfill-up-to-5, 5)", policy, backfillCap)
+	}
+
+	// Agent-scoped override wins over global
+	agentKey, err := ScopedSettingKey(fmt.Sprintf("agent:%d", agent.ID), MissedRunPolicyKey)
+	if err != nil {
+		t.Fatalf("Failed to build scoped key: %v", err)
+	}
+	if err := repos.Settings.Set(agentKey, MissedRunPolicyRunOnce, "test"); err != nil {
+		t.Fatalf("Failed to set agent policy: %v", err)
+	}
+	policy, _ = scheduler.missedRunPolicy(agent.ID)
+	if policy != MissedRunPolicyRunOnce {
+		t.Errorf("agent-scoped policy = %q, want %q", policy, MissedRunPolicyRunOnce)
+	}
+
+	// Invalid values fall back to skip rather than blocking startup
+	if err := repos.Settings.Set(agentKey, "run-always", "test"); err != nil {
+		t.Fatalf("Failed to set invalid policy: %v", err)
+	}
+	policy, _ = scheduler.missedRunPolicy(agent.ID)
+	if policy != MissedRunPolicySkip {
+		t.Errorf("invalid policy resolved to %q, want fallback %q", policy, MissedRunPolicySkip)
+	}
+}
+
+// TestMissedRunHistory tests gap recording and the per-agent history cap
+func TestMissedRunHistory(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer func() { _ = testDB.Close() }()
+
+	repos := repositories.New(testDB)
+	agentService := NewAgentService(repos)
+	scheduler := NewSchedulerService(testDB, repos, agentService)
+
+	// No history recorded yet
+	history, err := MissedRunHistory(repos, 42)
+	if err != nil {
+		t.Fatalf("MissedRunHistory() error = %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("MissedRunHistory() = %d records, want 0", len(history))
+	}
+
+	now := time.Now()
+	record := MissedRunRecord{
+		DetectedAt:  now,
+		Occurrences: []time.Time{now.Add(-2 * time.Minute), now.Add(-time.Minute)},
+		Policy:      MissedRunPolicySkip,
+	}
+	if err := scheduler.recordMissedRuns(42, record); err != nil {
+		t.Fatalf("recordMissedRuns() error = %v", err)
+	}
+
+	history, err = MissedRunHistory(repos, 42)
+	if err != nil {
+		t.Fatalf("MissedRunHistory() error = %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("MissedRunHistory() = %d records, want 1", len(history))
+	}
+	if len(history[0].Occurrences) != 2 {
+		t.Errorf("recorded occurrences = %d, want 2", len(history[0].Occurrences))
+	}
+	if history[0].Policy != MissedRunPolicySkip {
+		t.Errorf("recorded policy = %q, want %q", history[0].Policy, MissedRunPolicySkip)
+	}
+
+	// History is trimmed to the most recent records
+	for i := 0; i < missedRunRecordCap+10; i++ {
+		if err := scheduler.recordMissedRuns(42, record); err != nil {
+			t.Fatalf("recordMissedRuns() iteration %d error = %v", i, err)
+		}
+	}
+	history, err = MissedRunHistory(repos, 42)
+	if err != nil {
+		t.Fatalf("MissedRunHistory() error = %v", err)
+	}
+	if len(history) != missedRunRecordCap {
+		t.Errorf("MissedRunHistory() = %d records, want cap %d", len(history), missedRunRecordCap)
+	}
+}
+
+// TestSchedulerDetectsMissedRunsOnStartup tests end-to-end gap detection: an
+// agent whose persisted next_scheduled_run passed while the scheduler was
+// down gets a gap record on Start, and a restart does not re-record it
+func TestSchedulerDetectsMissedRunsOnStartup(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer func() { _ = testDB.Close() }()
+
+	repos := repositories.New(testDB)
+	agentService := NewAgentService(repos)
+	scheduler := NewSchedulerService(testDB, repos, agentService)
+
+	env, err := repos.Environments.Create("test-missed-startup-env", nil, 1)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+
+	agent, err := agentService.CreateAgent(context.Background(), &AgentConfig{
+		Name:          "test-missed-startup-agent",
+		Prompt:        "Test",
+		MaxSteps:      5,
+		EnvironmentID: env.ID,
+		CreatedBy:     1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// Simulate a schedule that fired twice while the scheduler was down
+	_, err = testDB.Conn().Exec(
+		"UPDATE agents SET cron_schedule = ?, is_scheduled = TRUE, schedule_enabled = TRUE, next_scheduled_run = ? WHERE id = ?",
+		"0 * * * * *", time.Now().Add(-90*time.Second), agent.ID)
+	if err != nil {
+		t.Fatalf("Failed to set schedule state: %v", err)
+	}
+
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	scheduler.Stop()
+
+	history, err := MissedRunHistory(repos, agent.ID)
+	if err != nil {
+		t.Fatalf("MissedRunHistory() error = %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("MissedRunHistory() after startup = %d records, want 1", len(history))
+	}
+	if len(history[0].Occurrences) != 2 {
+		t.Errorf("recorded occurrences = %d, want 2", len(history[0].Occurrences))
+	}
+	if history[0].Policy != MissedRunPolicySkip {
+		t.Errorf("recorded policy = %q, want default %q", history[0].Policy, MissedRunPolicySkip)
+	}
+	if history[0].RunsStarted != 0 {
+		t.Errorf("runs started under skip policy = %d, want 0", history[0].RunsStarted)
+	}
+
+	// The skip policy must not create catch-up runs
+	runs, err := repos.AgentRuns.ListByAgent(context.Background(), agent.ID)
+	if err != nil {
+		t.Fatalf("ListByAgent() error = %v", err)
+	}
+	if len(runs) != 0 {
+		t.Errorf("agent runs after skip policy = %d, want 0", len(runs))
+	}
+
+	// Scheduling refreshed next_scheduled_run, so a restart sees no gap
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("Restart Start() error = %v", err)
+	}
+	scheduler.Stop()
+
+	history, err = MissedRunHistory(repos, agent.ID)
+	if err != nil {
+		t.Fatalf("MissedRunHistory() error = %v", err)
+	}
+	if len(history) != 1 {
+		t.Errorf("MissedRunHistory() after restart = %d records, want 1 (gap should not be re-recorded)", len(history))
+	}
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+// The settings table is flat, so scoped settings encode their scope into the
+// key: env-scoped values are stored as scope.env.<name>.<key> and agent-scoped
+// values as scope.agent.<id>.<key>, while global values keep the bare key.
+// Resolution walks agent > environment > global so things like timeouts,
+// budgets, and judge models can differ per environment without code changes.
+const (
+	settingScopeEnvPrefix   = "scope.env."
+	settingScopeAgentPrefix = "scope.agent."
+)
+
+// ScopedSettingKey maps a CLI scope selector and key to the stored settings
+// key. Accepted selectors: "" or "global" (bare key), "env:<name>", and
+// "agent:<id>".
+func ScopedSettingKey(scope, key string) (string, error) {
+	switch {
+	case scope == "" || scope == "global":
+		return key, nil
+	case strings.HasPrefix(scope, "env:"):
+		name := strings.TrimPrefix(scope, "env:")
+		if name == "" {
+			return "", fmt.Errorf("environment scope requires a name, e.g. env:prod")
+		}
+		return settingScopeEnvPrefix + name + "." + key, nil
+	case strings.HasPrefix(scope, "agent:"):
+		id, err := strconv.ParseInt(strings.TrimPrefix(scope, "agent:"), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("agent scope requires a numeric ID, e.g. agent:3")
+		}
+		return fmt.Sprintf("%s%d.%s", settingScopeAgentPrefix, id, key), nil
+	default:
+		return "", fmt.Errorf("invalid scope '%s' (expected global, env:<name>, or agent:<id>)", scope)
+	}
+}
+
+// ResolveAgentSetting resolves a setting for an agent by walking the scope
+// hierarchy: agent override, then the agent's environment, then global.
+// Returns the winning setting and its scope selector, or (nil, "", nil) when
+// the key is not set at any level.
+func ResolveAgentSetting(repos *repositories.Repositories, agentID int64, key string) (*models.Setting, string, error) {
+	agentKey := fmt.Sprintf("%s%d.%s", settingScopeAgentPrefix, agentID, key)
+	if setting, err := repos.Settings.GetByKey(agentKey); err == nil {
+		return setting, fmt.Sprintf("agent:%d", agentID), nil
+	}
+
+	agent, err := repos.Agents.GetByID(agentID)
+	if err != nil {
+		return nil, "", fmt.Errorf("agent %d not found: %w", agentID, err)
+	}
+	env, err := repos.Environments.GetByID(agent.EnvironmentID)
+	if err != nil {
+		return nil, "", fmt.Errorf("environment %d not found: %w", agent.EnvironmentID, err)
+	}
+
+	envKey := settingScopeEnvPrefix + env.Name + "." + key
+	if setting, err := repos.Settings.GetByKey(envKey); err == nil {
+		return setting, "env:" + env.Name, nil
+	}
+
+	if setting, err := repos.Settings.GetByKey(key); err == nil {
+		return setting, "global", nil
+	}
+	return nil, "", nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestScopedSettingKey(t *testing.T) {
+	cases := []struct {
+		scope, key, want string
+		wantErr          bool
+	}{
+		{"", "judge_model", "judge_model", false},
+		{"global", "judge_model", "judge_model", false},
+		{"env:prod", "judge_model", "scope.env.prod.judge_model", false},
+		{"agent:3", "timeout", "scope.agent.3.timeout", false},
+		{"env:", "timeout", "", true},
+		{"agent:abc", "timeout", "", true},
+		{"team:ops", "timeout", "", true},
+	}
+	for _, tc := range cases {
+		got, err := ScopedSettingKey(tc.scope, tc.key)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("ScopedSettingKey(%q, %q): expected error", tc.scope, tc.key)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("ScopedSettingKey(%q, %q) = %q, %v; want %q", tc.scope, tc.key, got, err, tc.want)
+		}
+	}
+}
+
+func TestResolveAgentSetting(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("prod", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("scope-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Scoped Agent", "scoped", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	// Unset keys resolve to nothing
+	setting, scope, err := ResolveAgentSetting(repos, agent.ID, "judge_model")
+	if err != nil || setting != nil || scope != "" {
+		t.Fatalf("expected no resolution for unset key, got %+v / %q / %v", setting, scope, err)
+	}
+
+	// Global values apply when no scoped override exists
+	if err := repos.Settings.Set("judge_model", "gpt-4o-mini", ""); err != nil {
+		t.Fatalf("failed to set global: %v", err)
+	}
+	setting, scope, err = ResolveAgentSetting(repos, agent.ID, "judge_model")
+	if err != nil || setting == nil || setting.Value != "gpt-4o-mini" || scope != "global" {
+		t.Fatalf("expected global resolution, got %+v / %q / %v", setting, scope, err)
+	}
+
+	// Environment values override global
+	envKey, _ := ScopedSettingKey("env:prod", "judge_model")
+	if err := repos.Settings.Set(envKey, "gpt-4o", ""); err != nil {
+		t.Fatalf("failed to set env scope: %v", err)
+	}
+	setting, scope, err = ResolveAgentSetting(repos, agent.ID, "judge_model")
+	if err != nil || setting.Value != "gpt-4o" || scope != "env:prod" {
+		t.Fatalf("expected environment resolution, got %+v / %q / %v", setting, scope, err)
+	}
+
+	// Agent values override environment and global
+	agentKey, _ := ScopedSettingKey(fmt.Sprintf("agent:%d", agent.ID), "judge_model")
+	if err := repos.Settings.Set(agentKey, "o3-mini", ""); err != nil {
+		t.Fatalf("failed to set agent scope: %v", err)
+	}
+	setting, scope, err = ResolveAgentSetting(repos, agent.ID, "judge_model")
+	if err != nil || setting.Value != "o3-mini" || scope != fmt.Sprintf("agent:%d", agent.ID) {
+		t.Fatalf("expected agent resolution, got %+v / %q / %v", setting, scope, err)
+	}
+
+	// Unknown agents surface an error
+	if _, _, err := ResolveAgentSetting(repos, 999, "judge_model"); err == nil {
+		t.Error("expected error for unknown agent")
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"station/internal/db/repositories"
+)
+
+const (
+	// Share links live in the settings table: one JSON ShareLink document
+	// per token under "share.<token>"
+	shareSettingPrefix = "share."
+	shareTokenBytes    = 16
+)
+
+// Shareable resource types
+const (
+	ShareResourceRun    = "run"
+	ShareResourceReport = "report"
+)
+
+// ShareLink is an expiring, tokenized reference to a run or report that can
+// be rendered by the API as a read-only page without Station access
+type ShareLink struct {
+	Token        string    `json:"token"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   int64     `json:"resource_id"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Expired reports whether the link is past its expiry
+func (l *ShareLink) Expired() bool {
+	return time.Now().After(l.ExpiresAt)
+}
+
+// ShareService creates and resolves read-only share links for runs and
+// reports. Tokens are stored in the settings table so no schema change is
+// needed and links survive restarts.
+type ShareService struct {
+	repos *repositories.Repositories
+}
+
+// NewShareService creates a share link service
+func NewShareService(repos *repositories.Repositories) *ShareService {
+	return &ShareService{repos: repos}
+}
+
+// CreateShareLink generates an expiring share token for a run or report
+// after verifying the resource exists
+func (s *ShareService) CreateShareLink(ctx context.Context, resourceType string, resourceID int64, ttl time.Duration) (*ShareLink, error) {
+	switch resourceType {
+	case ShareResourceRun:
+		if _, err := s.repos.AgentRuns.GetByID(ctx, resourceID); err != nil {
+			return nil, fmt.Errorf("run %d not found: %w", resourceID, err)
+		}
+	case ShareResourceReport:
+		if _, err := s.repos.Reports.GetByID(ctx, resourceID); err != nil {
+			return nil, fmt.Errorf("report %d not found: %w", resourceID, err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown share resource type %q (expected %s or %s)", resourceType, ShareResourceRun, ShareResourceReport)
+	}
+
+	if ttl <= 0 {
+		return nil, fmt.Errorf("share expiry must be positive")
+	}
+
+	tokenBytes := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	link := &ShareLink{
+		Token:        hex.EncodeToString(tokenBytes),
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		ExpiresAt:    time.Now().Add(ttl),
+		CreatedAt:    time.Now(),
+	}
+
+	value, err := json.Marshal(link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode share link: %w", err)
+	}
+
+	description := fmt.Sprintf("Read-only share link for %s %d (expires %s)",
+		resourceType, resourceID, link.ExpiresAt.Format("2006-01-02 15:04"))
+	if err := s.repos.Settings.Set(shareSettingPrefix+link.Token, string(value), description); err != nil {
+		return nil, fmt.Errorf("failed to store share link: %w", err)
+	}
+
+	return link, nil
+}
+
+// Resolve looks up a share token, deleting and rejecting expired links
+func (s *ShareService) Resolve(token string) (*ShareLink, error) {
+	setting, err := s.repos.Settings.GetByKey(shareSettingPrefix + token)
+	if err != nil {
+		return nil, fmt.Errorf("share link not found")
+	}
+
+	var link ShareLink
+	if err := json.Unmarshal([]byte(setting.Value), &link); err != nil {
+		return nil, fmt.Errorf("invalid share link")
+	}
+
+	if link.Expired() {
+		_ = s.repos.Settings.Delete(setting.Key)
+		return nil, fmt.Errorf("share link expired")
+	}
+
+	return &link, nil
+}
+
+// Revoke removes a share link before its expiry
+func (s *ShareService) Revoke(token string) error {
+	return s.repos.Settings.Delete(shareSettingPrefix + token)
+}
+
+// ParseShareExpiry parses share expiry strings like "7d", "12h", or "30m".
+// The "d" suffix (days) is accepted on top of standard Go durations.
+func ParseShareExpiry(expires string) (time.Duration, error) {
+	if strings.HasSuffix(expires, "d") {
+		days, err := strconv.ParseFloat(strings.TrimSuffix(expires, "d"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid expiry %q (expected e.g. 7d, 12h, 30m)", expires)
+		}
+		return time.Duration(days * float64(24*time.Hour)), nil
+	}
+
+	ttl, err := time.ParseDuration(expires)
+	if err != nil {
+		return 0, fmt.Errorf("invalid expiry %q (expected e.g. 7d, 12h, 30m)", expires)
+	}
+	return ttl, nil
+}
+
+// Patterns for secrets that must never appear on a shared page. Values are
+// replaced wholesale rather than partially masked.
+var shareRedactionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`sk-[A-Za-z0-9_-]{16,}`),                                                // OpenAI-style API keys
+	regexp.MustCompile(`AKIA[0-9A-Z]{16}`),                                                     // AWS access key IDs
+	regexp.MustCompile(`ghp_[A-Za-z0-9]{36}`),                                                  // GitHub personal access tokens
+	regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._~+/-]{16,}=*`),                                // Bearer tokens
+	regexp.MustCompile(`(?i)(api[_-]?key|token|secret|password|passwd)["'\s:=]+[^\s"',}]+`),    // key=value style credentials
+	regexp.MustCompile(`(?i)(postgres|postgresql|mysql|redis|amqp)://[^:@\s]+:[^@\s]+@[^\s]+`), // connection strings with credentials
+}
+
+// RedactSensitive strips credential-looking content from text before it is
+// rendered on a public share page
+func RedactSensitive(text string) string {
+	for _, pattern := range shareRedactionPatterns {
+		text = pattern.ReplaceAllString(text, "[REDACTED]")
+	}
+	return text
+}
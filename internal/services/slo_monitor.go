@@ -0,0 +1,484 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+const (
+	// SLO definitions live in the settings table: one JSON AgentSLO document
+	// per agent under "slo.agent.<agent_id>"
+	sloSettingPrefix = "slo.agent."
+	// Group SLOs use the same document shape under "slo.group.<group_id>";
+	// metrics pool the member agents' runs and daily cost is the group's
+	// combined spend (i.e. a team budget)
+	sloGroupSettingPrefix = "slo.group."
+	// Optional alert webhook override (falls back to notify.webhook_url)
+	sloAlertWebhookSettingKey = "slo.alert_webhook_url"
+
+	sloEvaluationInterval = 5 * time.Minute
+	sloWindowRuns         = 50
+	sloAlertCooldown      = time.Hour
+
+	// Cost estimate matches the report generator: ~$0.002 per 1000 tokens
+	sloCostPerThousandTokens = 0.002
+)
+
+// AgentSLO defines per-agent service level objectives. Zero values disable
+// the corresponding objective, so an agent can target only latency, only
+// cost, or any combination. Set via:
+//
+//	stn settings set slo.agent.3 '{"p95_latency_seconds": 60, "error_rate_percent": 5, "daily_cost_usd": 10}'
+type AgentSLO struct {
+	P95LatencySeconds float64 `json:"p95_latency_seconds,omitempty"`
+	ErrorRatePercent  float64 `json:"error_rate_percent,omitempty"`
+	DailyCostUSD      float64 `json:"daily_cost_usd,omitempty"`
+}
+
+// SLOStatus reports current compliance for one agent over the rolling window
+type SLOStatus struct {
+	AgentID           int64
+	AgentName         string
+	SLO               AgentSLO
+	RunsAnalyzed      int
+	P95LatencySeconds float64
+	ErrorRatePercent  float64
+	DailyCostUSD      float64
+	Breaches          []string
+}
+
+// Compliant reports whether every defined objective is currently met
+func (s *SLOStatus) Compliant() bool {
+	return len(s.Breaches) == 0
+}
+
+// GroupSLOStatus reports current compliance for an agent group. Latency and
+// error rate pool the members' recent runs; daily cost is the group's
+// combined spend, so DailyCostUSD in the SLO acts as a team budget.
+type GroupSLOStatus struct {
+	GroupID           int64
+	GroupName         string
+	SLO               AgentSLO
+	MemberCount       int
+	RunsAnalyzed      int
+	P95LatencySeconds float64
+	ErrorRatePercent  float64
+	DailyCostUSD      float64
+	Breaches          []string
+}
+
+// Compliant reports whether every defined objective is currently met
+func (s *GroupSLOStatus) Compliant() bool {
+	return len(s.Breaches) == 0
+}
+
+// SLOMonitorService evaluates agent SLOs against recent runs. In serve mode
+// it runs on a ticker and fires webhook/Slack alerts on breach (with a
+// per-agent cooldown); the CLI uses it directly for `stn agent show`.
+type SLOMonitorService struct {
+	repos      *repositories.Repositories
+	webhookURL string
+	client     *http.Client
+	stopChan   chan struct{}
+
+	mu              sync.Mutex
+	lastAlerts      map[int64]time.Time
+	lastGroupAlerts map[int64]time.Time
+}
+
+// NewSLOMonitorService creates an SLO monitor. The alert webhook comes from
+// the "slo.alert_webhook_url" setting when present, otherwise from the
+// notify webhook config.
+func NewSLOMonitorService(repos *repositories.Repositories, cfg *config.Config) *SLOMonitorService {
+	webhookURL := ""
+	if cfg != nil {
+		webhookURL = cfg.Notify.WebhookURL
+	}
+	if setting, err := repos.Settings.GetByKey(sloAlertWebhookSettingKey); err == nil && setting.Value != "" {
+		webhookURL = setting.Value
+	}
+
+	return &SLOMonitorService{
+		repos:           repos,
+		webhookURL:      webhookURL,
+		client:          &http.Client{Timeout: 10 * time.Second},
+		stopChan:        make(chan struct{}),
+		lastAlerts:      make(map[int64]time.Time),
+		lastGroupAlerts: make(map[int64]time.Time),
+	}
+}
+
+// Start begins periodic SLO evaluation (serve mode)
+func (s *SLOMonitorService) Start() {
+	go func() {
+		ticker := time.NewTicker(sloEvaluationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.evaluateAll(context.Background())
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+	logging.Info("SLO monitor started (evaluating every %s)", sloEvaluationInterval)
+}
+
+// Stop halts periodic evaluation
+func (s *SLOMonitorService) Stop() {
+	close(s.stopChan)
+}
+
+// GetAgentSLO loads the SLO definition for an agent from settings; returns
+// nil when no SLO is defined
+func (s *SLOMonitorService) GetAgentSLO(agentID int64) (*AgentSLO, error) {
+	setting, err := s.repos.Settings.GetByKey(fmt.Sprintf("%s%d", sloSettingPrefix, agentID))
+	if err != nil {
+		return nil, nil
+	}
+
+	var slo AgentSLO
+	if err := json.Unmarshal([]byte(setting.Value), &slo); err != nil {
+		return nil, fmt.Errorf("invalid SLO definition for agent %d: %w", agentID, err)
+	}
+	return &slo, nil
+}
+
+// EvaluateAgent computes current SLO compliance for an agent over the last
+// sloWindowRuns runs
+func (s *SLOMonitorService) EvaluateAgent(ctx context.Context, agentID int64, slo *AgentSLO) (*SLOStatus, error) {
+	runs, err := s.repos.AgentRuns.GetRecentByAgent(ctx, agentID, sloWindowRuns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recent runs: %w", err)
+	}
+
+	status := &SLOStatus{AgentID: agentID, SLO: *slo, RunsAnalyzed: len(runs)}
+	if agent, err := s.repos.Agents.GetByID(agentID); err == nil {
+		status.AgentName = agent.Name
+	}
+	if len(runs) == 0 {
+		return status, nil
+	}
+
+	var durations []float64
+	failedCount := 0
+	var dailyTokens int64
+	dayAgo := time.Now().Add(-24 * time.Hour)
+
+	for _, run := range runs {
+		if run.DurationSeconds.Valid {
+			durations = append(durations, run.DurationSeconds.Float64)
+		}
+		if run.Status == "failed" {
+			failedCount++
+		}
+		if run.TotalTokens.Valid && run.StartedAt.Valid && run.StartedAt.Time.After(dayAgo) {
+			dailyTokens += run.TotalTokens.Int64
+		}
+	}
+
+	status.P95LatencySeconds = percentile(durations, 0.95)
+	status.ErrorRatePercent = float64(failedCount) / float64(len(runs)) * 100
+	status.DailyCostUSD = EstimateRunCostUSD(dailyTokens)
+
+	if slo.P95LatencySeconds > 0 && status.P95LatencySeconds > slo.P95LatencySeconds {
+		status.Breaches = append(status.Breaches,
+			fmt.Sprintf("p95 latency %.1fs exceeds target %.0fs", status.P95LatencySeconds, slo.P95LatencySeconds))
+	}
+	if slo.ErrorRatePercent > 0 && status.ErrorRatePercent > slo.ErrorRatePercent {
+		status.Breaches = append(status.Breaches,
+			fmt.Sprintf("error rate %.1f%% exceeds target %.1f%%", status.ErrorRatePercent, slo.ErrorRatePercent))
+	}
+	if slo.DailyCostUSD > 0 && status.DailyCostUSD > slo.DailyCostUSD {
+		status.Breaches = append(status.Breaches,
+			fmt.Sprintf("daily cost $%.2f exceeds target $%.2f", status.DailyCostUSD, slo.DailyCostUSD))
+	}
+
+	return status, nil
+}
+
+// GetGroupSLO loads the SLO definition for an agent group from settings;
+// returns nil when no SLO is defined
+func (s *SLOMonitorService) GetGroupSLO(groupID int64) (*AgentSLO, error) {
+	setting, err := s.repos.Settings.GetByKey(fmt.Sprintf("%s%d", sloGroupSettingPrefix, groupID))
+	if err != nil {
+		return nil, nil
+	}
+
+	var slo AgentSLO
+	if err := json.Unmarshal([]byte(setting.Value), &slo); err != nil {
+		return nil, fmt.Errorf("invalid SLO definition for group %d: %w", groupID, err)
+	}
+	return &slo, nil
+}
+
+// EvaluateGroup computes current SLO compliance for an agent group by
+// pooling the last sloWindowRuns runs from each member
+func (s *SLOMonitorService) EvaluateGroup(ctx context.Context, groupID int64, slo *AgentSLO) (*GroupSLOStatus, error) {
+	members, err := s.repos.AgentGroups.ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load group members: %w", err)
+	}
+
+	status := &GroupSLOStatus{GroupID: groupID, SLO: *slo, MemberCount: len(members)}
+	if group, err := s.repos.AgentGroups.GetByID(ctx, groupID); err == nil {
+		status.GroupName = group.Name
+	}
+
+	var durations []float64
+	failedCount := 0
+	var dailyTokens int64
+	dayAgo := time.Now().Add(-24 * time.Hour)
+
+	for _, member := range members {
+		runs, err := s.repos.AgentRuns.GetRecentByAgent(ctx, member.ID, sloWindowRuns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load recent runs for agent %d: %w", member.ID, err)
+		}
+		status.RunsAnalyzed += len(runs)
+
+		for _, run := range runs {
+			if run.DurationSeconds.Valid {
+				durations = append(durations, run.DurationSeconds.Float64)
+			}
+			if run.Status == "failed" {
+				failedCount++
+			}
+			if run.TotalTokens.Valid && run.StartedAt.Valid && run.StartedAt.Time.After(dayAgo) {
+				dailyTokens += run.TotalTokens.Int64
+			}
+		}
+	}
+
+	if status.RunsAnalyzed == 0 {
+		return status, nil
+	}
+
+	status.P95LatencySeconds = percentile(durations, 0.95)
+	status.ErrorRatePercent = float64(failedCount) / float64(status.RunsAnalyzed) * 100
+	status.DailyCostUSD = EstimateRunCostUSD(dailyTokens)
+
+	if slo.P95LatencySeconds > 0 && status.P95LatencySeconds > slo.P95LatencySeconds {
+		status.Breaches = append(status.Breaches,
+			fmt.Sprintf("p95 latency %.1fs exceeds target %.0fs", status.P95LatencySeconds, slo.P95LatencySeconds))
+	}
+	if slo.ErrorRatePercent > 0 && status.ErrorRatePercent > slo.ErrorRatePercent {
+		status.Breaches = append(status.Breaches,
+			fmt.Sprintf("error rate %.1f%% exceeds target %.1f%%", status.ErrorRatePercent, slo.ErrorRatePercent))
+	}
+	if slo.DailyCostUSD > 0 && status.DailyCostUSD > slo.DailyCostUSD {
+		status.Breaches = append(status.Breaches,
+			fmt.Sprintf("daily group cost $%.2f exceeds budget $%.2f", status.DailyCostUSD, slo.DailyCostUSD))
+	}
+
+	return status, nil
+}
+
+// evaluateAll checks every agent with a defined SLO and alerts on breaches
+func (s *SLOMonitorService) evaluateAll(ctx context.Context) {
+	settings, err := s.repos.Settings.GetAll()
+	if err != nil {
+		logging.Debug("SLO monitor: failed to load settings: %v", err)
+		return
+	}
+
+	for _, setting := range settings {
+		if strings.HasPrefix(setting.Key, sloGroupSettingPrefix) {
+			s.evaluateGroupSetting(ctx, setting.Key)
+			continue
+		}
+		if !strings.HasPrefix(setting.Key, sloSettingPrefix) {
+			continue
+		}
+		agentID, err := strconv.ParseInt(strings.TrimPrefix(setting.Key, sloSettingPrefix), 10, 64)
+		if err != nil {
+			logging.Debug("SLO monitor: skipping malformed setting key %s", setting.Key)
+			continue
+		}
+
+		slo, err := s.GetAgentSLO(agentID)
+		if err != nil || slo == nil {
+			if err != nil {
+				logging.Info("SLO monitor: %v", err)
+			}
+			continue
+		}
+
+		status, err := s.EvaluateAgent(ctx, agentID, slo)
+		if err != nil {
+			logging.Debug("SLO monitor: failed to evaluate agent %d: %v", agentID, err)
+			continue
+		}
+
+		if !status.Compliant() && s.shouldAlert(agentID) {
+			s.sendAlert(status)
+		}
+	}
+}
+
+// evaluateGroupSetting evaluates one "slo.group.<id>" setting and alerts on
+// breaches
+func (s *SLOMonitorService) evaluateGroupSetting(ctx context.Context, key string) {
+	groupID, err := strconv.ParseInt(strings.TrimPrefix(key, sloGroupSettingPrefix), 10, 64)
+	if err != nil {
+		logging.Debug("SLO monitor: skipping malformed setting key %s", key)
+		return
+	}
+
+	slo, err := s.GetGroupSLO(groupID)
+	if err != nil || slo == nil {
+		if err != nil {
+			logging.Info("SLO monitor: %v", err)
+		}
+		return
+	}
+
+	status, err := s.EvaluateGroup(ctx, groupID, slo)
+	if err != nil {
+		logging.Debug("SLO monitor: failed to evaluate group %d: %v", groupID, err)
+		return
+	}
+
+	if !status.Compliant() && s.shouldAlertGroup(groupID) {
+		s.sendGroupAlert(status)
+	}
+}
+
+// shouldAlert enforces the per-agent alert cooldown
+func (s *SLOMonitorService) shouldAlert(agentID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.lastAlerts[agentID]; ok && time.Since(last) < sloAlertCooldown {
+		return false
+	}
+	s.lastAlerts[agentID] = time.Now()
+	return true
+}
+
+// shouldAlertGroup enforces the per-group alert cooldown
+func (s *SLOMonitorService) shouldAlertGroup(groupID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.lastGroupAlerts[groupID]; ok && time.Since(last) < sloAlertCooldown {
+		return false
+	}
+	s.lastGroupAlerts[groupID] = time.Now()
+	return true
+}
+
+// sendAlert posts a breach notification to the configured webhook. The
+// payload includes a "text" field so Slack incoming webhooks render it
+// directly; other receivers get the structured fields alongside.
+func (s *SLOMonitorService) sendAlert(status *SLOStatus) {
+	if s.webhookURL == "" {
+		logging.Info("SLO breach for agent %s (%d): %s (no alert webhook configured)",
+			status.AgentName, status.AgentID, strings.Join(status.Breaches, "; "))
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"text": fmt.Sprintf("⚠️ Station SLO breach for agent '%s': %s",
+			status.AgentName, strings.Join(status.Breaches, "; ")),
+		"agent_id":            status.AgentID,
+		"agent_name":          status.AgentName,
+		"breaches":            status.Breaches,
+		"p95_latency_seconds": status.P95LatencySeconds,
+		"error_rate_percent":  status.ErrorRatePercent,
+		"daily_cost_usd":      status.DailyCostUSD,
+		"runs_analyzed":       status.RunsAnalyzed,
+	})
+	if err != nil {
+		return
+	}
+
+	resp, err := s.client.Post(s.webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		logging.Info("SLO monitor: failed to send alert for agent %d: %v", status.AgentID, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		logging.Info("SLO monitor: alert webhook returned status %d for agent %d", resp.StatusCode, status.AgentID)
+	} else {
+		logging.Info("SLO monitor: alert sent for agent %s (%d)", status.AgentName, status.AgentID)
+	}
+}
+
+// sendGroupAlert posts a group breach notification to the configured webhook
+func (s *SLOMonitorService) sendGroupAlert(status *GroupSLOStatus) {
+	if s.webhookURL == "" {
+		logging.Info("SLO breach for group %s (%d): %s (no alert webhook configured)",
+			status.GroupName, status.GroupID, strings.Join(status.Breaches, "; "))
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"text": fmt.Sprintf("⚠️ Station SLO breach for agent group '%s': %s",
+			status.GroupName, strings.Join(status.Breaches, "; ")),
+		"group_id":            status.GroupID,
+		"group_name":          status.GroupName,
+		"member_count":        status.MemberCount,
+		"breaches":            status.Breaches,
+		"p95_latency_seconds": status.P95LatencySeconds,
+		"error_rate_percent":  status.ErrorRatePercent,
+		"daily_cost_usd":      status.DailyCostUSD,
+		"runs_analyzed":       status.RunsAnalyzed,
+	})
+	if err != nil {
+		return
+	}
+
+	resp, err := s.client.Post(s.webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		logging.Info("SLO monitor: failed to send alert for group %d: %v", status.GroupID, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		logging.Info("SLO monitor: alert webhook returned status %d for group %d", resp.StatusCode, status.GroupID)
+	} else {
+		logging.Info("SLO monitor: alert sent for group %s (%d)", status.GroupName, status.GroupID)
+	}
+}
+
+// EstimateRunCostUSD converts a token count to an approximate dollar cost
+// using the same heuristic as the report generator (~$0.002 per 1000 tokens)
+func EstimateRunCostUSD(tokens int64) float64 {
+	return float64(tokens) / 1000.0 * sloCostPerThousandTokens
+}
+
+// percentile returns the p-th percentile (0-1) of values using
+// nearest-rank; 0 when there are no samples
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
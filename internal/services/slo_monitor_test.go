@@ -0,0 +1,63 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		p        float64
+		expected float64
+	}{
+		{"empty", nil, 0.95, 0},
+		{"single value", []float64{42}, 0.95, 42},
+		{"p95 of 1..100", seq(1, 100), 0.95, 95},
+		{"p50 of 1..100", seq(1, 100), 0.50, 50},
+		{"unsorted input", []float64{30, 10, 20}, 0.95, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.values, tt.p); got != tt.expected {
+				t.Errorf("percentile(%v, %v) = %v, want %v", tt.values, tt.p, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAgentSLOZeroValuesDisableObjectives(t *testing.T) {
+	var slo AgentSLO
+	if err := json.Unmarshal([]byte(`{"p95_latency_seconds": 60}`), &slo); err != nil {
+		t.Fatalf("failed to unmarshal SLO: %v", err)
+	}
+
+	if slo.P95LatencySeconds != 60 {
+		t.Errorf("expected p95 latency 60, got %v", slo.P95LatencySeconds)
+	}
+	if slo.ErrorRatePercent != 0 || slo.DailyCostUSD != 0 {
+		t.Errorf("expected unset objectives to stay zero, got %+v", slo)
+	}
+}
+
+func TestSLOStatusCompliant(t *testing.T) {
+	status := &SLOStatus{}
+	if !status.Compliant() {
+		t.Error("status with no breaches should be compliant")
+	}
+
+	status.Breaches = append(status.Breaches, "p95 latency 75.0s exceeds target 60s")
+	if status.Compliant() {
+		t.Error("status with breaches should not be compliant")
+	}
+}
+
+func seq(from, to int) []float64 {
+	values := make([]float64, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		values = append(values, float64(i))
+	}
+	return values
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+// SoftDeletePurgeResult summarizes one purge pass
+type SoftDeletePurgeResult struct {
+	AgentsPurged       int
+	EnvironmentsPurged int
+}
+
+// PurgeSoftDeleted hard-deletes agents and environments whose soft-delete
+// timestamp is older than the retention window, along with their parked
+// .prompt files and trashed environment directories. Run periodically by
+// the server and before restore listings so the trash doesn't grow forever.
+func PurgeSoftDeleted(ctx context.Context, repos *repositories.Repositories, retention time.Duration) (*SoftDeletePurgeResult, error) {
+	cutoff := time.Now().UTC().Add(-retention)
+	result := &SoftDeletePurgeResult{}
+
+	// Purge agents first so their parked files can still be resolved to an
+	// environment name before the environment itself is purged
+	purgedAgents, err := repos.Agents.PurgeDeletedBefore(ctx, cutoff)
+	for _, agent := range purgedAgents {
+		if env, envErr := repos.Environments.GetByID(agent.EnvironmentID); envErr == nil {
+			if rmErr := os.Remove(parkedAgentPromptPath(agent.Name, env.Name)); rmErr != nil && !os.IsNotExist(rmErr) {
+				logging.Info("Warning: failed to remove parked .prompt file for purged agent %s: %v", agent.Name, rmErr)
+			}
+		}
+	}
+	result.AgentsPurged = len(purgedAgents)
+	if err != nil {
+		return result, err
+	}
+
+	purgedEnvs, envErr := repos.Environments.PurgeDeletedBefore(ctx, cutoff)
+	for _, env := range purgedEnvs {
+		if rmErr := os.RemoveAll(trashedEnvironmentDir(env.Name)); rmErr != nil {
+			logging.Info("Warning: failed to remove trashed directory for purged environment %s: %v", env.Name, rmErr)
+		}
+	}
+	result.EnvironmentsPurged = len(purgedEnvs)
+	if envErr != nil {
+		return result, envErr
+	}
+
+	if result.AgentsPurged > 0 || result.EnvironmentsPurged > 0 {
+		logging.Info("Soft-delete purge removed %d agent(s) and %d environment(s) deleted before %s",
+			result.AgentsPurged, result.EnvironmentsPurged, cutoff.Format(time.RFC3339))
+	}
+	return result, nil
+}
+
+// SoftDeletePurgeService runs PurgeSoftDeleted daily in serve mode
+type SoftDeletePurgeService struct {
+	repos     *repositories.Repositories
+	retention time.Duration
+	stopChan  chan struct{}
+}
+
+// NewSoftDeletePurgeService creates the purge service using the configured
+// retention window (soft_delete_retention_days, default 30)
+func NewSoftDeletePurgeService(repos *repositories.Repositories, cfg *config.Config) *SoftDeletePurgeService {
+	retentionDays := cfg.SoftDeleteRetentionDays
+	if retentionDays <= 0 {
+		retentionDays = 30
+	}
+	return &SoftDeletePurgeService{
+		repos:     repos,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start purges once at boot and then daily until stopped
+func (s *SoftDeletePurgeService) Start() {
+	go func() {
+		if _, err := PurgeSoftDeleted(context.Background(), s.repos, s.retention); err != nil {
+			logging.Info("Soft-delete purge failed: %v", err)
+		}
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := PurgeSoftDeleted(context.Background(), s.repos, s.retention); err != nil {
+					logging.Info("Soft-delete purge failed: %v", err)
+				}
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the purge loop
+func (s *SoftDeletePurgeService) Stop() {
+	close(s.stopChan)
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"station/internal/db/repositories"
+)
+
+const (
+	// sshAuditLogKey holds the SSH admin action log in the settings table as
+	// a JSON list of SSHAuditRecord, capped at sshAuditLogCap entries
+	sshAuditLogKey = "ssh.audit.log"
+	sshAuditLogCap = 200
+)
+
+// SSHAuditRecord is one recorded admin action from an SSH session
+type SSHAuditRecord struct {
+	Time   time.Time `json:"time"`
+	User   string    `json:"user"`
+	Action string    `json:"action"`
+}
+
+// SSHAuditService records admin actions taken over the SSH interface
+// (session start/end, command palette actions) so operator activity is
+// attributable after the fact
+type SSHAuditService struct {
+	repos *repositories.Repositories
+}
+
+// NewSSHAuditService creates a new SSH audit service
+func NewSSHAuditService(repos *repositories.Repositories) *SSHAuditService {
+	return &SSHAuditService{repos: repos}
+}
+
+// Record appends one admin action to the audit log, trimming the oldest
+// entries past the cap
+func (s *SSHAuditService) Record(username, action string) error {
+	records, err := s.loadRecords()
+	if err != nil {
+		return err
+	}
+
+	records = append(records, SSHAuditRecord{
+		Time:   time.Now(),
+		User:   username,
+		Action: action,
+	})
+	if len(records) > sshAuditLogCap {
+		records = records[len(records)-sshAuditLogCap:]
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to serialize SSH audit log: %w", err)
+	}
+	return s.repos.Settings.Set(sshAuditLogKey, string(data), "SSH admin interface audit log")
+}
+
+// Recent returns up to limit of the most recent audit records, newest last
+func (s *SSHAuditService) Recent(limit int) ([]SSHAuditRecord, error) {
+	records, err := s.loadRecords()
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+	return records, nil
+}
+
+func (s *SSHAuditService) loadRecords() ([]SSHAuditRecord, error) {
+	setting, err := s.repos.Settings.GetByKey(sshAuditLogKey)
+	if err != nil || setting == nil || setting.Value == "" {
+		return nil, nil
+	}
+	var records []SSHAuditRecord
+	if err := json.Unmarshal([]byte(setting.Value), &records); err != nil {
+		return nil, fmt.Errorf("corrupt SSH audit log: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+
+	"station/internal/db/repositories"
+)
+
+const (
+	// sshKeySettingPrefix namespaces per-user authorized keys in the settings
+	// table: ssh.keys.<username> holds a JSON list of SSHAuthorizedKey
+	sshKeySettingPrefix = "ssh.keys."
+)
+
+// SSHAuthorizedKey is one named public key authorized for a Station user on
+// the SSH admin interface
+type SSHAuthorizedKey struct {
+	Name      string    `json:"name"`
+	PublicKey string    `json:"public_key"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// SSHKeyService manages per-user SSH public keys for the admin interface so
+// each operator authenticates with their own key instead of a shared
+// credential. Keys are stored in the settings table and checked by the SSH
+// server alongside system authorized_keys.
+type SSHKeyService struct {
+	repos *repositories.Repositories
+}
+
+// NewSSHKeyService creates a new SSH key management service
+func NewSSHKeyService(repos *repositories.Repositories) *SSHKeyService {
+	return &SSHKeyService{repos: repos}
+}
+
+func sshKeySettingKey(username string) string {
+	return sshKeySettingPrefix + username
+}
+
+// ListKeys returns the keys authorized for a user, oldest first. Returns an
+// empty list when the user has no managed keys.
+func (s *SSHKeyService) ListKeys(username string) ([]SSHAuthorizedKey, error) {
+	setting, err := s.repos.Settings.GetByKey(sshKeySettingKey(username))
+	if err != nil || setting == nil || setting.Value == "" {
+		return nil, nil
+	}
+	var keys []SSHAuthorizedKey
+	if err := json.Unmarshal([]byte(setting.Value), &keys); err != nil {
+		return nil, fmt.Errorf("corrupt SSH key list for user '%s': %w", username, err)
+	}
+	return keys, nil
+}
+
+// AddKey validates and registers a public key (authorized_keys format) for a
+// user. When name is empty the key's comment is used.
+func (s *SSHKeyService) AddKey(username, name, authorizedKeyLine string) (*SSHAuthorizedKey, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username cannot be empty")
+	}
+
+	parsed, comment, _, _, err := gossh.ParseAuthorizedKey([]byte(strings.TrimSpace(authorizedKeyLine)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	normalized := strings.TrimSpace(string(gossh.MarshalAuthorizedKey(parsed)))
+
+	if name == "" {
+		name = comment
+	}
+	if name == "" {
+		return nil, fmt.Errorf("key has no comment — provide a name with --name")
+	}
+
+	keys, err := s.ListKeys(username)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range keys {
+		if existing.Name == name {
+			return nil, fmt.Errorf("a key named '%s' already exists for user '%s'", name, username)
+		}
+		if existing.PublicKey == normalized {
+			return nil, fmt.Errorf("this key is already registered for user '%s' as '%s'", username, existing.Name)
+		}
+	}
+
+	key := SSHAuthorizedKey{
+		Name:      name,
+		PublicKey: normalized,
+		AddedAt:   time.Now(),
+	}
+	keys = append(keys, key)
+	if err := s.saveKeys(username, keys); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RemoveKey removes a user's key by name
+func (s *SSHKeyService) RemoveKey(username, name string) error {
+	keys, err := s.ListKeys(username)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]SSHAuthorizedKey, 0, len(keys))
+	for _, key := range keys {
+		if key.Name != name {
+			remaining = append(remaining, key)
+		}
+	}
+	if len(remaining) == len(keys) {
+		return fmt.Errorf("no key named '%s' for user '%s'", name, username)
+	}
+
+	if len(remaining) == 0 {
+		return s.repos.Settings.Delete(sshKeySettingKey(username))
+	}
+	return s.saveKeys(username, remaining)
+}
+
+// Authenticate reports whether the presented public key is registered for the
+// user. Used by the SSH server's public key callback.
+func (s *SSHKeyService) Authenticate(username string, presented gossh.PublicKey) bool {
+	keys, err := s.ListKeys(username)
+	if err != nil {
+		return false
+	}
+	for _, key := range keys {
+		authorized, _, _, _, err := gossh.ParseAuthorizedKey([]byte(key.PublicKey))
+		if err != nil {
+			continue
+		}
+		if authorized.Type() == presented.Type() &&
+			string(authorized.Marshal()) == string(presented.Marshal()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SSHKeyService) saveKeys(username string, keys []SSHAuthorizedKey) error {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("failed to serialize SSH keys: %w", err)
+	}
+	description := fmt.Sprintf("Authorized SSH keys for user '%s'", username)
+	return s.repos.Settings.Set(sshKeySettingKey(username), string(data), description)
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	gossh "golang.org/x/crypto/ssh"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+// generateTestKey produces a valid authorized_keys line with the given comment
+func generateTestKey(t *testing.T, comment string) string {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	sshPub, err := gossh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to convert key: %v", err)
+	}
+	line := strings.TrimSpace(string(gossh.MarshalAuthorizedKey(sshPub)))
+	if comment != "" {
+		line += " " + comment
+	}
+	return line
+}
+
+func newSSHKeyTestService(t *testing.T) *SSHKeyService {
+	t.Helper()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+	return NewSSHKeyService(repositories.New(testDB))
+}
+
+// TestSSHKeyAddListRemove tests the per-user key lifecycle
+func TestSSHKeyAddListRemove(t *testing.T) {
+	service := newSSHKeyTestService(t)
+
+	keys, err := service.ListKeys("alice")
+	if err != nil || len(keys) != 0 {
+		t.Fatalf("new user should have no keys, got %v, %v", keys, err)
+	}
+
+	laptopKey := generateTestKey(t, "alice@laptop")
+	added, err := service.AddKey("alice", "", laptopKey)
+	if err != nil {
+		t.Fatalf("AddKey() error: %v", err)
+	}
+	if added.Name != "alice@laptop" {
+		t.Errorf("key name should default to the comment, got %q", added.Name)
+	}
+
+	if _, err := service.AddKey("alice", "desktop", generateTestKey(t, "alice@desktop")); err != nil {
+		t.Fatalf("AddKey() second key error: %v", err)
+	}
+
+	keys, err = service.ListKeys("alice")
+	if err != nil || len(keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d, %v", len(keys), err)
+	}
+
+	// Other users are isolated
+	if keys, _ := service.ListKeys("bob"); len(keys) != 0 {
+		t.Errorf("bob should have no keys, got %d", len(keys))
+	}
+
+	if err := service.RemoveKey("alice", "desktop"); err != nil {
+		t.Fatalf("RemoveKey() error: %v", err)
+	}
+	keys, _ = service.ListKeys("alice")
+	if len(keys) != 1 || keys[0].Name != "alice@laptop" {
+		t.Errorf("expected only alice@laptop left, got %v", keys)
+	}
+
+	if err := service.RemoveKey("alice", "missing"); err == nil {
+		t.Error("removing an unknown key should fail")
+	}
+}
+
+// TestSSHKeyAddValidation tests rejection of bad keys and duplicates
+func TestSSHKeyAddValidation(t *testing.T) {
+	service := newSSHKeyTestService(t)
+
+	if _, err := service.AddKey("alice", "bad", "not-a-key"); err == nil {
+		t.Error("invalid key material should be rejected")
+	}
+	if _, err := service.AddKey("", "x", generateTestKey(t, "x")); err == nil {
+		t.Error("empty username should be rejected")
+	}
+
+	laptopKey := generateTestKey(t, "alice@laptop")
+	if _, err := service.AddKey("alice", "laptop", laptopKey); err != nil {
+		t.Fatalf("AddKey() error: %v", err)
+	}
+	if _, err := service.AddKey("alice", "laptop", generateTestKey(t, "other")); err == nil {
+		t.Error("duplicate key name should be rejected")
+	}
+	if _, err := service.AddKey("alice", "other-name", laptopKey); err == nil {
+		t.Error("re-registering the same key material should be rejected")
+	}
+}
+
+// TestSSHAuditLog tests action recording and cap trimming
+func TestSSHAuditLog(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+	service := NewSSHAuditService(repositories.New(testDB))
+
+	records, err := service.Recent(10)
+	if err != nil || len(records) != 0 {
+		t.Fatalf("fresh log should be empty, got %v, %v", records, err)
+	}
+
+	if err := service.Record("alice", "session started"); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+	if err := service.Record("alice", "List Agents"); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+
+	records, err = service.Recent(10)
+	if err != nil || len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d, %v", len(records), err)
+	}
+	if records[1].User != "alice" || records[1].Action != "List Agents" {
+		t.Errorf("newest record = %+v", records[1])
+	}
+
+	// Recent(limit) returns only the newest entries
+	records, _ = service.Recent(1)
+	if len(records) != 1 || records[0].Action != "List Agents" {
+		t.Errorf("Recent(1) = %v, want the newest record", records)
+	}
+
+	// The log is trimmed to the cap
+	for i := 0; i < sshAuditLogCap+10; i++ {
+		if err := service.Record("alice", "action"); err != nil {
+			t.Fatalf("Record() error: %v", err)
+		}
+	}
+	records, _ = service.Recent(0)
+	if len(records) != sshAuditLogCap {
+		t.Errorf("audit log should be capped at %d, got %d", sshAuditLogCap, len(records))
+	}
+}
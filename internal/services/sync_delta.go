@@ -0,0 +1,102 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"station/internal/db/repositories"
+)
+
+// serverHashesMetadataKey is where per-server config hashes live inside the
+// file config's metadata JSON
+const serverHashesMetadataKey = "server_hashes"
+
+// serverConfigHashes maps MCP server name -> sha256 of its rendered config.
+// Hashes are computed from the template AFTER variable resolution, so a
+// changed variable value re-triggers discovery just like an edited template.
+type serverConfigHashes map[string]string
+
+// computeServerConfigHashes hashes each rendered MCP server config in a
+// parsed template. Templates without a servers section return an empty map.
+func computeServerConfigHashes(mcpConfig map[string]interface{}) serverConfigHashes {
+	var serversData map[string]interface{}
+	if mcpServers, ok := mcpConfig["mcpServers"].(map[string]interface{}); ok {
+		serversData = mcpServers
+	} else if servers, ok := mcpConfig["servers"].(map[string]interface{}); ok {
+		serversData = servers
+	}
+
+	hashes := serverConfigHashes{}
+	for serverName, serverConfigRaw := range serversData {
+		configBytes, err := json.Marshal(serverConfigRaw)
+		if err != nil {
+			continue // unmarshalable configs fail later in server sync with a real error
+		}
+		hashes[serverName] = fmt.Sprintf("%x", sha256.Sum256(configBytes))
+	}
+	return hashes
+}
+
+// changedServerNames returns the servers whose rendered config differs from
+// the last sync - added, modified, or removed - sorted for stable output
+func changedServerNames(previous, current serverConfigHashes) []string {
+	changed := []string{}
+	for name, hash := range current {
+		if previous[name] != hash {
+			changed = append(changed, name)
+		}
+	}
+	for name := range previous {
+		if _, exists := current[name]; !exists {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// loadStoredServerHashes reads the per-server hashes recorded in a file
+// config's metadata. Missing or malformed metadata yields an empty map so
+// every server counts as changed.
+func loadStoredServerHashes(record *repositories.FileConfigRecord) serverConfigHashes {
+	if record == nil || record.Metadata == "" {
+		return serverConfigHashes{}
+	}
+
+	var metadata map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(record.Metadata), &metadata); err != nil {
+		return serverConfigHashes{}
+	}
+
+	hashes := serverConfigHashes{}
+	if raw, ok := metadata[serverHashesMetadataKey]; ok {
+		if err := json.Unmarshal(raw, &hashes); err != nil {
+			return serverConfigHashes{}
+		}
+	}
+	return hashes
+}
+
+// storeServerHashes persists the current per-server hashes into the file
+// config's metadata, preserving any other metadata keys
+func (s *DeclarativeSync) storeServerHashes(record *repositories.FileConfigRecord, hashes serverConfigHashes) error {
+	metadata := map[string]json.RawMessage{}
+	if record.Metadata != "" {
+		// Best effort - unreadable metadata is rebuilt from scratch
+		_ = json.Unmarshal([]byte(record.Metadata), &metadata)
+	}
+
+	hashesJSON, err := json.Marshal(hashes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal server hashes: %w", err)
+	}
+	metadata[serverHashesMetadataKey] = hashesJSON
+
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal file config metadata: %w", err)
+	}
+	return s.repos.FileMCPConfigs.UpdateMetadata(record.ID, string(metadataJSON))
+}
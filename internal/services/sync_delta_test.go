@@ -0,0 +1,107 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"station/internal/db/repositories"
+)
+
+func parseTemplateJSON(t *testing.T, content string) map[string]interface{} {
+	t.Helper()
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("failed to parse template JSON: %v", err)
+	}
+	return parsed
+}
+
+func TestComputeServerConfigHashes(t *testing.T) {
+	template := parseTemplateJSON(t, `{
+		"mcpServers": {
+			"filesystem": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem@latest", "/workspace"]},
+			"ship": {"command": "ship", "args": ["mcp", "security", "--stdio"]}
+		}
+	}`)
+
+	hashes := computeServerConfigHashes(template)
+	if len(hashes) != 2 {
+		t.Fatalf("Expected 2 server hashes, got %d", len(hashes))
+	}
+	if hashes["filesystem"] == "" || hashes["ship"] == "" {
+		t.Error("Expected non-empty hashes for every server")
+	}
+	if hashes["filesystem"] == hashes["ship"] {
+		t.Error("Expected different configs to produce different hashes")
+	}
+}
+
+func TestComputeServerConfigHashesStable(t *testing.T) {
+	content := `{"mcpServers": {"filesystem": {"command": "npx", "args": ["/workspace"]}}}`
+
+	first := computeServerConfigHashes(parseTemplateJSON(t, content))
+	second := computeServerConfigHashes(parseTemplateJSON(t, content))
+	if first["filesystem"] != second["filesystem"] {
+		t.Error("Expected identical configs to hash identically across syncs")
+	}
+}
+
+func TestComputeServerConfigHashesNoServers(t *testing.T) {
+	hashes := computeServerConfigHashes(parseTemplateJSON(t, `{"name": "config-only"}`))
+	if len(hashes) != 0 {
+		t.Errorf("Expected no hashes for a config-only template, got %v", hashes)
+	}
+}
+
+func TestChangedServerNames(t *testing.T) {
+	previous := serverConfigHashes{"filesystem": "aaa", "ship": "bbb", "removed": "ccc"}
+	current := serverConfigHashes{"filesystem": "aaa", "ship": "changed", "added": "ddd"}
+
+	changed := changedServerNames(previous, current)
+	expected := []string{"added", "removed", "ship"}
+	if len(changed) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, changed)
+	}
+	for i, name := range expected {
+		if changed[i] != name {
+			t.Errorf("Expected changed[%d]=%s, got %s", i, name, changed[i])
+		}
+	}
+}
+
+func TestChangedServerNamesUnchanged(t *testing.T) {
+	hashes := serverConfigHashes{"filesystem": "aaa"}
+	if changed := changedServerNames(hashes, serverConfigHashes{"filesystem": "aaa"}); len(changed) != 0 {
+		t.Errorf("Expected no changes for identical hashes, got %v", changed)
+	}
+}
+
+func TestChangedServerNamesFirstSync(t *testing.T) {
+	current := serverConfigHashes{"filesystem": "aaa", "ship": "bbb"}
+	if changed := changedServerNames(nil, current); len(changed) != 2 {
+		t.Errorf("Expected all servers changed on first sync, got %v", changed)
+	}
+}
+
+func TestLoadStoredServerHashes(t *testing.T) {
+	record := &repositories.FileConfigRecord{
+		Metadata: `{"server_hashes": {"filesystem": "abc123"}, "other_key": true}`,
+	}
+
+	hashes := loadStoredServerHashes(record)
+	if hashes["filesystem"] != "abc123" {
+		t.Errorf("Expected stored hash to round-trip, got %v", hashes)
+	}
+}
+
+func TestLoadStoredServerHashesMalformed(t *testing.T) {
+	for _, metadata := range []string{"", "{}", "{not json", `{"server_hashes": "not-a-map"}`} {
+		hashes := loadStoredServerHashes(&repositories.FileConfigRecord{Metadata: metadata})
+		if len(hashes) != 0 {
+			t.Errorf("Expected empty hashes for metadata %q, got %v", metadata, hashes)
+		}
+	}
+	if hashes := loadStoredServerHashes(nil); len(hashes) != 0 {
+		t.Errorf("Expected empty hashes for nil record, got %v", hashes)
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"station/internal/db/repositories"
+)
+
+// Sync locking prevents two syncs of the same environment from interleaving
+// (e.g. `stn sync` racing serve mode's hot reload), which can corrupt tool
+// assignments. The lock is a DB advisory lock stored in the settings table
+// under sync.lock.<environment> with ownership info, so it works across
+// processes sharing the same station.db.
+const syncLockSettingPrefix = "sync.lock."
+
+// A lock older than this is treated as abandoned (crashed process on another
+// host) and taken over with a warning
+const syncLockStaleAfter = 30 * time.Minute
+
+// SyncLockInfo identifies who holds a sync lock
+type SyncLockInfo struct {
+	Environment string    `json:"environment"`
+	Hostname    string    `json:"hostname"`
+	PID         int       `json:"pid"`
+	Source      string    `json:"source"`
+	AcquiredAt  time.Time `json:"acquired_at"`
+}
+
+// SyncLockService manages per-environment advisory sync locks
+type SyncLockService struct {
+	repos *repositories.Repositories
+}
+
+// NewSyncLockService creates a sync lock service
+func NewSyncLockService(repos *repositories.Repositories) *SyncLockService {
+	return &SyncLockService{repos: repos}
+}
+
+func syncLockKey(environmentName string) string {
+	return syncLockSettingPrefix + environmentName
+}
+
+// Acquire takes the sync lock for an environment, failing fast with the
+// holder's identity when another sync is in progress. Locks left by dead
+// local processes or older than the staleness window are broken automatically.
+// The returned release function must be called when the sync finishes.
+func (s *SyncLockService) Acquire(environmentName, source string) (func(), error) {
+	hostname, _ := os.Hostname()
+	if source == "" {
+		source = "sync"
+	}
+	info := SyncLockInfo{
+		Environment: environmentName,
+		Hostname:    hostname,
+		PID:         os.Getpid(),
+		Source:      source,
+		AcquiredAt:  time.Now().UTC(),
+	}
+	value, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize sync lock: %w", err)
+	}
+
+	key := syncLockKey(environmentName)
+	for attempt := 0; attempt < 2; attempt++ {
+		acquired, err := s.repos.Settings.TryAcquire(key, string(value), "Advisory sync lock")
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire sync lock: %w", err)
+		}
+		if acquired {
+			return func() { _ = s.repos.Settings.Delete(key) }, nil
+		}
+
+		holder, err := s.Holder(environmentName)
+		if err != nil || holder == nil {
+			// Holder released between our insert and read; retry once
+			continue
+		}
+		if s.isStale(holder) {
+			fmt.Printf("⚠️  Breaking stale sync lock for environment '%s' (held by %s pid %d since %s)\n",
+				environmentName, holder.Hostname, holder.PID, holder.AcquiredAt.Format(time.RFC3339))
+			_ = s.repos.Settings.Delete(key)
+			continue
+		}
+
+		return nil, fmt.Errorf("environment '%s' is already being synced by %s (pid %d on %s, started %s); wait for it to finish or run 'stn sync %s --force-unlock' if the lock is stale",
+			environmentName, holder.Source, holder.PID, holder.Hostname,
+			holder.AcquiredAt.Format(time.RFC3339), environmentName)
+	}
+
+	return nil, fmt.Errorf("failed to acquire sync lock for environment '%s' after retry", environmentName)
+}
+
+// Holder returns the current lock holder, or nil when unlocked
+func (s *SyncLockService) Holder(environmentName string) (*SyncLockInfo, error) {
+	setting, err := s.repos.Settings.GetByKey(syncLockKey(environmentName))
+	if err != nil {
+		return nil, nil
+	}
+	var info SyncLockInfo
+	if err := json.Unmarshal([]byte(setting.Value), &info); err != nil {
+		return nil, fmt.Errorf("failed to parse sync lock: %w", err)
+	}
+	return &info, nil
+}
+
+// ForceUnlock removes the lock regardless of holder, returning the evicted
+// holder when one existed. For operators clearing stale locks via
+// `stn sync --force-unlock`.
+func (s *SyncLockService) ForceUnlock(environmentName string) (*SyncLockInfo, error) {
+	holder, err := s.Holder(environmentName)
+	if err != nil {
+		return nil, err
+	}
+	if holder == nil {
+		return nil, nil
+	}
+	if err := s.repos.Settings.Delete(syncLockKey(environmentName)); err != nil {
+		return nil, fmt.Errorf("failed to remove sync lock: %w", err)
+	}
+	return holder, nil
+}
+
+// isStale reports whether a lock belongs to a process that no longer exists
+// (same host) or has exceeded the staleness window
+func (s *SyncLockService) isStale(holder *SyncLockInfo) bool {
+	if time.Since(holder.AcquiredAt) > syncLockStaleAfter {
+		return true
+	}
+	hostname, _ := os.Hostname()
+	if holder.Hostname == hostname && holder.PID > 0 && holder.PID != os.Getpid() {
+		process, err := os.FindProcess(holder.PID)
+		if err != nil {
+			return true
+		}
+		// Signal 0 probes liveness; ErrProcessDone means the holder crashed
+		// without releasing (platforms without signal 0 keep the lock)
+		if err := process.Signal(syscall.Signal(0)); errors.Is(err, os.ErrProcessDone) || errors.Is(err, syscall.ESRCH) {
+			return true
+		}
+	}
+	return false
+}
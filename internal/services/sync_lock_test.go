@@ -0,0 +1,154 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestSyncLockAcquireAndRelease(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	svc := NewSyncLockService(repos)
+
+	release, err := svc.Acquire("default", "cli")
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	holder, err := svc.Holder("default")
+	if err != nil {
+		t.Fatalf("Holder failed: %v", err)
+	}
+	if holder == nil || holder.PID != os.Getpid() || holder.Source != "cli" {
+		t.Errorf("unexpected holder: %+v", holder)
+	}
+
+	// A lock on another environment is independent
+	otherRelease, err := svc.Acquire("staging", "serve")
+	if err != nil {
+		t.Fatalf("Acquire for other environment failed: %v", err)
+	}
+	otherRelease()
+
+	release()
+	if holder, _ := svc.Holder("default"); holder != nil {
+		t.Errorf("expected lock released, still held by %+v", holder)
+	}
+
+	// Released lock can be reacquired
+	release, err = svc.Acquire("default", "serve")
+	if err != nil {
+		t.Fatalf("reacquire failed: %v", err)
+	}
+	release()
+}
+
+func TestSyncLockContention(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	// Simulate a live lock held by another host so liveness probing and the
+	// staleness window both keep it
+	hostname, _ := os.Hostname()
+	info := SyncLockInfo{
+		Environment: "default",
+		Hostname:    hostname + "-other",
+		PID:         1,
+		Source:      "serve",
+		AcquiredAt:  time.Now().UTC(),
+	}
+	value, _ := json.Marshal(info)
+	if err := repos.Settings.Set(syncLockKey("default"), string(value), "Advisory sync lock"); err != nil {
+		t.Fatalf("failed to seed lock: %v", err)
+	}
+
+	svc := NewSyncLockService(repos)
+	_, err = svc.Acquire("default", "cli")
+	if err == nil {
+		t.Fatal("expected contention error")
+	}
+	if !strings.Contains(err.Error(), "already being synced") || !strings.Contains(err.Error(), "--force-unlock") {
+		t.Errorf("expected holder info and force-unlock hint, got: %v", err)
+	}
+
+	holder, err := svc.ForceUnlock("default")
+	if err != nil {
+		t.Fatalf("ForceUnlock failed: %v", err)
+	}
+	if holder == nil || holder.Source != "serve" {
+		t.Errorf("expected evicted serve holder, got %+v", holder)
+	}
+
+	release, err := svc.Acquire("default", "cli")
+	if err != nil {
+		t.Fatalf("acquire after force-unlock failed: %v", err)
+	}
+	release()
+}
+
+func TestSyncLockBreaksStaleLocks(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	hostname, _ := os.Hostname()
+
+	// Lock older than the staleness window is taken over
+	stale := SyncLockInfo{
+		Environment: "default",
+		Hostname:    hostname + "-other",
+		PID:         1,
+		Source:      "cli",
+		AcquiredAt:  time.Now().UTC().Add(-syncLockStaleAfter - time.Minute),
+	}
+	value, _ := json.Marshal(stale)
+	if err := repos.Settings.Set(syncLockKey("default"), string(value), "Advisory sync lock"); err != nil {
+		t.Fatalf("failed to seed stale lock: %v", err)
+	}
+
+	svc := NewSyncLockService(repos)
+	release, err := svc.Acquire("default", "cli")
+	if err != nil {
+		t.Fatalf("expected stale lock takeover, got: %v", err)
+	}
+	release()
+
+	// Lock held by a dead process on this host is also taken over. PID
+	// 4194305 exceeds the Linux pid_max ceiling so it cannot be live.
+	dead := SyncLockInfo{
+		Environment: "default",
+		Hostname:    hostname,
+		PID:         4194305,
+		Source:      "serve",
+		AcquiredAt:  time.Now().UTC(),
+	}
+	value, _ = json.Marshal(dead)
+	if err := repos.Settings.Set(syncLockKey("default"), string(value), "Advisory sync lock"); err != nil {
+		t.Fatalf("failed to seed dead-process lock: %v", err)
+	}
+
+	release, err = svc.Acquire("default", "cli")
+	if err != nil {
+		t.Fatalf("expected dead-process lock takeover, got: %v", err)
+	}
+	release()
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"station/internal/config"
+)
+
+// spanCategory classifies spans for per-category sampling rates
+type spanCategory string
+
+const (
+	spanCategoryAgent spanCategory = "agent"
+	spanCategoryTool  spanCategory = "tool"
+	spanCategoryAPI   spanCategory = "api"
+	spanCategoryOther spanCategory = "other"
+)
+
+// categorizeSpan maps a span name onto its sampling category. Agent
+// execution spans (agent.*, dotprompt.*), MCP tool spans (mcp.*, tool.*),
+// and API request spans (http.*, api.*) each get their own rate; everything
+// else falls through to the default sampler.
+func categorizeSpan(spanName string) spanCategory {
+	name := strings.ToLower(spanName)
+	switch {
+	case strings.HasPrefix(name, "agent") || strings.HasPrefix(name, "dotprompt"):
+		return spanCategoryAgent
+	case strings.HasPrefix(name, "mcp") || strings.HasPrefix(name, "tool"):
+		return spanCategoryTool
+	case strings.HasPrefix(name, "http") || strings.HasPrefix(name, "api"):
+		return spanCategoryAPI
+	default:
+		return spanCategoryOther
+	}
+}
+
+// effectiveSamplingRates resolves the per-category rates for one deployment
+// environment: environment override first, then the top-level category rate.
+// Categories without a rate are absent from the result and use the default
+// sampler.
+func effectiveSamplingRates(sampling *config.TelemetrySamplingConfig, environment string) map[spanCategory]float64 {
+	rates := make(map[spanCategory]float64)
+	if sampling == nil {
+		return rates
+	}
+
+	apply := func(r config.TelemetrySamplingRates) {
+		if r.AgentRate != nil {
+			rates[spanCategoryAgent] = clampRate(*r.AgentRate)
+		}
+		if r.ToolRate != nil {
+			rates[spanCategoryTool] = clampRate(*r.ToolRate)
+		}
+		if r.APIRate != nil {
+			rates[spanCategoryAPI] = clampRate(*r.APIRate)
+		}
+	}
+
+	apply(sampling.TelemetrySamplingRates)
+	if override, ok := sampling.EnvironmentOverrides[environment]; ok {
+		apply(override)
+	}
+	return rates
+}
+
+func clampRate(rate float64) float64 {
+	if rate < 0 {
+		return 0
+	}
+	if rate > 1 {
+		return 1
+	}
+	return rate
+}
+
+// categorySampler applies head-based ratio sampling per span category. When
+// alwaysSampleErrors is set, unsampled spans are still recorded (not
+// exported) so the error processor can export the ones that fail.
+type categorySampler struct {
+	defaultSampler     sdktrace.Sampler
+	categorySamplers   map[spanCategory]sdktrace.Sampler
+	alwaysSampleErrors bool
+}
+
+// newCategorySampler builds a sampler from resolved per-category rates,
+// falling back to defaultSampler for categories without a rate
+func newCategorySampler(defaultSampler sdktrace.Sampler, rates map[spanCategory]float64, alwaysSampleErrors bool) *categorySampler {
+	categorySamplers := make(map[spanCategory]sdktrace.Sampler, len(rates))
+	for category, rate := range rates {
+		categorySamplers[category] = sdktrace.TraceIDRatioBased(rate)
+	}
+	return &categorySampler{
+		defaultSampler:     defaultSampler,
+		categorySamplers:   categorySamplers,
+		alwaysSampleErrors: alwaysSampleErrors,
+	}
+}
+
+func (s *categorySampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	sampler := s.defaultSampler
+	if categorySampler, ok := s.categorySamplers[categorizeSpan(p.Name)]; ok {
+		sampler = categorySampler
+	}
+
+	result := sampler.ShouldSample(p)
+	if result.Decision == sdktrace.Drop && s.alwaysSampleErrors {
+		// Keep recording so the error processor can export the span if it
+		// ends with an error status; non-error spans are still not exported
+		result.Decision = sdktrace.RecordOnly
+	}
+	return result
+}
+
+func (s *categorySampler) Description() string {
+	return fmt.Sprintf("CategorySampler{categories=%d,alwaysSampleErrors=%t}", len(s.categorySamplers), s.alwaysSampleErrors)
+}
+
+// errorSpanProcessor exports spans that ended with an error status but lost
+// the head-based sampling decision, so failures always reach the backend
+type errorSpanProcessor struct {
+	exporter sdktrace.SpanExporter
+}
+
+func newErrorSpanProcessor(exporter sdktrace.SpanExporter) *errorSpanProcessor {
+	return &errorSpanProcessor{exporter: exporter}
+}
+
+func (p *errorSpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {}
+
+func (p *errorSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	// Sampled spans are exported by the batch processor; only rescue the
+	// record-only error spans the sampler would otherwise discard
+	if s.SpanContext().IsSampled() || s.Status().Code != codes.Error {
+		return
+	}
+	_ = p.exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{s})
+}
+
+func (p *errorSpanProcessor) Shutdown(ctx context.Context) error {
+	// The batch processor owns the exporter's lifecycle
+	return nil
+}
+
+func (p *errorSpanProcessor) ForceFlush(ctx context.Context) error {
+	return nil
+}
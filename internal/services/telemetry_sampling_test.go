@@ -0,0 +1,130 @@
+package services
+
+import (
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"station/internal/config"
+)
+
+// TestCategorizeSpan tests span category classification by name prefix
+func TestCategorizeSpan(t *testing.T) {
+	cases := []struct {
+		spanName string
+		want     spanCategory
+	}{
+		{"agent_execution_engine.execute", spanCategoryAgent},
+		{"agent.execute_with_run_id", spanCategoryAgent},
+		{"dotprompt.execute", spanCategoryAgent},
+		{"mcp.load_tools", spanCategoryTool},
+		{"tool.call", spanCategoryTool},
+		{"http.request", spanCategoryAPI},
+		{"api.v1.agents", spanCategoryAPI},
+		{"database.query", spanCategoryOther},
+	}
+	for _, tc := range cases {
+		if got := categorizeSpan(tc.spanName); got != tc.want {
+			t.Errorf("categorizeSpan(%q) = %q, want %q", tc.spanName, got, tc.want)
+		}
+	}
+}
+
+// TestEffectiveSamplingRates tests override precedence and clamping
+func TestEffectiveSamplingRates(t *testing.T) {
+	if rates := effectiveSamplingRates(nil, "production"); len(rates) != 0 {
+		t.Errorf("nil config should yield no rates, got %v", rates)
+	}
+
+	sampling := &config.TelemetrySamplingConfig{
+		TelemetrySamplingRates: config.TelemetrySamplingRates{
+			AgentRate: floatPtr(0.5),
+			ToolRate:  floatPtr(2.0), // clamped to 1
+		},
+		EnvironmentOverrides: map[string]config.TelemetrySamplingRates{
+			"production": {
+				AgentRate: floatPtr(0.1),
+				APIRate:   floatPtr(-0.5), // clamped to 0
+			},
+		},
+	}
+
+	rates := effectiveSamplingRates(sampling, "development")
+	if rates[spanCategoryAgent] != 0.5 {
+		t.Errorf("development agent rate = %v, want 0.5", rates[spanCategoryAgent])
+	}
+	if rates[spanCategoryTool] != 1 {
+		t.Errorf("tool rate should clamp to 1, got %v", rates[spanCategoryTool])
+	}
+	if _, ok := rates[spanCategoryAPI]; ok {
+		t.Error("api rate should be unset for development")
+	}
+
+	rates = effectiveSamplingRates(sampling, "production")
+	if rates[spanCategoryAgent] != 0.1 {
+		t.Errorf("production override should win, got %v", rates[spanCategoryAgent])
+	}
+	if rates[spanCategoryTool] != 1 {
+		t.Errorf("production should inherit the top-level tool rate, got %v", rates[spanCategoryTool])
+	}
+	if rates[spanCategoryAPI] != 0 {
+		t.Errorf("api rate should clamp to 0, got %v", rates[spanCategoryAPI])
+	}
+}
+
+// TestCategorySampler tests per-category decisions and the error fallback
+func TestCategorySampler(t *testing.T) {
+	traceID := trace.TraceID{0x01}
+	params := func(name string) sdktrace.SamplingParameters {
+		return sdktrace.SamplingParameters{Name: name, TraceID: traceID}
+	}
+
+	sampler := newCategorySampler(sdktrace.AlwaysSample(), map[spanCategory]float64{
+		spanCategoryTool: 0,
+	}, false)
+
+	if result := sampler.ShouldSample(params("mcp.load_tools")); result.Decision != sdktrace.Drop {
+		t.Errorf("tool span at rate 0 should drop, got %v", result.Decision)
+	}
+	if result := sampler.ShouldSample(params("agent.execute")); result.Decision != sdktrace.RecordAndSample {
+		t.Errorf("uncategorized-rate span should use the default sampler, got %v", result.Decision)
+	}
+
+	// With always-sample-on-error, dropped spans stay recording so the error
+	// processor can export the ones that fail
+	errorSampler := newCategorySampler(sdktrace.AlwaysSample(), map[spanCategory]float64{
+		spanCategoryTool: 0,
+	}, true)
+	if result := errorSampler.ShouldSample(params("mcp.load_tools")); result.Decision != sdktrace.RecordOnly {
+		t.Errorf("dropped span with alwaysSampleErrors should be RecordOnly, got %v", result.Decision)
+	}
+	if result := errorSampler.ShouldSample(params("agent.execute")); result.Decision != sdktrace.RecordAndSample {
+		t.Errorf("sampled span should stay RecordAndSample, got %v", result.Decision)
+	}
+}
+
+// TestGetSamplerWithSamplingConfig tests that the service wires the
+// fine-grained sampler when configured
+func TestGetSamplerWithSamplingConfig(t *testing.T) {
+	service := NewTelemetryService(&TelemetryConfig{
+		Enabled:     true,
+		Environment: "production",
+		SampleRate:  1.0,
+		Sampling: &config.TelemetrySamplingConfig{
+			TelemetrySamplingRates: config.TelemetrySamplingRates{ToolRate: floatPtr(0.05)},
+			AlwaysSampleErrors:     true,
+		},
+	})
+
+	sampler := service.getSampler()
+	if _, ok := sampler.(*categorySampler); !ok {
+		t.Fatalf("expected categorySampler, got %T", sampler)
+	}
+
+	// Without sampling config the base sampler is used unchanged
+	service = NewTelemetryService(&TelemetryConfig{Enabled: true, SampleRate: 0.5})
+	if _, ok := service.getSampler().(*categorySampler); ok {
+		t.Error("category sampler should not be used without sampling config")
+	}
+}
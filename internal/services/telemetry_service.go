@@ -58,6 +58,9 @@ type TelemetryConfig struct {
 	Environment string
 	SampleRate  float64
 
+	// Sampling holds fine-grained per-category sampling controls
+	Sampling *config.TelemetrySamplingConfig
+
 	// CloudShip telemetry authentication (used when Provider = "cloudship")
 	CloudShipAPIKey string // Registration key for CloudShip telemetry endpoint
 
@@ -97,6 +100,7 @@ func NewTelemetryConfigFromConfig(cfg *config.TelemetryConfig, cloudShipInfo *Cl
 		ServiceName: cfg.ServiceName,
 		Environment: cfg.Environment,
 		SampleRate:  cfg.SampleRate,
+		Sampling:    cfg.Sampling,
 	}
 
 	// Set defaults
@@ -350,7 +354,7 @@ func (ts *TelemetryService) initTraceProvider(ctx context.Context, res *resource
 	ts.cloudShipProcessor = newCloudShipAttributeProcessor(ts.config)
 
 	// Create trace provider with resource and exporter - optimized for immediate export
-	tp := sdktrace.NewTracerProvider(
+	providerOpts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
 		sdktrace.WithBatcher(exporter,
 			sdktrace.WithBatchTimeout(time.Second*1), // Reduced timeout for faster export
@@ -360,7 +364,14 @@ func (ts *TelemetryService) initTraceProvider(ctx context.Context, res *resource
 		sdktrace.WithSampler(ts.getSampler()),
 		// Add CloudShip processor to inject org_id/station_name into ALL spans
 		sdktrace.WithSpanProcessor(ts.cloudShipProcessor),
-	)
+	}
+
+	// Export error spans even when the head-based decision dropped them
+	if ts.config.Sampling != nil && ts.config.Sampling.AlwaysSampleErrors {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(newErrorSpanProcessor(exporter)))
+	}
+
+	tp := sdktrace.NewTracerProvider(providerOpts...)
 
 	// Store shutdown function
 	ts.shutdownFunc = tp.Shutdown
@@ -422,6 +433,19 @@ func (ts *TelemetryService) initMetrics() error {
 
 // getSampler returns the appropriate sampling strategy
 func (ts *TelemetryService) getSampler() sdktrace.Sampler {
+	// Fine-grained sampling: per-category rates with per-environment
+	// overrides, wrapped around the base sampler for uncategorized spans
+	if ts.config != nil && ts.config.Sampling != nil {
+		rates := effectiveSamplingRates(ts.config.Sampling, ts.config.Environment)
+		return newCategorySampler(ts.getBaseSampler(), rates, ts.config.Sampling.AlwaysSampleErrors)
+	}
+
+	return ts.getBaseSampler()
+}
+
+// getBaseSampler returns the uniform sampling strategy from SampleRate or
+// environment defaults
+func (ts *TelemetryService) getBaseSampler() sdktrace.Sampler {
 	// Use configured sample rate if set
 	if ts.config != nil && ts.config.SampleRate > 0 && ts.config.SampleRate < 1.0 {
 		return sdktrace.TraceIDRatioBased(ts.config.SampleRate)
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/yaml.v3"
 	"station/internal/config"
 	"station/internal/db/repositories"
+	"station/pkg/templates"
 )
 
 // VariableResolver is a function type for resolving missing variables
@@ -26,11 +27,14 @@ type TemplateVariableService struct {
 
 // VariableInfo represents a detected variable in a template
 type VariableInfo struct {
-	Name        string `json:"name"`
-	Required    bool   `json:"required"`
-	Description string `json:"description"`
-	Default     string `json:"default"`
-	Secret      bool   `json:"secret"`
+	Name        string   `json:"name"`
+	Required    bool     `json:"required"`
+	Description string   `json:"description"`
+	Default     string   `json:"default"`
+	Secret      bool     `json:"secret"`
+	Type        string   `json:"type,omitempty"` // string, int, bool, enum, secret, url
+	Enum        []string `json:"enum,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
 }
 
 // VariableResolutionResult contains the result of variable resolution
@@ -161,6 +165,13 @@ func (tvs *TemplateVariableService) ProcessTemplateWithVariables(envID int64, co
 					delete(existingVars, v.Name)
 				}
 
+				// Enrich prompts with bundle manifest declarations (type,
+				// description, default, enum) when the environment has one
+				requirements := tvs.loadManifestRequirements(envName)
+				for i := range allMissingVars {
+					tvs.applyRequirement(&allMissingVars[i], requirementFor(requirements, allMissingVars[i].Name))
+				}
+
 				// Prompt for ALL missing variables at once
 				var newVars map[string]string
 				if tvs.variableResolver != nil {
@@ -172,6 +183,20 @@ func (tvs *TemplateVariableService) ProcessTemplateWithVariables(envID int64, co
 					return nil, fmt.Errorf("failed to collect missing variables: %w", err)
 				}
 
+				// Validate collected values against declared types before
+				// accepting them (custom resolvers bypass the CLI prompt loop)
+				for _, variable := range allMissingVars {
+					req := requirementFor(requirements, variable.Name)
+					if req == nil {
+						continue
+					}
+					if value, ok := newVars[variable.Name]; ok {
+						if validateErr := validateVariableValue(*req, value); validateErr != nil {
+							return nil, fmt.Errorf("variable validation failed: %w", validateErr)
+						}
+					}
+				}
+
 				// Merge new variables
 				for k, v := range newVars {
 					existingVars[k] = v
@@ -231,18 +256,20 @@ func (tvs *TemplateVariableService) ProcessTemplateWithVariables(envID int64, co
 
 // renderTemplate applies variables to template content using Go's text/template library
 func (tvs *TemplateVariableService) renderTemplate(templateContent string, variables map[string]string) (string, error) {
-	// Create a new template with the content and configure it to error on missing keys
-	tmpl, err := template.New("mcp-config").Option("missingkey=error").Parse(templateContent)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
-	}
-
 	// Convert variables to interface{} map for template execution
 	templateData := make(map[string]interface{})
 	for key, value := range variables {
 		templateData[key] = value
 	}
 
+	// Create a new template with the content and configure it to error on missing keys.
+	// The shared helper functions (default, required, env, lookup, ...) let bundles
+	// express optional variables without tripping missingkey=error.
+	tmpl, err := template.New("mcp-config").Funcs(templates.FuncMap(templateData)).Option("missingkey=error").Parse(templateContent)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
 	// Execute the template with the variables
 	var rendered bytes.Buffer
 	if err := tmpl.Execute(&rendered, templateData); err != nil {
@@ -334,9 +361,13 @@ func (tvs *TemplateVariableService) promptForMissingVariables(missingVars []Vari
 	fmt.Printf("The following variables need to be configured:\n\n")
 
 	for _, variable := range missingVars {
+		typeLabel := variablePromptLabel(&VariableRequirement{Type: variable.Type, Enum: variable.Enum})
+
 		var prompt string
 		if variable.Secret {
 			prompt = fmt.Sprintf("🔐 %s (secret): ", variable.Name)
+		} else if typeLabel != "" {
+			prompt = fmt.Sprintf("📝 %s %s: ", variable.Name, typeLabel)
 		} else {
 			prompt = fmt.Sprintf("📝 %s: ", variable.Name)
 		}
@@ -344,24 +375,46 @@ func (tvs *TemplateVariableService) promptForMissingVariables(missingVars []Vari
 		if variable.Description != "" {
 			fmt.Printf("   Description: %s\n", variable.Description)
 		}
-
-		fmt.Print(prompt)
-
-		var value string
-		if _, err := fmt.Scanln(&value); err != nil {
-			return nil, fmt.Errorf("failed to read variable %s: %w", variable.Name, err)
+		if variable.Default != "" {
+			fmt.Printf("   Default: %s\n", variable.Default)
 		}
 
-		if value == "" && variable.Default != "" {
-			value = variable.Default
+		req := VariableRequirement{
+			Name:    variable.Name,
+			Type:    variable.Type,
+			Enum:    variable.Enum,
+			Pattern: variable.Pattern,
 		}
 
-		if value == "" && variable.Required {
-			return nil, fmt.Errorf("variable %s is required but no value provided", variable.Name)
-		}
+		const maxAttempts = 3
+		for attempt := 1; ; attempt++ {
+			fmt.Print(prompt)
+
+			var value string
+			if _, err := fmt.Scanln(&value); err != nil {
+				return nil, fmt.Errorf("failed to read variable %s: %w", variable.Name, err)
+			}
+
+			if value == "" && variable.Default != "" {
+				value = variable.Default
+			}
 
-		result[variable.Name] = value
-		fmt.Printf("   ✅ Set %s\n\n", variable.Name)
+			if value == "" && variable.Required {
+				return nil, fmt.Errorf("variable %s is required but no value provided", variable.Name)
+			}
+
+			if err := validateVariableValue(req, value); err != nil {
+				if attempt >= maxAttempts {
+					return nil, err
+				}
+				fmt.Printf("   ❌ %v\n", err)
+				continue
+			}
+
+			result[variable.Name] = value
+			fmt.Printf("   ✅ Set %s\n\n", variable.Name)
+			break
+		}
 	}
 
 	return result, nil
@@ -369,6 +422,45 @@ func (tvs *TemplateVariableService) promptForMissingVariables(missingVars []Vari
 
 // Helper methods
 
+// loadManifestRequirements loads the bundle manifest's variable declarations
+// for an environment; environments without a manifest have none
+func (tvs *TemplateVariableService) loadManifestRequirements(envName string) []VariableRequirement {
+	var envDir string
+	if tvs.configDir != "" {
+		envDir = filepath.Join(tvs.configDir, "environments", envName)
+	} else {
+		envDir = config.GetEnvironmentDir(envName)
+	}
+
+	requirements, err := loadVariableRequirements(envDir)
+	if err != nil {
+		log.Printf("Warning: failed to load variable declarations for %s: %v", envName, err)
+		return nil
+	}
+	return requirements
+}
+
+// applyRequirement copies a manifest declaration onto a detected variable so
+// interactive prompts show type, description, and default
+func (tvs *TemplateVariableService) applyRequirement(info *VariableInfo, req *VariableRequirement) {
+	if req == nil {
+		return
+	}
+	info.Type = req.Type
+	info.Enum = req.Enum
+	info.Pattern = req.Pattern
+	info.Required = req.Required
+	if req.Description != "" {
+		info.Description = req.Description
+	}
+	if req.Default != nil {
+		info.Default = fmt.Sprintf("%v", req.Default)
+	}
+	if strings.EqualFold(req.Type, "secret") {
+		info.Secret = true
+	}
+}
+
 func (tvs *TemplateVariableService) isSecretVariable(name string) bool {
 	secretKeywords := []string{"TOKEN", "KEY", "SECRET", "PASSWORD", "CREDENTIAL", "AUTH"}
 	upperName := strings.ToUpper(name)
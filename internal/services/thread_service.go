@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"station/internal/db/repositories"
+)
+
+// Threads give MCP clients (e.g. Claude Desktop) multi-turn conversations
+// with an agent: each message executes as a normal agent run linked to the
+// previous one via parent_run_id, and the prior turns are replayed as task
+// context so the agent keeps the conversation state. Thread metadata is
+// stored in the settings table under thread.<id>.
+const threadSettingPrefix = "thread."
+
+// How many prior turns are replayed into the next message's task; older
+// turns are dropped so long threads do not blow up the context
+const threadContextMaxTurns = 10
+
+// Thread statuses
+const (
+	ThreadStatusOpen   = "open"
+	ThreadStatusClosed = "closed"
+)
+
+// Thread is a persistent conversation bound to one agent, with its messages
+// stored as linked agent runs
+type Thread struct {
+	ID        string  `json:"id"`
+	AgentID   int64   `json:"agent_id"`
+	AgentName string  `json:"agent_name"`
+	Status    string  `json:"status"`
+	RunIDs    []int64 `json:"run_ids"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// ThreadService manages conversation threads over agent runs
+type ThreadService struct {
+	repos *repositories.Repositories
+}
+
+// NewThreadService creates a thread service
+func NewThreadService(repos *repositories.Repositories) *ThreadService {
+	return &ThreadService{repos: repos}
+}
+
+func threadKey(threadID string) string {
+	return threadSettingPrefix + threadID
+}
+
+// CreateThread opens a new thread bound to an agent
+func (s *ThreadService) CreateThread(agentID int64) (*Thread, error) {
+	agent, err := s.repos.Agents.GetByID(agentID)
+	if err != nil {
+		return nil, fmt.Errorf("agent with ID %d not found: %w", agentID, err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	thread := &Thread{
+		ID:        uuid.New().String(),
+		AgentID:   agent.ID,
+		AgentName: agent.Name,
+		Status:    ThreadStatusOpen,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.saveThread(thread); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+// GetThread returns a thread by ID
+func (s *ThreadService) GetThread(threadID string) (*Thread, error) {
+	setting, err := s.repos.Settings.GetByKey(threadKey(threadID))
+	if err != nil {
+		return nil, fmt.Errorf("thread %s not found", threadID)
+	}
+
+	var thread Thread
+	if err := json.Unmarshal([]byte(setting.Value), &thread); err != nil {
+		return nil, fmt.Errorf("failed to parse thread %s: %w", threadID, err)
+	}
+	return &thread, nil
+}
+
+// ListThreads returns all threads, newest first
+func (s *ThreadService) ListThreads() ([]*Thread, error) {
+	settings, err := s.repos.Settings.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var threads []*Thread
+	for _, setting := range settings {
+		if !strings.HasPrefix(setting.Key, threadSettingPrefix) {
+			continue
+		}
+		var thread Thread
+		if err := json.Unmarshal([]byte(setting.Value), &thread); err != nil {
+			continue
+		}
+		threads = append(threads, &thread)
+	}
+
+	// Newest activity first
+	for i := 0; i < len(threads); i++ {
+		for j := i + 1; j < len(threads); j++ {
+			if threads[j].UpdatedAt > threads[i].UpdatedAt {
+				threads[i], threads[j] = threads[j], threads[i]
+			}
+		}
+	}
+	return threads, nil
+}
+
+// CloseThread marks a thread closed; its runs stay in history but new
+// messages are rejected
+func (s *ThreadService) CloseThread(threadID string) (*Thread, error) {
+	thread, err := s.GetThread(threadID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.Status == ThreadStatusClosed {
+		return thread, nil
+	}
+	thread.Status = ThreadStatusClosed
+	thread.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := s.saveThread(thread); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+// AppendRun links a completed message run to the thread
+func (s *ThreadService) AppendRun(threadID string, runID int64) (*Thread, error) {
+	thread, err := s.GetThread(threadID)
+	if err != nil {
+		return nil, err
+	}
+	thread.RunIDs = append(thread.RunIDs, runID)
+	thread.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := s.saveThread(thread); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+// LastRunID returns the ID of the thread's most recent run, or nil for a
+// fresh thread; used to link the next message run via parent_run_id
+func (t *Thread) LastRunID() *int64 {
+	if len(t.RunIDs) == 0 {
+		return nil
+	}
+	last := t.RunIDs[len(t.RunIDs)-1]
+	return &last
+}
+
+// BuildThreadTask composes the task for a new message by replaying the
+// thread's prior turns (capped to the most recent ones) ahead of it
+func (s *ThreadService) BuildThreadTask(ctx context.Context, thread *Thread, message string) string {
+	if thread.Status == ThreadStatusClosed || len(thread.RunIDs) == 0 {
+		return message
+	}
+
+	runIDs := thread.RunIDs
+	if len(runIDs) > threadContextMaxTurns {
+		runIDs = runIDs[len(runIDs)-threadContextMaxTurns:]
+	}
+
+	var b strings.Builder
+	b.WriteString("This is an ongoing conversation thread. Previous turns:\n\n")
+	for _, runID := range runIDs {
+		run, err := s.repos.AgentRuns.GetByID(ctx, runID)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "User: %s\n", run.Task)
+		if run.FinalResponse != "" {
+			fmt.Fprintf(&b, "You: %s\n", run.FinalResponse)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("Continue the conversation with the next message:\n\n")
+	b.WriteString(message)
+	return b.String()
+}
+
+func (s *ThreadService) saveThread(thread *Thread) error {
+	data, err := json.Marshal(thread)
+	if err != nil {
+		return fmt.Errorf("failed to serialize thread: %w", err)
+	}
+	return s.repos.Settings.Set(threadKey(thread.ID), string(data), "MCP conversation thread")
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestThreadLifecycle(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("thread-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("thread-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Thread Agent", "threads", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	svc := NewThreadService(repos)
+
+	if _, err := svc.CreateThread(99999); err == nil {
+		t.Error("expected error creating thread for missing agent")
+	}
+
+	thread, err := svc.CreateThread(agent.ID)
+	if err != nil {
+		t.Fatalf("CreateThread failed: %v", err)
+	}
+	if thread.Status != ThreadStatusOpen {
+		t.Errorf("expected open thread, got %s", thread.Status)
+	}
+	if thread.AgentName != agent.Name {
+		t.Errorf("expected agent name %s, got %s", agent.Name, thread.AgentName)
+	}
+	if thread.LastRunID() != nil {
+		t.Error("expected no last run on fresh thread")
+	}
+
+	ctx := context.Background()
+	completedAt := time.Now()
+	run, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "What is Station?", "Station is an agent platform.", 1, nil, nil, "completed", &completedAt)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+
+	thread, err = svc.AppendRun(thread.ID, run.ID)
+	if err != nil {
+		t.Fatalf("AppendRun failed: %v", err)
+	}
+	if last := thread.LastRunID(); last == nil || *last != run.ID {
+		t.Errorf("expected last run %d, got %v", run.ID, last)
+	}
+
+	fetched, err := svc.GetThread(thread.ID)
+	if err != nil {
+		t.Fatalf("GetThread failed: %v", err)
+	}
+	if len(fetched.RunIDs) != 1 || fetched.RunIDs[0] != run.ID {
+		t.Errorf("expected run IDs [%d], got %v", run.ID, fetched.RunIDs)
+	}
+
+	threads, err := svc.ListThreads()
+	if err != nil {
+		t.Fatalf("ListThreads failed: %v", err)
+	}
+	if len(threads) != 1 || threads[0].ID != thread.ID {
+		t.Errorf("expected one thread %s, got %v", thread.ID, threads)
+	}
+
+	closed, err := svc.CloseThread(thread.ID)
+	if err != nil {
+		t.Fatalf("CloseThread failed: %v", err)
+	}
+	if closed.Status != ThreadStatusClosed {
+		t.Errorf("expected closed thread, got %s", closed.Status)
+	}
+}
+
+func TestBuildThreadTask(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("thread-task-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("thread-task-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Thread Task Agent", "threads", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	svc := NewThreadService(repos)
+	thread, err := svc.CreateThread(agent.ID)
+	if err != nil {
+		t.Fatalf("CreateThread failed: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Fresh thread: the message passes through untouched
+	if task := svc.BuildThreadTask(ctx, thread, "hello"); task != "hello" {
+		t.Errorf("expected raw message for fresh thread, got %q", task)
+	}
+
+	completedAt := time.Now()
+	run, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "List the files", "Found main.go and README.md", 1, nil, nil, "completed", &completedAt)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+	thread, err = svc.AppendRun(thread.ID, run.ID)
+	if err != nil {
+		t.Fatalf("AppendRun failed: %v", err)
+	}
+
+	task := svc.BuildThreadTask(ctx, thread, "Now read the README")
+	if !strings.Contains(task, "List the files") {
+		t.Errorf("expected prior user turn in task, got %q", task)
+	}
+	if !strings.Contains(task, "Found main.go and README.md") {
+		t.Errorf("expected prior agent response in task, got %q", task)
+	}
+	if !strings.Contains(task, "Now read the README") {
+		t.Errorf("expected new message in task, got %q", task)
+	}
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"station/internal/db/queries"
+	"station/internal/db/repositories"
+)
+
+const (
+	// ToolAnnotationSourceManual marks annotations set explicitly via
+	// `stn mcp annotate`
+	ToolAnnotationSourceManual = "manual"
+	// ToolAnnotationSourceLearned marks annotations derived from the
+	// latency of past tool calls in run history
+	ToolAnnotationSourceLearned = "learned"
+
+	// toolAnnotationLearnWindow bounds how many recent runs per agent are
+	// sampled when learning latencies
+	toolAnnotationLearnWindow = 50
+)
+
+// ToolAnnotation holds expected cost and latency metadata for an MCP tool.
+// Annotations are surfaced to the model at run time so it can plan around
+// expensive or slow tools, and are used to aggregate per-tool spend in
+// reports.
+type ToolAnnotation struct {
+	ExpectedCostUSD   *float64 `json:"expected_cost_usd,omitempty"`
+	ExpectedLatencyMS *int64   `json:"expected_latency_ms,omitempty"`
+	Source            string   `json:"source,omitempty"`
+	Samples           int      `json:"samples,omitempty"`
+}
+
+// ToolSpend is the per-tool aggregation reported alongside agent
+// evaluations: how often a tool was called and the estimated spend based on
+// its annotated cost per call
+type ToolSpend struct {
+	Calls            int64   `json:"calls"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+}
+
+// ToolAnnotationService manages cost/latency annotations on MCP tools,
+// scoped to an environment
+type ToolAnnotationService struct {
+	repos *repositories.Repositories
+}
+
+// NewToolAnnotationService creates a new tool annotation service
+func NewToolAnnotationService(repos *repositories.Repositories) *ToolAnnotationService {
+	return &ToolAnnotationService{repos: repos}
+}
+
+// Annotate sets the expected cost and/or latency for a tool by name within
+// an environment. Fields left nil keep their current value, so cost and
+// latency can be set independently.
+func (s *ToolAnnotationService) Annotate(environmentID int64, toolName string, costUSD *float64, latencyMS *int64) (*ToolAnnotation, error) {
+	tool, err := s.repos.MCPTools.FindByNameInEnvironment(environmentID, toolName)
+	if err != nil {
+		return nil, fmt.Errorf("tool '%s' not found in environment: %w", toolName, err)
+	}
+
+	annotation, err := s.loadAnnotation(tool.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if costUSD != nil {
+		annotation.ExpectedCostUSD = costUSD
+	}
+	if latencyMS != nil {
+		annotation.ExpectedLatencyMS = latencyMS
+	}
+	annotation.Source = ToolAnnotationSourceManual
+
+	if err := s.storeAnnotation(tool.ID, annotation); err != nil {
+		return nil, err
+	}
+	return annotation, nil
+}
+
+// GetEnvironmentAnnotations returns all tool annotations for an environment
+// keyed by tool name. Malformed annotation JSON is skipped.
+func (s *ToolAnnotationService) GetEnvironmentAnnotations(environmentID int64) (map[string]ToolAnnotation, error) {
+	raw, err := s.repos.MCPTools.ListAnnotationsByEnvironment(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := make(map[string]ToolAnnotation)
+	for name, annotationJSON := range raw {
+		var annotation ToolAnnotation
+		if err := json.Unmarshal([]byte(annotationJSON), &annotation); err != nil {
+			continue
+		}
+		annotations[name] = annotation
+	}
+	return annotations, nil
+}
+
+// LearnLatencies derives expected latencies from the tool call durations
+// recorded in recent run history and stores them as learned annotations.
+// Manually annotated latencies are never overwritten; a manually set cost is
+// preserved when the latency beside it is learned. Returns the number of
+// tools updated.
+func (s *ToolAnnotationService) LearnLatencies(ctx context.Context, environmentID int64) (int, error) {
+	agents, err := s.repos.Agents.ListByEnvironment(environmentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	totals := make(map[string]int64)
+	counts := make(map[string]int64)
+	for _, agent := range agents {
+		runs, err := s.repos.AgentRuns.GetRecentByAgent(ctx, agent.ID, toolAnnotationLearnWindow)
+		if err != nil {
+			continue
+		}
+		for _, run := range runs {
+			for name, duration := range toolCallDurations(run) {
+				totals[name] += duration.total
+				counts[name] += duration.count
+			}
+		}
+	}
+
+	updated := 0
+	for name, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		tool, err := s.repos.MCPTools.FindByNameInEnvironment(environmentID, name)
+		if err != nil {
+			continue
+		}
+		annotation, err := s.loadAnnotation(tool.ID)
+		if err != nil {
+			continue
+		}
+		if annotation.Source == ToolAnnotationSourceManual && annotation.ExpectedLatencyMS != nil {
+			continue
+		}
+
+		avg := totals[name] / count
+		annotation.ExpectedLatencyMS = &avg
+		annotation.Samples = int(count)
+		if annotation.ExpectedCostUSD == nil || annotation.Source != ToolAnnotationSourceManual {
+			annotation.Source = ToolAnnotationSourceLearned
+		}
+
+		if err := s.storeAnnotation(tool.ID, annotation); err != nil {
+			continue
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// loadAnnotation reads a tool's current annotation, returning an empty
+// annotation when none is stored
+func (s *ToolAnnotationService) loadAnnotation(toolID int64) (*ToolAnnotation, error) {
+	annotationJSON, err := s.repos.MCPTools.GetAnnotations(toolID)
+	if err != nil {
+		return nil, err
+	}
+	annotation := &ToolAnnotation{}
+	if annotationJSON != "" {
+		if err := json.Unmarshal([]byte(annotationJSON), annotation); err != nil {
+			// Malformed stored annotation: start fresh rather than fail
+			annotation = &ToolAnnotation{}
+		}
+	}
+	return annotation, nil
+}
+
+func (s *ToolAnnotationService) storeAnnotation(toolID int64, annotation *ToolAnnotation) error {
+	annotationJSON, err := json.Marshal(annotation)
+	if err != nil {
+		return err
+	}
+	return s.repos.MCPTools.UpdateAnnotations(toolID, string(annotationJSON))
+}
+
+// FormatToolAnnotation renders an annotation for CLI display, e.g.
+// "~$0.0010/call, ~250ms (learned from 12 calls)"
+func FormatToolAnnotation(annotation ToolAnnotation) string {
+	var parts []string
+	if annotation.ExpectedCostUSD != nil {
+		parts = append(parts, fmt.Sprintf("~$%.4f/call", *annotation.ExpectedCostUSD))
+	}
+	if annotation.ExpectedLatencyMS != nil {
+		parts = append(parts, fmt.Sprintf("~%dms", *annotation.ExpectedLatencyMS))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	formatted := parts[0]
+	for _, part := range parts[1:] {
+		formatted += ", " + part
+	}
+	if annotation.Source == ToolAnnotationSourceLearned && annotation.Samples > 0 {
+		formatted += fmt.Sprintf(" (learned from %d calls)", annotation.Samples)
+	}
+	return formatted
+}
+
+// ToolAnnotationHint renders the environment's tool annotations as task
+// context so the model sees expected cost and latency next to each tool.
+// When preferCheap is set the hint also asks the model to order its tool
+// usage cheapest-first. Empty when there are no annotations.
+func ToolAnnotationHint(annotations map[string]ToolAnnotation, preferCheap bool) string {
+	if len(annotations) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(annotations))
+	for name := range annotations {
+		if FormatToolAnnotation(annotations[name]) != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	hint := "\n\nTool cost/latency annotations for this environment:\n"
+	for _, name := range names {
+		hint += fmt.Sprintf("- %s: %s\n", name, FormatToolAnnotation(annotations[name]))
+	}
+	if preferCheap {
+		hint += "\nPrefer cheaper and faster tools first; only fall back to expensive or slow tools when a cheaper tool cannot answer the question."
+	}
+	return hint
+}
+
+// AggregateToolSpend counts tool calls across runs and estimates per-tool
+// spend from annotated cost per call. Tools without a cost annotation still
+// appear with their call count so the report shows usage.
+func AggregateToolSpend(runs []queries.AgentRun, annotations map[string]ToolAnnotation) map[string]ToolSpend {
+	spend := make(map[string]ToolSpend)
+	for _, run := range runs {
+		if !run.ToolCalls.Valid || run.ToolCalls.String == "" {
+			continue
+		}
+		var toolCalls []map[string]interface{}
+		if err := json.Unmarshal([]byte(run.ToolCalls.String), &toolCalls); err != nil {
+			continue
+		}
+		for _, call := range toolCalls {
+			name, ok := call["tool_name"].(string)
+			if !ok || name == "" {
+				continue
+			}
+			entry := spend[name]
+			entry.Calls++
+			if annotation, ok := annotations[name]; ok && annotation.ExpectedCostUSD != nil {
+				entry.EstimatedCostUSD += *annotation.ExpectedCostUSD
+			}
+			spend[name] = entry
+		}
+	}
+	return spend
+}
+
+// toolDurationSample accumulates duration_ms observations per tool
+type toolDurationSample struct {
+	total int64
+	count int64
+}
+
+// toolCallDurations extracts per-tool duration_ms sums from a run's
+// recorded tool calls
+func toolCallDurations(run queries.AgentRun) map[string]toolDurationSample {
+	samples := make(map[string]toolDurationSample)
+	if !run.ToolCalls.Valid || run.ToolCalls.String == "" {
+		return samples
+	}
+	var toolCalls []map[string]interface{}
+	if err := json.Unmarshal([]byte(run.ToolCalls.String), &toolCalls); err != nil {
+		return samples
+	}
+	for _, call := range toolCalls {
+		name, ok := call["tool_name"].(string)
+		if !ok || name == "" {
+			continue
+		}
+		duration, ok := call["duration_ms"].(float64)
+		if !ok || duration <= 0 {
+			continue
+		}
+		sample := samples[name]
+		sample.total += int64(duration)
+		sample.count++
+		samples[name] = sample
+	}
+	return samples
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/queries"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+func setupAnnotationTest(t *testing.T) (*repositories.Repositories, int64, int64) {
+	t.Helper()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("annotate-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	server, err := repos.MCPServers.Create(&models.MCPServer{
+		Name:          "filesystem",
+		Command:       "npx",
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create MCP server: %v", err)
+	}
+
+	return repos, env.ID, server
+}
+
+func TestAnnotateAndGetEnvironmentAnnotations(t *testing.T) {
+	repos, envID, serverID := setupAnnotationTest(t)
+
+	if _, err := repos.MCPTools.Create(&models.MCPTool{
+		MCPServerID: serverID,
+		Name:        "__read_text_file",
+		Description: "Read file",
+	}); err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+
+	service := NewToolAnnotationService(repos)
+
+	cost := 0.002
+	annotation, err := service.Annotate(envID, "__read_text_file", &cost, nil)
+	if err != nil {
+		t.Fatalf("Annotate failed: %v", err)
+	}
+	if annotation.Source != ToolAnnotationSourceManual {
+		t.Errorf("Expected manual source, got %q", annotation.Source)
+	}
+
+	// Set latency separately: the cost must be preserved
+	latency := int64(250)
+	if _, err := service.Annotate(envID, "__read_text_file", nil, &latency); err != nil {
+		t.Fatalf("Annotate (latency) failed: %v", err)
+	}
+
+	annotations, err := service.GetEnvironmentAnnotations(envID)
+	if err != nil {
+		t.Fatalf("GetEnvironmentAnnotations failed: %v", err)
+	}
+	got, ok := annotations["__read_text_file"]
+	if !ok {
+		t.Fatalf("Expected annotation for __read_text_file, got %v", annotations)
+	}
+	if got.ExpectedCostUSD == nil || *got.ExpectedCostUSD != cost {
+		t.Errorf("Expected cost %v preserved, got %v", cost, got.ExpectedCostUSD)
+	}
+	if got.ExpectedLatencyMS == nil || *got.ExpectedLatencyMS != latency {
+		t.Errorf("Expected latency %d, got %v", latency, got.ExpectedLatencyMS)
+	}
+}
+
+func TestAnnotateUnknownTool(t *testing.T) {
+	repos, envID, _ := setupAnnotationTest(t)
+
+	cost := 0.01
+	if _, err := NewToolAnnotationService(repos).Annotate(envID, "__missing", &cost, nil); err == nil {
+		t.Error("Expected error annotating a tool that does not exist")
+	}
+}
+
+func TestLearnLatenciesFromRunHistory(t *testing.T) {
+	repos, envID, serverID := setupAnnotationTest(t)
+	ctx := context.Background()
+
+	if _, err := repos.MCPTools.Create(&models.MCPTool{
+		MCPServerID: serverID,
+		Name:        "__search_files",
+		Description: "Search",
+	}); err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+
+	user, err := repos.Users.Create("annotate-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Annotate Agent", "annotates", "prompt", 5, envID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	toolCalls := models.JSONArray{
+		map[string]interface{}{"tool_name": "__search_files", "duration_ms": float64(100)},
+		map[string]interface{}{"tool_name": "__search_files", "duration_ms": float64(300)},
+	}
+	run, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "task", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+	completedAt := time.Now()
+	if err := repos.AgentRuns.UpdateCompletionWithMetadata(ctx, run.ID, "done", 2,
+		&toolCalls, nil, "completed", &completedAt,
+		nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("failed to complete run: %v", err)
+	}
+
+	service := NewToolAnnotationService(repos)
+	updated, err := service.LearnLatencies(ctx, envID)
+	if err != nil {
+		t.Fatalf("LearnLatencies failed: %v", err)
+	}
+	if updated != 1 {
+		t.Fatalf("Expected 1 tool updated, got %d", updated)
+	}
+
+	annotations, err := service.GetEnvironmentAnnotations(envID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := annotations["__search_files"]
+	if got.ExpectedLatencyMS == nil || *got.ExpectedLatencyMS != 200 {
+		t.Errorf("Expected learned latency 200ms, got %v", got.ExpectedLatencyMS)
+	}
+	if got.Source != ToolAnnotationSourceLearned {
+		t.Errorf("Expected learned source, got %q", got.Source)
+	}
+	if got.Samples != 2 {
+		t.Errorf("Expected 2 samples, got %d", got.Samples)
+	}
+}
+
+func TestLearnLatenciesPreservesManualLatency(t *testing.T) {
+	repos, envID, serverID := setupAnnotationTest(t)
+	ctx := context.Background()
+
+	if _, err := repos.MCPTools.Create(&models.MCPTool{
+		MCPServerID: serverID,
+		Name:        "__search_files",
+		Description: "Search",
+	}); err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+
+	user, err := repos.Users.Create("annotate-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Annotate Agent", "annotates", "prompt", 5, envID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	service := NewToolAnnotationService(repos)
+	manualLatency := int64(50)
+	if _, err := service.Annotate(envID, "__search_files", nil, &manualLatency); err != nil {
+		t.Fatalf("Annotate failed: %v", err)
+	}
+
+	toolCalls := models.JSONArray{
+		map[string]interface{}{"tool_name": "__search_files", "duration_ms": float64(900)},
+	}
+	run, err := repos.AgentRuns.Create(ctx, agent.ID, user.ID, "task", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+	completedAt := time.Now()
+	if err := repos.AgentRuns.UpdateCompletionWithMetadata(ctx, run.ID, "done", 1,
+		&toolCalls, nil, "completed", &completedAt,
+		nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("failed to complete run: %v", err)
+	}
+
+	updated, err := service.LearnLatencies(ctx, envID)
+	if err != nil {
+		t.Fatalf("LearnLatencies failed: %v", err)
+	}
+	if updated != 0 {
+		t.Errorf("Expected manual latency to be preserved, but %d tool(s) were updated", updated)
+	}
+
+	annotations, _ := service.GetEnvironmentAnnotations(envID)
+	got := annotations["__search_files"]
+	if got.ExpectedLatencyMS == nil || *got.ExpectedLatencyMS != manualLatency {
+		t.Errorf("Expected manual latency %dms preserved, got %v", manualLatency, got.ExpectedLatencyMS)
+	}
+}
+
+func TestToolAnnotationHint(t *testing.T) {
+	cost := 0.05
+	latency := int64(1200)
+	annotations := map[string]ToolAnnotation{
+		"__checkov_scan_directory": {ExpectedCostUSD: &cost, ExpectedLatencyMS: &latency, Source: ToolAnnotationSourceManual},
+	}
+
+	hint := ToolAnnotationHint(annotations, false)
+	if !strings.Contains(hint, "__checkov_scan_directory") {
+		t.Error("Expected hint to include the annotated tool name")
+	}
+	if !strings.Contains(hint, "~$0.0500/call") || !strings.Contains(hint, "~1200ms") {
+		t.Errorf("Expected cost and latency in hint, got %q", hint)
+	}
+	if strings.Contains(hint, "Prefer cheaper") {
+		t.Error("Expected no ordering preference without preferCheap")
+	}
+
+	cheapHint := ToolAnnotationHint(annotations, true)
+	if !strings.Contains(cheapHint, "Prefer cheaper") {
+		t.Error("Expected ordering preference with preferCheap")
+	}
+
+	if ToolAnnotationHint(nil, true) != "" {
+		t.Error("Expected empty hint when no annotations exist")
+	}
+}
+
+func TestAggregateToolSpend(t *testing.T) {
+	cost := 0.01
+	annotations := map[string]ToolAnnotation{
+		"__read_text_file": {ExpectedCostUSD: &cost},
+	}
+	runs := []queries.AgentRun{
+		{ToolCalls: sql.NullString{String: `[{"tool_name": "__read_text_file"}, {"tool_name": "__read_text_file"}, {"tool_name": "__list_directory"}]`, Valid: true}},
+		{ToolCalls: sql.NullString{String: `[{"tool_name": "__read_text_file"}]`, Valid: true}},
+		{ToolCalls: sql.NullString{String: "not json", Valid: true}},
+		{},
+	}
+
+	spend := AggregateToolSpend(runs, annotations)
+
+	read := spend["__read_text_file"]
+	if read.Calls != 3 {
+		t.Errorf("Expected 3 calls for __read_text_file, got %d", read.Calls)
+	}
+	if read.EstimatedCostUSD < 0.029 || read.EstimatedCostUSD > 0.031 {
+		t.Errorf("Expected ~$0.03 spend for __read_text_file, got %f", read.EstimatedCostUSD)
+	}
+
+	// Unannotated tools still show their call count with zero spend
+	list := spend["__list_directory"]
+	if list.Calls != 1 || list.EstimatedCostUSD != 0 {
+		t.Errorf("Expected 1 call / $0 for __list_directory, got %+v", list)
+	}
+}
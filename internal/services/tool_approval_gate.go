@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+	"station/internal/notifications"
+)
+
+// Agents can gate specific tools behind operator approval by declaring glob
+// patterns in the .prompt frontmatter:
+//
+//	approval_tools:
+//	  - "*delete*"
+//	  - "__aws_terminate_*"
+//
+// When a gated tool is about to run, the run enters `awaiting_approval`, the
+// proposed call (tool name and arguments) is recorded and surfaced via
+// `stn runs inspect`, the approval webhook (if configured) fires, and
+// execution blocks until an operator runs `stn runs approve <id>` or
+// `stn runs deny <id>` — or the wait times out and the call is denied.
+const (
+	toolApprovalSettingPrefix = "tool_approval.run."
+
+	// ToolApprovalStatusPending marks a proposed tool call awaiting a decision
+	ToolApprovalStatusPending = "pending"
+	// ToolApprovalStatusApproved marks a call the operator allowed
+	ToolApprovalStatusApproved = "approved"
+	// ToolApprovalStatusDenied marks a call the operator rejected
+	ToolApprovalStatusDenied = "denied"
+)
+
+// How long a gated tool call waits for an operator decision before being
+// denied automatically, and how often the decision is polled
+const (
+	toolApprovalWaitTimeout = 15 * time.Minute
+	toolApprovalPollPeriod  = 2 * time.Second
+)
+
+// ToolApprovalRequest is the pending (or decided) approval for a run's gated
+// tool call, stored in the settings table under tool_approval.run.<run_id>.
+// Execution is sequential within a run, so at most one request exists per run.
+type ToolApprovalRequest struct {
+	RunID       int64                  `json:"run_id"`
+	AgentID     int64                  `json:"agent_id"`
+	AgentName   string                 `json:"agent_name"`
+	ToolName    string                 `json:"tool_name"`
+	Arguments   map[string]interface{} `json:"arguments,omitempty"`
+	Status      string                 `json:"status"`
+	RequestedAt string                 `json:"requested_at"`
+	DecidedAt   string                 `json:"decided_at,omitempty"`
+	DecidedBy   string                 `json:"decided_by,omitempty"`
+	Comment     string                 `json:"comment,omitempty"`
+}
+
+// ToolApprovalService manages approval requests for gated tool calls inside
+// agent runs (the agent-run counterpart to workflow approvals)
+type ToolApprovalService struct {
+	repos *repositories.Repositories
+}
+
+// NewToolApprovalService creates a tool approval service
+func NewToolApprovalService(repos *repositories.Repositories) *ToolApprovalService {
+	return &ToolApprovalService{repos: repos}
+}
+
+func toolApprovalKey(runID int64) string {
+	return fmt.Sprintf("%s%d", toolApprovalSettingPrefix, runID)
+}
+
+// GetRequest returns the approval request for a run, or nil when the run has
+// never gated a tool call
+func (s *ToolApprovalService) GetRequest(runID int64) (*ToolApprovalRequest, error) {
+	setting, err := s.repos.Settings.GetByKey(toolApprovalKey(runID))
+	if err != nil {
+		return nil, nil
+	}
+
+	var request ToolApprovalRequest
+	if err := json.Unmarshal([]byte(setting.Value), &request); err != nil {
+		return nil, fmt.Errorf("failed to parse approval request for run %d: %w", runID, err)
+	}
+	return &request, nil
+}
+
+// createRequest records a pending approval for the proposed tool call,
+// replacing any previous (decided) request for the run
+func (s *ToolApprovalService) createRequest(request *ToolApprovalRequest) error {
+	request.Status = ToolApprovalStatusPending
+	request.RequestedAt = time.Now().UTC().Format(time.RFC3339)
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to serialize approval request: %w", err)
+	}
+	return s.repos.Settings.Set(toolApprovalKey(request.RunID), string(data), "Pending tool call approval")
+}
+
+// Decide resolves the pending approval for a run. Returns the decided
+// request so callers can echo what was approved or denied.
+func (s *ToolApprovalService) Decide(runID int64, approve bool, decidedBy, comment string) (*ToolApprovalRequest, error) {
+	request, err := s.GetRequest(runID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, fmt.Errorf("run %d has no approval request", runID)
+	}
+	if request.Status != ToolApprovalStatusPending {
+		return nil, fmt.Errorf("approval for run %d already decided: %s", runID, request.Status)
+	}
+
+	if approve {
+		request.Status = ToolApprovalStatusApproved
+	} else {
+		request.Status = ToolApprovalStatusDenied
+	}
+	request.DecidedAt = time.Now().UTC().Format(time.RFC3339)
+	request.DecidedBy = decidedBy
+	request.Comment = comment
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize approval decision: %w", err)
+	}
+	if err := s.repos.Settings.Set(toolApprovalKey(runID), string(data), "Pending tool call approval"); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// approvalToolsFrontmatter extracts the approval_tools block from an agent's
+// .prompt frontmatter
+type approvalToolsFrontmatter struct {
+	ApprovalTools []string `yaml:"approval_tools"`
+}
+
+// parseApprovalTools extracts the gated tool patterns from a .prompt file's
+// content, returning nil when none are declared
+func parseApprovalTools(content []byte) []string {
+	parts := strings.Split(string(content), "---")
+	if len(parts) < 3 {
+		return nil
+	}
+
+	var fm approvalToolsFrontmatter
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(parts[1])), &fm); err != nil {
+		logging.Debug("Failed to parse approval_tools from dotprompt: %v", err)
+		return nil
+	}
+	if len(fm.ApprovalTools) == 0 {
+		return nil
+	}
+	return fm.ApprovalTools
+}
+
+// toolRequiresApproval reports whether a tool name matches any gated pattern
+// (exact names or globs like "*delete*")
+func toolRequiresApproval(patterns []string, toolName string) bool {
+	for _, pattern := range patterns {
+		if pattern == toolName {
+			return true
+		}
+		if ok, err := path.Match(pattern, toolName); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// toolApprovalMiddleware pauses gated tool calls until an operator decides.
+// The run sits in `awaiting_approval` while blocked; denied (or timed-out)
+// calls are short-circuited with a denial message the model can react to.
+type toolApprovalMiddleware struct {
+	repos   *repositories.Repositories
+	service *ToolApprovalService
+	// lookup resolves an agent's gated patterns; replaceable in tests
+	lookup func(agentID int64) []string
+	// waitTimeout/pollPeriod are shortened in tests
+	waitTimeout time.Duration
+	pollPeriod  time.Duration
+}
+
+func newToolApprovalMiddleware(repos *repositories.Repositories) *toolApprovalMiddleware {
+	m := &toolApprovalMiddleware{
+		repos:       repos,
+		service:     NewToolApprovalService(repos),
+		waitTimeout: toolApprovalWaitTimeout,
+		pollPeriod:  toolApprovalPollPeriod,
+	}
+	m.lookup = m.loadFromPromptFile
+	return m
+}
+
+func (m *toolApprovalMiddleware) Name() string {
+	return "tool-approval-gate"
+}
+
+// loadFromPromptFile reads the agent's .prompt frontmatter for gated
+// patterns. Read per call so `stn sync` changes apply without a restart.
+func (m *toolApprovalMiddleware) loadFromPromptFile(agentID int64) []string {
+	agent, err := m.repos.Agents.GetByID(agentID)
+	if err != nil {
+		return nil
+	}
+	env, err := m.repos.Environments.GetByID(agent.EnvironmentID)
+	if err != nil {
+		return nil
+	}
+
+	content, err := os.ReadFile(config.GetAgentPromptPath(env.Name, agent.Name))
+	if err != nil {
+		return nil
+	}
+	return parseApprovalTools(content)
+}
+
+func (m *toolApprovalMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	if call.AgentID == 0 || call.RunID == 0 {
+		return nil, nil
+	}
+
+	patterns := m.lookup(call.AgentID)
+	if len(patterns) == 0 || !toolRequiresApproval(patterns, call.ToolName) {
+		return nil, nil
+	}
+
+	request := &ToolApprovalRequest{
+		RunID:     call.RunID,
+		AgentID:   call.AgentID,
+		AgentName: call.AgentName,
+		ToolName:  call.ToolName,
+		Arguments: call.Arguments,
+	}
+	if err := m.service.createRequest(request); err != nil {
+		return nil, fmt.Errorf("failed to record approval request for %s: %w", call.ToolName, err)
+	}
+	if err := m.repos.AgentRuns.UpdateStatus(ctx, call.RunID, "awaiting_approval"); err != nil {
+		logging.Debug("Failed to set run %d awaiting_approval: %v", call.RunID, err)
+	}
+	m.recordGateEvent(ctx, call, fmt.Sprintf("Tool call %s requires approval: stn runs approve %d / stn runs deny %d", call.ToolName, call.RunID, call.RunID))
+	m.notifyOperator(ctx, call)
+
+	logging.Info("Run %d awaiting approval for tool %s (agent %s)", call.RunID, call.ToolName, call.AgentName)
+
+	decided, err := m.waitForDecision(ctx, call.RunID)
+
+	// Whatever the outcome, the run resumes executing (a denial is surfaced
+	// to the model as the tool output, not as a run failure)
+	if statusErr := m.repos.AgentRuns.UpdateStatus(ctx, call.RunID, "running"); statusErr != nil {
+		logging.Debug("Failed to restore run %d status after approval wait: %v", call.RunID, statusErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if decided.Status == ToolApprovalStatusApproved {
+		m.recordGateEvent(ctx, call, fmt.Sprintf("Tool call %s approved by %s", call.ToolName, decided.DecidedBy))
+		return nil, nil
+	}
+
+	denial := fmt.Sprintf("Tool call %s was denied by the operator", call.ToolName)
+	if decided.Comment != "" {
+		denial = fmt.Sprintf("%s: %s", denial, decided.Comment)
+	}
+	m.recordGateEvent(ctx, call, denial)
+	return map[string]interface{}{
+		"denied":  true,
+		"message": denial + ". Do not retry this call; adjust your approach or report what you could not do.",
+	}, nil
+}
+
+func (m *toolApprovalMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	return nil, nil
+}
+
+// waitForDecision polls the approval request until the operator decides,
+// denying automatically when the wait times out or the run is cancelled
+func (m *toolApprovalMiddleware) waitForDecision(ctx context.Context, runID int64) (*ToolApprovalRequest, error) {
+	deadline := time.Now().Add(m.waitTimeout)
+	ticker := time.NewTicker(m.pollPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("run cancelled while awaiting tool approval: %w", ctx.Err())
+		case <-ticker.C:
+			request, err := m.service.GetRequest(runID)
+			if err != nil {
+				return nil, err
+			}
+			if request != nil && request.Status != ToolApprovalStatusPending {
+				return request, nil
+			}
+			if time.Now().After(deadline) {
+				return m.service.Decide(runID, false, "system", fmt.Sprintf("no decision within %s", m.waitTimeout))
+			}
+		}
+	}
+}
+
+// recordGateEvent stores gate activity on the run's debug logs so
+// `stn runs inspect -v` shows what was proposed and how it was decided
+func (m *toolApprovalMiddleware) recordGateEvent(ctx context.Context, call *ToolCall, message string) {
+	logEntry := map[string]interface{}{
+		"level":     "info",
+		"event":     "tool_approval",
+		"message":   message,
+		"tool_name": call.ToolName,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := m.repos.AgentRuns.AppendDebugLog(ctx, call.RunID, logEntry); err != nil {
+		logging.Debug("Failed to record approval event for run %d: %v", call.RunID, err)
+	}
+}
+
+// notifyOperator fires the configured approval webhook (Slack, etc.) with
+// the proposed tool call; silently skipped when notifications are not set up
+func (m *toolApprovalMiddleware) notifyOperator(ctx context.Context, call *ToolCall) {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	notifier := notifications.NewWebhookNotifier(cfg, nil)
+	if !notifier.IsEnabled() {
+		return
+	}
+
+	args, _ := json.Marshal(call.Arguments)
+	payload := notifications.ApprovalWebhookPayload{
+		Event:          "agent_run.approval_required",
+		RunID:          fmt.Sprintf("%d", call.RunID),
+		StepName:       call.ToolName,
+		Message:        fmt.Sprintf("Agent %s wants to call %s with %s", call.AgentName, call.ToolName, string(args)),
+		TimeoutSeconds: int(m.waitTimeout.Seconds()),
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := notifier.NotifyApprovalRequired(ctx, payload); err != nil {
+		logging.Debug("Failed to send approval webhook for run %d: %v", call.RunID, err)
+	}
+}
+
+var enableToolApprovalOnce sync.Once
+
+// EnableToolApprovalGate registers the approval gate middleware so gated
+// tool calls pause for operator approval (first constructor wins)
+func EnableToolApprovalGate(repos *repositories.Repositories) {
+	enableToolApprovalOnce.Do(func() {
+		RegisterToolCallMiddleware(newToolApprovalMiddleware(repos))
+	})
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestParseApprovalTools(t *testing.T) {
+	prompt := `---
+metadata:
+  name: "Careful Agent"
+model: gpt-4o-mini
+approval_tools:
+  - "*delete*"
+  - "__aws_terminate_*"
+---
+
+{{role "system"}}
+You are a careful agent.
+`
+	patterns := parseApprovalTools([]byte(prompt))
+	if len(patterns) != 2 {
+		t.Fatalf("expected 2 patterns, got %v", patterns)
+	}
+	if patterns[0] != "*delete*" {
+		t.Errorf("expected first pattern '*delete*', got %q", patterns[0])
+	}
+
+	// Prompts without the block return nil
+	if got := parseApprovalTools([]byte("---\nmodel: gpt-4o-mini\n---\nbody")); got != nil {
+		t.Errorf("expected nil patterns, got %v", got)
+	}
+}
+
+func TestToolRequiresApproval(t *testing.T) {
+	patterns := []string{"*delete*", "__aws_terminate_*", "__drop_table"}
+
+	tests := []struct {
+		tool  string
+		gated bool
+	}{
+		{"__delete_file", true},
+		{"__s3_delete_bucket", true},
+		{"__aws_terminate_instances", true},
+		{"__drop_table", true},
+		{"__read_text_file", false},
+		{"__list_directory", false},
+	}
+	for _, tt := range tests {
+		if got := toolRequiresApproval(patterns, tt.tool); got != tt.gated {
+			t.Errorf("toolRequiresApproval(%q) = %v, want %v", tt.tool, got, tt.gated)
+		}
+	}
+}
+
+func TestToolApprovalMiddlewareApproveAndDeny(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("approval-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("approval-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Careful Agent", "careful", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	run, err := repos.AgentRuns.Create(context.Background(), agent.ID, user.ID, "task", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+
+	m := newToolApprovalMiddleware(repos)
+	m.lookup = func(agentID int64) []string { return []string{"*delete*"} }
+	m.pollPeriod = 10 * time.Millisecond
+	m.waitTimeout = 5 * time.Second
+
+	service := NewToolApprovalService(repos)
+
+	// Ungated tool passes straight through
+	call := &ToolCall{AgentID: agent.ID, AgentName: agent.Name, RunID: run.ID, ToolName: "__read_text_file"}
+	if response, err := m.BeforeToolCall(context.Background(), call); err != nil || response != nil {
+		t.Fatalf("expected ungated call to pass, got response=%v err=%v", response, err)
+	}
+
+	// Gated call pauses the run, then proceeds once approved
+	approveDone := make(chan struct{})
+	go func() {
+		defer close(approveDone)
+		for {
+			if request, _ := service.GetRequest(run.ID); request != nil && request.Status == ToolApprovalStatusPending {
+				paused, _ := repos.AgentRuns.GetByID(context.Background(), run.ID)
+				if paused.Status != "awaiting_approval" {
+					t.Errorf("expected run awaiting_approval while gated, got %s", paused.Status)
+				}
+				if _, err := service.Decide(run.ID, true, "tester", ""); err != nil {
+					t.Errorf("approve failed: %v", err)
+				}
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	call = &ToolCall{AgentID: agent.ID, AgentName: agent.Name, RunID: run.ID, ToolName: "__delete_file",
+		Arguments: map[string]interface{}{"path": "/tmp/old.log"}}
+	response, err := m.BeforeToolCall(context.Background(), call)
+	if err != nil {
+		t.Fatalf("approved call failed: %v", err)
+	}
+	if response != nil {
+		t.Fatalf("expected approved call to execute (nil response), got %v", response)
+	}
+	<-approveDone
+
+	resumed, _ := repos.AgentRuns.GetByID(context.Background(), run.ID)
+	if resumed.Status != "running" {
+		t.Errorf("expected run back to running after approval, got %s", resumed.Status)
+	}
+
+	// Denied call is short-circuited with a denial message
+	go func() {
+		for {
+			if request, _ := service.GetRequest(run.ID); request != nil && request.Status == ToolApprovalStatusPending {
+				if _, err := service.Decide(run.ID, false, "tester", "too risky"); err != nil {
+					t.Errorf("deny failed: %v", err)
+				}
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	response, err = m.BeforeToolCall(context.Background(), call)
+	if err != nil {
+		t.Fatalf("denied call errored: %v", err)
+	}
+	denial, ok := response.(map[string]interface{})
+	if !ok || denial["denied"] != true {
+		t.Fatalf("expected denial short-circuit, got %v", response)
+	}
+	if message, _ := denial["message"].(string); !strings.Contains(message, "too risky") {
+		t.Errorf("expected deny comment in message, got %q", message)
+	}
+}
+
+func TestToolApprovalWaitTimeoutDenies(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("approval-timeout-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("timeout-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Timeout Agent", "timeout", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	run, err := repos.AgentRuns.Create(context.Background(), agent.ID, user.ID, "task", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+
+	m := newToolApprovalMiddleware(repos)
+	m.lookup = func(agentID int64) []string { return []string{"*delete*"} }
+	m.pollPeriod = 10 * time.Millisecond
+	m.waitTimeout = 50 * time.Millisecond
+
+	call := &ToolCall{AgentID: agent.ID, AgentName: agent.Name, RunID: run.ID, ToolName: "__delete_file"}
+	response, err := m.BeforeToolCall(context.Background(), call)
+	if err != nil {
+		t.Fatalf("timed-out call errored: %v", err)
+	}
+	denial, ok := response.(map[string]interface{})
+	if !ok || denial["denied"] != true {
+		t.Fatalf("expected timeout to deny the call, got %v", response)
+	}
+
+	request, err := NewToolApprovalService(repos).GetRequest(run.ID)
+	if err != nil || request == nil {
+		t.Fatalf("expected decided request, got %v (err %v)", request, err)
+	}
+	if request.Status != ToolApprovalStatusDenied || request.DecidedBy != "system" {
+		t.Errorf("expected system denial on timeout, got %+v", request)
+	}
+}
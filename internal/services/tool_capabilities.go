@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Agents can declare abstract capabilities instead of (or alongside) concrete
+// tool names in their .prompt frontmatter:
+//
+//	capabilities:
+//	  - filesystem.read
+//	  - metrics.query
+//
+// Each environment maps capabilities to its own concrete tools in
+// capabilities.yml next to template.json (values may be one tool or a list):
+//
+//	filesystem.read: __read_text_file
+//	metrics.query:
+//	  - __prometheus_query
+//	  - __prometheus_query_range
+//
+// Sync resolves capabilities through this mapping, so the same agent
+// definition ports cleanly between environments whose MCP servers name tools
+// differently.
+const capabilitiesFileName = "capabilities.yml"
+
+// capabilityToolList accepts either a single tool name or a list in YAML
+type capabilityToolList []string
+
+func (l *capabilityToolList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		var single string
+		if err := node.Decode(&single); err != nil {
+			return err
+		}
+		*l = capabilityToolList{single}
+		return nil
+	case yaml.SequenceNode:
+		var list []string
+		if err := node.Decode(&list); err != nil {
+			return err
+		}
+		*l = capabilityToolList(list)
+		return nil
+	}
+	return fmt.Errorf("capability mapping must be a tool name or a list of tool names")
+}
+
+// capabilitiesPathForAgentFile resolves the environment's capabilities.yml
+// from an agent .prompt file path (agents/ and capabilities.yml are siblings)
+func capabilitiesPathForAgentFile(agentFilePath string) string {
+	return filepath.Join(filepath.Dir(filepath.Dir(agentFilePath)), capabilitiesFileName)
+}
+
+// loadCapabilityMap reads an environment's capability mapping. A missing file
+// yields an empty map: capabilities are opt-in per environment.
+func loadCapabilityMap(path string) (map[string]capabilityToolList, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]capabilityToolList{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", capabilitiesFileName, err)
+	}
+
+	capMap := map[string]capabilityToolList{}
+	if err := yaml.Unmarshal(data, &capMap); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", capabilitiesFileName, err)
+	}
+	return capMap, nil
+}
+
+// expandCapabilityTools resolves declared capabilities through the mapping,
+// returning the concrete tool names (deduped, declaration order) and any
+// capabilities the environment does not map
+func expandCapabilityTools(capMap map[string]capabilityToolList, capabilities []string) (tools []string, unmapped []string) {
+	seen := map[string]bool{}
+	for _, capability := range capabilities {
+		mapped, ok := capMap[capability]
+		if !ok || len(mapped) == 0 {
+			unmapped = append(unmapped, capability)
+			continue
+		}
+		for _, tool := range mapped {
+			if !seen[tool] {
+				seen[tool] = true
+				tools = append(tools, tool)
+			}
+		}
+	}
+	return tools, unmapped
+}
+
+// mergeCapabilityTools appends capability-resolved tools to an agent's
+// explicit tool list without duplicating entries
+func mergeCapabilityTools(agentTools, capabilityTools []string) []string {
+	seen := make(map[string]bool, len(agentTools))
+	merged := make([]string, 0, len(agentTools)+len(capabilityTools))
+	for _, tool := range agentTools {
+		if !seen[tool] {
+			seen[tool] = true
+			merged = append(merged, tool)
+		}
+	}
+	for _, tool := range capabilityTools {
+		if !seen[tool] {
+			seen[tool] = true
+			merged = append(merged, tool)
+		}
+	}
+	return merged
+}
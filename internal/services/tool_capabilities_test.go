@@ -0,0 +1,85 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadCapabilityMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), capabilitiesFileName)
+	content := `
+filesystem.read: __read_text_file
+metrics.query:
+  - __prometheus_query
+  - __prometheus_query_range
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write capabilities file: %v", err)
+	}
+
+	capMap, err := loadCapabilityMap(path)
+	if err != nil {
+		t.Fatalf("loadCapabilityMap failed: %v", err)
+	}
+	if got := []string(capMap["filesystem.read"]); !reflect.DeepEqual(got, []string{"__read_text_file"}) {
+		t.Errorf("expected scalar mapping as single-tool list, got %v", got)
+	}
+	if got := []string(capMap["metrics.query"]); !reflect.DeepEqual(got, []string{"__prometheus_query", "__prometheus_query_range"}) {
+		t.Errorf("expected list mapping preserved, got %v", got)
+	}
+}
+
+func TestLoadCapabilityMapMissingFile(t *testing.T) {
+	capMap, err := loadCapabilityMap(filepath.Join(t.TempDir(), capabilitiesFileName))
+	if err != nil {
+		t.Fatalf("expected missing file to yield empty map, got %v", err)
+	}
+	if len(capMap) != 0 {
+		t.Errorf("expected empty map, got %v", capMap)
+	}
+}
+
+func TestLoadCapabilityMapInvalidMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), capabilitiesFileName)
+	if err := os.WriteFile(path, []byte("filesystem.read:\n  nested: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write capabilities file: %v", err)
+	}
+	if _, err := loadCapabilityMap(path); err == nil {
+		t.Error("expected error for nested mapping value")
+	}
+}
+
+func TestExpandCapabilityTools(t *testing.T) {
+	capMap := map[string]capabilityToolList{
+		"filesystem.read": {"__read_text_file"},
+		"filesystem.list": {"__list_directory", "__read_text_file"},
+	}
+
+	tools, unmapped := expandCapabilityTools(capMap, []string{"filesystem.read", "filesystem.list", "metrics.query"})
+	if !reflect.DeepEqual(tools, []string{"__read_text_file", "__list_directory"}) {
+		t.Errorf("expected deduped tools in declaration order, got %v", tools)
+	}
+	if !reflect.DeepEqual(unmapped, []string{"metrics.query"}) {
+		t.Errorf("expected metrics.query reported as unmapped, got %v", unmapped)
+	}
+}
+
+func TestMergeCapabilityTools(t *testing.T) {
+	merged := mergeCapabilityTools(
+		[]string{"__read_text_file", "__search_files"},
+		[]string{"__read_text_file", "__list_directory"},
+	)
+	if !reflect.DeepEqual(merged, []string{"__read_text_file", "__search_files", "__list_directory"}) {
+		t.Errorf("expected explicit tools first without duplicates, got %v", merged)
+	}
+}
+
+func TestCapabilitiesPathForAgentFile(t *testing.T) {
+	got := capabilitiesPathForAgentFile("/root/.config/station/environments/prod/agents/scanner.prompt")
+	want := "/root/.config/station/environments/prod/capabilities.yml"
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
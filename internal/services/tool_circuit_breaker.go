@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+// Per-tool circuit breakers stop agents from burning turns retrying a dead
+// tool. After a tool fails several times in a row (timeouts or errors), its
+// circuit opens for a cooldown period: further calls fail fast with a message
+// the model can react to instead of waiting on another timeout. After the
+// cooldown one probe call is let through (half-open); success closes the
+// circuit, another failure re-opens it. State is stored in the settings table
+// under circuit.tool.<name> so it persists across runs and is visible in
+// `stn mcp status`.
+const (
+	toolCircuitSettingPrefix = "circuit.tool."
+
+	// ToolCircuitOpen rejects calls until the cooldown elapses
+	ToolCircuitOpen = "open"
+	// ToolCircuitHalfOpen lets one probe call through after the cooldown
+	ToolCircuitHalfOpen = "half_open"
+)
+
+// How many consecutive failures trip a tool's circuit, and how long calls
+// fail fast before a probe is allowed
+const (
+	toolCircuitFailureThreshold = 3
+	toolCircuitCooldown         = 2 * time.Minute
+)
+
+// ToolCircuitState is the persisted breaker state for one tool. Tools that
+// have never failed (or recovered) have no state at all.
+type ToolCircuitState struct {
+	ToolName            string `json:"tool_name"`
+	State               string `json:"state"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	TotalTrips          int    `json:"total_trips"`
+	LastError           string `json:"last_error,omitempty"`
+	LastFailureAt       string `json:"last_failure_at,omitempty"`
+	OpenedAt            string `json:"opened_at,omitempty"`
+	OpenUntil           string `json:"open_until,omitempty"`
+}
+
+// ToolCircuitService tracks per-tool failure streaks and open circuits
+type ToolCircuitService struct {
+	repos     *repositories.Repositories
+	threshold int
+	cooldown  time.Duration
+	// now is replaceable in tests
+	now func() time.Time
+	mu  sync.Mutex
+}
+
+// NewToolCircuitService creates a circuit service with the default thresholds
+func NewToolCircuitService(repos *repositories.Repositories) *ToolCircuitService {
+	return &ToolCircuitService{
+		repos:     repos,
+		threshold: toolCircuitFailureThreshold,
+		cooldown:  toolCircuitCooldown,
+		now:       time.Now,
+	}
+}
+
+func toolCircuitKey(toolName string) string {
+	return toolCircuitSettingPrefix + toolName
+}
+
+// GetState returns a tool's breaker state, or nil when the tool has no
+// recorded failures
+func (s *ToolCircuitService) GetState(toolName string) (*ToolCircuitState, error) {
+	setting, err := s.repos.Settings.GetByKey(toolCircuitKey(toolName))
+	if err != nil {
+		return nil, nil
+	}
+
+	var state ToolCircuitState
+	if err := json.Unmarshal([]byte(setting.Value), &state); err != nil {
+		return nil, fmt.Errorf("failed to parse circuit state for %s: %w", toolName, err)
+	}
+	return &state, nil
+}
+
+// List returns breaker state for every tool that has recorded failures
+func (s *ToolCircuitService) List() ([]*ToolCircuitState, error) {
+	settings, err := s.repos.Settings.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings: %w", err)
+	}
+
+	var states []*ToolCircuitState
+	for _, setting := range settings {
+		if !strings.HasPrefix(setting.Key, toolCircuitSettingPrefix) {
+			continue
+		}
+		var state ToolCircuitState
+		if err := json.Unmarshal([]byte(setting.Value), &state); err != nil {
+			logging.Debug("Skipping unparseable circuit state %s: %v", setting.Key, err)
+			continue
+		}
+		states = append(states, &state)
+	}
+	return states, nil
+}
+
+func (s *ToolCircuitService) saveState(state *ToolCircuitState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize circuit state: %w", err)
+	}
+	return s.repos.Settings.Set(toolCircuitKey(state.ToolName), string(data), "Tool circuit breaker state")
+}
+
+// CheckCall decides whether a tool call may proceed. When the circuit is open
+// it returns a rejection message for the model; when the cooldown has elapsed
+// it moves the circuit to half-open and lets the probe call through.
+func (s *ToolCircuitService) CheckCall(toolName string) (allowed bool, rejection string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.GetState(toolName)
+	if err != nil || state == nil || state.State != ToolCircuitOpen {
+		return true, ""
+	}
+
+	openUntil, err := time.Parse(time.RFC3339, state.OpenUntil)
+	if err != nil || !s.now().Before(openUntil) {
+		// Cooldown elapsed (or timestamp unreadable): allow one probe call
+		state.State = ToolCircuitHalfOpen
+		if err := s.saveState(state); err != nil {
+			logging.Debug("Failed to mark circuit half-open for %s: %v", toolName, err)
+		}
+		logging.Info("Circuit for tool %s half-open, allowing probe call", toolName)
+		return true, ""
+	}
+
+	return false, fmt.Sprintf(
+		"Tool %s is unavailable: it failed %d times in a row (last error: %s) and its circuit is open until %s. Do not retry this tool; use a different tool or report what you could not do.",
+		toolName, state.ConsecutiveFailures, state.LastError, openUntil.Format(time.RFC3339))
+}
+
+// RecordFailure counts a failed call, opening the circuit when the streak
+// reaches the threshold (immediately when a half-open probe fails)
+func (s *ToolCircuitService) RecordFailure(toolName, errMsg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.GetState(toolName)
+	if err != nil || state == nil {
+		state = &ToolCircuitState{ToolName: toolName}
+	}
+
+	now := s.now().UTC()
+	state.ConsecutiveFailures++
+	state.LastError = errMsg
+	state.LastFailureAt = now.Format(time.RFC3339)
+
+	if state.State == ToolCircuitHalfOpen || state.ConsecutiveFailures >= s.threshold {
+		if state.State != ToolCircuitOpen {
+			state.TotalTrips++
+		}
+		state.State = ToolCircuitOpen
+		state.OpenedAt = now.Format(time.RFC3339)
+		state.OpenUntil = now.Add(s.cooldown).Format(time.RFC3339)
+		logging.Info("Circuit opened for tool %s after %d consecutive failures (cooldown %s)",
+			toolName, state.ConsecutiveFailures, s.cooldown)
+	}
+
+	if err := s.saveState(state); err != nil {
+		logging.Debug("Failed to record circuit failure for %s: %v", toolName, err)
+	}
+}
+
+// RecordSuccess closes the circuit and clears the failure streak
+func (s *ToolCircuitService) RecordSuccess(toolName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.GetState(toolName)
+	if err != nil || state == nil {
+		return
+	}
+
+	if state.State != "" {
+		logging.Info("Circuit closed for tool %s after successful call", toolName)
+	}
+	if err := s.repos.Settings.Delete(toolCircuitKey(toolName)); err != nil {
+		logging.Debug("Failed to clear circuit state for %s: %v", toolName, err)
+	}
+}
+
+// toolCircuitBreakerMiddleware fails open-circuit tool calls fast and feeds
+// call outcomes back into the breaker
+type toolCircuitBreakerMiddleware struct {
+	service *ToolCircuitService
+}
+
+func (m *toolCircuitBreakerMiddleware) Name() string {
+	return "tool-circuit-breaker"
+}
+
+func (m *toolCircuitBreakerMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	allowed, rejection := m.service.CheckCall(call.ToolName)
+	if allowed {
+		return nil, nil
+	}
+	return map[string]interface{}{
+		"circuit_open": true,
+		"message":      rejection,
+	}, nil
+}
+
+func (m *toolCircuitBreakerMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	// Short-circuited rejections must not count as outcomes
+	if result, ok := output.(map[string]interface{}); ok {
+		if open, _ := result["circuit_open"].(bool); open {
+			return nil, nil
+		}
+	}
+
+	if callErr != nil {
+		m.service.RecordFailure(call.ToolName, callErr.Error())
+	} else {
+		m.service.RecordSuccess(call.ToolName)
+	}
+	return nil, nil
+}
+
+var enableToolCircuitBreakerOnce sync.Once
+
+// EnableToolCircuitBreaker registers the circuit breaker middleware so
+// repeatedly failing tools fail fast for a cooldown period (first
+// constructor wins)
+func EnableToolCircuitBreaker(repos *repositories.Repositories) {
+	enableToolCircuitBreakerOnce.Do(func() {
+		RegisterToolCallMiddleware(&toolCircuitBreakerMiddleware{service: NewToolCircuitService(repos)})
+	})
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestToolCircuitBreakerTripAndRecover(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	service := NewToolCircuitService(repos)
+
+	clock := time.Now()
+	service.now = func() time.Time { return clock }
+
+	const tool = "__flaky_tool"
+
+	// Failures below the threshold keep the circuit closed
+	service.RecordFailure(tool, "connection timed out")
+	service.RecordFailure(tool, "connection timed out")
+	if allowed, _ := service.CheckCall(tool); !allowed {
+		t.Fatal("expected circuit closed below the failure threshold")
+	}
+
+	// The third consecutive failure opens the circuit
+	service.RecordFailure(tool, "connection timed out")
+	allowed, rejection := service.CheckCall(tool)
+	if allowed {
+		t.Fatal("expected circuit open after 3 consecutive failures")
+	}
+	if !strings.Contains(rejection, tool) || !strings.Contains(rejection, "connection timed out") {
+		t.Errorf("rejection should name the tool and last error, got: %s", rejection)
+	}
+
+	state, err := service.GetState(tool)
+	if err != nil || state == nil {
+		t.Fatalf("expected persisted circuit state, got %v (%v)", state, err)
+	}
+	if state.State != ToolCircuitOpen || state.TotalTrips != 1 {
+		t.Errorf("expected open state with 1 trip, got %s with %d", state.State, state.TotalTrips)
+	}
+
+	// After the cooldown a probe call is allowed (half-open)
+	clock = clock.Add(toolCircuitCooldown + time.Second)
+	if allowed, _ := service.CheckCall(tool); !allowed {
+		t.Fatal("expected probe call allowed after cooldown")
+	}
+
+	// A failing probe re-opens immediately
+	service.RecordFailure(tool, "still down")
+	if allowed, _ := service.CheckCall(tool); allowed {
+		t.Fatal("expected circuit re-opened after failed probe")
+	}
+	state, _ = service.GetState(tool)
+	if state.TotalTrips != 2 {
+		t.Errorf("expected 2 trips after failed probe, got %d", state.TotalTrips)
+	}
+
+	// A successful call closes the circuit and clears the streak
+	clock = clock.Add(toolCircuitCooldown + time.Second)
+	service.RecordSuccess(tool)
+	if state, _ := service.GetState(tool); state != nil {
+		t.Errorf("expected circuit state cleared after success, got %+v", state)
+	}
+	if allowed, _ := service.CheckCall(tool); !allowed {
+		t.Fatal("expected circuit closed after successful call")
+	}
+}
+
+func TestToolCircuitBreakerMiddleware(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	service := NewToolCircuitService(repos)
+	m := &toolCircuitBreakerMiddleware{service: service}
+
+	call := &ToolCall{ToolName: "__dead_tool"}
+	ctx := context.Background()
+
+	// Healthy tool passes through; errors feed the breaker
+	for i := 0; i < toolCircuitFailureThreshold; i++ {
+		if response, err := m.BeforeToolCall(ctx, call); err != nil || response != nil {
+			t.Fatalf("expected call %d to pass, got response=%v err=%v", i, response, err)
+		}
+		if _, err := m.AfterToolCall(ctx, call, nil, context.DeadlineExceeded); err != nil {
+			t.Fatalf("AfterToolCall failed: %v", err)
+		}
+	}
+
+	// The circuit is now open: calls short-circuit with a rejection message
+	response, err := m.BeforeToolCall(ctx, call)
+	if err != nil || response == nil {
+		t.Fatalf("expected short-circuit response, got response=%v err=%v", response, err)
+	}
+	result, ok := response.(map[string]interface{})
+	if !ok || result["circuit_open"] != true {
+		t.Fatalf("expected circuit_open response, got %v", response)
+	}
+
+	// The rejection itself must not count as a call outcome
+	if _, err := m.AfterToolCall(ctx, call, response, nil); err != nil {
+		t.Fatalf("AfterToolCall on rejection failed: %v", err)
+	}
+	state, _ := service.GetState(call.ToolName)
+	if state == nil || state.State != ToolCircuitOpen {
+		t.Errorf("expected circuit still open after rejected call, got %+v", state)
+	}
+
+	// Unrelated tools are unaffected and listed state covers only the tripped one
+	other := &ToolCall{ToolName: "__healthy_tool"}
+	if response, err := m.BeforeToolCall(ctx, other); err != nil || response != nil {
+		t.Fatalf("expected unrelated tool to pass, got response=%v err=%v", response, err)
+	}
+	states, err := service.List()
+	if err != nil || len(states) != 1 || states[0].ToolName != call.ToolName {
+		t.Errorf("expected one tripped circuit for %s, got %v (%v)", call.ToolName, states, err)
+	}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+
+	"station/pkg/models"
+)
+
+// Tool citation support: when a run requires citations, the model is
+// instructed to end its response with a structured citations block mapping
+// each claim to the tool call(s) that support it. The block is then validated
+// against the tool calls that actually executed, so hallucinated evidence
+// (citing a tool that never ran) and unsupported claims are both flagged.
+
+// citationBlockMarker identifies the citations JSON block in a response
+const citationBlockMarker = `"citations"`
+
+// ToolCitation maps one claim in the response to its supporting tool calls
+type ToolCitation struct {
+	Claim     string   `json:"claim"`
+	ToolCalls []string `json:"tool_calls"`
+}
+
+// CitationReport is the result of validating a response's citations against
+// the run's actual tool call list
+type CitationReport struct {
+	Citations []ToolCitation `json:"citations"`
+	// MissingBlock is true when the response carries no citations block at all
+	MissingBlock bool `json:"missing_block"`
+	// UncitedClaims lists claims the model declared without any supporting tool call
+	UncitedClaims []string `json:"uncited_claims,omitempty"`
+	// UnknownTools lists cited tools that never actually executed during the run
+	UnknownTools []string `json:"unknown_tools,omitempty"`
+	// ValidCitations counts claims whose every cited tool actually executed
+	ValidCitations int `json:"valid_citations"`
+}
+
+// Clean reports whether every claim is backed by tool calls that really ran
+func (r *CitationReport) Clean() bool {
+	return !r.MissingBlock && len(r.UncitedClaims) == 0 && len(r.UnknownTools) == 0
+}
+
+// Summary renders a validation footer appended to the final response when
+// citations are incomplete, so problems are visible directly in run output.
+// A clean report produces no footer.
+func (r *CitationReport) Summary() string {
+	if r.Clean() {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("\n\n⚠️ Citation validation:")
+	if r.MissingBlock {
+		builder.WriteString("\n- Response carries no citations block despite citations being required")
+		return builder.String()
+	}
+	for _, claim := range r.UncitedClaims {
+		builder.WriteString("\n- Uncited claim: ")
+		builder.WriteString(claim)
+	}
+	for _, tool := range r.UnknownTools {
+		builder.WriteString("\n- Cited tool never executed: ")
+		builder.WriteString(tool)
+	}
+	return builder.String()
+}
+
+// CitationInstruction is appended to the task when tool citations are
+// required. The fenced JSON block keeps the citations machine-checkable
+// without constraining the rest of the response.
+func CitationInstruction() string {
+	return "\n\nIMPORTANT: End your response with a fenced ```json block containing " +
+		`{"citations": [{"claim": "...", "tool_calls": ["__tool_name"]}]}` +
+		" listing every factual claim you make and the tool call(s) whose output supports it. " +
+		"Use an empty tool_calls array for claims not backed by any tool output."
+}
+
+// ValidateToolCitations parses the citations block from a response and checks
+// each cited tool against the names of the tools that actually executed
+func ValidateToolCitations(response string, executedTools []string) *CitationReport {
+	report := &CitationReport{}
+
+	citations, ok := parseCitationsBlock(response)
+	if !ok {
+		report.MissingBlock = true
+		return report
+	}
+	report.Citations = citations
+
+	executed := make(map[string]bool, len(executedTools))
+	for _, name := range executedTools {
+		executed[name] = true
+	}
+
+	seenUnknown := make(map[string]bool)
+	for _, citation := range citations {
+		if len(citation.ToolCalls) == 0 {
+			report.UncitedClaims = append(report.UncitedClaims, citation.Claim)
+			continue
+		}
+		valid := true
+		for _, toolName := range citation.ToolCalls {
+			if !executed[toolName] {
+				valid = false
+				if !seenUnknown[toolName] {
+					seenUnknown[toolName] = true
+					report.UnknownTools = append(report.UnknownTools, toolName)
+				}
+			}
+		}
+		if valid {
+			report.ValidCitations++
+		}
+	}
+	return report
+}
+
+// ExecutedToolNames extracts the distinct tool names from a run's recorded
+// tool calls for citation validation
+func ExecutedToolNames(toolCalls *models.JSONArray) []string {
+	if toolCalls == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, entry := range *toolCalls {
+		call, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := call["tool_name"].(string)
+		if name == "" {
+			name, _ = call["name"].(string)
+		}
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseCitationsBlock extracts the last fenced JSON block containing a
+// citations list from the response
+func parseCitationsBlock(response string) ([]ToolCitation, bool) {
+	remaining := response
+	var lastBlock string
+	for {
+		start := strings.Index(remaining, "```json")
+		if start == -1 {
+			break
+		}
+		body := remaining[start+len("```json"):]
+		end := strings.Index(body, "```")
+		if end == -1 {
+			break
+		}
+		block := body[:end]
+		if strings.Contains(block, citationBlockMarker) {
+			lastBlock = block
+		}
+		remaining = body[end+3:]
+	}
+	if lastBlock == "" {
+		return nil, false
+	}
+
+	var parsed struct {
+		Citations []ToolCitation `json:"citations"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(lastBlock)), &parsed); err != nil {
+		return nil, false
+	}
+	return parsed.Citations, true
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"station/pkg/models"
+)
+
+const citedResponse = "The bucket is public and SSH is open to the world.\n\n" +
+	"```json\n" +
+	`{"citations": [
+		{"claim": "The bucket is public", "tool_calls": ["__checkov_scan_directory"]},
+		{"claim": "SSH is open to the world", "tool_calls": ["__checkov_scan_directory", "__tflint_directory"]}
+	]}` + "\n```\n"
+
+func TestValidateToolCitationsClean(t *testing.T) {
+	report := ValidateToolCitations(citedResponse, []string{"__checkov_scan_directory", "__tflint_directory"})
+
+	if !report.Clean() {
+		t.Errorf("Expected clean report, got %+v", report)
+	}
+	if report.ValidCitations != 2 {
+		t.Errorf("Expected 2 valid citations, got %d", report.ValidCitations)
+	}
+	if report.Summary() != "" {
+		t.Errorf("Expected no summary for clean report, got %q", report.Summary())
+	}
+}
+
+func TestValidateToolCitationsUnknownTool(t *testing.T) {
+	report := ValidateToolCitations(citedResponse, []string{"__checkov_scan_directory"})
+
+	if report.Clean() {
+		t.Error("Expected report flagged when a cited tool never ran")
+	}
+	if len(report.UnknownTools) != 1 || report.UnknownTools[0] != "__tflint_directory" {
+		t.Errorf("Expected __tflint_directory flagged, got %v", report.UnknownTools)
+	}
+	if report.ValidCitations != 1 {
+		t.Errorf("Expected 1 valid citation, got %d", report.ValidCitations)
+	}
+	if !strings.Contains(report.Summary(), "__tflint_directory") {
+		t.Errorf("Expected summary to name the unknown tool, got %q", report.Summary())
+	}
+}
+
+func TestValidateToolCitationsUncitedClaim(t *testing.T) {
+	response := "Result.\n```json\n" +
+		`{"citations": [{"claim": "The app is insecure", "tool_calls": []}]}` + "\n```"
+	report := ValidateToolCitations(response, []string{"__semgrep_scan"})
+
+	if len(report.UncitedClaims) != 1 || report.UncitedClaims[0] != "The app is insecure" {
+		t.Errorf("Expected uncited claim flagged, got %v", report.UncitedClaims)
+	}
+	if !strings.Contains(report.Summary(), "Uncited claim") {
+		t.Errorf("Expected summary to flag uncited claim, got %q", report.Summary())
+	}
+}
+
+func TestValidateToolCitationsMissingBlock(t *testing.T) {
+	report := ValidateToolCitations("No citations here.", []string{"__semgrep_scan"})
+
+	if !report.MissingBlock {
+		t.Error("Expected MissingBlock for response without citations")
+	}
+	if report.Clean() {
+		t.Error("Expected missing block to make the report not clean")
+	}
+	if !strings.Contains(report.Summary(), "no citations block") {
+		t.Errorf("Unexpected summary: %q", report.Summary())
+	}
+}
+
+func TestValidateToolCitationsIgnoresOtherJSONBlocks(t *testing.T) {
+	response := "```json\n{\"findings\": []}\n```\n\nDone.\n\n```json\n" +
+		`{"citations": [{"claim": "Done", "tool_calls": ["__semgrep_scan"]}]}` + "\n```"
+	report := ValidateToolCitations(response, []string{"__semgrep_scan"})
+
+	if !report.Clean() {
+		t.Errorf("Expected clean report, got %+v", report)
+	}
+}
+
+func TestExecutedToolNames(t *testing.T) {
+	toolCalls := models.JSONArray{
+		map[string]interface{}{"tool_name": "__read_text_file"},
+		map[string]interface{}{"tool_name": "__read_text_file"},
+		map[string]interface{}{"name": "__list_directory"},
+		map[string]interface{}{"status": "ok"},
+	}
+
+	names := ExecutedToolNames(&toolCalls)
+	if len(names) != 2 {
+		t.Fatalf("Expected 2 distinct tool names, got %v", names)
+	}
+	if names[0] != "__read_text_file" || names[1] != "__list_directory" {
+		t.Errorf("Unexpected names: %v", names)
+	}
+
+	if names := ExecutedToolNames(nil); names != nil {
+		t.Errorf("Expected nil for nil tool calls, got %v", names)
+	}
+}
+
+func TestCitationInstructionMentionsFormat(t *testing.T) {
+	instruction := CitationInstruction()
+	if !strings.Contains(instruction, `"citations"`) || !strings.Contains(instruction, "```json") {
+		t.Errorf("Expected instruction to describe the citations block format, got %q", instruction)
+	}
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+// ToolParamRule constrains a single tool parameter. Rules are declared per
+// agent in the .prompt frontmatter and enforced by the MCP client at call
+// time, so a misbehaving prompt cannot escape its intended scope:
+//
+//	tool_constraints:
+//	  __read_text_file:
+//	    path:
+//	      pattern: "/workspace/**"
+//	  __aws_list_instances:
+//	    region:
+//	      equals: "us-east-1"
+//	    limit:
+//	      max: 100
+type ToolParamRule struct {
+	Equals  interface{}   `yaml:"equals,omitempty"`
+	OneOf   []interface{} `yaml:"one_of,omitempty"`
+	Prefix  string        `yaml:"prefix,omitempty"`
+	Pattern string        `yaml:"pattern,omitempty"`
+	Max     *float64      `yaml:"max,omitempty"`
+	Min     *float64      `yaml:"min,omitempty"`
+}
+
+// AgentToolConstraints maps tool name (exact or glob, e.g. "__aws_*") to
+// per-parameter rules
+type AgentToolConstraints map[string]map[string]ToolParamRule
+
+// toolConstraintsFrontmatter extracts the tool_constraints block from an
+// agent's .prompt frontmatter
+type toolConstraintsFrontmatter struct {
+	ToolConstraints AgentToolConstraints `yaml:"tool_constraints"`
+}
+
+// parseToolConstraints extracts tool parameter constraints from a .prompt
+// file's content, returning nil when none are declared
+func parseToolConstraints(content []byte) AgentToolConstraints {
+	parts := strings.Split(string(content), "---")
+	if len(parts) < 3 {
+		return nil
+	}
+
+	var fm toolConstraintsFrontmatter
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(parts[1])), &fm); err != nil {
+		logging.Debug("Failed to parse tool_constraints from dotprompt: %v", err)
+		return nil
+	}
+	if len(fm.ToolConstraints) == 0 {
+		return nil
+	}
+	return fm.ToolConstraints
+}
+
+// rulesForTool returns the parameter rules applying to a tool, matching
+// exact names first and then glob entries like "__aws_*"
+func (c AgentToolConstraints) rulesForTool(toolName string) map[string]ToolParamRule {
+	if rules, ok := c[toolName]; ok {
+		return rules
+	}
+	for pattern, rules := range c {
+		if strings.Contains(pattern, "*") {
+			if ok, err := path.Match(pattern, toolName); err == nil && ok {
+				return rules
+			}
+		}
+	}
+	return nil
+}
+
+// validateToolParamValue checks one argument value against a rule, returning
+// a violation description or "" when the value is allowed. Missing arguments
+// pass — rules constrain values the model actually sends.
+func validateToolParamValue(param string, value interface{}, rule ToolParamRule) string {
+	if value == nil {
+		return ""
+	}
+
+	if rule.Equals != nil && fmt.Sprintf("%v", value) != fmt.Sprintf("%v", rule.Equals) {
+		return fmt.Sprintf("%s must equal %v (got %v)", param, rule.Equals, value)
+	}
+
+	if len(rule.OneOf) > 0 {
+		allowed := false
+		for _, candidate := range rule.OneOf {
+			if fmt.Sprintf("%v", value) == fmt.Sprintf("%v", candidate) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Sprintf("%s must be one of %v (got %v)", param, rule.OneOf, value)
+		}
+	}
+
+	if rule.Prefix != "" || rule.Pattern != "" {
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("%s must be a string (got %T)", param, value)
+		}
+		if rule.Prefix != "" && !strings.HasPrefix(text, rule.Prefix) {
+			return fmt.Sprintf("%s must start with %q (got %q)", param, rule.Prefix, text)
+		}
+		if rule.Pattern != "" && !matchConstraintPattern(rule.Pattern, text) {
+			return fmt.Sprintf("%s must match %q (got %q)", param, rule.Pattern, text)
+		}
+	}
+
+	if rule.Max != nil || rule.Min != nil {
+		number, ok := toFloat(value)
+		if !ok {
+			return fmt.Sprintf("%s must be a number (got %T)", param, value)
+		}
+		if rule.Max != nil && number > *rule.Max {
+			return fmt.Sprintf("%s must be <= %v (got %v)", param, *rule.Max, value)
+		}
+		if rule.Min != nil && number < *rule.Min {
+			return fmt.Sprintf("%s must be >= %v (got %v)", param, *rule.Min, value)
+		}
+	}
+
+	return ""
+}
+
+// matchConstraintPattern matches a value against a glob-style pattern. A
+// trailing "/**" matches the directory itself and everything under it;
+// everything else uses standard path matching.
+func matchConstraintPattern(pattern, value string) bool {
+	if strings.HasSuffix(pattern, "/**") {
+		root := strings.TrimSuffix(pattern, "/**")
+		return value == root || strings.HasPrefix(value, root+"/")
+	}
+	ok, err := path.Match(pattern, value)
+	return err == nil && ok
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// toolConstraintsMiddleware enforces per-agent parameter constraints at tool
+// call time. Violations abort the call with a descriptive error (which the
+// model sees and can react to) and are recorded on the run's debug logs.
+type toolConstraintsMiddleware struct {
+	repos *repositories.Repositories
+	// lookup resolves an agent's constraints; replaceable in tests
+	lookup func(agentID int64) AgentToolConstraints
+}
+
+func newToolConstraintsMiddleware(repos *repositories.Repositories) *toolConstraintsMiddleware {
+	m := &toolConstraintsMiddleware{repos: repos}
+	m.lookup = m.loadFromPromptFile
+	return m
+}
+
+func (m *toolConstraintsMiddleware) Name() string {
+	return "tool-constraints"
+}
+
+// loadFromPromptFile reads the agent's .prompt frontmatter for constraints.
+// Read per call so `stn sync` changes apply without a restart; tool calls are
+// model-paced, so the file read is negligible.
+func (m *toolConstraintsMiddleware) loadFromPromptFile(agentID int64) AgentToolConstraints {
+	agent, err := m.repos.Agents.GetByID(agentID)
+	if err != nil {
+		return nil
+	}
+	env, err := m.repos.Environments.GetByID(agent.EnvironmentID)
+	if err != nil {
+		return nil
+	}
+
+	content, err := os.ReadFile(config.GetAgentPromptPath(env.Name, agent.Name))
+	if err != nil {
+		return nil
+	}
+	return parseToolConstraints(content)
+}
+
+func (m *toolConstraintsMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	if call.AgentID == 0 {
+		return nil, nil
+	}
+
+	constraints := m.lookup(call.AgentID)
+	if constraints == nil {
+		return nil, nil
+	}
+	rules := constraints.rulesForTool(call.ToolName)
+	if rules == nil {
+		return nil, nil
+	}
+
+	for param, rule := range rules {
+		var value interface{}
+		if call.Arguments != nil {
+			value = call.Arguments[param]
+		}
+		if violation := validateToolParamValue(param, value, rule); violation != "" {
+			m.recordViolation(ctx, call, violation)
+			return nil, fmt.Errorf("tool call %s blocked by agent constraints: %s", call.ToolName, violation)
+		}
+	}
+	return nil, nil
+}
+
+func (m *toolConstraintsMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	return nil, nil
+}
+
+// recordViolation stores the blocked call on the run's debug logs so
+// `stn runs inspect -v` shows what was attempted and why it was denied
+func (m *toolConstraintsMiddleware) recordViolation(ctx context.Context, call *ToolCall, violation string) {
+	logging.Info("Tool constraint violation: agent %s (run %d) called %s: %s", call.AgentName, call.RunID, call.ToolName, violation)
+	if call.RunID == 0 {
+		return
+	}
+	logEntry := map[string]interface{}{
+		"level":     "warn",
+		"message":   fmt.Sprintf("Tool constraint violation: %s: %s", call.ToolName, violation),
+		"tool_name": call.ToolName,
+		"violation": violation,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := m.repos.AgentRuns.AppendDebugLog(ctx, call.RunID, logEntry); err != nil {
+		logging.Debug("Failed to record constraint violation for run %d: %v", call.RunID, err)
+	}
+}
+
+var enableToolConstraintsOnce sync.Once
+
+// EnableToolConstraintEnforcement registers the constraints middleware so
+// agent-declared parameter rules are enforced on every tool call (first
+// constructor wins)
+func EnableToolConstraintEnforcement(repos *repositories.Repositories) {
+	enableToolConstraintsOnce.Do(func() {
+		RegisterToolCallMiddleware(newToolConstraintsMiddleware(repos))
+	})
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestParseToolConstraints(t *testing.T) {
+	prompt := `---
+metadata:
+  name: "Scoped Scanner"
+model: gpt-4o-mini
+tool_constraints:
+  __read_text_file:
+    path:
+      pattern: "/workspace/**"
+  __aws_list_instances:
+    region:
+      equals: "us-east-1"
+    limit:
+      max: 100
+---
+
+{{role "system"}}
+You are a scoped scanner.
+`
+	constraints := parseToolConstraints([]byte(prompt))
+	if constraints == nil {
+		t.Fatal("expected constraints parsed from frontmatter")
+	}
+	if constraints["__read_text_file"]["path"].Pattern != "/workspace/**" {
+		t.Errorf("expected path pattern, got %+v", constraints["__read_text_file"])
+	}
+	if *constraints["__aws_list_instances"]["limit"].Max != 100 {
+		t.Errorf("expected limit max 100, got %+v", constraints["__aws_list_instances"])
+	}
+
+	// Prompts without the block return nil
+	if got := parseToolConstraints([]byte("---\nmodel: gpt-4o-mini\n---\nbody")); got != nil {
+		t.Errorf("expected nil constraints, got %+v", got)
+	}
+}
+
+func TestRulesForToolGlobMatch(t *testing.T) {
+	constraints := AgentToolConstraints{
+		"__aws_*":          {"region": {Equals: "us-east-1"}},
+		"__read_text_file": {"path": {Prefix: "/workspace/"}},
+	}
+
+	if rules := constraints.rulesForTool("__read_text_file"); rules == nil || rules["path"].Prefix != "/workspace/" {
+		t.Errorf("expected exact match rules, got %+v", rules)
+	}
+	if rules := constraints.rulesForTool("__aws_list_instances"); rules == nil || rules["region"].Equals != "us-east-1" {
+		t.Errorf("expected glob match rules, got %+v", rules)
+	}
+	if rules := constraints.rulesForTool("__list_directory"); rules != nil {
+		t.Errorf("expected no rules for unconstrained tool, got %+v", rules)
+	}
+}
+
+func TestValidateToolParamValue(t *testing.T) {
+	maxLimit := 100.0
+	tests := []struct {
+		name     string
+		value    interface{}
+		rule     ToolParamRule
+		violates bool
+	}{
+		{"missing value passes", nil, ToolParamRule{Prefix: "/workspace/"}, false},
+		{"prefix allowed", "/workspace/main.go", ToolParamRule{Prefix: "/workspace/"}, false},
+		{"prefix escape blocked", "/etc/passwd", ToolParamRule{Prefix: "/workspace/"}, true},
+		{"glob allowed", "/workspace/src/app.py", ToolParamRule{Pattern: "/workspace/**"}, false},
+		{"glob root itself allowed", "/workspace", ToolParamRule{Pattern: "/workspace/**"}, false},
+		{"glob escape blocked", "/home/user/.ssh/id_rsa", ToolParamRule{Pattern: "/workspace/**"}, true},
+		{"equals allowed", "us-east-1", ToolParamRule{Equals: "us-east-1"}, false},
+		{"equals blocked", "eu-west-1", ToolParamRule{Equals: "us-east-1"}, true},
+		{"one_of allowed", "staging", ToolParamRule{OneOf: []interface{}{"dev", "staging"}}, false},
+		{"one_of blocked", "prod", ToolParamRule{OneOf: []interface{}{"dev", "staging"}}, true},
+		{"max allowed", float64(50), ToolParamRule{Max: &maxLimit}, false},
+		{"max blocked", float64(500), ToolParamRule{Max: &maxLimit}, true},
+		{"non-string for pattern blocked", 42, ToolParamRule{Pattern: "/workspace/**"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violation := validateToolParamValue("param", tt.value, tt.rule)
+			if tt.violates && violation == "" {
+				t.Errorf("expected violation for %v against %+v", tt.value, tt.rule)
+			}
+			if !tt.violates && violation != "" {
+				t.Errorf("unexpected violation: %s", violation)
+			}
+		})
+	}
+}
+
+func TestToolConstraintsMiddlewareBlocksAndRecords(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("constraints-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("constraints-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Scoped Agent", "scoped", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	run, err := repos.AgentRuns.Create(context.Background(), agent.ID, user.ID, "task", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+	runID := run.ID
+
+	m := newToolConstraintsMiddleware(repos)
+	m.lookup = func(agentID int64) AgentToolConstraints {
+		return AgentToolConstraints{
+			"__read_text_file": {"path": {Pattern: "/workspace/**"}},
+		}
+	}
+
+	// In-scope call passes through
+	call := &ToolCall{AgentID: agent.ID, RunID: runID, ToolName: "__read_text_file", Arguments: map[string]interface{}{"path": "/workspace/main.go"}}
+	if _, err := m.BeforeToolCall(context.Background(), call); err != nil {
+		t.Fatalf("expected in-scope call allowed, got %v", err)
+	}
+
+	// Escape attempt is blocked and recorded on the run's debug logs
+	call.Arguments["path"] = "/etc/passwd"
+	if _, err := m.BeforeToolCall(context.Background(), call); err == nil {
+		t.Fatal("expected out-of-scope call blocked")
+	}
+
+	run, err = repos.AgentRuns.GetByID(context.Background(), runID)
+	if err != nil {
+		t.Fatalf("failed to load run: %v", err)
+	}
+	if run.DebugLogs == nil || len(*run.DebugLogs) == 0 {
+		t.Fatal("expected violation recorded in debug logs")
+	}
+	entry, ok := (*run.DebugLogs)[0].(map[string]interface{})
+	if !ok || !strings.Contains(entry["message"].(string), "constraint violation") {
+		t.Errorf("expected constraint violation log entry, got %+v", (*run.DebugLogs)[0])
+	}
+
+	// Tools without rules are unaffected
+	other := &ToolCall{AgentID: agent.ID, RunID: runID, ToolName: "__list_directory", Arguments: map[string]interface{}{"path": "/etc"}}
+	if _, err := m.BeforeToolCall(context.Background(), other); err != nil {
+		t.Errorf("expected unconstrained tool allowed, got %v", err)
+	}
+}
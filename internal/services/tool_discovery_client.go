@@ -247,6 +247,50 @@ func (c *MCPClient) DiscoverToolsFromServer(serverConfig models.MCPServerConfig)
 	return toolsResult.Tools, nil
 }
 
+// MCPServerInfo is the identity a server reports during the MCP initialize handshake
+type MCPServerInfo struct {
+	Name    string
+	Version string
+}
+
+// ProbeServerInfo connects to a server just long enough to run the MCP
+// initialize handshake and returns the serverInfo it reports. Used during
+// sync to record server versions and detect upgrades across syncs.
+func (c *MCPClient) ProbeServerInfo(ctx context.Context, serverConfigRaw interface{}) (*MCPServerInfo, error) {
+	serverConfig, ok := serverConfigRaw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid server config format")
+	}
+
+	mcpTransport, err := c.createTransportFromConfig(serverConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transport: %w", err)
+	}
+
+	mcpClient := client.NewClient(mcpTransport)
+	if err := mcpClient.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start client: %w", err)
+	}
+	defer func() { _ = mcpClient.Close() }()
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    "Station Tool Discovery",
+		Version: "1.0.0",
+	}
+
+	result, err := mcpClient.Initialize(ctx, initRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	return &MCPServerInfo{
+		Name:    result.ServerInfo.Name,
+		Version: result.ServerInfo.Version,
+	}, nil
+}
+
 // createTransportFromConfig creates transport from generic config map (for rendered configs)
 func (c *MCPClient) createTransportFromConfig(serverConfig map[string]interface{}) (transport.Interface, error) {
 	// Extract fields from generic config map
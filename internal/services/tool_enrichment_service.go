@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/genkit"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+// maxEnrichedDescriptionLength bounds what the model can store so a runaway
+// response never bloats tool listings
+const maxEnrichedDescriptionLength = 1200
+
+// ToolEnrichmentService expands terse MCP tool descriptions with the
+// configured model during sync. Enriched descriptions are stored in their
+// own column so the server-provided original is always preserved; tools are
+// only enriched once and re-enriched when discovery replaces them.
+type ToolEnrichmentService struct {
+	repos          *repositories.Repositories
+	genkitProvider *GenKitProvider
+}
+
+// NewToolEnrichmentService creates a tool enrichment service
+func NewToolEnrichmentService(repos *repositories.Repositories) *ToolEnrichmentService {
+	return &ToolEnrichmentService{
+		repos:          repos,
+		genkitProvider: NewGenKitProvider(),
+	}
+}
+
+// EnrichEnvironmentTools expands descriptions for every tool in the
+// environment that has not been enriched yet. Returns how many tools were
+// enriched; individual tool failures are skipped so one bad response does
+// not abort the pass.
+func (s *ToolEnrichmentService) EnrichEnvironmentTools(ctx context.Context, environmentID int64) (int, error) {
+	tools, err := s.repos.MCPTools.ListUnenrichedByEnvironment(environmentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tools for enrichment: %w", err)
+	}
+	if len(tools) == 0 {
+		return 0, nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load Station config: %w", err)
+	}
+
+	genkitApp, err := s.genkitProvider.GetApp(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to initialize GenKit: %w", err)
+	}
+
+	modelName := cfg.AIModel
+	if !strings.Contains(modelName, "/") {
+		provider := strings.ToLower(cfg.AIProvider)
+		if provider == "gemini" {
+			provider = "googleai"
+		}
+		modelName = fmt.Sprintf("%s/%s", provider, modelName)
+	}
+
+	enriched := 0
+	for _, tool := range tools {
+		response, err := genkit.Generate(ctx, genkitApp,
+			ai.WithPrompt(buildEnrichmentPrompt(tool)),
+			ai.WithModelName(modelName))
+		if err != nil {
+			fmt.Printf("   ⚠️  Failed to enrich tool %s: %v\n", tool.Name, err)
+			continue
+		}
+
+		description := cleanEnrichedDescription(response.Text())
+		if description == "" {
+			fmt.Printf("   ⚠️  Model returned empty enrichment for tool %s\n", tool.Name)
+			continue
+		}
+
+		if err := s.repos.MCPTools.UpdateEnrichedDescription(tool.ID, description); err != nil {
+			fmt.Printf("   ⚠️  Failed to store enrichment for tool %s: %v\n", tool.Name, err)
+			continue
+		}
+		enriched++
+	}
+
+	return enriched, nil
+}
+
+// buildEnrichmentPrompt asks the model to expand a tool's description with
+// behavior details and parameter examples grounded in its input schema
+func buildEnrichmentPrompt(tool *models.MCPTool) string {
+	var b strings.Builder
+
+	b.WriteString("You are improving MCP tool descriptions so AI agents pick the right tool.\n\n")
+	fmt.Fprintf(&b, "**Tool name:** %s\n", tool.Name)
+	fmt.Fprintf(&b, "**Current description:** %s\n", tool.Description)
+	if len(tool.Schema) > 0 && string(tool.Schema) != "null" {
+		fmt.Fprintf(&b, "**Input schema:**\n%s\n", string(tool.Schema))
+	}
+
+	b.WriteString(`
+**Instructions:**
+Write an expanded description (2-4 sentences) explaining what the tool does, when an agent should choose it over similar tools, and any important constraints. Then add a short "Example:" line with realistic parameter values drawn from the input schema.
+Do not invent parameters that are not in the schema. Output plain text only - no markdown headings, no JSON.`)
+
+	return b.String()
+}
+
+// cleanEnrichedDescription normalizes the model output before storage
+func cleanEnrichedDescription(response string) string {
+	cleaned := strings.TrimSpace(stripMarkdownCodeBlocks(response))
+	if len(cleaned) > maxEnrichedDescriptionLength {
+		cleaned = cleaned[:maxEnrichedDescriptionLength]
+	}
+	return cleaned
+}
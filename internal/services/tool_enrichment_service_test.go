@@ -0,0 +1,127 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+func setupEnrichmentTest(t *testing.T) (*repositories.Repositories, int64, int64) {
+	t.Helper()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("enrich-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	server, err := repos.MCPServers.Create(&models.MCPServer{
+		Name:          "filesystem",
+		Command:       "npx",
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create MCP server: %v", err)
+	}
+
+	return repos, env.ID, server
+}
+
+func TestEnrichedDescriptionRoundTrip(t *testing.T) {
+	repos, envID, serverID := setupEnrichmentTest(t)
+
+	toolID, err := repos.MCPTools.Create(&models.MCPTool{
+		MCPServerID: serverID,
+		Name:        "__read_text_file",
+		Description: "Read file",
+	})
+	if err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+
+	unenriched, err := repos.MCPTools.ListUnenrichedByEnvironment(envID)
+	if err != nil {
+		t.Fatalf("ListUnenrichedByEnvironment failed: %v", err)
+	}
+	if len(unenriched) != 1 || unenriched[0].Name != "__read_text_file" {
+		t.Fatalf("Expected the new tool to need enrichment, got %v", unenriched)
+	}
+
+	if err := repos.MCPTools.UpdateEnrichedDescription(toolID, "Reads the full contents of a UTF-8 text file at a given path. Example: path=\"/etc/hosts\""); err != nil {
+		t.Fatalf("UpdateEnrichedDescription failed: %v", err)
+	}
+
+	enriched, err := repos.MCPTools.GetEnrichedDescription(toolID)
+	if err != nil {
+		t.Fatalf("GetEnrichedDescription failed: %v", err)
+	}
+	if !strings.Contains(enriched, "UTF-8 text file") {
+		t.Errorf("Unexpected enriched description: %q", enriched)
+	}
+
+	// Original description is preserved
+	tool, err := repos.MCPTools.GetByID(toolID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tool.Description != "Read file" {
+		t.Errorf("Expected original description preserved, got %q", tool.Description)
+	}
+
+	// The tool no longer needs enrichment
+	unenriched, err = repos.MCPTools.ListUnenrichedByEnvironment(envID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unenriched) != 0 {
+		t.Errorf("Expected no tools needing enrichment, got %d", len(unenriched))
+	}
+}
+
+func TestBuildEnrichmentPrompt(t *testing.T) {
+	tool := &models.MCPTool{
+		Name:        "__search_files",
+		Description: "Search",
+		Schema:      json.RawMessage(`{"type": "object", "properties": {"pattern": {"type": "string"}}}`),
+	}
+
+	prompt := buildEnrichmentPrompt(tool)
+	if !strings.Contains(prompt, "__search_files") {
+		t.Error("Expected prompt to include the tool name")
+	}
+	if !strings.Contains(prompt, `"pattern"`) {
+		t.Error("Expected prompt to include the input schema")
+	}
+	if !strings.Contains(prompt, "Example:") {
+		t.Error("Expected prompt to ask for a parameter example")
+	}
+}
+
+func TestBuildEnrichmentPromptWithoutSchema(t *testing.T) {
+	prompt := buildEnrichmentPrompt(&models.MCPTool{Name: "__ping", Description: "Ping"})
+	if strings.Contains(prompt, "Input schema") {
+		t.Error("Expected no schema section for schemaless tools")
+	}
+}
+
+func TestCleanEnrichedDescription(t *testing.T) {
+	if got := cleanEnrichedDescription("```\nExpanded text\n```"); got != "Expanded text" {
+		t.Errorf("Expected code fences stripped, got %q", got)
+	}
+	if got := cleanEnrichedDescription("   \n  "); got != "" {
+		t.Errorf("Expected whitespace-only response to clean to empty, got %q", got)
+	}
+	long := strings.Repeat("a", maxEnrichedDescriptionLength+100)
+	if got := cleanEnrichedDescription(long); len(got) != maxEnrichedDescriptionLength {
+		t.Errorf("Expected truncation to %d chars, got %d", maxEnrichedDescriptionLength, len(got))
+	}
+}
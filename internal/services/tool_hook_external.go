@@ -0,0 +1,235 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"sync"
+	"time"
+
+	"station/internal/config"
+	"station/internal/logging"
+)
+
+// externalToolHook adapts a config-declared external hook (stdio command or
+// HTTP endpoint) to the ToolCallMiddleware interface. The hook receives a
+// JSON payload for every tool call and responds with an action:
+//
+//	{"action": "continue", "arguments": {...}}  - proceed (optionally with mutated arguments)
+//	{"action": "respond", "response": ...}      - short-circuit with the given response
+//	{"action": "deny", "message": "..."}        - abort the tool call with an error
+//	{"annotations": {...}}                      - attach metadata to the tool result
+//
+// Hook transport failures are logged and treated as "continue" so a broken
+// hook cannot take down agent execution; use "deny" for enforced policy.
+type externalToolHook struct {
+	cfg     config.ToolHookConfig
+	timeout time.Duration
+}
+
+// toolHookPayload is the JSON document sent to the external hook
+type toolHookPayload struct {
+	Event     string                 `json:"event"` // "before" or "after"
+	Hook      string                 `json:"hook"`
+	AgentID   int64                  `json:"agent_id"`
+	AgentName string                 `json:"agent_name"`
+	RunID     int64                  `json:"run_id"`
+	ToolName  string                 `json:"tool_name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Output    interface{}            `json:"output,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+}
+
+// toolHookResponse is the JSON document expected back from the external hook
+type toolHookResponse struct {
+	Action      string                 `json:"action"`
+	Arguments   map[string]interface{} `json:"arguments,omitempty"`
+	Response    interface{}            `json:"response,omitempty"`
+	Message     string                 `json:"message,omitempty"`
+	Annotations map[string]interface{} `json:"annotations,omitempty"`
+}
+
+var registerExternalToolHooksOnce sync.Once
+
+// RegisterExternalToolHooks registers config-declared external hooks as tool
+// call middlewares. Safe to call from multiple engine constructors; only the
+// first call takes effect.
+func RegisterExternalToolHooks(hooks []config.ToolHookConfig) {
+	registerExternalToolHooksOnce.Do(func() {
+		for _, hookCfg := range hooks {
+			hook, err := newExternalToolHook(hookCfg)
+			if err != nil {
+				logging.Info("Skipping tool hook %s: %v", hookCfg.Name, err)
+				continue
+			}
+			RegisterToolCallMiddleware(hook)
+		}
+	})
+}
+
+func newExternalToolHook(cfg config.ToolHookConfig) (*externalToolHook, error) {
+	switch cfg.Type {
+	case "stdio":
+		if cfg.Command == "" {
+			return nil, fmt.Errorf("stdio tool hook requires a command")
+		}
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("http tool hook requires a url")
+		}
+	default:
+		return nil, fmt.Errorf("unknown tool hook type %q (expected stdio or http)", cfg.Type)
+	}
+
+	timeout := 10 * time.Second
+	if cfg.Timeout != "" {
+		parsed, err := time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tool hook timeout %q: %w", cfg.Timeout, err)
+		}
+		timeout = parsed
+	}
+
+	return &externalToolHook{cfg: cfg, timeout: timeout}, nil
+}
+
+func (h *externalToolHook) Name() string {
+	return h.cfg.Name
+}
+
+func (h *externalToolHook) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	resp := h.invoke(ctx, toolHookPayload{
+		Event:     "before",
+		Hook:      h.cfg.Name,
+		AgentID:   call.AgentID,
+		AgentName: call.AgentName,
+		RunID:     call.RunID,
+		ToolName:  call.ToolName,
+		Arguments: call.Arguments,
+	})
+	if resp == nil {
+		return nil, nil
+	}
+
+	for key, value := range resp.Annotations {
+		call.Annotations[key] = value
+	}
+
+	switch resp.Action {
+	case "deny":
+		message := resp.Message
+		if message == "" {
+			message = "tool call denied by hook"
+		}
+		return nil, fmt.Errorf("tool call %s denied by hook %s: %s", call.ToolName, h.cfg.Name, message)
+	case "respond":
+		return resp.Response, nil
+	default:
+		if resp.Arguments != nil {
+			call.Arguments = resp.Arguments
+		}
+		return nil, nil
+	}
+}
+
+func (h *externalToolHook) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	payload := toolHookPayload{
+		Event:     "after",
+		Hook:      h.cfg.Name,
+		AgentID:   call.AgentID,
+		AgentName: call.AgentName,
+		RunID:     call.RunID,
+		ToolName:  call.ToolName,
+		Arguments: call.Arguments,
+		Output:    output,
+	}
+	if callErr != nil {
+		payload.Error = callErr.Error()
+	}
+
+	resp := h.invoke(ctx, payload)
+	if resp == nil {
+		return nil, nil
+	}
+
+	for key, value := range resp.Annotations {
+		call.Annotations[key] = value
+	}
+	return resp.Response, nil
+}
+
+// invoke sends the payload to the external hook and parses its response.
+// Returns nil (meaning "continue unchanged") on any transport failure.
+func (h *externalToolHook) invoke(ctx context.Context, payload toolHookPayload) *toolHookResponse {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logging.Debug("Tool hook %s: failed to marshal payload: %v", h.cfg.Name, err)
+		return nil
+	}
+
+	hookCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	var raw []byte
+	switch h.cfg.Type {
+	case "stdio":
+		raw, err = h.invokeStdio(hookCtx, body)
+	case "http":
+		raw, err = h.invokeHTTP(hookCtx, body)
+	}
+	if err != nil {
+		logging.Debug("Tool hook %s (%s) failed: %v", h.cfg.Name, payload.Event, err)
+		return nil
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+
+	var resp toolHookResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		logging.Debug("Tool hook %s returned invalid JSON: %v", h.cfg.Name, err)
+		return nil
+	}
+	return &resp
+}
+
+// invokeStdio runs the hook command, writing the payload to stdin and
+// reading the response from stdout
+func (h *externalToolHook) invokeStdio(ctx context.Context, payload []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, h.cfg.Command, h.cfg.Args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// invokeHTTP POSTs the payload to the hook endpoint
+func (h *externalToolHook) invokeHTTP(ctx context.Context, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.cfg.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("hook returned HTTP %d", resp.StatusCode)
+	}
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"station/internal/config"
+	"station/internal/db/repositories"
+	"station/internal/logging"
+)
+
+// Agents opt into tool-call memoization in their .prompt frontmatter:
+//
+//	memoize_tools: true
+//
+// When enabled, identical tool+arguments calls within one run reuse the
+// prior result instead of re-executing, saving time and tokens on agents
+// that loop over the same reads. Each reuse is recorded on the run's debug
+// logs and annotated on the tool output so the behavior is transparent.
+const (
+	// Maximum cached results per run; beyond this, new calls execute normally
+	memoizationMaxEntriesPerRun = 200
+	// Maximum concurrently tracked runs; the oldest run's cache is evicted
+	// when exceeded (runs have no explicit end hook in the middleware chain)
+	memoizationMaxTrackedRuns = 32
+)
+
+// memoizeToolsFrontmatter extracts the memoize_tools flag from an agent's
+// .prompt frontmatter
+type memoizeToolsFrontmatter struct {
+	MemoizeTools bool `yaml:"memoize_tools"`
+}
+
+// parseMemoizeTools reads the memoize_tools flag from a .prompt file's content
+func parseMemoizeTools(content []byte) bool {
+	parts := strings.Split(string(content), "---")
+	if len(parts) < 3 {
+		return false
+	}
+
+	var fm memoizeToolsFrontmatter
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(parts[1])), &fm); err != nil {
+		logging.Debug("Failed to parse memoize_tools from dotprompt: %v", err)
+		return false
+	}
+	return fm.MemoizeTools
+}
+
+// memoizationFingerprint derives the cache key for a tool call from the tool
+// name and canonical JSON of its arguments (map keys marshal sorted)
+func memoizationFingerprint(toolName string, arguments map[string]interface{}) string {
+	encoded, err := json.Marshal(arguments)
+	if err != nil {
+		// Unencodable arguments cannot be compared reliably; a unique key
+		// guarantees a cache miss
+		return fmt.Sprintf("%s|unencodable|%d", toolName, time.Now().UnixNano())
+	}
+	return toolName + "|" + string(encoded)
+}
+
+// toolMemoizationMiddleware reuses results of identical tool calls within a
+// run for agents that opted in via memoize_tools frontmatter
+type toolMemoizationMiddleware struct {
+	repos *repositories.Repositories
+	// lookup resolves whether an agent opted in; replaceable in tests
+	lookup func(agentID int64) bool
+
+	mu       sync.Mutex
+	caches   map[int64]map[string]interface{}
+	runOrder []int64
+}
+
+func newToolMemoizationMiddleware(repos *repositories.Repositories) *toolMemoizationMiddleware {
+	m := &toolMemoizationMiddleware{
+		repos:  repos,
+		caches: make(map[int64]map[string]interface{}),
+	}
+	m.lookup = m.loadFromPromptFile
+	return m
+}
+
+func (m *toolMemoizationMiddleware) Name() string {
+	return "tool-memoization"
+}
+
+// loadFromPromptFile reads the agent's .prompt frontmatter for the opt-in
+// flag. Read per call so `stn sync` changes apply without a restart.
+func (m *toolMemoizationMiddleware) loadFromPromptFile(agentID int64) bool {
+	agent, err := m.repos.Agents.GetByID(agentID)
+	if err != nil {
+		return false
+	}
+	env, err := m.repos.Environments.GetByID(agent.EnvironmentID)
+	if err != nil {
+		return false
+	}
+
+	content, err := os.ReadFile(config.GetAgentPromptPath(env.Name, agent.Name))
+	if err != nil {
+		return false
+	}
+	return parseMemoizeTools(content)
+}
+
+func (m *toolMemoizationMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	if call.RunID == 0 || call.AgentID == 0 || !m.lookup(call.AgentID) {
+		return nil, nil
+	}
+
+	fingerprint := memoizationFingerprint(call.ToolName, call.Arguments)
+
+	m.mu.Lock()
+	cached, hit := m.caches[call.RunID][fingerprint]
+	m.mu.Unlock()
+	if !hit {
+		return nil, nil
+	}
+
+	call.Annotations["memoized"] = true
+	m.recordReuse(ctx, call)
+	return cached, nil
+}
+
+func (m *toolMemoizationMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	// Only successful, non-memoized results are cached — errors may be
+	// transient and reused hits are already in the cache
+	if call.RunID == 0 || call.AgentID == 0 || callErr != nil || output == nil {
+		return nil, nil
+	}
+	if _, reused := call.Annotations["memoized"]; reused {
+		return nil, nil
+	}
+	if !m.lookup(call.AgentID) {
+		return nil, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cache, exists := m.caches[call.RunID]
+	if !exists {
+		// Evict the oldest tracked run's cache when at capacity
+		if len(m.runOrder) >= memoizationMaxTrackedRuns {
+			delete(m.caches, m.runOrder[0])
+			m.runOrder = m.runOrder[1:]
+		}
+		cache = make(map[string]interface{})
+		m.caches[call.RunID] = cache
+		m.runOrder = append(m.runOrder, call.RunID)
+	}
+	if len(cache) >= memoizationMaxEntriesPerRun {
+		return nil, nil
+	}
+
+	cache[memoizationFingerprint(call.ToolName, call.Arguments)] = output
+	return nil, nil
+}
+
+// recordReuse stores the memoization event on the run's debug logs so
+// `stn runs inspect -v` shows which calls were served from cache
+func (m *toolMemoizationMiddleware) recordReuse(ctx context.Context, call *ToolCall) {
+	logging.Debug("Memoized tool call reused: agent %s (run %d) called %s", call.AgentName, call.RunID, call.ToolName)
+	logEntry := map[string]interface{}{
+		"level":     "info",
+		"message":   fmt.Sprintf("Tool call memoized: %s reused the result of an identical earlier call in this run", call.ToolName),
+		"tool_name": call.ToolName,
+		"memoized":  true,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := m.repos.AgentRuns.AppendDebugLog(ctx, call.RunID, logEntry); err != nil {
+		logging.Debug("Failed to record memoization event for run %d: %v", call.RunID, err)
+	}
+}
+
+var enableToolMemoizationOnce sync.Once
+
+// EnableToolMemoization registers the memoization middleware so agents that
+// opt in via memoize_tools reuse identical tool call results within a run
+// (first constructor wins)
+func EnableToolMemoization(repos *repositories.Repositories) {
+	enableToolMemoizationOnce.Do(func() {
+		RegisterToolCallMiddleware(newToolMemoizationMiddleware(repos))
+	})
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func TestParseMemoizeTools(t *testing.T) {
+	prompt := `---
+metadata:
+  name: "Looping Scanner"
+model: gpt-4o-mini
+memoize_tools: true
+---
+
+{{role "system"}}
+You are a looping scanner.
+`
+	if !parseMemoizeTools([]byte(prompt)) {
+		t.Error("expected memoize_tools parsed from frontmatter")
+	}
+	if parseMemoizeTools([]byte("---\nmodel: gpt-4o-mini\n---\nbody")) {
+		t.Error("expected false when the flag is absent")
+	}
+}
+
+func TestMemoizationFingerprint(t *testing.T) {
+	a := memoizationFingerprint("__read_text_file", map[string]interface{}{"path": "/workspace/main.go"})
+	b := memoizationFingerprint("__read_text_file", map[string]interface{}{"path": "/workspace/main.go"})
+	if a != b {
+		t.Error("identical calls should produce identical fingerprints")
+	}
+
+	other := memoizationFingerprint("__read_text_file", map[string]interface{}{"path": "/workspace/other.go"})
+	if a == other {
+		t.Error("different arguments should produce different fingerprints")
+	}
+	if a == memoizationFingerprint("__get_file_info", map[string]interface{}{"path": "/workspace/main.go"}) {
+		t.Error("different tools should produce different fingerprints")
+	}
+}
+
+func TestToolMemoizationMiddlewareReusesResults(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+
+	env, err := repos.Environments.Create("memoize-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+	user, err := repos.Users.Create("memoize-user", "ssh-key", false, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	agent, err := repos.Agents.Create("Memoizing Agent", "memoizes", "prompt", 5, env.ID, user.ID,
+		nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	run, err := repos.AgentRuns.Create(context.Background(), agent.ID, user.ID, "task", "", 0, nil, nil, "running", nil)
+	if err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+
+	m := newToolMemoizationMiddleware(repos)
+	m.lookup = func(agentID int64) bool { return true }
+
+	args := map[string]interface{}{"path": "/workspace/main.go"}
+	first := &ToolCall{AgentID: agent.ID, RunID: run.ID, ToolName: "__read_text_file", Arguments: args, Annotations: map[string]interface{}{}}
+
+	// First call misses the cache and its result is stored afterwards
+	if response, err := m.BeforeToolCall(context.Background(), first); err != nil || response != nil {
+		t.Fatalf("expected cache miss on first call, got %v / %v", response, err)
+	}
+	if _, err := m.AfterToolCall(context.Background(), first, "file contents", nil); err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+
+	// Identical second call is served from the cache and annotated
+	second := &ToolCall{AgentID: agent.ID, RunID: run.ID, ToolName: "__read_text_file", Arguments: args, Annotations: map[string]interface{}{}}
+	response, err := m.BeforeToolCall(context.Background(), second)
+	if err != nil {
+		t.Fatalf("BeforeToolCall failed: %v", err)
+	}
+	if response != "file contents" {
+		t.Errorf("expected cached result reused, got %v", response)
+	}
+	if memoized, _ := second.Annotations["memoized"].(bool); !memoized {
+		t.Error("expected memoized annotation on reused call")
+	}
+
+	// The reuse is recorded on the run's debug logs
+	updated, err := repos.AgentRuns.GetByID(context.Background(), run.ID)
+	if err != nil {
+		t.Fatalf("failed to load run: %v", err)
+	}
+	if updated.DebugLogs == nil || len(*updated.DebugLogs) == 0 {
+		t.Fatal("expected memoization event in debug logs")
+	}
+	entry, ok := (*updated.DebugLogs)[0].(map[string]interface{})
+	if !ok || !strings.Contains(entry["message"].(string), "memoized") {
+		t.Errorf("expected memoization log entry, got %+v", (*updated.DebugLogs)[0])
+	}
+
+	// Different arguments miss the cache
+	different := &ToolCall{AgentID: agent.ID, RunID: run.ID, ToolName: "__read_text_file",
+		Arguments: map[string]interface{}{"path": "/workspace/other.go"}, Annotations: map[string]interface{}{}}
+	if response, err := m.BeforeToolCall(context.Background(), different); err != nil || response != nil {
+		t.Errorf("expected cache miss for different arguments, got %v / %v", response, err)
+	}
+
+	// A different run does not see this run's cache
+	otherRun := &ToolCall{AgentID: agent.ID, RunID: run.ID + 1, ToolName: "__read_text_file", Arguments: args, Annotations: map[string]interface{}{}}
+	if response, err := m.BeforeToolCall(context.Background(), otherRun); err != nil || response != nil {
+		t.Errorf("expected cache scoped per run, got %v / %v", response, err)
+	}
+}
+
+func TestToolMemoizationSkipsErrorsAndOptOuts(t *testing.T) {
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	m := newToolMemoizationMiddleware(repos)
+
+	args := map[string]interface{}{"path": "/workspace/main.go"}
+
+	// Failed calls are not cached
+	m.lookup = func(agentID int64) bool { return true }
+	failed := &ToolCall{AgentID: 1, RunID: 7, ToolName: "__read_text_file", Arguments: args, Annotations: map[string]interface{}{}}
+	if _, err := m.AfterToolCall(context.Background(), failed, nil, context.DeadlineExceeded); err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+	retry := &ToolCall{AgentID: 1, RunID: 7, ToolName: "__read_text_file", Arguments: args, Annotations: map[string]interface{}{}}
+	if response, _ := m.BeforeToolCall(context.Background(), retry); response != nil {
+		t.Error("failed calls must not be served from cache")
+	}
+
+	// Agents that did not opt in are untouched
+	m.lookup = func(agentID int64) bool { return false }
+	optedOut := &ToolCall{AgentID: 2, RunID: 8, ToolName: "__read_text_file", Arguments: args, Annotations: map[string]interface{}{}}
+	if _, err := m.AfterToolCall(context.Background(), optedOut, "result", nil); err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+	if response, _ := m.BeforeToolCall(context.Background(), optedOut); response != nil {
+		t.Error("opted-out agents must not use the cache")
+	}
+}
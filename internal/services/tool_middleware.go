@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/firebase/genkit/go/ai"
+
+	"station/internal/logging"
+	"station/pkg/models"
+)
+
+// ToolCall describes a single MCP tool invocation flowing through the
+// middleware chain during agent execution. BeforeToolCall hooks may mutate
+// Arguments in place; Annotations collects metadata attached by hooks and is
+// surfaced alongside the tool output when non-empty.
+type ToolCall struct {
+	AgentID     int64
+	AgentName   string
+	RunID       int64
+	ToolName    string
+	Arguments   map[string]interface{}
+	Annotations map[string]interface{}
+}
+
+// ToolCallMiddleware is the registration point for intercepting MCP tool
+// calls during agent execution. Middlewares run in registration order before
+// the tool executes and in reverse order afterwards, enabling custom policy,
+// caching, and audit integrations without forking.
+type ToolCallMiddleware interface {
+	// Name identifies the middleware in logs
+	Name() string
+	// BeforeToolCall runs before the tool executes. It may mutate
+	// call.Arguments. Returning a non-nil response short-circuits the tool
+	// call and uses the response as the tool output. Returning an error
+	// aborts the tool call.
+	BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error)
+	// AfterToolCall runs after the tool executes (or short-circuits). It may
+	// return a replacement output; returning nil keeps the current output.
+	AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error)
+}
+
+var (
+	toolMiddlewareMu sync.RWMutex
+	toolMiddlewares  []ToolCallMiddleware
+)
+
+// RegisterToolCallMiddleware registers a middleware that is invoked around
+// every MCP tool call for all subsequently started agent executions.
+func RegisterToolCallMiddleware(m ToolCallMiddleware) {
+	toolMiddlewareMu.Lock()
+	defer toolMiddlewareMu.Unlock()
+	toolMiddlewares = append(toolMiddlewares, m)
+	logging.Info("Registered tool call middleware: %s", m.Name())
+}
+
+// registeredToolMiddlewares returns a snapshot of the middleware chain
+func registeredToolMiddlewares() []ToolCallMiddleware {
+	toolMiddlewareMu.RLock()
+	defer toolMiddlewareMu.RUnlock()
+	snapshot := make([]ToolCallMiddleware, len(toolMiddlewares))
+	copy(snapshot, toolMiddlewares)
+	return snapshot
+}
+
+// resetToolCallMiddlewares clears the chain (test helper)
+func resetToolCallMiddlewares() {
+	toolMiddlewareMu.Lock()
+	defer toolMiddlewareMu.Unlock()
+	toolMiddlewares = nil
+}
+
+// wrapToolsWithMiddleware wraps each tool so registered middlewares run
+// before and after every invocation. Returns the tools unchanged when no
+// middleware is registered.
+func wrapToolsWithMiddleware(tools []ai.ToolRef, agent *models.Agent, runID int64) []ai.ToolRef {
+	middlewares := registeredToolMiddlewares()
+	if len(middlewares) == 0 {
+		return tools
+	}
+
+	wrapped := make([]ai.ToolRef, 0, len(tools))
+	for _, ref := range tools {
+		if tool, ok := ref.(ai.Tool); ok {
+			wrapped = append(wrapped, wrapToolWithMiddleware(tool, middlewares, agent, runID))
+		} else {
+			wrapped = append(wrapped, ref)
+		}
+	}
+	return wrapped
+}
+
+// wrapToolWithMiddleware creates a replacement tool with the same name and
+// schema that routes the invocation through the middleware chain.
+func wrapToolWithMiddleware(inner ai.Tool, middlewares []ToolCallMiddleware, agent *models.Agent, runID int64) ai.Tool {
+	def := inner.Definition()
+	return ai.NewToolWithInputSchema[any](def.Name, def.Description, def.InputSchema,
+		func(toolCtx *ai.ToolContext, input any) (any, error) {
+			call := &ToolCall{
+				ToolName:    def.Name,
+				RunID:       runID,
+				Annotations: make(map[string]interface{}),
+			}
+			if agent != nil {
+				call.AgentID = agent.ID
+				call.AgentName = agent.Name
+			}
+			if args, ok := input.(map[string]interface{}); ok {
+				call.Arguments = args
+			}
+
+			var output interface{}
+			var callErr error
+			shortCircuited := false
+
+			// Before hooks in registration order; first short-circuit wins
+			ranBefore := 0
+			for _, m := range middlewares {
+				ranBefore++
+				response, err := m.BeforeToolCall(toolCtx, call)
+				if err != nil {
+					return nil, err
+				}
+				if response != nil {
+					output = response
+					shortCircuited = true
+					break
+				}
+			}
+
+			if !shortCircuited {
+				toolInput := input
+				if call.Arguments != nil {
+					toolInput = call.Arguments
+				}
+				output, callErr = inner.RunRaw(toolCtx, toolInput)
+			}
+
+			// After hooks in reverse order, limited to middlewares whose
+			// Before hook ran
+			for i := ranBefore - 1; i >= 0; i-- {
+				replacement, err := middlewares[i].AfterToolCall(toolCtx, call, output, callErr)
+				if err != nil {
+					logging.Debug("Tool middleware %s AfterToolCall failed for %s: %v", middlewares[i].Name(), def.Name, err)
+					continue
+				}
+				if replacement != nil {
+					output = replacement
+				}
+			}
+
+			if callErr != nil {
+				return nil, callErr
+			}
+
+			// Surface hook annotations alongside the tool output
+			if len(call.Annotations) > 0 {
+				return map[string]interface{}{
+					"result":      output,
+					"annotations": call.Annotations,
+				}, nil
+			}
+			return output, nil
+		})
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/firebase/genkit/go/ai"
+
+	"station/pkg/models"
+)
+
+// recordingMiddleware is a configurable ToolCallMiddleware for tests
+type recordingMiddleware struct {
+	name         string
+	beforeCalls  []string
+	afterCalls   []string
+	shortCircuit interface{}
+	mutateArgs   map[string]interface{}
+	annotate     map[string]interface{}
+	replace      interface{}
+}
+
+func (m *recordingMiddleware) Name() string { return m.name }
+
+func (m *recordingMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	m.beforeCalls = append(m.beforeCalls, call.ToolName)
+	if m.mutateArgs != nil {
+		call.Arguments = m.mutateArgs
+	}
+	for k, v := range m.annotate {
+		call.Annotations[k] = v
+	}
+	return m.shortCircuit, nil
+}
+
+func (m *recordingMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	m.afterCalls = append(m.afterCalls, call.ToolName)
+	return m.replace, nil
+}
+
+func newEchoTool(t *testing.T) ai.Tool {
+	t.Helper()
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"path": map[string]any{"type": "string"},
+		},
+	}
+	return ai.NewToolWithInputSchema[any]("__test_echo", "echoes its input", schema,
+		func(toolCtx *ai.ToolContext, input any) (any, error) {
+			return input, nil
+		})
+}
+
+func TestWrapToolsWithMiddleware_NoMiddlewareIsPassthrough(t *testing.T) {
+	resetToolCallMiddlewares()
+
+	tool := newEchoTool(t)
+	agent := &models.Agent{ID: 1, Name: "Test Agent"}
+
+	wrapped := wrapToolsWithMiddleware([]ai.ToolRef{tool}, agent, 1)
+	if len(wrapped) != 1 {
+		t.Fatalf("expected 1 tool, got %d", len(wrapped))
+	}
+	if wrapped[0] != ai.ToolRef(tool) {
+		t.Error("expected original tool to be returned unchanged when no middleware registered")
+	}
+}
+
+func TestWrapToolWithMiddleware_MutatesArguments(t *testing.T) {
+	resetToolCallMiddlewares()
+	defer resetToolCallMiddlewares()
+
+	mw := &recordingMiddleware{name: "mutator", mutateArgs: map[string]interface{}{"path": "/rewritten"}}
+	RegisterToolCallMiddleware(mw)
+
+	agent := &models.Agent{ID: 1, Name: "Test Agent"}
+	wrapped := wrapToolsWithMiddleware([]ai.ToolRef{newEchoTool(t)}, agent, 7)
+
+	tool, ok := wrapped[0].(ai.Tool)
+	if !ok {
+		t.Fatal("expected wrapped tool to implement ai.Tool")
+	}
+	if tool.Name() != "__test_echo" {
+		t.Errorf("wrapped tool name = %q, want __test_echo", tool.Name())
+	}
+
+	output, err := tool.RunRaw(context.Background(), map[string]interface{}{"path": "/original"})
+	if err != nil {
+		t.Fatalf("RunRaw failed: %v", err)
+	}
+
+	result, ok := output.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected map output, got %T", output)
+	}
+	if result["path"] != "/rewritten" {
+		t.Errorf("expected mutated arguments to reach the tool, got %v", result)
+	}
+	if len(mw.beforeCalls) != 1 || len(mw.afterCalls) != 1 {
+		t.Errorf("expected before/after hooks to run once, got %d/%d", len(mw.beforeCalls), len(mw.afterCalls))
+	}
+}
+
+func TestWrapToolWithMiddleware_ShortCircuit(t *testing.T) {
+	resetToolCallMiddlewares()
+	defer resetToolCallMiddlewares()
+
+	first := &recordingMiddleware{name: "policy", shortCircuit: "blocked: cached response"}
+	second := &recordingMiddleware{name: "audit"}
+	RegisterToolCallMiddleware(first)
+	RegisterToolCallMiddleware(second)
+
+	agent := &models.Agent{ID: 1, Name: "Test Agent"}
+	wrapped := wrapToolsWithMiddleware([]ai.ToolRef{newEchoTool(t)}, agent, 7)
+
+	output, err := wrapped[0].(ai.Tool).RunRaw(context.Background(), map[string]interface{}{"path": "/x"})
+	if err != nil {
+		t.Fatalf("RunRaw failed: %v", err)
+	}
+	if output != "blocked: cached response" {
+		t.Errorf("expected short-circuit response, got %v", output)
+	}
+	if len(second.beforeCalls) != 0 {
+		t.Error("expected short-circuit to skip later before hooks")
+	}
+	if len(first.afterCalls) != 1 {
+		t.Error("expected after hook to run for the short-circuiting middleware")
+	}
+}
+
+func TestWrapToolWithMiddleware_Annotations(t *testing.T) {
+	resetToolCallMiddlewares()
+	defer resetToolCallMiddlewares()
+
+	mw := &recordingMiddleware{name: "annotator", annotate: map[string]interface{}{"policy": "allowed"}}
+	RegisterToolCallMiddleware(mw)
+
+	agent := &models.Agent{ID: 1, Name: "Test Agent"}
+	wrapped := wrapToolsWithMiddleware([]ai.ToolRef{newEchoTool(t)}, agent, 7)
+
+	output, err := wrapped[0].(ai.Tool).RunRaw(context.Background(), map[string]interface{}{"path": "/x"})
+	if err != nil {
+		t.Fatalf("RunRaw failed: %v", err)
+	}
+
+	result, ok := output.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected annotated map output, got %T", output)
+	}
+	annotations, ok := result["annotations"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected annotations in output, got %v", result)
+	}
+	if annotations["policy"] != "allowed" {
+		t.Errorf("expected annotation to be surfaced, got %v", annotations)
+	}
+}
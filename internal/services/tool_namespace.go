@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+// QualifiedToolName returns the deterministic fully-qualified form of a tool
+// name: serverName__toolName. Leading underscores on the raw tool name are
+// collapsed, so "filesystem" + "__read_text_file" → "filesystem__read_text_file".
+func QualifiedToolName(serverName, toolName string) string {
+	return serverName + "__" + strings.TrimLeft(toolName, "_")
+}
+
+// resolveToolInEnvironment resolves a tool assignment name to a tool row.
+// Plain names resolve as before (first match in the environment); when two
+// servers expose the same tool name, agents can disambiguate with the
+// fully-qualified serverName__toolName form.
+func resolveToolInEnvironment(repos *repositories.Repositories, environmentID int64, toolName string) (*models.MCPTool, error) {
+	// Exact names win so existing assignments keep resolving unchanged
+	if tool, err := repos.MCPTools.FindByNameInEnvironment(environmentID, toolName); err == nil {
+		return tool, nil
+	}
+
+	// Try to interpret the name as serverName__toolName
+	servers, err := repos.MCPServers.GetByEnvironmentID(environmentID)
+	if err != nil {
+		return nil, fmt.Errorf("tool %s not found in environment", toolName)
+	}
+
+	for _, server := range servers {
+		prefix := server.Name + "__"
+		if !strings.HasPrefix(toolName, prefix) {
+			continue
+		}
+		rawName := strings.TrimPrefix(toolName, prefix)
+
+		serverTools, err := repos.MCPTools.GetByServerID(server.ID)
+		if err != nil {
+			continue
+		}
+		for _, tool := range serverTools {
+			// Stored tool names may carry leading underscores ("__read_text_file")
+			if tool.Name == rawName || strings.TrimLeft(tool.Name, "_") == rawName {
+				return tool, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("tool %s not found in environment", toolName)
+}
+
+// reportToolCollisions finds tool names exposed by more than one MCP server in
+// an environment and returns one warning per collision, suggesting the
+// fully-qualified names agents can use to disambiguate.
+func reportToolCollisions(repos *repositories.Repositories, environmentID int64) []string {
+	servers, err := repos.MCPServers.GetByEnvironmentID(environmentID)
+	if err != nil {
+		return nil
+	}
+
+	serversByTool := make(map[string][]string)
+	for _, server := range servers {
+		tools, err := repos.MCPTools.GetByServerID(server.ID)
+		if err != nil {
+			continue
+		}
+		for _, tool := range tools {
+			serversByTool[tool.Name] = append(serversByTool[tool.Name], server.Name)
+		}
+	}
+
+	var collidingNames []string
+	for name, serverNames := range serversByTool {
+		if len(serverNames) > 1 {
+			collidingNames = append(collidingNames, name)
+		}
+	}
+	sort.Strings(collidingNames)
+
+	var warnings []string
+	for _, name := range collidingNames {
+		serverNames := serversByTool[name]
+		sort.Strings(serverNames)
+
+		qualified := make([]string, len(serverNames))
+		for i, serverName := range serverNames {
+			qualified[i] = QualifiedToolName(serverName, name)
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"Tool name collision: '%s' is exposed by servers [%s] — agents can disambiguate with %s",
+			name, strings.Join(serverNames, ", "), strings.Join(qualified, " or ")))
+	}
+	return warnings
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+func setupCollisionTest(t *testing.T) (*repositories.Repositories, int64) {
+	t.Helper()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+	env, err := repos.Environments.Create("collision-test-env", nil, 1)
+	if err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+
+	// Two servers exposing the same tool name, plus one unique tool
+	for _, serverName := range []string{"filesystem", "backup"} {
+		serverID, err := repos.MCPServers.Create(&models.MCPServer{
+			Name:          serverName,
+			Command:       "npx",
+			EnvironmentID: env.ID,
+		})
+		if err != nil {
+			t.Fatalf("failed to create server %s: %v", serverName, err)
+		}
+		if _, err := repos.MCPTools.Create(&models.MCPTool{
+			MCPServerID: serverID,
+			Name:        "__read_text_file",
+		}); err != nil {
+			t.Fatalf("failed to create tool: %v", err)
+		}
+		if serverName == "backup" {
+			if _, err := repos.MCPTools.Create(&models.MCPTool{
+				MCPServerID: serverID,
+				Name:        "__restore_snapshot",
+			}); err != nil {
+				t.Fatalf("failed to create tool: %v", err)
+			}
+		}
+	}
+
+	return repos, env.ID
+}
+
+func TestQualifiedToolName(t *testing.T) {
+	if got := QualifiedToolName("filesystem", "__read_text_file"); got != "filesystem__read_text_file" {
+		t.Errorf("QualifiedToolName() = %s, want filesystem__read_text_file", got)
+	}
+	if got := QualifiedToolName("ship", "checkov_scan_directory"); got != "ship__checkov_scan_directory" {
+		t.Errorf("QualifiedToolName() = %s, want ship__checkov_scan_directory", got)
+	}
+}
+
+func TestResolveToolInEnvironment(t *testing.T) {
+	repos, envID := setupCollisionTest(t)
+
+	// Plain names still resolve (first match, as before)
+	tool, err := resolveToolInEnvironment(repos, envID, "__restore_snapshot")
+	if err != nil {
+		t.Fatalf("failed to resolve plain tool name: %v", err)
+	}
+	if tool.Name != "__restore_snapshot" {
+		t.Errorf("resolved wrong tool: %s", tool.Name)
+	}
+
+	// Fully-qualified names pick the tool from the named server
+	backupServer, err := repos.MCPServers.GetByNameAndEnvironment("backup", envID)
+	if err != nil {
+		t.Fatalf("failed to get backup server: %v", err)
+	}
+	tool, err = resolveToolInEnvironment(repos, envID, "backup__read_text_file")
+	if err != nil {
+		t.Fatalf("failed to resolve qualified tool name: %v", err)
+	}
+	if tool.MCPServerID != backupServer.ID {
+		t.Errorf("qualified name resolved to server %d, want %d", tool.MCPServerID, backupServer.ID)
+	}
+
+	// Unknown names fail
+	if _, err := resolveToolInEnvironment(repos, envID, "filesystem__does_not_exist"); err == nil {
+		t.Error("expected error for unknown qualified tool")
+	}
+	if _, err := resolveToolInEnvironment(repos, envID, "__does_not_exist"); err == nil {
+		t.Error("expected error for unknown plain tool")
+	}
+}
+
+func TestReportToolCollisions(t *testing.T) {
+	repos, envID := setupCollisionTest(t)
+
+	warnings := reportToolCollisions(repos, envID)
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 collision warning, got %d: %v", len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[0], "__read_text_file") ||
+		!strings.Contains(warnings[0], "backup__read_text_file") ||
+		!strings.Contains(warnings[0], "filesystem__read_text_file") {
+		t.Errorf("warning missing expected qualified names: %s", warnings[0])
+	}
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"station/internal/config"
+	"station/internal/logging"
+)
+
+// toolOutputSchemaMiddleware validates MCP tool responses against declared
+// JSON schemas. Near-miss payloads (JSON encoded as a string, numbers or
+// booleans delivered as strings) are coerced before validation; remaining
+// violations are attached to the tool result as a schema warning so
+// upstream MCP server regressions surface in run metadata instead of
+// silently feeding malformed data to the model.
+type toolOutputSchemaMiddleware struct {
+	schemas map[string]*toolOutputSchema
+}
+
+// toolOutputSchema holds both the compiled validator and the parsed schema
+// document (the latter drives type coercion)
+type toolOutputSchema struct {
+	compiled *gojsonschema.Schema
+	doc      map[string]interface{}
+}
+
+var registerToolOutputSchemasOnce sync.Once
+
+// RegisterToolOutputSchemas registers config-declared tool output schemas as
+// a tool call middleware. Safe to call from multiple engine constructors;
+// only the first call takes effect.
+func RegisterToolOutputSchemas(configs []config.ToolOutputSchemaConfig) {
+	registerToolOutputSchemasOnce.Do(func() {
+		schemas := make(map[string]*toolOutputSchema)
+		for _, cfg := range configs {
+			if cfg.Tool == "" || cfg.Schema == "" {
+				logging.Info("Skipping tool output schema with missing tool name or schema")
+				continue
+			}
+
+			compiled, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(cfg.Schema))
+			if err != nil {
+				logging.Info("Skipping invalid output schema for tool %s: %v", cfg.Tool, err)
+				continue
+			}
+
+			var doc map[string]interface{}
+			if err := json.Unmarshal([]byte(cfg.Schema), &doc); err != nil {
+				logging.Info("Skipping invalid output schema for tool %s: %v", cfg.Tool, err)
+				continue
+			}
+
+			schemas[cfg.Tool] = &toolOutputSchema{compiled: compiled, doc: doc}
+		}
+
+		if len(schemas) > 0 {
+			RegisterToolCallMiddleware(&toolOutputSchemaMiddleware{schemas: schemas})
+		}
+	})
+}
+
+func (m *toolOutputSchemaMiddleware) Name() string {
+	return "tool-output-schema"
+}
+
+func (m *toolOutputSchemaMiddleware) BeforeToolCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	return nil, nil
+}
+
+func (m *toolOutputSchemaMiddleware) AfterToolCall(ctx context.Context, call *ToolCall, output interface{}, callErr error) (interface{}, error) {
+	if callErr != nil {
+		return nil, nil
+	}
+	schema, ok := m.schemas[call.ToolName]
+	if !ok {
+		return nil, nil
+	}
+
+	candidate, decoded := normalizeToolOutput(output)
+
+	result, err := schema.compiled.Validate(gojsonschema.NewGoLoader(candidate))
+	if err != nil {
+		m.flagViolation(call, fmt.Sprintf("schema validation failed: %v", err))
+		return nil, nil
+	}
+
+	if result.Valid() {
+		if decoded {
+			// The payload was valid JSON delivered as a string - hand the
+			// decoded document to the model
+			call.Annotations["schema_coerced"] = true
+			return candidate, nil
+		}
+		return nil, nil
+	}
+
+	// Attempt coercion of near-miss payloads (e.g. "3" where an integer is
+	// expected) and revalidate
+	coerced := coerceToSchema(candidate, schema.doc)
+	if coercedResult, err := schema.compiled.Validate(gojsonschema.NewGoLoader(coerced)); err == nil && coercedResult.Valid() {
+		call.Annotations["schema_coerced"] = true
+		return coerced, nil
+	}
+
+	m.flagViolation(call, formatSchemaErrors(result))
+	return nil, nil
+}
+
+// flagViolation records the violation as a run warning on the tool result
+// and in the logs
+func (m *toolOutputSchemaMiddleware) flagViolation(call *ToolCall, detail string) {
+	call.Annotations["schema_warning"] = fmt.Sprintf("tool output does not match declared schema: %s", detail)
+	logging.Info("Schema warning for tool %s (run %d): %s", call.ToolName, call.RunID, detail)
+}
+
+// normalizeToolOutput prepares a tool response for validation. MCP servers
+// frequently return JSON documents as text content; those are decoded so the
+// schema applies to the document rather than the wrapping string.
+func normalizeToolOutput(output interface{}) (interface{}, bool) {
+	if s, ok := output.(string); ok {
+		trimmed := strings.TrimSpace(s)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+				return decoded, true
+			}
+		}
+	}
+	return output, false
+}
+
+// coerceToSchema walks the value alongside the schema document and converts
+// string-encoded numbers and booleans to the expected primitive types.
+// Unknown or already-conforming values pass through unchanged.
+func coerceToSchema(value interface{}, schemaDoc map[string]interface{}) interface{} {
+	schemaType, _ := schemaDoc["type"].(string)
+
+	switch schemaType {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		properties, _ := schemaDoc["properties"].(map[string]interface{})
+		coerced := make(map[string]interface{}, len(obj))
+		for key, item := range obj {
+			if propSchema, ok := properties[key].(map[string]interface{}); ok {
+				coerced[key] = coerceToSchema(item, propSchema)
+			} else {
+				coerced[key] = item
+			}
+		}
+		return coerced
+
+	case "array":
+		list, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		items, _ := schemaDoc["items"].(map[string]interface{})
+		if items == nil {
+			return value
+		}
+		coerced := make([]interface{}, len(list))
+		for i, item := range list {
+			coerced[i] = coerceToSchema(item, items)
+		}
+		return coerced
+
+	case "number", "integer":
+		if s, ok := value.(string); ok {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+				return parsed
+			}
+		}
+		return value
+
+	case "boolean":
+		if s, ok := value.(string); ok {
+			if parsed, err := strconv.ParseBool(strings.TrimSpace(s)); err == nil {
+				return parsed
+			}
+		}
+		return value
+
+	case "string":
+		return value
+
+	default:
+		return value
+	}
+}
+
+// formatSchemaErrors flattens validation errors into a single warning line
+func formatSchemaErrors(result *gojsonschema.Result) string {
+	errs := result.Errors()
+	parts := make([]string, 0, len(errs))
+	for _, e := range errs {
+		parts = append(parts, e.String())
+	}
+	return strings.Join(parts, "; ")
+}
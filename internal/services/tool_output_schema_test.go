@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const scanResultSchema = `{
+	"type": "object",
+	"properties": {
+		"passed": {"type": "boolean"},
+		"findings": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {
+					"severity": {"type": "string"},
+					"count": {"type": "integer"}
+				}
+			}
+		}
+	},
+	"required": ["passed", "findings"]
+}`
+
+func newSchemaMiddleware(t *testing.T, toolName, schemaJSON string) *toolOutputSchemaMiddleware {
+	t.Helper()
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schemaJSON))
+	if err != nil {
+		t.Fatalf("failed to compile schema: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(schemaJSON), &doc); err != nil {
+		t.Fatalf("failed to parse schema doc: %v", err)
+	}
+	return &toolOutputSchemaMiddleware{
+		schemas: map[string]*toolOutputSchema{
+			toolName: {compiled: compiled, doc: doc},
+		},
+	}
+}
+
+func newSchemaToolCall(toolName string) *ToolCall {
+	return &ToolCall{
+		ToolName:    toolName,
+		Annotations: make(map[string]interface{}),
+	}
+}
+
+func TestToolOutputSchema_ValidOutputPassesUntouched(t *testing.T) {
+	m := newSchemaMiddleware(t, "__scan", scanResultSchema)
+	call := newSchemaToolCall("__scan")
+
+	output := map[string]interface{}{
+		"passed":   true,
+		"findings": []interface{}{},
+	}
+
+	replacement, err := m.AfterToolCall(context.Background(), call, output, nil)
+	if err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+	if replacement != nil {
+		t.Errorf("Expected valid output to pass untouched, got replacement %v", replacement)
+	}
+	if len(call.Annotations) != 0 {
+		t.Errorf("Expected no annotations for valid output, got %v", call.Annotations)
+	}
+}
+
+func TestToolOutputSchema_JSONStringDecoded(t *testing.T) {
+	m := newSchemaMiddleware(t, "__scan", scanResultSchema)
+	call := newSchemaToolCall("__scan")
+
+	output := `{"passed": true, "findings": [{"severity": "high", "count": 2}]}`
+
+	replacement, err := m.AfterToolCall(context.Background(), call, output, nil)
+	if err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+	decoded, ok := replacement.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected decoded map replacement, got %T", replacement)
+	}
+	if decoded["passed"] != true {
+		t.Errorf("Expected decoded payload, got %v", decoded)
+	}
+	if call.Annotations["schema_coerced"] != true {
+		t.Errorf("Expected schema_coerced annotation, got %v", call.Annotations)
+	}
+}
+
+func TestToolOutputSchema_NearMissCoerced(t *testing.T) {
+	m := newSchemaMiddleware(t, "__scan", scanResultSchema)
+	call := newSchemaToolCall("__scan")
+
+	// passed delivered as a string, count as a numeric string
+	output := map[string]interface{}{
+		"passed": "true",
+		"findings": []interface{}{
+			map[string]interface{}{"severity": "high", "count": "2"},
+		},
+	}
+
+	replacement, err := m.AfterToolCall(context.Background(), call, output, nil)
+	if err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+	coerced, ok := replacement.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected coerced map replacement, got %T", replacement)
+	}
+	if coerced["passed"] != true {
+		t.Errorf("Expected passed coerced to bool, got %v (%T)", coerced["passed"], coerced["passed"])
+	}
+	findings := coerced["findings"].([]interface{})
+	first := findings[0].(map[string]interface{})
+	if first["count"] != float64(2) {
+		t.Errorf("Expected count coerced to number, got %v (%T)", first["count"], first["count"])
+	}
+	if call.Annotations["schema_coerced"] != true {
+		t.Errorf("Expected schema_coerced annotation, got %v", call.Annotations)
+	}
+}
+
+func TestToolOutputSchema_ViolationFlaggedAsWarning(t *testing.T) {
+	m := newSchemaMiddleware(t, "__scan", scanResultSchema)
+	call := newSchemaToolCall("__scan")
+
+	// findings missing entirely - not coercible
+	output := map[string]interface{}{"passed": true}
+
+	replacement, err := m.AfterToolCall(context.Background(), call, output, nil)
+	if err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+	if replacement != nil {
+		t.Errorf("Expected original output kept on violation, got %v", replacement)
+	}
+	warning, ok := call.Annotations["schema_warning"].(string)
+	if !ok || warning == "" {
+		t.Errorf("Expected schema_warning annotation, got %v", call.Annotations)
+	}
+}
+
+func TestToolOutputSchema_OtherToolsIgnored(t *testing.T) {
+	m := newSchemaMiddleware(t, "__scan", scanResultSchema)
+	call := newSchemaToolCall("__other_tool")
+
+	replacement, err := m.AfterToolCall(context.Background(), call, "anything", nil)
+	if err != nil {
+		t.Fatalf("AfterToolCall failed: %v", err)
+	}
+	if replacement != nil || len(call.Annotations) != 0 {
+		t.Errorf("Expected tools without schemas to be ignored, got %v / %v", replacement, call.Annotations)
+	}
+}
+
+func TestCoerceToSchemaLeavesUncoercibleValues(t *testing.T) {
+	schemaDoc := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"count": map[string]interface{}{"type": "integer"},
+		},
+	}
+
+	input := map[string]interface{}{"count": "not-a-number"}
+	coerced := coerceToSchema(input, schemaDoc).(map[string]interface{})
+	if coerced["count"] != "not-a-number" {
+		t.Errorf("Expected uncoercible value preserved, got %v", coerced["count"])
+	}
+}
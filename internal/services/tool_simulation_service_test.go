@@ -0,0 +1,137 @@
+package services
+
+import (
+	"testing"
+
+	"station/pkg/models"
+)
+
+// TestArgumentShape tests JSON type reduction of recorded tool arguments
+func TestArgumentShape(t *testing.T) {
+	shape := argumentShape(map[string]interface{}{
+		"path":      "/tmp/report.csv",
+		"max_rows":  float64(100),
+		"recursive": true,
+		"filters":   []interface{}{"*.tf", "*.yaml"},
+		"options":   map[string]interface{}{"follow_links": true},
+		"cursor":    nil,
+	})
+
+	expected := map[string]string{
+		"path":      "string",
+		"max_rows":  "number",
+		"recursive": "boolean",
+		"filters":   "array[string]",
+		"options":   "object",
+		"cursor":    "null",
+	}
+	for key, want := range expected {
+		if shape[key] != want {
+			t.Errorf("argumentShape()[%q] = %q, want %q", key, shape[key], want)
+		}
+	}
+
+	if argumentShape(nil) != nil {
+		t.Error("argumentShape(nil) should return nil")
+	}
+	if argumentShape(map[string]interface{}{})["anything"] != "" {
+		t.Error("argumentShape of empty args should be empty")
+	}
+
+	if got := jsonTypeName([]interface{}{}); got != "array" {
+		t.Errorf("jsonTypeName(empty array) = %q, want array", got)
+	}
+}
+
+// TestToolCallRecorder tests concurrent-safe call recording
+func TestToolCallRecorder(t *testing.T) {
+	recorder := &toolCallRecorder{}
+	recorder.record("__list_directory", map[string]interface{}{"path": "/tmp"})
+	recorder.record("__read_text_file", map[string]interface{}{"path": "/tmp/a.txt", "limit": float64(10)})
+	recorder.record("__list_directory", nil)
+
+	if len(recorder.calls) != 3 {
+		t.Fatalf("recorded %d calls, want 3", len(recorder.calls))
+	}
+	if recorder.calls[0].ToolName != "__list_directory" {
+		t.Errorf("first call = %q, want __list_directory", recorder.calls[0].ToolName)
+	}
+	if recorder.calls[1].ArgumentShape["limit"] != "number" {
+		t.Errorf("argument shape for limit = %q, want number", recorder.calls[1].ArgumentShape["limit"])
+	}
+	if recorder.calls[2].ArgumentShape != nil {
+		t.Error("call without arguments should have no argument shape")
+	}
+}
+
+// TestBuildSimulationReport tests report assembly from recorded calls
+func TestBuildSimulationReport(t *testing.T) {
+	agent := &models.Agent{ID: 7, Name: "test-sim-agent"}
+	agentTools := []*models.AgentToolWithDetails{
+		{ToolName: "__list_directory"},
+		{ToolName: "__read_text_file"},
+		{ToolName: "__write_file"},
+	}
+	calls := []SimulatedToolCall{
+		{ToolName: "__list_directory", ArgumentShape: map[string]string{"path": "string"}},
+		{ToolName: "__list_directory", ArgumentShape: map[string]string{"path": "string"}},
+		{ToolName: "__read_text_file", ArgumentShape: map[string]string{"path": "string"}},
+	}
+
+	report := buildSimulationReport(agent, "scan the repo", agentTools, calls)
+
+	if report.AgentID != 7 || report.AgentName != "test-sim-agent" {
+		t.Errorf("report identity = (%d, %q), want (7, test-sim-agent)", report.AgentID, report.AgentName)
+	}
+	if len(report.AssignedTools) != 3 {
+		t.Errorf("assigned tools = %d, want 3", len(report.AssignedTools))
+	}
+	if report.CallCounts["__list_directory"] != 2 {
+		t.Errorf("call count for __list_directory = %d, want 2", report.CallCounts["__list_directory"])
+	}
+	if report.CallCounts["__read_text_file"] != 1 {
+		t.Errorf("call count for __read_text_file = %d, want 1", report.CallCounts["__read_text_file"])
+	}
+	if len(report.UnusedTools) != 1 || report.UnusedTools[0] != "__write_file" {
+		t.Errorf("unused tools = %v, want [__write_file]", report.UnusedTools)
+	}
+
+	// No calls at all: every assigned tool is unused
+	report = buildSimulationReport(agent, "scan the repo", agentTools, nil)
+	if len(report.UnusedTools) != 3 {
+		t.Errorf("unused tools with no calls = %d, want 3", len(report.UnusedTools))
+	}
+}
+
+// TestBuildSimulatedTool tests that stubs keep the discovered schema and
+// record invocations
+func TestBuildSimulatedTool(t *testing.T) {
+	recorder := &toolCallRecorder{}
+	tool := buildSimulatedTool(&models.AgentToolWithDetails{
+		ToolName:        "__search_files",
+		ToolDescription: "Search for files",
+		ToolSchema:      `{"type":"object","properties":{"pattern":{"type":"string"}},"required":["pattern"]}`,
+	}, recorder)
+
+	if tool.Name() != "__search_files" {
+		t.Errorf("tool name = %q, want __search_files", tool.Name())
+	}
+	if tool.Definition().Description != "Search for files" {
+		t.Errorf("tool description = %q, want 'Search for files'", tool.Definition().Description)
+	}
+	if tool.Definition().InputSchema["properties"] == nil {
+		t.Error("tool should keep the discovered input schema")
+	}
+
+	// Broken schemas fall back to a generic object rather than failing
+	fallback := buildSimulatedTool(&models.AgentToolWithDetails{
+		ToolName:   "__broken",
+		ToolSchema: "{not json",
+	}, recorder)
+	if fallback.Definition().InputSchema["type"] != "object" {
+		t.Error("broken schema should fall back to generic object schema")
+	}
+	if fallback.Definition().Description == "" {
+		t.Error("missing description should get a simulated placeholder")
+	}
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"gopkg.in/yaml.v3"
+
+	"station/pkg/templates"
+)
+
+// VariableDriftReport describes how an environment's variables.yml has
+// drifted from the variables its template configs actually reference, plus
+// value differences against other environments that share the same bundle
+// templates. Produced by `stn sync <env> --check-drift`.
+type VariableDriftReport struct {
+	Environment string `json:"environment"`
+	// Missing variables are referenced by a template but defined neither in
+	// variables.yml nor as a process environment variable / secret
+	Missing []string `json:"missing,omitempty"`
+	// Unused variables are defined in variables.yml but referenced by no template
+	Unused []string `json:"unused,omitempty"`
+	// CrossEnvDiffs are variables whose values differ from another
+	// environment containing the same template file
+	CrossEnvDiffs []VariableValueDiff `json:"cross_env_diffs,omitempty"`
+}
+
+// VariableValueDiff records a variable whose value differs between two
+// environments that share a template file (values themselves are omitted so
+// secrets never end up in CI logs)
+type VariableValueDiff struct {
+	Variable         string `json:"variable"`
+	OtherEnvironment string `json:"other_environment"`
+	SharedTemplate   string `json:"shared_template"`
+}
+
+// Clean reports whether no drift of any kind was detected
+func (r *VariableDriftReport) Clean() bool {
+	return len(r.Missing) == 0 && len(r.Unused) == 0 && len(r.CrossEnvDiffs) == 0
+}
+
+// CheckVariableDrift compares the variables referenced by the environment's
+// template configs (*.json) against variables.yml and the process
+// environment, and compares shared-template variable values against sibling
+// environments. environmentsRoot is the directory containing all environment
+// directories (normally <config>/environments).
+func CheckVariableDrift(environmentsRoot, envName string) (*VariableDriftReport, error) {
+	envDir := filepath.Join(environmentsRoot, envName)
+	if _, err := os.Stat(envDir); err != nil {
+		return nil, fmt.Errorf("environment directory not found: %s", envDir)
+	}
+
+	report := &VariableDriftReport{Environment: envName}
+
+	// Collect the variables referenced by each template config
+	templateVars, err := templateVariableReferences(envDir)
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool)
+	for _, vars := range templateVars {
+		for _, name := range vars {
+			referenced[name] = true
+		}
+	}
+
+	defined, err := loadVariablesFile(filepath.Join(envDir, "variables.yml"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing: referenced but satisfied by neither variables.yml nor the
+	// process environment (secrets loaded from backends land in the latter)
+	for name := range referenced {
+		if _, ok := defined[name]; ok {
+			continue
+		}
+		if os.Getenv(name) != "" {
+			continue
+		}
+		report.Missing = append(report.Missing, name)
+	}
+
+	// Unused: defined but referenced by no template
+	for name := range defined {
+		if !referenced[name] {
+			report.Unused = append(report.Unused, name)
+		}
+	}
+
+	// Cross-environment drift: environments containing a template file with
+	// the same name share a bundle; flag variables whose values differ
+	siblings, err := os.ReadDir(environmentsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list environments: %w", err)
+	}
+	for _, sibling := range siblings {
+		if !sibling.IsDir() || sibling.Name() == envName {
+			continue
+		}
+		otherDir := filepath.Join(environmentsRoot, sibling.Name())
+		otherDefined, err := loadVariablesFile(filepath.Join(otherDir, "variables.yml"))
+		if err != nil {
+			continue
+		}
+		for templateName, vars := range templateVars {
+			if _, err := os.Stat(filepath.Join(otherDir, templateName)); err != nil {
+				continue
+			}
+			for _, name := range vars {
+				ours, okOurs := defined[name]
+				theirs, okTheirs := otherDefined[name]
+				if okOurs && okTheirs && ours != theirs {
+					report.CrossEnvDiffs = append(report.CrossEnvDiffs, VariableValueDiff{
+						Variable:         name,
+						OtherEnvironment: sibling.Name(),
+						SharedTemplate:   templateName,
+					})
+				}
+			}
+		}
+	}
+
+	sort.Strings(report.Missing)
+	sort.Strings(report.Unused)
+	sort.Slice(report.CrossEnvDiffs, func(i, j int) bool {
+		a, b := report.CrossEnvDiffs[i], report.CrossEnvDiffs[j]
+		if a.Variable != b.Variable {
+			return a.Variable < b.Variable
+		}
+		return a.OtherEnvironment < b.OtherEnvironment
+	})
+
+	return report, nil
+}
+
+// templateVariableReferences maps each template config file name in the
+// environment directory to the variables it references
+func templateVariableReferences(envDir string) (map[string][]string, error) {
+	templateFiles, err := filepath.Glob(filepath.Join(envDir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template configs: %w", err)
+	}
+
+	result := make(map[string][]string)
+	for _, path := range templateFiles {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+		}
+		vars, err := referencedTemplateVariables(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", filepath.Base(path), err)
+		}
+		result[filepath.Base(path)] = vars
+	}
+	return result, nil
+}
+
+// referencedTemplateVariables parses the content as a Go template and walks
+// the parse tree collecting top-level field references ({{ .VAR }}). Using
+// the template engine rather than a regex handles whitespace and pipeline
+// variations the same way rendering does.
+func referencedTemplateVariables(content string) ([]string, error) {
+	tmpl, err := template.New("drift-check").Funcs(templates.FuncMap(nil)).Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && t.Tree.Root != nil {
+			collectFieldReferences(t.Tree.Root, seen)
+		}
+	}
+
+	vars := make([]string, 0, len(seen))
+	for name := range seen {
+		vars = append(vars, name)
+	}
+	sort.Strings(vars)
+	return vars, nil
+}
+
+// collectFieldReferences walks a template parse tree node recursively,
+// recording the first identifier of every field node
+func collectFieldReferences(node parse.Node, seen map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectFieldReferences(child, seen)
+		}
+	case *parse.ActionNode:
+		collectPipeReferences(n.Pipe, seen)
+	case *parse.IfNode:
+		collectPipeReferences(n.Pipe, seen)
+		collectFieldReferences(n.List, seen)
+		collectFieldReferences(n.ElseList, seen)
+	case *parse.RangeNode:
+		collectPipeReferences(n.Pipe, seen)
+		collectFieldReferences(n.List, seen)
+		collectFieldReferences(n.ElseList, seen)
+	case *parse.WithNode:
+		collectPipeReferences(n.Pipe, seen)
+		collectFieldReferences(n.List, seen)
+		collectFieldReferences(n.ElseList, seen)
+	case *parse.TemplateNode:
+		collectPipeReferences(n.Pipe, seen)
+	}
+}
+
+// collectPipeReferences records field references inside a pipeline
+func collectPipeReferences(pipe *parse.PipeNode, seen map[string]bool) {
+	if pipe == nil {
+		return
+	}
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			if field, ok := arg.(*parse.FieldNode); ok && len(field.Ident) > 0 {
+				seen[field.Ident[0]] = true
+			}
+		}
+	}
+}
+
+// loadVariablesFile reads a variables.yml into a string map; a missing file
+// is treated as an empty variable set
+func loadVariablesFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	vars := make(map[string]string, len(raw))
+	for key, value := range raw {
+		vars[key] = strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+	return vars, nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeDriftFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestReferencedTemplateVariables(t *testing.T) {
+	content := `{
+		"mcpServers": {
+			"filesystem": {
+				"command": "npx",
+				"args": ["-y", "server-filesystem", "{{ .PROJECT_ROOT }}"],
+				"env": {"TOKEN": "{{.API_TOKEN}}"}
+			}
+		}
+	}`
+
+	vars, err := referencedTemplateVariables(content)
+	if err != nil {
+		t.Fatalf("referencedTemplateVariables failed: %v", err)
+	}
+	if len(vars) != 2 || vars[0] != "API_TOKEN" || vars[1] != "PROJECT_ROOT" {
+		t.Errorf("Expected [API_TOKEN PROJECT_ROOT], got %v", vars)
+	}
+}
+
+func TestReferencedTemplateVariablesHandlesSpacesAndNoVars(t *testing.T) {
+	vars, err := referencedTemplateVariables(`{"command": "echo", "path": "{{   .ROOT_PATH   }}"}`)
+	if err != nil {
+		t.Fatalf("referencedTemplateVariables failed: %v", err)
+	}
+	if len(vars) != 1 || vars[0] != "ROOT_PATH" {
+		t.Errorf("Expected [ROOT_PATH], got %v", vars)
+	}
+
+	vars, err = referencedTemplateVariables(`{"command": "echo"}`)
+	if err != nil {
+		t.Fatalf("referencedTemplateVariables failed: %v", err)
+	}
+	if len(vars) != 0 {
+		t.Errorf("Expected no variables, got %v", vars)
+	}
+}
+
+func TestCheckVariableDriftMissingAndUnused(t *testing.T) {
+	root := t.TempDir()
+	envDir := filepath.Join(root, "default")
+	writeDriftFile(t, filepath.Join(envDir, "github.json"),
+		`{"env": {"TOKEN": "{{ .GITHUB_TOKEN }}", "ROOT": "{{ .PROJECT_ROOT }}"}}`)
+	writeDriftFile(t, filepath.Join(envDir, "variables.yml"),
+		"PROJECT_ROOT: /workspace\nLEFTOVER_VAR: stale\n")
+
+	report, err := CheckVariableDrift(root, "default")
+	if err != nil {
+		t.Fatalf("CheckVariableDrift failed: %v", err)
+	}
+
+	if len(report.Missing) != 1 || report.Missing[0] != "GITHUB_TOKEN" {
+		t.Errorf("Expected GITHUB_TOKEN missing, got %v", report.Missing)
+	}
+	if len(report.Unused) != 1 || report.Unused[0] != "LEFTOVER_VAR" {
+		t.Errorf("Expected LEFTOVER_VAR unused, got %v", report.Unused)
+	}
+	if report.Clean() {
+		t.Error("Expected drift to be reported")
+	}
+}
+
+func TestCheckVariableDriftEnvVarSatisfiesReference(t *testing.T) {
+	root := t.TempDir()
+	envDir := filepath.Join(root, "default")
+	writeDriftFile(t, filepath.Join(envDir, "github.json"),
+		`{"env": {"TOKEN": "{{ .DRIFT_TEST_TOKEN }}"}}`)
+
+	t.Setenv("DRIFT_TEST_TOKEN", "from-secrets-backend")
+
+	report, err := CheckVariableDrift(root, "default")
+	if err != nil {
+		t.Fatalf("CheckVariableDrift failed: %v", err)
+	}
+	if len(report.Missing) != 0 {
+		t.Errorf("Expected env var to satisfy the reference, got missing %v", report.Missing)
+	}
+}
+
+func TestCheckVariableDriftCrossEnvironmentDiff(t *testing.T) {
+	root := t.TempDir()
+	writeDriftFile(t, filepath.Join(root, "staging", "bundle.json"),
+		`{"path": "{{ .PROJECT_ROOT }}"}`)
+	writeDriftFile(t, filepath.Join(root, "staging", "variables.yml"),
+		"PROJECT_ROOT: /staging\n")
+	writeDriftFile(t, filepath.Join(root, "prod", "bundle.json"),
+		`{"path": "{{ .PROJECT_ROOT }}"}`)
+	writeDriftFile(t, filepath.Join(root, "prod", "variables.yml"),
+		"PROJECT_ROOT: /prod\n")
+	// Environment with the same variable but no shared template must not count
+	writeDriftFile(t, filepath.Join(root, "unrelated", "other.json"),
+		`{"path": "{{ .PROJECT_ROOT }}"}`)
+	writeDriftFile(t, filepath.Join(root, "unrelated", "variables.yml"),
+		"PROJECT_ROOT: /elsewhere\n")
+
+	report, err := CheckVariableDrift(root, "staging")
+	if err != nil {
+		t.Fatalf("CheckVariableDrift failed: %v", err)
+	}
+
+	if len(report.CrossEnvDiffs) != 1 {
+		t.Fatalf("Expected exactly one cross-env diff, got %v", report.CrossEnvDiffs)
+	}
+	diff := report.CrossEnvDiffs[0]
+	if diff.Variable != "PROJECT_ROOT" || diff.OtherEnvironment != "prod" || diff.SharedTemplate != "bundle.json" {
+		t.Errorf("Unexpected diff: %+v", diff)
+	}
+}
+
+func TestCheckVariableDriftCleanEnvironment(t *testing.T) {
+	root := t.TempDir()
+	envDir := filepath.Join(root, "default")
+	writeDriftFile(t, filepath.Join(envDir, "fs.json"),
+		`{"path": "{{ .PROJECT_ROOT }}"}`)
+	writeDriftFile(t, filepath.Join(envDir, "variables.yml"),
+		"PROJECT_ROOT: /workspace\n")
+
+	report, err := CheckVariableDrift(root, "default")
+	if err != nil {
+		t.Fatalf("CheckVariableDrift failed: %v", err)
+	}
+	if !report.Clean() {
+		t.Errorf("Expected clean report, got %+v", report)
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// loadVariableRequirements reads the variable declarations from a bundle
+// manifest.json in the environment directory. Environments that were not
+// installed from a bundle (no manifest.json) have no declared requirements.
+func loadVariableRequirements(envDir string) ([]VariableRequirement, error) {
+	manifestPath := filepath.Join(envDir, "manifest.json")
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", manifestPath, err)
+	}
+
+	var manifest struct {
+		RequiredVariables []VariableRequirement `json:"required_variables"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestPath, err)
+	}
+	return manifest.RequiredVariables, nil
+}
+
+// requirementFor returns the declared requirement for a variable name, or nil
+// when the variable has no declaration
+func requirementFor(requirements []VariableRequirement, name string) *VariableRequirement {
+	for i := range requirements {
+		if requirements[i].Name == name {
+			return &requirements[i]
+		}
+	}
+	return nil
+}
+
+// validateVariableValue checks a provided value against a variable's declared
+// type, enum membership, and regex pattern
+func validateVariableValue(req VariableRequirement, value string) error {
+	switch strings.ToLower(req.Type) {
+	case "", "string", "secret":
+		// any string is valid
+	case "int", "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("variable %s must be a number, got %q", req.Name, value)
+		}
+	case "bool", "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("variable %s must be true or false, got %q", req.Name, value)
+		}
+	case "enum":
+		found := false
+		for _, allowed := range req.Enum {
+			if value == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("variable %s must be one of [%s], got %q",
+				req.Name, strings.Join(req.Enum, ", "), value)
+		}
+	case "url":
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("variable %s must be a valid URL with scheme and host, got %q", req.Name, value)
+		}
+	default:
+		return fmt.Errorf("variable %s declares unknown type %q", req.Name, req.Type)
+	}
+
+	if req.Pattern != "" {
+		matched, err := regexp.MatchString(req.Pattern, value)
+		if err != nil {
+			return fmt.Errorf("variable %s has an invalid pattern %q: %w", req.Name, req.Pattern, err)
+		}
+		if !matched {
+			return fmt.Errorf("variable %s value %q does not match pattern %q", req.Name, value, req.Pattern)
+		}
+	}
+	return nil
+}
+
+// validateEnvironmentVariables checks the values present in variables.yml
+// against the bundle manifest's declarations. Missing variables are left to
+// the template resolution flow (which can prompt interactively); only values
+// that are present are type-checked here.
+func validateEnvironmentVariables(requirements []VariableRequirement, variables map[string]string) []string {
+	var errors []string
+	for _, req := range requirements {
+		value, ok := variables[req.Name]
+		if !ok {
+			continue
+		}
+		if err := validateVariableValue(req, value); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+	return errors
+}
+
+// variablePromptLabel renders the type hint shown in interactive prompts,
+// e.g. "(enum: dev, staging, prod)" or "(url)"
+func variablePromptLabel(req *VariableRequirement) string {
+	if req == nil || req.Type == "" || strings.EqualFold(req.Type, "string") {
+		return ""
+	}
+	if strings.EqualFold(req.Type, "enum") && len(req.Enum) > 0 {
+		return fmt.Sprintf("(enum: %s)", strings.Join(req.Enum, ", "))
+	}
+	return fmt.Sprintf("(%s)", strings.ToLower(req.Type))
+}
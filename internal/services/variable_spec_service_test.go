@@ -0,0 +1,136 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestValidateVariableValue tests typed variable validation
+func TestValidateVariableValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     VariableRequirement
+		value   string
+		wantErr bool
+	}{
+		{name: "untyped accepts anything", req: VariableRequirement{Name: "V"}, value: "anything", wantErr: false},
+		{name: "string accepts anything", req: VariableRequirement{Name: "V", Type: "string"}, value: "hello world", wantErr: false},
+		{name: "secret accepts anything", req: VariableRequirement{Name: "V", Type: "secret"}, value: "sk-abc123", wantErr: false},
+		{name: "int accepts number", req: VariableRequirement{Name: "V", Type: "int"}, value: "8585", wantErr: false},
+		{name: "int rejects text", req: VariableRequirement{Name: "V", Type: "int"}, value: "eight", wantErr: true},
+		{name: "bool accepts true", req: VariableRequirement{Name: "V", Type: "bool"}, value: "true", wantErr: false},
+		{name: "bool rejects text", req: VariableRequirement{Name: "V", Type: "bool"}, value: "yes please", wantErr: true},
+		{name: "enum accepts member", req: VariableRequirement{Name: "V", Type: "enum", Enum: []string{"dev", "prod"}}, value: "prod", wantErr: false},
+		{name: "enum rejects non-member", req: VariableRequirement{Name: "V", Type: "enum", Enum: []string{"dev", "prod"}}, value: "staging", wantErr: true},
+		{name: "url accepts https", req: VariableRequirement{Name: "V", Type: "url"}, value: "https://api.example.com/v1", wantErr: false},
+		{name: "url rejects bare string", req: VariableRequirement{Name: "V", Type: "url"}, value: "not-a-url", wantErr: true},
+		{name: "pattern match passes", req: VariableRequirement{Name: "V", Pattern: `^sk-[a-z0-9]+$`}, value: "sk-abc123", wantErr: false},
+		{name: "pattern mismatch fails", req: VariableRequirement{Name: "V", Pattern: `^sk-[a-z0-9]+$`}, value: "token-123", wantErr: true},
+		{name: "unknown type fails", req: VariableRequirement{Name: "V", Type: "float128"}, value: "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVariableValue(tt.req, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateVariableValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestValidateEnvironmentVariables tests validation of variables.yml values
+func TestValidateEnvironmentVariables(t *testing.T) {
+	requirements := []VariableRequirement{
+		{Name: "PORT", Type: "int", Required: true},
+		{Name: "ENVIRONMENT", Type: "enum", Enum: []string{"dev", "prod"}},
+		{Name: "OPTIONAL_URL", Type: "url"},
+	}
+
+	// Valid values pass
+	errors := validateEnvironmentVariables(requirements, map[string]string{
+		"PORT":        "8585",
+		"ENVIRONMENT": "dev",
+	})
+	if len(errors) != 0 {
+		t.Errorf("expected no validation errors, got %v", errors)
+	}
+
+	// Missing variables are left to the template resolution flow
+	errors = validateEnvironmentVariables(requirements, map[string]string{})
+	if len(errors) != 0 {
+		t.Errorf("expected missing variables to be skipped, got %v", errors)
+	}
+
+	// Bad values are each reported
+	errors = validateEnvironmentVariables(requirements, map[string]string{
+		"PORT":        "not-a-port",
+		"ENVIRONMENT": "staging",
+	})
+	if len(errors) != 2 {
+		t.Errorf("expected 2 validation errors, got %v", errors)
+	}
+}
+
+// TestLoadVariableRequirements tests manifest.json parsing
+func TestLoadVariableRequirements(t *testing.T) {
+	envDir := t.TempDir()
+
+	// No manifest means no declared requirements
+	requirements, err := loadVariableRequirements(envDir)
+	if err != nil || requirements != nil {
+		t.Fatalf("expected nil requirements for missing manifest, got %v, %v", requirements, err)
+	}
+
+	manifest := `{
+		"required_variables": [
+			{"name": "API_URL", "type": "url", "description": "Upstream API endpoint", "required": true},
+			{"name": "REGION", "type": "enum", "enum": ["us-east-1", "eu-west-1"], "default": "us-east-1"}
+		]
+	}`
+	if err := os.WriteFile(filepath.Join(envDir, "manifest.json"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	requirements, err = loadVariableRequirements(envDir)
+	if err != nil {
+		t.Fatalf("loadVariableRequirements returned error: %v", err)
+	}
+	if len(requirements) != 2 {
+		t.Fatalf("expected 2 requirements, got %d", len(requirements))
+	}
+
+	req := requirementFor(requirements, "REGION")
+	if req == nil || req.Type != "enum" || len(req.Enum) != 2 {
+		t.Errorf("unexpected REGION requirement: %+v", req)
+	}
+	if requirementFor(requirements, "UNDECLARED") != nil {
+		t.Error("expected nil for undeclared variable")
+	}
+}
+
+// TestApplyRequirementEnrichesPrompt tests manifest-to-prompt enrichment
+func TestApplyRequirementEnrichesPrompt(t *testing.T) {
+	service := NewTemplateVariableService("", nil)
+
+	info := VariableInfo{Name: "DEPLOY_ENV", Required: true}
+	service.applyRequirement(&info, &VariableRequirement{
+		Name:        "DEPLOY_ENV",
+		Type:        "enum",
+		Enum:        []string{"dev", "prod"},
+		Description: "Deployment target",
+		Default:     "dev",
+	})
+
+	if info.Type != "enum" || len(info.Enum) != 2 || info.Description != "Deployment target" || info.Default != "dev" {
+		t.Errorf("unexpected enriched info: %+v", info)
+	}
+
+	// Secret type flips the secret flag so prompts mask the value
+	secretInfo := VariableInfo{Name: "API_TOKEN"}
+	service.applyRequirement(&secretInfo, &VariableRequirement{Name: "API_TOKEN", Type: "secret"})
+	if !secretInfo.Secret {
+		t.Error("expected secret type to set Secret flag")
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"station/pkg/models"
+)
+
+const (
+	// Canary rollout state lives in the settings table: one JSON
+	// WorkflowCanary document per workflow under "workflow.canary.<workflow_id>"
+	workflowCanarySettingPrefix = "workflow.canary."
+
+	// How many recent runs feed the per-version success metrics
+	canaryMetricsWindow = 200
+)
+
+// WorkflowCanary describes an in-progress gradual rollout: Percent of new
+// runs use CanaryVersion, the rest stay on StableVersion. Promote removes
+// the split (all traffic on the canary); rollback re-publishes the stable
+// definition as a new version.
+type WorkflowCanary struct {
+	CanaryVersion int64 `json:"canary_version"`
+	StableVersion int64 `json:"stable_version"`
+	Percent       int   `json:"percent"`
+}
+
+// WorkflowVersionMetrics aggregates run outcomes for one workflow version
+type WorkflowVersionMetrics struct {
+	Version   int64
+	Runs      int
+	Succeeded int
+	Failed    int
+}
+
+// SuccessRatePercent is the share of finished runs that completed; 0 when
+// no runs have finished yet
+func (m *WorkflowVersionMetrics) SuccessRatePercent() float64 {
+	finished := m.Succeeded + m.Failed
+	if finished == 0 {
+		return 0
+	}
+	return float64(m.Succeeded) / float64(finished) * 100
+}
+
+// GetCanary loads the active canary rollout for a workflow; nil when no
+// rollout is in progress
+func (s *WorkflowService) GetCanary(workflowID string) (*WorkflowCanary, error) {
+	setting, err := s.repos.Settings.GetByKey(workflowCanarySettingPrefix + workflowID)
+	if err != nil {
+		return nil, nil
+	}
+
+	var canary WorkflowCanary
+	if err := json.Unmarshal([]byte(setting.Value), &canary); err != nil {
+		return nil, fmt.Errorf("invalid canary state for workflow %s: %w", workflowID, err)
+	}
+	return &canary, nil
+}
+
+// StartCanary begins a gradual rollout of the latest workflow version:
+// percent of new runs use it, the rest stay on the previous version
+func (s *WorkflowService) StartCanary(ctx context.Context, workflowID string, percent int) (*WorkflowCanary, error) {
+	if percent < 1 || percent > 99 {
+		return nil, fmt.Errorf("canary percent must be between 1 and 99, got %d", percent)
+	}
+
+	latest, err := s.repos.Workflows.GetLatest(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("workflow not found: %w", err)
+	}
+
+	versions, err := s.repos.Workflows.ListVersions(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow versions: %w", err)
+	}
+	var stableVersion int64
+	for _, v := range versions {
+		if v.Version < latest.Version && v.Version > stableVersion {
+			stableVersion = v.Version
+		}
+	}
+	if stableVersion == 0 {
+		return nil, fmt.Errorf("workflow %s has only one version (v%d); sync a new version before starting a canary", workflowID, latest.Version)
+	}
+
+	canary := &WorkflowCanary{
+		CanaryVersion: latest.Version,
+		StableVersion: stableVersion,
+		Percent:       percent,
+	}
+	if err := s.saveCanary(workflowID, canary); err != nil {
+		return nil, err
+	}
+	return canary, nil
+}
+
+// PromoteCanary ends the rollout with the canary version taking all
+// traffic; returns the promoted state for display
+func (s *WorkflowService) PromoteCanary(workflowID string) (*WorkflowCanary, error) {
+	canary, err := s.GetCanary(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if canary == nil {
+		return nil, fmt.Errorf("no canary rollout in progress for workflow %s", workflowID)
+	}
+	if err := s.repos.Settings.Delete(workflowCanarySettingPrefix + workflowID); err != nil {
+		return nil, fmt.Errorf("failed to clear canary state: %w", err)
+	}
+	return canary, nil
+}
+
+// RollbackCanary ends the rollout by re-publishing the stable version's
+// definition as a new latest version, so all new runs (including callers
+// that pin no version) go back to the pre-canary behavior
+func (s *WorkflowService) RollbackCanary(ctx context.Context, workflowID string) (*models.WorkflowDefinition, error) {
+	canary, err := s.GetCanary(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if canary == nil {
+		return nil, fmt.Errorf("no canary rollout in progress for workflow %s", workflowID)
+	}
+
+	stable, err := s.repos.Workflows.Get(ctx, workflowID, canary.StableVersion)
+	if err != nil {
+		return nil, fmt.Errorf("stable version v%d not found: %w", canary.StableVersion, err)
+	}
+
+	nextVersion, err := s.repos.Workflows.GetNextVersion(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine next version: %w", err)
+	}
+
+	description := ""
+	if stable.Description != nil {
+		description = *stable.Description
+	}
+	restored, err := s.repos.Workflows.Insert(ctx, workflowID, stable.Name, description, nextVersion, stable.Definition, stable.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore stable definition: %w", err)
+	}
+
+	if err := s.repos.Settings.Delete(workflowCanarySettingPrefix + workflowID); err != nil {
+		return nil, fmt.Errorf("failed to clear canary state: %w", err)
+	}
+	return restored, nil
+}
+
+// resolveCanaryVersion picks the version a new run should execute when the
+// caller did not pin one. The run ID is hashed into a 0-99 bucket so the
+// assignment is deterministic and the split converges on the configured
+// percentage. Returns 0 when no canary rollout is active.
+func (s *WorkflowService) resolveCanaryVersion(workflowID, runID string) int64 {
+	canary, err := s.GetCanary(workflowID)
+	if err != nil || canary == nil {
+		return 0
+	}
+	if canaryBucket(runID) < canary.Percent {
+		return canary.CanaryVersion
+	}
+	return canary.StableVersion
+}
+
+// canaryBucket maps a run ID to a stable bucket in [0, 100)
+func canaryBucket(runID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(runID))
+	return int(h.Sum32() % 100)
+}
+
+// VersionMetrics aggregates recent run outcomes per workflow version,
+// newest version first, so canary and stable behavior can be compared
+func (s *WorkflowService) VersionMetrics(ctx context.Context, workflowID string) ([]WorkflowVersionMetrics, error) {
+	runs, err := s.repos.WorkflowRuns.List(ctx, workflowID, "", canaryMetricsWindow, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+
+	byVersion := make(map[int64]*WorkflowVersionMetrics)
+	for _, run := range runs {
+		metrics, exists := byVersion[run.WorkflowVersion]
+		if !exists {
+			metrics = &WorkflowVersionMetrics{Version: run.WorkflowVersion}
+			byVersion[run.WorkflowVersion] = metrics
+		}
+		metrics.Runs++
+		switch run.Status {
+		case "completed":
+			metrics.Succeeded++
+		case "failed":
+			metrics.Failed++
+		}
+	}
+
+	result := make([]WorkflowVersionMetrics, 0, len(byVersion))
+	for _, metrics := range byVersion {
+		result = append(result, *metrics)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version > result[j].Version })
+	return result, nil
+}
+
+// saveCanary persists canary state to the settings table
+func (s *WorkflowService) saveCanary(workflowID string, canary *WorkflowCanary) error {
+	payload, err := json.Marshal(canary)
+	if err != nil {
+		return err
+	}
+	return s.repos.Settings.Set(workflowCanarySettingPrefix+workflowID, string(payload),
+		fmt.Sprintf("Canary rollout state for workflow %s", workflowID))
+}
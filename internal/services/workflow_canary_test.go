@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+)
+
+func canaryTestDefinition(transition string) json.RawMessage {
+	return json.RawMessage(`{
+		"id": "canary-workflow",
+		"start": "start",
+		"states": [
+			{"id":"start","type":"operation","input":{},"output":{},"timeout":"5m","retry":{"max_attempts":1},"transition":"` + transition + `"},
+			{"id":"` + transition + `","type":"operation","input":{},"output":{},"timeout":"1m","retry":{"max_attempts":1}}
+		]
+	}`)
+}
+
+func setupCanaryWorkflow(t *testing.T) (*WorkflowService, context.Context) {
+	t.Helper()
+	ctx := context.Background()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	t.Cleanup(func() { testDB.Close() })
+
+	repos := repositories.New(testDB)
+	svc := NewWorkflowService(repos)
+
+	if _, _, err := svc.CreateWorkflow(ctx, WorkflowDefinitionInput{
+		WorkflowID: "canary-workflow",
+		Name:       "Canary Workflow",
+		Definition: canaryTestDefinition("v1_step"),
+	}); err != nil {
+		t.Fatalf("CreateWorkflow returned error: %v", err)
+	}
+	if _, _, err := svc.UpdateWorkflow(ctx, WorkflowDefinitionInput{
+		WorkflowID: "canary-workflow",
+		Name:       "Canary Workflow",
+		Definition: canaryTestDefinition("v2_step"),
+	}); err != nil {
+		t.Fatalf("UpdateWorkflow returned error: %v", err)
+	}
+	return svc, ctx
+}
+
+func TestStartCanaryRequiresTwoVersions(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	svc := NewWorkflowService(repos)
+
+	if _, _, err := svc.CreateWorkflow(ctx, WorkflowDefinitionInput{
+		WorkflowID: "canary-workflow",
+		Name:       "Canary Workflow",
+		Definition: canaryTestDefinition("v1_step"),
+	}); err != nil {
+		t.Fatalf("CreateWorkflow returned error: %v", err)
+	}
+
+	if _, err := svc.StartCanary(ctx, "canary-workflow", 20); err == nil {
+		t.Fatal("expected StartCanary to fail with a single version")
+	}
+}
+
+func TestStartCanaryValidatesPercent(t *testing.T) {
+	svc, ctx := setupCanaryWorkflow(t)
+
+	for _, percent := range []int{0, 100, -5} {
+		if _, err := svc.StartCanary(ctx, "canary-workflow", percent); err == nil {
+			t.Errorf("expected StartCanary to reject percent %d", percent)
+		}
+	}
+}
+
+func TestCanaryVersionResolution(t *testing.T) {
+	svc, ctx := setupCanaryWorkflow(t)
+
+	canary, err := svc.StartCanary(ctx, "canary-workflow", 50)
+	if err != nil {
+		t.Fatalf("StartCanary returned error: %v", err)
+	}
+	if canary.CanaryVersion != 2 || canary.StableVersion != 1 {
+		t.Fatalf("expected canary v2 / stable v1, got %+v", canary)
+	}
+
+	// The bucket assignment is deterministic per run ID and both versions
+	// must be reachable at a 50% split
+	sawCanary, sawStable := false, false
+	runIDs := []string{"run-a", "run-b", "run-c", "run-d", "run-e", "run-f", "run-g", "run-h"}
+	for _, runID := range runIDs {
+		version := svc.resolveCanaryVersion("canary-workflow", runID)
+		if version != svc.resolveCanaryVersion("canary-workflow", runID) {
+			t.Fatalf("version resolution not deterministic for run %s", runID)
+		}
+		switch version {
+		case 2:
+			sawCanary = true
+		case 1:
+			sawStable = true
+		default:
+			t.Fatalf("unexpected version %d for run %s", version, runID)
+		}
+	}
+	if !sawCanary || !sawStable {
+		t.Errorf("expected both versions at 50%% split (canary=%v stable=%v)", sawCanary, sawStable)
+	}
+
+	// Pinned runs and workflows without a rollout are untouched
+	if version := svc.resolveCanaryVersion("other-workflow", "run-a"); version != 0 {
+		t.Errorf("expected no canary version for workflow without rollout, got %d", version)
+	}
+}
+
+func TestPromoteCanary(t *testing.T) {
+	svc, ctx := setupCanaryWorkflow(t)
+
+	if _, err := svc.StartCanary(ctx, "canary-workflow", 20); err != nil {
+		t.Fatalf("StartCanary returned error: %v", err)
+	}
+
+	promoted, err := svc.PromoteCanary("canary-workflow")
+	if err != nil {
+		t.Fatalf("PromoteCanary returned error: %v", err)
+	}
+	if promoted.CanaryVersion != 2 {
+		t.Fatalf("expected promoted canary version 2, got %d", promoted.CanaryVersion)
+	}
+
+	if canary, _ := svc.GetCanary("canary-workflow"); canary != nil {
+		t.Fatal("expected canary state to be cleared after promote")
+	}
+	if _, err := svc.PromoteCanary("canary-workflow"); err == nil {
+		t.Fatal("expected PromoteCanary to fail when no rollout is in progress")
+	}
+}
+
+func TestRollbackCanaryRestoresStableDefinition(t *testing.T) {
+	svc, ctx := setupCanaryWorkflow(t)
+
+	if _, err := svc.StartCanary(ctx, "canary-workflow", 20); err != nil {
+		t.Fatalf("StartCanary returned error: %v", err)
+	}
+
+	restored, err := svc.RollbackCanary(ctx, "canary-workflow")
+	if err != nil {
+		t.Fatalf("RollbackCanary returned error: %v", err)
+	}
+	if restored.Version != 3 {
+		t.Fatalf("expected stable definition re-published as v3, got v%d", restored.Version)
+	}
+
+	stable, err := svc.GetWorkflow(ctx, "canary-workflow", 1)
+	if err != nil {
+		t.Fatalf("failed to load stable version: %v", err)
+	}
+	if string(restored.Definition) != string(stable.Definition) {
+		t.Error("expected restored definition to match the stable version")
+	}
+
+	if canary, _ := svc.GetCanary("canary-workflow"); canary != nil {
+		t.Fatal("expected canary state to be cleared after rollback")
+	}
+}
+
+func TestCanaryBucketRange(t *testing.T) {
+	for _, runID := range []string{"", "a", "run-123", "2f1d9c4e-8b11-4c6a-9d3e-7f20a1b5c688"} {
+		bucket := canaryBucket(runID)
+		if bucket < 0 || bucket >= 100 {
+			t.Errorf("canaryBucket(%q) = %d, want [0, 100)", runID, bucket)
+		}
+	}
+}
@@ -47,6 +47,14 @@ type SignalWorkflowRunRequest struct {
 	Payload json.RawMessage
 }
 
+// DeliverWorkflowEventRequest represents a named external event delivered to
+// a run, typically to wake an await_event step.
+type DeliverWorkflowEventRequest struct {
+	RunID   string
+	Name    string
+	Payload json.RawMessage
+}
+
 // StepUpdate captures step progress updates.
 type StepUpdate struct {
 	RunID       string
@@ -275,7 +283,22 @@ func (s *WorkflowService) DeleteWorkflows(ctx context.Context, req DeleteWorkflo
 }
 
 func (s *WorkflowService) StartRun(ctx context.Context, req StartWorkflowRunRequest) (*models.WorkflowRun, workflows.ValidationResult, error) {
-	definition, err := s.GetWorkflow(ctx, req.WorkflowID, req.Version)
+	// New workflow runs are rejected while the server drains for a restart;
+	// in-flight runs and queued JetStream work are unaffected
+	if SharedDrainCoordinator().IsDraining() {
+		return nil, workflows.ValidationResult{}, ErrServerDraining
+	}
+
+	runID := uuid.NewString()
+
+	// When the caller does not pin a version and a canary rollout is active,
+	// the run ID's bucket decides between the canary and stable versions
+	version := req.Version
+	if version == 0 {
+		version = s.resolveCanaryVersion(req.WorkflowID, runID)
+	}
+
+	definition, err := s.GetWorkflow(ctx, req.WorkflowID, version)
 	if err != nil {
 		return nil, workflows.ValidationResult{}, err
 	}
@@ -288,36 +311,12 @@ func (s *WorkflowService) StartRun(ctx context.Context, req StartWorkflowRunRequ
 		return nil, validation, workflows.ErrValidation
 	}
 
-	if parsed.InputSchema != nil && len(parsed.InputSchema) > 0 {
-		var inputData map[string]interface{}
-		if len(req.Input) > 0 {
-			if err := json.Unmarshal(req.Input, &inputData); err != nil {
-				validation.Errors = append(validation.Errors, workflows.ValidationIssue{
-					Code:    "INVALID_INPUT_JSON",
-					Path:    "/input",
-					Message: fmt.Sprintf("Failed to parse input JSON: %v", err),
-					Hint:    "Provide valid JSON for the workflow input",
-				})
-				return nil, validation, workflows.ErrValidation
-			}
-		} else {
-			inputData = make(map[string]interface{})
-		}
-
-		schemaJSON, _ := json.Marshal(parsed.InputSchema)
-		if err := workflows.ValidateInputAgainstSchema(inputData, string(schemaJSON)); err != nil {
-			validation.Errors = append(validation.Errors, workflows.ValidationIssue{
-				Code:    "INPUT_SCHEMA_VIOLATION",
-				Path:    "/input",
-				Message: err.Error(),
-				Hint:    "Ensure the workflow input matches the defined inputSchema",
-			})
-			return nil, validation, workflows.ErrValidation
-		}
+	if inputIssues := workflows.ValidateRunInput(req.Input, parsed.InputSchema); len(inputIssues) > 0 {
+		validation.Errors = append(validation.Errors, inputIssues...)
+		return nil, validation, workflows.ErrValidation
 	}
 
 	startStep := parsed.Start
-	runID := uuid.NewString()
 	now := time.Now()
 
 	if s.telemetry != nil {
@@ -496,6 +495,66 @@ func (s *WorkflowService) SignalRun(ctx context.Context, req SignalWorkflowRunRe
 	return s.repos.WorkflowRuns.Get(ctx, req.RunID)
 }
 
+// DeliverEvent records a named external event in the run's context and, when
+// the run is blocked on an await_event step, re-publishes the current step so
+// it observes the delivery and continues. Events delivered before their
+// await_event step executes are kept, letting the step complete immediately.
+func (s *WorkflowService) DeliverEvent(ctx context.Context, req DeliverWorkflowEventRequest) (*models.WorkflowRun, error) {
+	run, err := s.repos.WorkflowRuns.Get(ctx, req.RunID)
+	if err != nil {
+		return nil, err
+	}
+
+	runContext := make(map[string]interface{})
+	if len(run.Context) > 0 {
+		if err := json.Unmarshal(run.Context, &runContext); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal run context: %w", err)
+		}
+	}
+
+	deliveredEvents, ok := runContext["_events"].(map[string]interface{})
+	if !ok {
+		deliveredEvents = make(map[string]interface{})
+	}
+	delivered := map[string]interface{}{
+		"delivered_at": time.Now().UTC().Format(time.RFC3339),
+	}
+	if len(req.Payload) > 0 {
+		var payload interface{}
+		if err := json.Unmarshal(req.Payload, &payload); err == nil {
+			delivered["payload"] = payload
+		}
+	}
+	deliveredEvents[req.Name] = delivered
+	runContext["_events"] = deliveredEvents
+
+	contextJSON, err := json.Marshal(runContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal run context: %w", err)
+	}
+
+	if err := s.repos.WorkflowRuns.Update(ctx, repositories.UpdateWorkflowRunParams{
+		RunID:   req.RunID,
+		Status:  run.Status,
+		Context: contextJSON,
+	}); err != nil {
+		return nil, err
+	}
+
+	_ = s.emitRunEvent(ctx, req.RunID, map[string]interface{}{
+		"type":    models.EventTypeEventReceived,
+		"event":   req.Name,
+		"payload": string(req.Payload),
+		"time":    time.Now().UTC().Format(time.RFC3339),
+	})
+
+	if run.Status == "waiting_event" && run.CurrentStep != nil {
+		s.republishStep(ctx, req.RunID, *run.CurrentStep)
+	}
+
+	return s.repos.WorkflowRuns.Get(ctx, req.RunID)
+}
+
 func (s *WorkflowService) PauseRun(ctx context.Context, runID, reason string) (*models.WorkflowRun, error) {
 	if reason == "" {
 		reason = "Run paused"
@@ -885,6 +944,37 @@ func (s *WorkflowService) resumeAfterApproval(ctx context.Context, runID, stepID
 	_ = s.engine.PublishStepWithTrace(ctx, runID, step.Next, nextStep)
 }
 
+// republishStep re-schedules an existing step so its executor runs again,
+// used to wake await_event steps once their event arrives.
+func (s *WorkflowService) republishStep(ctx context.Context, runID, stepID string) {
+	if s.engine == nil {
+		return
+	}
+
+	run, err := s.repos.WorkflowRuns.Get(ctx, runID)
+	if err != nil {
+		return
+	}
+
+	definition, err := s.repos.Workflows.Get(ctx, run.WorkflowID, run.WorkflowVersion)
+	if err != nil {
+		return
+	}
+
+	parsed, _, err := workflows.ValidateDefinition(definition.Definition)
+	if err != nil || parsed == nil {
+		return
+	}
+
+	plan := workflows.CompileExecutionPlan(parsed)
+	step, exists := plan.Steps[stepID]
+	if !exists {
+		return
+	}
+
+	_ = s.engine.PublishStepWithTrace(ctx, runID, stepID, step)
+}
+
 type WorkflowSyncResult struct {
 	WorkflowsProcessed int
 	WorkflowsSynced    int
@@ -3,10 +3,12 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"station/internal/db"
 	"station/internal/db/repositories"
+	"station/internal/workflows"
 )
 
 func TestWorkflowServiceCreateAndStartRun(t *testing.T) {
@@ -300,3 +302,67 @@ func TestWorkflowServiceListApprovalsByRun(t *testing.T) {
 		t.Errorf("expected 3 approvals, got %d", len(approvals))
 	}
 }
+
+func TestWorkflowServiceStartRunRejectsInvalidInput(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.NewTest(t)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer testDB.Close()
+
+	repos := repositories.New(testDB)
+	svc := NewWorkflowService(repos)
+
+	definition := json.RawMessage(`{
+		"id": "schema-workflow",
+		"start": "start",
+		"inputSchema": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"name": {"type": "string"},
+				"retries": {"type": "integer"}
+			}
+		},
+		"states": [
+			{"id":"start","type":"operation","input":{},"output":{},"timeout":"5m","retry":{"max_attempts":1},"end":true}
+		]
+	}`)
+
+	_, _, err = svc.CreateWorkflow(ctx, WorkflowDefinitionInput{
+		WorkflowID: "schema-workflow",
+		Name:       "Schema Workflow",
+		Definition: definition,
+	})
+	if err != nil {
+		t.Fatalf("CreateWorkflow returned error: %v", err)
+	}
+
+	run, validation, err := svc.StartRun(ctx, StartWorkflowRunRequest{
+		WorkflowID: "schema-workflow",
+		Input:      json.RawMessage(`{"retries": "three"}`),
+	})
+	if !errors.Is(err, workflows.ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v (run: %+v)", err, run)
+	}
+	if len(validation.Errors) != 2 {
+		t.Fatalf("expected 2 input schema errors, got %+v", validation.Errors)
+	}
+	for _, issue := range validation.Errors {
+		if issue.Code != "INPUT_SCHEMA_VIOLATION" {
+			t.Errorf("unexpected issue code %q: %+v", issue.Code, issue)
+		}
+	}
+
+	run, validation, err = svc.StartRun(ctx, StartWorkflowRunRequest{
+		WorkflowID: "schema-workflow",
+		Input:      json.RawMessage(`{"name": "demo", "retries": 3}`),
+	})
+	if err != nil {
+		t.Fatalf("StartRun with valid input returned error: %v (validation: %+v)", err, validation)
+	}
+	if run == nil {
+		t.Fatal("expected run to start with valid input")
+	}
+}
@@ -67,9 +67,14 @@ func (s *Server) createSSHServer() *ssh.Server {
 			}),
 		)
 	} else {
-		// Remote mode: System user authentication
+		// Remote mode: Station-managed per-user keys (stn ssh-keys) first,
+		// then system user authorized_keys
 		options = append(options,
 			wish.WithPublicKeyAuth(func(ctx ssh.Context, key ssh.PublicKey) bool {
+				if services.NewSSHKeyService(s.repos).Authenticate(ctx.User(), key) {
+					log.Printf("SSH: Station-managed key authenticated for user: %s", ctx.User())
+					return true
+				}
 				return s.authenticateSystemUserKey(ctx.User(), key)
 			}),
 			wish.WithPasswordAuth(func(ctx ssh.Context, password string) bool {
@@ -98,8 +103,19 @@ func (s *Server) teaHandler(session ssh.Session) (tea.Model, []tea.ProgramOption
 	// For now, we'll default to the new chat interface
 	// TODO: Add configuration option to choose between chat and traditional TUI
 
+	// Record admin actions taken during this session to the audit log
+	username := session.User()
+	auditService := services.NewSSHAuditService(s.repos)
+	if err := auditService.Record(username, "session started"); err != nil {
+		log.Printf("SSH: Failed to record session start for %s: %v", username, err)
+	}
+
 	// Create the new chat TUI model
-	chatModel := tui.NewChatModel(s.db, s.repos, s.genkitService)
+	chatModel := tui.NewChatModel(s.db, s.repos, s.genkitService).WithAudit(username, func(action string) {
+		if err := auditService.Record(username, action); err != nil {
+			log.Printf("SSH: Failed to record action for %s: %v", username, err)
+		}
+	})
 
 	return chatModel, []tea.ProgramOption{
 		tea.WithAltScreen(),
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -18,6 +19,14 @@ type Model struct {
 	content       string
 	width         int
 	height        int
+
+	// Command palette state (toggled with ctrl+k)
+	username      string
+	recordAudit   func(action string)
+	actions       []paletteAction
+	paletteOpen   bool
+	paletteQuery  string
+	paletteCursor int
 }
 
 func NewChatModel(database *db.DB, repos *repositories.Repositories, genkitService services.AgentServiceInterface) *Model {
@@ -25,7 +34,8 @@ func NewChatModel(database *db.DB, repos *repositories.Repositories, genkitServi
 		db:            database,
 		repos:         repos,
 		genkitService: genkitService,
-		content:       "Welcome to Station SSH Interface\n\nPress 'q' to quit",
+		content:       "Welcome to Station SSH Interface\n\nPress ctrl+k for the command palette, 'q' to quit",
+		actions:       paletteActions(),
 	}
 }
 
@@ -39,10 +49,19 @@ func NewModel(database *db.DB, genkitService services.AgentServiceInterface) *Mo
 		db:            database,
 		repos:         repos,
 		genkitService: genkitService,
-		content:       "Welcome to Station TUI\n\nPress 'q' to quit",
+		content:       "Welcome to Station TUI\n\nPress ctrl+k for the command palette, 'q' to quit",
+		actions:       paletteActions(),
 	}
 }
 
+// WithAudit attributes the session to a user and records each palette action
+// (and session lifecycle) through the given recorder
+func (m *Model) WithAudit(username string, recordAudit func(action string)) *Model {
+	m.username = username
+	m.recordAudit = recordAudit
+	return m
+}
+
 func (m *Model) Init() tea.Cmd {
 	return nil
 }
@@ -53,9 +72,60 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 	case tea.KeyMsg:
+		if m.paletteOpen {
+			return m.updatePalette(msg)
+		}
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "ctrl+k":
+			m.openPalette()
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) openPalette() {
+	m.paletteOpen = true
+	m.paletteQuery = ""
+	m.paletteCursor = 0
+}
+
+// updatePalette handles key input while the command palette is open
+func (m *Model) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := filterPaletteActions(m.actions, m.paletteQuery)
+
+	switch msg.String() {
+	case "esc", "ctrl+k":
+		m.paletteOpen = false
+	case "ctrl+c":
+		return m, tea.Quit
+	case "up":
+		if m.paletteCursor > 0 {
+			m.paletteCursor--
+		}
+	case "down":
+		if m.paletteCursor < len(matches)-1 {
+			m.paletteCursor++
+		}
+	case "enter":
+		if m.paletteCursor < len(matches) {
+			action := matches[m.paletteCursor]
+			if m.recordAudit != nil {
+				m.recordAudit(action.Name)
+			}
+			m.content = action.run(m)
+		}
+		m.paletteOpen = false
+	case "backspace":
+		if len(m.paletteQuery) > 0 {
+			m.paletteQuery = m.paletteQuery[:len(m.paletteQuery)-1]
+			m.paletteCursor = 0
+		}
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.paletteQuery += string(msg.Runes)
+			m.paletteCursor = 0
 		}
 	}
 	return m, nil
@@ -69,5 +139,34 @@ func (m *Model) View() string {
 
 	content := fmt.Sprintf("%s\n\nTerminal size: %dx%d", m.content, m.width, m.height)
 
+	if m.paletteOpen {
+		content += "\n\n" + m.renderPalette()
+	}
+
 	return style.Render(content)
 }
+
+// renderPalette renders the command palette with the current query and
+// fuzzy-matched actions
+func (m *Model) renderPalette() string {
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575")).Bold(true)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Command palette › %s▌\n", m.paletteQuery))
+
+	matches := filterPaletteActions(m.actions, m.paletteQuery)
+	if len(matches) == 0 {
+		builder.WriteString("  (no matching actions)\n")
+		return builder.String()
+	}
+
+	for i, action := range matches {
+		line := fmt.Sprintf("%s — %s", action.Name, action.Description)
+		if i == m.paletteCursor {
+			builder.WriteString("  " + selectedStyle.Render("› "+line) + "\n")
+		} else {
+			builder.WriteString("    " + line + "\n")
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// paletteAction is one entry in the SSH interface command palette
+type paletteAction struct {
+	Name        string
+	Description string
+	run         func(m *Model) string
+}
+
+// paletteActions lists everything the command palette can do. Actions render
+// their result into the model's content area.
+func paletteActions() []paletteAction {
+	return []paletteAction{
+		{
+			Name:        "List Agents",
+			Description: "Show all agents across environments",
+			run:         runListAgents,
+		},
+		{
+			Name:        "List Environments",
+			Description: "Show all environments",
+			run:         runListEnvironments,
+		},
+		{
+			Name:        "Recent Runs",
+			Description: "Show the most recent agent runs",
+			run:         runRecentRuns,
+		},
+		{
+			Name:        "Help",
+			Description: "Show key bindings",
+			run: func(m *Model) string {
+				return "Key bindings:\n\n  ctrl+k  open command palette\n  q       quit"
+			},
+		},
+	}
+}
+
+func runListAgents(m *Model) string {
+	if m.repos == nil {
+		return "Database not available"
+	}
+	agents, err := m.repos.Agents.List()
+	if err != nil {
+		return fmt.Sprintf("Failed to list agents: %v", err)
+	}
+	if len(agents) == 0 {
+		return "No agents found"
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Agents (%d):\n\n", len(agents)))
+	for _, agent := range agents {
+		builder.WriteString(fmt.Sprintf("  [%d] %s — %s\n", agent.ID, agent.Name, agent.Description))
+	}
+	return builder.String()
+}
+
+func runListEnvironments(m *Model) string {
+	if m.repos == nil {
+		return "Database not available"
+	}
+	environments, err := m.repos.Environments.List()
+	if err != nil {
+		return fmt.Sprintf("Failed to list environments: %v", err)
+	}
+	if len(environments) == 0 {
+		return "No environments found"
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Environments (%d):\n\n", len(environments)))
+	for _, env := range environments {
+		builder.WriteString(fmt.Sprintf("  [%d] %s\n", env.ID, env.Name))
+	}
+	return builder.String()
+}
+
+func runRecentRuns(m *Model) string {
+	if m.repos == nil {
+		return "Database not available"
+	}
+	runs, err := m.repos.AgentRuns.ListRecent(context.Background(), 10)
+	if err != nil {
+		return fmt.Sprintf("Failed to list runs: %v", err)
+	}
+	if len(runs) == 0 {
+		return "No runs found"
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Recent runs (%d):\n\n", len(runs)))
+	for _, run := range runs {
+		builder.WriteString(fmt.Sprintf("  [%d] %s — %s\n", run.ID, run.AgentName, run.Status))
+	}
+	return builder.String()
+}
+
+// paletteMatch pairs an action with its fuzzy score for ranking
+type paletteMatch struct {
+	action paletteAction
+	score  int
+}
+
+// filterPaletteActions ranks actions against the query with fuzzy matching.
+// An empty query returns every action in declaration order.
+func filterPaletteActions(actions []paletteAction, query string) []paletteAction {
+	if strings.TrimSpace(query) == "" {
+		return actions
+	}
+
+	matches := make([]paletteMatch, 0, len(actions))
+	for _, action := range actions {
+		score, ok := fuzzyScore(query, action.Name)
+		if !ok {
+			// Fall back to matching against the description
+			score, ok = fuzzyScore(query, action.Description)
+		}
+		if ok {
+			matches = append(matches, paletteMatch{action: action, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	filtered := make([]paletteAction, 0, len(matches))
+	for _, match := range matches {
+		filtered = append(filtered, match.action)
+	}
+	return filtered
+}
+
+// fuzzyScore reports whether query is a case-insensitive subsequence of
+// target, scoring consecutive matches and word-start matches higher
+func fuzzyScore(query, target string) (int, bool) {
+	queryRunes := []rune(strings.ToLower(query))
+	targetRunes := []rune(strings.ToLower(target))
+	originalRunes := []rune(target)
+
+	score := 0
+	queryIdx := 0
+	lastMatch := -2
+	for targetIdx := 0; targetIdx < len(targetRunes) && queryIdx < len(queryRunes); targetIdx++ {
+		if targetRunes[targetIdx] != queryRunes[queryIdx] {
+			continue
+		}
+		score++
+		if targetIdx == lastMatch+1 {
+			score += 2 // consecutive characters
+		}
+		if targetIdx == 0 || unicode.IsSpace(originalRunes[targetIdx-1]) {
+			score += 3 // start of a word
+		}
+		lastMatch = targetIdx
+		queryIdx++
+	}
+
+	if queryIdx < len(queryRunes) {
+		return 0, false
+	}
+	return score, true
+}
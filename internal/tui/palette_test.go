@@ -0,0 +1,54 @@
+package tui
+
+import "testing"
+
+// TestFuzzyScore tests the palette's subsequence matcher
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore("agents", "List Agents"); !ok {
+		t.Error("'agents' should match 'List Agents'")
+	}
+	if _, ok := fuzzyScore("la", "List Agents"); !ok {
+		t.Error("subsequence 'la' should match 'List Agents'")
+	}
+	if _, ok := fuzzyScore("runs", "List Agents"); ok {
+		t.Error("'runs' should not match 'List Agents'")
+	}
+	if _, ok := fuzzyScore("AGENTS", "list agents"); !ok {
+		t.Error("matching should be case-insensitive")
+	}
+
+	// Word-start matches score higher than scattered subsequence matches
+	wordStart, _ := fuzzyScore("list", "List Agents")
+	scattered, _ := fuzzyScore("lst", "List Agents")
+	if wordStart <= scattered {
+		t.Errorf("word-start match (%d) should outscore scattered match (%d)", wordStart, scattered)
+	}
+}
+
+// TestFilterPaletteActions tests ranking and the empty-query passthrough
+func TestFilterPaletteActions(t *testing.T) {
+	actions := []paletteAction{
+		{Name: "List Agents", Description: "Show all agents across environments"},
+		{Name: "List Environments", Description: "Show all environments"},
+		{Name: "Recent Runs", Description: "Show the most recent agent runs"},
+	}
+
+	if got := filterPaletteActions(actions, ""); len(got) != len(actions) {
+		t.Errorf("empty query should return all actions, got %d", len(got))
+	}
+
+	matches := filterPaletteActions(actions, "runs")
+	if len(matches) == 0 || matches[0].Name != "Recent Runs" {
+		t.Errorf("query 'runs' should rank 'Recent Runs' first, got %v", matches)
+	}
+
+	// Queries matching only a description still surface the action
+	matches = filterPaletteActions(actions, "most recent")
+	if len(matches) == 0 || matches[0].Name != "Recent Runs" {
+		t.Errorf("description match should surface 'Recent Runs', got %v", matches)
+	}
+
+	if got := filterPaletteActions(actions, "zzzz"); len(got) != 0 {
+		t.Errorf("unmatched query should return nothing, got %v", got)
+	}
+}
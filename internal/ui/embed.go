@@ -1,5 +1,3 @@
-//go:build ui
-
 package ui
 
 import (
@@ -8,9 +6,11 @@ import (
 	"net/http"
 )
 
-// Embed the UI build files when building with -tags ui
+// The management UI build output is embedded directly into the stn binary so
+// 'stn serve' provides the complete experience without a separate frontend
+// deployment. Run 'make build-ui' to refresh static/ from the ui/ sources.
 //
-//go:embed static
+//go:embed all:static
 var uiFiles embed.FS
 
 // GetFileSystem returns the embedded UI filesystem
@@ -23,7 +23,13 @@ func GetFileSystem() (http.FileSystem, error) {
 	return http.FS(fsys), nil
 }
 
-// IsEmbedded returns true when UI is embedded (build tag enabled)
+// IsEmbedded reports whether a usable UI build is present in the binary.
+// The static directory always exists, but a UI needs at least its index.html.
 func IsEmbedded() bool {
+	file, err := uiFiles.Open("static/index.html")
+	if err != nil {
+		return false
+	}
+	_ = file.Close()
 	return true
 }
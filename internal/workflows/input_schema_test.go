@@ -0,0 +1,96 @@
+package workflows
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateRunInputNoSchema(t *testing.T) {
+	if issues := ValidateRunInput(json.RawMessage(`{"anything": true}`), nil); issues != nil {
+		t.Errorf("expected no issues without a schema, got %v", issues)
+	}
+}
+
+func TestValidateRunInputValid(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"name"},
+		"properties": map[string]any{
+			"name":    map[string]any{"type": "string"},
+			"retries": map[string]any{"type": "integer"},
+		},
+	}
+	input := json.RawMessage(`{"name": "demo", "retries": 3}`)
+	if issues := ValidateRunInput(input, schema); len(issues) != 0 {
+		t.Errorf("expected valid input, got %v", issues)
+	}
+}
+
+func TestValidateRunInputReportsAllViolationsWithPointers(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"name"},
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string"},
+			"config": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"retries": map[string]any{"type": "integer"},
+				},
+			},
+		},
+	}
+	input := json.RawMessage(`{"config": {"retries": "three"}}`)
+
+	issues := ValidateRunInput(input, schema)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues (missing name, wrong retries type), got %v", issues)
+	}
+
+	paths := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.Code != "INPUT_SCHEMA_VIOLATION" {
+			t.Errorf("unexpected code %q in %v", issue.Code, issue)
+		}
+		paths[issue.Path] = true
+	}
+	if !paths["/input"] {
+		t.Errorf("expected /input pointer for missing required field, got %v", paths)
+	}
+	if !paths["/input/config/retries"] {
+		t.Errorf("expected /input/config/retries pointer, got %v", paths)
+	}
+}
+
+func TestValidateRunInputEmptyInputChecksRequired(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"name"},
+	}
+	issues := ValidateRunInput(nil, schema)
+	if len(issues) != 1 || issues[0].Code != "INPUT_SCHEMA_VIOLATION" {
+		t.Fatalf("expected one required-field violation for empty input, got %v", issues)
+	}
+}
+
+func TestValidateRunInputMalformedJSON(t *testing.T) {
+	schema := map[string]any{"type": "object"}
+	issues := ValidateRunInput(json.RawMessage(`{"name": `), schema)
+	if len(issues) != 1 || issues[0].Code != "INVALID_INPUT_JSON" {
+		t.Fatalf("expected INVALID_INPUT_JSON, got %v", issues)
+	}
+	if issues[0].Path != "/input" {
+		t.Errorf("expected /input path, got %q", issues[0].Path)
+	}
+}
+
+func TestValidateRunInputInvalidSchema(t *testing.T) {
+	schema := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"name": map[string]any{"type": 42}},
+	}
+	issues := ValidateRunInput(json.RawMessage(`{}`), schema)
+	if len(issues) != 1 || issues[0].Code != "INVALID_INPUT_SCHEMA" {
+		t.Fatalf("expected INVALID_INPUT_SCHEMA, got %v", issues)
+	}
+}
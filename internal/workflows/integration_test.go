@@ -415,9 +415,11 @@ func TestStepTypeClassification(t *testing.T) {
 		{"sleep", "", workflows.StepTypeTimer},
 		{"delay", "", workflows.StepTypeTimer},
 		{"timer", "", workflows.StepTypeTimer},
+		{"wait", "", workflows.StepTypeTimer},
 		{"await", "", workflows.StepTypeAwait},
 		{"await.signal", "", workflows.StepTypeAwait},
-		{"await.event", "", workflows.StepTypeAwait},
+		{"await.event", "", workflows.StepTypeEvent},
+		{"await_event", "", workflows.StepTypeEvent},
 		{"try", "", workflows.StepTypeTryCatch},
 		{"inject", "", workflows.StepTypeContextOp},
 		{"set", "", workflows.StepTypeContextOp},
@@ -256,6 +256,34 @@ func (a *WorkflowServiceAdapter) RecordStepWaiting(ctx context.Context, runID, s
 	})
 }
 
+func (a *WorkflowServiceAdapter) RecordStepWaitingTimer(ctx context.Context, runID, stepID string, resumeAt string) error {
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"resume_at": resumeAt,
+	})
+
+	return a.repos.WorkflowRunSteps.Update(ctx, repositories.UpdateWorkflowRunStepParams{
+		RunID:    runID,
+		StepID:   stepID,
+		Attempt:  1,
+		Status:   "waiting_timer",
+		Metadata: metadata,
+	})
+}
+
+func (a *WorkflowServiceAdapter) RecordStepWaitingEvent(ctx context.Context, runID, stepID string, eventName string) error {
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"event": eventName,
+	})
+
+	return a.repos.WorkflowRunSteps.Update(ctx, repositories.UpdateWorkflowRunStepParams{
+		RunID:    runID,
+		StepID:   stepID,
+		Attempt:  1,
+		Status:   "waiting_event",
+		Metadata: metadata,
+	})
+}
+
 func (a *WorkflowServiceAdapter) GetExecutionPlan(ctx context.Context, runID string) (workflows.ExecutionPlan, error) {
 	if plan, ok := a.GetCachedPlan(runID); ok {
 		return plan, nil
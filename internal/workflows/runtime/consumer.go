@@ -12,6 +12,7 @@ import (
 	"github.com/nats-io/nats.go"
 	"go.opentelemetry.io/otel/trace"
 
+	"station/internal/events"
 	"station/internal/workflows"
 )
 
@@ -38,6 +39,8 @@ type StepRecorder interface {
 	RecordStepComplete(ctx context.Context, runID, stepID string, output map[string]interface{}) error
 	RecordStepFailed(ctx context.Context, runID, stepID string, errMsg string) error
 	RecordStepWaiting(ctx context.Context, runID, stepID string, approvalID string) error
+	RecordStepWaitingTimer(ctx context.Context, runID, stepID string, resumeAt string) error
+	RecordStepWaitingEvent(ctx context.Context, runID, stepID string, eventName string) error
 }
 
 type StepProvider interface {
@@ -289,6 +292,14 @@ func (c *WorkflowConsumer) executeStep(ctx context.Context, runID string, step w
 		if c.telemetry != nil {
 			c.telemetry.EndStepSpan(asTraceSpan(stepSpan), step.Type, StepStatusFailed, duration, execErr)
 		}
+		events.Publish(events.WorkflowTransition, map[string]interface{}{
+			"run_id":      runID,
+			"step_id":     step.ID,
+			"step_type":   string(step.Type),
+			"status":      string(StepStatusFailed),
+			"error":       errStr,
+			"duration_ms": duration.Milliseconds(),
+		})
 		return execErr
 	}
 
@@ -318,6 +329,31 @@ func (c *WorkflowConsumer) executeStep(ctx context.Context, runID string, step w
 		_ = c.runUpdater.UpdateRunStatus(ctx, runID, "waiting_approval", &currentStep, nil)
 		log.Printf("Workflow consumer: step %s waiting for approval %s", step.ID, result.ApprovalID)
 
+	case StepStatusWaitingTimer:
+		// Persist the armed timer (_timerResumeAt/_timerStep) so the
+		// re-executed step completes instead of re-arming
+		_ = c.runUpdater.UpdateRunContext(ctx, runID, runContext)
+		resumeAtStr, _ := result.Output["resume_at"].(string)
+		_ = c.stepRecorder.RecordStepWaitingTimer(ctx, runID, step.ID, resumeAtStr)
+		_ = c.runUpdater.UpdateRunStatus(ctx, runID, "waiting_timer", &currentStep, nil)
+		if resumeAt, parseErr := time.Parse(time.RFC3339, resumeAtStr); parseErr == nil {
+			c.resumeStepAfter(runID, step, resumeAt)
+		}
+		log.Printf("Workflow consumer: step %s sleeping until %s", step.ID, resumeAtStr)
+
+	case StepStatusWaitingEvent:
+		// Persist the recorded await (_awaitEvents) so re-executions see
+		// the original deadline
+		_ = c.runUpdater.UpdateRunContext(ctx, runID, runContext)
+		_ = c.stepRecorder.RecordStepWaitingEvent(ctx, runID, step.ID, result.EventName)
+		_ = c.runUpdater.UpdateRunStatus(ctx, runID, "waiting_event", &currentStep, nil)
+		if deadlineStr, ok := result.Output["deadline"].(string); ok {
+			if deadline, parseErr := time.Parse(time.RFC3339, deadlineStr); parseErr == nil {
+				c.resumeStepAfter(runID, step, deadline)
+			}
+		}
+		log.Printf("Workflow consumer: step %s waiting for event '%s'", step.ID, result.EventName)
+
 	case StepStatusFailed, StepStatusRejected, StepStatusTimedOut:
 		errMsg := "step failed"
 		if result.Error != nil {
@@ -331,6 +367,14 @@ func (c *WorkflowConsumer) executeStep(ctx context.Context, runID string, step w
 		c.telemetry.EndStepSpan(asTraceSpan(stepSpan), step.Type, result.Status, duration, nil)
 	}
 
+	events.Publish(events.WorkflowTransition, map[string]interface{}{
+		"run_id":      runID,
+		"step_id":     step.ID,
+		"step_type":   string(step.Type),
+		"status":      string(result.Status),
+		"duration_ms": duration.Milliseconds(),
+	})
+
 	return nil
 }
 
@@ -516,6 +560,24 @@ func extractAgentResult(data map[string]interface{}) interface{} {
 	}
 }
 
+// resumeStepAfter re-publishes a waiting step once its wake-up time passes,
+// letting the executor observe the elapsed timer or expired event deadline.
+func (c *WorkflowConsumer) resumeStepAfter(runID string, step workflows.ExecutionStep, resumeAt time.Time) {
+	delay := time.Until(resumeAt)
+	if delay < 0 {
+		delay = 0
+	}
+	time.AfterFunc(delay, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		log.Printf("Workflow consumer: waking step %s for run %s", step.ID, runID)
+		if err := c.engine.PublishStepWithTrace(ctx, runID, step.ID, step); err != nil {
+			log.Printf("Workflow consumer: failed to re-publish step %s for run %s: %v", step.ID, runID, err)
+		}
+	})
+}
+
 func (c *WorkflowConsumer) scheduleNextStep(ctx context.Context, runID, nextStepID string) error {
 	nextStep, err := c.stepProvider.GetStep(ctx, runID, nextStepID)
 	if err != nil {
@@ -0,0 +1,138 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"station/internal/workflows"
+)
+
+var (
+	ErrEventNameRequired = errors.New("event name is required for await_event")
+)
+
+const StepStatusWaitingEvent StepStatus = "waiting_event"
+
+// AwaitEventExecutor blocks a run until a named external event is delivered
+// via POST /workflow-runs/:runId/events/:name. Delivered events are recorded
+// in the run context under "_events", so a step whose event already arrived
+// completes immediately with the delivered payload. Unlike human approval
+// steps, no approval record is created - the step is keyed purely by event
+// name.
+type AwaitEventExecutor struct{}
+
+func NewAwaitEventExecutor() *AwaitEventExecutor {
+	return &AwaitEventExecutor{}
+}
+
+func (e *AwaitEventExecutor) SupportedTypes() []workflows.ExecutionStepType {
+	return []workflows.ExecutionStepType{workflows.StepTypeEvent}
+}
+
+func (e *AwaitEventExecutor) Execute(ctx context.Context, step workflows.ExecutionStep, runContext map[string]interface{}) (StepResult, error) {
+	eventName := step.Raw.Event
+	if eventName == "" {
+		if n, ok := step.Raw.Input["event"].(string); ok {
+			eventName = n
+		}
+	}
+	if eventName == "" {
+		if n, ok := step.Raw.Input["name"].(string); ok {
+			eventName = n
+		}
+	}
+	if eventName == "" {
+		return StepResult{
+			Status: StepStatusFailed,
+			Error:  strPtr("no event name specified for await_event"),
+		}, ErrEventNameRequired
+	}
+
+	// Event already delivered: complete with the recorded payload
+	if events, ok := runContext["_events"].(map[string]interface{}); ok {
+		if delivered, ok := events[eventName].(map[string]interface{}); ok {
+			output := map[string]interface{}{"event": eventName}
+			for k, v := range delivered {
+				output[k] = v
+			}
+			return StepResult{
+				Status:   StepStatusCompleted,
+				Output:   output,
+				NextStep: step.Next,
+				End:      step.End,
+			}, nil
+		}
+	}
+
+	deadlineStr := e.stepDeadline(step, eventName, runContext)
+	if deadlineStr != "" {
+		if deadline, err := time.Parse(time.RFC3339, deadlineStr); err == nil && time.Now().After(deadline) {
+			errStr := fmt.Sprintf("timed out waiting for event '%s'", eventName)
+			return StepResult{
+				Status: StepStatusTimedOut,
+				Output: map[string]interface{}{
+					"event":    eventName,
+					"deadline": deadlineStr,
+				},
+				Error: &errStr,
+			}, nil
+		}
+	}
+
+	output := map[string]interface{}{"event": eventName}
+	if deadlineStr != "" {
+		output["deadline"] = deadlineStr
+	}
+
+	return StepResult{
+		Status:    StepStatusWaitingEvent,
+		EventName: eventName,
+		Output:    output,
+		NextStep:  step.Next,
+		End:       step.End,
+	}, nil
+}
+
+// stepDeadline returns the persisted deadline for an await step, computing
+// and recording it in the run context on first execution so re-executions
+// (event delivery, timeout wake-up) see the original deadline instead of a
+// fresh one
+func (e *AwaitEventExecutor) stepDeadline(step workflows.ExecutionStep, eventName string, runContext map[string]interface{}) string {
+	awaits, ok := runContext["_awaitEvents"].(map[string]interface{})
+	if !ok {
+		awaits = make(map[string]interface{})
+	}
+
+	if existing, ok := awaits[step.ID].(map[string]interface{}); ok {
+		if deadline, ok := existing["deadline"].(string); ok {
+			return deadline
+		}
+		return ""
+	}
+
+	var timeout time.Duration
+	if step.Raw.Timeout != "" {
+		if parsed, err := time.ParseDuration(step.Raw.Timeout); err == nil {
+			timeout = parsed
+		}
+	}
+	if timeout == 0 {
+		if secs, ok := step.Raw.Input["timeout_seconds"].(float64); ok {
+			timeout = time.Duration(secs) * time.Second
+		}
+	}
+
+	entry := map[string]interface{}{"event": eventName}
+	if timeout > 0 {
+		entry["deadline"] = time.Now().Add(timeout).UTC().Format(time.RFC3339)
+	}
+	awaits[step.ID] = entry
+	runContext["_awaitEvents"] = awaits
+
+	if deadline, ok := entry["deadline"].(string); ok {
+		return deadline
+	}
+	return ""
+}
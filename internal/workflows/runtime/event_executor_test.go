@@ -0,0 +1,173 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"station/internal/workflows"
+)
+
+func TestAwaitEventExecutor_SupportedTypes(t *testing.T) {
+	executor := NewAwaitEventExecutor()
+	types := executor.SupportedTypes()
+
+	if len(types) != 1 {
+		t.Errorf("expected 1 supported type, got %d", len(types))
+	}
+	if types[0] != workflows.StepTypeEvent {
+		t.Errorf("expected StepTypeEvent, got %v", types[0])
+	}
+}
+
+func TestAwaitEventExecutor_MissingEventName(t *testing.T) {
+	executor := NewAwaitEventExecutor()
+
+	step := workflows.ExecutionStep{
+		ID:   "gate",
+		Type: workflows.StepTypeEvent,
+		Raw:  workflows.StateSpec{Type: "await_event"},
+	}
+
+	result, err := executor.Execute(context.Background(), step, map[string]interface{}{})
+	if !errors.Is(err, ErrEventNameRequired) {
+		t.Errorf("expected ErrEventNameRequired, got %v", err)
+	}
+	if result.Status != StepStatusFailed {
+		t.Errorf("expected failed status, got %v", result.Status)
+	}
+}
+
+func TestAwaitEventExecutor_WaitsForEvent(t *testing.T) {
+	executor := NewAwaitEventExecutor()
+
+	step := workflows.ExecutionStep{
+		ID:   "gate",
+		Type: workflows.StepTypeEvent,
+		Next: "next-step",
+		Raw: workflows.StateSpec{
+			Type:    "await_event",
+			Event:   "deploy-approved",
+			Timeout: "1h",
+		},
+	}
+	runContext := map[string]interface{}{}
+
+	result, err := executor.Execute(context.Background(), step, runContext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != StepStatusWaitingEvent {
+		t.Errorf("expected waiting_event status, got %v", result.Status)
+	}
+	if result.EventName != "deploy-approved" {
+		t.Errorf("expected event name in result, got %q", result.EventName)
+	}
+	if _, ok := result.Output["deadline"].(string); !ok {
+		t.Error("expected deadline in output when timeout configured")
+	}
+
+	// The deadline must be persisted in the run context so re-executions see
+	// the original deadline
+	awaits, ok := runContext["_awaitEvents"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected _awaitEvents to be recorded in run context")
+	}
+	if _, ok := awaits["gate"].(map[string]interface{}); !ok {
+		t.Errorf("expected await entry for step 'gate', got %v", awaits)
+	}
+}
+
+func TestAwaitEventExecutor_EventNameFromInput(t *testing.T) {
+	executor := NewAwaitEventExecutor()
+
+	step := workflows.ExecutionStep{
+		ID:   "gate",
+		Type: workflows.StepTypeEvent,
+		Raw: workflows.StateSpec{
+			Type:  "await_event",
+			Input: map[string]interface{}{"event": "input-event"},
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), step, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.EventName != "input-event" {
+		t.Errorf("expected event name from input, got %q", result.EventName)
+	}
+}
+
+func TestAwaitEventExecutor_CompletesWhenEventDelivered(t *testing.T) {
+	executor := NewAwaitEventExecutor()
+
+	step := workflows.ExecutionStep{
+		ID:   "gate",
+		Type: workflows.StepTypeEvent,
+		Next: "next-step",
+		Raw: workflows.StateSpec{
+			Type:  "await_event",
+			Event: "deploy-approved",
+		},
+	}
+	runContext := map[string]interface{}{
+		"_events": map[string]interface{}{
+			"deploy-approved": map[string]interface{}{
+				"payload":      map[string]interface{}{"version": "v2"},
+				"delivered_at": time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), step, runContext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != StepStatusCompleted {
+		t.Errorf("expected completed status, got %v", result.Status)
+	}
+	if result.Output["event"] != "deploy-approved" {
+		t.Errorf("expected event name in output, got %v", result.Output["event"])
+	}
+	payload, ok := result.Output["payload"].(map[string]interface{})
+	if !ok || payload["version"] != "v2" {
+		t.Errorf("expected delivered payload in output, got %v", result.Output["payload"])
+	}
+	if result.NextStep != "next-step" {
+		t.Errorf("expected next step preserved, got %q", result.NextStep)
+	}
+}
+
+func TestAwaitEventExecutor_TimesOutPastDeadline(t *testing.T) {
+	executor := NewAwaitEventExecutor()
+
+	step := workflows.ExecutionStep{
+		ID:   "gate",
+		Type: workflows.StepTypeEvent,
+		Raw: workflows.StateSpec{
+			Type:  "await_event",
+			Event: "deploy-approved",
+		},
+	}
+	runContext := map[string]interface{}{
+		"_awaitEvents": map[string]interface{}{
+			"gate": map[string]interface{}{
+				"event":    "deploy-approved",
+				"deadline": time.Now().Add(-1 * time.Minute).UTC().Format(time.RFC3339),
+			},
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), step, runContext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != StepStatusTimedOut {
+		t.Errorf("expected timed_out status, got %v", result.Status)
+	}
+	if result.Error == nil {
+		t.Error("expected timeout error message")
+	}
+}
@@ -51,6 +51,7 @@ type StepResult struct {
 	NextStep   string                 `json:"next_step,omitempty"`
 	End        bool                   `json:"end,omitempty"`
 	ApprovalID string                 `json:"approval_id,omitempty"`
+	EventName  string                 `json:"event_name,omitempty"`
 }
 
 type AgentExecutorDeps interface {
@@ -0,0 +1,215 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"station/internal/workflows"
+)
+
+const (
+	httpDefaultTimeout = 30 * time.Second
+	httpDefaultBackoff = 2 * time.Second
+	httpMaxBodyBytes   = 10 * 1024 * 1024
+)
+
+// envPlaceholderPattern matches ${env:NAME} references, used to pull secrets
+// (API keys, tokens) from the Station process environment instead of baking
+// them into workflow definitions or run context.
+var envPlaceholderPattern = regexp.MustCompile(`\$\{env:([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// HTTPExecutor calls external systems directly from a workflow step without
+// routing through an agent + MCP tool. URL, headers, and body support
+// ${key} interpolation from the run context and ${env:NAME} for secrets;
+// JSON responses are captured into the step output.
+type HTTPExecutor struct {
+	client *http.Client
+}
+
+func NewHTTPExecutor() *HTTPExecutor {
+	return &HTTPExecutor{client: &http.Client{}}
+}
+
+func (e *HTTPExecutor) SupportedTypes() []workflows.ExecutionStepType {
+	return []workflows.ExecutionStepType{workflows.StepTypeHTTP}
+}
+
+func (e *HTTPExecutor) Execute(ctx context.Context, step workflows.ExecutionStep, runContext map[string]any) (StepResult, error) {
+	if step.Raw.URL == "" {
+		errStr := fmt.Sprintf("http step %s has no url", step.ID)
+		return StepResult{
+			Status: StepStatusFailed,
+			Error:  &errStr,
+		}, fmt.Errorf("%s", errStr)
+	}
+
+	timeout := httpDefaultTimeout
+	if step.Raw.Timeout != "" {
+		if parsed, err := time.ParseDuration(step.Raw.Timeout); err == nil && parsed > 0 {
+			timeout = parsed
+		}
+	}
+
+	maxAttempts := 1
+	backoff := httpDefaultBackoff
+	if step.Raw.Retry != nil {
+		if step.Raw.Retry.MaxAttempts != nil && *step.Raw.Retry.MaxAttempts > 1 {
+			maxAttempts = *step.Raw.Retry.MaxAttempts
+		}
+		if step.Raw.Retry.Backoff != "" {
+			if parsed, err := time.ParseDuration(step.Raw.Retry.Backoff); err == nil && parsed > 0 {
+				backoff = parsed
+			}
+		}
+	}
+
+	var output map[string]any
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		output, lastErr = e.doRequest(ctx, step, runContext, timeout)
+		if lastErr == nil {
+			break
+		}
+		if attempt < maxAttempts {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				lastErr = ctx.Err()
+				attempt = maxAttempts
+			}
+		}
+	}
+
+	if lastErr != nil {
+		errStr := fmt.Sprintf("http request failed after %d attempt(s): %v", maxAttempts, lastErr)
+		return StepResult{
+			Status: StepStatusFailed,
+			Error:  &errStr,
+		}, lastErr
+	}
+
+	return StepResult{
+		Status:   StepStatusCompleted,
+		Output:   output,
+		NextStep: step.Next,
+		End:      step.End,
+	}, nil
+}
+
+// doRequest performs a single attempt and returns the captured response
+func (e *HTTPExecutor) doRequest(ctx context.Context, step workflows.ExecutionStep, runContext map[string]any, timeout time.Duration) (map[string]any, error) {
+	method := strings.ToUpper(step.Raw.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	url := expandHTTPPlaceholders(step.Raw.URL, runContext)
+
+	var bodyReader io.Reader
+	if step.Raw.Body != nil {
+		resolved := resolveHTTPBody(step.Raw.Body, runContext)
+		bodyJSON, err := json.Marshal(resolved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyJSON)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, method, url, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	if step.Raw.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range step.Raw.Headers {
+		req.Header.Set(name, expandHTTPPlaceholders(value, runContext))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, httpMaxBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	expectedStatus := step.Raw.ExpectedStatus
+	statusOK := resp.StatusCode == expectedStatus
+	if expectedStatus == 0 {
+		statusOK = resp.StatusCode >= 200 && resp.StatusCode < 300
+	}
+	if !statusOK {
+		return nil, fmt.Errorf("unexpected status %d (body: %s)", resp.StatusCode, truncateHTTPBody(respBody))
+	}
+
+	output := map[string]any{
+		"status": resp.StatusCode,
+	}
+
+	var parsed any
+	if len(respBody) > 0 && json.Unmarshal(respBody, &parsed) == nil {
+		output["body"] = parsed
+	} else {
+		output["body"] = string(respBody)
+	}
+
+	return output, nil
+}
+
+// expandHTTPPlaceholders resolves ${key} from the run context and
+// ${env:NAME} from the process environment (for secrets)
+func expandHTTPPlaceholders(value string, runContext map[string]any) string {
+	value = interpolateTemplateVars(value, runContext)
+	return envPlaceholderPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := envPlaceholderPattern.FindStringSubmatch(match)[1]
+		return os.Getenv(name)
+	})
+}
+
+// resolveHTTPBody interpolates string values in the request body, recursing
+// into nested maps and lists so context data can be embedded anywhere
+func resolveHTTPBody(value any, runContext map[string]any) any {
+	switch v := value.(type) {
+	case string:
+		return expandHTTPPlaceholders(v, runContext)
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved[key] = resolveHTTPBody(item, runContext)
+		}
+		return resolved
+	case []any:
+		resolved := make([]any, len(v))
+		for i, item := range v {
+			resolved[i] = resolveHTTPBody(item, runContext)
+		}
+		return resolved
+	default:
+		return v
+	}
+}
+
+func truncateHTTPBody(body []byte) string {
+	const maxLen = 200
+	s := string(body)
+	if len(s) > maxLen {
+		return s[:maxLen] + "..."
+	}
+	return s
+}
@@ -0,0 +1,197 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"station/internal/workflows"
+)
+
+func httpStep(state workflows.StateSpec) workflows.ExecutionStep {
+	state.Name = "call-api"
+	state.Type = "http"
+	return workflows.ExecutionStep{
+		ID:   "call-api",
+		Type: workflows.StepTypeHTTP,
+		Next: "next-step",
+		Raw:  state,
+	}
+}
+
+func TestHTTPExecutor_GetCapturesJSONResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/items/42" {
+			t.Errorf("Expected interpolated path /items/42, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "widget", "count": 3}`))
+	}))
+	defer server.Close()
+
+	executor := NewHTTPExecutor()
+	step := httpStep(workflows.StateSpec{
+		URL: server.URL + "/items/${item_id}",
+	})
+
+	result, err := executor.Execute(context.Background(), step, map[string]any{"item_id": 42})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Status != StepStatusCompleted {
+		t.Errorf("Expected completed status, got %s", result.Status)
+	}
+
+	if status, ok := result.Output["status"].(int); !ok || status != 200 {
+		t.Errorf("Expected status 200 in output, got %v", result.Output["status"])
+	}
+	body, ok := result.Output["body"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected JSON body captured as map, got %T", result.Output["body"])
+	}
+	if body["name"] != "widget" {
+		t.Errorf("Expected body.name widget, got %v", body["name"])
+	}
+}
+
+func TestHTTPExecutor_PostBodyAndSecretHeader(t *testing.T) {
+	t.Setenv("HTTP_EXECUTOR_TEST_TOKEN", "tok-123")
+
+	var gotAuth string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &gotBody)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 7}`))
+	}))
+	defer server.Close()
+
+	executor := NewHTTPExecutor()
+	step := httpStep(workflows.StateSpec{
+		Method: "post",
+		URL:    server.URL + "/create",
+		Headers: map[string]string{
+			"Authorization": "Bearer ${env:HTTP_EXECUTOR_TEST_TOKEN}",
+		},
+		Body: map[string]interface{}{
+			"summary": "Finding: ${finding}",
+			"nested":  map[string]any{"env": "${environment}"},
+		},
+		ExpectedStatus: http.StatusCreated,
+	})
+
+	runContext := map[string]any{"finding": "open port", "environment": "prod"}
+	result, err := executor.Execute(context.Background(), step, runContext)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Status != StepStatusCompleted {
+		t.Errorf("Expected completed status, got %s", result.Status)
+	}
+
+	if gotAuth != "Bearer tok-123" {
+		t.Errorf("Expected secret header from environment, got %q", gotAuth)
+	}
+	if gotBody["summary"] != "Finding: open port" {
+		t.Errorf("Expected interpolated body summary, got %v", gotBody["summary"])
+	}
+	if nested, ok := gotBody["nested"].(map[string]any); !ok || nested["env"] != "prod" {
+		t.Errorf("Expected nested body interpolation, got %v", gotBody["nested"])
+	}
+}
+
+func TestHTTPExecutor_UnexpectedStatusFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	executor := NewHTTPExecutor()
+	step := httpStep(workflows.StateSpec{URL: server.URL})
+
+	result, err := executor.Execute(context.Background(), step, map[string]any{})
+	if err == nil {
+		t.Fatal("Expected error for 403 response")
+	}
+	if result.Status != StepStatusFailed {
+		t.Errorf("Expected failed status, got %s", result.Status)
+	}
+}
+
+func TestHTTPExecutor_RetriesUntilSuccess(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "flaky", http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	maxAttempts := 3
+	executor := NewHTTPExecutor()
+	step := httpStep(workflows.StateSpec{
+		URL: server.URL,
+		Retry: &workflows.RetryPolicy{
+			MaxAttempts: &maxAttempts,
+			Backoff:     "10ms",
+		},
+	})
+
+	result, err := executor.Execute(context.Background(), step, map[string]any{})
+	if err != nil {
+		t.Fatalf("Execute failed after retries: %v", err)
+	}
+	if result.Status != StepStatusCompleted {
+		t.Errorf("Expected completed status, got %s", result.Status)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestHTTPExecutor_MissingURLFails(t *testing.T) {
+	executor := NewHTTPExecutor()
+	step := httpStep(workflows.StateSpec{})
+
+	result, err := executor.Execute(context.Background(), step, map[string]any{})
+	if err == nil {
+		t.Fatal("Expected error for missing url")
+	}
+	if result.Status != StepStatusFailed {
+		t.Errorf("Expected failed status, got %s", result.Status)
+	}
+}
+
+func TestHTTPStepClassification(t *testing.T) {
+	plan := workflows.CompileExecutionPlan(&workflows.Definition{
+		Start: "call-api",
+		States: []workflows.StateSpec{
+			{Name: "call-api", Type: "http", URL: "https://example.com"},
+		},
+	})
+
+	step, ok := plan.Steps["call-api"]
+	if !ok {
+		t.Fatal("Expected call-api step in plan")
+	}
+	if step.Type != workflows.StepTypeHTTP {
+		t.Errorf("Expected http step type, got %s", step.Type)
+	}
+}
+
+func TestExpandHTTPPlaceholders_UnsetEnvBecomesEmpty(t *testing.T) {
+	_ = os.Unsetenv("HTTP_EXECUTOR_TEST_MISSING")
+	got := expandHTTPPlaceholders("Bearer ${env:HTTP_EXECUTOR_TEST_MISSING}", map[string]any{})
+	if got != "Bearer " {
+		t.Errorf("Expected unset env placeholder to expand empty, got %q", got)
+	}
+}
@@ -290,6 +290,117 @@ func TestTimerExecutor_CheckTimerComplete(t *testing.T) {
 	})
 }
 
+func TestTimerExecutor_Until(t *testing.T) {
+	executor := NewTimerExecutor()
+
+	t.Run("future until waits", func(t *testing.T) {
+		until := time.Now().Add(1 * time.Hour).UTC().Format(time.RFC3339)
+		step := workflows.ExecutionStep{
+			ID:   "wait-until",
+			Type: workflows.StepTypeTimer,
+			Next: "next",
+			Raw: workflows.StateSpec{
+				Type:  "wait",
+				Until: until,
+			},
+		}
+		runContext := map[string]interface{}{}
+
+		result, err := executor.Execute(context.Background(), step, runContext)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Status != StepStatusWaitingTimer {
+			t.Errorf("expected waiting_timer status, got %v", result.Status)
+		}
+		if runContext["_timerStep"] != "wait-until" {
+			t.Errorf("expected _timerStep='wait-until', got %v", runContext["_timerStep"])
+		}
+	})
+
+	t.Run("past until completes immediately", func(t *testing.T) {
+		until := time.Now().Add(-1 * time.Hour).UTC().Format(time.RFC3339)
+		step := workflows.ExecutionStep{
+			ID:   "wait-until",
+			Type: workflows.StepTypeTimer,
+			Next: "next",
+			Raw: workflows.StateSpec{
+				Type:  "wait",
+				Until: until,
+			},
+		}
+
+		result, err := executor.Execute(context.Background(), step, map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Status != StepStatusCompleted {
+			t.Errorf("expected completed status for past until, got %v", result.Status)
+		}
+	})
+
+	t.Run("invalid until format", func(t *testing.T) {
+		step := workflows.ExecutionStep{
+			ID:   "wait-until",
+			Type: workflows.StepTypeTimer,
+			Raw: workflows.StateSpec{
+				Type:  "wait",
+				Until: "tomorrow",
+			},
+		}
+
+		result, err := executor.Execute(context.Background(), step, map[string]interface{}{})
+		if !errors.Is(err, ErrInvalidUntil) {
+			t.Errorf("expected ErrInvalidUntil, got %v", err)
+		}
+		if result.Status != StepStatusFailed {
+			t.Errorf("expected failed status, got %v", result.Status)
+		}
+	})
+}
+
+func TestTimerExecutor_ReExecutionCompletesElapsedTimer(t *testing.T) {
+	executor := NewTimerExecutor()
+
+	step := workflows.ExecutionStep{
+		ID:   "timer-step",
+		Type: workflows.StepTypeTimer,
+		Next: "next-step",
+		Raw: workflows.StateSpec{
+			Type:     "timer",
+			Duration: "5m",
+		},
+	}
+	runContext := map[string]interface{}{
+		"_timerStep":     "timer-step",
+		"_timerResumeAt": time.Now().Add(-1 * time.Minute).UTC().Format(time.RFC3339),
+	}
+
+	result, err := executor.Execute(context.Background(), step, runContext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != StepStatusCompleted {
+		t.Errorf("expected elapsed timer to complete on re-execution, got %v", result.Status)
+	}
+	if result.NextStep != "next-step" {
+		t.Errorf("expected next step preserved, got %q", result.NextStep)
+	}
+
+	// A different step's armed timer must not satisfy this step
+	otherContext := map[string]interface{}{
+		"_timerStep":     "other-step",
+		"_timerResumeAt": time.Now().Add(-1 * time.Minute).UTC().Format(time.RFC3339),
+	}
+	result, err = executor.Execute(context.Background(), step, otherContext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != StepStatusWaitingTimer {
+		t.Errorf("expected fresh timer to wait, got %v", result.Status)
+	}
+}
+
 func TestTryCatchExecutor_SupportedTypes(t *testing.T) {
 	registry := NewExecutorRegistry()
 	executor := NewTryCatchExecutor(registry)
@@ -0,0 +1,107 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkjson"
+
+	"station/internal/workflows"
+)
+
+// scriptMaxExecutionSteps bounds how much work an inline script may do.
+// Scripts are for light data reshaping between agent steps, not computation.
+const scriptMaxExecutionSteps = 1_000_000
+
+// ScriptExecutor runs an inline Starlark program sandboxed against the
+// workflow context. Unlike transform (single expression), a script step is a
+// full program: it can define helper functions and either expose a
+// main(ctx) function or assign a top-level result variable. The interpreter
+// has no filesystem, network, or host access — only the workflow context.
+type ScriptExecutor struct{}
+
+func NewScriptExecutor() *ScriptExecutor {
+	return &ScriptExecutor{}
+}
+
+func (e *ScriptExecutor) SupportedTypes() []workflows.ExecutionStepType {
+	return []workflows.ExecutionStepType{workflows.StepTypeScript}
+}
+
+func (e *ScriptExecutor) Execute(ctx context.Context, step workflows.ExecutionStep, runContext map[string]any) (StepResult, error) {
+	script := step.Raw.Script
+	if script == "" {
+		errStr := fmt.Sprintf("script step %s has no script body", step.ID)
+		return StepResult{
+			Status: StepStatusFailed,
+			Error:  &errStr,
+		}, fmt.Errorf("%s", errStr)
+	}
+
+	output, err := e.runScript(script, runContext)
+	if err != nil {
+		errStr := fmt.Sprintf("script execution failed: %v", err)
+		return StepResult{
+			Status: StepStatusFailed,
+			Error:  &errStr,
+		}, err
+	}
+
+	return StepResult{
+		Status:   StepStatusCompleted,
+		Output:   output,
+		NextStep: step.Next,
+		End:      step.End,
+	}, nil
+}
+
+func (e *ScriptExecutor) runScript(script string, runContext map[string]any) (map[string]any, error) {
+	ctxJSON, err := json.Marshal(runContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal context: %w", err)
+	}
+
+	ctxVal, err := starlarkjson.Module.Members["decode"].(*starlark.Builtin).CallInternal(
+		nil,
+		starlark.Tuple{starlark.String(ctxJSON)},
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode context for starlark: %w", err)
+	}
+
+	predeclared := starlark.StringDict{
+		"json":    starlarkjson.Module,
+		"ctx":     ctxVal,
+		"sum":     starlark.NewBuiltin("sum", builtinSum),
+		"hasattr": starlark.NewBuiltin("hasattr", builtinHasattrDict),
+		"getattr": starlark.NewBuiltin("getattr", builtinGetattrDict),
+	}
+
+	thread := &starlark.Thread{Name: "script"}
+	thread.SetMaxExecutionSteps(scriptMaxExecutionSteps)
+
+	globals, err := starlark.ExecFile(thread, "script.star", script, predeclared)
+	if err != nil {
+		return nil, enhanceStarlarkError(err, predeclared)
+	}
+
+	// Prefer a main(ctx) entrypoint; fall back to a top-level result variable
+	if mainFn, ok := globals["main"]; ok {
+		if callable, ok := mainFn.(starlark.Callable); ok {
+			value, err := starlark.Call(thread, callable, starlark.Tuple{ctxVal}, nil)
+			if err != nil {
+				return nil, enhanceStarlarkError(err, predeclared)
+			}
+			return starlarkToGo(value)
+		}
+	}
+
+	if result, ok := globals["result"]; ok {
+		return starlarkToGo(result)
+	}
+
+	return nil, fmt.Errorf("script must define a main(ctx) function or assign a top-level result variable")
+}
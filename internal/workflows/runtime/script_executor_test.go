@@ -0,0 +1,175 @@
+package runtime
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"station/internal/workflows"
+)
+
+func scriptStep(script string) workflows.ExecutionStep {
+	return workflows.ExecutionStep{
+		ID:   "reshape",
+		Type: workflows.StepTypeScript,
+		Next: "next-step",
+		Raw: workflows.StateSpec{
+			Name:   "reshape",
+			Type:   "script",
+			Script: script,
+		},
+	}
+}
+
+func TestScriptExecutor_MainFunction(t *testing.T) {
+	executor := NewScriptExecutor()
+
+	script := `
+def main(ctx):
+    findings = ctx["scan"]["findings"]
+    critical = [f for f in findings if f["severity"] == "critical"]
+    return {"critical_count": len(critical), "total": len(findings)}
+`
+	runContext := map[string]any{
+		"scan": map[string]any{
+			"findings": []any{
+				map[string]any{"severity": "critical"},
+				map[string]any{"severity": "low"},
+				map[string]any{"severity": "critical"},
+			},
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), scriptStep(script), runContext)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Status != StepStatusCompleted {
+		t.Errorf("Expected completed status, got %s", result.Status)
+	}
+	if result.NextStep != "next-step" {
+		t.Errorf("Expected next step to propagate, got %q", result.NextStep)
+	}
+
+	if count, ok := result.Output["critical_count"].(float64); !ok || count != 2 {
+		t.Errorf("Expected critical_count 2, got %v", result.Output["critical_count"])
+	}
+	if total, ok := result.Output["total"].(float64); !ok || total != 3 {
+		t.Errorf("Expected total 3, got %v", result.Output["total"])
+	}
+}
+
+func TestScriptExecutor_ResultVariable(t *testing.T) {
+	executor := NewScriptExecutor()
+
+	script := `result = {"doubled": ctx["value"] * 2}`
+	runContext := map[string]any{"value": 21}
+
+	result, err := executor.Execute(context.Background(), scriptStep(script), runContext)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if doubled, ok := result.Output["doubled"].(float64); !ok || doubled != 42 {
+		t.Errorf("Expected doubled 42, got %v", result.Output["doubled"])
+	}
+}
+
+func TestScriptExecutor_NonDictResultWrapped(t *testing.T) {
+	executor := NewScriptExecutor()
+
+	script := `
+def main(ctx):
+    return [x * 2 for x in ctx["items"]]
+`
+	runContext := map[string]any{"items": []any{1, 2, 3}}
+
+	result, err := executor.Execute(context.Background(), scriptStep(script), runContext)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if _, ok := result.Output["result"]; !ok {
+		t.Errorf("Expected non-dict return to be wrapped under 'result', got %v", result.Output)
+	}
+}
+
+func TestScriptExecutor_MissingEntrypoint(t *testing.T) {
+	executor := NewScriptExecutor()
+
+	script := `x = 1`
+	result, err := executor.Execute(context.Background(), scriptStep(script), map[string]any{})
+	if err == nil {
+		t.Fatal("Expected error for script without main or result")
+	}
+	if result.Status != StepStatusFailed {
+		t.Errorf("Expected failed status, got %s", result.Status)
+	}
+	if !strings.Contains(err.Error(), "main(ctx)") {
+		t.Errorf("Expected error to mention main(ctx), got: %v", err)
+	}
+}
+
+func TestScriptExecutor_EmptyScript(t *testing.T) {
+	executor := NewScriptExecutor()
+
+	result, err := executor.Execute(context.Background(), scriptStep(""), map[string]any{})
+	if err == nil {
+		t.Fatal("Expected error for empty script body")
+	}
+	if result.Status != StepStatusFailed {
+		t.Errorf("Expected failed status, got %s", result.Status)
+	}
+}
+
+func TestScriptExecutor_RuntimeErrorFails(t *testing.T) {
+	executor := NewScriptExecutor()
+
+	script := `
+def main(ctx):
+    return ctx["missing_key"]["deeper"]
+`
+	result, err := executor.Execute(context.Background(), scriptStep(script), map[string]any{})
+	if err == nil {
+		t.Fatal("Expected runtime error for missing key access")
+	}
+	if result.Status != StepStatusFailed {
+		t.Errorf("Expected failed status, got %s", result.Status)
+	}
+}
+
+func TestScriptExecutor_ExecutionStepLimit(t *testing.T) {
+	executor := NewScriptExecutor()
+
+	// An unbounded loop must be cut off by the execution step budget
+	script := `
+def main(ctx):
+    total = 0
+    for i in range(100000000):
+        total += i
+    return {"total": total}
+`
+	result, err := executor.Execute(context.Background(), scriptStep(script), map[string]any{})
+	if err == nil {
+		t.Fatal("Expected runaway script to exceed the execution step limit")
+	}
+	if result.Status != StepStatusFailed {
+		t.Errorf("Expected failed status, got %s", result.Status)
+	}
+}
+
+func TestScriptStepClassification(t *testing.T) {
+	state := workflows.StateSpec{Name: "reshape", Type: "script", Script: "result = {}"}
+	plan := workflows.CompileExecutionPlan(&workflows.Definition{
+		Start:  "reshape",
+		States: []workflows.StateSpec{state},
+	})
+
+	step, ok := plan.Steps["reshape"]
+	if !ok {
+		t.Fatal("Expected reshape step in plan")
+	}
+	if step.Type != workflows.StepTypeScript {
+		t.Errorf("Expected script step type, got %s", step.Type)
+	}
+}
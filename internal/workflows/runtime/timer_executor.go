@@ -10,6 +10,7 @@ import (
 
 var (
 	ErrInvalidDuration = errors.New("invalid or missing duration for timer")
+	ErrInvalidUntil    = errors.New("invalid until timestamp for timer")
 )
 
 type StepStatusTimer StepStatus
@@ -29,6 +30,24 @@ func (e *TimerExecutor) SupportedTypes() []workflows.ExecutionStepType {
 func (e *TimerExecutor) Execute(ctx context.Context, step workflows.ExecutionStep, runContext map[string]interface{}) (StepResult, error) {
 	raw := step.Raw
 
+	// Re-execution after the wait elapsed: the consumer re-publishes this
+	// step once _timerResumeAt passes, so complete instead of re-arming
+	if armedStep, ok := runContext["_timerStep"].(string); ok && armedStep == step.ID {
+		if resumeAtStr, ok := runContext["_timerResumeAt"].(string); ok {
+			if resumeAt, err := time.Parse(time.RFC3339, resumeAtStr); err == nil && time.Now().After(resumeAt) {
+				return StepResult{
+					Status: StepStatusCompleted,
+					Output: map[string]interface{}{
+						"resume_at": resumeAtStr,
+						"waited":    true,
+					},
+					NextStep: step.Next,
+					End:      step.End,
+				}, nil
+			}
+		}
+	}
+
 	durationStr := raw.Duration
 	if durationStr == "" {
 		if d, ok := raw.Input["duration"].(string); ok {
@@ -36,33 +55,73 @@ func (e *TimerExecutor) Execute(ctx context.Context, step workflows.ExecutionSte
 		}
 	}
 
-	if durationStr == "" {
-		return StepResult{
-			Status: StepStatusFailed,
-			Error:  strPtr("no duration specified for timer"),
-		}, ErrInvalidDuration
+	untilStr := raw.Until
+	if untilStr == "" {
+		if u, ok := raw.Input["until"].(string); ok {
+			untilStr = u
+		}
 	}
 
-	duration, err := time.ParseDuration(durationStr)
-	if err != nil {
+	var resumeAt time.Time
+	switch {
+	case durationStr != "":
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil {
+			return StepResult{
+				Status: StepStatusFailed,
+				Error:  strPtr("invalid duration format: " + err.Error()),
+			}, ErrInvalidDuration
+		}
+		resumeAt = time.Now().Add(duration)
+	case untilStr != "":
+		parsed, err := time.Parse(time.RFC3339, untilStr)
+		if err != nil {
+			return StepResult{
+				Status: StepStatusFailed,
+				Error:  strPtr("invalid until timestamp (expected RFC3339): " + err.Error()),
+			}, ErrInvalidUntil
+		}
+		resumeAt = parsed
+	default:
 		return StepResult{
 			Status: StepStatusFailed,
-			Error:  strPtr("invalid duration format: " + err.Error()),
+			Error:  strPtr("no duration or until timestamp specified for timer"),
 		}, ErrInvalidDuration
 	}
 
-	resumeAt := time.Now().Add(duration)
+	// An absolute timestamp already in the past needs no wait
+	if !resumeAt.After(time.Now()) {
+		return StepResult{
+			Status: StepStatusCompleted,
+			Output: map[string]interface{}{
+				"resume_at": resumeAt.UTC().Format(time.RFC3339),
+				"waited":    false,
+			},
+			NextStep: step.Next,
+			End:      step.End,
+		}, nil
+	}
 
 	runContext["_timerResumeAt"] = resumeAt.UTC().Format(time.RFC3339)
-	runContext["_timerDuration"] = durationStr
+	runContext["_timerStep"] = step.ID
+	if durationStr != "" {
+		runContext["_timerDuration"] = durationStr
+	}
+
+	output := map[string]interface{}{
+		"resume_at":  resumeAt.UTC().Format(time.RFC3339),
+		"started_at": time.Now().UTC().Format(time.RFC3339),
+	}
+	if durationStr != "" {
+		output["duration"] = durationStr
+	}
+	if untilStr != "" {
+		output["until"] = untilStr
+	}
 
 	return StepResult{
-		Status: StepStatusWaitingTimer,
-		Output: map[string]interface{}{
-			"duration":   durationStr,
-			"resume_at":  resumeAt.UTC().Format(time.RFC3339),
-			"started_at": time.Now().UTC().Format(time.RFC3339),
-		},
+		Status:   StepStatusWaitingTimer,
+		Output:   output,
 		NextStep: step.Next,
 		End:      step.End,
 	}, nil
@@ -51,6 +51,18 @@ func (v *StarlarkValidator) validateStateExpressions(state *StateSpec, path stri
 		}
 	}
 
+	if state.Type == "script" && state.Script != "" {
+		if err := v.validateScriptProgram(state.Script); err != nil {
+			issues = append(issues, ValidationIssue{
+				Code:    "STARLARK_SYNTAX_ERROR",
+				Path:    path + "/script",
+				Message: fmt.Sprintf("Invalid Starlark syntax in script: %v", err),
+				Actual:  truncateExpression(state.Script, 100),
+				Hint:    "Script steps run a full Starlark program: define main(ctx) or assign a top-level result variable.",
+			})
+		}
+	}
+
 	for j, cond := range state.Conditions {
 		if cond.If != "" {
 			if err := v.validateConditionExpression(cond.If); err != nil {
@@ -119,6 +131,21 @@ func (v *StarlarkValidator) validateTransformExpression(expression string) error
 	return nil
 }
 
+// validateScriptProgram validates a script step body. Scripts are full
+// Starlark programs, so they parse as-is without wrapping.
+func (v *StarlarkValidator) validateScriptProgram(script string) error {
+	if strings.TrimSpace(script) == "" {
+		return nil
+	}
+
+	_, err := syntax.Parse("script.star", script, 0)
+	if err != nil {
+		return simplifyStarlarkError(err)
+	}
+
+	return nil
+}
+
 // validateConditionExpression validates a switch condition expression.
 // Conditions are boolean expressions, wrapped as: __result__ = (expression)
 func (v *StarlarkValidator) validateConditionExpression(expression string) error {
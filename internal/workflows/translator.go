@@ -7,6 +7,7 @@ const (
 	StepTypeAgent     ExecutionStepType = "agent"
 	StepTypeCustom    ExecutionStepType = "custom"
 	StepTypeAwait     ExecutionStepType = "await"
+	StepTypeEvent     ExecutionStepType = "event"
 	StepTypeTimer     ExecutionStepType = "timer"
 	StepTypeBranch    ExecutionStepType = "branch"
 	StepTypeLoop      ExecutionStepType = "loop"
@@ -15,6 +16,8 @@ const (
 	StepTypeContextOp ExecutionStepType = "context"
 	StepTypeCron      ExecutionStepType = "cron"
 	StepTypeTransform ExecutionStepType = "transform"
+	StepTypeScript    ExecutionStepType = "script"
+	StepTypeHTTP      ExecutionStepType = "http"
 )
 
 // ExecutionStep captures the minimal execution metadata the runtime needs.
@@ -86,14 +89,20 @@ func classifyStep(state StateSpec) ExecutionStepType {
 		return StepTypeLoop
 	case "parallel":
 		return StepTypeParallel
-	case "sleep", "delay", "timer":
+	case "sleep", "delay", "timer", "wait":
 		return StepTypeTimer
-	case "await", "await.signal", "await.event":
+	case "await", "await.signal":
 		return StepTypeAwait
+	case "await_event", "await.event", "event":
+		return StepTypeEvent
 	case "try":
 		return StepTypeTryCatch
 	case "transform":
 		return StepTypeTransform
+	case "script":
+		return StepTypeScript
+	case "http":
+		return StepTypeHTTP
 	case "inject", "set", "context":
 		return StepTypeContextOp
 	case "cron", "schedule":
@@ -29,6 +29,29 @@ func TestCompileExecutionPlanMapsStepTypes(t *testing.T) {
 	}
 }
 
+func TestCompileExecutionPlanWaitAndEventSteps(t *testing.T) {
+	def := &Definition{
+		ID:    "waits",
+		Start: "pause",
+		States: []StateSpec{
+			{ID: "pause", Type: "wait", Duration: "5m", Transition: "gate"},
+			{ID: "gate", Type: "await_event", Event: "deploy-approved", Transition: "legacy"},
+			{ID: "legacy", Type: "await.event", Event: "done"},
+		},
+	}
+
+	plan := CompileExecutionPlan(def)
+	if plan.Steps["pause"].Type != StepTypeTimer {
+		t.Fatalf("expected wait to map to timer, got %s", plan.Steps["pause"].Type)
+	}
+	if plan.Steps["gate"].Type != StepTypeEvent {
+		t.Fatalf("expected await_event to map to event, got %s", plan.Steps["gate"].Type)
+	}
+	if plan.Steps["legacy"].Type != StepTypeEvent {
+		t.Fatalf("expected await.event to map to event, got %s", plan.Steps["legacy"].Type)
+	}
+}
+
 func TestCompileExecutionPlanEndStates(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -63,12 +63,26 @@ type StateSpec struct {
 
 	// Timer state fields
 	Duration string `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Until    string `json:"until,omitempty" yaml:"until,omitempty"`
+
+	// Await event state fields
+	Event string `json:"event,omitempty" yaml:"event,omitempty"`
 
 	// TryCatch state fields
 	Try     *IteratorSpec `json:"try,omitempty" yaml:"try,omitempty"`
 	Catch   *IteratorSpec `json:"catch,omitempty" yaml:"catch,omitempty"`
 	Finally *IteratorSpec `json:"finally,omitempty" yaml:"finally,omitempty"`
 
+	// Script state fields
+	Script string `json:"script,omitempty" yaml:"script,omitempty"`
+
+	// HTTP state fields
+	Method         string                 `json:"method,omitempty" yaml:"method,omitempty"`
+	URL            string                 `json:"url,omitempty" yaml:"url,omitempty"`
+	Headers        map[string]string      `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body           map[string]interface{} `json:"body,omitempty" yaml:"body,omitempty"`
+	ExpectedStatus int                    `json:"expectedStatus,omitempty" yaml:"expectedStatus,omitempty"`
+
 	// Data Flow fields (Phase 11)
 	Expression        string `json:"expression,omitempty" yaml:"expression,omitempty"`
 	InputPath         string `json:"inputPath,omitempty" yaml:"inputPath,omitempty"`
@@ -22,6 +22,8 @@ type Analyzer struct {
 	jaegerClient JaegerClientInterface
 	mu           sync.RWMutex
 	thresholds   map[string]float64
+	metricModes  map[string]string // metric type -> ModeLLM (default) or ModeHeuristic
+	progress     func(completed, total int, task string)
 }
 
 // NewAnalyzer creates a new benchmark analyzer
@@ -50,6 +52,43 @@ func (a *Analyzer) SetThreshold(metricType string, threshold float64) {
 	a.thresholds[metricType] = threshold
 }
 
+// SetMetricMode selects the evaluation mode for a metric: ModeLLM (default)
+// or ModeHeuristic. Heuristic mode is supported for relevancy and
+// faithfulness and uses local embedding similarity instead of judge calls.
+func (a *Analyzer) SetMetricMode(metricType, mode string) error {
+	if mode != ModeLLM && mode != ModeHeuristic {
+		return fmt.Errorf("unknown evaluation mode %q (expected %s or %s)", mode, ModeLLM, ModeHeuristic)
+	}
+	if mode == ModeHeuristic && metricType != MetricRelevancy && metricType != MetricFaithfulness {
+		return fmt.Errorf("heuristic mode is not supported for metric %q", metricType)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.metricModes == nil {
+		a.metricModes = make(map[string]string)
+	}
+	a.metricModes[metricType] = mode
+	return nil
+}
+
+// SetProgress registers a callback invoked as each metric evaluation
+// finishes, so callers can show per-metric progress for slow judge calls
+func (a *Analyzer) SetProgress(fn func(completed, total int, task string)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.progress = fn
+}
+
+// metricMode returns the configured mode for a metric (ModeLLM by default)
+func (a *Analyzer) metricMode(metricType string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if mode, ok := a.metricModes[metricType]; ok {
+		return mode
+	}
+	return ModeLLM
+}
+
 // ============================================================================
 // Main Evaluation Entry Point
 // ============================================================================
@@ -240,6 +279,15 @@ func (a *Analyzer) evaluateMetrics(ctx context.Context, input *EvaluationInput)
 		{MetricToxicity, a.evaluateToxicity},
 	}
 
+	a.mu.RLock()
+	progress := a.progress
+	a.mu.RUnlock()
+
+	completed := 0
+	if progress != nil {
+		progress(0, len(metrics), "")
+	}
+
 	// Evaluate metrics in parallel
 	for _, metric := range metrics {
 		wg.Add(1)
@@ -254,6 +302,10 @@ func (a *Analyzer) evaluateMetrics(ctx context.Context, input *EvaluationInput)
 
 			mu.Lock()
 			results[name] = result
+			completed++
+			if progress != nil {
+				progress(completed, len(metrics), name)
+			}
 			mu.Unlock()
 		}(metric.name, metric.fn)
 	}
@@ -279,6 +331,9 @@ func (a *Analyzer) evaluateHallucination(ctx context.Context, input *EvaluationI
 }
 
 func (a *Analyzer) evaluateRelevancy(ctx context.Context, input *EvaluationInput) (MetricResult, error) {
+	if a.metricMode(MetricRelevancy) == ModeHeuristic {
+		return NewHeuristicRelevancyEvaluator().Evaluate(ctx, input)
+	}
 	evaluator := NewRelevancyEvaluator(a.judge)
 	return evaluator.Evaluate(ctx, input)
 }
@@ -289,6 +344,9 @@ func (a *Analyzer) evaluateTaskCompletion(ctx context.Context, input *Evaluation
 }
 
 func (a *Analyzer) evaluateFaithfulness(ctx context.Context, input *EvaluationInput) (MetricResult, error) {
+	if a.metricMode(MetricFaithfulness) == ModeHeuristic {
+		return NewHeuristicFaithfulnessEvaluator().Evaluate(ctx, input)
+	}
 	// Use dedicated faithfulness evaluator that checks if claims are grounded in context
 	evaluator := NewFaithfulnessEvaluator(a.judge)
 	return evaluator.Evaluate(ctx, input)
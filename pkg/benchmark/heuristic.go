@@ -0,0 +1,202 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// ============================================================================
+// Heuristic (GPU-free) Metric Evaluators
+// ============================================================================
+// Lightweight local alternatives to the LLM judge for relevancy and
+// faithfulness: responses are split into statements, embedded as term
+// frequency vectors, and scored by cosine similarity. Approximate but free
+// and fast — intended for CI runs, with the LLM judge reserved for nightly
+// evaluations.
+// ============================================================================
+
+const (
+	// Evaluation modes selectable per metric
+	ModeLLM       = "llm"
+	ModeHeuristic = "heuristic"
+
+	// Minimum cosine similarity between a statement and the task for the
+	// statement to count as relevant
+	heuristicRelevancyCutoff = 0.15
+	// Minimum cosine similarity between a claim and any context for the
+	// claim to count as grounded
+	heuristicFaithfulnessCutoff = 0.25
+)
+
+// embedText builds a term-frequency vector over lowercased word tokens
+func embedText(text string) map[string]float64 {
+	vector := make(map[string]float64)
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 1 { // skip single-character noise
+			vector[word.String()]++
+		}
+		word.Reset()
+	}
+
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word.WriteRune(r)
+		} else {
+			flush()
+		}
+	}
+	flush()
+	return vector
+}
+
+// cosineSimilarity computes the cosine of the angle between two term vectors
+func cosineSimilarity(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for term, weight := range a {
+		normA += weight * weight
+		if other, ok := b[term]; ok {
+			dot += weight * other
+		}
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// splitStatements breaks a response into sentence-level statements
+func splitStatements(text string) []string {
+	var statements []string
+	for _, chunk := range strings.FieldsFunc(text, func(r rune) bool {
+		return r == '.' || r == '!' || r == '?' || r == '\n'
+	}) {
+		statement := strings.TrimSpace(chunk)
+		if len(strings.Fields(statement)) >= 3 { // skip fragments
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
+
+// HeuristicRelevancyEvaluator scores relevancy by cosine similarity between
+// each response statement and the task, without any LLM calls
+type HeuristicRelevancyEvaluator struct {
+	threshold float64
+}
+
+// NewHeuristicRelevancyEvaluator creates a heuristic relevancy evaluator
+func NewHeuristicRelevancyEvaluator() *HeuristicRelevancyEvaluator {
+	return &HeuristicRelevancyEvaluator{threshold: DefaultThresholds[MetricRelevancy]}
+}
+
+// Evaluate performs local relevancy evaluation
+func (e *HeuristicRelevancyEvaluator) Evaluate(ctx context.Context, input *EvaluationInput) (MetricResult, error) {
+	taskVector := embedText(input.Task)
+	statements := splitStatements(input.FinalResponse)
+
+	result := MetricResult{
+		MetricType: MetricRelevancy,
+		Threshold:  e.threshold,
+	}
+
+	if len(statements) == 0 {
+		result.Score = 1.0
+		result.Passed = true
+		result.Reason = "No statements to evaluate (heuristic mode)"
+		return result, nil
+	}
+
+	relevant := 0
+	for _, statement := range statements {
+		similarity := cosineSimilarity(taskVector, embedText(statement))
+		verdict := Verdict{Statement: statement, Verdict: "yes"}
+		if similarity < heuristicRelevancyCutoff {
+			verdict.Verdict = "no"
+			verdict.Reason = fmt.Sprintf("Low similarity to task (%.2f)", similarity)
+		} else {
+			relevant++
+		}
+		result.Verdicts = append(result.Verdicts, verdict)
+	}
+
+	result.Score = float64(relevant) / float64(len(statements))
+	result.Passed = result.Score >= e.threshold
+	result.Reason = fmt.Sprintf("Heuristic (embedding similarity): %d/%d statements relevant to the task",
+		relevant, len(statements))
+	return result, nil
+}
+
+// HeuristicFaithfulnessEvaluator scores faithfulness by checking each
+// response claim against the extracted contexts (tool outputs) using cosine
+// similarity, without any LLM calls
+type HeuristicFaithfulnessEvaluator struct {
+	threshold float64
+}
+
+// NewHeuristicFaithfulnessEvaluator creates a heuristic faithfulness evaluator
+func NewHeuristicFaithfulnessEvaluator() *HeuristicFaithfulnessEvaluator {
+	return &HeuristicFaithfulnessEvaluator{threshold: DefaultThresholds[MetricFaithfulness]}
+}
+
+// Evaluate performs local faithfulness evaluation
+func (e *HeuristicFaithfulnessEvaluator) Evaluate(ctx context.Context, input *EvaluationInput) (MetricResult, error) {
+	result := MetricResult{
+		MetricType: MetricFaithfulness,
+		Threshold:  e.threshold,
+	}
+
+	// No contexts means nothing to contradict — mirror the LLM evaluator's
+	// treatment of context-free runs
+	if len(input.Contexts) == 0 {
+		result.Score = 1.0
+		result.Passed = true
+		result.Reason = "No retrieval contexts available (heuristic mode)"
+		return result, nil
+	}
+
+	contextVectors := make([]map[string]float64, len(input.Contexts))
+	for i, context := range input.Contexts {
+		contextVectors[i] = embedText(context)
+	}
+
+	statements := splitStatements(input.FinalResponse)
+	if len(statements) == 0 {
+		result.Score = 1.0
+		result.Passed = true
+		result.Reason = "No claims to evaluate (heuristic mode)"
+		return result, nil
+	}
+
+	grounded := 0
+	for _, statement := range statements {
+		statementVector := embedText(statement)
+		best := 0.0
+		for _, contextVector := range contextVectors {
+			if similarity := cosineSimilarity(statementVector, contextVector); similarity > best {
+				best = similarity
+			}
+		}
+
+		verdict := Verdict{Statement: statement, Verdict: "yes"}
+		if best < heuristicFaithfulnessCutoff {
+			verdict.Verdict = "no"
+			verdict.Reason = fmt.Sprintf("Not grounded in any context (best similarity %.2f)", best)
+		} else {
+			grounded++
+		}
+		result.Verdicts = append(result.Verdicts, verdict)
+	}
+
+	result.Score = float64(grounded) / float64(len(statements))
+	result.Passed = result.Score >= e.threshold
+	result.Reason = fmt.Sprintf("Heuristic (embedding similarity): %d/%d claims grounded in tool outputs",
+		grounded, len(statements))
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package benchmark
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	identical := cosineSimilarity(embedText("scan terraform files"), embedText("scan terraform files"))
+	if identical < 0.99 {
+		t.Errorf("identical texts should have similarity ~1.0, got %.2f", identical)
+	}
+
+	unrelated := cosineSimilarity(embedText("scan terraform files"), embedText("bake chocolate cake"))
+	if unrelated != 0 {
+		t.Errorf("disjoint texts should have similarity 0, got %.2f", unrelated)
+	}
+
+	partial := cosineSimilarity(embedText("scan terraform files for issues"), embedText("terraform issues found"))
+	if partial <= unrelated || partial >= identical {
+		t.Errorf("overlapping texts should score between 0 and 1, got %.2f", partial)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	statements := splitStatements("I scanned 15 files. Found 3 issues in main.tf! OK.\nThe scan used checkov and semgrep.")
+	if len(statements) != 3 {
+		t.Fatalf("expected 3 statements (fragments dropped), got %d: %v", len(statements), statements)
+	}
+	if statements[0] != "I scanned 15 files" {
+		t.Errorf("unexpected first statement: %q", statements[0])
+	}
+}
+
+func TestHeuristicRelevancyEvaluator(t *testing.T) {
+	evaluator := NewHeuristicRelevancyEvaluator()
+
+	// Relevant response: statements share vocabulary with the task
+	input := &EvaluationInput{
+		Task:          "Scan terraform files for security vulnerabilities",
+		FinalResponse: "I scanned 15 terraform files for vulnerabilities. The security scan found 3 issues in the terraform configuration.",
+	}
+	result, err := evaluator.Evaluate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("evaluation failed: %v", err)
+	}
+	if result.Score < 0.9 {
+		t.Errorf("expected high relevancy score, got %.2f (%s)", result.Score, result.Reason)
+	}
+	if result.JudgeTokens != 0 || result.JudgeCost != 0 {
+		t.Errorf("heuristic mode should have zero judge cost, got %d tokens / $%.4f", result.JudgeTokens, result.JudgeCost)
+	}
+
+	// Irrelevant response shares no vocabulary with the task
+	input.FinalResponse = "The weather today is sunny with light clouds. Remember to water your garden plants regularly."
+	result, err = evaluator.Evaluate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("evaluation failed: %v", err)
+	}
+	if result.Score > 0.5 {
+		t.Errorf("expected low relevancy score for off-topic response, got %.2f", result.Score)
+	}
+	if result.Passed {
+		t.Error("off-topic response should not pass the relevancy threshold")
+	}
+}
+
+func TestHeuristicFaithfulnessEvaluator(t *testing.T) {
+	evaluator := NewHeuristicFaithfulnessEvaluator()
+
+	// Grounded claims: response vocabulary comes from the contexts
+	input := &EvaluationInput{
+		Task:          "Scan the repository",
+		FinalResponse: "The checkov scan found hardcoded credentials in main.tf with severity high.",
+		Contexts:      []string{"checkov results: hardcoded credentials detected in main.tf, severity high, rule CKV_SECRET_6"},
+	}
+	result, err := evaluator.Evaluate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("evaluation failed: %v", err)
+	}
+	if result.Score < 0.9 {
+		t.Errorf("expected grounded claims to score high, got %.2f (%s)", result.Score, result.Reason)
+	}
+
+	// Ungrounded claims have no overlap with any context
+	input.FinalResponse = "The kubernetes cluster has seventeen misconfigured ingress routes exposing internal dashboards."
+	result, err = evaluator.Evaluate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("evaluation failed: %v", err)
+	}
+	if result.Score > 0.5 {
+		t.Errorf("expected ungrounded claims to score low, got %.2f", result.Score)
+	}
+
+	// No contexts means nothing to contradict
+	input.Contexts = nil
+	result, err = evaluator.Evaluate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("evaluation failed: %v", err)
+	}
+	if result.Score != 1.0 || !result.Passed {
+		t.Errorf("expected perfect score without contexts, got %.2f", result.Score)
+	}
+}
+
+func TestSetMetricMode(t *testing.T) {
+	analyzer := NewAnalyzer(nil, NewMockJudge())
+
+	if err := analyzer.SetMetricMode(MetricRelevancy, ModeHeuristic); err != nil {
+		t.Errorf("heuristic relevancy should be supported: %v", err)
+	}
+	if analyzer.metricMode(MetricRelevancy) != ModeHeuristic {
+		t.Error("metric mode not applied")
+	}
+	if analyzer.metricMode(MetricFaithfulness) != ModeLLM {
+		t.Error("unset metrics should default to LLM mode")
+	}
+
+	if err := analyzer.SetMetricMode(MetricToxicity, ModeHeuristic); err == nil {
+		t.Error("heuristic toxicity should be rejected")
+	}
+	if err := analyzer.SetMetricMode(MetricRelevancy, "quantum"); err == nil {
+		t.Error("unknown mode should be rejected")
+	}
+}
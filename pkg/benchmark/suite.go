@@ -0,0 +1,180 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// Benchmark Suite Format
+// ============================================================================
+// A suite is a versionable file (YAML or JSON) describing a set of benchmark
+// tasks, so task definitions can live in git and be shared across teams:
+//
+//   name: payments
+//   description: Payment workflow evaluation tasks
+//   tasks:
+//     - name: refund-processing
+//       category: finops
+//       description: Process a refund request end to end
+//       expected_output_example: "Refund #1234 issued for $50.00"
+//       evaluation_criteria: "Refund issued, amount correct, customer notified"
+//       weights:
+//         task_completion: 0.3
+//         relevancy: 0.2
+//         hallucination: 0.2
+//         faithfulness: 0.2
+//         toxicity: 0.1
+//
+// Suites import/export via `stn benchmark import` / `stn benchmark export`
+// and can also be exported as OpenAI evals-style JSONL.
+// ============================================================================
+
+// Suite is a portable collection of benchmark task definitions
+type Suite struct {
+	Name        string      `yaml:"name" json:"name"`
+	Description string      `yaml:"description,omitempty" json:"description,omitempty"`
+	Tasks       []SuiteTask `yaml:"tasks" json:"tasks"`
+}
+
+// SuiteTask mirrors a benchmark_tasks row without database identity
+type SuiteTask struct {
+	Name                  string       `yaml:"name" json:"name"`
+	Category              string       `yaml:"category" json:"category"`
+	Description           string       `yaml:"description" json:"description"`
+	ExpectedOutputExample string       `yaml:"expected_output_example,omitempty" json:"expected_output_example,omitempty"`
+	EvaluationCriteria    string       `yaml:"evaluation_criteria,omitempty" json:"evaluation_criteria,omitempty"`
+	Weights               SuiteWeights `yaml:"weights,omitempty" json:"weights,omitempty"`
+}
+
+// SuiteWeights holds per-metric weighting for a task. A zero value means
+// "use the default weight" so hand-written suites can omit the block.
+type SuiteWeights struct {
+	TaskCompletion float64 `yaml:"task_completion,omitempty" json:"task_completion,omitempty"`
+	Relevancy      float64 `yaml:"relevancy,omitempty" json:"relevancy,omitempty"`
+	Hallucination  float64 `yaml:"hallucination,omitempty" json:"hallucination,omitempty"`
+	Faithfulness   float64 `yaml:"faithfulness,omitempty" json:"faithfulness,omitempty"`
+	Toxicity       float64 `yaml:"toxicity,omitempty" json:"toxicity,omitempty"`
+}
+
+// DefaultWeights is the per-metric weighting applied when a suite task omits
+// its weights block
+var DefaultWeights = SuiteWeights{
+	TaskCompletion: 0.3,
+	Relevancy:      0.2,
+	Hallucination:  0.2,
+	Faithfulness:   0.2,
+	Toxicity:       0.1,
+}
+
+// LoadSuiteFile reads a suite from a YAML or JSON file (by extension)
+func LoadSuiteFile(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suite file: %w", err)
+	}
+
+	var suite Suite
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &suite); err != nil {
+			return nil, fmt.Errorf("invalid JSON suite file %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &suite); err != nil {
+			return nil, fmt.Errorf("invalid YAML suite file %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported suite file extension %q (use .yaml, .yml, or .json)", filepath.Ext(path))
+	}
+
+	if err := suite.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid suite %s: %w", path, err)
+	}
+	return &suite, nil
+}
+
+// SaveFile writes the suite to a YAML or JSON file (by extension)
+func (s *Suite) SaveFile(path string) error {
+	var data []byte
+	var err error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		data, err = json.MarshalIndent(s, "", "  ")
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(s)
+	default:
+		return fmt.Errorf("unsupported suite file extension %q (use .yaml, .yml, or .json)", filepath.Ext(path))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to serialize suite: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write suite file: %w", err)
+	}
+	return nil
+}
+
+// Validate checks suite structural integrity before import
+func (s *Suite) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("suite name is required")
+	}
+	if len(s.Tasks) == 0 {
+		return fmt.Errorf("suite must contain at least one task")
+	}
+
+	seen := make(map[string]bool)
+	for i, task := range s.Tasks {
+		if task.Name == "" {
+			return fmt.Errorf("task %d: name is required", i+1)
+		}
+		if seen[task.Name] {
+			return fmt.Errorf("duplicate task name %q", task.Name)
+		}
+		seen[task.Name] = true
+		if task.Description == "" {
+			return fmt.Errorf("task %q: description is required", task.Name)
+		}
+	}
+	return nil
+}
+
+// evalsRecord is one line of OpenAI evals-style JSONL output
+type evalsRecord struct {
+	Input []evalsMessage `json:"input"`
+	Ideal string         `json:"ideal"`
+}
+
+type evalsMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// WriteEvalsJSONL writes the suite's tasks as OpenAI evals-style JSONL: one
+// record per task with the evaluation criteria as the system message, the
+// task description as the user message, and the expected output as the ideal.
+func (s *Suite) WriteEvalsJSONL(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, task := range s.Tasks {
+		record := evalsRecord{
+			Input: []evalsMessage{
+				{Role: "system", Content: task.EvaluationCriteria},
+				{Role: "user", Content: task.Description},
+			},
+			Ideal: task.ExpectedOutputExample,
+		}
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode task %q: %w", task.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package benchmark
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func sampleSuite() *Suite {
+	return &Suite{
+		Name:        "payments",
+		Description: "Payment workflow evaluation tasks",
+		Tasks: []SuiteTask{
+			{
+				Name:                  "refund-processing",
+				Category:              CategoryFinOps,
+				Description:           "Process a refund request end to end",
+				ExpectedOutputExample: "Refund #1234 issued for $50.00",
+				EvaluationCriteria:    "Refund issued, amount correct, customer notified",
+				Weights: SuiteWeights{
+					TaskCompletion: 0.3,
+					Relevancy:      0.2,
+					Hallucination:  0.2,
+					Faithfulness:   0.2,
+					Toxicity:       0.1,
+				},
+			},
+			{
+				Name:        "chargeback-triage",
+				Category:    CategoryFinOps,
+				Description: "Classify a chargeback and recommend next steps",
+			},
+		},
+	}
+}
+
+func TestSuiteRoundTrip(t *testing.T) {
+	for _, ext := range []string{".yaml", ".json"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "payments"+ext)
+			original := sampleSuite()
+
+			if err := original.SaveFile(path); err != nil {
+				t.Fatalf("SaveFile failed: %v", err)
+			}
+
+			loaded, err := LoadSuiteFile(path)
+			if err != nil {
+				t.Fatalf("LoadSuiteFile failed: %v", err)
+			}
+
+			if loaded.Name != original.Name {
+				t.Errorf("name = %q, want %q", loaded.Name, original.Name)
+			}
+			if len(loaded.Tasks) != len(original.Tasks) {
+				t.Fatalf("got %d tasks, want %d", len(loaded.Tasks), len(original.Tasks))
+			}
+			if loaded.Tasks[0] != original.Tasks[0] {
+				t.Errorf("task round-trip mismatch:\ngot  %+v\nwant %+v", loaded.Tasks[0], original.Tasks[0])
+			}
+		})
+	}
+}
+
+func TestSuiteValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Suite)
+		wantErr bool
+	}{
+		{"valid suite", func(s *Suite) {}, false},
+		{"missing suite name", func(s *Suite) { s.Name = "" }, true},
+		{"no tasks", func(s *Suite) { s.Tasks = nil }, true},
+		{"missing task name", func(s *Suite) { s.Tasks[0].Name = "" }, true},
+		{"duplicate task name", func(s *Suite) { s.Tasks[1].Name = s.Tasks[0].Name }, true},
+		{"missing task description", func(s *Suite) { s.Tasks[0].Description = "" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := sampleSuite()
+			tt.mutate(suite)
+			err := suite.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuiteUnsupportedExtension(t *testing.T) {
+	if _, err := LoadSuiteFile("suite.toml"); err == nil {
+		t.Error("expected error for unsupported extension")
+	}
+}
+
+func TestWriteEvalsJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	suite := sampleSuite()
+
+	if err := suite.WriteEvalsJSONL(&buf); err != nil {
+		t.Fatalf("WriteEvalsJSONL failed: %v", err)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	lines := 0
+	for scanner.Scan() {
+		lines++
+		var record struct {
+			Input []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"input"`
+			Ideal string `json:"ideal"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", lines, err)
+		}
+		if len(record.Input) != 2 || record.Input[0].Role != "system" || record.Input[1].Role != "user" {
+			t.Errorf("line %d: unexpected input structure: %+v", lines, record.Input)
+		}
+	}
+	if lines != len(suite.Tasks) {
+		t.Errorf("got %d JSONL records, want %d", lines, len(suite.Tasks))
+	}
+}
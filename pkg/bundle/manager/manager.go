@@ -17,6 +17,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"station/pkg/bundle"
+	"station/pkg/templates"
 )
 
 // Manager implements the BundleManager interface
@@ -413,8 +414,9 @@ func (m *Manager) copyFile(src, dst string) error {
 }
 
 func (m *Manager) processTemplate(templateContent string, variables map[string]interface{}) (string, error) {
-	// Use Go template engine for proper template processing
-	tmpl, err := template.New("bundle").Parse(templateContent)
+	// Use Go template engine for proper template processing, with the shared
+	// helper functions (default, required, env, lookup, ...)
+	tmpl, err := template.New("bundle").Funcs(templates.FuncMap(variables)).Parse(templateContent)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -57,6 +57,10 @@ type DotPromptConfig struct {
 // GenKitExecutor handles agent execution using GenKit's dotprompt.Execute()
 type GenKitExecutor struct {
 	logCallback func(map[string]interface{})
+	profileName string
+	profile     *config.ExecutionProfile
+	stepPolicy  *models.AgentStepPolicy
+	policyState *stepPolicyState
 }
 
 // NewGenKitExecutor creates a new GenKit-based dotprompt executor
@@ -64,6 +68,20 @@ func NewGenKitExecutor() *GenKitExecutor {
 	return &GenKitExecutor{}
 }
 
+// SetExecutionProfile applies a resolved execution profile (temperature,
+// top_p, max turns, tool choice, seed) to every generate call in this
+// execution. Must be set before ExecuteAgent.
+func (e *GenKitExecutor) SetExecutionProfile(name string, profile *config.ExecutionProfile) {
+	e.profileName = name
+	e.profile = profile
+}
+
+// SetStepPolicy applies an agent's step policy (max turns, per-turn timeout,
+// require-tool-call check) to this execution. Must be set before ExecuteAgent.
+func (e *GenKitExecutor) SetStepPolicy(policy *models.AgentStepPolicy) {
+	e.stepPolicy = policy
+}
+
 func getPromptMutex(agentName string) *sync.Mutex {
 	actual, _ := promptLoadMutexes.LoadOrStore(agentName, &sync.Mutex{})
 	return actual.(*sync.Mutex)
@@ -277,6 +295,15 @@ func (e *GenKitExecutor) ExecuteAgent(ctx context.Context, agent models.Agent, a
 	if maxTurns <= 0 {
 		maxTurns = 25
 	}
+	if e.stepPolicy != nil && e.stepPolicy.MaxTurns != nil && *e.stepPolicy.MaxTurns > 0 {
+		maxTurns = int(*e.stepPolicy.MaxTurns)
+	}
+	if e.profile != nil && e.profile.MaxTurns > 0 {
+		maxTurns = e.profile.MaxTurns
+	}
+	if e.stepPolicy != nil {
+		e.policyState = &stepPolicyState{}
+	}
 
 	// DEBUG: Check if OutputSchema is populated
 	if agent.OutputSchema != nil {
@@ -298,11 +325,8 @@ func (e *GenKitExecutor) ExecuteAgent(ctx context.Context, agent models.Agent, a
 	// results in duplicate traces (our empty root + GenKit's generate traces).
 	// Instead, the agent_execution_engine creates a proper span with full metadata.
 
-	resp, err := agentPrompt.Execute(execCtx,
-		ai.WithInput(inputMap),
-		ai.WithMaxTurns(maxTurns),
-		ai.WithModelName(modelName),
-		ai.WithTools(mcpTools...))
+	resp, usedModel, err := e.executePromptWithRetry(execCtx, agentPrompt, modelName, inputMap, maxTurns, mcpTools, logCallback)
+	modelName = usedModel // Record the model that actually produced the response
 
 	if err != nil {
 		execError := fmt.Errorf("dotprompt.Execute() failed: %w", err)
@@ -347,20 +371,27 @@ func (e *GenKitExecutor) ExecuteAgent(ctx context.Context, agent models.Agent, a
 	// First pass: collect all tool requests
 	toolRequestMap := make(map[string]map[string]interface{}) // Map by ref ID for matching responses
 
+	turn := 0
 	for _, msg := range history {
 		if msg.Content != nil && len(msg.Content) > 0 {
+			messageHadToolRequest := false
 			for _, part := range msg.Content {
 				if part.IsToolRequest() {
+					messageHadToolRequest = true
 					toolReq := part.ToolRequest
 					toolCall := map[string]interface{}{
 						"tool_name":    toolReq.Name,
 						"parameters":   toolReq.Input,
 						"tool_call_id": toolReq.Ref,
+						"turn":         turn,
 					}
 					toolRequestMap[toolReq.Ref] = toolCall
 					toolCallsInterface = append(toolCallsInterface, toolCall)
 				}
 			}
+			if messageHadToolRequest {
+				turn++
+			}
 		}
 	}
 
@@ -370,9 +401,11 @@ func (e *GenKitExecutor) ExecuteAgent(ctx context.Context, agent models.Agent, a
 			for _, part := range msg.Content {
 				if part.IsToolResponse() {
 					toolResp := part.ToolResponse
-					// Find the matching tool call and add the output
+					// Find the matching tool call and add the output plus the
+					// estimated prompt growth its output causes
 					if toolCall, exists := toolRequestMap[toolResp.Ref]; exists {
 						toolCall["output"] = toolResp.Output
+						toolCall["estimated_tokens"] = EstimateToolCallTokens(toolResp.Output)
 					}
 				}
 			}
@@ -384,6 +417,11 @@ func (e *GenKitExecutor) ExecuteAgent(ctx context.Context, agent models.Agent, a
 		toolCallsArray = &toolCallsInterface
 	}
 
+	var warnings []string
+	if e.policyState != nil {
+		warnings = e.policyState.Warnings()
+	}
+
 	return &ExecutionResponse{
 		Success:    true,
 		Response:   finalResponse,
@@ -393,6 +431,7 @@ func (e *GenKitExecutor) ExecuteAgent(ctx context.Context, agent models.Agent, a
 		StepsUsed:  len(toolCallsInterface),
 		ToolsUsed:  len(toolCallsInterface),
 		TokenUsage: tokenUsage,
+		Warnings:   warnings,
 		App:        app,     // CloudShip data ingestion app classification
 		AppType:    appType, // CloudShip data ingestion app_type classification
 	}, nil
@@ -0,0 +1,164 @@
+package dotprompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"station/internal/config"
+
+	"github.com/firebase/genkit/go/ai"
+)
+
+const (
+	// maxExecuteAttempts is how many times a single model is tried before
+	// giving up (or switching to the fallback model)
+	maxExecuteAttempts = 3
+	// retryBackoffBase is the first retry delay; it doubles on each attempt
+	retryBackoffBase = 2 * time.Second
+)
+
+// executePromptWithRetry runs the prompt against the primary model, retrying
+// transient provider errors (429, 5xx, timeouts) with exponential backoff.
+// Once the primary model's attempts are exhausted it switches to the
+// configured fallback model (fallback_ai_provider / fallback_ai_model) and
+// retries there. Returns the response plus the model that actually produced
+// it so runs record the real model, not just the configured one.
+func (e *GenKitExecutor) executePromptWithRetry(ctx context.Context, agentPrompt ai.Prompt, modelName string, inputMap map[string]any, maxTurns int, mcpTools []ai.ToolRef, logCallback func(map[string]interface{})) (*ai.ModelResponse, string, error) {
+	models := []string{modelName}
+	if fallback := e.getFallbackModelName(); fallback != "" && fallback != modelName {
+		models = append(models, fallback)
+	}
+
+	var lastErr error
+	for modelIdx, model := range models {
+		if modelIdx > 0 {
+			logRetryEvent(logCallback, fmt.Sprintf("Falling back to model %s after primary model %s failed: %v", model, models[0], lastErr))
+		}
+
+		backoff := retryBackoffBase
+		for attempt := 1; attempt <= maxExecuteAttempts; attempt++ {
+			execOpts := []ai.PromptExecuteOption{
+				ai.WithInput(inputMap),
+				ai.WithMaxTurns(maxTurns),
+				ai.WithModelName(model),
+				ai.WithTools(mcpTools...),
+			}
+			// Enforce the agent's step policy around each model turn, if one is set
+			if e.stepPolicy != nil && e.policyState != nil {
+				execOpts = append(execOpts, ai.WithMiddleware(stepPolicyMiddleware(e.stepPolicy, e.policyState)))
+			}
+			// Apply the execution profile's generation settings, if one is set
+			if e.profile != nil {
+				if genCfg := e.profile.GenerateConfig(); len(genCfg) > 0 {
+					execOpts = append(execOpts, ai.WithConfig(genCfg))
+				}
+				if e.profile.ToolChoice != "" {
+					execOpts = append(execOpts, ai.WithToolChoice(ai.ToolChoice(e.profile.ToolChoice)))
+				}
+			}
+
+			resp, err := agentPrompt.Execute(ctx, execOpts...)
+			if err == nil {
+				return resp, model, nil
+			}
+			lastErr = err
+
+			if !isRetryableProviderError(err) {
+				return nil, model, err
+			}
+			// Don't burn retries against an expired run deadline
+			if ctx.Err() != nil {
+				return nil, model, err
+			}
+			if attempt == maxExecuteAttempts {
+				break // Exhausted this model, move to fallback if configured
+			}
+
+			logRetryEvent(logCallback, fmt.Sprintf("Provider error on %s (attempt %d/%d), retrying in %s: %v", model, attempt, maxExecuteAttempts, backoff, err))
+			select {
+			case <-ctx.Done():
+				return nil, model, err
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+
+	return nil, models[len(models)-1], lastErr
+}
+
+// getFallbackModelName builds the fallback model name with provider prefix,
+// mirroring getModelName. Returns "" when no fallback is configured.
+func (e *GenKitExecutor) getFallbackModelName() string {
+	cfg, err := config.Load()
+	if err != nil || cfg.FallbackAIProvider == "" || cfg.FallbackAIModel == "" {
+		return ""
+	}
+
+	switch strings.ToLower(cfg.FallbackAIProvider) {
+	case "gemini", "googlegenai":
+		return fmt.Sprintf("googleai/%s", cfg.FallbackAIModel)
+	case "openai":
+		return fmt.Sprintf("openai/%s", cfg.FallbackAIModel)
+	default:
+		return fmt.Sprintf("%s/%s", cfg.FallbackAIProvider, cfg.FallbackAIModel)
+	}
+}
+
+// isRetryableProviderError reports whether a provider failure is transient
+// (rate limit, server error, timeout) and worth retrying. Genuine request
+// errors (bad schema, auth, model not found) are not retried.
+func isRetryableProviderError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	retryableFragments := []string{
+		"429",
+		"rate limit",
+		"quota exceeded",
+		"resource exhausted",
+		"500",
+		"502",
+		"503",
+		"504",
+		"internal server error",
+		"bad gateway",
+		"service unavailable",
+		"gateway timeout",
+		"overloaded",
+		"timeout",
+		"deadline exceeded",
+		"connection reset",
+		"connection refused",
+		"eof",
+	}
+	for _, fragment := range retryableFragments {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// logRetryEvent surfaces retry/fallback decisions to the execution log stream
+func logRetryEvent(logCallback func(map[string]interface{}), message string) {
+	fmt.Printf("DEBUG: %s\n", message)
+	if logCallback != nil {
+		logCallback(map[string]interface{}{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"level":     "info",
+			"message":   message,
+			"details": map[string]interface{}{
+				"source": "provider_retry",
+			},
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package dotprompt
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestIsRetryableProviderError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"nil error", nil, false},
+		{"rate limit", fmt.Errorf("openai: 429 Too Many Requests"), true},
+		{"server error", fmt.Errorf("googleai: 503 Service Unavailable"), true},
+		{"overloaded", fmt.Errorf("anthropic: Overloaded"), true},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"wrapped timeout", fmt.Errorf("dotprompt.Execute() failed: %w", context.DeadlineExceeded), true},
+		{"auth error", fmt.Errorf("401 Unauthorized: invalid api key"), false},
+		{"bad request", fmt.Errorf("400 Bad Request: invalid schema"), false},
+		{"model not found", fmt.Errorf("model openai/gpt-nonexistent not found"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableProviderError(tt.err); got != tt.retryable {
+				t.Errorf("isRetryableProviderError(%v) = %v, want %v", tt.err, got, tt.retryable)
+			}
+		})
+	}
+}
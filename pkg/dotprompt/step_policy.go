@@ -0,0 +1,85 @@
+package dotprompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"station/pkg/models"
+
+	"github.com/firebase/genkit/go/ai"
+)
+
+// stepPolicyState tracks what the step policy middleware observed across the
+// turns of one execution so violations surface as run warnings
+type stepPolicyState struct {
+	mu         sync.Mutex
+	toolCalled bool
+	warnings   []string
+}
+
+func (s *stepPolicyState) addWarning(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.warnings = append(s.warnings, message)
+}
+
+func (s *stepPolicyState) markToolCalled() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.toolCalled = true
+}
+
+func (s *stepPolicyState) hasToolCall() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.toolCalled
+}
+
+// Warnings returns a copy of the accumulated policy warnings
+func (s *stepPolicyState) Warnings() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.warnings...)
+}
+
+// stepPolicyMiddleware enforces an agent's step policy around each model turn:
+// an optional per-turn timeout and a "must call a tool before finalizing"
+// check. Each model call inside the generate loop is one turn, so wrapping the
+// model request gives per-turn rather than per-run semantics. Violations are
+// recorded on the shared state as warnings instead of silently changing the
+// agent's behavior.
+func stepPolicyMiddleware(policy *models.AgentStepPolicy, state *stepPolicyState) ai.ModelMiddleware {
+	return func(next ai.ModelFunc) ai.ModelFunc {
+		return func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+			turnCtx := ctx
+			if policy.PerTurnTimeoutSeconds != nil && *policy.PerTurnTimeoutSeconds > 0 {
+				timeout := time.Duration(*policy.PerTurnTimeoutSeconds) * time.Second
+				var cancel context.CancelFunc
+				turnCtx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			resp, err := next(turnCtx, req, cb)
+			if err != nil {
+				if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil &&
+					policy.PerTurnTimeoutSeconds != nil {
+					message := fmt.Sprintf("turn exceeded per-turn timeout of %ds", *policy.PerTurnTimeoutSeconds)
+					state.addWarning(message)
+					return nil, fmt.Errorf("%s: %w", message, err)
+				}
+				return nil, err
+			}
+
+			if len(resp.ToolRequests()) > 0 {
+				state.markToolCalled()
+			} else if policy.RequireToolCall && !state.hasToolCall() {
+				state.addWarning("agent finalized its response without calling any tool (require_tool_call policy)")
+			}
+
+			return resp, nil
+		}
+	}
+}
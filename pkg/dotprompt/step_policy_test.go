@@ -0,0 +1,125 @@
+package dotprompt
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"station/pkg/models"
+
+	"github.com/firebase/genkit/go/ai"
+)
+
+func textResponse(text string) *ai.ModelResponse {
+	return &ai.ModelResponse{
+		Message: &ai.Message{
+			Role:    ai.RoleModel,
+			Content: []*ai.Part{ai.NewTextPart(text)},
+		},
+	}
+}
+
+func toolRequestResponse(toolName string) *ai.ModelResponse {
+	return &ai.ModelResponse{
+		Message: &ai.Message{
+			Role:    ai.RoleModel,
+			Content: []*ai.Part{ai.NewToolRequestPart(&ai.ToolRequest{Name: toolName})},
+		},
+	}
+}
+
+func TestStepPolicyMiddlewareRequireToolCall(t *testing.T) {
+	policy := &models.AgentStepPolicy{RequireToolCall: true}
+	state := &stepPolicyState{}
+	mw := stepPolicyMiddleware(policy, state)
+
+	finalize := mw(func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		return textResponse("done without tools"), nil
+	})
+	if _, err := finalize(context.Background(), &ai.ModelRequest{}, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	warnings := state.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %v", warnings)
+	}
+}
+
+func TestStepPolicyMiddlewareToolCallSatisfiesPolicy(t *testing.T) {
+	policy := &models.AgentStepPolicy{RequireToolCall: true}
+	state := &stepPolicyState{}
+	mw := stepPolicyMiddleware(policy, state)
+
+	toolTurn := mw(func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		return toolRequestResponse("__read_text_file"), nil
+	})
+	if _, err := toolTurn(context.Background(), &ai.ModelRequest{}, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	finalTurn := mw(func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		return textResponse("summary of file"), nil
+	})
+	if _, err := finalTurn(context.Background(), &ai.ModelRequest{}, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if warnings := state.Warnings(); len(warnings) != 0 {
+		t.Errorf("Expected no warnings after tool call, got %v", warnings)
+	}
+}
+
+func TestStepPolicyMiddlewarePerTurnTimeout(t *testing.T) {
+	timeout := int64(1)
+	policy := &models.AgentStepPolicy{PerTurnTimeoutSeconds: &timeout}
+	state := &stepPolicyState{}
+	mw := stepPolicyMiddleware(policy, state)
+
+	slowTurn := mw(func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return textResponse("too late"), nil
+		}
+	})
+
+	_, err := slowTurn(context.Background(), &ai.ModelRequest{}, nil)
+	if err == nil {
+		t.Fatal("Expected per-turn timeout error")
+	}
+	if got := err.Error(); !strings.Contains(got, "per-turn timeout of 1s") {
+		t.Errorf("Unexpected error message: %s", got)
+	}
+
+	warnings := state.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %v", warnings)
+	}
+}
+
+func TestStepPolicyMiddlewareIgnoresRunDeadline(t *testing.T) {
+	timeout := int64(30)
+	policy := &models.AgentStepPolicy{PerTurnTimeoutSeconds: &timeout}
+	state := &stepPolicyState{}
+	mw := stepPolicyMiddleware(policy, state)
+
+	runCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	slowTurn := mw(func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	if _, err := slowTurn(runCtx, &ai.ModelRequest{}, nil); err == nil {
+		t.Fatal("Expected run deadline error")
+	}
+
+	// The run's own deadline expiring is not a policy violation
+	if warnings := state.Warnings(); len(warnings) != 0 {
+		t.Errorf("Expected no warnings for run deadline, got %v", warnings)
+	}
+}
@@ -0,0 +1,34 @@
+package dotprompt
+
+import "encoding/json"
+
+// estimatedCharsPerToken is the standard rough conversion for English text
+// and JSON payloads; exact tokenization is model-specific and not worth a
+// tokenizer dependency for an attribution estimate
+const estimatedCharsPerToken = 4
+
+// EstimateToolCallTokens estimates how many prompt tokens a tool's output
+// adds to the conversation. Every subsequent turn re-sends the output, so
+// large tool responses compound - this estimate lets run inspection surface
+// which tools are growing the context.
+func EstimateToolCallTokens(output interface{}) int {
+	if output == nil {
+		return 0
+	}
+
+	var size int
+	if s, ok := output.(string); ok {
+		size = len(s)
+	} else if encoded, err := json.Marshal(output); err == nil {
+		size = len(encoded)
+	}
+
+	if size == 0 {
+		return 0
+	}
+	tokens := size / estimatedCharsPerToken
+	if tokens == 0 {
+		tokens = 1
+	}
+	return tokens
+}
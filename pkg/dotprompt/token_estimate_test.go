@@ -0,0 +1,52 @@
+package dotprompt
+
+import "testing"
+
+func TestEstimateToolCallTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		output interface{}
+		want   int
+	}{
+		{
+			name:   "nil output",
+			output: nil,
+			want:   0,
+		},
+		{
+			name:   "empty string",
+			output: "",
+			want:   0,
+		},
+		{
+			name:   "short string rounds up to one token",
+			output: "ok",
+			want:   1,
+		},
+		{
+			name:   "string uses raw length",
+			output: "0123456789abcdef", // 16 chars
+			want:   4,
+		},
+		{
+			name:   "structured output measured as JSON",
+			output: map[string]interface{}{"status": "done"}, // {"status":"done"} = 17 chars
+			want:   4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateToolCallTokens(tt.output); got != tt.want {
+				t.Errorf("EstimateToolCallTokens(%v) = %d, want %d", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateToolCallTokensUnmarshalableOutput(t *testing.T) {
+	// Values json.Marshal cannot encode should not panic and estimate zero
+	if got := EstimateToolCallTokens(make(chan int)); got != 0 {
+		t.Errorf("EstimateToolCallTokens(chan) = %d, want 0", got)
+	}
+}
@@ -137,6 +137,7 @@ type ExecutionResponse struct {
 	StepsUsed      int                    `json:"steps_used"`
 	ToolsUsed      int                    `json:"tools_used"`
 	TokenUsage     map[string]interface{} `json:"token_usage,omitempty"`
+	Warnings       []string               `json:"warnings,omitempty"` // Step policy violations surfaced as run warnings
 	Error          string                 `json:"error,omitempty"`
 	RawResponse    *ai.ModelResponse      `json:"-"` // Don't serialize raw response
 	// Metadata from dotprompt for data ingestion classification
@@ -0,0 +1,249 @@
+package faker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Chaos injection simulates degraded MCP conditions — slow servers,
+// intermittent failures, malformed payloads — so agent resilience and retry
+// logic can be tested without a flaky real backend. Parameters come from a
+// scenario file (--chaos-file / STN_FAKER_CHAOS_FILE) or individual env vars:
+//
+//	STN_FAKER_CHAOS_LATENCY_MS=100-500   artificial latency (uniform range or fixed)
+//	STN_FAKER_CHAOS_ERROR_RATE=0.1       fraction of calls that fail
+//	STN_FAKER_CHAOS_MALFORMED_RATE=0.05  fraction of calls returning broken JSON
+//
+// A scenario file sets the same knobs globally and per tool:
+//
+//	{
+//	  "latency_min_ms": 100, "latency_max_ms": 500, "error_rate": 0.1,
+//	  "tools": {"get_metrics": {"error_rate": 0.5, "malformed_rate": 0.2}}
+//	}
+
+// ChaosRule holds the injection parameters for one tool (or the global default)
+type ChaosRule struct {
+	LatencyMinMs  int     `json:"latency_min_ms"`
+	LatencyMaxMs  int     `json:"latency_max_ms"`
+	ErrorRate     float64 `json:"error_rate"`
+	MalformedRate float64 `json:"malformed_rate"`
+}
+
+// ChaosConfig combines a global rule with per-tool overrides
+type ChaosConfig struct {
+	ChaosRule
+	Tools map[string]ChaosRule `json:"tools,omitempty"`
+}
+
+// chaos outcomes decided per call
+const (
+	chaosPass = iota
+	chaosError
+	chaosMalformed
+)
+
+func validateChaosRule(name string, rule ChaosRule) error {
+	if rule.ErrorRate < 0 || rule.ErrorRate > 1 {
+		return fmt.Errorf("%s: error_rate must be between 0 and 1, got %v", name, rule.ErrorRate)
+	}
+	if rule.MalformedRate < 0 || rule.MalformedRate > 1 {
+		return fmt.Errorf("%s: malformed_rate must be between 0 and 1, got %v", name, rule.MalformedRate)
+	}
+	if rule.LatencyMinMs < 0 || rule.LatencyMaxMs < 0 {
+		return fmt.Errorf("%s: latency must not be negative", name)
+	}
+	if rule.LatencyMaxMs > 0 && rule.LatencyMaxMs < rule.LatencyMinMs {
+		return fmt.Errorf("%s: latency_max_ms must be >= latency_min_ms", name)
+	}
+	return nil
+}
+
+// LoadChaosConfig reads and validates a chaos scenario file
+func LoadChaosConfig(path string) (*ChaosConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chaos scenario file: %w", err)
+	}
+
+	var cfg ChaosConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse chaos scenario file: %w", err)
+	}
+	if err := validateChaosRule("global", cfg.ChaosRule); err != nil {
+		return nil, err
+	}
+	for tool, rule := range cfg.Tools {
+		if err := validateChaosRule("tools."+tool, rule); err != nil {
+			return nil, err
+		}
+	}
+	return &cfg, nil
+}
+
+// chaosConfigFromEnv builds a global-only chaos config from env vars,
+// returning nil when no chaos knobs are set
+func chaosConfigFromEnv() (*ChaosConfig, error) {
+	latency := os.Getenv("STN_FAKER_CHAOS_LATENCY_MS")
+	errorRate := os.Getenv("STN_FAKER_CHAOS_ERROR_RATE")
+	malformedRate := os.Getenv("STN_FAKER_CHAOS_MALFORMED_RATE")
+	if latency == "" && errorRate == "" && malformedRate == "" {
+		return nil, nil
+	}
+
+	var cfg ChaosConfig
+	if latency != "" {
+		minStr, maxStr, found := strings.Cut(latency, "-")
+		if !found {
+			maxStr = minStr
+		}
+		minMs, err := strconv.Atoi(strings.TrimSpace(minStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid STN_FAKER_CHAOS_LATENCY_MS: %s", latency)
+		}
+		maxMs, err := strconv.Atoi(strings.TrimSpace(maxStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid STN_FAKER_CHAOS_LATENCY_MS: %s", latency)
+		}
+		cfg.LatencyMinMs, cfg.LatencyMaxMs = minMs, maxMs
+	}
+	if errorRate != "" {
+		rate, err := strconv.ParseFloat(errorRate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STN_FAKER_CHAOS_ERROR_RATE: %s", errorRate)
+		}
+		cfg.ErrorRate = rate
+	}
+	if malformedRate != "" {
+		rate, err := strconv.ParseFloat(malformedRate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STN_FAKER_CHAOS_MALFORMED_RATE: %s", malformedRate)
+		}
+		cfg.MalformedRate = rate
+	}
+
+	if err := validateChaosRule("env", cfg.ChaosRule); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// loadChaosFromEnvironment resolves the chaos config for a new faker:
+// scenario file (STN_FAKER_CHAOS_FILE) wins over individual env vars
+func loadChaosFromEnvironment(debug bool) *ChaosConfig {
+	if path := os.Getenv("STN_FAKER_CHAOS_FILE"); path != "" {
+		cfg, err := LoadChaosConfig(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[FAKER] Warning: Ignoring chaos scenario file: %v\n", err)
+			return nil
+		}
+		if debug {
+			fmt.Fprintf(os.Stderr, "[FAKER] Chaos scenario loaded from %s\n", path)
+		}
+		return cfg
+	}
+
+	cfg, err := chaosConfigFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[FAKER] Warning: Ignoring chaos env vars: %v\n", err)
+		return nil
+	}
+	if cfg != nil && debug {
+		fmt.Fprintf(os.Stderr, "[FAKER] Chaos injection enabled from env vars\n")
+	}
+	return cfg
+}
+
+// ruleFor returns the per-tool rule when one exists, otherwise the global rule
+func (c *ChaosConfig) ruleFor(toolName string) ChaosRule {
+	if rule, ok := c.Tools[toolName]; ok {
+		return rule
+	}
+	return c.ChaosRule
+}
+
+// chaosLatency draws an artificial delay from the rule's uniform distribution
+func chaosLatency(rule ChaosRule, rng *rand.Rand) time.Duration {
+	if rule.LatencyMaxMs <= 0 {
+		return 0
+	}
+	spread := rule.LatencyMaxMs - rule.LatencyMinMs
+	ms := rule.LatencyMinMs
+	if spread > 0 {
+		ms += rng.Intn(spread + 1)
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// chaosOutcome rolls whether this call fails, returns malformed data, or passes
+func chaosOutcome(rule ChaosRule, rng *rand.Rand) int {
+	roll := rng.Float64()
+	if roll < rule.ErrorRate {
+		return chaosError
+	}
+	if roll < rule.ErrorRate+rule.MalformedRate {
+		return chaosMalformed
+	}
+	return chaosPass
+}
+
+// SetChaosConfig overrides the faker's chaos configuration (e.g. from --chaos-file)
+func (f *MCPFaker) SetChaosConfig(cfg *ChaosConfig) {
+	f.chaos = cfg
+}
+
+// maybeInjectChaos applies the configured latency and failure injection for a
+// tool call. Returns a non-nil result when the call's outcome is injected
+// (error or malformed payload); nil means the call proceeds normally.
+func (f *MCPFaker) maybeInjectChaos(ctx context.Context, toolName string) *mcp.CallToolResult {
+	if f.chaos == nil {
+		return nil
+	}
+	rule := f.chaos.ruleFor(toolName)
+
+	if delay := chaosLatency(rule, chaosRand); delay > 0 {
+		if f.debug {
+			fmt.Fprintf(os.Stderr, "[FAKER] 🌀 Chaos: delaying %s by %v\n", toolName, delay)
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	switch chaosOutcome(rule, chaosRand) {
+	case chaosError:
+		if f.debug {
+			fmt.Fprintf(os.Stderr, "[FAKER] 🌀 Chaos: injecting error for %s\n", toolName)
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("chaos: injected intermittent failure for tool %s (simulated degraded MCP server)", toolName)),
+			},
+			IsError: true,
+		}
+	case chaosMalformed:
+		if f.debug {
+			fmt.Fprintf(os.Stderr, "[FAKER] 🌀 Chaos: injecting malformed response for %s\n", toolName)
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(`{"truncated": "chaos-injected malformed payload", "data": [{"id": 1,`),
+			},
+			IsError: false,
+		}
+	}
+	return nil
+}
+
+// chaosRand is the shared source for chaos rolls; tool handlers run
+// sequentially per faker process so a plain source suffices
+var chaosRand = rand.New(rand.NewSource(time.Now().UnixNano()))
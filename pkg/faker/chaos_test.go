@@ -0,0 +1,150 @@
+package faker
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadChaosConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.json")
+	scenario := `{
+		"latency_min_ms": 100,
+		"latency_max_ms": 500,
+		"error_rate": 0.1,
+		"tools": {
+			"get_metrics": {"error_rate": 0.5, "malformed_rate": 0.2}
+		}
+	}`
+	if err := os.WriteFile(path, []byte(scenario), 0644); err != nil {
+		t.Fatalf("failed to write scenario: %v", err)
+	}
+
+	cfg, err := LoadChaosConfig(path)
+	if err != nil {
+		t.Fatalf("LoadChaosConfig failed: %v", err)
+	}
+	if cfg.LatencyMinMs != 100 || cfg.LatencyMaxMs != 500 || cfg.ErrorRate != 0.1 {
+		t.Errorf("unexpected global rule: %+v", cfg.ChaosRule)
+	}
+
+	// Per-tool override wins; unknown tools fall back to the global rule
+	rule := cfg.ruleFor("get_metrics")
+	if rule.ErrorRate != 0.5 || rule.MalformedRate != 0.2 {
+		t.Errorf("unexpected tool rule: %+v", rule)
+	}
+	if rule := cfg.ruleFor("list_alarms"); rule.ErrorRate != 0.1 {
+		t.Errorf("expected global fallback, got %+v", rule)
+	}
+
+	// Invalid rates are rejected
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"error_rate": 1.5}`), 0644); err != nil {
+		t.Fatalf("failed to write scenario: %v", err)
+	}
+	if _, err := LoadChaosConfig(bad); err == nil {
+		t.Error("expected error for error_rate > 1")
+	}
+}
+
+func TestChaosConfigFromEnv(t *testing.T) {
+	// No knobs set means no chaos
+	cfg, err := chaosConfigFromEnv()
+	if err != nil || cfg != nil {
+		t.Fatalf("expected nil config without env vars, got %+v / %v", cfg, err)
+	}
+
+	t.Setenv("STN_FAKER_CHAOS_LATENCY_MS", "100-500")
+	t.Setenv("STN_FAKER_CHAOS_ERROR_RATE", "0.25")
+	cfg, err = chaosConfigFromEnv()
+	if err != nil {
+		t.Fatalf("chaosConfigFromEnv failed: %v", err)
+	}
+	if cfg.LatencyMinMs != 100 || cfg.LatencyMaxMs != 500 || cfg.ErrorRate != 0.25 {
+		t.Errorf("unexpected config from env: %+v", cfg.ChaosRule)
+	}
+
+	// Fixed latency (no range)
+	t.Setenv("STN_FAKER_CHAOS_LATENCY_MS", "250")
+	cfg, err = chaosConfigFromEnv()
+	if err != nil || cfg.LatencyMinMs != 250 || cfg.LatencyMaxMs != 250 {
+		t.Errorf("expected fixed 250ms latency, got %+v / %v", cfg, err)
+	}
+
+	t.Setenv("STN_FAKER_CHAOS_ERROR_RATE", "lots")
+	if _, err := chaosConfigFromEnv(); err == nil {
+		t.Error("expected error for non-numeric error rate")
+	}
+}
+
+func TestChaosLatency(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	if d := chaosLatency(ChaosRule{}, rng); d != 0 {
+		t.Errorf("expected no delay without latency config, got %v", d)
+	}
+
+	rule := ChaosRule{LatencyMinMs: 100, LatencyMaxMs: 500}
+	for i := 0; i < 100; i++ {
+		d := chaosLatency(rule, rng)
+		if d < 100*time.Millisecond || d > 500*time.Millisecond {
+			t.Fatalf("delay %v outside configured range", d)
+		}
+	}
+}
+
+func TestChaosOutcome(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// Rate extremes are deterministic
+	for i := 0; i < 20; i++ {
+		if got := chaosOutcome(ChaosRule{}, rng); got != chaosPass {
+			t.Fatalf("zero rates must always pass, got %d", got)
+		}
+		if got := chaosOutcome(ChaosRule{ErrorRate: 1}, rng); got != chaosError {
+			t.Fatalf("error_rate=1 must always fail, got %d", got)
+		}
+		if got := chaosOutcome(ChaosRule{MalformedRate: 1}, rng); got != chaosMalformed {
+			t.Fatalf("malformed_rate=1 must always inject malformed data, got %d", got)
+		}
+	}
+}
+
+func TestMaybeInjectChaos(t *testing.T) {
+	ctx := context.Background()
+
+	// Nil config is a no-op
+	f := &MCPFaker{}
+	if result := f.maybeInjectChaos(ctx, "get_metrics"); result != nil {
+		t.Error("expected pass-through without chaos config")
+	}
+
+	// Guaranteed error injection returns an IsError result
+	f.chaos = &ChaosConfig{ChaosRule: ChaosRule{ErrorRate: 1}}
+	result := f.maybeInjectChaos(ctx, "get_metrics")
+	if result == nil || !result.IsError {
+		t.Fatalf("expected injected error result, got %+v", result)
+	}
+
+	// Guaranteed malformed injection returns a non-error broken payload
+	f.chaos = &ChaosConfig{ChaosRule: ChaosRule{MalformedRate: 1}}
+	result = f.maybeInjectChaos(ctx, "get_metrics")
+	if result == nil || result.IsError {
+		t.Fatalf("expected injected malformed result, got %+v", result)
+	}
+
+	// Per-tool override disables chaos for other tools
+	f.chaos = &ChaosConfig{
+		Tools: map[string]ChaosRule{"flaky_tool": {ErrorRate: 1}},
+	}
+	if result := f.maybeInjectChaos(ctx, "stable_tool"); result != nil {
+		t.Error("expected pass-through for tool without a chaos rule")
+	}
+	if result := f.maybeInjectChaos(ctx, "flaky_tool"); result == nil || !result.IsError {
+		t.Error("expected injected error for flaky_tool")
+	}
+}
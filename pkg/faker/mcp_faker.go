@@ -69,6 +69,9 @@ type MCPFaker struct {
 	// Response cache for deterministic behavior within a session
 	responseCache     map[string]*mcp.CallToolResult // Cache key: hash(toolName + args)
 	responseCacheLock sync.RWMutex                   // Lock for thread-safe cache access
+
+	// Chaos injection for resilience testing (nil = disabled)
+	chaos *ChaosConfig
 }
 
 // Global log file for faker debugging (since stderr isn't captured by parent)
@@ -377,6 +380,7 @@ func NewMCPFaker(targetCmd string, targetArgs []string, targetEnv map[string]str
 		standaloneMode:    false,
 		responseCache:     make(map[string]*mcp.CallToolResult),
 		responseCacheLock: sync.RWMutex{},
+		chaos:             loadChaosFromEnvironment(debug),
 	}, nil
 }
 
@@ -569,6 +573,7 @@ func NewStandaloneFaker(fakerID string, instruction string, toolCache interface{
 		toolCache:         toolCache,
 		responseCache:     make(map[string]*mcp.CallToolResult),
 		responseCacheLock: sync.RWMutex{},
+		chaos:             loadChaosFromEnvironment(debug),
 	}, nil
 }
 
@@ -816,6 +821,16 @@ func (f *MCPFaker) handleToolCall(ctx context.Context, request mcp.CallToolReque
 		span.SetAttributes(attribute.String("faker.session_id", f.session.ID))
 	}
 
+	// Chaos injection runs before the response cache so latency and failures
+	// apply to every call (including retries of previously cached calls)
+	if injected := f.maybeInjectChaos(ctx, request.Params.Name); injected != nil {
+		span.SetAttributes(
+			attribute.Bool("faker.chaos_injected", true),
+			attribute.Bool("faker.chaos_error", injected.IsError),
+		)
+		return injected, nil
+	}
+
 	if f.debug {
 		fmt.Fprintf(os.Stderr, "[FAKER] Handling tool call: %s\n", request.Params.Name)
 		fmt.Fprintf(os.Stderr, "[FAKER DEBUG] safetyMode=%v, isWriteOp=%v, hasSession=%v\n",
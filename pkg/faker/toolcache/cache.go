@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -22,20 +24,57 @@ type Cache interface {
 
 	// HasTools checks if tools are cached for a faker ID
 	HasTools(ctx context.Context, fakerID string) (bool, error)
+
+	// ListEntries returns a summary of all cached tool sets
+	ListEntries(ctx context.Context) ([]CacheEntry, error)
+
+	// ClearAll removes all cached tools and returns the number of entries deleted
+	ClearAll(ctx context.Context) (int64, error)
 }
 
 // cache implements the Cache interface
 type cache struct {
 	db *sql.DB
+	// ttl of 0 means entries never expire
+	ttl time.Duration
+	// mu serializes writes so concurrent fakers starting during sync do not
+	// interleave the delete+insert in SetTools
+	mu sync.Mutex
 }
 
-// NewCache creates a new tool cache
+// NewCache creates a new tool cache whose entries never expire
 func NewCache(db *sql.DB) Cache {
-	return &cache{db: db}
+	return NewCacheWithTTL(db, 0)
+}
+
+// NewCacheWithTTL creates a tool cache whose entries expire after ttl;
+// expired entries are treated as misses and purged so tools regenerate
+func NewCacheWithTTL(db *sql.DB, ttl time.Duration) Cache {
+	return &cache{db: db, ttl: ttl}
+}
+
+// expireStale deletes entries for a faker ID that are older than the TTL, so
+// the subsequent lookup misses and tools are regenerated
+func (c *cache) expireStale(ctx context.Context, fakerID string) error {
+	if c.ttl <= 0 {
+		return nil
+	}
+	cutoff := fmt.Sprintf("-%d seconds", int64(c.ttl.Seconds()))
+	_, err := c.db.ExecContext(ctx,
+		"DELETE FROM faker_tool_cache WHERE config_hash = ? AND updated_at < datetime('now', ?)",
+		fakerID, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to expire stale tools: %w", err)
+	}
+	return nil
 }
 
 // GetTools retrieves cached tools for a faker ID (config hash) and session ID
 func (c *cache) GetTools(ctx context.Context, fakerID string) ([]mcp.Tool, string, error) {
+	if err := c.expireStale(ctx, fakerID); err != nil {
+		return nil, "", err
+	}
+
 	// First try to find by config_hash (new deterministic key)
 	query := `
 		SELECT tool_name, tool_schema, session_id
@@ -114,6 +153,9 @@ func (c *cache) GetTools(ctx context.Context, fakerID string) ([]mcp.Tool, strin
 
 // SetTools caches tools for a faker ID (config hash) with session ID - replaces existing
 func (c *cache) SetTools(ctx context.Context, fakerID string, tools []mcp.Tool, sessionID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Start transaction
 	tx, err := c.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -159,6 +201,9 @@ func (c *cache) SetTools(ctx context.Context, fakerID string, tools []mcp.Tool,
 
 // ClearTools removes all cached tools for a faker ID (config hash)
 func (c *cache) ClearTools(ctx context.Context, fakerID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Try clearing by config_hash first
 	result, err := c.db.ExecContext(ctx, "DELETE FROM faker_tool_cache WHERE config_hash = ?", fakerID)
 	if err != nil {
@@ -183,6 +228,10 @@ func (c *cache) ClearTools(ctx context.Context, fakerID string) error {
 
 // HasTools checks if tools are cached for a faker ID (config hash)
 func (c *cache) HasTools(ctx context.Context, fakerID string) (bool, error) {
+	if err := c.expireStale(ctx, fakerID); err != nil {
+		return false, err
+	}
+
 	// Try config_hash first (new deterministic key)
 	var count int
 	err := c.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM faker_tool_cache WHERE config_hash = ?", fakerID).Scan(&count)
@@ -201,3 +250,44 @@ func (c *cache) HasTools(ctx context.Context, fakerID string) (bool, error) {
 	}
 	return count > 0, nil
 }
+
+// ListEntries returns a per-faker summary of cached tool sets
+func (c *cache) ListEntries(ctx context.Context) ([]CacheEntry, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT faker_id, COALESCE(config_hash, ''), COALESCE(session_id, ''), COUNT(*), MAX(updated_at)
+		FROM faker_tool_cache
+		GROUP BY faker_id, config_hash
+		ORDER BY MAX(updated_at) DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cache entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []CacheEntry
+	for rows.Next() {
+		var entry CacheEntry
+		var updatedAt string
+		if err := rows.Scan(&entry.FakerID, &entry.ConfigHash, &entry.SessionID, &entry.ToolCount, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		if parsed, err := time.Parse("2006-01-02 15:04:05", updatedAt); err == nil {
+			entry.UpdatedAt = parsed
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// ClearAll removes every cached tool across all fakers
+func (c *cache) ClearAll(ctx context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result, err := c.db.ExecContext(ctx, "DELETE FROM faker_tool_cache")
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear cache: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows, nil
+}
@@ -3,7 +3,10 @@ package toolcache
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	_ "modernc.org/sqlite"
@@ -186,3 +189,125 @@ func TestToolCache_ReplaceTools(t *testing.T) {
 		t.Errorf("expected tool name 'tool3', got '%s'", retrieved[0].Name)
 	}
 }
+
+func TestToolCache_TTLExpiry(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cache := NewCacheWithTTL(db, time.Hour)
+	ctx := context.Background()
+
+	tools := []mcp.Tool{{Name: "fresh_tool", Description: "Fresh"}}
+	if err := cache.SetTools(ctx, "ttl-faker", tools, "session-ttl"); err != nil {
+		t.Fatalf("SetTools failed: %v", err)
+	}
+
+	// Fresh entries are served
+	has, err := cache.HasTools(ctx, "ttl-faker")
+	if err != nil || !has {
+		t.Fatalf("expected fresh tools cached, got %v / %v", has, err)
+	}
+
+	// Age the entry past the TTL; lookups then miss and purge it
+	if _, err := db.Exec("UPDATE faker_tool_cache SET updated_at = datetime('now', '-2 hours') WHERE config_hash = 'ttl-faker'"); err != nil {
+		t.Fatalf("failed to age entry: %v", err)
+	}
+	has, err = cache.HasTools(ctx, "ttl-faker")
+	if err != nil {
+		t.Fatalf("HasTools failed: %v", err)
+	}
+	if has {
+		t.Error("expired tools should not be served")
+	}
+
+	retrieved, _, err := cache.GetTools(ctx, "ttl-faker")
+	if err != nil || len(retrieved) != 0 {
+		t.Errorf("expired tools should be purged, got %d tools / %v", len(retrieved), err)
+	}
+
+	// A TTL of 0 never expires
+	eternal := NewCache(db)
+	if err := eternal.SetTools(ctx, "eternal-faker", tools, "session-eternal"); err != nil {
+		t.Fatalf("SetTools failed: %v", err)
+	}
+	if _, err := db.Exec("UPDATE faker_tool_cache SET updated_at = datetime('now', '-1 year') WHERE config_hash = 'eternal-faker'"); err != nil {
+		t.Fatalf("failed to age entry: %v", err)
+	}
+	has, err = eternal.HasTools(ctx, "eternal-faker")
+	if err != nil || !has {
+		t.Errorf("tools without a TTL should never expire, got %v / %v", has, err)
+	}
+}
+
+func TestToolCache_ListAndClearAll(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cache := NewCache(db)
+	ctx := context.Background()
+
+	if err := cache.SetTools(ctx, "faker-a", []mcp.Tool{{Name: "t1"}, {Name: "t2"}}, "s1"); err != nil {
+		t.Fatalf("SetTools failed: %v", err)
+	}
+	if err := cache.SetTools(ctx, "faker-b", []mcp.Tool{{Name: "t3"}}, "s2"); err != nil {
+		t.Fatalf("SetTools failed: %v", err)
+	}
+
+	entries, err := cache.ListEntries(ctx)
+	if err != nil {
+		t.Fatalf("ListEntries failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	counts := map[string]int{}
+	for _, entry := range entries {
+		counts[entry.ConfigHash] = entry.ToolCount
+	}
+	if counts["faker-a"] != 2 || counts["faker-b"] != 1 {
+		t.Errorf("unexpected tool counts: %v", counts)
+	}
+
+	deleted, err := cache.ClearAll(ctx)
+	if err != nil || deleted != 3 {
+		t.Errorf("expected 3 rows cleared, got %d / %v", deleted, err)
+	}
+	entries, err = cache.ListEntries(ctx)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("expected empty cache after ClearAll, got %d entries / %v", len(entries), err)
+	}
+}
+
+func TestToolCache_ConcurrentSetTools(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cache := NewCache(db)
+	ctx := context.Background()
+
+	// Simulate multiple fakers starting during sync writing the same entry
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			tools := []mcp.Tool{{Name: fmt.Sprintf("tool_%d", n)}}
+			if err := cache.SetTools(ctx, "concurrent-faker", tools, fmt.Sprintf("session-%d", n)); err != nil {
+				t.Errorf("concurrent SetTools failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// One writer wins cleanly: exactly one tool with a matching session
+	retrieved, sessionID, err := cache.GetTools(ctx, "concurrent-faker")
+	if err != nil {
+		t.Fatalf("GetTools failed: %v", err)
+	}
+	if len(retrieved) != 1 {
+		t.Fatalf("expected exactly 1 tool after concurrent writes, got %d", len(retrieved))
+	}
+	if sessionID == "" {
+		t.Error("expected a session ID from the winning writer")
+	}
+}
@@ -12,3 +12,12 @@ type CachedTool struct {
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
+
+// CacheEntry summarizes a cached tool set for one faker configuration
+type CacheEntry struct {
+	FakerID    string
+	ConfigHash string
+	SessionID  string
+	ToolCount  int
+	UpdatedAt  time.Time
+}
@@ -50,6 +50,11 @@ type MCPServer struct {
 	EnvironmentID  int64             `json:"environment_id" db:"environment_id"`
 	FileConfigID   *int64            `json:"file_config_id,omitempty" db:"file_config_id"`
 	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+
+	// Identity the server reported during the MCP initialize handshake,
+	// recorded at discovery time so version changes can be tracked across syncs
+	ServerInfoName    string `json:"server_info_name,omitempty" db:"server_info_name"`
+	ServerInfoVersion string `json:"server_info_version,omitempty" db:"server_info_version"`
 }
 
 type MCPTool struct {
@@ -87,6 +92,41 @@ type Agent struct {
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// AgentGroup is a named team of agents (e.g. "finops-team") used to scope
+// reports, budgets, and SLOs to a subset of an environment's agents
+type AgentGroup struct {
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// AgentStepPolicy controls how an agent's execution loop behaves: optional
+// max turns and per-turn timeout overrides plus a "must call a tool before
+// finalizing" check. Violations are recorded as run warnings rather than
+// silently changing behavior
+type AgentStepPolicy struct {
+	AgentID               int64     `json:"agent_id" db:"agent_id"`
+	MaxTurns              *int64    `json:"max_turns,omitempty" db:"max_turns"`
+	PerTurnTimeoutSeconds *int64    `json:"per_turn_timeout_seconds,omitempty" db:"per_turn_timeout_seconds"`
+	RequireToolCall       bool      `json:"require_tool_call" db:"require_tool_call"`
+	CreatedAt             time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// RunAnnotation is human feedback attached to an agent run: a 1-5 rating
+// and/or comment from `stn runs annotate`, or a thumbs up/down from the UI
+type RunAnnotation struct {
+	ID        int64     `json:"id" db:"id"`
+	RunID     int64     `json:"run_id" db:"run_id"`
+	Rating    *int64    `json:"rating,omitempty" db:"rating"`
+	Thumbs    *string   `json:"thumbs,omitempty" db:"thumbs"`
+	Comment   string    `json:"comment" db:"comment"`
+	CreatedBy string    `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
 // AgentTool represents the many-to-many relationship between agents and tools (environment-specific)
 type AgentTool struct {
 	ID        int64     `json:"id" db:"id"`
@@ -197,6 +237,7 @@ type MCPServerConfig struct {
 	Args    []string          `json:"args,omitempty"`
 	URL     string            `json:"url,omitempty"`
 	Env     map[string]string `json:"env,omitempty"`
+	Runner  string            `json:"runner,omitempty"` // Named SSH runner to execute the stdio process on
 }
 
 // Tool execution types for agent framework integration
@@ -95,6 +95,7 @@ const (
 	EventTypeStepCompleted   = "step_completed"
 	EventTypeStepFailed      = "step_failed"
 	EventTypeSignalReceived  = "signal_received"
+	EventTypeEventReceived   = "event_received"
 	EventTypeApprovalDecided = "approval_decided"
 )
 
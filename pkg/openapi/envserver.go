@@ -0,0 +1,360 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"station/pkg/openapi/runtime"
+)
+
+// supportedProtocolVersions lists the MCP protocol revisions the environment
+// server speaks, oldest first. Initialize echoes the client's requested
+// version when supported and falls back to the latest otherwise.
+var supportedProtocolVersions = []string{"2024-11-05", "2025-03-26", "2025-06-18"}
+
+// mcpRequest represents an MCP JSON-RPC request
+type mcpRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      interface{}     `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// mcpResponse represents an MCP JSON-RPC response
+type mcpResponse struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      interface{} `json:"id"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   *mcpError   `json:"error,omitempty"`
+}
+
+// mcpError represents an MCP error
+type mcpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// EnvironmentServer serves every *.openapi.json spec in an environment
+// directory as one combined MCP server over stdio. It backs both the
+// standalone openapi-mcp-server binary and `stn openapi-mcp serve`, so a
+// single distributed stn binary can cover the subprocess role.
+type EnvironmentServer struct {
+	servers map[string]*runtime.Server // Map of spec name to server
+	tools   []map[string]interface{}   // Combined list of all tools
+}
+
+// NewEnvironmentServer loads all OpenAPI specs from the environment
+// directory and prepares their tools for serving
+func NewEnvironmentServer(envDir string) (*EnvironmentServer, error) {
+	server := &EnvironmentServer{
+		servers: make(map[string]*runtime.Server),
+		tools:   []map[string]interface{}{},
+	}
+	if err := server.loadAllSpecs(envDir); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// ToolCount returns the number of tools across all loaded specs
+func (s *EnvironmentServer) ToolCount() int {
+	return len(s.tools)
+}
+
+// SpecCount returns the number of specs loaded from the environment
+func (s *EnvironmentServer) SpecCount() int {
+	return len(s.servers)
+}
+
+// ServeStdio reads JSON-RPC requests (single or batch) from in and writes
+// responses to out until EOF. Logging goes to the standard logger, which
+// callers must point at stderr to avoid corrupting the protocol stream.
+func (s *EnvironmentServer) ServeStdio(in io.Reader, out io.Writer) {
+	reader := bufio.NewReader(in)
+	encoder := json.NewEncoder(out)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			log.Printf("Error reading input: %v", err)
+			continue
+		}
+
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 {
+			continue
+		}
+
+		// JSON-RPC batch: an array of requests answered with an array of
+		// responses (notifications produce no response entry)
+		if trimmed[0] == '[' {
+			var batch []mcpRequest
+			if err := json.Unmarshal(trimmed, &batch); err != nil {
+				log.Printf("Error parsing batch request: %v", err)
+				_ = encoder.Encode(mcpResponse{JSONRPC: "2.0", Error: &mcpError{Code: -32700, Message: "Parse error"}})
+				continue
+			}
+			if len(batch) == 0 {
+				_ = encoder.Encode(mcpResponse{JSONRPC: "2.0", Error: &mcpError{Code: -32600, Message: "Invalid request: empty batch"}})
+				continue
+			}
+
+			responses := []mcpResponse{}
+			for _, req := range batch {
+				if response := s.handleRequest(req); response != nil {
+					responses = append(responses, *response)
+				}
+			}
+			if len(responses) > 0 {
+				if err := encoder.Encode(responses); err != nil {
+					log.Printf("Error sending batch response: %v", err)
+				}
+			}
+			continue
+		}
+
+		var req mcpRequest
+		if err := json.Unmarshal(trimmed, &req); err != nil {
+			log.Printf("Error parsing request: %v", err)
+			_ = encoder.Encode(mcpResponse{JSONRPC: "2.0", Error: &mcpError{Code: -32700, Message: "Parse error"}})
+			continue
+		}
+
+		if response := s.handleRequest(req); response != nil {
+			if err := encoder.Encode(*response); err != nil {
+				log.Printf("Error sending response: %v", err)
+			}
+		}
+	}
+}
+
+func (s *EnvironmentServer) loadAllSpecs(envDir string) error {
+	// Find all *.openapi.json files
+	pattern := filepath.Join(envDir, "*.openapi.json")
+	specFiles, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to scan for OpenAPI specs: %w", err)
+	}
+
+	if len(specFiles) == 0 {
+		log.Printf("No OpenAPI specs found in %s", envDir)
+		return nil
+	}
+
+	// Load each spec
+	for _, specFile := range specFiles {
+		specName := filepath.Base(specFile)
+		specName = strings.TrimSuffix(specName, ".openapi.json")
+
+		log.Printf("Loading OpenAPI spec: %s", specName)
+
+		// Read the spec file
+		specData, err := os.ReadFile(specFile)
+		if err != nil {
+			log.Printf("Failed to read %s: %v", specFile, err)
+			continue
+		}
+
+		// Parse and convert the OpenAPI spec
+		if err := s.loadSpec(specName, string(specData)); err != nil {
+			log.Printf("Failed to load spec %s: %v", specName, err)
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *EnvironmentServer) loadSpec(specName string, specContent string) error {
+	// Create OpenAPI service to convert the spec
+	svc := NewService()
+
+	// Validate the spec
+	if err := svc.ValidateSpec(specContent); err != nil {
+		return fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+
+	// Convert to MCP configuration
+	options := ConvertOptions{
+		ServerName:     specName,
+		ToolNamePrefix: specName,
+	}
+
+	stationConfig, err := svc.ConvertFromSpec(specContent, options)
+	if err != nil {
+		return fmt.Errorf("failed to convert spec: %w", err)
+	}
+
+	// Parse the Station config to get the embedded MCP config
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(stationConfig), &config); err != nil {
+		return fmt.Errorf("failed to parse station config: %w", err)
+	}
+
+	// Extract the MCP config from the environment variable
+	env, ok := config["env"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("no env in station config")
+	}
+
+	mcpConfigYAML, ok := env["OPENAPI_MCP_CONFIG"].(string)
+	if !ok {
+		return fmt.Errorf("no OPENAPI_MCP_CONFIG in env")
+	}
+
+	// Create a runtime server for this spec
+	server := runtime.NewServer(runtime.ServerConfig{
+		ConfigData: mcpConfigYAML,
+	})
+
+	s.servers[specName] = server
+
+	// Add tools from this server to our combined list
+	tools := server.ListTools()
+	s.tools = append(s.tools, tools...)
+
+	log.Printf("Loaded %d tools from %s", len(tools), specName)
+	return nil
+}
+
+// negotiateProtocolVersion picks the protocol version for an initialize
+// response: the client's requested version when supported, otherwise the
+// latest version this server speaks
+func negotiateProtocolVersion(requested string) string {
+	for _, version := range supportedProtocolVersions {
+		if version == requested {
+			return version
+		}
+	}
+	return supportedProtocolVersions[len(supportedProtocolVersions)-1]
+}
+
+// handleRequest processes a single JSON-RPC request. Notifications return
+// nil - they must not produce a response.
+func (s *EnvironmentServer) handleRequest(req mcpRequest) *mcpResponse {
+	// Notifications (initialized, cancelled, etc.) never get responses;
+	// cancellation is acknowledged by simply not responding since tool
+	// calls execute synchronously here
+	if strings.HasPrefix(req.Method, "notifications/") {
+		if req.Method == "notifications/cancelled" {
+			log.Printf("Received cancellation notification")
+		}
+		return nil
+	}
+
+	response := &mcpResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+	}
+
+	switch req.Method {
+	case "initialize":
+		var initParams struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params, &initParams)
+		}
+
+		result := map[string]interface{}{
+			"protocolVersion": negotiateProtocolVersion(initParams.ProtocolVersion),
+			"capabilities": map[string]interface{}{
+				"tools": map[string]interface{}{},
+			},
+			"serverInfo": map[string]interface{}{
+				"name":    "openapi-mcp-server",
+				"version": "1.0.0",
+			},
+		}
+		response.Result = result
+
+	case "ping":
+		response.Result = map[string]interface{}{}
+
+	case "tools/list":
+		// All tools fit in a single page; a cursor past that page yields an
+		// empty result rather than an error so paginating clients terminate
+		var listParams struct {
+			Cursor string `json:"cursor"`
+		}
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params, &listParams)
+		}
+
+		if listParams.Cursor != "" {
+			response.Result = map[string]interface{}{
+				"tools": []map[string]interface{}{},
+			}
+			return response
+		}
+		response.Result = map[string]interface{}{
+			"tools": s.tools,
+		}
+
+	case "tools/call":
+		// Extract parameters
+		var params map[string]interface{}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			response.Error = &mcpError{
+				Code:    -32602,
+				Message: "Invalid params",
+			}
+			return response
+		}
+
+		toolName, ok := params["name"].(string)
+		if !ok {
+			response.Error = &mcpError{
+				Code:    -32602,
+				Message: "Missing tool name",
+			}
+			return response
+		}
+
+		arguments, _ := params["arguments"].(map[string]interface{})
+
+		// Find which server has this tool
+		for specName, server := range s.servers {
+			tools := server.ListTools()
+			for _, tool := range tools {
+				if toolInfo, ok := tool["name"].(string); ok && toolInfo == toolName {
+					// Found the server that handles this tool
+					log.Printf("Executing tool %s from spec %s", toolName, specName)
+					result, err := server.CallTool(toolName, arguments)
+					if err != nil {
+						response.Error = &mcpError{
+							Code:    -32603,
+							Message: err.Error(),
+						}
+						return response
+					}
+					response.Result = result
+					return response
+				}
+			}
+		}
+
+		response.Error = &mcpError{
+			Code:    -32601,
+			Message: fmt.Sprintf("Tool not found: %s", toolName),
+		}
+
+	default:
+		response.Error = &mcpError{
+			Code:    -32601,
+			Message: fmt.Sprintf("Method not found: %s", req.Method),
+		}
+	}
+
+	return response
+}
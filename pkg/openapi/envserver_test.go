@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"station/pkg/openapi/runtime"
+)
+
+func testServer() *EnvironmentServer {
+	return &EnvironmentServer{
+		servers: make(map[string]*runtime.Server),
+		tools: []map[string]interface{}{
+			{"name": "test__get_user", "description": "Get a user"},
+		},
+	}
+}
+
+func TestNegotiateProtocolVersion(t *testing.T) {
+	tests := []struct {
+		requested string
+		expected  string
+	}{
+		{"2024-11-05", "2024-11-05"},
+		{"2025-03-26", "2025-03-26"},
+		{"2025-06-18", "2025-06-18"},
+		{"1999-01-01", "2025-06-18"},
+		{"", "2025-06-18"},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateProtocolVersion(tt.requested); got != tt.expected {
+			t.Errorf("negotiateProtocolVersion(%q) = %q, expected %q", tt.requested, got, tt.expected)
+		}
+	}
+}
+
+func TestHandleInitializeEchoesSupportedVersion(t *testing.T) {
+	server := testServer()
+
+	params, _ := json.Marshal(map[string]interface{}{"protocolVersion": "2024-11-05"})
+	response := server.handleRequest(mcpRequest{JSONRPC: "2.0", ID: 1, Method: "initialize", Params: params})
+	if response == nil || response.Error != nil {
+		t.Fatalf("Expected successful initialize, got %+v", response)
+	}
+
+	result := response.Result.(map[string]interface{})
+	if result["protocolVersion"] != "2024-11-05" {
+		t.Errorf("Expected negotiated version 2024-11-05, got %v", result["protocolVersion"])
+	}
+}
+
+func TestHandlePing(t *testing.T) {
+	response := testServer().handleRequest(mcpRequest{JSONRPC: "2.0", ID: 2, Method: "ping"})
+	if response == nil || response.Error != nil {
+		t.Fatalf("Expected ping to succeed, got %+v", response)
+	}
+}
+
+func TestHandleNotificationsProduceNoResponse(t *testing.T) {
+	server := testServer()
+	for _, method := range []string{"notifications/initialized", "notifications/cancelled", "notifications/unknown"} {
+		if response := server.handleRequest(mcpRequest{JSONRPC: "2.0", Method: method}); response != nil {
+			t.Errorf("Expected no response for %s, got %+v", method, response)
+		}
+	}
+}
+
+func TestHandleToolsListPagination(t *testing.T) {
+	server := testServer()
+
+	response := server.handleRequest(mcpRequest{JSONRPC: "2.0", ID: 3, Method: "tools/list"})
+	result := response.Result.(map[string]interface{})
+	if tools := result["tools"].([]map[string]interface{}); len(tools) != 1 {
+		t.Errorf("Expected 1 tool on first page, got %d", len(tools))
+	}
+
+	// A cursor past the single page terminates pagination with an empty page
+	params, _ := json.Marshal(map[string]interface{}{"cursor": "next"})
+	response = server.handleRequest(mcpRequest{JSONRPC: "2.0", ID: 4, Method: "tools/list", Params: params})
+	result = response.Result.(map[string]interface{})
+	if tools := result["tools"].([]map[string]interface{}); len(tools) != 0 {
+		t.Errorf("Expected empty page for trailing cursor, got %d tools", len(tools))
+	}
+}
+
+func TestHandleUnknownMethod(t *testing.T) {
+	response := testServer().handleRequest(mcpRequest{JSONRPC: "2.0", ID: 5, Method: "resources/list"})
+	if response == nil || response.Error == nil || response.Error.Code != -32601 {
+		t.Errorf("Expected method-not-found error, got %+v", response)
+	}
+}
@@ -0,0 +1,99 @@
+package stationtest
+
+import (
+	"context"
+	"fmt"
+
+	"station/internal/services"
+	"station/internal/storage"
+	"station/pkg/models"
+)
+
+// AgentService returns a scripted implementation of
+// services.AgentServiceInterface backed by this Station, so code that takes
+// the interface (queue services, MCP handlers, downstream embedders) can be
+// exercised in tests without a real provider.
+func (s *Station) AgentService() services.AgentServiceInterface {
+	return &scriptedAgentService{station: s}
+}
+
+// scriptedAgentService delegates agent management to the repositories and
+// execution to the Station's scripted runs.
+type scriptedAgentService struct {
+	station *Station
+}
+
+func (a *scriptedAgentService) ExecuteAgent(ctx context.Context, agentID int64, task string, userVariables map[string]interface{}) (*services.Message, error) {
+	run, err := a.station.RunAgent(ctx, agentID, task)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status == "failed" {
+		return nil, fmt.Errorf("agent execution failed: %s", run.FinalResponse)
+	}
+	return &services.Message{Role: services.RoleAssistant, Content: run.FinalResponse}, nil
+}
+
+func (a *scriptedAgentService) ExecuteAgentWithRunID(ctx context.Context, agentID int64, task string, runID int64, userVariables map[string]interface{}) (*services.Message, error) {
+	script := a.station.nextScript(agentID, task)
+	if err := a.station.completeRun(ctx, runID, script); err != nil {
+		return nil, err
+	}
+	if script.Err != nil {
+		return nil, script.Err
+	}
+	return &services.Message{Role: services.RoleAssistant, Content: script.Response}, nil
+}
+
+func (a *scriptedAgentService) CreateAgent(ctx context.Context, config *services.AgentConfig) (*models.Agent, error) {
+	environmentID := config.EnvironmentID
+	if environmentID == 0 {
+		environmentID = a.station.Environment.ID
+	}
+	createdBy := config.CreatedBy
+	if createdBy == 0 {
+		createdBy = a.station.User.ID
+	}
+	return a.station.Repos.Agents.Create(
+		config.Name, config.Description, config.Prompt, config.MaxSteps,
+		environmentID, createdBy, config.InputSchema,
+		config.CronSchedule, config.ScheduleEnabled,
+		config.OutputSchema, config.OutputSchemaPreset,
+		config.App, config.AppType,
+	)
+}
+
+func (a *scriptedAgentService) GetAgent(ctx context.Context, agentID int64) (*models.Agent, error) {
+	return a.station.Repos.Agents.GetByID(agentID)
+}
+
+func (a *scriptedAgentService) ListAgentsByEnvironment(ctx context.Context, environmentID int64) ([]*models.Agent, error) {
+	return a.station.Repos.Agents.ListByEnvironment(environmentID)
+}
+
+func (a *scriptedAgentService) UpdateAgent(ctx context.Context, agentID int64, config *services.AgentConfig) (*models.Agent, error) {
+	err := a.station.Repos.Agents.Update(
+		agentID, config.Name, config.Description, config.Prompt, config.MaxSteps,
+		config.InputSchema, config.CronSchedule, config.ScheduleEnabled,
+		config.ScheduleVariables, config.OutputSchema, config.OutputSchemaPreset,
+		config.App, config.AppType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a.station.Repos.Agents.GetByID(agentID)
+}
+
+func (a *scriptedAgentService) UpdateAgentPrompt(ctx context.Context, agentID int64, prompt string) error {
+	return a.station.Repos.Agents.UpdatePrompt(agentID, prompt)
+}
+
+func (a *scriptedAgentService) DeleteAgent(ctx context.Context, agentID int64) error {
+	return a.station.Repos.Agents.Delete(agentID)
+}
+
+// SetFileStore is a no-op; the scripted executor never stages sandbox files.
+func (a *scriptedAgentService) SetFileStore(store storage.FileStore) {}
+
+// SetSessionStore is a no-op; the scripted executor never persists sessions.
+func (a *scriptedAgentService) SetSessionStore(store services.SessionStore) {}
@@ -0,0 +1,242 @@
+// Package stationtest provides an in-memory Station harness for Go tests.
+//
+// It spins up a temp SQLite database with all migrations applied and exposes
+// the repository layer plus a scripted agent executor, so downstream projects
+// can write integration tests against agents and runs without real AI
+// providers, MCP subprocesses, or network access:
+//
+//	st := stationtest.New(t)
+//	agent := st.CreateAgent("auditor", "Scans configs", "You are an auditor", 5)
+//	st.Script(agent.ID, stationtest.ScriptedRun{
+//		Response: "No issues found",
+//		ToolCalls: []stationtest.ScriptedToolCall{
+//			{Tool: "__read_text_file", Arguments: map[string]interface{}{"path": "main.tf"}},
+//		},
+//	})
+//	run, err := st.RunAgent(context.Background(), agent.ID, "audit the repo")
+//
+// Each scripted run is consumed in FIFO order; tool call outputs can come
+// from the script itself or from stub tools registered with StubTool, which
+// stand in for faker-backed MCP servers. Run records are written through the
+// real repositories, so assertions see the same shapes (tool_calls,
+// execution_steps, token metadata) that a live execution produces.
+package stationtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"station/internal/db"
+	"station/internal/db/repositories"
+	"station/pkg/models"
+)
+
+// ScriptedToolCall describes one tool invocation the scripted executor will
+// record on the run. When Result is nil and a stub is registered for the tool
+// via StubTool, the stub supplies the output.
+type ScriptedToolCall struct {
+	Tool      string
+	Arguments map[string]interface{}
+	Result    interface{}
+}
+
+// ScriptedRun is one canned agent execution. Runs queued with Script are
+// consumed in FIFO order; when the queue is empty RunAgent falls back to a
+// generic completion response.
+type ScriptedRun struct {
+	Response     string
+	ToolCalls    []ScriptedToolCall
+	InputTokens  int64
+	OutputTokens int64
+	// Err marks the run failed with this error recorded on the run row
+	Err error
+}
+
+// Station is an in-memory Station instance backed by a temp SQLite database.
+// The database is migrated on creation and closed automatically via
+// tb.Cleanup. The seeded console user and default environment are exposed so
+// tests can attach agents without extra setup.
+type Station struct {
+	Repos       *repositories.Repositories
+	Environment *models.Environment
+	User        *models.User
+
+	tb      testing.TB
+	mu      sync.Mutex
+	scripts map[int64][]ScriptedRun
+	stubs   map[string]func(args map[string]interface{}) interface{}
+}
+
+// New creates an in-memory Station for the test. All failures during setup
+// are fatal to the test.
+func New(tb testing.TB) *Station {
+	tb.Helper()
+
+	database, err := db.NewTest(tb)
+	if err != nil {
+		tb.Fatalf("stationtest: failed to create test database: %v", err)
+	}
+	tb.Cleanup(func() { database.Close() })
+
+	repos := repositories.New(database)
+
+	user, err := repos.Users.GetByUsername("console")
+	if err != nil {
+		tb.Fatalf("stationtest: failed to load console user: %v", err)
+	}
+	env, err := repos.Environments.GetByName("default")
+	if err != nil {
+		tb.Fatalf("stationtest: failed to load default environment: %v", err)
+	}
+
+	return &Station{
+		Repos:       repos,
+		Environment: env,
+		User:        user,
+		tb:          tb,
+		scripts:     make(map[int64][]ScriptedRun),
+		stubs:       make(map[string]func(args map[string]interface{}) interface{}),
+	}
+}
+
+// CreateAgent creates an agent in the default environment owned by the
+// console user. Setup failures are fatal to the test.
+func (s *Station) CreateAgent(name, description, prompt string, maxSteps int64) *models.Agent {
+	s.tb.Helper()
+
+	agent, err := s.Repos.Agents.Create(name, description, prompt, maxSteps, s.Environment.ID, s.User.ID, nil, nil, false, nil, nil, "", "")
+	if err != nil {
+		s.tb.Fatalf("stationtest: failed to create agent %s: %v", name, err)
+	}
+	return agent
+}
+
+// CreateEnvironment creates an additional environment for multi-environment
+// tests. Setup failures are fatal to the test.
+func (s *Station) CreateEnvironment(name string) *models.Environment {
+	s.tb.Helper()
+
+	description := fmt.Sprintf("stationtest environment %s", name)
+	env, err := s.Repos.Environments.Create(name, &description, s.User.ID)
+	if err != nil {
+		s.tb.Fatalf("stationtest: failed to create environment %s: %v", name, err)
+	}
+	return env
+}
+
+// StubTool registers a handler that supplies outputs for scripted tool calls
+// whose Result is nil, playing the role of a faked MCP server for that tool.
+func (s *Station) StubTool(name string, handler func(args map[string]interface{}) interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stubs[name] = handler
+}
+
+// Script queues canned executions for an agent. Each call to RunAgent (or
+// AgentService().ExecuteAgent) consumes one queued run in FIFO order.
+func (s *Station) Script(agentID int64, runs ...ScriptedRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[agentID] = append(s.scripts[agentID], runs...)
+}
+
+// nextScript pops the next queued run for the agent, falling back to a
+// generic completion when nothing is scripted.
+func (s *Station) nextScript(agentID int64, task string) ScriptedRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue := s.scripts[agentID]
+	if len(queue) == 0 {
+		return ScriptedRun{Response: fmt.Sprintf("Completed task: %s", task)}
+	}
+	next := queue[0]
+	s.scripts[agentID] = queue[1:]
+	return next
+}
+
+// toolOutput resolves a scripted tool call's output, consulting stubs when
+// the script leaves the result unset.
+func (s *Station) toolOutput(call ScriptedToolCall) interface{} {
+	if call.Result != nil {
+		return call.Result
+	}
+	s.mu.Lock()
+	handler := s.stubs[call.Tool]
+	s.mu.Unlock()
+	if handler != nil {
+		return handler(call.Arguments)
+	}
+	return fmt.Sprintf("stubbed output for %s", call.Tool)
+}
+
+// RunAgent executes the next scripted run for the agent and returns the
+// completed run record as persisted through the repositories.
+func (s *Station) RunAgent(ctx context.Context, agentID int64, task string) (*models.AgentRun, error) {
+	agent, err := s.Repos.Agents.GetByID(agentID)
+	if err != nil {
+		return nil, fmt.Errorf("agent %d not found: %w", agentID, err)
+	}
+
+	run, err := s.Repos.AgentRuns.Create(ctx, agent.ID, s.User.ID, task, "", 0, nil, nil, "running", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run: %w", err)
+	}
+
+	script := s.nextScript(agentID, task)
+	if err := s.completeRun(ctx, run.ID, script); err != nil {
+		return nil, err
+	}
+	if script.Err != nil {
+		return s.Repos.AgentRuns.GetByID(ctx, run.ID)
+	}
+	return s.Repos.AgentRuns.GetByID(ctx, run.ID)
+}
+
+// completeRun writes the scripted outcome onto the run row with the same
+// tool_calls and execution_steps shapes the real execution engine produces.
+func (s *Station) completeRun(ctx context.Context, runID int64, script ScriptedRun) error {
+	toolCalls := make(models.JSONArray, 0, len(script.ToolCalls))
+	executionSteps := make(models.JSONArray, 0, len(script.ToolCalls))
+	for i, call := range script.ToolCalls {
+		turn := int64(i + 1)
+		toolCalls = append(toolCalls, map[string]interface{}{
+			"tool_name":    call.Tool,
+			"parameters":   call.Arguments,
+			"tool_call_id": fmt.Sprintf("scripted_%d_%d", runID, turn),
+			"turn":         turn,
+			"output":       s.toolOutput(call),
+		})
+		executionSteps = append(executionSteps, map[string]interface{}{
+			"step":      turn,
+			"type":      "tool_call",
+			"tool_name": call.Tool,
+		})
+	}
+
+	status := "completed"
+	response := script.Response
+	var errorMsg *string
+	if script.Err != nil {
+		status = "failed"
+		msg := script.Err.Error()
+		errorMsg = &msg
+		if response == "" {
+			response = fmt.Sprintf("Execution failed: %s", msg)
+		}
+	}
+
+	completedAt := time.Now()
+	stepsTaken := int64(len(script.ToolCalls))
+	totalTokens := script.InputTokens + script.OutputTokens
+	durationSeconds := 0.0
+	modelName := "stationtest/scripted"
+	return s.Repos.AgentRuns.UpdateCompletionWithMetadata(
+		ctx, runID, response, stepsTaken, &toolCalls, &executionSteps, status, &completedAt,
+		&script.InputTokens, &script.OutputTokens, &totalTokens,
+		&durationSeconds, &modelName, &stepsTaken, errorMsg,
+	)
+}
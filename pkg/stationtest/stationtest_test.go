@@ -0,0 +1,142 @@
+package stationtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"station/internal/services"
+)
+
+func TestRunAgentScripted(t *testing.T) {
+	st := New(t)
+	ctx := context.Background()
+
+	agent := st.CreateAgent("auditor", "Scans configs", "You are an auditor", 5)
+
+	st.StubTool("__read_text_file", func(args map[string]interface{}) interface{} {
+		return "resource \"aws_s3_bucket\" \"demo\" {}"
+	})
+	st.Script(agent.ID,
+		ScriptedRun{
+			Response: "Found 1 public bucket",
+			ToolCalls: []ScriptedToolCall{
+				{Tool: "__list_directory", Arguments: map[string]interface{}{"path": "."}, Result: []string{"main.tf"}},
+				{Tool: "__read_text_file", Arguments: map[string]interface{}{"path": "main.tf"}},
+			},
+			InputTokens:  120,
+			OutputTokens: 45,
+		},
+		ScriptedRun{Response: "Nothing new since last scan"},
+	)
+
+	run, err := st.RunAgent(ctx, agent.ID, "audit the repo")
+	if err != nil {
+		t.Fatalf("RunAgent failed: %v", err)
+	}
+	if run.Status != "completed" || run.FinalResponse != "Found 1 public bucket" {
+		t.Errorf("expected completed run with scripted response, got %s / %q", run.Status, run.FinalResponse)
+	}
+	if run.StepsTaken != 2 {
+		t.Errorf("expected 2 steps taken, got %d", run.StepsTaken)
+	}
+	if run.ToolCalls == nil || len(*run.ToolCalls) != 2 {
+		t.Fatalf("expected 2 recorded tool calls, got %+v", run.ToolCalls)
+	}
+	first, ok := (*run.ToolCalls)[0].(map[string]interface{})
+	if !ok || first["tool_name"] != "__list_directory" {
+		t.Errorf("expected first tool call __list_directory, got %+v", (*run.ToolCalls)[0])
+	}
+	second, ok := (*run.ToolCalls)[1].(map[string]interface{})
+	if !ok || second["output"] != "resource \"aws_s3_bucket\" \"demo\" {}" {
+		t.Errorf("expected stubbed tool output, got %+v", (*run.ToolCalls)[1])
+	}
+	if run.TotalTokens == nil || *run.TotalTokens != 165 {
+		t.Errorf("expected 165 total tokens, got %+v", run.TotalTokens)
+	}
+
+	// Scripted runs are consumed FIFO
+	run, err = st.RunAgent(ctx, agent.ID, "audit again")
+	if err != nil {
+		t.Fatalf("second RunAgent failed: %v", err)
+	}
+	if run.FinalResponse != "Nothing new since last scan" {
+		t.Errorf("expected second scripted response, got %q", run.FinalResponse)
+	}
+
+	// An exhausted script falls back to a generic completion
+	run, err = st.RunAgent(ctx, agent.ID, "third pass")
+	if err != nil {
+		t.Fatalf("fallback RunAgent failed: %v", err)
+	}
+	if run.Status != "completed" || run.FinalResponse == "" {
+		t.Errorf("expected generic completion, got %s / %q", run.Status, run.FinalResponse)
+	}
+}
+
+func TestRunAgentScriptedFailure(t *testing.T) {
+	st := New(t)
+
+	agent := st.CreateAgent("flaky", "Fails on purpose", "You fail", 3)
+	st.Script(agent.ID, ScriptedRun{Err: errors.New("provider timeout")})
+
+	run, err := st.RunAgent(context.Background(), agent.ID, "do the thing")
+	if err != nil {
+		t.Fatalf("RunAgent failed: %v", err)
+	}
+	if run.Status != "failed" {
+		t.Errorf("expected failed status, got %s", run.Status)
+	}
+	if run.Error == nil || *run.Error != "provider timeout" {
+		t.Errorf("expected provider timeout recorded on the run, got %+v", run.Error)
+	}
+}
+
+func TestAgentServiceInterface(t *testing.T) {
+	st := New(t)
+	ctx := context.Background()
+
+	var svc services.AgentServiceInterface = st.AgentService()
+
+	agent, err := svc.CreateAgent(ctx, &services.AgentConfig{
+		Name:        "via-interface",
+		Description: "Created through the service interface",
+		Prompt:      "You are scripted",
+		MaxSteps:    4,
+	})
+	if err != nil {
+		t.Fatalf("CreateAgent failed: %v", err)
+	}
+	if agent.EnvironmentID != st.Environment.ID {
+		t.Errorf("expected agent in default environment %d, got %d", st.Environment.ID, agent.EnvironmentID)
+	}
+
+	st.Script(agent.ID, ScriptedRun{Response: "interface response"})
+	msg, err := svc.ExecuteAgent(ctx, agent.ID, "run it", nil)
+	if err != nil {
+		t.Fatalf("ExecuteAgent failed: %v", err)
+	}
+	if msg.Role != services.RoleAssistant || msg.Content != "interface response" {
+		t.Errorf("expected scripted assistant message, got %+v", msg)
+	}
+
+	agents, err := svc.ListAgentsByEnvironment(ctx, st.Environment.ID)
+	if err != nil || len(agents) != 1 {
+		t.Fatalf("expected 1 agent in environment, got %d (%v)", len(agents), err)
+	}
+
+	if err := svc.UpdateAgentPrompt(ctx, agent.ID, "updated prompt"); err != nil {
+		t.Fatalf("UpdateAgentPrompt failed: %v", err)
+	}
+	updated, err := svc.GetAgent(ctx, agent.ID)
+	if err != nil || updated.Prompt != "updated prompt" {
+		t.Errorf("expected updated prompt, got %+v (%v)", updated, err)
+	}
+
+	if err := svc.DeleteAgent(ctx, agent.ID); err != nil {
+		t.Fatalf("DeleteAgent failed: %v", err)
+	}
+	if _, err := svc.GetAgent(ctx, agent.ID); err == nil {
+		t.Error("expected deleted agent to be gone")
+	}
+}
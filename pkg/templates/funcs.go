@@ -0,0 +1,100 @@
+// Package templates provides the shared function map for Station's Go
+// template rendering (bundle templates, MCP config templates, lint). The
+// helpers mirror Helm-style semantics so bundle authors can express defaults,
+// required values, and encodings without workarounds around plain {{ .VAR }}.
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// FuncMap returns the template function map. The variables map backs the
+// lookup helper so templates can reference variables dynamically (including
+// ones merged in from other files); pass nil when only the static helpers
+// are needed (e.g. parse-only use).
+func FuncMap(variables map[string]interface{}) template.FuncMap {
+	return template.FuncMap{
+		// default returns the fallback when the value is empty. Combine with
+		// lookup for variables that may not be defined at all:
+		// {{ lookup "PORT" | default "8080" }}
+		"default": func(fallback, value interface{}) interface{} {
+			if isEmptyValue(value) {
+				return fallback
+			}
+			return value
+		},
+		// required fails rendering with the given message when the value is
+		// empty: {{ required "API_KEY must be set" .API_KEY }}
+		"required": func(message string, value interface{}) (interface{}, error) {
+			if isEmptyValue(value) {
+				return nil, fmt.Errorf("%s", message)
+			}
+			return value, nil
+		},
+		// env reads a process environment variable: {{ env "HOME" }}
+		"env": os.Getenv,
+		// b64enc base64-encodes a string: {{ .PASSWORD | b64enc }}
+		"b64enc": func(value string) string {
+			return base64.StdEncoding.EncodeToString([]byte(value))
+		},
+		// sha256 returns the hex digest of a string: {{ .TOKEN | sha256 }}
+		"sha256": func(value string) string {
+			sum := sha256.Sum256([]byte(value))
+			return hex.EncodeToString(sum[:])
+		},
+		// toJson serializes a value as JSON: {{ .CONFIG | toJson }}
+		"toJson": func(value interface{}) (string, error) {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return "", fmt.Errorf("toJson: %w", err)
+			}
+			return string(data), nil
+		},
+		// indent prefixes every line with n spaces: {{ .BLOCK | indent 4 }}
+		"indent": func(spaces int, value string) string {
+			if spaces < 0 {
+				spaces = 0
+			}
+			pad := strings.Repeat(" ", spaces)
+			lines := strings.Split(value, "\n")
+			for i, line := range lines {
+				if line != "" {
+					lines[i] = pad + line
+				}
+			}
+			return strings.Join(lines, "\n")
+		},
+		// lookup resolves a variable by name from the merged variable map
+		// (variables.yml, environment overrides): {{ lookup "DB_HOST" }}.
+		// Unknown names render as an empty string.
+		"lookup": func(name string) interface{} {
+			if variables == nil {
+				return ""
+			}
+			if value, ok := variables[name]; ok {
+				return value
+			}
+			return ""
+		},
+	}
+}
+
+// isEmptyValue reports whether a template value counts as unset for the
+// default/required helpers
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	default:
+		return false
+	}
+}
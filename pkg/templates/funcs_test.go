@@ -0,0 +1,111 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// render parses and executes a template snippet with the shared function map
+func render(t *testing.T, content string, variables map[string]interface{}) (string, error) {
+	t.Helper()
+	tmpl, err := template.New("test").Funcs(FuncMap(variables)).Option("missingkey=error").Parse(content)
+	if err != nil {
+		t.Fatalf("failed to parse template %q: %v", content, err)
+	}
+	var out strings.Builder
+	execErr := tmpl.Execute(&out, variables)
+	return out.String(), execErr
+}
+
+func TestDefaultFunc(t *testing.T) {
+	vars := map[string]interface{}{"SET": "value", "EMPTY": ""}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{`{{ .SET | default "fallback" }}`, "value"},
+		{`{{ .EMPTY | default "fallback" }}`, "fallback"},
+		// lookup escapes missingkey=error for variables that may not exist
+		{`{{ lookup "MISSING" | default "fallback" }}`, "fallback"},
+		{`{{ lookup "SET" | default "fallback" }}`, "value"},
+	}
+	for _, tt := range tests {
+		got, err := render(t, tt.template, vars)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.template, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestRequiredFunc(t *testing.T) {
+	vars := map[string]interface{}{"SET": "value", "EMPTY": ""}
+
+	if got, err := render(t, `{{ required "SET must be set" .SET }}`, vars); err != nil || got != "value" {
+		t.Errorf("required on set value = %q, %v; want \"value\", nil", got, err)
+	}
+
+	_, err := render(t, `{{ required "EMPTY must be set" .EMPTY }}`, vars)
+	if err == nil || !strings.Contains(err.Error(), "EMPTY must be set") {
+		t.Errorf("required on empty value should fail with the given message, got %v", err)
+	}
+}
+
+func TestEnvFunc(t *testing.T) {
+	t.Setenv("STATION_TEMPLATE_TEST", "from-env")
+	got, err := render(t, `{{ env "STATION_TEMPLATE_TEST" }}`, nil)
+	if err != nil || got != "from-env" {
+		t.Errorf("env = %q, %v; want \"from-env\", nil", got, err)
+	}
+}
+
+func TestEncodingFuncs(t *testing.T) {
+	vars := map[string]interface{}{"SECRET": "hello"}
+
+	if got, _ := render(t, `{{ .SECRET | b64enc }}`, vars); got != "aGVsbG8=" {
+		t.Errorf("b64enc = %q, want %q", got, "aGVsbG8=")
+	}
+	if got, _ := render(t, `{{ .SECRET | sha256 }}`, vars); got != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Errorf("sha256 = %q", got)
+	}
+}
+
+func TestToJsonFunc(t *testing.T) {
+	vars := map[string]interface{}{"LIST": []string{"a", "b"}, "NAME": `with "quotes"`}
+
+	if got, _ := render(t, `{{ .LIST | toJson }}`, vars); got != `["a","b"]` {
+		t.Errorf("toJson list = %q", got)
+	}
+	if got, _ := render(t, `{{ .NAME | toJson }}`, vars); got != `"with \"quotes\""` {
+		t.Errorf("toJson string = %q", got)
+	}
+}
+
+func TestIndentFunc(t *testing.T) {
+	vars := map[string]interface{}{"BLOCK": "line1\nline2\n\nline3"}
+
+	got, _ := render(t, `{{ .BLOCK | indent 2 }}`, vars)
+	want := "  line1\n  line2\n\n  line3"
+	if got != want {
+		t.Errorf("indent = %q, want %q", got, want)
+	}
+}
+
+func TestLookupFunc(t *testing.T) {
+	vars := map[string]interface{}{"DB_HOST": "localhost"}
+
+	if got, _ := render(t, `{{ lookup "DB_HOST" }}`, vars); got != "localhost" {
+		t.Errorf("lookup known = %q, want %q", got, "localhost")
+	}
+	if got, err := render(t, `{{ lookup "MISSING" }}`, vars); err != nil || got != "" {
+		t.Errorf("lookup unknown = %q, %v; want empty, nil", got, err)
+	}
+	if got, err := render(t, `{{ lookup "ANY" }}`, nil); err != nil || got != "" {
+		t.Errorf("lookup with nil variables = %q, %v; want empty, nil", got, err)
+	}
+}